@@ -1,3 +1,14 @@
+resource "aws_sqs_queue" "order_events" {
+  name                       = "${var.application_name}-order-events"
+  message_retention_seconds  = 345600
+  visibility_timeout_seconds = 30
+
+  tags = merge(
+    data.terraform_remote_state.infra.outputs.project_common_tags
+    , { Name = "${var.application_name}-order-events-queue" }
+  )
+}
+
 module "order_api" {
   source = "git::https://github.com/FIAP-11soat-grupo-21/infra-core.git//modules/ECS-Service?ref=main"
 
@@ -26,6 +37,7 @@ module "order_api" {
       AWS_SQS_ORDERS_ERROR_QUEUE : data.terraform_remote_state.infra.outputs.sqs_orders_order_error_queue_url
       AWS_SQS_PAYMENTS_QUEUE : data.terraform_remote_state.infra.outputs.sqs_payments_queue_url
       AWS_SQS_KITCHEN_ORDERS_QUEUE : data.terraform_remote_state.infra.outputs.sqs_kitchen_orders_queue_url
+      AWS_SQS_ORDER_EVENTS_QUEUE : aws_sqs_queue.order_events.url
   })
   ecs_container_secrets = merge(var.container_secrets,
     {
@@ -48,29 +60,72 @@ module "GetOrderAPIRoute" {
   api_id       = data.terraform_remote_state.infra.outputs.api_gateway_id
   alb_proxy_id = aws_apigatewayv2_integration.alb_proxy.id
 
+  # Rotas autenticadas usam o authorizer Cognito do mesmo user pool e client
+  # validados pelo serviço; os papéis continuam sendo checados no serviço.
+  # O pedido de convidado é acessado pelo guest token, sem authorizer
   endpoints = {
     get_order = {
       route_key  = "GET /orders/{id}"
-      restricted = false
+      restricted = true
     },
     get_all_orders = {
       route_key  = "GET /orders"
-      restricted = false
+      restricted = true
     },
     get_all_status = {
       route_key  = "GET /orders/status"
-      restricted = false
+      restricted = true
     },
     create_order = {
       route_key  = "POST /orders"
-      restricted = false
+      restricted = true
     },
     update_order = {
       route_key  = "PUT /orders/{id}"
-      restricted = false
+      restricted = true
     },
     delete_order = {
       route_key  = "DELETE /orders/{id}"
+      restricted = true
+    },
+    update_order_status = {
+      route_key  = "PUT /orders/{id}/status"
+      restricted = true
+    },
+    cancel_order = {
+      route_key  = "POST /orders/{id}/cancel"
+      restricted = true
+    },
+    get_order_history = {
+      route_key  = "GET /orders/{id}/history"
+      restricted = true
+    },
+    get_order_board = {
+      route_key  = "GET /orders/board"
+      restricted = true
+    },
+    get_order_by_code = {
+      route_key  = "GET /orders/by-code/{code}"
+      restricted = true
+    },
+    claim_order = {
+      route_key  = "POST /orders/{id}/claim"
+      restricted = true
+    },
+    apply_coupon = {
+      route_key  = "POST /orders/{id}/coupons"
+      restricted = true
+    },
+    create_coupon = {
+      route_key  = "POST /coupons"
+      restricted = true
+    },
+    restore_order = {
+      route_key  = "POST /admin/orders/{id}/restore"
+      restricted = true
+    },
+    get_guest_order = {
+      route_key  = "GET /guest/orders/{id}"
       restricted = false
     }
   }
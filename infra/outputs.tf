@@ -28,6 +28,11 @@ output "sqs_kitchen_orders_queue_url" {
   value       = data.terraform_remote_state.infra.outputs.sqs_kitchen_orders_queue_url
 }
 
+output "sqs_order_events_queue_url" {
+  description = "URL da fila SQS de eventos de pedidos"
+  value       = aws_sqs_queue.order_events.url
+}
+
 
 
 output "ecs_service_id" {
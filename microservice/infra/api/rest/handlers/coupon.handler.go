@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"microservice/infra/api/rest/http_errors"
+	"microservice/infra/api/rest/schemas"
+	"microservice/internal/adapters/controllers"
+	"microservice/internal/adapters/dtos"
+	"microservice/utils/factories"
+)
+
+type CouponHandler struct {
+	controller *controllers.CouponController
+}
+
+func NewCouponHandler() *CouponHandler {
+	return &CouponHandler{
+		controller: controllers.NewCouponController(factories.NewCouponDataSource()),
+	}
+}
+
+func (h *CouponHandler) Create(ctx *gin.Context) {
+	var body schemas.CreateCouponSchema
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		http_errors.WriteValidationError(ctx, http_errors.NewBindingError(err))
+		return
+	}
+
+	coupon, err := h.controller.Create(ctx.Request.Context(), dtos.CreateCouponDTO{
+		Code:           body.Code,
+		Type:           body.Type,
+		Percentage:     body.Percentage,
+		Amount:         string(body.Amount),
+		Currency:       body.Currency,
+		ProductID:      body.ProductID,
+		BuyQuantity:    body.BuyQuantity,
+		FreeQuantity:   body.FreeQuantity,
+		FirstOrderOnly: body.FirstOrderOnly,
+		ValidFrom:      body.ValidFrom,
+		ValidUntil:     body.ValidUntil,
+		MaxUses:        body.MaxUses,
+	})
+	if err != nil {
+		_ = ctx.Error(http_errors.WithStack(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, toCouponResponse(coupon))
+}
+
+func toCouponResponse(coupon dtos.CouponResponseDTO) schemas.CouponResponseSchema {
+	var amount *schemas.Decimal
+	if coupon.Amount != nil {
+		value := schemas.Decimal(*coupon.Amount)
+		amount = &value
+	}
+
+	return schemas.CouponResponseSchema{
+		Code:           coupon.Code,
+		Type:           coupon.Type,
+		Percentage:     coupon.Percentage,
+		Amount:         amount,
+		Currency:       coupon.Currency,
+		ProductID:      coupon.ProductID,
+		BuyQuantity:    coupon.BuyQuantity,
+		FreeQuantity:   coupon.FreeQuantity,
+		FirstOrderOnly: coupon.FirstOrderOnly,
+		ValidFrom:      coupon.ValidFrom,
+		ValidUntil:     coupon.ValidUntil,
+		MaxUses:        coupon.MaxUses,
+		UsedCount:      coupon.UsedCount,
+		CreatedAt:      coupon.CreatedAt,
+	}
+}
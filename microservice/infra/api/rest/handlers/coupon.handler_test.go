@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"microservice/infra/api/rest/middlewares"
+	"microservice/infra/api/rest/schemas"
+	"microservice/internal/adapters/daos"
+	"microservice/internal/domain/exceptions"
+	"microservice/internal/interfaces"
+	"microservice/utils/factories"
+)
+
+type mockCouponDS struct {
+	coupons map[string]daos.CouponDAO
+}
+
+func newMockCouponDS(coupons ...daos.CouponDAO) *mockCouponDS {
+	ds := &mockCouponDS{coupons: make(map[string]daos.CouponDAO)}
+	for _, coupon := range coupons {
+		ds.coupons[coupon.Code] = coupon
+	}
+	return ds
+}
+
+func (m *mockCouponDS) Create(ctx context.Context, coupon daos.CouponDAO) error {
+	if _, exists := m.coupons[coupon.Code]; exists {
+		return &exceptions.CouponAlreadyExistsException{}
+	}
+	m.coupons[coupon.Code] = coupon
+	return nil
+}
+
+func (m *mockCouponDS) FindByCode(ctx context.Context, code string) (*daos.CouponDAO, error) {
+	coupon, exists := m.coupons[code]
+	if !exists {
+		return nil, nil
+	}
+	return &coupon, nil
+}
+
+// Troca o datasource de cupons configurado por setupMocks
+func setupCouponMocks(couponDS *mockCouponDS) {
+	factories.SetNewCouponDataSource(func() interfaces.ICouponDataSource {
+		return couponDS
+	})
+}
+
+func newReceivedOrderDS() *mockOrderDS {
+	return &mockOrderDS{
+		findByIDFunc: func(id string) (daos.OrderDAO, error) {
+			return daos.OrderDAO{
+				ID:       id,
+				Amount:   2000,
+				Currency: "BRL",
+				Status:   daos.OrderStatusDAO{ID: "status-1", Name: "Recebido"},
+				Items: []daos.OrderItemDAO{
+					{ID: "item-1", OrderID: id, ProductID: "product-1", Quantity: 2, UnitPrice: 1000},
+				},
+				CreatedAt: time.Now(),
+			}, nil
+		},
+	}
+}
+
+func TestOrderHandler_ApplyCoupon_Success(t *testing.T) {
+	var saved daos.OrderDAO
+	orderDS := newReceivedOrderDS()
+	orderDS.applyCouponFunc = func(order daos.OrderDAO) error {
+		saved = order
+		return nil
+	}
+	cleanup := setupMocks(orderDS, &mockOrderStatusDS{})
+	defer cleanup()
+	setupCouponMocks(newMockCouponDS(daos.CouponDAO{Code: "PROMO10", Type: "percentage", Percentage: 10, Currency: "BRL"}))
+
+	handler := NewOrderHandler()
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+	router.Use(middlewares.ErrorHandlerMiddleware())
+	router.POST("/orders/:id/coupons", handler.ApplyCoupon)
+
+	req := httptest.NewRequest("POST", "/orders/550e8400-e29b-41d4-a716-446655440000/coupons", strings.NewReader(`{"code":"promo10"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("ApplyCoupon() status = %v, want %v: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+
+	var response schemas.OrderResponseSchema
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Subtotal != "20.00" || response.Discount != "2.00" || response.Amount != "18.00" {
+		t.Errorf("ApplyCoupon() subtotal/discount/amount = %v/%v/%v, want 20.00/2.00/18.00", response.Subtotal, response.Discount, response.Amount)
+	}
+	if response.CouponCode == nil || *response.CouponCode != "PROMO10" {
+		t.Errorf("ApplyCoupon() coupon_code = %v, want PROMO10", response.CouponCode)
+	}
+	if saved.Discount != 200 {
+		t.Errorf("ApplyCoupon() saved discount = %v, want 200", saved.Discount)
+	}
+}
+
+func TestOrderHandler_ApplyCoupon_Errors(t *testing.T) {
+	expired := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name     string
+		body     string
+		coupons  []daos.CouponDAO
+		expected int
+	}{
+		{"missing code", `{}`, nil, http.StatusBadRequest},
+		{"unknown coupon", `{"code":"PROMO10"}`, nil, http.StatusNotFound},
+		{"expired coupon", `{"code":"PROMO10"}`, []daos.CouponDAO{{Code: "PROMO10", Type: "percentage", Percentage: 10, Currency: "BRL", ValidUntil: &expired}}, http.StatusUnprocessableEntity},
+		{"no uses left", `{"code":"PROMO10"}`, []daos.CouponDAO{{Code: "PROMO10", Type: "percentage", Percentage: 10, Currency: "BRL", MaxUses: 1, UsedCount: 1}}, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cleanup := setupMocks(newReceivedOrderDS(), &mockOrderStatusDS{})
+			defer cleanup()
+			setupCouponMocks(newMockCouponDS(tt.coupons...))
+
+			handler := NewOrderHandler()
+			w := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(w)
+			router.Use(middlewares.ErrorHandlerMiddleware())
+			router.POST("/orders/:id/coupons", handler.ApplyCoupon)
+
+			req := httptest.NewRequest("POST", "/orders/550e8400-e29b-41d4-a716-446655440000/coupons", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expected {
+				t.Errorf("ApplyCoupon() status = %v, want %v: %s", w.Code, tt.expected, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestCouponHandler_Create_Success(t *testing.T) {
+	couponDS := newMockCouponDS()
+	setupCouponMocks(couponDS)
+	defer factories.SetNewCouponDataSource(nil)
+
+	handler := NewCouponHandler()
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+	router.Use(middlewares.ErrorHandlerMiddleware())
+	router.POST("/coupons", handler.Create)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"code":             "leve3",
+		"type":             "buy_x_get_y",
+		"product_id":       "product-1",
+		"buy_quantity":     2,
+		"free_quantity":    1,
+		"first_order_only": true,
+		"max_uses":         50,
+	})
+	req := httptest.NewRequest("POST", "/coupons", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Create() status = %v, want %v: %s", w.Code, http.StatusCreated, w.Body.String())
+	}
+
+	var response schemas.CouponResponseSchema
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Code != "LEVE3" || response.BuyQuantity != 2 || response.FreeQuantity != 1 || !response.FirstOrderOnly || response.MaxUses != 50 {
+		t.Errorf("Create() response = %+v", response)
+	}
+	if _, exists := couponDS.coupons["LEVE3"]; !exists {
+		t.Error("Create() did not save the coupon")
+	}
+}
+
+func TestCouponHandler_Create_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected int
+	}{
+		{"missing type", `{"code":"PROMO10"}`, http.StatusBadRequest},
+		{"unknown type", `{"code":"PROMO10","type":"free_shipping"}`, http.StatusBadRequest},
+		{"percentage out of range", `{"code":"PROMO10","type":"percentage","percentage":100}`, http.StatusBadRequest},
+		{"fixed amount without amount", `{"code":"MENOS5","type":"fixed_amount"}`, http.StatusBadRequest},
+		{"duplicated code", `{"code":"EXISTENTE","type":"percentage","percentage":10}`, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupCouponMocks(newMockCouponDS(daos.CouponDAO{Code: "EXISTENTE"}))
+			defer factories.SetNewCouponDataSource(nil)
+
+			handler := NewCouponHandler()
+			w := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(w)
+			router.Use(middlewares.ErrorHandlerMiddleware())
+			router.POST("/coupons", handler.Create)
+
+			req := httptest.NewRequest("POST", "/coupons", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expected {
+				t.Errorf("Create() status = %v, want %v: %s", w.Code, tt.expected, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"microservice/infra/api/rest/http_errors"
+	"microservice/infra/api/rest/middlewares"
+	"microservice/infra/api/rest/schemas"
+	"microservice/internal/adapters/daos"
+	"microservice/internal/domain/exceptions"
+)
+
+const (
+	IDEMPOTENCY_KEY_HEADER      = "Idempotency-Key"
+	IDEMPOTENCY_REPLAYED_HEADER = "Idempotent-Replayed"
+	MAX_IDEMPOTENCY_KEY_LENGTH  = 255
+
+	DEFAULT_IDEMPOTENCY_KEY_TTL      = 24 * time.Hour
+	DEFAULT_IDEMPOTENCY_LOCK_TIMEOUT = time.Minute
+)
+
+// Usados pelos handlers criados depois de ConfigureIdempotency
+var (
+	idempotencyKeyTTL      = DEFAULT_IDEMPOTENCY_KEY_TTL
+	idempotencyLockTimeout = DEFAULT_IDEMPOTENCY_LOCK_TIMEOUT
+)
+
+// Zero mantém o padrão
+func ConfigureIdempotency(keyTTL time.Duration, lockTimeout time.Duration) {
+	if keyTTL > 0 {
+		idempotencyKeyTTL = keyTTL
+	}
+	if lockTimeout > 0 {
+		idempotencyLockTimeout = lockTimeout
+	}
+}
+
+// hashRequest usa o corpo já decodificado para que diferenças de espaçamento
+// ou ordem dos campos no JSON não mudem o hash
+func hashRequest(body interface{}) (string, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Separa as chaves de cada principal, para que a chave de outro nunca repita a resposta dele
+func idempotencyScope(ctx *gin.Context) string {
+	if principal, ok := middlewares.CurrentPrincipal(ctx); ok {
+		return principal.Subject
+	}
+	return ""
+}
+
+// Retoma reservas paradas há mais que o lock timeout; com false a resposta já foi escrita
+func (h *OrderHandler) beginIdempotentRequest(ctx *gin.Context, key string, requestHash string) bool {
+	scope := idempotencyScope(ctx)
+	now := time.Now().UTC()
+	reserved, err := h.idempotency.Reserve(ctx.Request.Context(), daos.IdempotencyKeyDAO{
+		Scope:       scope,
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		LockedAt:    &now,
+		ExpiresAt:   now.Add(h.idempotencyKeyTTL),
+	}, now.Add(-h.idempotencyLockTimeout))
+	if err != nil {
+		_ = ctx.Error(http_errors.WithStack(err))
+		return false
+	}
+	if reserved {
+		return true
+	}
+
+	record, err := h.idempotency.FindByKey(ctx.Request.Context(), scope, key)
+	if err != nil {
+		_ = ctx.Error(http_errors.WithStack(err))
+		return false
+	}
+
+	switch {
+	case record == nil || record.CompletedAt == nil:
+		http_errors.WriteProblem(ctx, http_errors.PROBLEM_IDEMPOTENCY_KEY_IN_USE, "A request with this Idempotency-Key is still being processed")
+	case record.RequestHash != requestHash:
+		http_errors.WriteProblem(ctx, http_errors.PROBLEM_IDEMPOTENCY_KEY_REUSED, "Idempotency-Key was already used with a different request body")
+	default:
+		h.replayIdempotentResponse(ctx, *record)
+	}
+	return false
+}
+
+// A criação termina bem antes de a reserva poder ser retomada; assim uma nova
+// tentativa nunca roda enquanto a anterior ainda pode gravar o pedido
+func (h *OrderHandler) idempotentRequestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, h.idempotencyLockTimeout/2)
+}
+
+// O guest token não é guardado: pedidos de convidado recebem um novo e o perdido deixa de valer
+func (h *OrderHandler) replayIdempotentResponse(ctx *gin.Context, record daos.IdempotencyKeyDAO) {
+	responseBody := record.ResponseBody
+
+	var response schemas.OrderResponseSchema
+	if record.StatusCode == http.StatusCreated && json.Unmarshal(responseBody, &response) == nil && response.CustomerID == nil {
+		order, err := h.controller.ReissueGuestToken(ctx.Request.Context(), response.ID)
+		var alreadyClaimed *exceptions.OrderAlreadyClaimedException
+		var notFound *exceptions.OrderNotFoundException
+		switch {
+		case errors.As(err, &alreadyClaimed), errors.As(err, &notFound):
+			// Pedido reivindicado ou removido desde a criação; não há token a devolver
+		case err != nil:
+			_ = ctx.Error(http_errors.WithStack(err))
+			return
+		case order.GuestToken != "":
+			response.GuestToken = order.GuestToken
+			if responseBody, err = json.Marshal(response); err != nil {
+				_ = ctx.Error(http_errors.WithStack(err))
+				return
+			}
+		}
+	}
+
+	ctx.Header(IDEMPOTENCY_REPLAYED_HEADER, "true")
+	ctx.Data(record.StatusCode, "application/json; charset=utf-8", responseBody)
+}
+
+// Rodam mesmo se o cliente desconectou; senão a chave ficaria travada até expirar
+func (h *OrderHandler) completeIdempotentRequest(ctx *gin.Context, key string, statusCode int, responseBody []byte) {
+	if err := h.idempotency.Complete(context.WithoutCancel(ctx.Request.Context()), idempotencyScope(ctx), key, statusCode, responseBody, time.Now().UTC()); err != nil {
+		log.Printf("Failed to store response for Idempotency-Key %s: %v", key, err)
+	}
+}
+
+func (h *OrderHandler) releaseIdempotentRequest(ctx *gin.Context, key string) {
+	if err := h.idempotency.Release(context.WithoutCancel(ctx.Request.Context()), idempotencyScope(ctx), key); err != nil {
+		log.Printf("Failed to release Idempotency-Key %s: %v", key, err)
+	}
+}
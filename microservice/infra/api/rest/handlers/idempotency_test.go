@@ -0,0 +1,355 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"microservice/infra/api/rest/middlewares"
+	"microservice/infra/api/rest/schemas"
+	"microservice/infra/auth"
+	"microservice/internal/adapters/daos"
+)
+
+func newIdempotentCreateRouter(orderDS *mockOrderDS) (*gin.Engine, func()) {
+	cleanup := setupMocks(orderDS, &mockOrderStatusDS{})
+
+	handler := NewOrderHandler()
+	router := gin.New()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Next()
+		if len(ctx.Errors) > 0 {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		}
+	})
+	router.POST("/orders", handler.Create)
+
+	return router, cleanup
+}
+
+func postOrder(router *gin.Engine, key string, body schemas.CreateOrderSchema) *httptest.ResponseRecorder {
+	jsonBody, _ := json.Marshal(body)
+
+	req := httptest.NewRequest("POST", "/orders", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(IDEMPOTENCY_KEY_HEADER, key)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func newCreateOrderBody(quantity int) schemas.CreateOrderSchema {
+	return schemas.CreateOrderSchema{
+		Items: []schemas.CreateOrderItemSchema{
+			{ProductID: "product-1", Quantity: quantity, Price: "10.00"},
+		},
+	}
+}
+
+func TestOrderHandler_Create_IdempotentRetryReturnsOriginalOrder(t *testing.T) {
+	creates := 0
+	orderDS := newGuestOrderDS()
+	save := orderDS.createFunc
+	orderDS.createFunc = func(order daos.OrderDAO) error {
+		creates++
+		return save(order)
+	}
+	router, cleanup := newIdempotentCreateRouter(orderDS)
+	defer cleanup()
+
+	first := postOrder(router, "totem-1-request-1", newCreateOrderBody(2))
+	second := postOrder(router, "totem-1-request-1", newCreateOrderBody(2))
+
+	if first.Code != http.StatusCreated || second.Code != http.StatusCreated {
+		t.Fatalf("Create() status = %v/%v, want %v", first.Code, second.Code, http.StatusCreated)
+	}
+	if creates != 1 {
+		t.Errorf("Create() persisted %d orders, want 1", creates)
+	}
+	var original, replayed map[string]interface{}
+	_ = json.Unmarshal(first.Body.Bytes(), &original)
+	_ = json.Unmarshal(second.Body.Bytes(), &replayed)
+	if original["guest_token"] == nil || replayed["guest_token"] == nil {
+		t.Fatal("Create() should return a guest token on the original response and on the retry")
+	}
+	// O replay traz um guest token novo, já que o original não é guardado
+	delete(original, "guest_token")
+	delete(replayed, "guest_token")
+	if !reflect.DeepEqual(original, replayed) {
+		t.Errorf("Create() retry body = %v, want %v", replayed, original)
+	}
+	if second.Header().Get(IDEMPOTENCY_REPLAYED_HEADER) != "true" {
+		t.Error("Create() retry should be flagged as replayed")
+	}
+}
+
+func TestOrderHandler_Create_IdempotencyKeyDoesNotStoreGuestToken(t *testing.T) {
+	router, cleanup := newIdempotentCreateRouter(&mockOrderDS{})
+	defer cleanup()
+
+	w := postOrder(router, "totem-1-request-1", newCreateOrderBody(2))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Create() status = %v, want %v", w.Code, http.StatusCreated)
+	}
+
+	record, _ := NewOrderHandler().idempotency.FindByKey(context.Background(), "", "totem-1-request-1")
+	if record == nil || record.CompletedAt == nil {
+		t.Fatal("Create() should store the response for the Idempotency-Key")
+	}
+	if strings.Contains(string(record.ResponseBody), "guest_token") {
+		t.Errorf("Create() stored response = %s, must not contain the guest token", record.ResponseBody)
+	}
+}
+
+func TestOrderHandler_Create_IdempotentRetryReissuesGuestToken(t *testing.T) {
+	statusDS := &mockOrderStatusDS{
+		findByIDFunc: func(id string) (daos.OrderStatusDAO, error) {
+			return daos.OrderStatusDAO{ID: id, Name: "Recebido"}, nil
+		},
+	}
+	cleanup := setupMocks(newGuestOrderDS(), statusDS)
+	defer cleanup()
+
+	handler := NewOrderHandler()
+	router := gin.New()
+	router.Use(middlewares.ErrorHandlerMiddleware())
+	router.POST("/orders", handler.Create)
+	router.GET("/guest/orders/:id", handler.FindGuestOrder)
+
+	first := postOrder(router, "totem-1-request-1", newCreateOrderBody(1))
+	second := postOrder(router, "totem-1-request-1", newCreateOrderBody(1))
+	if first.Code != http.StatusCreated || second.Code != http.StatusCreated {
+		t.Fatalf("Create() status = %v/%v, want %v: %s", first.Code, second.Code, http.StatusCreated, second.Body.String())
+	}
+
+	var original, replayed schemas.OrderResponseSchema
+	_ = json.Unmarshal(first.Body.Bytes(), &original)
+	_ = json.Unmarshal(second.Body.Bytes(), &replayed)
+	if replayed.ID != original.ID {
+		t.Fatalf("Create() retry returned order %s, want %s", replayed.ID, original.ID)
+	}
+	if replayed.GuestToken == "" || replayed.GuestToken == original.GuestToken {
+		t.Fatalf("Create() retry guest token = %q, want a new token", replayed.GuestToken)
+	}
+
+	findGuestOrder := func(token string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/guest/orders/"+original.ID, nil)
+		req.Header.Set(GUEST_TOKEN_HEADER, token)
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	if code := findGuestOrder(replayed.GuestToken); code != http.StatusOK {
+		t.Errorf("FindGuestOrder() with the reissued token status = %v, want %v", code, http.StatusOK)
+	}
+	// O token anterior deixa de valer
+	if code := findGuestOrder(original.GuestToken); code != http.StatusNotFound {
+		t.Errorf("FindGuestOrder() with the original token status = %v, want %v", code, http.StatusNotFound)
+	}
+}
+
+func TestOrderHandler_Create_IdempotencyKeysAreScopedByPrincipal(t *testing.T) {
+	creates := 0
+	orderDS := &mockOrderDS{
+		createFunc: func(order daos.OrderDAO) error {
+			creates++
+			return nil
+		},
+	}
+	cleanup := setupMocks(orderDS, &mockOrderStatusDS{})
+	defer cleanup()
+
+	handler := NewOrderHandler()
+	router := gin.New()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set(middlewares.PRINCIPAL_CONTEXT_KEY, auth.Principal{Subject: ctx.GetHeader("X-Test-Subject"), Roles: []string{auth.ROLE_STAFF}})
+	})
+	router.POST("/orders", handler.Create)
+
+	post := func(subject string) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(newCreateOrderBody(2))
+		req := httptest.NewRequest("POST", "/orders", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(IDEMPOTENCY_KEY_HEADER, "request-1")
+		req.Header.Set("X-Test-Subject", subject)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := post("totem-1")
+	second := post("totem-2")
+
+	if first.Code != http.StatusCreated || second.Code != http.StatusCreated {
+		t.Fatalf("Create() status = %v/%v, want %v", first.Code, second.Code, http.StatusCreated)
+	}
+	if creates != 2 {
+		t.Errorf("Create() persisted %d orders, want 2", creates)
+	}
+	if second.Header().Get(IDEMPOTENCY_REPLAYED_HEADER) != "" {
+		t.Error("Create() must not replay the response of another principal")
+	}
+}
+
+func TestOrderHandler_Create_IdempotencyKeyWithDifferentBody(t *testing.T) {
+	router, cleanup := newIdempotentCreateRouter(&mockOrderDS{})
+	defer cleanup()
+
+	first := postOrder(router, "totem-1-request-1", newCreateOrderBody(2))
+	second := postOrder(router, "totem-1-request-1", newCreateOrderBody(3))
+
+	if first.Code != http.StatusCreated {
+		t.Fatalf("Create() status = %v, want %v", first.Code, http.StatusCreated)
+	}
+	if second.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Create() status = %v, want %v", second.Code, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestOrderHandler_Create_WithoutIdempotencyKeyCreatesEveryTime(t *testing.T) {
+	creates := 0
+	orderDS := &mockOrderDS{
+		createFunc: func(order daos.OrderDAO) error {
+			creates++
+			return nil
+		},
+	}
+	router, cleanup := newIdempotentCreateRouter(orderDS)
+	defer cleanup()
+
+	postOrder(router, "", newCreateOrderBody(2))
+	postOrder(router, "", newCreateOrderBody(2))
+
+	if creates != 2 {
+		t.Errorf("Create() persisted %d orders, want 2", creates)
+	}
+}
+
+func TestOrderHandler_Create_FailedRequestReleasesIdempotencyKey(t *testing.T) {
+	fail := true
+	orderDS := &mockOrderDS{
+		createFunc: func(order daos.OrderDAO) error {
+			if fail {
+				return errors.New("database error")
+			}
+			return nil
+		},
+	}
+	router, cleanup := newIdempotentCreateRouter(orderDS)
+	defer cleanup()
+
+	first := postOrder(router, "totem-1-request-1", newCreateOrderBody(2))
+	fail = false
+	second := postOrder(router, "totem-1-request-1", newCreateOrderBody(2))
+
+	if first.Code != http.StatusInternalServerError {
+		t.Errorf("Create() first status = %v, want %v", first.Code, http.StatusInternalServerError)
+	}
+	if second.Code != http.StatusCreated {
+		t.Errorf("Create() retry status = %v, want %v", second.Code, http.StatusCreated)
+	}
+	if second.Header().Get(IDEMPOTENCY_REPLAYED_HEADER) != "" {
+		t.Error("Create() retry after failure should not be a replay")
+	}
+}
+
+func TestOrderHandler_Create_IdempotencyKeyInProgress(t *testing.T) {
+	router, cleanup := newIdempotentCreateRouter(&mockOrderDS{})
+	defer cleanup()
+
+	body := newCreateOrderBody(2)
+	requestHash, _ := hashRequest(body)
+	idempotencyDS := NewOrderHandler().idempotency
+	now := time.Now().UTC()
+	_, _ = idempotencyDS.Reserve(context.Background(), daos.IdempotencyKeyDAO{Key: "totem-1-request-1", RequestHash: requestHash, CreatedAt: now, LockedAt: &now, ExpiresAt: now.Add(time.Hour)}, now.Add(-time.Minute))
+
+	w := postOrder(router, "totem-1-request-1", body)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Create() status = %v, want %v", w.Code, http.StatusConflict)
+	}
+}
+
+func TestOrderHandler_Create_StaleIdempotencyKeyIsTakenOver(t *testing.T) {
+	creates := 0
+	orderDS := &mockOrderDS{
+		createFunc: func(order daos.OrderDAO) error {
+			creates++
+			return nil
+		},
+	}
+	router, cleanup := newIdempotentCreateRouter(orderDS)
+	defer cleanup()
+
+	body := newCreateOrderBody(2)
+	requestHash, _ := hashRequest(body)
+	// Requisição que parou no meio, sem concluir nem liberar a chave
+	lockedAt := time.Now().UTC().Add(-time.Hour)
+	_, _ = NewOrderHandler().idempotency.Reserve(context.Background(), daos.IdempotencyKeyDAO{Key: "totem-1-request-1", RequestHash: requestHash, CreatedAt: lockedAt, LockedAt: &lockedAt, ExpiresAt: lockedAt.Add(24 * time.Hour)}, lockedAt)
+
+	w := postOrder(router, "totem-1-request-1", body)
+
+	if w.Code != http.StatusCreated {
+		t.Errorf("Create() status = %v, want %v", w.Code, http.StatusCreated)
+	}
+	if creates != 1 {
+		t.Errorf("Create() persisted %d orders, want 1", creates)
+	}
+}
+
+func TestOrderHandler_Create_IdempotentRequestEndsBeforeLockTimeout(t *testing.T) {
+	orderDS := &mockOrderDS{}
+	router, cleanup := newIdempotentCreateRouter(orderDS)
+	defer cleanup()
+
+	start := time.Now()
+	w := postOrder(router, "totem-1-request-1", newCreateOrderBody(2))
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Create() status = %v, want %v", w.Code, http.StatusCreated)
+	}
+	deadline, ok := orderDS.createCtx.Deadline()
+	if !ok {
+		t.Fatal("Create() should run with a deadline when an Idempotency-Key is sent")
+	}
+	if !deadline.Before(start.Add(DEFAULT_IDEMPOTENCY_LOCK_TIMEOUT)) {
+		t.Errorf("Create() deadline = %v, want before the lock timeout %v", deadline, start.Add(DEFAULT_IDEMPOTENCY_LOCK_TIMEOUT))
+	}
+}
+
+func TestOrderHandler_Create_IdempotencyKeyTooLong(t *testing.T) {
+	router, cleanup := newIdempotentCreateRouter(&mockOrderDS{})
+	defer cleanup()
+
+	key := string(bytes.Repeat([]byte("k"), MAX_IDEMPOTENCY_KEY_LENGTH+1))
+	w := postOrder(router, key, newCreateOrderBody(2))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Create() status = %v, want %v", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHashRequest_IgnoresFormatting(t *testing.T) {
+	var compact, spaced schemas.CreateOrderSchema
+	_ = json.Unmarshal([]byte(`{"items":[{"product_id":"p1","quantity":1,"price":5}]}`), &compact)
+	_ = json.Unmarshal([]byte(`{ "items": [ { "price": 5, "quantity": 1, "product_id": "p1" } ] }`), &spaced)
+
+	compactHash, _ := hashRequest(compact)
+	spacedHash, _ := hashRequest(spaced)
+
+	if compactHash != spacedHash {
+		t.Errorf("hashRequest() = %v and %v, want equal hashes", compactHash, spacedHash)
+	}
+}
@@ -1,37 +1,60 @@
 package handlers
 
 import (
-	"log"
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"microservice/infra/api/rest/http_errors"
+	"microservice/infra/api/rest/middlewares"
 	"microservice/infra/api/rest/schemas"
-	"microservice/infra/messaging"
 	"microservice/internal/adapters/controllers"
 	"microservice/internal/adapters/dtos"
+	"microservice/internal/domain/exceptions"
+	"microservice/internal/interfaces"
 	"microservice/utils/factories"
 )
 
+// Token devolvido na criação de pedidos sem cliente; vai no header para não
+// aparecer em logs de URL
+const GUEST_TOKEN_HEADER = "X-Guest-Token"
+
+// Fuso do restaurante usado pelos handlers criados depois de ConfigurePickupLocation
+var pickupLocation = time.UTC
+
+func ConfigurePickupLocation(location *time.Location) {
+	if location != nil {
+		pickupLocation = location
+	}
+}
+
 type OrderHandler struct {
-	controller *controllers.OrderController
+	controller  *controllers.OrderController
+	idempotency interfaces.IIdempotencyDataSource
+	// Por quanto tempo uma chave vale e quando uma requisição travada pode ser retomada
+	idempotencyKeyTTL      time.Duration
+	idempotencyLockTimeout time.Duration
 }
 
 func NewOrderHandler() *OrderHandler {
 	orderDataSource := factories.NewOrderDataSource()
 	orderStatusDataSource := factories.NewOrderStatusDataSource()
-	broker := messaging.GetBroker()
-
-	if broker == nil {
-		log.Println("Warning: Message broker not available, orders will be created without messaging")
-	}
+	productCatalogDataSource := factories.NewProductCatalogDataSource()
+	couponDataSource := factories.NewCouponDataSource()
 
-	controller := controllers.NewOrderController(orderDataSource, orderStatusDataSource, broker)
+	controller := controllers.NewOrderController(orderDataSource, orderStatusDataSource, productCatalogDataSource, couponDataSource, pickupLocation)
 
 	return &OrderHandler{
-		controller: controller,
+		controller:             controller,
+		idempotency:            factories.NewIdempotencyDataSource(),
+		idempotencyKeyTTL:      idempotencyKeyTTL,
+		idempotencyLockTimeout: idempotencyLockTimeout,
 	}
 }
 
@@ -39,46 +62,120 @@ func (h *OrderHandler) Create(ctx *gin.Context) {
 	var body schemas.CreateOrderSchema
 
 	if err := ctx.ShouldBindJSON(&body); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		http_errors.WriteValidationError(ctx, http_errors.NewBindingError(err))
 		return
 	}
 
+	// Clientes criam pedidos apenas em nome próprio
+	if customerID, restricted := customerScope(ctx); restricted {
+		body.CustomerID = &customerID
+	}
+
+	idempotencyKey := strings.TrimSpace(ctx.GetHeader(IDEMPOTENCY_KEY_HEADER))
+	if len(idempotencyKey) > MAX_IDEMPOTENCY_KEY_LENGTH {
+		http_errors.WriteValidationError(ctx, http_errors.NewValidationError(http_errors.INVALID_HEADERS_DETAIL, http_errors.FieldError{
+			Field:   IDEMPOTENCY_KEY_HEADER,
+			Code:    "max",
+			Message: fmt.Sprintf("must have at most %d characters", MAX_IDEMPOTENCY_KEY_LENGTH),
+		}))
+		return
+	}
+	createCtx := ctx.Request.Context()
+	if idempotencyKey != "" {
+		requestHash, err := hashRequest(body)
+		if err != nil {
+			_ = ctx.Error(http_errors.WithStack(err))
+			return
+		}
+		if !h.beginIdempotentRequest(ctx, idempotencyKey, requestHash) {
+			return
+		}
+
+		var cancel context.CancelFunc
+		createCtx, cancel = h.idempotentRequestContext(createCtx)
+		defer cancel()
+	}
+
 	items := make([]dtos.CreateOrderItemDTO, len(body.Items))
 	for i, item := range body.Items {
+		modifiers := make([]dtos.CreateOrderItemModifierDTO, len(item.Modifiers))
+		for j, modifier := range item.Modifiers {
+			modifiers[j] = dtos.CreateOrderItemModifierDTO{
+				ModifierID: modifier.ID,
+				PriceDelta: string(modifier.PriceDelta),
+			}
+		}
+
 		items[i] = dtos.CreateOrderItemDTO{
 			ProductID: item.ProductID,
 			Quantity:  item.Quantity,
-			Price:     item.Price,
+			Price:     string(item.Price),
+			Notes:     item.Notes,
+			Modifiers: modifiers,
 		}
 	}
 
-	order, err := h.controller.Create(dtos.CreateOrderDTO{
-		CustomerID: body.CustomerID,
-		Items:      items,
+	order, err := h.controller.Create(createCtx, dtos.CreateOrderDTO{
+		CustomerID:   body.CustomerID,
+		CustomerName: body.CustomerName,
+		Currency:     body.Currency,
+		Items:        items,
 	})
 
 	if err != nil {
-		_ = ctx.Error(err)
+		if idempotencyKey != "" {
+			h.releaseIdempotentRequest(ctx, idempotencyKey)
+		}
+		_ = ctx.Error(http_errors.WithStack(err))
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, toOrderResponse(order))
+	if idempotencyKey == "" {
+		ctx.JSON(http.StatusCreated, toOrderResponse(order))
+		return
+	}
+
+	response := toOrderResponse(order)
+	responseBody, err := json.Marshal(response)
+	if err != nil {
+		_ = ctx.Error(http_errors.WithStack(err))
+		return
+	}
+
+	// O guest token não fica guardado; um replay emite um novo
+	response.GuestToken = ""
+	storedBody, err := json.Marshal(response)
+	if err != nil {
+		_ = ctx.Error(http_errors.WithStack(err))
+		return
+	}
+	h.completeIdempotentRequest(ctx, idempotencyKey, http.StatusCreated, storedBody)
+	ctx.Data(http.StatusCreated, "application/json; charset=utf-8", responseBody)
 }
 
 func (h *OrderHandler) FindAll(ctx *gin.Context) {
 	var filter dtos.OrderFilterDTO
+	var details []http_errors.FieldError
 
 	if createdAtFromStr := ctx.Query("created_at_from"); createdAtFromStr != "" {
 		if t, err := time.Parse(time.RFC3339, createdAtFromStr); err == nil {
 			filter.CreatedAtFrom = &t
+		} else {
+			details = append(details, http_errors.FieldError{Field: "created_at_from", Code: "format", Message: "must be an RFC3339 timestamp"})
 		}
 	}
 	if createdAtToStr := ctx.Query("created_at_to"); createdAtToStr != "" {
 		if t, err := time.Parse(time.RFC3339, createdAtToStr); err == nil {
 			filter.CreatedAtTo = &t
+		} else {
+			details = append(details, http_errors.FieldError{Field: "created_at_to", Code: "format", Message: "must be an RFC3339 timestamp"})
+		}
+	}
+	if limitStr := ctx.Query("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
+			filter.Limit = limit
+		} else {
+			details = append(details, http_errors.FieldError{Field: "limit", Code: "positive_integer", Message: "must be a positive integer"})
 		}
 	}
 	if statusID := ctx.Query("status_id"); statusID != "" {
@@ -87,28 +184,108 @@ func (h *OrderHandler) FindAll(ctx *gin.Context) {
 	if customerID := ctx.Query("customer_id"); customerID != "" {
 		filter.CustomerID = &customerID
 	}
+	if customerID, restricted := customerScope(ctx); restricted {
+		filter.CustomerID = &customerID
+	}
+	if cursor := ctx.Query("cursor"); cursor != "" {
+		filter.Cursor = &cursor
+	}
+	filter.Sort = ctx.Query("sort")
 
-	orders, err := h.controller.FindAll(filter)
+	if len(details) > 0 {
+		http_errors.WriteValidationError(ctx, http_errors.NewValidationError(http_errors.INVALID_QUERY_DETAIL, details...))
+		return
+	}
+
+	page, err := h.controller.FindAll(ctx.Request.Context(), filter)
 	if err != nil {
-		_ = ctx.Error(err)
+		_ = ctx.Error(http_errors.WithStack(err))
 		return
 	}
 
-	responses := make([]schemas.OrderResponseSchema, len(orders))
-	for i, order := range orders {
+	responses := make([]schemas.OrderResponseSchema, len(page.Orders))
+	for i, order := range page.Orders {
 		responses[i] = toOrderResponse(order)
 	}
 
-	ctx.JSON(http.StatusOK, responses)
+	ctx.JSON(http.StatusOK, schemas.OrderPageResponseSchema{
+		Data:       responses,
+		NextCursor: page.NextCursor,
+		Total:      page.Total,
+	})
 }
 
 func (h *OrderHandler) FindByID(ctx *gin.Context) {
 	userInput := ctx.Param("id")
 	orderID := strings.ReplaceAll(strings.ReplaceAll(userInput, "\n", "_"), "\r", "_")
 
-	order, err := h.controller.FindByID(orderID)
+	order, err := h.controller.FindByID(ctx.Request.Context(), orderID)
 	if err != nil {
-		_ = ctx.Error(err)
+		_ = ctx.Error(http_errors.WithStack(err))
+		return
+	}
+	if !canAccessOrder(ctx, order) {
+		_ = ctx.Error(&exceptions.OrderNotFoundException{})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toOrderResponse(order))
+}
+
+// Sem max_age_minutes vale o padrão do caso de uso
+func (h *OrderHandler) FindBoard(ctx *gin.Context) {
+	var maxAge time.Duration
+	if maxAgeStr := ctx.Query("max_age_minutes"); maxAgeStr != "" {
+		minutes, err := strconv.Atoi(maxAgeStr)
+		if err != nil || minutes <= 0 {
+			http_errors.WriteValidationError(ctx, http_errors.NewValidationError(http_errors.INVALID_QUERY_DETAIL, http_errors.FieldError{
+				Field:   "max_age_minutes",
+				Code:    "positive_integer",
+				Message: "must be a positive integer",
+			}))
+			return
+		}
+		maxAge = time.Duration(minutes) * time.Minute
+	}
+
+	board, err := h.controller.FindBoard(ctx.Request.Context(), maxAge)
+	if err != nil {
+		_ = ctx.Error(http_errors.WithStack(err))
+		return
+	}
+
+	groups := make([]schemas.OrderBoardGroupSchema, len(board.Groups))
+	for i, group := range board.Groups {
+		orders := make([]schemas.OrderBoardEntrySchema, len(group.Orders))
+		for j, entry := range group.Orders {
+			orders[j] = schemas.OrderBoardEntrySchema{
+				ID:           entry.ID,
+				PickupCode:   entry.PickupCode,
+				CustomerName: entry.CustomerName,
+				CreatedAt:    entry.CreatedAt,
+				WaitSeconds:  entry.WaitSeconds,
+			}
+		}
+		groups[i] = schemas.OrderBoardGroupSchema{
+			StatusID: group.Status.ID,
+			Status:   group.Status.Name,
+			Orders:   orders,
+		}
+	}
+
+	ctx.JSON(http.StatusOK, schemas.OrderBoardResponseSchema{
+		GeneratedAt: board.GeneratedAt,
+		Groups:      groups,
+	})
+}
+
+func (h *OrderHandler) FindByPickupCode(ctx *gin.Context) {
+	userInput := ctx.Param("code")
+	code := strings.ReplaceAll(strings.ReplaceAll(userInput, "\n", "_"), "\r", "_")
+
+	order, err := h.controller.FindByPickupCode(ctx.Request.Context(), code)
+	if err != nil {
+		_ = ctx.Error(http_errors.WithStack(err))
 		return
 	}
 
@@ -121,20 +298,22 @@ func (h *OrderHandler) Update(ctx *gin.Context) {
 
 	var body schemas.UpdateOrderSchema
 	if err := ctx.ShouldBindJSON(&body); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		http_errors.WriteValidationError(ctx, http_errors.NewBindingError(err))
 		return
 	}
 
-	order, err := h.controller.Update(dtos.UpdateOrderDTO{
+	dto := dtos.UpdateOrderDTO{
 		ID:       orderID,
 		StatusID: body.StatusID,
-	})
+	}
+	if principal, ok := middlewares.CurrentPrincipal(ctx); ok {
+		dto.Actor = &principal.Subject
+	}
+
+	order, err := h.controller.Update(ctx.Request.Context(), dto)
 
 	if err != nil {
-		_ = ctx.Error(err)
+		_ = ctx.Error(http_errors.WithStack(err))
 		return
 	}
 
@@ -147,20 +326,22 @@ func (h *OrderHandler) UpdateStatus(ctx *gin.Context) {
 
 	var body schemas.UpdateOrderStatusSchema
 	if err := ctx.ShouldBindJSON(&body); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		http_errors.WriteValidationError(ctx, http_errors.NewBindingError(err))
 		return
 	}
 
-	order, err := h.controller.UpdateStatus(dtos.UpdateOrderStatusDTO{
+	dto := dtos.UpdateOrderStatusDTO{
 		OrderID: orderID,
 		Status:  body.Status,
-	})
+	}
+	if principal, ok := middlewares.CurrentPrincipal(ctx); ok {
+		dto.Actor = &principal.Subject
+	}
+
+	order, err := h.controller.UpdateStatus(ctx.Request.Context(), dto)
 
 	if err != nil {
-		_ = ctx.Error(err)
+		_ = ctx.Error(http_errors.WithStack(err))
 		return
 	}
 
@@ -170,23 +351,168 @@ func (h *OrderHandler) UpdateStatus(ctx *gin.Context) {
 	})
 }
 
+func (h *OrderHandler) ApplyCoupon(ctx *gin.Context) {
+	userInput := ctx.Param("id")
+	orderID := strings.ReplaceAll(strings.ReplaceAll(userInput, "\n", "_"), "\r", "_")
+
+	var body schemas.ApplyCouponSchema
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		http_errors.WriteValidationError(ctx, http_errors.NewBindingError(err))
+		return
+	}
+
+	if _, restricted := customerScope(ctx); restricted {
+		order, err := h.controller.FindByID(ctx.Request.Context(), orderID)
+		if err != nil {
+			_ = ctx.Error(http_errors.WithStack(err))
+			return
+		}
+		if !canAccessOrder(ctx, order) {
+			_ = ctx.Error(&exceptions.OrderNotFoundException{})
+			return
+		}
+	}
+
+	order, err := h.controller.ApplyCoupon(ctx.Request.Context(), dtos.ApplyCouponDTO{
+		OrderID: orderID,
+		Code:    body.Code,
+	})
+
+	if err != nil {
+		_ = ctx.Error(http_errors.WithStack(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toOrderResponse(order))
+}
+
+// Acesso do convidado, autorizado pelo guest token em vez de login
+func (h *OrderHandler) FindGuestOrder(ctx *gin.Context) {
+	userInput := ctx.Param("id")
+	orderID := strings.ReplaceAll(strings.ReplaceAll(userInput, "\n", "_"), "\r", "_")
+
+	order, err := h.controller.FindGuestOrder(ctx.Request.Context(), orderID, ctx.GetHeader(GUEST_TOKEN_HEADER))
+	if err != nil {
+		_ = ctx.Error(http_errors.WithStack(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toOrderResponse(order))
+}
+
+func (h *OrderHandler) Claim(ctx *gin.Context) {
+	userInput := ctx.Param("id")
+	orderID := strings.ReplaceAll(strings.ReplaceAll(userInput, "\n", "_"), "\r", "_")
+
+	var body schemas.ClaimOrderSchema
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		http_errors.WriteValidationError(ctx, http_errors.NewBindingError(err))
+		return
+	}
+
+	principal, _ := middlewares.CurrentPrincipal(ctx)
+	order, err := h.controller.Claim(ctx.Request.Context(), dtos.ClaimOrderDTO{
+		OrderID:    orderID,
+		GuestToken: body.GuestToken,
+		CustomerID: principal.Subject,
+	})
+
+	if err != nil {
+		_ = ctx.Error(http_errors.WithStack(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toOrderResponse(order))
+}
+
+func (h *OrderHandler) Cancel(ctx *gin.Context) {
+	userInput := ctx.Param("id")
+	orderID := strings.ReplaceAll(strings.ReplaceAll(userInput, "\n", "_"), "\r", "_")
+
+	var body schemas.CancelOrderSchema
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		http_errors.WriteValidationError(ctx, http_errors.NewBindingError(err))
+		return
+	}
+
+	dto := dtos.CancelOrderDTO{
+		OrderID: orderID,
+		Reason:  body.Reason,
+	}
+	if principal, ok := middlewares.CurrentPrincipal(ctx); ok {
+		dto.Actor = &principal.Subject
+	}
+
+	order, err := h.controller.Cancel(ctx.Request.Context(), dto)
+
+	if err != nil {
+		_ = ctx.Error(http_errors.WithStack(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toOrderResponse(order))
+}
+
 func (h *OrderHandler) Delete(ctx *gin.Context) {
 	userInput := ctx.Param("id")
 	orderID := strings.ReplaceAll(strings.ReplaceAll(userInput, "\n", "_"), "\r", "_")
 
-	err := h.controller.Delete(orderID)
+	err := h.controller.Delete(ctx.Request.Context(), orderID)
 	if err != nil {
-		_ = ctx.Error(err)
+		_ = ctx.Error(http_errors.WithStack(err))
 		return
 	}
 
 	ctx.Status(http.StatusNoContent)
 }
 
+func (h *OrderHandler) Restore(ctx *gin.Context) {
+	userInput := ctx.Param("id")
+	orderID := strings.ReplaceAll(strings.ReplaceAll(userInput, "\n", "_"), "\r", "_")
+
+	order, err := h.controller.Restore(ctx.Request.Context(), orderID)
+	if err != nil {
+		_ = ctx.Error(http_errors.WithStack(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toOrderResponse(order))
+}
+
+func (h *OrderHandler) FindStatusHistory(ctx *gin.Context) {
+	userInput := ctx.Param("id")
+	orderID := strings.ReplaceAll(strings.ReplaceAll(userInput, "\n", "_"), "\r", "_")
+
+	history, err := h.controller.FindStatusHistory(ctx.Request.Context(), orderID)
+	if err != nil {
+		_ = ctx.Error(http_errors.WithStack(err))
+		return
+	}
+
+	responses := make([]schemas.OrderStatusHistoryResponseSchema, len(history))
+	for i, change := range history {
+		var previousStatus *string
+		if change.PreviousStatus != nil {
+			previousStatus = &change.PreviousStatus.Name
+		}
+
+		responses[i] = schemas.OrderStatusHistoryResponseSchema{
+			ID:             change.ID,
+			PreviousStatus: previousStatus,
+			Status:         change.NewStatus.Name,
+			Source:         change.Source,
+			Actor:          change.Actor,
+			ChangedAt:      change.ChangedAt,
+		}
+	}
+
+	ctx.JSON(http.StatusOK, responses)
+}
+
 func (h *OrderHandler) FindAllStatus(ctx *gin.Context) {
-	statuses, err := h.controller.FindAllStatus()
+	statuses, err := h.controller.FindAllStatus(ctx.Request.Context())
 	if err != nil {
-		_ = ctx.Error(err)
+		_ = ctx.Error(http_errors.WithStack(err))
 		return
 	}
 
@@ -201,24 +527,78 @@ func (h *OrderHandler) FindAllStatus(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, responses)
 }
 
-func toOrderResponse(order dtos.OrderResponseDTO) schemas.OrderResponseSchema {
-	items := make([]schemas.OrderItemResponseSchema, len(order.Items))
-	for i, item := range order.Items {
-		items[i] = schemas.OrderItemResponseSchema{
+func toOrderItemResponses(items []dtos.OrderItemDTO) []schemas.OrderItemResponseSchema {
+	responses := make([]schemas.OrderItemResponseSchema, len(items))
+	for i, item := range items {
+		responses[i] = schemas.OrderItemResponseSchema{
 			ID:        item.ID,
 			ProductID: item.ProductID,
-			Quantity:  item.Quantity,
-			UnitPrice: item.UnitPrice,
+			Product: schemas.ProductSnapshotResponseSchema{
+				Name:     item.Product.Name,
+				Category: item.Product.Category,
+				ImageURL: item.Product.ImageURL,
+				Notes:    item.Product.Notes,
+			},
+			Quantity:   item.Quantity,
+			UnitPrice:  schemas.Decimal(item.UnitPrice),
+			Notes:      item.Notes,
+			Modifiers:  toOrderItemModifierResponses(item.Modifiers),
+			Components: toOrderItemResponses(item.Components),
+		}
+	}
+	return responses
+}
+
+func toOrderItemModifierResponses(modifiers []dtos.OrderItemModifierDTO) []schemas.OrderItemModifierResponseSchema {
+	responses := make([]schemas.OrderItemModifierResponseSchema, len(modifiers))
+	for i, modifier := range modifiers {
+		responses[i] = schemas.OrderItemModifierResponseSchema{
+			ID:         modifier.ModifierID,
+			Name:       modifier.Name,
+			PriceDelta: schemas.Decimal(modifier.PriceDelta),
 		}
 	}
+	return responses
+}
 
+func toOrderResponse(order dtos.OrderResponseDTO) schemas.OrderResponseSchema {
 	return schemas.OrderResponseSchema{
 		ID:         order.ID,
 		CustomerID: order.CustomerID,
-		Amount:     order.Amount,
+		Amount:     schemas.Decimal(order.Amount),
+		Currency:   order.Currency,
+		Subtotal:   schemas.Decimal(order.Subtotal),
+		Discount:   schemas.Decimal(order.Discount),
+		CouponCode: order.CouponCode,
+		PickupCode: order.PickupCode,
 		Status:     order.Status.Name,
-		Items:      items,
+		Items:      toOrderItemResponses(order.Items),
 		CreatedAt:  order.CreatedAt,
 		UpdatedAt:  order.UpdatedAt,
+
+		CustomerName: order.CustomerName,
+		GuestToken:   order.GuestToken,
+
+		CancellationReason: order.CancellationReason,
+		CancelledAt:        order.CancelledAt,
+	}
+}
+
+// Requisições sem principal (testes de handler) não são restritas; as rotas
+// sempre passam pelo AuthMiddleware
+func customerScope(ctx *gin.Context) (string, bool) {
+	principal, ok := middlewares.CurrentPrincipal(ctx)
+	if !ok || !principal.IsCustomerOnly() {
+		return "", false
+	}
+	return principal.Subject, true
+}
+
+// Pedidos de outros clientes aparecem como inexistentes
+func canAccessOrder(ctx *gin.Context, order dtos.OrderResponseDTO) bool {
+	customerID, restricted := customerScope(ctx)
+	if !restricted {
+		return true
 	}
+	return order.CustomerID != nil && *order.CustomerID == customerID
 }
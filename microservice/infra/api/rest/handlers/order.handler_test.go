@@ -2,18 +2,27 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"microservice/infra/api/rest/http_errors"
+	"microservice/infra/api/rest/middlewares"
 	"microservice/infra/api/rest/schemas"
+	"microservice/infra/auth"
+	"microservice/infra/catalog"
+	"microservice/infra/db/memory"
 	"microservice/internal/adapters/daos"
 	"microservice/internal/adapters/dtos"
+	"microservice/internal/domain/exceptions"
 	"microservice/internal/interfaces"
 	"microservice/utils/factories"
 )
@@ -25,67 +34,150 @@ func init() {
 type mockOrderDS struct {
 	createFunc   func(order daos.OrderDAO) error
 	findAllFunc  func(filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error)
+	countFunc    func(filter dtos.OrderFilterDTO) (int64, error)
 	findByIDFunc func(id string) (daos.OrderDAO, error)
 	updateFunc   func(order daos.OrderDAO) error
 	deleteFunc   func(id string) error
+
+	findStatusHistoryFunc func(orderID string) ([]daos.OrderStatusHistoryDAO, error)
+	applyCouponFunc       func(order daos.OrderDAO) error
+	restoreFunc           func(id string) error
+	claimFunc             func(orderID string, customerID string, guestTokenHash string) error
+	reissueGuestTokenFunc func(orderID string, guestTokenHash string) error
+
+	findByPickupCodeFunc func(day string, code string) (daos.OrderDAO, error)
+	findBoardFunc        func(statusIDs []string, createdFrom time.Time) ([]daos.OrderBoardEntryDAO, error)
+	pickupSequence       int
+	// Contexto recebido pelo último Create
+	createCtx context.Context
 }
 
-func (m *mockOrderDS) Create(order daos.OrderDAO) error {
+func (m *mockOrderDS) Create(ctx context.Context, order daos.OrderDAO, outbox ...daos.OutboxMessageDAO) error {
+	m.createCtx = ctx
 	if m.createFunc != nil {
 		return m.createFunc(order)
 	}
 	return nil
 }
 
-func (m *mockOrderDS) FindAll(filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error) {
+func (m *mockOrderDS) FindAll(ctx context.Context, filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error) {
 	if m.findAllFunc != nil {
 		return m.findAllFunc(filter)
 	}
 	return []daos.OrderDAO{}, nil
 }
 
-func (m *mockOrderDS) FindByID(id string) (daos.OrderDAO, error) {
+func (m *mockOrderDS) Count(ctx context.Context, filter dtos.OrderFilterDTO) (int64, error) {
+	if m.countFunc != nil {
+		return m.countFunc(filter)
+	}
+	return 0, nil
+}
+
+func (m *mockOrderDS) FindByID(ctx context.Context, id string) (daos.OrderDAO, error) {
 	if m.findByIDFunc != nil {
 		return m.findByIDFunc(id)
 	}
 	return daos.OrderDAO{}, nil
 }
 
-func (m *mockOrderDS) Update(order daos.OrderDAO) error {
+func (m *mockOrderDS) Update(ctx context.Context, order daos.OrderDAO, outbox ...daos.OutboxMessageDAO) error {
 	if m.updateFunc != nil {
 		return m.updateFunc(order)
 	}
 	return nil
 }
 
-func (m *mockOrderDS) Delete(id string) error {
+func (m *mockOrderDS) UpdateFromMessage(ctx context.Context, order daos.OrderDAO, message daos.ProcessedMessageDAO, outbox ...daos.OutboxMessageDAO) error {
+	return m.Update(ctx, order, outbox...)
+}
+
+func (m *mockOrderDS) ApplyCoupon(ctx context.Context, order daos.OrderDAO, outbox ...daos.OutboxMessageDAO) error {
+	if m.applyCouponFunc != nil {
+		return m.applyCouponFunc(order)
+	}
+	return nil
+}
+
+func (m *mockOrderDS) Delete(ctx context.Context, id string, outbox ...daos.OutboxMessageDAO) error {
 	if m.deleteFunc != nil {
 		return m.deleteFunc(id)
 	}
 	return nil
 }
 
+func (m *mockOrderDS) FindByPickupCode(ctx context.Context, day string, code string) (daos.OrderDAO, error) {
+	if m.findByPickupCodeFunc != nil {
+		return m.findByPickupCodeFunc(day, code)
+	}
+	return daos.OrderDAO{}, errors.New("not found")
+}
+
+func (m *mockOrderDS) FindBoard(ctx context.Context, statusIDs []string, createdFrom time.Time) ([]daos.OrderBoardEntryDAO, error) {
+	if m.findBoardFunc != nil {
+		return m.findBoardFunc(statusIDs, createdFrom)
+	}
+	return []daos.OrderBoardEntryDAO{}, nil
+}
+
+func (m *mockOrderDS) NextPickupSequence(ctx context.Context, day string) (int, error) {
+	m.pickupSequence++
+	return m.pickupSequence, nil
+}
+
+func (m *mockOrderDS) Restore(ctx context.Context, id string, outbox ...daos.OutboxMessageDAO) error {
+	if m.restoreFunc != nil {
+		return m.restoreFunc(id)
+	}
+	return nil
+}
+
+func (m *mockOrderDS) Claim(ctx context.Context, orderID string, customerID string, guestTokenHash string, claimedAt time.Time) error {
+	if m.claimFunc != nil {
+		return m.claimFunc(orderID, customerID, guestTokenHash)
+	}
+	return nil
+}
+
+func (m *mockOrderDS) ReissueGuestToken(ctx context.Context, orderID string, guestTokenHash string) error {
+	if m.reissueGuestTokenFunc != nil {
+		return m.reissueGuestTokenFunc(orderID, guestTokenHash)
+	}
+	return nil
+}
+
+func (m *mockOrderDS) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockOrderDS) FindStatusHistory(ctx context.Context, orderID string) ([]daos.OrderStatusHistoryDAO, error) {
+	if m.findStatusHistoryFunc != nil {
+		return m.findStatusHistoryFunc(orderID)
+	}
+	return []daos.OrderStatusHistoryDAO{}, nil
+}
+
 type mockOrderStatusDS struct {
 	findByIDFunc   func(id string) (daos.OrderStatusDAO, error)
 	findByNameFunc func(name string) (daos.OrderStatusDAO, error)
 	findAllFunc    func() ([]daos.OrderStatusDAO, error)
 }
 
-func (m *mockOrderStatusDS) FindByID(id string) (daos.OrderStatusDAO, error) {
+func (m *mockOrderStatusDS) FindByID(ctx context.Context, id string) (daos.OrderStatusDAO, error) {
 	if m.findByIDFunc != nil {
 		return m.findByIDFunc(id)
 	}
 	return daos.OrderStatusDAO{ID: "status-1", Name: "Pending"}, nil
 }
 
-func (m *mockOrderStatusDS) FindByName(name string) (daos.OrderStatusDAO, error) {
+func (m *mockOrderStatusDS) FindByName(ctx context.Context, name string) (daos.OrderStatusDAO, error) {
 	if m.findByNameFunc != nil {
 		return m.findByNameFunc(name)
 	}
 	return daos.OrderStatusDAO{ID: "status-1", Name: name}, nil
 }
 
-func (m *mockOrderStatusDS) FindAll() ([]daos.OrderStatusDAO, error) {
+func (m *mockOrderStatusDS) FindAll(ctx context.Context) ([]daos.OrderStatusDAO, error) {
 	if m.findAllFunc != nil {
 		return m.findAllFunc()
 	}
@@ -99,10 +191,34 @@ func setupMocks(orderDS *mockOrderDS, statusDS *mockOrderStatusDS) func() {
 	factories.SetNewOrderStatusDataSource(func() interfaces.IOrderStatusDataSource {
 		return statusDS
 	})
+	idempotencyDS := memory.NewIdempotencyDataSource()
+	factories.SetNewIdempotencyDataSource(func() interfaces.IIdempotencyDataSource {
+		return idempotencyDS
+	})
+	productCatalogDS := catalog.NewInMemoryProductCatalogDataSource(
+		daos.ProductDAO{ID: "product-1", Name: "X-Burger", Price: 1000, Currency: "BRL", Available: true, Modifiers: []daos.ProductModifierDAO{
+			{ID: "queijo-extra", Name: "Queijo extra", PriceDelta: 300},
+		}},
+		daos.ProductDAO{ID: "product-2", Name: "Batata Frita", Price: 1290, Currency: "BRL", Available: true},
+		daos.ProductDAO{ID: "combo-1", Name: "Combo X-Burger", Price: 1990, Currency: "BRL", Available: true, ComboItems: []daos.ProductComboItemDAO{
+			{ProductID: "product-1", Quantity: 1},
+			{ProductID: "product-2", Quantity: 1},
+		}},
+	)
+	factories.SetNewProductCatalogDataSource(func() interfaces.IProductCatalogDataSource {
+		return productCatalogDS
+	})
+	couponDS := newMockCouponDS()
+	factories.SetNewCouponDataSource(func() interfaces.ICouponDataSource {
+		return couponDS
+	})
 
 	return func() {
 		factories.SetNewOrderDataSource(nil)
 		factories.SetNewOrderStatusDataSource(nil)
+		factories.SetNewIdempotencyDataSource(nil)
+		factories.SetNewProductCatalogDataSource(nil)
+		factories.SetNewCouponDataSource(nil)
 	}
 }
 
@@ -141,7 +257,7 @@ func TestOrderHandler_Create_Success(t *testing.T) {
 
 	body := schemas.CreateOrderSchema{
 		Items: []schemas.CreateOrderItemSchema{
-			{ProductID: "product-1", Quantity: 2, Price: 10.0},
+			{ProductID: "product-1", Quantity: 2, Price: "10.00"},
 		},
 	}
 	jsonBody, _ := json.Marshal(body)
@@ -155,9 +271,19 @@ func TestOrderHandler_Create_Success(t *testing.T) {
 	}
 }
 
-func TestOrderHandler_Create_InvalidBody(t *testing.T) {
-	orderDS := &mockOrderDS{}
-	statusDS := &mockOrderStatusDS{}
+func TestOrderHandler_Create_MoneyAsDecimalString(t *testing.T) {
+	var created daos.OrderDAO
+	orderDS := &mockOrderDS{
+		createFunc: func(order daos.OrderDAO) error {
+			created = order
+			return nil
+		},
+	}
+	statusDS := &mockOrderStatusDS{
+		findByIDFunc: func(id string) (daos.OrderStatusDAO, error) {
+			return daos.OrderStatusDAO{ID: "status-1", Name: "Pending"}, nil
+		},
+	}
 	cleanup := setupMocks(orderDS, statusDS)
 	defer cleanup()
 
@@ -168,33 +294,32 @@ func TestOrderHandler_Create_InvalidBody(t *testing.T) {
 
 	router.POST("/orders", handler.Create)
 
-	req := httptest.NewRequest("POST", "/orders", bytes.NewBuffer([]byte("invalid json")))
+	// Preço numérico ainda é aceito por compatibilidade
+	body := `{"items":[{"product_id":"product-1","quantity":3,"price":10}]}`
+	req := httptest.NewRequest("POST", "/orders", bytes.NewBufferString(body))
 	req.Header.Set("Content-Type", "application/json")
 	router.ServeHTTP(w, req)
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("Create() status = %v, want %v", w.Code, http.StatusBadRequest)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Create() status = %v, want %v", w.Code, http.StatusCreated)
+	}
+	if created.Amount != 3000 || created.Currency != "BRL" {
+		t.Errorf("Create() stored Amount = %v %v, want 3000 BRL", created.Amount, created.Currency)
 	}
-}
 
-func TestOrderHandler_FindAll_Success(t *testing.T) {
-	customerID := "customer-123"
-	now := time.Now()
+	var response map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	if response["amount"] != "30.00" || response["currency"] != "BRL" {
+		t.Errorf("Create() response amount = %v %v, want \"30.00\" BRL", response["amount"], response["currency"])
+	}
+}
 
+func TestOrderHandler_Create_PriceDifferentFromCatalog(t *testing.T) {
+	created := false
 	orderDS := &mockOrderDS{
-		findAllFunc: func(filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error) {
-			return []daos.OrderDAO{
-				{
-					ID:         "order-1",
-					CustomerID: &customerID,
-					Amount:     20.0,
-					Status:     daos.OrderStatusDAO{ID: "status-1", Name: "Pending"},
-					Items: []daos.OrderItemDAO{
-						{ID: "item-1", OrderID: "order-1", ProductID: "product-1", Quantity: 2, UnitPrice: 10.0},
-					},
-					CreatedAt: now,
-				},
-			}, nil
+		createFunc: func(order daos.OrderDAO) error {
+			created = true
+			return nil
 		},
 	}
 	statusDS := &mockOrderStatusDS{}
@@ -206,20 +331,28 @@ func TestOrderHandler_FindAll_Success(t *testing.T) {
 	w := httptest.NewRecorder()
 	_, router := gin.CreateTestContext(w)
 
-	router.GET("/orders", handler.FindAll)
+	router.Use(middlewares.ErrorHandlerMiddleware())
+	router.POST("/orders", handler.Create)
 
-	req := httptest.NewRequest("GET", "/orders", nil)
+	body := `{"items":[{"product_id":"product-1","quantity":1,"price":"0.01"}]}`
+	req := httptest.NewRequest("POST", "/orders", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
 	router.ServeHTTP(w, req)
 
-	if w.Code != http.StatusOK {
-		t.Errorf("FindAll() status = %v, want %v", w.Code, http.StatusOK)
+	if w.Code != http.StatusConflict {
+		t.Errorf("Create() status = %v, want %v", w.Code, http.StatusConflict)
+	}
+	if created {
+		t.Error("Create() should not store an order priced by the client")
 	}
 }
 
-func TestOrderHandler_FindAll_WithFilters(t *testing.T) {
+func TestOrderHandler_Create_WithNotesAndModifiers(t *testing.T) {
+	var stored daos.OrderDAO
 	orderDS := &mockOrderDS{
-		findAllFunc: func(filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error) {
-			return []daos.OrderDAO{}, nil
+		createFunc: func(order daos.OrderDAO) error {
+			stored = order
+			return nil
 		},
 	}
 	statusDS := &mockOrderStatusDS{}
@@ -231,36 +364,44 @@ func TestOrderHandler_FindAll_WithFilters(t *testing.T) {
 	w := httptest.NewRecorder()
 	_, router := gin.CreateTestContext(w)
 
-	router.GET("/orders", handler.FindAll)
+	router.Use(middlewares.ErrorHandlerMiddleware())
+	router.POST("/orders", handler.Create)
 
-	req := httptest.NewRequest("GET", "/orders?status_id=status-1&customer_id=customer-123&created_at_from=2024-01-01T00:00:00Z&created_at_to=2024-12-31T23:59:59Z", nil)
+	body := `{"items":[{"product_id":"product-1","quantity":2,"notes":"sem cebola","modifiers":[{"id":"queijo-extra"}]}]}`
+	req := httptest.NewRequest("POST", "/orders", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
 	router.ServeHTTP(w, req)
 
-	if w.Code != http.StatusOK {
-		t.Errorf("FindAll() with filters status = %v, want %v", w.Code, http.StatusOK)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Create() status = %v, want %v (%s)", w.Code, http.StatusCreated, w.Body.String())
+	}
+	if stored.Amount != 2600 {
+		t.Errorf("Create() stored amount = %v, want 2600", stored.Amount)
 	}
-}
 
-func TestOrderHandler_FindByID_Success(t *testing.T) {
-	customerID := "customer-123"
-	now := time.Now()
+	var response schemas.OrderResponseSchema
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Create() invalid response: %v", err)
+	}
+	item := response.Items[0]
+	if item.Notes != "sem cebola" {
+		t.Errorf("Create() item notes = %q, want sem cebola", item.Notes)
+	}
+	expectedModifiers := []schemas.OrderItemModifierResponseSchema{{ID: "queijo-extra", Name: "Queijo extra", PriceDelta: "3.00"}}
+	if !reflect.DeepEqual(item.Modifiers, expectedModifiers) {
+		t.Errorf("Create() item modifiers = %+v, want %+v", item.Modifiers, expectedModifiers)
+	}
+}
 
+func TestOrderHandler_Create_Combo(t *testing.T) {
+	var stored daos.OrderDAO
 	orderDS := &mockOrderDS{
-		findByIDFunc: func(id string) (daos.OrderDAO, error) {
-			return daos.OrderDAO{
-				ID:         "550e8400-e29b-41d4-a716-446655440000",
-				CustomerID: &customerID,
-				Amount:     20.0,
-				Status:     daos.OrderStatusDAO{ID: "status-1", Name: "Pending"},
-				Items: []daos.OrderItemDAO{
-					{ID: "item-1", OrderID: "550e8400-e29b-41d4-a716-446655440000", ProductID: "product-1", Quantity: 2, UnitPrice: 10.0},
-				},
-				CreatedAt: now,
-			}, nil
+		createFunc: func(order daos.OrderDAO) error {
+			stored = order
+			return nil
 		},
 	}
-	statusDS := &mockOrderStatusDS{}
-	cleanup := setupMocks(orderDS, statusDS)
+	cleanup := setupMocks(orderDS, &mockOrderStatusDS{})
 	defer cleanup()
 
 	handler := NewOrderHandler()
@@ -268,42 +409,67 @@ func TestOrderHandler_FindByID_Success(t *testing.T) {
 	w := httptest.NewRecorder()
 	_, router := gin.CreateTestContext(w)
 
-	router.GET("/orders/:id", handler.FindByID)
+	router.Use(middlewares.ErrorHandlerMiddleware())
+	router.POST("/orders", handler.Create)
 
-	req := httptest.NewRequest("GET", "/orders/550e8400-e29b-41d4-a716-446655440000", nil)
+	req := httptest.NewRequest("POST", "/orders", bytes.NewBufferString(`{"items":[{"product_id":"combo-1","quantity":1}]}`))
+	req.Header.Set("Content-Type", "application/json")
 	router.ServeHTTP(w, req)
 
-	if w.Code != http.StatusOK {
-		t.Errorf("FindByID() status = %v, want %v", w.Code, http.StatusOK)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Create() status = %v, want %v (%s)", w.Code, http.StatusCreated, w.Body.String())
+	}
+	if stored.Amount != 1990 || len(stored.Items) != 3 {
+		t.Errorf("Create() stored amount = %v with %d items, want 1990 with combo and 2 components", stored.Amount, len(stored.Items))
 	}
-}
 
-func TestOrderHandler_Update_Success(t *testing.T) {
-	customerID := "customer-123"
-	now := time.Now()
+	var response schemas.OrderResponseSchema
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Create() invalid response: %v", err)
+	}
+	if response.Amount != "19.90" || len(response.Items) != 1 || len(response.Items[0].Components) != 2 {
+		t.Errorf("Create() response = %+v, want one combo item at 19.90 with 2 components", response)
+	}
+}
 
-	orderDS := &mockOrderDS{
-		findByIDFunc: func(id string) (daos.OrderDAO, error) {
-			return daos.OrderDAO{
-				ID:         "550e8400-e29b-41d4-a716-446655440000",
-				CustomerID: &customerID,
-				Amount:     20.0,
-				Status:     daos.OrderStatusDAO{ID: "status-1", Name: "Pending"},
-				Items: []daos.OrderItemDAO{
-					{ID: "item-1", OrderID: "550e8400-e29b-41d4-a716-446655440000", ProductID: "product-1", Quantity: 2, UnitPrice: 10.0},
-				},
-				CreatedAt: now,
-			}, nil
-		},
-		updateFunc: func(order daos.OrderDAO) error {
-			return nil
-		},
+func TestOrderHandler_Create_InvalidCustomization(t *testing.T) {
+	tests := []struct {
+		name     string
+		item     string
+		expected int
+	}{
+		{"modifier not offered", `{"product_id":"product-1","quantity":1,"modifiers":[{"id":"bacon-extra"}]}`, http.StatusUnprocessableEntity},
+		{"modifier without id", `{"product_id":"product-1","quantity":1,"modifiers":[{}]}`, http.StatusBadRequest},
+		{"notes too long", `{"product_id":"product-1","quantity":1,"notes":"` + strings.Repeat("a", 201) + `"}`, http.StatusBadRequest},
 	}
-	statusDS := &mockOrderStatusDS{
-		findByIDFunc: func(id string) (daos.OrderStatusDAO, error) {
-			return daos.OrderStatusDAO{ID: "status-2", Name: "Confirmed"}, nil
-		},
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cleanup := setupMocks(&mockOrderDS{}, &mockOrderStatusDS{})
+			defer cleanup()
+
+			handler := NewOrderHandler()
+
+			w := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(w)
+
+			router.Use(middlewares.ErrorHandlerMiddleware())
+			router.POST("/orders", handler.Create)
+
+			req := httptest.NewRequest("POST", "/orders", bytes.NewBufferString(`{"items":[`+tt.item+`]}`))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expected {
+				t.Errorf("Create() status = %v, want %v (%s)", w.Code, tt.expected, w.Body.String())
+			}
+		})
 	}
+}
+
+func TestOrderHandler_Create_UnknownProduct(t *testing.T) {
+	orderDS := &mockOrderDS{}
+	statusDS := &mockOrderStatusDS{}
 	cleanup := setupMocks(orderDS, statusDS)
 	defer cleanup()
 
@@ -312,21 +478,20 @@ func TestOrderHandler_Update_Success(t *testing.T) {
 	w := httptest.NewRecorder()
 	_, router := gin.CreateTestContext(w)
 
-	router.PUT("/orders/:id", handler.Update)
-
-	body := schemas.UpdateOrderSchema{StatusID: "status-2"}
-	jsonBody, _ := json.Marshal(body)
+	router.Use(middlewares.ErrorHandlerMiddleware())
+	router.POST("/orders", handler.Create)
 
-	req := httptest.NewRequest("PUT", "/orders/550e8400-e29b-41d4-a716-446655440000", bytes.NewBuffer(jsonBody))
+	body := `{"items":[{"product_id":"product-404","quantity":1}]}`
+	req := httptest.NewRequest("POST", "/orders", bytes.NewBufferString(body))
 	req.Header.Set("Content-Type", "application/json")
 	router.ServeHTTP(w, req)
 
-	if w.Code != http.StatusOK {
-		t.Errorf("Update() status = %v, want %v", w.Code, http.StatusOK)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Create() status = %v, want %v", w.Code, http.StatusUnprocessableEntity)
 	}
 }
 
-func TestOrderHandler_Update_InvalidBody(t *testing.T) {
+func TestOrderHandler_Create_PriceWithTooManyDecimals(t *testing.T) {
 	orderDS := &mockOrderDS{}
 	statusDS := &mockOrderStatusDS{}
 	cleanup := setupMocks(orderDS, statusDS)
@@ -337,36 +502,21 @@ func TestOrderHandler_Update_InvalidBody(t *testing.T) {
 	w := httptest.NewRecorder()
 	_, router := gin.CreateTestContext(w)
 
-	router.PUT("/orders/:id", handler.Update)
+	router.Use(middlewares.ErrorHandlerMiddleware())
+	router.POST("/orders", handler.Create)
 
-	req := httptest.NewRequest("PUT", "/orders/550e8400-e29b-41d4-a716-446655440000", bytes.NewBuffer([]byte("invalid json")))
+	body := `{"items":[{"product_id":"product-1","quantity":1,"price":"10.999"}]}`
+	req := httptest.NewRequest("POST", "/orders", bytes.NewBufferString(body))
 	req.Header.Set("Content-Type", "application/json")
 	router.ServeHTTP(w, req)
 
 	if w.Code != http.StatusBadRequest {
-		t.Errorf("Update() status = %v, want %v", w.Code, http.StatusBadRequest)
+		t.Errorf("Create() status = %v, want %v", w.Code, http.StatusBadRequest)
 	}
 }
 
-func TestOrderHandler_Delete_Success(t *testing.T) {
-	customerID := "customer-123"
-	now := time.Now()
-
-	orderDS := &mockOrderDS{
-		findByIDFunc: func(id string) (daos.OrderDAO, error) {
-			return daos.OrderDAO{
-				ID:         "550e8400-e29b-41d4-a716-446655440000",
-				CustomerID: &customerID,
-				Amount:     20.0,
-				Status:     daos.OrderStatusDAO{ID: "status-1", Name: "Pending"},
-				Items:      []daos.OrderItemDAO{},
-				CreatedAt:  now,
-			}, nil
-		},
-		deleteFunc: func(id string) error {
-			return nil
-		},
-	}
+func TestOrderHandler_Create_InvalidBody(t *testing.T) {
+	orderDS := &mockOrderDS{}
 	statusDS := &mockOrderStatusDS{}
 	cleanup := setupMocks(orderDS, statusDS)
 	defer cleanup()
@@ -376,26 +526,20 @@ func TestOrderHandler_Delete_Success(t *testing.T) {
 	w := httptest.NewRecorder()
 	_, router := gin.CreateTestContext(w)
 
-	router.DELETE("/orders/:id", handler.Delete)
+	router.POST("/orders", handler.Create)
 
-	req := httptest.NewRequest("DELETE", "/orders/550e8400-e29b-41d4-a716-446655440000", nil)
+	req := httptest.NewRequest("POST", "/orders", bytes.NewBuffer([]byte("invalid json")))
+	req.Header.Set("Content-Type", "application/json")
 	router.ServeHTTP(w, req)
 
-	if w.Code != http.StatusNoContent {
-		t.Errorf("Delete() status = %v, want %v", w.Code, http.StatusNoContent)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Create() status = %v, want %v", w.Code, http.StatusBadRequest)
 	}
 }
 
-func TestOrderHandler_FindAllStatus_Success(t *testing.T) {
+func TestOrderHandler_Create_FieldErrors(t *testing.T) {
 	orderDS := &mockOrderDS{}
-	statusDS := &mockOrderStatusDS{
-		findAllFunc: func() ([]daos.OrderStatusDAO, error) {
-			return []daos.OrderStatusDAO{
-				{ID: "status-1", Name: "Pending"},
-				{ID: "status-2", Name: "Confirmed"},
-			}, nil
-		},
-	}
+	statusDS := &mockOrderStatusDS{}
 	cleanup := setupMocks(orderDS, statusDS)
 	defer cleanup()
 
@@ -404,58 +548,54 @@ func TestOrderHandler_FindAllStatus_Success(t *testing.T) {
 	w := httptest.NewRecorder()
 	_, router := gin.CreateTestContext(w)
 
-	router.GET("/orders/status", handler.FindAllStatus)
+	router.POST("/orders", handler.Create)
 
-	req := httptest.NewRequest("GET", "/orders/status", nil)
+	body := `{"currency":"brl","items":[{"product_id":"","quantity":1}]}`
+	req := httptest.NewRequest("POST", "/orders", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
 	router.ServeHTTP(w, req)
 
-	if w.Code != http.StatusOK {
-		t.Errorf("FindAllStatus() status = %v, want %v", w.Code, http.StatusOK)
-	}
-}
-
-func TestToOrderResponse(t *testing.T) {
-	customerID := "customer-123"
-	now := time.Now()
-
-	dto := dtos.OrderResponseDTO{
-		ID:         "order-1",
-		CustomerID: &customerID,
-		Amount:     100.0,
-		Status:     dtos.OrderStatusDTO{ID: "status-1", Name: "Pending"},
-		Items: []dtos.OrderItemDTO{
-			{ID: "item-1", ProductID: "product-1", OrderID: "order-1", Quantity: 2, UnitPrice: 50.0},
-		},
-		CreatedAt: now,
-		UpdatedAt: nil,
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Create() status = %v, want %v", w.Code, http.StatusBadRequest)
 	}
 
-	response := toOrderResponse(dto)
-
-	if response.ID != "order-1" {
-		t.Errorf("toOrderResponse() ID = %v, want order-1", response.ID)
+	var problem http_errors.Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
 	}
-	if *response.CustomerID != customerID {
-		t.Errorf("toOrderResponse() CustomerID = %v, want %v", *response.CustomerID, customerID)
-	}
-	if response.Amount != 100.0 {
-		t.Errorf("toOrderResponse() Amount = %v, want 100.0", response.Amount)
+	want := []http_errors.FieldError{
+		{Field: "currency", Code: "uppercase", Message: "must be uppercase"},
+		{Field: "items[0].product_id", Code: "required", Message: "is required"},
 	}
-	if response.Status != "Pending" {
-		t.Errorf("toOrderResponse() Status = %v, want Pending", response.Status)
+	if problem.Code != "VALIDATION_FAILED" || !reflect.DeepEqual(problem.Errors, want) {
+		t.Errorf("Create() problem = %+v, want VALIDATION_FAILED with %+v", problem, want)
 	}
-	if len(response.Items) != 1 {
-		t.Errorf("toOrderResponse() Items length = %v, want 1", len(response.Items))
+	if strings.Contains(w.Body.String(), "Key:") {
+		t.Errorf("Create() body = %s, must not expose the validator output", w.Body.String())
 	}
 }
 
-func TestOrderHandler_Create_Error(t *testing.T) {
-	orderDS := &mockOrderDS{}
-	statusDS := &mockOrderStatusDS{
-		findByIDFunc: func(id string) (daos.OrderStatusDAO, error) {
-			return daos.OrderStatusDAO{}, errors.New("status not found")
+func TestOrderHandler_FindAll_Success(t *testing.T) {
+	customerID := "customer-123"
+	now := time.Now()
+
+	orderDS := &mockOrderDS{
+		findAllFunc: func(filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error) {
+			return []daos.OrderDAO{
+				{
+					ID:         "order-1",
+					CustomerID: &customerID,
+					Amount:     2000,
+					Status:     daos.OrderStatusDAO{ID: "status-1", Name: "Pending"},
+					Items: []daos.OrderItemDAO{
+						{ID: "item-1", OrderID: "order-1", ProductID: "product-1", Quantity: 2, UnitPrice: 1000},
+					},
+					CreatedAt: now,
+				},
+			}, nil
 		},
 	}
+	statusDS := &mockOrderStatusDS{}
 	cleanup := setupMocks(orderDS, statusDS)
 	defer cleanup()
 
@@ -464,28 +604,20 @@ func TestOrderHandler_Create_Error(t *testing.T) {
 	w := httptest.NewRecorder()
 	_, router := gin.CreateTestContext(w)
 
-	router.POST("/orders", handler.Create)
-
-	body := schemas.CreateOrderSchema{
-		Items: []schemas.CreateOrderItemSchema{
-			{ProductID: "product-1", Quantity: 2, Price: 10.0},
-		},
-	}
-	jsonBody, _ := json.Marshal(body)
+	router.GET("/orders", handler.FindAll)
 
-	req := httptest.NewRequest("POST", "/orders", bytes.NewBuffer(jsonBody))
-	req.Header.Set("Content-Type", "application/json")
+	req := httptest.NewRequest("GET", "/orders", nil)
 	router.ServeHTTP(w, req)
 
-	if w.Code == http.StatusCreated {
-		t.Error("Create() should fail when status not found")
+	if w.Code != http.StatusOK {
+		t.Errorf("FindAll() status = %v, want %v", w.Code, http.StatusOK)
 	}
 }
 
-func TestOrderHandler_FindAll_Error(t *testing.T) {
+func TestOrderHandler_FindAll_WithFilters(t *testing.T) {
 	orderDS := &mockOrderDS{
 		findAllFunc: func(filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error) {
-			return nil, errors.New("database error")
+			return []daos.OrderDAO{}, nil
 		},
 	}
 	statusDS := &mockOrderStatusDS{}
@@ -499,18 +631,28 @@ func TestOrderHandler_FindAll_Error(t *testing.T) {
 
 	router.GET("/orders", handler.FindAll)
 
-	req := httptest.NewRequest("GET", "/orders", nil)
+	req := httptest.NewRequest("GET", "/orders?status_id=status-1&customer_id=customer-123&created_at_from=2024-01-01T00:00:00Z&created_at_to=2024-12-31T23:59:59Z", nil)
 	router.ServeHTTP(w, req)
 
-	if w.Code == http.StatusOK && w.Body.Len() > 2 {
-		t.Log("FindAll() returned data despite error")
+	if w.Code != http.StatusOK {
+		t.Errorf("FindAll() with filters status = %v, want %v", w.Code, http.StatusOK)
 	}
 }
 
-func TestOrderHandler_FindByID_Error(t *testing.T) {
+func TestOrderHandler_FindAll_ResponseEnvelope(t *testing.T) {
+	now := time.Now().UTC()
+	var received dtos.OrderFilterDTO
+
 	orderDS := &mockOrderDS{
-		findByIDFunc: func(id string) (daos.OrderDAO, error) {
-			return daos.OrderDAO{}, errors.New("not found")
+		findAllFunc: func(filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error) {
+			received = filter
+			return []daos.OrderDAO{
+				{ID: "order-2", Amount: 1000, Status: daos.OrderStatusDAO{ID: "status-1", Name: "Recebido"}, CreatedAt: now},
+				{ID: "order-1", Amount: 1000, Status: daos.OrderStatusDAO{ID: "status-1", Name: "Recebido"}, CreatedAt: now.Add(-time.Minute)},
+			}, nil
+		},
+		countFunc: func(filter dtos.OrderFilterDTO) (int64, error) {
+			return 5, nil
 		},
 	}
 	statusDS := &mockOrderStatusDS{}
@@ -522,49 +664,93 @@ func TestOrderHandler_FindByID_Error(t *testing.T) {
 	w := httptest.NewRecorder()
 	_, router := gin.CreateTestContext(w)
 
-	router.GET("/orders/:id", handler.FindByID)
+	router.GET("/orders", handler.FindAll)
 
-	req := httptest.NewRequest("GET", "/orders/550e8400-e29b-41d4-a716-446655440000", nil)
+	req := httptest.NewRequest("GET", "/orders?limit=1&sort=-created_at", nil)
 	router.ServeHTTP(w, req)
 
-	if w.Code == http.StatusOK {
-		t.Log("FindByID() returned OK despite error")
+	if w.Code != http.StatusOK {
+		t.Fatalf("FindAll() status = %v, want %v", w.Code, http.StatusOK)
+	}
+	if received.Limit != 2 || received.Sort != dtos.ORDER_SORT_CREATED_AT_DESC {
+		t.Errorf("FindAll() filter = %+v, want limit 2 and sort -created_at", received)
+	}
+
+	var response schemas.OrderPageResponseSchema
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("FindAll() invalid body: %v", err)
+	}
+	if len(response.Data) != 1 || response.Data[0].ID != "order-2" {
+		t.Errorf("FindAll() data = %+v, want only order-2", response.Data)
+	}
+	if response.Total != 5 {
+		t.Errorf("FindAll() total = %v, want 5", response.Total)
+	}
+	if response.NextCursor == nil {
+		t.Error("FindAll() next_cursor should be set when more orders exist")
 	}
 }
 
-func TestOrderHandler_Update_Error(t *testing.T) {
-	orderDS := &mockOrderDS{
-		findByIDFunc: func(id string) (daos.OrderDAO, error) {
-			return daos.OrderDAO{}, errors.New("not found")
-		},
+func TestOrderHandler_FindAll_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"malformed created_at_from", "created_at_from=yesterday"},
+		{"malformed created_at_to", "created_at_to=2024-13-01"},
+		{"non numeric limit", "limit=ten"},
+		{"zero limit", "limit=0"},
 	}
-	statusDS := &mockOrderStatusDS{}
-	cleanup := setupMocks(orderDS, statusDS)
-	defer cleanup()
 
-	handler := NewOrderHandler()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findAllCalled := false
+			orderDS := &mockOrderDS{
+				findAllFunc: func(filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error) {
+					findAllCalled = true
+					return []daos.OrderDAO{}, nil
+				},
+			}
+			statusDS := &mockOrderStatusDS{}
+			cleanup := setupMocks(orderDS, statusDS)
+			defer cleanup()
 
-	w := httptest.NewRecorder()
-	_, router := gin.CreateTestContext(w)
+			handler := NewOrderHandler()
 
-	router.PUT("/orders/:id", handler.Update)
+			w := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(w)
 
-	body := schemas.UpdateOrderSchema{StatusID: "status-2"}
-	jsonBody, _ := json.Marshal(body)
+			router.GET("/orders", handler.FindAll)
 
-	req := httptest.NewRequest("PUT", "/orders/550e8400-e29b-41d4-a716-446655440000", bytes.NewBuffer(jsonBody))
-	req.Header.Set("Content-Type", "application/json")
-	router.ServeHTTP(w, req)
+			req := httptest.NewRequest("GET", "/orders?"+tt.query, nil)
+			router.ServeHTTP(w, req)
 
-	if w.Code == http.StatusOK {
-		t.Log("Update() returned OK despite error")
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("FindAll() status = %v, want %v", w.Code, http.StatusBadRequest)
+			}
+			if findAllCalled {
+				t.Error("FindAll() should not query the data source for invalid parameters")
+			}
+		})
 	}
 }
 
-func TestOrderHandler_Delete_Error(t *testing.T) {
+func TestOrderHandler_FindByID_Success(t *testing.T) {
+	customerID := "customer-123"
+	now := time.Now()
+
 	orderDS := &mockOrderDS{
 		findByIDFunc: func(id string) (daos.OrderDAO, error) {
-			return daos.OrderDAO{}, errors.New("not found")
+			return daos.OrderDAO{
+				ID:         "550e8400-e29b-41d4-a716-446655440000",
+				CustomerID: &customerID,
+				Amount:     2000,
+				Status:     daos.OrderStatusDAO{ID: "status-1", Name: "Pending"},
+				Items: []daos.OrderItemDAO{
+					{ID: "item-1", OrderID: "550e8400-e29b-41d4-a716-446655440000", ProductID: "product-1", Quantity: 2, UnitPrice: 1000},
+				},
+				CreatedAt: now,
+			}, nil
 		},
 	}
 	statusDS := &mockOrderStatusDS{}
@@ -576,41 +762,17 @@ func TestOrderHandler_Delete_Error(t *testing.T) {
 	w := httptest.NewRecorder()
 	_, router := gin.CreateTestContext(w)
 
-	router.DELETE("/orders/:id", handler.Delete)
+	router.GET("/orders/:id", handler.FindByID)
 
-	req := httptest.NewRequest("DELETE", "/orders/550e8400-e29b-41d4-a716-446655440000", nil)
+	req := httptest.NewRequest("GET", "/orders/550e8400-e29b-41d4-a716-446655440000", nil)
 	router.ServeHTTP(w, req)
 
-	if w.Code == http.StatusNoContent {
-		t.Log("Delete() returned NoContent despite error")
+	if w.Code != http.StatusOK {
+		t.Errorf("FindByID() status = %v, want %v", w.Code, http.StatusOK)
 	}
 }
 
-func TestOrderHandler_FindAllStatus_Error(t *testing.T) {
-	orderDS := &mockOrderDS{}
-	statusDS := &mockOrderStatusDS{
-		findAllFunc: func() ([]daos.OrderStatusDAO, error) {
-			return nil, errors.New("database error")
-		},
-	}
-	cleanup := setupMocks(orderDS, statusDS)
-	defer cleanup()
-
-	handler := NewOrderHandler()
-
-	w := httptest.NewRecorder()
-	_, router := gin.CreateTestContext(w)
-
-	router.GET("/orders/status", handler.FindAllStatus)
-
-	req := httptest.NewRequest("GET", "/orders/status", nil)
-	router.ServeHTTP(w, req)
-
-	if w.Code == http.StatusOK && w.Body.Len() > 2 {
-		t.Log("FindAllStatus() returned data despite error")
-	}
-}
-func TestOrderHandler_UpdateStatus_Success(t *testing.T) {
+func TestOrderHandler_Update_Success(t *testing.T) {
 	customerID := "customer-123"
 	now := time.Now()
 
@@ -619,10 +781,10 @@ func TestOrderHandler_UpdateStatus_Success(t *testing.T) {
 			return daos.OrderDAO{
 				ID:         "550e8400-e29b-41d4-a716-446655440000",
 				CustomerID: &customerID,
-				Amount:     20.0,
+				Amount:     2000,
 				Status:     daos.OrderStatusDAO{ID: "status-1", Name: "Pending"},
 				Items: []daos.OrderItemDAO{
-					{ID: "item-1", OrderID: "550e8400-e29b-41d4-a716-446655440000", ProductID: "product-1", Quantity: 2, UnitPrice: 10.0},
+					{ID: "item-1", OrderID: "550e8400-e29b-41d4-a716-446655440000", ProductID: "product-1", Quantity: 2, UnitPrice: 1000},
 				},
 				CreatedAt: now,
 			}, nil
@@ -632,8 +794,8 @@ func TestOrderHandler_UpdateStatus_Success(t *testing.T) {
 		},
 	}
 	statusDS := &mockOrderStatusDS{
-		findByNameFunc: func(name string) (daos.OrderStatusDAO, error) {
-			return daos.OrderStatusDAO{ID: "status-2", Name: name}, nil
+		findByIDFunc: func(id string) (daos.OrderStatusDAO, error) {
+			return daos.OrderStatusDAO{ID: "status-2", Name: "Confirmed"}, nil
 		},
 	}
 	cleanup := setupMocks(orderDS, statusDS)
@@ -644,68 +806,91 @@ func TestOrderHandler_UpdateStatus_Success(t *testing.T) {
 	w := httptest.NewRecorder()
 	_, router := gin.CreateTestContext(w)
 
-	router.PUT("/orders/:id/status", handler.UpdateStatus)
+	router.PUT("/orders/:id", handler.Update)
 
-	body := schemas.UpdateOrderStatusSchema{Status: "Em preparação"}
+	body := schemas.UpdateOrderSchema{StatusID: "status-2"}
 	jsonBody, _ := json.Marshal(body)
 
-	req := httptest.NewRequest("PUT", "/orders/550e8400-e29b-41d4-a716-446655440000/status", bytes.NewBuffer(jsonBody))
+	req := httptest.NewRequest("PUT", "/orders/550e8400-e29b-41d4-a716-446655440000", bytes.NewBuffer(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
 	router.ServeHTTP(w, req)
 
 	if w.Code != http.StatusOK {
-		t.Errorf("UpdateStatus() status = %v, want %v", w.Code, http.StatusOK)
-	}
-
-	var response map[string]interface{}
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-	if err != nil {
-		t.Errorf("Failed to unmarshal response: %v", err)
-	}
-
-	if response["message"] != "Order status updated successfully" {
-		t.Errorf("UpdateStatus() message = %v, want 'Order status updated successfully'", response["message"])
+		t.Errorf("Update() status = %v, want %v", w.Code, http.StatusOK)
 	}
 }
 
-func TestOrderHandler_UpdateStatus_InvalidBody(t *testing.T) {
-	orderDS := &mockOrderDS{}
-	statusDS := &mockOrderStatusDS{}
-	cleanup := setupMocks(orderDS, statusDS)
-	defer cleanup()
-
-	handler := NewOrderHandler()
+func TestOrderHandler_StatusChanges_RecordActor(t *testing.T) {
+	tests := []struct {
+		name  string
+		route string
+		path  string
+		body  any
+		setup func(h *OrderHandler) gin.HandlerFunc
+	}{
+		{"update", "/orders/:id", "/orders/550e8400-e29b-41d4-a716-446655440000", schemas.UpdateOrderSchema{StatusID: "status-2"}, func(h *OrderHandler) gin.HandlerFunc { return h.Update }},
+		{"update status", "/orders/:id/status", "/orders/550e8400-e29b-41d4-a716-446655440000/status", schemas.UpdateOrderStatusSchema{Status: "Confirmado"}, func(h *OrderHandler) gin.HandlerFunc { return h.UpdateStatus }},
+	}
 
-	w := httptest.NewRecorder()
-	_, router := gin.CreateTestContext(w)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated daos.OrderDAO
+			orderDS := &mockOrderDS{
+				findByIDFunc: func(id string) (daos.OrderDAO, error) {
+					return daos.OrderDAO{
+						ID:     "550e8400-e29b-41d4-a716-446655440000",
+						Amount: 1000,
+						Status: daos.OrderStatusDAO{ID: "status-1", Name: "Recebido"},
+						Items: []daos.OrderItemDAO{
+							{ID: "item-1", OrderID: "550e8400-e29b-41d4-a716-446655440000", ProductID: "product-1", Quantity: 1, UnitPrice: 1000},
+						},
+						CreatedAt: time.Now(),
+					}, nil
+				},
+				updateFunc: func(order daos.OrderDAO) error {
+					updated = order
+					return nil
+				},
+			}
+			statusDS := &mockOrderStatusDS{
+				findByIDFunc: func(id string) (daos.OrderStatusDAO, error) {
+					return daos.OrderStatusDAO{ID: "status-2", Name: "Confirmado"}, nil
+				},
+				findByNameFunc: func(name string) (daos.OrderStatusDAO, error) {
+					return daos.OrderStatusDAO{ID: "status-2", Name: name}, nil
+				},
+			}
+			cleanup := setupMocks(orderDS, statusDS)
+			defer cleanup()
 
-	router.PUT("/orders/:id/status", handler.UpdateStatus)
+			handler := NewOrderHandler()
 
-	req := httptest.NewRequest("PUT", "/orders/550e8400-e29b-41d4-a716-446655440000/status", bytes.NewBuffer([]byte("invalid json")))
-	req.Header.Set("Content-Type", "application/json")
-	router.ServeHTTP(w, req)
+			w := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(w)
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("UpdateStatus() status = %v, want %v", w.Code, http.StatusBadRequest)
-	}
+			router.Use(withPrincipal(auth.Principal{Subject: "staff-1", Roles: []string{auth.ROLE_STAFF}}))
+			router.PUT(tt.route, tt.setup(handler))
 
-	var response map[string]interface{}
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-	if err != nil {
-		t.Errorf("Failed to unmarshal response: %v", err)
-	}
+			jsonBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("PUT", tt.path, bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
 
-	if response["error"] != "Invalid request body" {
-		t.Errorf("UpdateStatus() error = %v, want 'Invalid request body'", response["error"])
+			if w.Code != http.StatusOK {
+				t.Fatalf("%s status = %v, want %v", tt.name, w.Code, http.StatusOK)
+			}
+			if len(updated.StatusChanges) != 1 {
+				t.Fatalf("%s StatusChanges length = %v, want 1", tt.name, len(updated.StatusChanges))
+			}
+			if actor := updated.StatusChanges[0].Actor; actor == nil || *actor != "staff-1" {
+				t.Errorf("%s actor = %v, want staff-1", tt.name, actor)
+			}
+		})
 	}
 }
 
-func TestOrderHandler_UpdateStatus_OrderNotFound(t *testing.T) {
-	orderDS := &mockOrderDS{
-		findByIDFunc: func(id string) (daos.OrderDAO, error) {
-			return daos.OrderDAO{}, errors.New("order not found")
-		},
-	}
+func TestOrderHandler_Update_InvalidBody(t *testing.T) {
+	orderDS := &mockOrderDS{}
 	statusDS := &mockOrderStatusDS{}
 	cleanup := setupMocks(orderDS, statusDS)
 	defer cleanup()
@@ -715,30 +900,18 @@ func TestOrderHandler_UpdateStatus_OrderNotFound(t *testing.T) {
 	w := httptest.NewRecorder()
 	_, router := gin.CreateTestContext(w)
 
-	// Add the error handler middleware to process errors properly
-	router.Use(func(ctx *gin.Context) {
-		ctx.Next()
-		if len(ctx.Errors) > 0 {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
-			ctx.Abort()
-		}
-	})
-
-	router.PUT("/orders/:id/status", handler.UpdateStatus)
-
-	body := schemas.UpdateOrderStatusSchema{Status: "Em preparação"}
-	jsonBody, _ := json.Marshal(body)
+	router.PUT("/orders/:id", handler.Update)
 
-	req := httptest.NewRequest("PUT", "/orders/non-existent-order/status", bytes.NewBuffer(jsonBody))
+	req := httptest.NewRequest("PUT", "/orders/550e8400-e29b-41d4-a716-446655440000", bytes.NewBuffer([]byte("invalid json")))
 	req.Header.Set("Content-Type", "application/json")
 	router.ServeHTTP(w, req)
 
-	if w.Code != http.StatusInternalServerError {
-		t.Errorf("UpdateStatus() status = %v, want %v", w.Code, http.StatusInternalServerError)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Update() status = %v, want %v", w.Code, http.StatusBadRequest)
 	}
 }
 
-func TestOrderHandler_UpdateStatus_StatusNotFound(t *testing.T) {
+func TestOrderHandler_Delete_Success(t *testing.T) {
 	customerID := "customer-123"
 	now := time.Now()
 
@@ -747,18 +920,688 @@ func TestOrderHandler_UpdateStatus_StatusNotFound(t *testing.T) {
 			return daos.OrderDAO{
 				ID:         "550e8400-e29b-41d4-a716-446655440000",
 				CustomerID: &customerID,
-				Amount:     20.0,
+				Amount:     2000,
 				Status:     daos.OrderStatusDAO{ID: "status-1", Name: "Pending"},
 				Items:      []daos.OrderItemDAO{},
 				CreatedAt:  now,
 			}, nil
 		},
-	}
-	statusDS := &mockOrderStatusDS{
-		findByNameFunc: func(name string) (daos.OrderStatusDAO, error) {
-			return daos.OrderStatusDAO{}, errors.New("status not found")
+		deleteFunc: func(id string) error {
+			return nil
 		},
 	}
+	statusDS := &mockOrderStatusDS{}
+	cleanup := setupMocks(orderDS, statusDS)
+	defer cleanup()
+
+	handler := NewOrderHandler()
+
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+
+	router.DELETE("/orders/:id", handler.Delete)
+
+	req := httptest.NewRequest("DELETE", "/orders/550e8400-e29b-41d4-a716-446655440000", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Delete() status = %v, want %v", w.Code, http.StatusNoContent)
+	}
+}
+
+func TestOrderHandler_FindAllStatus_Success(t *testing.T) {
+	orderDS := &mockOrderDS{}
+	statusDS := &mockOrderStatusDS{
+		findAllFunc: func() ([]daos.OrderStatusDAO, error) {
+			return []daos.OrderStatusDAO{
+				{ID: "status-1", Name: "Pending"},
+				{ID: "status-2", Name: "Confirmed"},
+			}, nil
+		},
+	}
+	cleanup := setupMocks(orderDS, statusDS)
+	defer cleanup()
+
+	handler := NewOrderHandler()
+
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+
+	router.GET("/orders/status", handler.FindAllStatus)
+
+	req := httptest.NewRequest("GET", "/orders/status", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("FindAllStatus() status = %v, want %v", w.Code, http.StatusOK)
+	}
+}
+
+func TestToOrderResponse(t *testing.T) {
+	customerID := "customer-123"
+	now := time.Now()
+
+	dto := dtos.OrderResponseDTO{
+		ID:         "order-1",
+		CustomerID: &customerID,
+		Amount:     "100.00",
+		Status:     dtos.OrderStatusDTO{ID: "status-1", Name: "Pending"},
+		Items: []dtos.OrderItemDTO{
+			{ID: "item-1", ProductID: "product-1", OrderID: "order-1", Quantity: 2, UnitPrice: "50.00",
+				Product: dtos.ProductSnapshotDTO{Name: "X-Burger", Category: "Lanche", ImageURL: "https://cdn.example.com/x-burger.png"}},
+		},
+		CreatedAt: now,
+		UpdatedAt: nil,
+	}
+
+	response := toOrderResponse(dto)
+
+	if response.ID != "order-1" {
+		t.Errorf("toOrderResponse() ID = %v, want order-1", response.ID)
+	}
+	if *response.CustomerID != customerID {
+		t.Errorf("toOrderResponse() CustomerID = %v, want %v", *response.CustomerID, customerID)
+	}
+	if response.Amount != "100.00" {
+		t.Errorf("toOrderResponse() Amount = %v, want 100.0", response.Amount)
+	}
+	if response.Status != "Pending" {
+		t.Errorf("toOrderResponse() Status = %v, want Pending", response.Status)
+	}
+	if len(response.Items) != 1 {
+		t.Fatalf("toOrderResponse() Items length = %v, want 1", len(response.Items))
+	}
+	if response.Items[0].Product.Name != "X-Burger" || response.Items[0].Product.Category != "Lanche" ||
+		response.Items[0].Product.ImageURL != "https://cdn.example.com/x-burger.png" {
+		t.Errorf("toOrderResponse() Items[0].Product = %+v, want X-Burger snapshot", response.Items[0].Product)
+	}
+}
+
+func TestOrderHandler_Create_Error(t *testing.T) {
+	orderDS := &mockOrderDS{}
+	statusDS := &mockOrderStatusDS{
+		findByIDFunc: func(id string) (daos.OrderStatusDAO, error) {
+			return daos.OrderStatusDAO{}, errors.New("status not found")
+		},
+	}
+	cleanup := setupMocks(orderDS, statusDS)
+	defer cleanup()
+
+	handler := NewOrderHandler()
+
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+
+	router.POST("/orders", handler.Create)
+
+	body := schemas.CreateOrderSchema{
+		Items: []schemas.CreateOrderItemSchema{
+			{ProductID: "product-1", Quantity: 2, Price: "10.00"},
+		},
+	}
+	jsonBody, _ := json.Marshal(body)
+
+	req := httptest.NewRequest("POST", "/orders", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code == http.StatusCreated {
+		t.Error("Create() should fail when status not found")
+	}
+}
+
+func TestOrderHandler_FindAll_Error(t *testing.T) {
+	orderDS := &mockOrderDS{
+		findAllFunc: func(filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error) {
+			return nil, errors.New("database error")
+		},
+	}
+	statusDS := &mockOrderStatusDS{}
+	cleanup := setupMocks(orderDS, statusDS)
+	defer cleanup()
+
+	handler := NewOrderHandler()
+
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+
+	router.GET("/orders", handler.FindAll)
+
+	req := httptest.NewRequest("GET", "/orders", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code == http.StatusOK && w.Body.Len() > 2 {
+		t.Log("FindAll() returned data despite error")
+	}
+}
+
+func TestOrderHandler_FindByID_Error(t *testing.T) {
+	orderDS := &mockOrderDS{
+		findByIDFunc: func(id string) (daos.OrderDAO, error) {
+			return daos.OrderDAO{}, errors.New("not found")
+		},
+	}
+	statusDS := &mockOrderStatusDS{}
+	cleanup := setupMocks(orderDS, statusDS)
+	defer cleanup()
+
+	handler := NewOrderHandler()
+
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+
+	router.GET("/orders/:id", handler.FindByID)
+
+	req := httptest.NewRequest("GET", "/orders/550e8400-e29b-41d4-a716-446655440000", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code == http.StatusOK {
+		t.Log("FindByID() returned OK despite error")
+	}
+}
+
+func TestOrderHandler_Update_Error(t *testing.T) {
+	orderDS := &mockOrderDS{
+		findByIDFunc: func(id string) (daos.OrderDAO, error) {
+			return daos.OrderDAO{}, errors.New("not found")
+		},
+	}
+	statusDS := &mockOrderStatusDS{}
+	cleanup := setupMocks(orderDS, statusDS)
+	defer cleanup()
+
+	handler := NewOrderHandler()
+
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+
+	router.PUT("/orders/:id", handler.Update)
+
+	body := schemas.UpdateOrderSchema{StatusID: "status-2"}
+	jsonBody, _ := json.Marshal(body)
+
+	req := httptest.NewRequest("PUT", "/orders/550e8400-e29b-41d4-a716-446655440000", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code == http.StatusOK {
+		t.Log("Update() returned OK despite error")
+	}
+}
+
+func TestOrderHandler_Delete_Error(t *testing.T) {
+	orderDS := &mockOrderDS{
+		findByIDFunc: func(id string) (daos.OrderDAO, error) {
+			return daos.OrderDAO{}, errors.New("not found")
+		},
+	}
+	statusDS := &mockOrderStatusDS{}
+	cleanup := setupMocks(orderDS, statusDS)
+	defer cleanup()
+
+	handler := NewOrderHandler()
+
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+
+	router.DELETE("/orders/:id", handler.Delete)
+
+	req := httptest.NewRequest("DELETE", "/orders/550e8400-e29b-41d4-a716-446655440000", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code == http.StatusNoContent {
+		t.Log("Delete() returned NoContent despite error")
+	}
+}
+
+func TestOrderHandler_FindAllStatus_Error(t *testing.T) {
+	orderDS := &mockOrderDS{}
+	statusDS := &mockOrderStatusDS{
+		findAllFunc: func() ([]daos.OrderStatusDAO, error) {
+			return nil, errors.New("database error")
+		},
+	}
+	cleanup := setupMocks(orderDS, statusDS)
+	defer cleanup()
+
+	handler := NewOrderHandler()
+
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+
+	router.GET("/orders/status", handler.FindAllStatus)
+
+	req := httptest.NewRequest("GET", "/orders/status", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code == http.StatusOK && w.Body.Len() > 2 {
+		t.Log("FindAllStatus() returned data despite error")
+	}
+}
+func TestOrderHandler_UpdateStatus_Success(t *testing.T) {
+	customerID := "customer-123"
+	now := time.Now()
+
+	orderDS := &mockOrderDS{
+		findByIDFunc: func(id string) (daos.OrderDAO, error) {
+			return daos.OrderDAO{
+				ID:         "550e8400-e29b-41d4-a716-446655440000",
+				CustomerID: &customerID,
+				Amount:     2000,
+				Status:     daos.OrderStatusDAO{ID: "status-1", Name: "Confirmado"},
+				Items: []daos.OrderItemDAO{
+					{ID: "item-1", OrderID: "550e8400-e29b-41d4-a716-446655440000", ProductID: "product-1", Quantity: 2, UnitPrice: 1000},
+				},
+				CreatedAt: now,
+			}, nil
+		},
+		updateFunc: func(order daos.OrderDAO) error {
+			return nil
+		},
+	}
+	statusDS := &mockOrderStatusDS{
+		findByNameFunc: func(name string) (daos.OrderStatusDAO, error) {
+			return daos.OrderStatusDAO{ID: "status-2", Name: name}, nil
+		},
+	}
+	cleanup := setupMocks(orderDS, statusDS)
+	defer cleanup()
+
+	handler := NewOrderHandler()
+
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+
+	router.PUT("/orders/:id/status", handler.UpdateStatus)
+
+	body := schemas.UpdateOrderStatusSchema{Status: "Em preparação"}
+	jsonBody, _ := json.Marshal(body)
+
+	req := httptest.NewRequest("PUT", "/orders/550e8400-e29b-41d4-a716-446655440000/status", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("UpdateStatus() status = %v, want %v", w.Code, http.StatusOK)
+	}
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	if err != nil {
+		t.Errorf("Failed to unmarshal response: %v", err)
+	}
+
+	if response["message"] != "Order status updated successfully" {
+		t.Errorf("UpdateStatus() message = %v, want 'Order status updated successfully'", response["message"])
+	}
+}
+
+func TestOrderHandler_UpdateStatus_InvalidBody(t *testing.T) {
+	orderDS := &mockOrderDS{}
+	statusDS := &mockOrderStatusDS{}
+	cleanup := setupMocks(orderDS, statusDS)
+	defer cleanup()
+
+	handler := NewOrderHandler()
+
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+
+	router.PUT("/orders/:id/status", handler.UpdateStatus)
+
+	req := httptest.NewRequest("PUT", "/orders/550e8400-e29b-41d4-a716-446655440000/status", bytes.NewBuffer([]byte("invalid json")))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("UpdateStatus() status = %v, want %v", w.Code, http.StatusBadRequest)
+	}
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	if err != nil {
+		t.Errorf("Failed to unmarshal response: %v", err)
+	}
+
+	if response["code"] != "VALIDATION_FAILED" || response["detail"] != "Request body is not valid JSON" {
+		t.Errorf("UpdateStatus() problem = %v, want VALIDATION_FAILED for invalid JSON", response)
+	}
+	if contentType := w.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "application/problem+json") {
+		t.Errorf("UpdateStatus() Content-Type = %q, want application/problem+json", contentType)
+	}
+}
+
+func TestOrderHandler_UpdateStatus_OrderNotFound(t *testing.T) {
+	orderDS := &mockOrderDS{
+		findByIDFunc: func(id string) (daos.OrderDAO, error) {
+			return daos.OrderDAO{}, errors.New("order not found")
+		},
+	}
+	statusDS := &mockOrderStatusDS{}
+	cleanup := setupMocks(orderDS, statusDS)
+	defer cleanup()
+
+	handler := NewOrderHandler()
+
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+
+	// Add the error handler middleware to process errors properly
+	router.Use(func(ctx *gin.Context) {
+		ctx.Next()
+		if len(ctx.Errors) > 0 {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			ctx.Abort()
+		}
+	})
+
+	router.PUT("/orders/:id/status", handler.UpdateStatus)
+
+	body := schemas.UpdateOrderStatusSchema{Status: "Em preparação"}
+	jsonBody, _ := json.Marshal(body)
+
+	req := httptest.NewRequest("PUT", "/orders/non-existent-order/status", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("UpdateStatus() status = %v, want %v", w.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestOrderHandler_UpdateStatus_StatusNotFound(t *testing.T) {
+	customerID := "customer-123"
+	now := time.Now()
+
+	orderDS := &mockOrderDS{
+		findByIDFunc: func(id string) (daos.OrderDAO, error) {
+			return daos.OrderDAO{
+				ID:         "550e8400-e29b-41d4-a716-446655440000",
+				CustomerID: &customerID,
+				Amount:     2000,
+				Status:     daos.OrderStatusDAO{ID: "status-1", Name: "Pending"},
+				Items:      []daos.OrderItemDAO{},
+				CreatedAt:  now,
+			}, nil
+		},
+	}
+	statusDS := &mockOrderStatusDS{
+		findByNameFunc: func(name string) (daos.OrderStatusDAO, error) {
+			return daos.OrderStatusDAO{}, errors.New("status not found")
+		},
+	}
+	cleanup := setupMocks(orderDS, statusDS)
+	defer cleanup()
+
+	handler := NewOrderHandler()
+
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+
+	// Add the error handler middleware to process errors properly
+	router.Use(func(ctx *gin.Context) {
+		ctx.Next()
+		if len(ctx.Errors) > 0 {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			ctx.Abort()
+		}
+	})
+
+	router.PUT("/orders/:id/status", handler.UpdateStatus)
+
+	body := schemas.UpdateOrderStatusSchema{Status: "Status Inexistente"}
+	jsonBody, _ := json.Marshal(body)
+
+	req := httptest.NewRequest("PUT", "/orders/550e8400-e29b-41d4-a716-446655440000/status", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("UpdateStatus() status = %v, want %v", w.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestOrderHandler_UpdateStatus_UpdateError(t *testing.T) {
+	customerID := "customer-123"
+	now := time.Now()
+
+	orderDS := &mockOrderDS{
+		findByIDFunc: func(id string) (daos.OrderDAO, error) {
+			return daos.OrderDAO{
+				ID:         "550e8400-e29b-41d4-a716-446655440000",
+				CustomerID: &customerID,
+				Amount:     2000,
+				Status:     daos.OrderStatusDAO{ID: "status-1", Name: "Pending"},
+				Items:      []daos.OrderItemDAO{},
+				CreatedAt:  now,
+			}, nil
+		},
+		updateFunc: func(order daos.OrderDAO) error {
+			return errors.New("database update failed")
+		},
+	}
+	statusDS := &mockOrderStatusDS{
+		findByNameFunc: func(name string) (daos.OrderStatusDAO, error) {
+			return daos.OrderStatusDAO{ID: "status-2", Name: name}, nil
+		},
+	}
+	cleanup := setupMocks(orderDS, statusDS)
+	defer cleanup()
+
+	handler := NewOrderHandler()
+
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+
+	// Add the error handler middleware to process errors properly
+	router.Use(func(ctx *gin.Context) {
+		ctx.Next()
+		if len(ctx.Errors) > 0 {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			ctx.Abort()
+		}
+	})
+
+	router.PUT("/orders/:id/status", handler.UpdateStatus)
+
+	body := schemas.UpdateOrderStatusSchema{Status: "Em preparação"}
+	jsonBody, _ := json.Marshal(body)
+
+	req := httptest.NewRequest("PUT", "/orders/550e8400-e29b-41d4-a716-446655440000/status", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("UpdateStatus() status = %v, want %v", w.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestOrderHandler_UpdateStatus_SpecialCharactersInID(t *testing.T) {
+	customerID := "customer-123"
+	now := time.Now()
+
+	orderDS := &mockOrderDS{
+		findByIDFunc: func(id string) (daos.OrderDAO, error) {
+			// Verificar se caracteres especiais foram sanitizados
+			if id == "order_with_newline_and_carriage_return" {
+				return daos.OrderDAO{
+					ID:         id,
+					CustomerID: &customerID,
+					Amount:     2000,
+					Status:     daos.OrderStatusDAO{ID: "status-1", Name: "Pending"},
+					Items:      []daos.OrderItemDAO{},
+					CreatedAt:  now,
+				}, nil
+			}
+			return daos.OrderDAO{}, errors.New("order not found")
+		},
+		updateFunc: func(order daos.OrderDAO) error {
+			return nil
+		},
+	}
+	statusDS := &mockOrderStatusDS{
+		findByNameFunc: func(name string) (daos.OrderStatusDAO, error) {
+			return daos.OrderStatusDAO{ID: "status-2", Name: name}, nil
+		},
+	}
+	cleanup := setupMocks(orderDS, statusDS)
+	defer cleanup()
+
+	handler := NewOrderHandler()
+
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+
+	router.PUT("/orders/:id/status", handler.UpdateStatus)
+
+	body := schemas.UpdateOrderStatusSchema{Status: "Em preparação"}
+	jsonBody, _ := json.Marshal(body)
+
+	// Testar com caracteres especiais no ID que devem ser sanitizados
+	// Usar URL encoding para caracteres especiais
+	req := httptest.NewRequest("PUT", "/orders/order%0Awith%0Dnewline%0Aand%0Dcarriage%0Dreturn/status", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("UpdateStatus() status = %v, want %v", w.Code, http.StatusOK)
+	}
+}
+
+func TestOrderHandler_UpdateStatus_DifferentStatuses(t *testing.T) {
+	testCases := []struct {
+		name   string
+		status string
+	}{
+		{
+			name:   "Em preparação status",
+			status: "Em preparação",
+		},
+		{
+			name:   "Pronto status",
+			status: "Pronto",
+		},
+		{
+			name:   "Finalizado status",
+			status: "Finalizado",
+		},
+		{
+			name:   "Cancelado status",
+			status: "Cancelado",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			customerID := "customer-123"
+			now := time.Now()
+
+			orderDS := &mockOrderDS{
+				findByIDFunc: func(id string) (daos.OrderDAO, error) {
+					return daos.OrderDAO{
+						ID:         "550e8400-e29b-41d4-a716-446655440000",
+						CustomerID: &customerID,
+						Amount:     2000,
+						Status:     daos.OrderStatusDAO{ID: "status-1", Name: "Pending"},
+						Items:      []daos.OrderItemDAO{},
+						CreatedAt:  now,
+					}, nil
+				},
+				updateFunc: func(order daos.OrderDAO) error {
+					return nil
+				},
+			}
+			statusDS := &mockOrderStatusDS{
+				findByNameFunc: func(name string) (daos.OrderStatusDAO, error) {
+					return daos.OrderStatusDAO{ID: "status-2", Name: name}, nil
+				},
+			}
+			cleanup := setupMocks(orderDS, statusDS)
+			defer cleanup()
+
+			handler := NewOrderHandler()
+
+			w := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(w)
+
+			router.PUT("/orders/:id/status", handler.UpdateStatus)
+
+			body := schemas.UpdateOrderStatusSchema{Status: tc.status}
+			jsonBody, _ := json.Marshal(body)
+
+			req := httptest.NewRequest("PUT", "/orders/550e8400-e29b-41d4-a716-446655440000/status", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Errorf("UpdateStatus() for %s status = %v, want %v", tc.status, w.Code, http.StatusOK)
+			}
+		})
+	}
+}
+func TestOrderHandler_FindStatusHistory_Success(t *testing.T) {
+	orderID := "550e8400-e29b-41d4-a716-446655440000"
+	now := time.Now()
+
+	orderDS := &mockOrderDS{
+		findByIDFunc: func(id string) (daos.OrderDAO, error) {
+			return daos.OrderDAO{
+				ID:        orderID,
+				Amount:    2000,
+				Status:    daos.OrderStatusDAO{ID: "status-2", Name: "Confirmado"},
+				CreatedAt: now,
+			}, nil
+		},
+		findStatusHistoryFunc: func(id string) ([]daos.OrderStatusHistoryDAO, error) {
+			return []daos.OrderStatusHistoryDAO{
+				{ID: "change-1", OrderID: id, NewStatus: daos.OrderStatusDAO{ID: "status-1", Name: "Recebido"}, Source: "rest", ChangedAt: now},
+				{ID: "change-2", OrderID: id, PreviousStatus: &daos.OrderStatusDAO{ID: "status-1", Name: "Recebido"}, NewStatus: daos.OrderStatusDAO{ID: "status-2", Name: "Confirmado"}, Source: "payment_consumer", ChangedAt: now},
+			}, nil
+		},
+	}
+	statusDS := &mockOrderStatusDS{}
+	cleanup := setupMocks(orderDS, statusDS)
+	defer cleanup()
+
+	handler := NewOrderHandler()
+
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+
+	router.GET("/orders/:id/history", handler.FindStatusHistory)
+
+	req := httptest.NewRequest("GET", "/orders/"+orderID+"/history", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("FindStatusHistory() status = %v, want %v", w.Code, http.StatusOK)
+	}
+
+	var response []schemas.OrderStatusHistoryResponseSchema
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("FindStatusHistory() invalid body: %v", err)
+	}
+	if len(response) != 2 {
+		t.Fatalf("FindStatusHistory() len = %v, want 2", len(response))
+	}
+	if response[0].PreviousStatus != nil {
+		t.Errorf("FindStatusHistory() first previous_status = %v, want nil", *response[0].PreviousStatus)
+	}
+	if *response[1].PreviousStatus != "Recebido" || response[1].Status != "Confirmado" {
+		t.Errorf("FindStatusHistory() second entry = %+v", response[1])
+	}
+	if response[1].Source != "payment_consumer" {
+		t.Errorf("FindStatusHistory() source = %v, want payment_consumer", response[1].Source)
+	}
+}
+
+func TestOrderHandler_FindStatusHistory_OrderNotFound(t *testing.T) {
+	orderDS := &mockOrderDS{
+		findByIDFunc: func(id string) (daos.OrderDAO, error) {
+			return daos.OrderDAO{}, errors.New("not found")
+		},
+	}
+	statusDS := &mockOrderStatusDS{}
 	cleanup := setupMocks(orderDS, statusDS)
 	defer cleanup()
 
@@ -767,110 +1610,359 @@ func TestOrderHandler_UpdateStatus_StatusNotFound(t *testing.T) {
 	w := httptest.NewRecorder()
 	_, router := gin.CreateTestContext(w)
 
-	// Add the error handler middleware to process errors properly
 	router.Use(func(ctx *gin.Context) {
 		ctx.Next()
 		if len(ctx.Errors) > 0 {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			ctx.JSON(http.StatusNotFound, gin.H{"error": ctx.Errors.Last().Error()})
 			ctx.Abort()
 		}
 	})
 
-	router.PUT("/orders/:id/status", handler.UpdateStatus)
+	router.GET("/orders/:id/history", handler.FindStatusHistory)
 
-	body := schemas.UpdateOrderStatusSchema{Status: "Status Inexistente"}
-	jsonBody, _ := json.Marshal(body)
+	req := httptest.NewRequest("GET", "/orders/550e8400-e29b-41d4-a716-446655440000/history", nil)
+	router.ServeHTTP(w, req)
 
-	req := httptest.NewRequest("PUT", "/orders/550e8400-e29b-41d4-a716-446655440000/status", bytes.NewBuffer(jsonBody))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("FindStatusHistory() status = %v, want %v", w.Code, http.StatusNotFound)
+	}
+}
+
+func newCancelledStatusDS() *mockOrderStatusDS {
+	return &mockOrderStatusDS{
+		findByNameFunc: func(name string) (daos.OrderStatusDAO, error) {
+			return daos.OrderStatusDAO{ID: "status-cancelled", Name: name}, nil
+		},
+	}
+}
+
+func TestOrderHandler_Cancel_Success(t *testing.T) {
+	var saved daos.OrderDAO
+	orderDS := newReceivedOrderDS()
+	orderDS.updateFunc = func(order daos.OrderDAO) error {
+		saved = order
+		return nil
+	}
+	cleanup := setupMocks(orderDS, newCancelledStatusDS())
+	defer cleanup()
+
+	handler := NewOrderHandler()
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+	router.Use(middlewares.ErrorHandlerMiddleware())
+	router.POST("/orders/:id/cancel", handler.Cancel)
+
+	req := httptest.NewRequest("POST", "/orders/550e8400-e29b-41d4-a716-446655440000/cancel", strings.NewReader(`{"reason":"Cliente desistiu"}`))
 	req.Header.Set("Content-Type", "application/json")
 	router.ServeHTTP(w, req)
 
-	if w.Code != http.StatusInternalServerError {
-		t.Errorf("UpdateStatus() status = %v, want %v", w.Code, http.StatusInternalServerError)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Cancel() status = %v, want %v: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+
+	var response schemas.OrderResponseSchema
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Status != "Cancelado" {
+		t.Errorf("Cancel() status = %v, want Cancelado", response.Status)
+	}
+	if response.CancellationReason == nil || *response.CancellationReason != "Cliente desistiu" {
+		t.Errorf("Cancel() cancellation_reason = %v, want Cliente desistiu", response.CancellationReason)
+	}
+	if response.CancelledAt == nil {
+		t.Error("Cancel() cancelled_at should be set")
+	}
+	if saved.CancellationReason == nil || saved.Status.Name != "Cancelado" {
+		t.Errorf("Cancel() saved order = %+v, want cancelled with reason", saved)
+	}
+}
+
+func TestOrderHandler_Cancel_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		status   string
+		expected int
+	}{
+		{"missing reason", `{}`, "Recebido", http.StatusBadRequest},
+		{"reason too long", `{"reason":"` + strings.Repeat("a", 256) + `"}`, "Recebido", http.StatusBadRequest},
+		{"ready order", `{"reason":"Cliente desistiu"}`, "Pronto", http.StatusConflict},
+		{"already cancelled", `{"reason":"Cliente desistiu"}`, "Cancelado", http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orderDS := newReceivedOrderDS()
+			findByID := orderDS.findByIDFunc
+			orderDS.findByIDFunc = func(id string) (daos.OrderDAO, error) {
+				order, err := findByID(id)
+				order.Status = daos.OrderStatusDAO{ID: "status-current", Name: tt.status}
+				return order, err
+			}
+			cleanup := setupMocks(orderDS, newCancelledStatusDS())
+			defer cleanup()
+
+			handler := NewOrderHandler()
+			w := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(w)
+			router.Use(middlewares.ErrorHandlerMiddleware())
+			router.POST("/orders/:id/cancel", handler.Cancel)
+
+			req := httptest.NewRequest("POST", "/orders/550e8400-e29b-41d4-a716-446655440000/cancel", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expected {
+				t.Errorf("Cancel() status = %v, want %v: %s", w.Code, tt.expected, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestOrderHandler_Restore(t *testing.T) {
+	tests := []struct {
+		name       string
+		restoreErr error
+		expected   int
+	}{
+		{"restored", nil, http.StatusOK},
+		{"not deleted", &exceptions.OrderNotFoundException{Message: "Deleted order not found"}, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orderDS := newReceivedOrderDS()
+			orderDS.restoreFunc = func(id string) error {
+				return tt.restoreErr
+			}
+			cleanup := setupMocks(orderDS, &mockOrderStatusDS{})
+			defer cleanup()
+
+			handler := NewOrderHandler()
+			w := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(w)
+			router.Use(middlewares.ErrorHandlerMiddleware())
+			router.POST("/admin/orders/:id/restore", handler.Restore)
+
+			req := httptest.NewRequest("POST", "/admin/orders/550e8400-e29b-41d4-a716-446655440000/restore", nil)
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expected {
+				t.Errorf("Restore() status = %v, want %v: %s", w.Code, tt.expected, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestOrderHandler_FindByPickupCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		expected int
+	}{
+		{"found", "a042", http.StatusOK},
+		{"invalid code", "A0420", http.StatusBadRequest},
+		{"not found", "B001", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orderDS := newReceivedOrderDS()
+			orderDS.findByPickupCodeFunc = func(day string, code string) (daos.OrderDAO, error) {
+				if code != "A042" {
+					return daos.OrderDAO{}, &exceptions.OrderNotFoundException{}
+				}
+				order, _ := orderDS.findByIDFunc("550e8400-e29b-41d4-a716-446655440000")
+				order.PickupDate, order.PickupCode = &day, &code
+				return order, nil
+			}
+			cleanup := setupMocks(orderDS, &mockOrderStatusDS{})
+			defer cleanup()
+
+			handler := NewOrderHandler()
+			w := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(w)
+			router.Use(middlewares.ErrorHandlerMiddleware())
+			router.GET("/orders/by-code/:code", handler.FindByPickupCode)
+			router.GET("/orders/:id", handler.FindByID)
+
+			req := httptest.NewRequest("GET", "/orders/by-code/"+tt.code, nil)
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expected {
+				t.Fatalf("FindByPickupCode() status = %v, want %v: %s", w.Code, tt.expected, w.Body.String())
+			}
+			if tt.expected == http.StatusOK {
+				var response schemas.OrderResponseSchema
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				if response.PickupCode != "A042" {
+					t.Errorf("FindByPickupCode() pickup_code = %q, want A042", response.PickupCode)
+				}
+			}
+		})
+	}
+}
+
+func TestOrderHandler_Create_CustomerName(t *testing.T) {
+	tests := []struct {
+		name         string
+		customerName string
+		expected     int
+	}{
+		{"with name", "Maria", http.StatusCreated},
+		{"name too long", strings.Repeat("a", 51), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created daos.OrderDAO
+			orderDS := &mockOrderDS{
+				createFunc: func(order daos.OrderDAO) error {
+					created = order
+					return nil
+				},
+			}
+			statusDS := &mockOrderStatusDS{
+				findByIDFunc: func(id string) (daos.OrderStatusDAO, error) {
+					return daos.OrderStatusDAO{ID: "status-1", Name: "Recebido"}, nil
+				},
+			}
+			cleanup := setupMocks(orderDS, statusDS)
+			defer cleanup()
+
+			handler := NewOrderHandler()
+			w := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(w)
+			router.POST("/orders", handler.Create)
+
+			jsonBody, _ := json.Marshal(schemas.CreateOrderSchema{
+				CustomerName: tt.customerName,
+				Items:        []schemas.CreateOrderItemSchema{{ProductID: "product-1", Quantity: 1}},
+			})
+			req := httptest.NewRequest("POST", "/orders", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expected {
+				t.Fatalf("Create() status = %v, want %v: %s", w.Code, tt.expected, w.Body.String())
+			}
+			if tt.expected != http.StatusCreated {
+				return
+			}
+			if created.CustomerName == nil || *created.CustomerName != "Maria" {
+				t.Errorf("Create() saved CustomerName = %v, want Maria", created.CustomerName)
+			}
+			var response schemas.OrderResponseSchema
+			_ = json.Unmarshal(w.Body.Bytes(), &response)
+			if response.CustomerName != "Maria" {
+				t.Errorf("Create() customer_name = %q, want Maria", response.CustomerName)
+			}
+		})
 	}
 }
 
-func TestOrderHandler_UpdateStatus_UpdateError(t *testing.T) {
-	customerID := "customer-123"
-	now := time.Now()
-
+func TestOrderHandler_FindBoard(t *testing.T) {
+	var createdFrom time.Time
+	code, name := "A003", "Maria"
 	orderDS := &mockOrderDS{
-		findByIDFunc: func(id string) (daos.OrderDAO, error) {
-			return daos.OrderDAO{
-				ID:         "550e8400-e29b-41d4-a716-446655440000",
-				CustomerID: &customerID,
-				Amount:     20.0,
-				Status:     daos.OrderStatusDAO{ID: "status-1", Name: "Pending"},
-				Items:      []daos.OrderItemDAO{},
-				CreatedAt:  now,
+		findBoardFunc: func(statusIDs []string, from time.Time) ([]daos.OrderBoardEntryDAO, error) {
+			createdFrom = from
+			return []daos.OrderBoardEntryDAO{
+				{ID: "order-1", PickupCode: &code, CustomerName: &name, StatusID: "status-4", StatusName: "Pronto", CreatedAt: time.Now().Add(-3 * time.Minute)},
 			}, nil
 		},
-		updateFunc: func(order daos.OrderDAO) error {
-			return errors.New("database update failed")
-		},
 	}
 	statusDS := &mockOrderStatusDS{
-		findByNameFunc: func(name string) (daos.OrderStatusDAO, error) {
-			return daos.OrderStatusDAO{ID: "status-2", Name: name}, nil
+		findAllFunc: func() ([]daos.OrderStatusDAO, error) {
+			return []daos.OrderStatusDAO{
+				{ID: "status-1", Name: "Recebido"},
+				{ID: "status-3", Name: "Em preparação"},
+				{ID: "status-4", Name: "Pronto"},
+				{ID: "status-5", Name: "Entregue"},
+			}, nil
 		},
 	}
 	cleanup := setupMocks(orderDS, statusDS)
 	defer cleanup()
 
 	handler := NewOrderHandler()
-
 	w := httptest.NewRecorder()
 	_, router := gin.CreateTestContext(w)
+	router.Use(middlewares.ErrorHandlerMiddleware())
+	router.GET("/orders/board", handler.FindBoard)
+	router.GET("/orders/:id", handler.FindByID)
 
-	// Add the error handler middleware to process errors properly
-	router.Use(func(ctx *gin.Context) {
-		ctx.Next()
-		if len(ctx.Errors) > 0 {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
-			ctx.Abort()
-		}
-	})
+	req := httptest.NewRequest("GET", "/orders/board?max_age_minutes=45", nil)
+	router.ServeHTTP(w, req)
 
-	router.PUT("/orders/:id/status", handler.UpdateStatus)
+	if w.Code != http.StatusOK {
+		t.Fatalf("FindBoard() status = %v, want %v: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	if wait := time.Since(createdFrom); wait < 45*time.Minute || wait > 46*time.Minute {
+		t.Errorf("FindBoard() created_from = %v ago, want 45m", wait)
+	}
 
-	body := schemas.UpdateOrderStatusSchema{Status: "Em preparação"}
-	jsonBody, _ := json.Marshal(body)
+	var response schemas.OrderBoardResponseSchema
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	var statuses []string
+	for _, group := range response.Groups {
+		statuses = append(statuses, group.Status)
+	}
+	if strings.Join(statuses, ",") != "Pronto,Em preparação,Recebido" {
+		t.Errorf("FindBoard() groups = %v, want Pronto, Em preparação, Recebido", statuses)
+	}
+	entry := response.Groups[0].Orders[0]
+	if entry.PickupCode != "A003" || entry.CustomerName != "Maria" || entry.WaitSeconds < 170 {
+		t.Errorf("FindBoard() entry = %+v, want A003/Maria waiting 3m", entry)
+	}
+	if response.Groups[1].Orders == nil {
+		t.Error("FindBoard() empty groups should have an empty orders list")
+	}
+}
 
-	req := httptest.NewRequest("PUT", "/orders/550e8400-e29b-41d4-a716-446655440000/status", bytes.NewBuffer(jsonBody))
-	req.Header.Set("Content-Type", "application/json")
-	router.ServeHTTP(w, req)
+func TestOrderHandler_FindBoard_InvalidMaxAge(t *testing.T) {
+	cleanup := setupMocks(&mockOrderDS{}, &mockOrderStatusDS{})
+	defer cleanup()
 
-	if w.Code != http.StatusInternalServerError {
-		t.Errorf("UpdateStatus() status = %v, want %v", w.Code, http.StatusInternalServerError)
+	handler := NewOrderHandler()
+	for _, maxAge := range []string{"0", "-5", "abc"} {
+		w := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(w)
+		router.GET("/orders/board", handler.FindBoard)
+
+		req := httptest.NewRequest("GET", "/orders/board?max_age_minutes="+maxAge, nil)
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("FindBoard(%s) status = %v, want %v", maxAge, w.Code, http.StatusBadRequest)
+		}
 	}
 }
 
-func TestOrderHandler_UpdateStatus_SpecialCharactersInID(t *testing.T) {
-	customerID := "customer-123"
-	now := time.Now()
+// Simula o AuthMiddleware para o chamador informado
+func withPrincipal(principal auth.Principal) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set(middlewares.PRINCIPAL_CONTEXT_KEY, principal)
+		ctx.Next()
+	}
+}
 
+func TestOrderHandler_Create_CustomerCreatesForThemselves(t *testing.T) {
+	var created daos.OrderDAO
 	orderDS := &mockOrderDS{
-		findByIDFunc: func(id string) (daos.OrderDAO, error) {
-			// Verificar se caracteres especiais foram sanitizados
-			if id == "order_with_newline_and_carriage_return" {
-				return daos.OrderDAO{
-					ID:         id,
-					CustomerID: &customerID,
-					Amount:     20.0,
-					Status:     daos.OrderStatusDAO{ID: "status-1", Name: "Pending"},
-					Items:      []daos.OrderItemDAO{},
-					CreatedAt:  now,
-				}, nil
-			}
-			return daos.OrderDAO{}, errors.New("order not found")
-		},
-		updateFunc: func(order daos.OrderDAO) error {
+		createFunc: func(order daos.OrderDAO) error {
+			created = order
 			return nil
 		},
 	}
 	statusDS := &mockOrderStatusDS{
-		findByNameFunc: func(name string) (daos.OrderStatusDAO, error) {
-			return daos.OrderStatusDAO{ID: "status-2", Name: name}, nil
+		findByIDFunc: func(id string) (daos.OrderStatusDAO, error) {
+			return daos.OrderStatusDAO{ID: "status-1", Name: "Pending"}, nil
 		},
 	}
 	cleanup := setupMocks(orderDS, statusDS)
@@ -881,71 +1973,104 @@ func TestOrderHandler_UpdateStatus_SpecialCharactersInID(t *testing.T) {
 	w := httptest.NewRecorder()
 	_, router := gin.CreateTestContext(w)
 
-	router.PUT("/orders/:id/status", handler.UpdateStatus)
+	router.Use(withPrincipal(auth.Principal{Subject: "customer-123", Roles: []string{auth.ROLE_CUSTOMER}}))
+	router.POST("/orders", handler.Create)
 
-	body := schemas.UpdateOrderStatusSchema{Status: "Em preparação"}
+	otherCustomer := "customer-456"
+	body := schemas.CreateOrderSchema{
+		CustomerID: &otherCustomer,
+		Items: []schemas.CreateOrderItemSchema{
+			{ProductID: "product-1", Quantity: 1, Price: "10.00"},
+		},
+	}
 	jsonBody, _ := json.Marshal(body)
 
-	// Testar com caracteres especiais no ID que devem ser sanitizados
-	// Usar URL encoding para caracteres especiais
-	req := httptest.NewRequest("PUT", "/orders/order%0Awith%0Dnewline%0Aand%0Dcarriage%0Dreturn/status", bytes.NewBuffer(jsonBody))
+	req := httptest.NewRequest("POST", "/orders", bytes.NewBuffer(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
 	router.ServeHTTP(w, req)
 
-	if w.Code != http.StatusOK {
-		t.Errorf("UpdateStatus() status = %v, want %v", w.Code, http.StatusOK)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Create() status = %v, want %v", w.Code, http.StatusCreated)
+	}
+	if created.CustomerID == nil || *created.CustomerID != "customer-123" {
+		t.Errorf("Create() customer = %v, want customer-123", created.CustomerID)
 	}
 }
 
-func TestOrderHandler_UpdateStatus_DifferentStatuses(t *testing.T) {
-	testCases := []struct {
-		name   string
-		status string
+func TestOrderHandler_FindAll_CustomerSeesOnlyOwnOrders(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal auth.Principal
+		want      string
 	}{
-		{
-			name:   "Em preparação status",
-			status: "Em preparação",
-		},
-		{
-			name:   "Pronto status",
-			status: "Pronto",
-		},
-		{
-			name:   "Finalizado status",
-			status: "Finalizado",
-		},
-		{
-			name:   "Cancelado status",
-			status: "Cancelado",
-		},
+		{"customer", auth.Principal{Subject: "customer-123", Roles: []string{auth.ROLE_CUSTOMER}}, "customer-123"},
+		{"staff", auth.Principal{Subject: "staff-1", Roles: []string{auth.ROLE_STAFF}}, "customer-456"},
 	}
 
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			customerID := "customer-123"
-			now := time.Now()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received dtos.OrderFilterDTO
+			orderDS := &mockOrderDS{
+				findAllFunc: func(filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error) {
+					received = filter
+					return []daos.OrderDAO{}, nil
+				},
+			}
+			cleanup := setupMocks(orderDS, &mockOrderStatusDS{})
+			defer cleanup()
+
+			handler := NewOrderHandler()
+
+			w := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(w)
+
+			router.Use(withPrincipal(tt.principal))
+			router.GET("/orders", handler.FindAll)
+
+			req := httptest.NewRequest("GET", "/orders?customer_id=customer-456", nil)
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("FindAll() status = %v, want %v", w.Code, http.StatusOK)
+			}
+			if received.CustomerID == nil || *received.CustomerID != tt.want {
+				t.Errorf("FindAll() customer filter = %v, want %s", received.CustomerID, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderHandler_FindByID_CustomerOwnership(t *testing.T) {
+	ownerID := "customer-123"
+	tests := []struct {
+		name       string
+		principal  auth.Principal
+		customerID *string
+		wantStatus int
+	}{
+		{"owner", auth.Principal{Subject: "customer-123", Roles: []string{auth.ROLE_CUSTOMER}}, &ownerID, http.StatusOK},
+		{"other customer", auth.Principal{Subject: "customer-456", Roles: []string{auth.ROLE_CUSTOMER}}, &ownerID, http.StatusNotFound},
+		{"anonymous order", auth.Principal{Subject: "customer-456", Roles: []string{auth.ROLE_CUSTOMER}}, nil, http.StatusNotFound},
+		{"staff", auth.Principal{Subject: "staff-1", Roles: []string{auth.ROLE_STAFF}}, &ownerID, http.StatusOK},
+	}
 
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
 			orderDS := &mockOrderDS{
 				findByIDFunc: func(id string) (daos.OrderDAO, error) {
 					return daos.OrderDAO{
-						ID:         "550e8400-e29b-41d4-a716-446655440000",
-						CustomerID: &customerID,
-						Amount:     20.0,
+						ID:         id,
+						CustomerID: tt.customerID,
+						Amount:     1000,
 						Status:     daos.OrderStatusDAO{ID: "status-1", Name: "Pending"},
-						Items:      []daos.OrderItemDAO{},
-						CreatedAt:  now,
+						Items: []daos.OrderItemDAO{
+							{ID: "item-1", OrderID: id, ProductID: "product-1", Quantity: 1, UnitPrice: 1000},
+						},
+						CreatedAt: time.Now(),
 					}, nil
 				},
-				updateFunc: func(order daos.OrderDAO) error {
-					return nil
-				},
-			}
-			statusDS := &mockOrderStatusDS{
-				findByNameFunc: func(name string) (daos.OrderStatusDAO, error) {
-					return daos.OrderStatusDAO{ID: "status-2", Name: name}, nil
-				},
 			}
-			cleanup := setupMocks(orderDS, statusDS)
+			cleanup := setupMocks(orderDS, &mockOrderStatusDS{})
 			defer cleanup()
 
 			handler := NewOrderHandler()
@@ -953,18 +2078,213 @@ func TestOrderHandler_UpdateStatus_DifferentStatuses(t *testing.T) {
 			w := httptest.NewRecorder()
 			_, router := gin.CreateTestContext(w)
 
-			router.PUT("/orders/:id/status", handler.UpdateStatus)
+			router.Use(middlewares.ErrorHandlerMiddleware())
+			router.Use(withPrincipal(tt.principal))
+			router.GET("/orders/:id", handler.FindByID)
 
-			body := schemas.UpdateOrderStatusSchema{Status: tc.status}
-			jsonBody, _ := json.Marshal(body)
+			req := httptest.NewRequest("GET", "/orders/550e8400-e29b-41d4-a716-446655440000", nil)
+			router.ServeHTTP(w, req)
 
-			req := httptest.NewRequest("PUT", "/orders/550e8400-e29b-41d4-a716-446655440000/status", bytes.NewBuffer(jsonBody))
+			if w.Code != tt.wantStatus {
+				t.Errorf("FindByID() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestOrderHandler_ApplyCoupon_OtherCustomerOrder(t *testing.T) {
+	ownerID := "customer-123"
+	applied := false
+	orderDS := &mockOrderDS{
+		findByIDFunc: func(id string) (daos.OrderDAO, error) {
+			return daos.OrderDAO{
+				ID:         id,
+				CustomerID: &ownerID,
+				Amount:     1000,
+				Status:     daos.OrderStatusDAO{ID: "status-1", Name: "Pending"},
+				CreatedAt:  time.Now(),
+			}, nil
+		},
+		applyCouponFunc: func(order daos.OrderDAO) error {
+			applied = true
+			return nil
+		},
+	}
+	cleanup := setupMocks(orderDS, &mockOrderStatusDS{})
+	defer cleanup()
+
+	handler := NewOrderHandler()
+
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+
+	router.Use(middlewares.ErrorHandlerMiddleware())
+	router.Use(withPrincipal(auth.Principal{Subject: "customer-456", Roles: []string{auth.ROLE_CUSTOMER}}))
+	router.POST("/orders/:id/coupons", handler.ApplyCoupon)
+
+	req := httptest.NewRequest("POST", "/orders/550e8400-e29b-41d4-a716-446655440000/coupons", strings.NewReader(`{"code":"PROMO10"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("ApplyCoupon() status = %v, want %v", w.Code, http.StatusNotFound)
+	}
+	if applied {
+		t.Error("ApplyCoupon() applied a coupon to another customer's order")
+	}
+}
+
+// Guarda o pedido criado pelo handler, como o banco faria
+func newGuestOrderDS() *mockOrderDS {
+	orderDS := &mockOrderDS{}
+	var saved daos.OrderDAO
+	orderDS.createFunc = func(order daos.OrderDAO) error {
+		saved = order
+		return nil
+	}
+	orderDS.findByIDFunc = func(id string) (daos.OrderDAO, error) {
+		if id != saved.ID {
+			return daos.OrderDAO{}, &exceptions.OrderNotFoundException{}
+		}
+		return saved, nil
+	}
+	orderDS.reissueGuestTokenFunc = func(orderID string, guestTokenHash string) error {
+		if orderID != saved.ID || saved.CustomerID != nil {
+			return &exceptions.OrderAlreadyClaimedException{}
+		}
+		saved.GuestTokenHash = &guestTokenHash
+		return nil
+	}
+	return orderDS
+}
+
+func createGuestOrder(t *testing.T, router *gin.Engine) schemas.OrderResponseSchema {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/orders", strings.NewReader(`{"items":[{"product_id":"product-1","quantity":1}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Create() status = %v, want %v: %s", w.Code, http.StatusCreated, w.Body.String())
+	}
+	var created schemas.OrderResponseSchema
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return created
+}
+
+func TestOrderHandler_FindGuestOrder(t *testing.T) {
+	statusDS := &mockOrderStatusDS{
+		findByIDFunc: func(id string) (daos.OrderStatusDAO, error) {
+			return daos.OrderStatusDAO{ID: id, Name: "Recebido"}, nil
+		},
+	}
+	cleanup := setupMocks(newGuestOrderDS(), statusDS)
+	defer cleanup()
+
+	handler := NewOrderHandler()
+	_, router := gin.CreateTestContext(httptest.NewRecorder())
+	router.Use(middlewares.ErrorHandlerMiddleware())
+	router.POST("/orders", handler.Create)
+	router.GET("/guest/orders/:id", handler.FindGuestOrder)
+
+	created := createGuestOrder(t, router)
+	if created.GuestToken == "" {
+		t.Fatal("Create() did not return a guest token for an order without customer")
+	}
+
+	tests := []struct {
+		name     string
+		token    string
+		expected int
+	}{
+		{"valid token", created.GuestToken, http.StatusOK},
+		{"wrong token", "wrong-token", http.StatusNotFound},
+		{"without token", "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/guest/orders/"+created.ID, nil)
+			if tt.token != "" {
+				req.Header.Set(GUEST_TOKEN_HEADER, tt.token)
+			}
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expected {
+				t.Fatalf("FindGuestOrder() status = %v, want %v: %s", w.Code, tt.expected, w.Body.String())
+			}
+			if tt.expected == http.StatusOK && strings.Contains(w.Body.String(), "guest_token") {
+				t.Error("FindGuestOrder() returned the guest token again")
+			}
+		})
+	}
+}
+
+func TestOrderHandler_Claim(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		claimErr error
+		expected int
+	}{
+		{"valid token", `{"guest_token":"%s"}`, nil, http.StatusOK},
+		{"wrong token", `{"guest_token":"wrong-token"}`, nil, http.StatusNotFound},
+		{"without token", `{}`, nil, http.StatusBadRequest},
+		{"claimed concurrently", `{"guest_token":"%s"}`, &exceptions.OrderAlreadyClaimedException{}, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var claimedBy string
+			orderDS := newGuestOrderDS()
+			orderDS.claimFunc = func(orderID string, customerID string, guestTokenHash string) error {
+				claimedBy = customerID
+				return tt.claimErr
+			}
+			statusDS := &mockOrderStatusDS{
+				findByIDFunc: func(id string) (daos.OrderStatusDAO, error) {
+					return daos.OrderStatusDAO{ID: id, Name: "Recebido"}, nil
+				},
+			}
+			cleanup := setupMocks(orderDS, statusDS)
+			defer cleanup()
+
+			handler := NewOrderHandler()
+			_, router := gin.CreateTestContext(httptest.NewRecorder())
+			router.Use(middlewares.ErrorHandlerMiddleware())
+			router.POST("/orders", handler.Create)
+			customer := router.Group("", withPrincipal(auth.Principal{Subject: "customer-123", Roles: []string{auth.ROLE_CUSTOMER}}))
+			customer.POST("/orders/:id/claim", handler.Claim)
+
+			created := createGuestOrder(t, router)
+
+			w := httptest.NewRecorder()
+			body := strings.ReplaceAll(tt.body, "%s", created.GuestToken)
+			req := httptest.NewRequest("POST", "/orders/"+created.ID+"/claim", strings.NewReader(body))
 			req.Header.Set("Content-Type", "application/json")
 			router.ServeHTTP(w, req)
 
-			if w.Code != http.StatusOK {
-				t.Errorf("UpdateStatus() for %s status = %v, want %v", tc.status, w.Code, http.StatusOK)
+			if w.Code != tt.expected {
+				t.Fatalf("Claim() status = %v, want %v: %s", w.Code, tt.expected, w.Body.String())
+			}
+			if tt.expected != http.StatusOK {
+				return
+			}
+			if claimedBy != "customer-123" {
+				t.Errorf("Claim() customer = %q, want customer-123", claimedBy)
+			}
+			var response schemas.OrderResponseSchema
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if response.CustomerID == nil || *response.CustomerID != "customer-123" {
+				t.Errorf("Claim() customer_id = %v, want customer-123", response.CustomerID)
 			}
 		})
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,25 @@
+package http_errors
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"microservice/utils/correlation"
+)
+
+const CORRELATION_ID_CONTEXT_KEY = "correlation_id"
+
+// X-Request-ID do cliente quando bem formado, senão um UUID novo
+func CorrelationID(ctx *gin.Context) string {
+	if id := ctx.GetString(CORRELATION_ID_CONTEXT_KEY); id != "" {
+		return id
+	}
+
+	received := ""
+	if ctx.Request != nil {
+		received = ctx.GetHeader(correlation.REQUEST_ID_HEADER)
+	}
+	id := correlation.Resolve(received)
+	ctx.Set(CORRELATION_ID_CONTEXT_KEY, id)
+	ctx.Header(correlation.REQUEST_ID_HEADER, id)
+	return id
+}
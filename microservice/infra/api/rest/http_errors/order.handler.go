@@ -1,35 +1,94 @@
 package http_errors
 
 import (
-	"net/http"
+	"errors"
+	"log"
 
 	"github.com/gin-gonic/gin"
 
 	"microservice/internal/domain/exceptions"
+	"microservice/utils/correlation"
 )
 
+// O detalhe de erros inesperados nunca expõe a causa; ela fica só no log
+const INTERNAL_ERROR_DETAIL = "An unexpected error occurred. Include the correlation ID when reporting it."
+
+// Retorna false para erros que não são de validação nem de domínio
 func HandleDomainErrors(err error, ctx *gin.Context) bool {
-	switch e := err.(type) {
-	case *exceptions.InvalidOrderDataException:
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": e.Error()})
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		WriteValidationError(ctx, validationErr)
 		return true
+	}
 
-	case *exceptions.OrderNotFoundException:
-		ctx.JSON(http.StatusNotFound, gin.H{"error": e.Error()})
-		return true
+	problemType, domainErr, ok := domainProblem(err)
+	if !ok {
+		return false
+	}
+	// A causa da falha do catálogo expõe URLs e hosts internos; fica só no log
+	if catalogErr, ok := domainErr.(*exceptions.ProductCatalogUnavailableException); ok && catalogErr.Cause != nil {
+		log.Printf(correlation.LogPrefix(CorrelationID(ctx))+"Product catalog unavailable: %v", catalogErr.Cause)
+	}
+	WriteProblem(ctx, problemType, domainErr.Error())
+	return true
+}
 
-	case *exceptions.InvalidOrderItemData:
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": e.Error()})
-		return true
+// Registra o erro, com a pilha quando houver, e responde com INTERNAL_ERROR genérico
+func HandleUnknownError(err error, ctx *gin.Context) {
+	method, path := "", ""
+	if ctx.Request != nil {
+		method, path = ctx.Request.Method, ctx.Request.URL.Path
+	}
+	log.Printf(correlation.LogPrefix(CorrelationID(ctx))+"Unhandled error %s %s: %v\n%s", method, path, err, StackOf(err))
 
-	case *exceptions.AmountNotValidException:
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": e.Error()})
-		return true
+	WriteProblem(ctx, PROBLEM_INTERNAL_ERROR, INTERNAL_ERROR_DETAIL)
+}
 
-	case *exceptions.OrderStatusNotFoundException:
-		ctx.JSON(http.StatusNotFound, gin.H{"error": e.Error()})
-		return true
+// Percorre a cadeia de err em busca de uma exceção de domínio
+func domainProblem(err error) (ProblemType, error, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		switch err.(type) {
+		case *exceptions.InvalidOrderDataException:
+			return PROBLEM_INVALID_ORDER, err, true
+		case *exceptions.OrderNotFoundException:
+			return PROBLEM_ORDER_NOT_FOUND, err, true
+		case *exceptions.InvalidOrderItemData:
+			return PROBLEM_INVALID_ITEM, err, true
+		case *exceptions.AmountNotValidException:
+			return PROBLEM_INVALID_AMOUNT, err, true
+		case *exceptions.OrderStatusNotFoundException:
+			return PROBLEM_ORDER_STATUS_NOT_FOUND, err, true
+		case *exceptions.InvalidOrderFilterException:
+			return PROBLEM_INVALID_FILTER, err, true
+		case *exceptions.OrderAlreadyClaimedException:
+			return PROBLEM_ORDER_ALREADY_CLAIMED, err, true
+		case *exceptions.OrderConcurrentModificationException:
+			return PROBLEM_ORDER_MODIFIED, err, true
+		case *exceptions.InvalidStatusTransitionException:
+			return PROBLEM_INVALID_STATUS_TRANSITION, err, true
+		case *exceptions.ProductNotFoundException:
+			return PROBLEM_PRODUCT_NOT_FOUND, err, true
+		case *exceptions.ProductUnavailableException:
+			return PROBLEM_PRODUCT_UNAVAILABLE, err, true
+		case *exceptions.ProductModifierNotFoundException:
+			return PROBLEM_PRODUCT_MODIFIER_NOT_FOUND, err, true
+		case *exceptions.ProductPriceMismatchException:
+			return PROBLEM_PRICE_MISMATCH, err, true
+		case *exceptions.ProductCatalogUnavailableException:
+			return PROBLEM_PRODUCT_CATALOG_UNAVAILABLE, err, true
+		case *exceptions.CouponNotFoundException:
+			return PROBLEM_COUPON_NOT_FOUND, err, true
+		case *exceptions.InvalidCouponDataException:
+			return PROBLEM_INVALID_COUPON, err, true
+		case *exceptions.CouponAlreadyExistsException:
+			return PROBLEM_COUPON_ALREADY_EXISTS, err, true
+		case *exceptions.CouponExpiredException:
+			return PROBLEM_COUPON_EXPIRED, err, true
+		case *exceptions.CouponUsageLimitReachedException:
+			return PROBLEM_COUPON_USAGE_LIMIT_REACHED, err, true
+		case *exceptions.CouponNotApplicableException:
+			return PROBLEM_COUPON_NOT_APPLICABLE, err, true
+		}
 	}
-
-	return false
+	return ProblemType{}, nil, false
 }
@@ -1,9 +1,15 @@
 package http_errors
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"log"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -90,6 +96,100 @@ func TestHandleDomainErrors_OrderStatusNotFoundException(t *testing.T) {
 	}
 }
 
+func TestHandleDomainErrors_InvalidStatusTransitionException(t *testing.T) {
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+
+	err := &exceptions.InvalidStatusTransitionException{Message: "Order cannot change status from Entregue to Pronto"}
+	handled := HandleDomainErrors(err, ctx)
+
+	if !handled {
+		t.Error("HandleDomainErrors() should return true for InvalidStatusTransitionException")
+	}
+	if w.Code != http.StatusConflict {
+		t.Errorf("HandleDomainErrors() status = %v, want %v", w.Code, http.StatusConflict)
+	}
+}
+
+func TestHandleDomainErrors_OrderAlreadyClaimedException(t *testing.T) {
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+
+	handled := HandleDomainErrors(&exceptions.OrderAlreadyClaimedException{}, ctx)
+
+	if !handled {
+		t.Error("HandleDomainErrors() should return true for OrderAlreadyClaimedException")
+	}
+	if w.Code != http.StatusConflict {
+		t.Errorf("HandleDomainErrors() status = %v, want %v", w.Code, http.StatusConflict)
+	}
+}
+
+func TestHandleDomainErrors_OrderConcurrentModificationException(t *testing.T) {
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+
+	handled := HandleDomainErrors(&exceptions.OrderConcurrentModificationException{}, ctx)
+
+	if !handled {
+		t.Error("HandleDomainErrors() should return true for OrderConcurrentModificationException")
+	}
+	if w.Code != http.StatusConflict {
+		t.Errorf("HandleDomainErrors() status = %v, want %v", w.Code, http.StatusConflict)
+	}
+}
+
+func TestHandleDomainErrors_InvalidOrderFilterException(t *testing.T) {
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+
+	err := &exceptions.InvalidOrderFilterException{Message: "Invalid cursor"}
+	handled := HandleDomainErrors(err, ctx)
+
+	if !handled {
+		t.Error("HandleDomainErrors() should return true for InvalidOrderFilterException")
+	}
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("HandleDomainErrors() status = %v, want %v", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandleDomainErrors_ProductExceptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"product not found", &exceptions.ProductNotFoundException{}, http.StatusUnprocessableEntity},
+		{"product unavailable", &exceptions.ProductUnavailableException{}, http.StatusUnprocessableEntity},
+		{"modifier not found", &exceptions.ProductModifierNotFoundException{}, http.StatusUnprocessableEntity},
+		{"price mismatch", &exceptions.ProductPriceMismatchException{}, http.StatusConflict},
+		{"catalog unavailable", &exceptions.ProductCatalogUnavailableException{}, http.StatusServiceUnavailable},
+		{"coupon not found", &exceptions.CouponNotFoundException{}, http.StatusNotFound},
+		{"invalid coupon", &exceptions.InvalidCouponDataException{}, http.StatusBadRequest},
+		{"coupon already exists", &exceptions.CouponAlreadyExistsException{}, http.StatusConflict},
+		{"coupon expired", &exceptions.CouponExpiredException{}, http.StatusUnprocessableEntity},
+		{"coupon usage limit", &exceptions.CouponUsageLimitReachedException{}, http.StatusConflict},
+		{"coupon not applicable", &exceptions.CouponNotApplicableException{}, http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			handled := HandleDomainErrors(tt.err, ctx)
+
+			if !handled {
+				t.Errorf("HandleDomainErrors() should return true for %T", tt.err)
+			}
+			if w.Code != tt.expected {
+				t.Errorf("HandleDomainErrors() status = %v, want %v", w.Code, tt.expected)
+			}
+		})
+	}
+}
+
 func TestHandleDomainErrors_UnknownError(t *testing.T) {
 	w := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(w)
@@ -101,3 +201,98 @@ func TestHandleDomainErrors_UnknownError(t *testing.T) {
 		t.Error("HandleDomainErrors() should return false for unknown errors")
 	}
 }
+
+func TestHandleDomainErrors_WrappedDomainError(t *testing.T) {
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+
+	err := fmt.Errorf("claiming order: %w", &exceptions.OrderAlreadyClaimedException{})
+	if !HandleDomainErrors(err, ctx) {
+		t.Fatal("HandleDomainErrors() should return true for wrapped domain errors")
+	}
+
+	var problem Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("invalid problem body: %v", err)
+	}
+	if w.Code != http.StatusConflict || problem.Code != "ORDER_ALREADY_CLAIMED" || problem.Detail != "Order already belongs to a customer" {
+		t.Errorf("HandleDomainErrors() = %d %+v, want 409 ORDER_ALREADY_CLAIMED with the exception message", w.Code, problem)
+	}
+}
+
+func TestHandleDomainErrors_StableCodes(t *testing.T) {
+	tests := []struct {
+		err      error
+		wantCode string
+	}{
+		{&exceptions.InvalidOrderDataException{}, "INVALID_ORDER"},
+		{&exceptions.OrderNotFoundException{}, "ORDER_NOT_FOUND"},
+		{&exceptions.InvalidOrderItemData{}, "INVALID_ITEM"},
+		{&exceptions.ProductPriceMismatchException{}, "PRICE_MISMATCH"},
+		{&exceptions.CouponExpiredException{}, "COUPON_EXPIRED"},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		HandleDomainErrors(tt.err, ctx)
+
+		var problem Problem
+		if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+			t.Fatalf("invalid problem body: %v", err)
+		}
+		if problem.Code != tt.wantCode || problem.Status != w.Code {
+			t.Errorf("HandleDomainErrors(%T) = %+v, want code %s", tt.err, problem, tt.wantCode)
+		}
+	}
+}
+
+func TestHandleUnknownError_HidesCause(t *testing.T) {
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest("GET", "/orders", nil)
+
+	HandleUnknownError(WithStack(errors.New("pq: password authentication failed")), ctx)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("HandleUnknownError() status = %v, want %v", w.Code, http.StatusInternalServerError)
+	}
+	if strings.Contains(w.Body.String(), "password") {
+		t.Errorf("HandleUnknownError() body = %s, must not expose the cause", w.Body.String())
+	}
+
+	var problem Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("invalid problem body: %v", err)
+	}
+	if problem.Code != "INTERNAL_ERROR" || problem.CorrelationID == "" {
+		t.Errorf("HandleUnknownError() = %+v, want INTERNAL_ERROR with a correlation ID", problem)
+	}
+}
+
+func TestHandleDomainErrors_CatalogUnavailableHidesCause(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest("POST", "/orders", nil)
+
+	cause := errors.New(`Get "http://products.internal:8081/products/product-1": dial tcp 10.0.3.7:8081: connect: connection refused`)
+	HandleDomainErrors(fmt.Errorf("create order: %w", &exceptions.ProductCatalogUnavailableException{Cause: cause}), ctx)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("HandleDomainErrors() status = %v, want %v", w.Code, http.StatusServiceUnavailable)
+	}
+	var problem Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("invalid problem body: %v", err)
+	}
+	if problem.Detail != "Product catalog is temporarily unavailable" {
+		t.Errorf("HandleDomainErrors() detail = %q, must not expose the cause", problem.Detail)
+	}
+	if !strings.Contains(logs.String(), "connection refused") || !strings.Contains(logs.String(), problem.CorrelationID) {
+		t.Errorf("HandleDomainErrors() log = %q, want the cause with correlation ID %s", logs.String(), problem.CorrelationID)
+	}
+}
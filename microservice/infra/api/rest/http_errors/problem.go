@@ -0,0 +1,64 @@
+package http_errors
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	PROBLEM_CONTENT_TYPE = "application/problem+json"
+	PROBLEM_TYPE_PREFIX  = "urn:problem-type:orders:"
+)
+
+// Code é estável e é nele que os clientes devem se basear
+type ProblemType struct {
+	Status int
+	Code   string
+	Title  string
+}
+
+func (t ProblemType) URI() string {
+	return PROBLEM_TYPE_PREFIX + strings.ToLower(strings.ReplaceAll(t.Code, "_", "-"))
+}
+
+// Corpo RFC 7807 de toda resposta de erro; Errors lista os campos inválidos
+type Problem struct {
+	Type          string       `json:"type"`
+	Title         string       `json:"title"`
+	Status        int          `json:"status"`
+	Detail        string       `json:"detail,omitempty"`
+	Instance      string       `json:"instance,omitempty"`
+	Code          string       `json:"code"`
+	CorrelationID string       `json:"correlation_id,omitempty"`
+	Errors        []FieldError `json:"errors,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func NewProblem(ctx *gin.Context, problemType ProblemType, detail string, fieldErrors ...FieldError) Problem {
+	problem := Problem{
+		Type:          problemType.URI(),
+		Title:         problemType.Title,
+		Status:        problemType.Status,
+		Detail:        detail,
+		Code:          problemType.Code,
+		CorrelationID: CorrelationID(ctx),
+		Errors:        fieldErrors,
+	}
+	// Só o caminho: a query pode ter dados que não devem voltar na resposta
+	if ctx.Request != nil {
+		problem.Instance = ctx.Request.URL.Path
+	}
+	return problem
+}
+
+func WriteProblem(ctx *gin.Context, problemType ProblemType, detail string, fieldErrors ...FieldError) {
+	// O gin só define o Content-Type quando ele ainda não existe
+	ctx.Header("Content-Type", PROBLEM_CONTENT_TYPE)
+	ctx.AbortWithStatusJSON(problemType.Status, NewProblem(ctx, problemType, detail, fieldErrors...))
+}
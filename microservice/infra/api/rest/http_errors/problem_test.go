@@ -0,0 +1,97 @@
+package http_errors
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"microservice/utils/correlation"
+)
+
+func serveProblem(t *testing.T, req *http.Request, handler gin.HandlerFunc) (*httptest.ResponseRecorder, Problem) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+	router.GET("/orders/:id", handler)
+	router.ServeHTTP(w, req)
+
+	var problem Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("invalid problem body %s: %v", w.Body.String(), err)
+	}
+	return w, problem
+}
+
+func TestWriteProblem(t *testing.T) {
+	req := httptest.NewRequest("GET", "/orders/123?token=secret", nil)
+	w, problem := serveProblem(t, req, func(ctx *gin.Context) {
+		WriteProblem(ctx, PROBLEM_ORDER_NOT_FOUND, "Order not found", FieldError{Field: "id", Code: "unknown", Message: "does not exist"})
+	})
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("WriteProblem() status = %v, want %v", w.Code, http.StatusNotFound)
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != PROBLEM_CONTENT_TYPE {
+		t.Errorf("WriteProblem() Content-Type = %q, want %q", contentType, PROBLEM_CONTENT_TYPE)
+	}
+
+	want := Problem{
+		Type:          "urn:problem-type:orders:order-not-found",
+		Title:         "Order not found",
+		Status:        http.StatusNotFound,
+		Detail:        "Order not found",
+		Instance:      "/orders/123",
+		Code:          "ORDER_NOT_FOUND",
+		CorrelationID: w.Header().Get(correlation.REQUEST_ID_HEADER),
+		Errors:        []FieldError{{Field: "id", Code: "unknown", Message: "does not exist"}},
+	}
+	if problem.CorrelationID == "" {
+		t.Error("WriteProblem() correlation_id is empty")
+	}
+	if problem.Type != want.Type || problem.Title != want.Title || problem.Status != want.Status ||
+		problem.Detail != want.Detail || problem.Instance != want.Instance || problem.Code != want.Code ||
+		problem.CorrelationID != want.CorrelationID || len(problem.Errors) != 1 || problem.Errors[0] != want.Errors[0] {
+		t.Errorf("WriteProblem() = %+v, want %+v", problem, want)
+	}
+}
+
+func TestCorrelationID(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestID string
+		wantEcho  bool
+	}{
+		{name: "uses a well formed request ID", requestID: "req-123.abc", wantEcho: true},
+		{name: "generates one when missing", requestID: "", wantEcho: false},
+		{name: "ignores request IDs that are unsafe to log", requestID: "abc\tforged log line", wantEcho: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/orders/1", nil)
+			if tt.requestID != "" {
+				req.Header.Set(correlation.REQUEST_ID_HEADER, tt.requestID)
+			}
+
+			var first, second string
+			w, _ := serveProblem(t, req, func(ctx *gin.Context) {
+				first = CorrelationID(ctx)
+				second = CorrelationID(ctx)
+				WriteProblem(ctx, PROBLEM_INTERNAL_ERROR, "")
+			})
+
+			if first == "" || first != second {
+				t.Errorf("CorrelationID() = %q then %q, want the same non-empty ID", first, second)
+			}
+			if (first == tt.requestID) != tt.wantEcho {
+				t.Errorf("CorrelationID() = %q, request ID %q used = %v", first, tt.requestID, !tt.wantEcho)
+			}
+			if header := w.Header().Get(correlation.REQUEST_ID_HEADER); header != first {
+				t.Errorf("X-Request-ID header = %q, want %q", header, first)
+			}
+		})
+	}
+}
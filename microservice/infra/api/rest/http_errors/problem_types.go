@@ -0,0 +1,37 @@
+package http_errors
+
+import "net/http"
+
+// Tipos de problema de domínio
+var (
+	PROBLEM_INVALID_ORDER               = ProblemType{Status: http.StatusBadRequest, Code: "INVALID_ORDER", Title: "Invalid order"}
+	PROBLEM_ORDER_NOT_FOUND             = ProblemType{Status: http.StatusNotFound, Code: "ORDER_NOT_FOUND", Title: "Order not found"}
+	PROBLEM_INVALID_ITEM                = ProblemType{Status: http.StatusBadRequest, Code: "INVALID_ITEM", Title: "Invalid order item"}
+	PROBLEM_INVALID_AMOUNT              = ProblemType{Status: http.StatusBadRequest, Code: "INVALID_AMOUNT", Title: "Invalid amount"}
+	PROBLEM_ORDER_STATUS_NOT_FOUND      = ProblemType{Status: http.StatusNotFound, Code: "ORDER_STATUS_NOT_FOUND", Title: "Order status not found"}
+	PROBLEM_INVALID_FILTER              = ProblemType{Status: http.StatusBadRequest, Code: "INVALID_FILTER", Title: "Invalid order filter"}
+	PROBLEM_ORDER_ALREADY_CLAIMED       = ProblemType{Status: http.StatusConflict, Code: "ORDER_ALREADY_CLAIMED", Title: "Order already claimed"}
+	PROBLEM_ORDER_MODIFIED              = ProblemType{Status: http.StatusConflict, Code: "ORDER_MODIFIED", Title: "Order modified concurrently"}
+	PROBLEM_INVALID_STATUS_TRANSITION   = ProblemType{Status: http.StatusConflict, Code: "INVALID_STATUS_TRANSITION", Title: "Invalid status transition"}
+	PROBLEM_PRODUCT_NOT_FOUND           = ProblemType{Status: http.StatusUnprocessableEntity, Code: "PRODUCT_NOT_FOUND", Title: "Product not found"}
+	PROBLEM_PRODUCT_UNAVAILABLE         = ProblemType{Status: http.StatusUnprocessableEntity, Code: "PRODUCT_UNAVAILABLE", Title: "Product unavailable"}
+	PROBLEM_PRODUCT_MODIFIER_NOT_FOUND  = ProblemType{Status: http.StatusUnprocessableEntity, Code: "PRODUCT_MODIFIER_NOT_FOUND", Title: "Product modifier not found"}
+	PROBLEM_PRICE_MISMATCH              = ProblemType{Status: http.StatusConflict, Code: "PRICE_MISMATCH", Title: "Price mismatch"}
+	PROBLEM_PRODUCT_CATALOG_UNAVAILABLE = ProblemType{Status: http.StatusServiceUnavailable, Code: "PRODUCT_CATALOG_UNAVAILABLE", Title: "Product catalog unavailable"}
+	PROBLEM_COUPON_NOT_FOUND            = ProblemType{Status: http.StatusNotFound, Code: "COUPON_NOT_FOUND", Title: "Coupon not found"}
+	PROBLEM_INVALID_COUPON              = ProblemType{Status: http.StatusBadRequest, Code: "INVALID_COUPON", Title: "Invalid coupon"}
+	PROBLEM_COUPON_ALREADY_EXISTS       = ProblemType{Status: http.StatusConflict, Code: "COUPON_ALREADY_EXISTS", Title: "Coupon already exists"}
+	PROBLEM_COUPON_EXPIRED              = ProblemType{Status: http.StatusUnprocessableEntity, Code: "COUPON_EXPIRED", Title: "Coupon expired"}
+	PROBLEM_COUPON_USAGE_LIMIT_REACHED  = ProblemType{Status: http.StatusConflict, Code: "COUPON_USAGE_LIMIT_REACHED", Title: "Coupon usage limit reached"}
+	PROBLEM_COUPON_NOT_APPLICABLE       = ProblemType{Status: http.StatusUnprocessableEntity, Code: "COUPON_NOT_APPLICABLE", Title: "Coupon not applicable"}
+)
+
+// Tipos de problema da camada HTTP
+var (
+	PROBLEM_VALIDATION_FAILED      = ProblemType{Status: http.StatusBadRequest, Code: "VALIDATION_FAILED", Title: "Request validation failed"}
+	PROBLEM_UNAUTHORIZED           = ProblemType{Status: http.StatusUnauthorized, Code: "UNAUTHORIZED", Title: "Unauthorized"}
+	PROBLEM_FORBIDDEN              = ProblemType{Status: http.StatusForbidden, Code: "FORBIDDEN", Title: "Forbidden"}
+	PROBLEM_IDEMPOTENCY_KEY_IN_USE = ProblemType{Status: http.StatusConflict, Code: "IDEMPOTENCY_KEY_IN_USE", Title: "Idempotency key in use"}
+	PROBLEM_IDEMPOTENCY_KEY_REUSED = ProblemType{Status: http.StatusUnprocessableEntity, Code: "IDEMPOTENCY_KEY_REUSED", Title: "Idempotency key reused"}
+	PROBLEM_INTERNAL_ERROR         = ProblemType{Status: http.StatusInternalServerError, Code: "INTERNAL_ERROR", Title: "Internal server error"}
+)
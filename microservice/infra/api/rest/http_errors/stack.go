@@ -0,0 +1,43 @@
+package http_errors
+
+import (
+	"errors"
+	"runtime/debug"
+)
+
+type stackError struct {
+	err   error
+	stack []byte
+}
+
+func (e *stackError) Error() string {
+	return e.err.Error()
+}
+
+func (e *stackError) Unwrap() error {
+	return e.err
+}
+
+// Erros de domínio e de validação não recebem pilha, pois viram problemas comuns
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+	var validationErr *ValidationError
+	if _, _, known := domainProblem(err); known || errors.As(err, &validationErr) {
+		return err
+	}
+	var withStack *stackError
+	if errors.As(err, &withStack) {
+		return err
+	}
+	return &stackError{err: err, stack: debug.Stack()}
+}
+
+func StackOf(err error) []byte {
+	var withStack *stackError
+	if errors.As(err, &withStack) {
+		return withStack.stack
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package http_errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"microservice/internal/domain/exceptions"
+)
+
+func TestWithStack(t *testing.T) {
+	if WithStack(nil) != nil {
+		t.Error("WithStack(nil) should be nil")
+	}
+
+	domainErr := &exceptions.OrderNotFoundException{}
+	if err := WithStack(domainErr); err != domainErr || StackOf(err) != nil {
+		t.Error("WithStack() should return domain errors unchanged")
+	}
+	validationErr := NewValidationError(INVALID_BODY_DETAIL)
+	if err := WithStack(validationErr); err != validationErr {
+		t.Error("WithStack() should return validation errors unchanged")
+	}
+
+	cause := errors.New("connection refused")
+	err := WithStack(fmt.Errorf("saving order: %w", cause))
+	if !errors.Is(err, cause) {
+		t.Error("WithStack() should keep the error chain")
+	}
+	if err.Error() != "saving order: connection refused" {
+		t.Errorf("WithStack() message = %q, want the original message", err.Error())
+	}
+	if len(StackOf(err)) == 0 {
+		t.Error("StackOf() is empty, want the stack recorded by WithStack")
+	}
+	if again := WithStack(err); again != err {
+		t.Error("WithStack() should not wrap an error twice")
+	}
+}
@@ -0,0 +1,143 @@
+package http_errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	INVALID_BODY_DETAIL    = "Invalid request body"
+	INVALID_QUERY_DETAIL   = "Invalid query parameters"
+	INVALID_HEADERS_DETAIL = "Invalid request headers"
+)
+
+// Requisição rejeitada antes dos casos de uso; vira um problema VALIDATION_FAILED
+type ValidationError struct {
+	Message string
+	Errors  []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	if e.Message == "" {
+		return "Request validation failed"
+	}
+	return e.Message
+}
+
+// Versões recentes do encoding/json incluem índices como items.0.quantity
+var jsonIndex = regexp.MustCompile(`\.(\d+)`)
+
+func init() {
+	// Os campos das mensagens usam os nomes do JSON, não os da struct
+	if engine, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		engine.RegisterTagNameFunc(jsonFieldName)
+	}
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "uri", "header"} {
+		name := strings.Split(field.Tag.Get(tag), ",")[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+func NewValidationError(message string, fieldErrors ...FieldError) *ValidationError {
+	return &ValidationError{Message: message, Errors: fieldErrors}
+}
+
+func WriteValidationError(ctx *gin.Context, err *ValidationError) {
+	WriteProblem(ctx, PROBLEM_VALIDATION_FAILED, err.Error(), err.Errors...)
+}
+
+// Não expõe a saída crua do decoder nem do validator
+func NewBindingError(err error) *ValidationError {
+	var validationErrs validator.ValidationErrors
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &validationErrs):
+		fieldErrors := make([]FieldError, 0, len(validationErrs))
+		for _, fieldErr := range validationErrs {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:   fieldPath(fieldErr.Namespace()),
+				Code:    fieldErr.Tag(),
+				Message: validationMessage(fieldErr),
+			})
+		}
+		return NewValidationError(INVALID_BODY_DETAIL, fieldErrors...)
+
+	case errors.As(err, &typeErr):
+		return NewValidationError(INVALID_BODY_DETAIL, FieldError{
+			Field:   jsonIndex.ReplaceAllString(typeErr.Field, "[$1]"),
+			Code:    "type",
+			Message: fmt.Sprintf("must be of type %s", typeErr.Type.Kind()),
+		})
+
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return NewValidationError("Request body is not valid JSON")
+
+	case errors.Is(err, io.EOF):
+		return NewValidationError("Request body is required")
+	}
+
+	return NewValidationError(INVALID_BODY_DETAIL)
+}
+
+// CreateOrderSchema.items[0].quantity vira items[0].quantity
+func fieldPath(namespace string) string {
+	if _, path, found := strings.Cut(namespace, "."); found {
+		return path
+	}
+	return namespace
+}
+
+func validationMessage(fieldErr validator.FieldError) string {
+	unit := ""
+	switch fieldErr.Kind() {
+	case reflect.String:
+		unit = " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit = " items"
+	}
+
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "min":
+		if unit == "" {
+			return fmt.Sprintf("must be at least %s", fieldErr.Param())
+		}
+		return fmt.Sprintf("must have at least %s%s", fieldErr.Param(), unit)
+	case "max":
+		if unit == "" {
+			return fmt.Sprintf("must be at most %s", fieldErr.Param())
+		}
+		return fmt.Sprintf("must have at most %s%s", fieldErr.Param(), unit)
+	case "len":
+		if unit == "" {
+			return fmt.Sprintf("must be equal to %s", fieldErr.Param())
+		}
+		return fmt.Sprintf("must have exactly %s%s", fieldErr.Param(), unit)
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", strings.Join(strings.Fields(fieldErr.Param()), ", "))
+	case "uppercase":
+		return "must be uppercase"
+	}
+	return fmt.Sprintf("failed the %s validation", fieldErr.Tag())
+}
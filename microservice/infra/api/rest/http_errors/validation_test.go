@@ -0,0 +1,115 @@
+package http_errors
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type testItemSchema struct {
+	Quantity int    `json:"quantity" binding:"required,min=1"`
+	Notes    string `json:"notes" binding:"max=5"`
+}
+
+type testOrderSchema struct {
+	Currency string           `json:"currency" binding:"omitempty,len=3,uppercase"`
+	Channel  string           `json:"channel" binding:"omitempty,oneof=app kiosk"`
+	Items    []testItemSchema `json:"items" binding:"required,min=1,dive"`
+}
+
+func bindTestOrder(t *testing.T, body string) error {
+	t.Helper()
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest("POST", "/orders", bytes.NewBufferString(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	var schema testOrderSchema
+	err := ctx.ShouldBindJSON(&schema)
+	if err == nil {
+		t.Fatalf("ShouldBindJSON(%s) succeeded, want an error", body)
+	}
+	return err
+}
+
+func TestNewBindingError(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantMessage string
+		wantErrors  []FieldError
+	}{
+		{
+			name:        "validation errors use JSON field paths",
+			body:        `{"currency":"br","channel":"web","items":[{"quantity":0,"notes":"too long"}]}`,
+			wantMessage: INVALID_BODY_DETAIL,
+			wantErrors: []FieldError{
+				{Field: "currency", Code: "len", Message: "must have exactly 3 characters"},
+				{Field: "channel", Code: "oneof", Message: "must be one of: app, kiosk"},
+				{Field: "items[0].quantity", Code: "required", Message: "is required"},
+				{Field: "items[0].notes", Code: "max", Message: "must have at most 5 characters"},
+			},
+		},
+		{
+			name:        "missing items",
+			body:        `{"items":[]}`,
+			wantMessage: INVALID_BODY_DETAIL,
+			wantErrors:  []FieldError{{Field: "items", Code: "min", Message: "must have at least 1 items"}},
+		},
+		{
+			name:        "wrong JSON type",
+			body:        `{"items":[{"quantity":"two"}]}`,
+			wantMessage: INVALID_BODY_DETAIL,
+			wantErrors:  []FieldError{{Field: "quantity", Code: "type", Message: "must be of type int"}},
+		},
+		{
+			name:        "malformed JSON",
+			body:        `{"items":`,
+			wantMessage: "Request body is not valid JSON",
+		},
+		{
+			name:        "empty body",
+			body:        ``,
+			wantMessage: "Request body is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validationErr := NewBindingError(bindTestOrder(t, tt.body))
+			// O índice só aparece no caminho em versões recentes do Go
+			for i, fieldErr := range validationErr.Errors {
+				if fieldErr.Code == "type" {
+					validationErr.Errors[i].Field = fieldErr.Field[strings.LastIndex(fieldErr.Field, ".")+1:]
+				}
+			}
+
+			if validationErr.Error() != tt.wantMessage {
+				t.Errorf("NewBindingError() message = %q, want %q", validationErr.Error(), tt.wantMessage)
+			}
+			if !reflect.DeepEqual(validationErr.Errors, tt.wantErrors) {
+				t.Errorf("NewBindingError() errors = %+v, want %+v", validationErr.Errors, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestHandleDomainErrors_ValidationError(t *testing.T) {
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+
+	handled := HandleDomainErrors(NewValidationError(INVALID_QUERY_DETAIL, FieldError{Field: "limit", Code: "positive_integer", Message: "must be a positive integer"}), ctx)
+
+	if !handled {
+		t.Fatal("HandleDomainErrors() should return true for ValidationError")
+	}
+	if w.Code != PROBLEM_VALIDATION_FAILED.Status {
+		t.Errorf("HandleDomainErrors() status = %v, want %v", w.Code, PROBLEM_VALIDATION_FAILED.Status)
+	}
+	if !bytes.Contains(w.Body.Bytes(), []byte(`"field":"limit"`)) {
+		t.Errorf("HandleDomainErrors() body = %s, want the limit field error", w.Body.String())
+	}
+}
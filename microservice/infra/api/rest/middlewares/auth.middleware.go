@@ -0,0 +1,66 @@
+package middlewares
+
+import (
+	"log"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"microservice/infra/api/rest/http_errors"
+	"microservice/infra/auth"
+	"microservice/utils/correlation"
+)
+
+const PRINCIPAL_CONTEXT_KEY = "principal"
+
+// Sem verifier, todas as requisições são rejeitadas
+func AuthMiddleware(verifier auth.TokenVerifier) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token, found := strings.CutPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+		if !found || strings.TrimSpace(token) == "" {
+			unauthorized(ctx, "Missing bearer token")
+			return
+		}
+		if verifier == nil {
+			unauthorized(ctx, "Authentication is not configured")
+			return
+		}
+
+		// O motivo fica só no log para não expor detalhes do verifier ou do JWKS
+		principal, err := verifier.Verify(strings.TrimSpace(token))
+		if err != nil {
+			log.Printf(correlation.LogPrefix(http_errors.CorrelationID(ctx))+"Token rejected: %v", err)
+			unauthorized(ctx, "Invalid or missing token")
+			return
+		}
+
+		ctx.Set(PRINCIPAL_CONTEXT_KEY, principal)
+		ctx.Next()
+	}
+}
+
+// Basta um dos papéis
+func RequireRoles(roles ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		principal, ok := CurrentPrincipal(ctx)
+		if !ok || !principal.HasAnyRole(roles...) {
+			http_errors.WriteProblem(ctx, http_errors.PROBLEM_FORBIDDEN, "You are not allowed to perform this operation")
+			return
+		}
+		ctx.Next()
+	}
+}
+
+func CurrentPrincipal(ctx *gin.Context) (auth.Principal, bool) {
+	value, exists := ctx.Get(PRINCIPAL_CONTEXT_KEY)
+	if !exists {
+		return auth.Principal{}, false
+	}
+	principal, ok := value.(auth.Principal)
+	return principal, ok
+}
+
+func unauthorized(ctx *gin.Context, message string) {
+	ctx.Header("WWW-Authenticate", "Bearer")
+	http_errors.WriteProblem(ctx, http_errors.PROBLEM_UNAUTHORIZED, message)
+}
@@ -0,0 +1,113 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"microservice/infra/auth"
+)
+
+type fakeVerifier struct {
+	principals map[string]auth.Principal
+}
+
+func (f fakeVerifier) Verify(token string) (auth.Principal, error) {
+	if token == "jwks-down" {
+		return auth.Principal{}, errors.New("failed to fetch JWKS from https://cognito-idp.internal/keys")
+	}
+	principal, ok := f.principals[token]
+	if !ok {
+		return auth.Principal{}, &auth.InvalidTokenException{Message: "Token has expired"}
+	}
+	return principal, nil
+}
+
+func newAuthRouter(verifier auth.TokenVerifier, roles ...string) *gin.Engine {
+	router := gin.New()
+	router.Use(AuthMiddleware(verifier))
+	router.GET("/test", RequireRoles(roles...), func(c *gin.Context) {
+		principal, _ := CurrentPrincipal(c)
+		c.JSON(http.StatusOK, gin.H{"subject": principal.Subject})
+	})
+	return router
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	verifier := fakeVerifier{principals: map[string]auth.Principal{
+		"customer-token": {Subject: "customer-123", Roles: []string{auth.ROLE_CUSTOMER}},
+		"staff-token":    {Subject: "staff-1", Roles: []string{auth.ROLE_STAFF}},
+	}}
+
+	tests := []struct {
+		name          string
+		verifier      auth.TokenVerifier
+		authorization string
+		wantStatus    int
+	}{
+		{"allowed role", verifier, "Bearer staff-token", http.StatusOK},
+		{"missing header", verifier, "", http.StatusUnauthorized},
+		{"not bearer", verifier, "Basic dXNlcjpwYXNz", http.StatusUnauthorized},
+		{"invalid token", verifier, "Bearer expired-token", http.StatusUnauthorized},
+		{"forbidden role", verifier, "Bearer customer-token", http.StatusForbidden},
+		{"without verifier", nil, "Bearer staff-token", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router := newAuthRouter(tt.verifier, auth.ROLE_STAFF, auth.ROLE_ADMIN)
+
+			req := httptest.NewRequest("GET", "/test", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %v, want %v (body %s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") != "Bearer" {
+				t.Errorf("WWW-Authenticate = %q, want Bearer", w.Header().Get("WWW-Authenticate"))
+			}
+			wantCode := map[int]string{http.StatusUnauthorized: "UNAUTHORIZED", http.StatusForbidden: "FORBIDDEN"}[tt.wantStatus]
+			if wantCode != "" && !strings.Contains(w.Body.String(), `"code":"`+wantCode+`"`) {
+				t.Errorf("body = %s, want problem code %s", w.Body.String(), wantCode)
+			}
+		})
+	}
+}
+
+func TestCurrentPrincipal_WithoutAuth(t *testing.T) {
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+
+	if _, ok := CurrentPrincipal(ctx); ok {
+		t.Error("CurrentPrincipal() found a principal without AuthMiddleware")
+	}
+}
+
+func TestAuthMiddleware_HidesVerifierError(t *testing.T) {
+	for _, token := range []string{"expired-token", "jwks-down"} {
+		w := httptest.NewRecorder()
+		router := newAuthRouter(fakeVerifier{}, auth.ROLE_STAFF)
+
+		req := httptest.NewRequest("GET", "/test", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("%s status = %v, want %v", token, w.Code, http.StatusUnauthorized)
+		}
+		if !strings.Contains(w.Body.String(), "Invalid or missing token") {
+			t.Errorf("%s body = %s, want fixed detail", token, w.Body.String())
+		}
+		if strings.Contains(w.Body.String(), "JWKS") || strings.Contains(w.Body.String(), "expired") {
+			t.Errorf("%s body = %s, leaks verifier error", token, w.Body.String())
+		}
+	}
+}
@@ -1,11 +1,12 @@
 package middlewares
 
 import (
-	"net/http"
+	"log"
 
 	"github.com/gin-gonic/gin"
 
 	"microservice/infra/api/rest/http_errors"
+	"microservice/utils/correlation"
 )
 
 func ErrorHandlerMiddleware() gin.HandlerFunc {
@@ -18,10 +19,18 @@ func ErrorHandlerMiddleware() gin.HandlerFunc {
 			errorHandled := http_errors.HandleDomainErrors(err, ctx)
 
 			if !errorHandled {
-				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+				http_errors.HandleUnknownError(err, ctx)
 			}
 
 			ctx.Abort()
 		}
 	}
 }
+
+// A pilha é registrada pelo gin; o correlation ID a liga à resposta
+func RecoveryMiddleware() gin.HandlerFunc {
+	return gin.CustomRecovery(func(ctx *gin.Context, recovered any) {
+		log.Printf(correlation.LogPrefix(http_errors.CorrelationID(ctx))+"Recovered panic: %v", recovered)
+		http_errors.WriteProblem(ctx, http_errors.PROBLEM_INTERNAL_ERROR, http_errors.INTERNAL_ERROR_DETAIL)
+	})
+}
@@ -4,11 +4,14 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 
+	"microservice/infra/api/rest/http_errors"
 	"microservice/internal/domain/exceptions"
+	"microservice/utils/correlation"
 )
 
 func init() {
@@ -82,3 +85,46 @@ func TestErrorHandlerMiddleware_UnknownError(t *testing.T) {
 		t.Errorf("ErrorHandlerMiddleware() status = %v, want %v", w.Code, http.StatusInternalServerError)
 	}
 }
+
+func TestErrorHandlerMiddleware_UnknownErrorIsNotExposed(t *testing.T) {
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+
+	router.Use(ErrorHandlerMiddleware())
+	router.GET("/test", func(c *gin.Context) {
+		_ = c.Error(errors.New("dial tcp 10.0.0.5:5432: connection refused"))
+	})
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set(correlation.REQUEST_ID_HEADER, "req-42")
+	router.ServeHTTP(w, req)
+
+	if strings.Contains(w.Body.String(), "10.0.0.5") {
+		t.Errorf("ErrorHandlerMiddleware() body = %s, must not expose the error", w.Body.String())
+	}
+	for _, want := range []string{`"code":"INTERNAL_ERROR"`, `"correlation_id":"req-42"`} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("ErrorHandlerMiddleware() body = %s, want %s", w.Body.String(), want)
+		}
+	}
+}
+
+func TestRecoveryMiddleware(t *testing.T) {
+	w := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(w)
+
+	router.Use(RecoveryMiddleware())
+	router.GET("/test", func(c *gin.Context) {
+		panic("nil map")
+	})
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("RecoveryMiddleware() status = %v, want %v", w.Code, http.StatusInternalServerError)
+	}
+	if w.Header().Get("Content-Type") != http_errors.PROBLEM_CONTENT_TYPE || strings.Contains(w.Body.String(), "nil map") {
+		t.Errorf("RecoveryMiddleware() = %s %s, want an INTERNAL_ERROR problem", w.Header().Get("Content-Type"), w.Body.String())
+	}
+}
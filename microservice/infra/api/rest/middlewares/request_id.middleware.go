@@ -0,0 +1,36 @@
+package middlewares
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"microservice/infra/api/rest/http_errors"
+	"microservice/utils/correlation"
+)
+
+// Aceita o X-Request-ID do cliente ou cria um, e o propaga pelo contexto até logs e mensagens
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := http_errors.CorrelationID(ctx)
+		ctx.Request = ctx.Request.WithContext(correlation.WithID(ctx.Request.Context(), id))
+		ctx.Next()
+	}
+}
+
+func RequestLogger() gin.HandlerFunc {
+	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
+		id, _ := param.Keys[http_errors.CORRELATION_ID_CONTEXT_KEY].(string)
+		return fmt.Sprintf("[GIN] %s | %3d | %13v | %15s | %-7s %#v %s\n%s",
+			param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+			param.StatusCode,
+			param.Latency,
+			param.ClientIP,
+			param.Method,
+			param.Path,
+			strings.TrimSpace(correlation.LogPrefix(id)),
+			param.ErrorMessage,
+		)
+	})
+}
@@ -0,0 +1,92 @@
+package middlewares
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"microservice/utils/correlation"
+)
+
+func newRequestIDRouter(received *string) *gin.Engine {
+	router := gin.New()
+	router.Use(RequestIDMiddleware())
+	router.GET("/test", func(c *gin.Context) {
+		*received = correlation.ID(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func TestRequestIDMiddleware_KeepsValidID(t *testing.T) {
+	var received string
+	router := newRequestIDRouter(&received)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set(correlation.REQUEST_ID_HEADER, "req-123")
+	router.ServeHTTP(w, req)
+
+	if got := w.Header().Get(correlation.REQUEST_ID_HEADER); got != "req-123" {
+		t.Errorf("response header = %q, want req-123", got)
+	}
+	if received != "req-123" {
+		t.Errorf("request context ID = %q, want req-123", received)
+	}
+}
+
+func TestRequestIDMiddleware_GeneratesID(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{name: "missing", header: ""},
+		{name: "invalid", header: "bad id\nwith newline"},
+		{name: "too long", header: strings.Repeat("a", 129)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received string
+			router := newRequestIDRouter(&received)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/test", nil)
+			if tt.header != "" {
+				req.Header.Set(correlation.REQUEST_ID_HEADER, tt.header)
+			}
+			router.ServeHTTP(w, req)
+
+			got := w.Header().Get(correlation.REQUEST_ID_HEADER)
+			if got == "" || got == tt.header {
+				t.Errorf("response header = %q, want a generated ID", got)
+			}
+			if received != got {
+				t.Errorf("request context ID = %q, want %q", received, got)
+			}
+		})
+	}
+}
+
+func TestRequestLogger_IncludesRequestID(t *testing.T) {
+	var output bytes.Buffer
+	previous := gin.DefaultWriter
+	gin.DefaultWriter = &output
+	defer func() { gin.DefaultWriter = previous }()
+
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), RequestLogger())
+	router.GET("/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set(correlation.REQUEST_ID_HEADER, "req-123")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !strings.Contains(output.String(), "[request_id=req-123]") {
+		t.Errorf("access log = %q, want the request ID", output.String())
+	}
+}
@@ -4,20 +4,57 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"microservice/infra/api/rest/handlers"
+	"microservice/infra/api/rest/middlewares"
+	"microservice/infra/auth"
+)
+
+var (
+	// Clientes só enxergam os próprios pedidos; o handler aplica o filtro
+	anyRole   = middlewares.RequireRoles(auth.ROLE_CUSTOMER, auth.ROLE_STAFF, auth.ROLE_ADMIN, auth.ROLE_SERVICE)
+	staffRole = middlewares.RequireRoles(auth.ROLE_STAFF, auth.ROLE_ADMIN, auth.ROLE_SERVICE)
+	adminRole = middlewares.RequireRoles(auth.ROLE_ADMIN)
+	// Apenas pessoas; serviços não aplicam cupons nem removem pedidos
+	userRole       = middlewares.RequireRoles(auth.ROLE_CUSTOMER, auth.ROLE_STAFF, auth.ROLE_ADMIN)
+	backOfficeRole = middlewares.RequireRoles(auth.ROLE_STAFF, auth.ROLE_ADMIN)
 )
 
 func RegisterOrderRoutes(router *gin.RouterGroup) {
 	handler := handlers.NewOrderHandler()
 
-	router.POST("", handler.Create)
-	router.GET("", handler.FindAll)
-	router.GET("/:id", handler.FindByID)
-	router.PUT("/:id", handler.Update)
-	router.PUT("/:id/status", handler.UpdateStatus)
-	router.DELETE("/:id", handler.Delete)
+	router.POST("", anyRole, handler.Create)
+	router.GET("", anyRole, handler.FindAll)
+	router.GET("/board", staffRole, handler.FindBoard)
+	router.GET("/by-code/:code", staffRole, handler.FindByPickupCode)
+	router.GET("/:id", anyRole, handler.FindByID)
+	router.GET("/:id/history", staffRole, handler.FindStatusHistory)
+	router.PUT("/:id", staffRole, handler.Update)
+	router.PUT("/:id/status", staffRole, handler.UpdateStatus)
+	router.POST("/:id/coupons", userRole, handler.ApplyCoupon)
+	router.POST("/:id/claim", middlewares.RequireRoles(auth.ROLE_CUSTOMER), handler.Claim)
+	router.POST("/:id/cancel", staffRole, handler.Cancel)
+	router.DELETE("/:id", backOfficeRole, handler.Delete)
+}
+
+// Rotas fora do AuthMiddleware; o acesso é pelo guest token
+func RegisterGuestOrderRoutes(router *gin.RouterGroup) {
+	handler := handlers.NewOrderHandler()
+
+	router.GET("/:id", handler.FindGuestOrder)
+}
+
+func RegisterAdminOrderRoutes(router *gin.RouterGroup) {
+	handler := handlers.NewOrderHandler()
+
+	router.POST("/:id/restore", adminRole, handler.Restore)
+}
+
+func RegisterCouponRoutes(router *gin.RouterGroup) {
+	handler := handlers.NewCouponHandler()
+
+	router.POST("", adminRole, handler.Create)
 }
 
 func RegisterOrderStatusRoutes(router *gin.RouterGroup) {
 	handler := handlers.NewOrderHandler()
-	router.GET("/", handler.FindAllStatus)
+	router.GET("/", anyRole, handler.FindAllStatus)
 }
@@ -35,3 +35,16 @@ func TestRegisterOrderStatusRoutes(t *testing.T) {
 
 	RegisterOrderStatusRoutes(group)
 }
+
+func TestRegisterCouponRoutes(t *testing.T) {
+	router := gin.New()
+	group := router.Group("/coupons")
+
+	defer func() {
+		if r := recover(); r != nil {
+			t.Log("RegisterCouponRoutes panicked as expected without database setup")
+		}
+	}()
+
+	RegisterCouponRoutes(group)
+}
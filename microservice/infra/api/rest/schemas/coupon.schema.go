@@ -0,0 +1,37 @@
+package schemas
+
+import "time"
+
+type CreateCouponSchema struct {
+	Code       string  `json:"code" binding:"required,max=30"`
+	Type       string  `json:"type" binding:"required,oneof=percentage fixed_amount buy_x_get_y"`
+	Percentage int     `json:"percentage" binding:"omitempty,min=1,max=99"`
+	Amount     Decimal `json:"amount"`
+	Currency   string  `json:"currency" binding:"omitempty,len=3,uppercase"`
+
+	ProductID    string `json:"product_id"`
+	BuyQuantity  int    `json:"buy_quantity" binding:"omitempty,min=1"`
+	FreeQuantity int    `json:"free_quantity" binding:"omitempty,min=1"`
+
+	FirstOrderOnly bool       `json:"first_order_only"`
+	ValidFrom      *time.Time `json:"valid_from"`
+	ValidUntil     *time.Time `json:"valid_until"`
+	MaxUses        int        `json:"max_uses" binding:"min=0"` // 0 é ilimitado
+}
+
+type CouponResponseSchema struct {
+	Code           string     `json:"code"`
+	Type           string     `json:"type"`
+	Percentage     int        `json:"percentage,omitempty"`
+	Amount         *Decimal   `json:"amount,omitempty"`
+	Currency       string     `json:"currency"`
+	ProductID      string     `json:"product_id,omitempty"`
+	BuyQuantity    int        `json:"buy_quantity,omitempty"`
+	FreeQuantity   int        `json:"free_quantity,omitempty"`
+	FirstOrderOnly bool       `json:"first_order_only"`
+	ValidFrom      *time.Time `json:"valid_from"`
+	ValidUntil     *time.Time `json:"valid_until"`
+	MaxUses        int        `json:"max_uses"`
+	UsedCount      int        `json:"used_count"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
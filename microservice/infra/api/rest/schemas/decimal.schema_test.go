@@ -0,0 +1,45 @@
+package schemas
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecimal_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Decimal
+	}{
+		{"string", `"19.99"`, "19.99"},
+		{"number keeps its digits", `92233720368547758.07`, "92233720368547758.07"},
+		{"integer", `10`, "10"},
+		{"null", `null`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value Decimal
+			err := json.Unmarshal([]byte(tt.input), &value)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestDecimal_UnmarshalJSON_Invalid(t *testing.T) {
+	var value Decimal
+	err := json.Unmarshal([]byte(`true`), &value)
+
+	assert.Error(t, err)
+}
+
+func TestDecimal_MarshalJSON(t *testing.T) {
+	body, err := json.Marshal(OrderItemResponseSchema{UnitPrice: "19.90"})
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"unit_price":"19.90"`)
+}
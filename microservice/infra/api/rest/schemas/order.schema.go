@@ -3,42 +3,127 @@ package schemas
 import "time"
 
 type CreateOrderItemSchema struct {
-	ProductID string  `json:"product_id" binding:"required"`
-	Quantity  int     `json:"quantity" binding:"required,min=1"`
-	Price     float64 `json:"price" binding:"required,min=0"`
+	ProductID string                          `json:"product_id" binding:"required"`
+	Quantity  int                             `json:"quantity" binding:"required,min=1"`
+	Price     Decimal                         `json:"price"` // opcional: vale o preço do catálogo; se enviado e diferente, o pedido é rejeitado
+	Notes     string                          `json:"notes" binding:"max=200"`
+	Modifiers []CreateOrderItemModifierSchema `json:"modifiers" binding:"max=10,dive"`
+}
+
+type CreateOrderItemModifierSchema struct {
+	ID         string  `json:"id" binding:"required"`
+	PriceDelta Decimal `json:"price_delta"` // opcional, conferido com o catálogo
 }
 
 type CreateOrderSchema struct {
-	CustomerID *string                 `json:"customer_id"`
-	Items      []CreateOrderItemSchema `json:"items" binding:"required,min=1"`
+	CustomerID   *string                 `json:"customer_id"`
+	CustomerName string                  `json:"customer_name" binding:"max=50"`
+	Currency     string                  `json:"currency" binding:"omitempty,len=3,uppercase"`
+	Items        []CreateOrderItemSchema `json:"items" binding:"required,min=1,dive"`
 }
 
 type UpdateOrderSchema struct {
 	StatusID string `json:"status_id" binding:"required"`
 }
 
+type ApplyCouponSchema struct {
+	Code string `json:"code" binding:"required,max=30"`
+}
+
+type ClaimOrderSchema struct {
+	GuestToken string `json:"guest_token" binding:"required,max=64"`
+}
+
+type CancelOrderSchema struct {
+	Reason string `json:"reason" binding:"required,max=255"`
+}
+
 type UpdateOrderStatusSchema struct {
 	Status string `json:"status" binding:"required"`
 }
 
 type OrderItemResponseSchema struct {
-	ID        string  `json:"id"`
-	ProductID string  `json:"product_id"`
-	Quantity  int     `json:"quantity"`
-	UnitPrice float64 `json:"unit_price"`
+	ID        string                            `json:"id"`
+	ProductID string                            `json:"product_id"`
+	Product   ProductSnapshotResponseSchema     `json:"product"`
+	Quantity  int                               `json:"quantity"`
+	UnitPrice Decimal                           `json:"unit_price"`
+	Notes     string                            `json:"notes"`
+	Modifiers []OrderItemModifierResponseSchema `json:"modifiers"`
+	// Itens de um combo; o unit_price deles é o preço avulso, não cobrado
+	Components []OrderItemResponseSchema `json:"components,omitempty"`
+}
+
+type OrderItemModifierResponseSchema struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	PriceDelta Decimal `json:"price_delta"`
+}
+
+type ProductSnapshotResponseSchema struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	ImageURL string `json:"image_url"`
+	Notes    string `json:"notes"`
 }
 
 type OrderResponseSchema struct {
 	ID         string                    `json:"id"`
 	CustomerID *string                   `json:"customer_id"`
-	Amount     float64                   `json:"amount"`
+	Amount     Decimal                   `json:"amount"`
+	Currency   string                    `json:"currency"`
+	Subtotal   Decimal                   `json:"subtotal"`
+	Discount   Decimal                   `json:"discount"`
+	CouponCode *string                   `json:"coupon_code"`
+	PickupCode string                    `json:"pickup_code,omitempty"`
 	Status     string                    `json:"status"`
 	Items      []OrderItemResponseSchema `json:"items"`
 	CreatedAt  time.Time                 `json:"created_at"`
 	UpdatedAt  *time.Time                `json:"updated_at"`
+
+	CustomerName string `json:"customer_name,omitempty"`
+	// Só na criação de pedidos de convidado
+	GuestToken string `json:"guest_token,omitempty"`
+
+	CancellationReason *string    `json:"cancellation_reason,omitempty"`
+	CancelledAt        *time.Time `json:"cancelled_at,omitempty"`
+}
+
+type OrderPageResponseSchema struct {
+	Data       []OrderResponseSchema `json:"data"`
+	NextCursor *string               `json:"next_cursor"`
+	Total      int64                 `json:"total"`
+}
+
+type OrderBoardResponseSchema struct {
+	GeneratedAt time.Time               `json:"generated_at"`
+	Groups      []OrderBoardGroupSchema `json:"groups"`
+}
+
+type OrderBoardGroupSchema struct {
+	StatusID string                  `json:"status_id"`
+	Status   string                  `json:"status"`
+	Orders   []OrderBoardEntrySchema `json:"orders"`
+}
+
+type OrderBoardEntrySchema struct {
+	ID           string    `json:"id"`
+	PickupCode   string    `json:"pickup_code,omitempty"`
+	CustomerName string    `json:"customer_name,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	WaitSeconds  int64     `json:"wait_seconds"`
 }
 
 type OrderStatusResponseSchema struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
 }
+
+type OrderStatusHistoryResponseSchema struct {
+	ID             string    `json:"id"`
+	PreviousStatus *string   `json:"previous_status"`
+	Status         string    `json:"status"`
+	Source         string    `json:"source"`
+	Actor          *string   `json:"actor"`
+	ChangedAt      time.Time `json:"changed_at"`
+}
@@ -9,7 +9,7 @@ func TestCreateOrderItemSchema_Structure(t *testing.T) {
 	schema := CreateOrderItemSchema{
 		ProductID: "product-123",
 		Quantity:  2,
-		Price:     25.50,
+		Price:     "25.50",
 	}
 
 	if schema.ProductID != "product-123" {
@@ -18,7 +18,7 @@ func TestCreateOrderItemSchema_Structure(t *testing.T) {
 	if schema.Quantity != 2 {
 		t.Errorf("CreateOrderItemSchema.Quantity = %v, want 2", schema.Quantity)
 	}
-	if schema.Price != 25.50 {
+	if schema.Price != "25.50" {
 		t.Errorf("CreateOrderItemSchema.Price = %v, want 25.50", schema.Price)
 	}
 }
@@ -28,7 +28,7 @@ func TestCreateOrderSchema_Structure(t *testing.T) {
 	schema := CreateOrderSchema{
 		CustomerID: &customerID,
 		Items: []CreateOrderItemSchema{
-			{ProductID: "product-1", Quantity: 1, Price: 10.0},
+			{ProductID: "product-1", Quantity: 1, Price: "10.00"},
 		},
 	}
 
@@ -44,7 +44,7 @@ func TestCreateOrderSchema_NilCustomerID(t *testing.T) {
 	schema := CreateOrderSchema{
 		CustomerID: nil,
 		Items: []CreateOrderItemSchema{
-			{ProductID: "product-1", Quantity: 1, Price: 10.0},
+			{ProductID: "product-1", Quantity: 1, Price: "10.00"},
 		},
 	}
 
@@ -68,7 +68,7 @@ func TestOrderItemResponseSchema_Structure(t *testing.T) {
 		ID:        "item-1",
 		ProductID: "product-1",
 		Quantity:  2,
-		UnitPrice: 10.0,
+		UnitPrice: "10.00",
 	}
 
 	if schema.ID != "item-1" {
@@ -80,7 +80,7 @@ func TestOrderItemResponseSchema_Structure(t *testing.T) {
 	if schema.Quantity != 2 {
 		t.Errorf("OrderItemResponseSchema.Quantity = %v, want 2", schema.Quantity)
 	}
-	if schema.UnitPrice != 10.0 {
+	if schema.UnitPrice != "10.00" {
 		t.Errorf("OrderItemResponseSchema.UnitPrice = %v, want 10.0", schema.UnitPrice)
 	}
 }
@@ -93,10 +93,10 @@ func TestOrderResponseSchema_Structure(t *testing.T) {
 	schema := OrderResponseSchema{
 		ID:         "order-1",
 		CustomerID: &customerID,
-		Amount:     100.0,
+		Amount:     "100.00",
 		Status:     "Pending",
 		Items: []OrderItemResponseSchema{
-			{ID: "item-1", ProductID: "product-1", Quantity: 2, UnitPrice: 50.0},
+			{ID: "item-1", ProductID: "product-1", Quantity: 2, UnitPrice: "50.00"},
 		},
 		CreatedAt: now,
 		UpdatedAt: &updatedAt,
@@ -108,7 +108,7 @@ func TestOrderResponseSchema_Structure(t *testing.T) {
 	if *schema.CustomerID != customerID {
 		t.Errorf("OrderResponseSchema.CustomerID = %v, want %v", *schema.CustomerID, customerID)
 	}
-	if schema.Amount != 100.0 {
+	if schema.Amount != "100.00" {
 		t.Errorf("OrderResponseSchema.Amount = %v, want 100.0", schema.Amount)
 	}
 	if schema.Status != "Pending" {
@@ -128,7 +128,7 @@ func TestOrderResponseSchema_NilCustomerID(t *testing.T) {
 	schema := OrderResponseSchema{
 		ID:         "order-1",
 		CustomerID: nil,
-		Amount:     100.0,
+		Amount:     "100.00",
 		Status:     "Pending",
 		Items:      []OrderItemResponseSchema{},
 		CreatedAt:  now,
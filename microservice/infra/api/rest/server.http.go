@@ -2,34 +2,52 @@ package rest
 
 import (
 	"context"
+	"errors"
 	"log"
+	"net/http"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"microservice/infra/api/rest/handlers"
 	"microservice/infra/api/rest/middlewares"
 	"microservice/infra/api/rest/routes"
+	"microservice/infra/auth"
+	"microservice/infra/catalog"
 	"microservice/infra/db/postgres"
 	"microservice/infra/db/postgres/data_source"
 	"microservice/infra/messaging"
 	"microservice/internal/adapters/consumers"
 	"microservice/internal/adapters/gateways"
+	"microservice/internal/adapters/jobs"
+	"microservice/internal/adapters/relays"
+	"microservice/internal/use_cases"
 	"microservice/utils/config"
 )
 
 func NewRouter() *gin.Engine {
-	ginRouter := gin.Default()
+	ginRouter := gin.New()
 
-	ginRouter.Use(gin.Logger())
-	ginRouter.Use(gin.Recovery())
+	ginRouter.Use(middlewares.RequestIDMiddleware())
+	ginRouter.Use(middlewares.RequestLogger())
+	ginRouter.Use(middlewares.RecoveryMiddleware())
 	ginRouter.Use(middlewares.ErrorHandlerMiddleware())
 
 	healthHandler := handlers.NewHealthHandler()
 	ginRouter.GET("/health", healthHandler.Health)
 
 	v1Routes := ginRouter.Group("/v1")
-	routes.RegisterOrderRoutes(v1Routes.Group("/orders"))
-	routes.RegisterOrderStatusRoutes(v1Routes.Group("/orders/status"))
+	// Convidados se autenticam pelo guest token do pedido
+	routes.RegisterGuestOrderRoutes(v1Routes.Group("/guest/orders"))
+
+	authenticated := v1Routes.Group("", middlewares.AuthMiddleware(auth.GetVerifier()))
+	routes.RegisterOrderRoutes(authenticated.Group("/orders"))
+	routes.RegisterOrderStatusRoutes(authenticated.Group("/orders/status"))
+	routes.RegisterCouponRoutes(authenticated.Group("/coupons"))
+	routes.RegisterAdminOrderRoutes(authenticated.Group("/admin/orders"))
 
 	return ginRouter
 }
@@ -48,6 +66,14 @@ func Init() {
 		postgres.RunMigrations()
 	}
 
+	catalog.Connect()
+	auth.Connect()
+
+	// Consumers e relay param quando o contexto é cancelado no encerramento
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	var workers sync.WaitGroup
+
 	err := messaging.Connect()
 	if err != nil {
 		log.Printf("Warning: Failed to connect to message broker: %v", err)
@@ -58,7 +84,7 @@ func Init() {
 		// Inicializar OrderUpdatesConsumer se broker estiver disponível
 		broker := messaging.GetBroker()
 		if broker != nil {
-			ctx := context.Background()
+			ctx := workersCtx
 			
 			// Criar datasources e gateways necessários
 			orderDataSource := data_source.NewGormOrderDataSource()
@@ -67,7 +93,7 @@ func Init() {
 			orderStatusGateway := gateways.NewOrderStatusGateway(orderStatusDataSource)
 			
 			// Criar consumer para atualizações de pedidos vindas do Kitchen Order
-			orderUpdatesConsumer := consumers.NewOrderUpdatesConsumer(broker, orderGateway, orderStatusGateway)
+			orderUpdatesConsumer := consumers.NewOrderUpdatesConsumer(broker, orderGateway, orderStatusGateway, data_source.NewGormProcessedMessageDataSource())
 			
 			go func() {
 				if err := orderUpdatesConsumer.Start(ctx); err != nil {
@@ -76,11 +102,116 @@ func Init() {
 			}()
 			
 			log.Println("Order updates consumer started successfully")
+
+			// Criar consumer para confirmações vindas do serviço de pagamentos
+			paymentConfirmationsConsumer := consumers.NewPaymentConfirmationsConsumer(
+				broker,
+				use_cases.NewProcessPaymentConfirmationUseCase(orderGateway, orderStatusGateway),
+			)
+
+			if err := paymentConfirmationsConsumer.Start(ctx); err != nil {
+				log.Printf("Failed to start payment confirmations consumer: %v", err)
+			} else {
+				log.Println("Payment confirmations consumer started successfully")
+			}
+
+			// Publicar os eventos gravados na outbox
+			outboxRelay := relays.NewOutboxRelay(broker, data_source.NewGormOutboxDataSource(), relays.OutboxRelayConfig{
+				PollInterval:  cfg.Outbox.PollInterval,
+				BatchSize:     cfg.Outbox.BatchSize,
+				MaxAttempts:   cfg.Outbox.MaxAttempts,
+				LeaseDuration: cfg.Outbox.LeaseDuration,
+			})
+			workers.Add(1)
+			go func() {
+				defer workers.Done()
+				outboxRelay.Start(ctx)
+			}()
+
+			log.Println("Outbox relay started successfully")
 		}
 	}
 
-	ginRouter := NewRouter()
-	if err := ginRouter.Run(":" + cfg.APIPort); err != nil {
+	// Expurgar pedidos removidos após o período de retenção
+	if cfg.Retention.DeletedOrders > 0 {
+		retentionJob := jobs.NewOrderRetentionJob(
+			use_cases.NewPurgeDeletedOrdersUseCase(gateways.NewOrderGateway(data_source.NewGormOrderDataSource()), cfg.Retention.DeletedOrders, use_cases.DEFAULT_PURGE_BATCH_SIZE),
+			cfg.Retention.PurgeInterval,
+		)
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			retentionJob.Start(workersCtx)
+		}()
+	}
+
+	// Expurgar chaves de idempotência expiradas
+	idempotencyRetentionJob := jobs.NewIdempotencyKeyRetentionJob(data_source.NewGormIdempotencyDataSource(), cfg.Idempotency.PurgeInterval)
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		idempotencyRetentionJob.Start(workersCtx)
+	}()
+
+	handlers.ConfigureIdempotency(cfg.Idempotency.KeyTTL, cfg.Idempotency.LockTimeout)
+	handlers.ConfigurePickupLocation(cfg.RestaurantLocation)
+
+	server := &http.Server{
+		Addr:    ":" + cfg.APIPort,
+		Handler: NewRouter(),
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stopSignals()
+
+	select {
+	case <-signalCtx.Done():
+		log.Println("Shutdown signal received, draining requests and messages...")
+	case err := <-serverErr:
+		shutdown(server, stopWorkers, &workers, cfg.ShutdownTimeout)
 		log.Fatalf("failed to start gin server: %v", err)
 	}
+
+	shutdown(server, stopWorkers, &workers, cfg.ShutdownTimeout)
+	log.Println("Shutdown completed")
+}
+
+// Para de aceitar requisições, espera as em andamento, para os consumers e fecha broker
+// e banco; etapas que passam do timeout são abandonadas
+func shutdown(server *http.Server, stopWorkers context.CancelFunc, workers *sync.WaitGroup, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("Error shutting down HTTP server: %v", err)
+	}
+
+	stopWorkers()
+	waitWithTimeout(ctx, "background workers", workers.Wait)
+	waitWithTimeout(ctx, "message broker", messaging.Close)
+
+	postgres.Close()
+}
+
+func waitWithTimeout(ctx context.Context, name string, fn func()) bool {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		log.Printf("Timed out waiting for %s to stop", name)
+		return false
+	}
 }
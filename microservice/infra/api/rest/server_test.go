@@ -2,7 +2,10 @@ package rest
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -386,4 +389,62 @@ func TestInit_ContextHandling(t *testing.T) {
 	default:
 		t.Error("Context should be cancelled")
 	}
-}
\ No newline at end of file
+}
+func TestShutdown_StopsWorkersAndWaitsForThem(t *testing.T) {
+	server := &http.Server{Addr: ":0"}
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+
+	stopped := false
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		<-workersCtx.Done()
+		time.Sleep(20 * time.Millisecond)
+		stopped = true
+	}()
+
+	shutdown(server, stopWorkers, &workers, time.Second)
+
+	assert.True(t, stopped)
+}
+
+func TestWaitWithTimeout_ReturnsWhenFunctionFinishes(t *testing.T) {
+	ok := waitWithTimeout(context.Background(), "test", func() {})
+
+	assert.True(t, ok)
+}
+
+func TestWaitWithTimeout_GivesUpAfterTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	release := make(chan struct{})
+	defer close(release)
+
+	ok := waitWithTimeout(ctx, "test", func() { <-release })
+
+	assert.False(t, ok)
+}
+
+func TestNewRouter_RequiresAuthentication(t *testing.T) {
+	router := NewRouter()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/v1/orders", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/health", nil))
+	assert.NotEqual(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestNewRouter_GuestOrdersUseGuestToken(t *testing.T) {
+	router := NewRouter()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/v1/guest/orders/invalid-id", nil)
+	req.Header.Set("X-Guest-Token", "token")
+	router.ServeHTTP(w, req)
+
+	assert.NotEqual(t, http.StatusUnauthorized, w.Code)
+}
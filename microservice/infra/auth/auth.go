@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"log"
+
+	"microservice/utils/config"
+)
+
+const (
+	AUTH_MODE_COGNITO = "cognito"
+	AUTH_MODE_STATIC  = "static"
+)
+
+var (
+	verifier TokenVerifier
+)
+
+func Connect() {
+	cfg := config.LoadConfig()
+
+	if cfg.Auth.Mode == AUTH_MODE_STATIC {
+		if cfg.IsProduction() {
+			log.Fatal("AUTH_MODE=static is not allowed in production")
+		}
+		if cfg.Auth.StaticSecret == "" {
+			log.Fatal("AUTH_STATIC_SECRET must be set when AUTH_MODE=static")
+		}
+		log.Println("Using static key authentication")
+		verifier = NewJWTVerifier(NewStaticKeySource(cfg.Auth.StaticSecret), cfg.Auth.StaticIssuer, cfg.Auth.ClientIDs, cfg.Auth.ServiceClientIDs)
+		return
+	}
+
+	if cfg.Auth.UserPoolID == "" || len(cfg.Auth.ClientIDs) == 0 {
+		log.Fatal("AWS_COGNITO_USER_POOL_ID and AWS_COGNITO_USER_POOL_CLIENT_ID must be set")
+	}
+	log.Printf("Using Cognito user pool %s", cfg.Auth.UserPoolID)
+	keys := NewJWKSKeySource(CognitoJWKSURL(cfg.Auth.Region, cfg.Auth.UserPoolID), cfg.Auth.JWKSCacheTTL, cfg.Auth.JWKSTimeout)
+	verifier = NewJWTVerifier(keys, CognitoIssuer(cfg.Auth.Region, cfg.Auth.UserPoolID), cfg.Auth.ClientIDs, cfg.Auth.ServiceClientIDs)
+}
+
+func GetVerifier() TokenVerifier {
+	return verifier
+}
@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"os"
+	"testing"
+
+	"microservice/utils/config"
+)
+
+func TestConnect_Static(t *testing.T) {
+	env := map[string]string{
+		"GO_ENV":                          "test",
+		"API_PORT":                        "8080",
+		"API_HOST":                        "localhost",
+		"DB_RUN_MIGRATIONS":               "false",
+		"DB_HOST":                         "localhost",
+		"DB_NAME":                         "test_db",
+		"DB_PORT":                         "5432",
+		"DB_USERNAME":                     "test_user",
+		"DB_PASSWORD":                     "test_pass",
+		"AUTH_MODE":                       AUTH_MODE_STATIC,
+		"AUTH_STATIC_SECRET":              testSecret,
+		"AWS_COGNITO_USER_POOL_CLIENT_ID": testClientID,
+	}
+	for key, value := range env {
+		os.Setenv(key, value)
+	}
+	defer func() {
+		for key := range env {
+			os.Unsetenv(key)
+		}
+		verifier = nil
+	}()
+
+	config.LoadConfig()
+	Connect()
+
+	if GetVerifier() == nil {
+		t.Fatal("GetVerifier() = nil after Connect()")
+	}
+
+	token := signToken(t, ALG_HS256, "", []byte(testSecret), accessClaims(map[string]any{
+		"iss": "orders-local",
+		"exp": 4102444800, // 2100-01-01
+	}))
+	principal, err := GetVerifier().Verify(token)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if principal.Subject != "customer-123" {
+		t.Errorf("Verify() subject = %s, want customer-123", principal.Subject)
+	}
+}
@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Intervalo mínimo entre buscas das chaves, mesmo quando falham
+const JWKS_MIN_REFRESH_INTERVAL = time.Minute
+
+// Chaves em cache por ttl; um kid desconhecido antecipa a busca. Se a busca falha,
+// as chaves em cache continuam valendo
+type JWKSKeySource struct {
+	url    string
+	client *http.Client
+	ttl    time.Duration
+
+	mu          sync.Mutex
+	keys        map[string]*rsa.PublicKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+	now         func() time.Time
+
+	// Aberto enquanto uma busca está em andamento; fechado quando ela termina
+	refreshing chan struct{}
+}
+
+type jwksResponse struct {
+	Keys []struct {
+		KeyID   string `json:"kid"`
+		KeyType string `json:"kty"`
+		Use     string `json:"use"`
+		N       string `json:"n"`
+		E       string `json:"e"`
+	} `json:"keys"`
+}
+
+func NewJWKSKeySource(url string, ttl time.Duration, timeout time.Duration) *JWKSKeySource {
+	return &JWKSKeySource{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+		ttl:    ttl,
+		keys:   make(map[string]*rsa.PublicKey),
+		now:    time.Now,
+	}
+}
+
+func CognitoJWKSURL(region string, userPoolID string) string {
+	return CognitoIssuer(region, userPoolID) + "/.well-known/jwks.json"
+}
+
+func CognitoIssuer(region string, userPoolID string) string {
+	return fmt.Sprintf("https://cognito-idp.%s.amazonaws.com/%s", region, userPoolID)
+}
+
+// A busca é feita fora do lock, para não segurar quem já acha a chave em cache
+func (s *JWKSKeySource) Key(kid string) (any, error) {
+	s.mu.Lock()
+	now := s.now()
+	key, known := s.keys[kid]
+	if known && now.Sub(s.fetchedAt) < s.ttl {
+		s.mu.Unlock()
+		return key, nil
+	}
+
+	// Outra requisição já está buscando: espera o resultado dela
+	if refreshing := s.refreshing; refreshing != nil {
+		s.mu.Unlock()
+		<-refreshing
+		return s.cachedKey(kid)
+	}
+
+	if now.Sub(s.attemptedAt) < JWKS_MIN_REFRESH_INTERVAL {
+		defer s.mu.Unlock()
+		return s.keyOrError(key)
+	}
+
+	s.attemptedAt = now
+	refreshing := make(chan struct{})
+	s.refreshing = refreshing
+	s.mu.Unlock()
+
+	keys, err := s.fetch()
+
+	s.mu.Lock()
+	if err != nil {
+		log.Printf("Failed to refresh JWKS from %s: %v", s.url, err)
+	} else {
+		s.keys = keys
+		s.fetchedAt = now
+	}
+	s.refreshing = nil
+	close(refreshing)
+	s.mu.Unlock()
+
+	return s.cachedKey(kid)
+}
+
+func (s *JWKSKeySource) cachedKey(kid string) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.keyOrError(s.keys[kid])
+}
+
+func (s *JWKSKeySource) keyOrError(key *rsa.PublicKey) (any, error) {
+	if len(s.keys) == 0 {
+		return nil, &InvalidTokenException{Message: "Token signing keys are unavailable"}
+	}
+	if key == nil {
+		return nil, &InvalidTokenException{Message: "Token signed with an unknown key"}
+	}
+	return key, nil
+}
+
+func (s *JWKSKeySource) fetch() (map[string]*rsa.PublicKey, error) {
+	response, err := s.client.Get(s.url)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("JWKS endpoint returned status %d", response.StatusCode)
+	}
+
+	var body jwksResponse
+	if err := json.NewDecoder(response.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("invalid JWKS response: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(body.Keys))
+	for _, jwk := range body.Keys {
+		if jwk.KeyType != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		key, err := parseRSAPublicKey(jwk.N, jwk.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %s: %w", jwk.KeyID, err)
+		}
+		keys[jwk.KeyID] = key
+	}
+	return keys, nil
+}
+
+func parseRSAPublicKey(modulus string, exponent string) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(modulus)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(exponent)
+	if err != nil {
+		return nil, err
+	}
+	if len(e) == 0 || len(e) > 4 {
+		return nil, fmt.Errorf("unsupported exponent size %d", len(e))
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
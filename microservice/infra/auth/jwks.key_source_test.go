@@ -0,0 +1,229 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type jwksServer struct {
+	*httptest.Server
+	requests atomic.Int32
+	failing  atomic.Bool
+	keys     map[string]*rsa.PublicKey
+	// Quando definido, as respostas esperam até ele ser fechado
+	release chan struct{}
+}
+
+func newJWKSServer(t *testing.T, keys map[string]*rsa.PublicKey) *jwksServer {
+	t.Helper()
+
+	server := &jwksServer{keys: keys}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.requests.Add(1)
+		if server.release != nil {
+			<-server.release
+		}
+		if server.failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body := map[string][]map[string]string{"keys": {}}
+		for kid, key := range server.keys {
+			body["keys"] = append(body["keys"], map[string]string{
+				"kid": kid,
+				"kty": "RSA",
+				"alg": ALG_RS256,
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestKeySource(url string, now *time.Time) *JWKSKeySource {
+	source := NewJWKSKeySource(url, time.Hour, time.Second)
+	source.now = func() time.Time { return *now }
+	return source
+}
+
+func generateKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	return key
+}
+
+func TestJWKSKeySource_Key_Cached(t *testing.T) {
+	privateKey := generateKey(t)
+	server := newJWKSServer(t, map[string]*rsa.PublicKey{"key-1": &privateKey.PublicKey})
+	now := testNow
+	source := newTestKeySource(server.URL, &now)
+
+	for i := 0; i < 3; i++ {
+		key, err := source.Key("key-1")
+		if err != nil {
+			t.Fatalf("Key() error = %v", err)
+		}
+		if !key.(*rsa.PublicKey).Equal(&privateKey.PublicKey) {
+			t.Fatal("Key() returned a different key")
+		}
+	}
+	if got := server.requests.Load(); got != 1 {
+		t.Errorf("JWKS requests = %d, want 1", got)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, err := source.Key("key-1"); err != nil {
+		t.Fatalf("Key() after ttl error = %v", err)
+	}
+	if got := server.requests.Load(); got != 2 {
+		t.Errorf("JWKS requests after ttl = %d, want 2", got)
+	}
+}
+
+func TestJWKSKeySource_Key_RotatedKey(t *testing.T) {
+	oldKey := generateKey(t)
+	server := newJWKSServer(t, map[string]*rsa.PublicKey{"key-1": &oldKey.PublicKey})
+	now := testNow
+	source := newTestKeySource(server.URL, &now)
+
+	if _, err := source.Key("key-1"); err != nil {
+		t.Fatalf("Key() error = %v", err)
+	}
+
+	newKey := generateKey(t)
+	server.keys = map[string]*rsa.PublicKey{"key-1": &oldKey.PublicKey, "key-2": &newKey.PublicKey}
+
+	// Recém-buscadas: a chave nova só aparece depois do intervalo mínimo
+	if _, err := source.Key("key-2"); err == nil {
+		t.Error("Key() found a key before the minimum refresh interval")
+	}
+
+	now = now.Add(JWKS_MIN_REFRESH_INTERVAL)
+	key, err := source.Key("key-2")
+	if err != nil {
+		t.Fatalf("Key() rotated key error = %v", err)
+	}
+	if !key.(*rsa.PublicKey).Equal(&newKey.PublicKey) {
+		t.Error("Key() returned a different key")
+	}
+	if got := server.requests.Load(); got != 2 {
+		t.Errorf("JWKS requests = %d, want 2", got)
+	}
+}
+
+func TestJWKSKeySource_Key_RefreshDoesNotBlockCachedKeys(t *testing.T) {
+	oldKey := generateKey(t)
+	newKey := generateKey(t)
+	server := newJWKSServer(t, map[string]*rsa.PublicKey{"key-1": &oldKey.PublicKey})
+	now := testNow
+	source := newTestKeySource(server.URL, &now)
+
+	if _, err := source.Key("key-1"); err != nil {
+		t.Fatalf("Key() error = %v", err)
+	}
+
+	server.keys = map[string]*rsa.PublicKey{"key-1": &oldKey.PublicKey, "key-2": &newKey.PublicKey}
+	server.release = make(chan struct{})
+	now = now.Add(JWKS_MIN_REFRESH_INTERVAL)
+
+	results := make(chan error, 2)
+	lookup := func() {
+		_, err := source.Key("key-2")
+		results <- err
+	}
+	go lookup()
+	for server.requests.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	go lookup()
+
+	// A busca está parada no servidor, mas a chave em cache continua disponível
+	cached := make(chan error, 1)
+	go func() {
+		_, err := source.Key("key-1")
+		cached <- err
+	}()
+	select {
+	case err := <-cached:
+		if err != nil {
+			t.Fatalf("Key() cached key error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Key() for a cached key waited for the refresh")
+	}
+
+	close(server.release)
+	for i := 0; i < 2; i++ {
+		if err := <-results; err != nil {
+			t.Errorf("Key() rotated key error = %v", err)
+		}
+	}
+	if got := server.requests.Load(); got != 2 {
+		t.Errorf("JWKS requests = %d, want 2", got)
+	}
+}
+
+func TestJWKSKeySource_Key_StaleOnFailure(t *testing.T) {
+	privateKey := generateKey(t)
+	server := newJWKSServer(t, map[string]*rsa.PublicKey{"key-1": &privateKey.PublicKey})
+	now := testNow
+	source := newTestKeySource(server.URL, &now)
+
+	if _, err := source.Key("key-1"); err != nil {
+		t.Fatalf("Key() error = %v", err)
+	}
+
+	server.failing.Store(true)
+	now = now.Add(2 * time.Hour)
+
+	if _, err := source.Key("key-1"); err != nil {
+		t.Errorf("Key() with JWKS unavailable error = %v, want the cached key", err)
+	}
+	if _, err := source.Key("key-1"); err != nil {
+		t.Errorf("Key() with JWKS unavailable error = %v, want the cached key", err)
+	}
+	if got := server.requests.Load(); got != 2 {
+		t.Errorf("JWKS requests = %d, want 2", got)
+	}
+}
+
+func TestJWKSKeySource_Key_Unavailable(t *testing.T) {
+	server := newJWKSServer(t, nil)
+	server.failing.Store(true)
+	now := testNow
+	source := newTestKeySource(server.URL, &now)
+
+	_, err := source.Key("key-1")
+
+	var invalid *InvalidTokenException
+	if !errors.As(err, &invalid) || invalid.Message != "Token signing keys are unavailable" {
+		t.Errorf("Key() error = %v, want signing keys unavailable", err)
+	}
+}
+
+func TestCognitoURLs(t *testing.T) {
+	if got := CognitoIssuer("us-east-2", "us-east-2_abc"); got != "https://cognito-idp.us-east-2.amazonaws.com/us-east-2_abc" {
+		t.Errorf("CognitoIssuer() = %s", got)
+	}
+	if got := CognitoJWKSURL("us-east-2", "us-east-2_abc"); got != "https://cognito-idp.us-east-2.amazonaws.com/us-east-2_abc/.well-known/jwks.json" {
+		t.Errorf("CognitoJWKSURL() = %s", got)
+	}
+}
@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	ALG_RS256 = "RS256"
+	ALG_HS256 = "HS256"
+
+	TOKEN_USE_ACCESS = "access"
+	TOKEN_USE_ID     = "id"
+
+	// Tolerância para relógios fora de sincronia
+	CLOCK_SKEW = 30 * time.Second
+)
+
+type InvalidTokenException struct {
+	Message string
+}
+
+func (e *InvalidTokenException) Error() string {
+	if e.Message == "" {
+		return "Invalid token"
+	}
+	return e.Message
+}
+
+type TokenVerifier interface {
+	Verify(token string) (Principal, error)
+}
+
+// *rsa.PublicKey para RS256 ou []byte para HS256
+type KeySource interface {
+	Key(kid string) (any, error)
+}
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+// Claims usadas dos tokens do Cognito. Tokens de acesso trazem client_id e
+// tokens de ID trazem aud.
+type jwtClaims struct {
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss"`
+	Audience  string   `json:"aud"`
+	ClientID  string   `json:"client_id"`
+	TokenUse  string   `json:"token_use"`
+	ExpiresAt int64    `json:"exp"`
+	NotBefore int64    `json:"nbf"`
+	Groups    []string `json:"cognito:groups"`
+}
+
+// Tokens de serviceClientIDs recebem o papel de serviço; os de clientIDs, os grupos
+// do Cognito, ou customer quando não têm grupo
+type JWTVerifier struct {
+	keys             KeySource
+	issuer           string
+	clientIDs        []string
+	serviceClientIDs []string
+	now              func() time.Time
+}
+
+func NewJWTVerifier(keys KeySource, issuer string, clientIDs []string, serviceClientIDs []string) *JWTVerifier {
+	return &JWTVerifier{
+		keys:             keys,
+		issuer:           issuer,
+		clientIDs:        clientIDs,
+		serviceClientIDs: serviceClientIDs,
+		now:              time.Now,
+	}
+}
+
+func (v *JWTVerifier) Verify(token string) (Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Principal{}, &InvalidTokenException{Message: "Malformed token"}
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return Principal{}, err
+	}
+
+	key, err := v.keys.Key(header.KeyID)
+	if err != nil {
+		return Principal{}, err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Principal{}, &InvalidTokenException{Message: "Malformed token signature"}
+	}
+	if err := verifySignature(header.Algorithm, key, parts[0]+"."+parts[1], signature); err != nil {
+		return Principal{}, err
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return Principal{}, err
+	}
+	if err := v.validateClaims(claims); err != nil {
+		return Principal{}, err
+	}
+
+	return v.toPrincipal(claims)
+}
+
+// Só aceita o algoritmo do tipo da chave, para que o token não escolha um mais fraco
+func verifySignature(algorithm string, key any, signed string, signature []byte) error {
+	digest := sha256.Sum256([]byte(signed))
+
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if algorithm != ALG_RS256 {
+			break
+		}
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return &InvalidTokenException{Message: "Invalid token signature"}
+		}
+		return nil
+	case []byte:
+		if algorithm != ALG_HS256 {
+			break
+		}
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return &InvalidTokenException{Message: "Invalid token signature"}
+		}
+		return nil
+	}
+
+	return &InvalidTokenException{Message: fmt.Sprintf("Unsupported token algorithm %q", algorithm)}
+}
+
+func (v *JWTVerifier) validateClaims(claims jwtClaims) error {
+	now := v.now()
+
+	if claims.ExpiresAt == 0 || now.After(time.Unix(claims.ExpiresAt, 0).Add(CLOCK_SKEW)) {
+		return &InvalidTokenException{Message: "Token has expired"}
+	}
+	if claims.NotBefore != 0 && now.Add(CLOCK_SKEW).Before(time.Unix(claims.NotBefore, 0)) {
+		return &InvalidTokenException{Message: "Token is not valid yet"}
+	}
+	if claims.Issuer != v.issuer {
+		return &InvalidTokenException{Message: "Token issuer is not trusted"}
+	}
+	if claims.Subject == "" {
+		return &InvalidTokenException{Message: "Token has no subject"}
+	}
+	if claims.TokenUse != TOKEN_USE_ACCESS && claims.TokenUse != TOKEN_USE_ID {
+		return &InvalidTokenException{Message: "Token use must be access or id"}
+	}
+	return nil
+}
+
+func (v *JWTVerifier) toPrincipal(claims jwtClaims) (Principal, error) {
+	clientID := claims.ClientID
+	if claims.TokenUse == TOKEN_USE_ID {
+		clientID = claims.Audience
+	}
+
+	principal := Principal{Subject: claims.Subject, ClientID: clientID}
+	switch {
+	case slices.Contains(v.serviceClientIDs, clientID):
+		principal.Roles = []string{ROLE_SERVICE}
+	case slices.Contains(v.clientIDs, clientID):
+		for _, group := range claims.Groups {
+			if group == ROLE_CUSTOMER || group == ROLE_STAFF || group == ROLE_ADMIN {
+				principal.Roles = append(principal.Roles, group)
+			}
+		}
+		if len(principal.Roles) == 0 {
+			principal.Roles = []string{ROLE_CUSTOMER}
+		}
+	default:
+		return Principal{}, &InvalidTokenException{Message: "Token was issued to an unknown client"}
+	}
+
+	return principal, nil
+}
+
+func decodeSegment(segment string, target any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return &InvalidTokenException{Message: "Malformed token"}
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return &InvalidTokenException{Message: "Malformed token"}
+	}
+	return nil
+}
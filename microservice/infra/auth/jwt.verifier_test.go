@@ -0,0 +1,206 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+const (
+	testIssuer        = "https://cognito-idp.us-east-2.amazonaws.com/us-east-2_pool"
+	testClientID      = "web-client"
+	testServiceClient = "kitchen-service"
+	testSecret        = "test-secret"
+)
+
+var testNow = time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+type fakeKeySource struct {
+	keys map[string]any
+}
+
+func (f fakeKeySource) Key(kid string) (any, error) {
+	key, ok := f.keys[kid]
+	if !ok {
+		return nil, &InvalidTokenException{Message: "Token signed with an unknown key"}
+	}
+	return key, nil
+}
+
+func signToken(t *testing.T, alg string, kid string, key any, claims map[string]any) string {
+	t.Helper()
+
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	var signature []byte
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		digest := sha256.Sum256([]byte(signed))
+		var err error
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatalf("SignPKCS1v15() error = %v", err)
+		}
+	case []byte:
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(signed))
+		signature = mac.Sum(nil)
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func accessClaims(overrides map[string]any) map[string]any {
+	claims := map[string]any{
+		"sub":       "customer-123",
+		"iss":       testIssuer,
+		"client_id": testClientID,
+		"token_use": TOKEN_USE_ACCESS,
+		"exp":       testNow.Add(time.Hour).Unix(),
+	}
+	for key, value := range overrides {
+		if value == nil {
+			delete(claims, key)
+			continue
+		}
+		claims[key] = value
+	}
+	return claims
+}
+
+func newTestVerifier(t *testing.T) (*JWTVerifier, *rsa.PrivateKey) {
+	t.Helper()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	keys := fakeKeySource{keys: map[string]any{
+		"rsa-key":  &privateKey.PublicKey,
+		"hmac-key": []byte(testSecret),
+	}}
+
+	verifier := NewJWTVerifier(keys, testIssuer, []string{testClientID}, []string{testServiceClient})
+	verifier.now = func() time.Time { return testNow }
+	return verifier, privateKey
+}
+
+func TestJWTVerifier_Verify_RS256(t *testing.T) {
+	verifier, privateKey := newTestVerifier(t)
+
+	principal, err := verifier.Verify(signToken(t, ALG_RS256, "rsa-key", privateKey, accessClaims(nil)))
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if principal.Subject != "customer-123" || principal.ClientID != testClientID {
+		t.Errorf("Verify() principal = %+v", principal)
+	}
+	if !slices.Equal(principal.Roles, []string{ROLE_CUSTOMER}) {
+		t.Errorf("Verify() roles = %v, want [customer]", principal.Roles)
+	}
+}
+
+func TestJWTVerifier_Verify_HS256(t *testing.T) {
+	verifier, _ := newTestVerifier(t)
+
+	principal, err := verifier.Verify(signToken(t, ALG_HS256, "hmac-key", []byte(testSecret), accessClaims(nil)))
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if principal.Subject != "customer-123" {
+		t.Errorf("Verify() subject = %s, want customer-123", principal.Subject)
+	}
+}
+
+func TestJWTVerifier_Verify_Roles(t *testing.T) {
+	verifier, privateKey := newTestVerifier(t)
+
+	tests := []struct {
+		name   string
+		claims map[string]any
+		want   []string
+	}{
+		{"groups", accessClaims(map[string]any{"cognito:groups": []string{"staff", "admin", "beta-testers"}}), []string{ROLE_STAFF, ROLE_ADMIN}},
+		{"unknown groups only", accessClaims(map[string]any{"cognito:groups": []string{"beta-testers"}}), []string{ROLE_CUSTOMER}},
+		{"service client", accessClaims(map[string]any{"client_id": testServiceClient, "cognito:groups": []string{"admin"}}), []string{ROLE_SERVICE}},
+		{"id token", accessClaims(map[string]any{"token_use": TOKEN_USE_ID, "client_id": nil, "aud": testClientID, "cognito:groups": []string{"staff"}}), []string{ROLE_STAFF}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := verifier.Verify(signToken(t, ALG_RS256, "rsa-key", privateKey, tt.claims))
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if !slices.Equal(principal.Roles, tt.want) {
+				t.Errorf("Verify() roles = %v, want %v", principal.Roles, tt.want)
+			}
+		})
+	}
+}
+
+func TestJWTVerifier_Verify_Invalid(t *testing.T) {
+	verifier, privateKey := newTestVerifier(t)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"malformed", "not-a-token"},
+		{"expired", signToken(t, ALG_RS256, "rsa-key", privateKey, accessClaims(map[string]any{"exp": testNow.Add(-time.Minute).Unix()}))},
+		{"without expiration", signToken(t, ALG_RS256, "rsa-key", privateKey, accessClaims(map[string]any{"exp": nil}))},
+		{"not valid yet", signToken(t, ALG_RS256, "rsa-key", privateKey, accessClaims(map[string]any{"nbf": testNow.Add(time.Hour).Unix()}))},
+		{"wrong issuer", signToken(t, ALG_RS256, "rsa-key", privateKey, accessClaims(map[string]any{"iss": "https://evil.example.com"}))},
+		{"without subject", signToken(t, ALG_RS256, "rsa-key", privateKey, accessClaims(map[string]any{"sub": ""}))},
+		{"refresh token use", signToken(t, ALG_RS256, "rsa-key", privateKey, accessClaims(map[string]any{"token_use": "refresh"}))},
+		{"unknown client", signToken(t, ALG_RS256, "rsa-key", privateKey, accessClaims(map[string]any{"client_id": "other-client"}))},
+		{"unknown key", signToken(t, ALG_RS256, "other-key", privateKey, accessClaims(nil))},
+		{"wrong signature", signToken(t, ALG_RS256, "rsa-key", otherKey, accessClaims(nil))},
+		{"hmac with rsa key", signToken(t, ALG_HS256, "rsa-key", []byte(testSecret), accessClaims(nil))},
+		{"rsa with hmac key", signToken(t, ALG_RS256, "hmac-key", privateKey, accessClaims(nil))},
+		{"none algorithm", signToken(t, "none", "hmac-key", nil, accessClaims(nil))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verifier.Verify(tt.token)
+
+			var invalid *InvalidTokenException
+			if !errors.As(err, &invalid) {
+				t.Errorf("Verify() error = %v, want InvalidTokenException", err)
+			}
+		})
+	}
+}
+
+func TestJWTVerifier_Verify_ClockSkew(t *testing.T) {
+	verifier, privateKey := newTestVerifier(t)
+
+	token := signToken(t, ALG_RS256, "rsa-key", privateKey, accessClaims(map[string]any{"exp": testNow.Add(-10 * time.Second).Unix()}))
+	if _, err := verifier.Verify(token); err != nil {
+		t.Errorf("Verify() error = %v, want token accepted within the clock skew", err)
+	}
+}
+
+func TestInvalidTokenException_Error(t *testing.T) {
+	if got := (&InvalidTokenException{}).Error(); got != "Invalid token" {
+		t.Errorf("Error() = %q, want %q", got, "Invalid token")
+	}
+	if got := (&InvalidTokenException{Message: "Token has expired"}).Error(); got != "Token has expired" {
+		t.Errorf("Error() = %q, want %q", got, "Token has expired")
+	}
+}
@@ -0,0 +1,35 @@
+package auth
+
+import "slices"
+
+const (
+	ROLE_CUSTOMER = "customer"
+	ROLE_STAFF    = "staff"
+	ROLE_ADMIN    = "admin"
+	// Outros serviços autenticados pelo client credentials do Cognito
+	ROLE_SERVICE = "service"
+)
+
+type Principal struct {
+	// sub do token; para clientes é o CustomerID dos pedidos
+	Subject  string
+	ClientID string
+	Roles    []string
+}
+
+func (p Principal) HasRole(role string) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+func (p Principal) HasAnyRole(roles ...string) bool {
+	for _, role := range roles {
+		if p.HasRole(role) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Principal) IsCustomerOnly() bool {
+	return !p.HasAnyRole(ROLE_STAFF, ROLE_ADMIN, ROLE_SERVICE)
+}
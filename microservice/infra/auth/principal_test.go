@@ -0,0 +1,34 @@
+package auth
+
+import "testing"
+
+func TestPrincipal_IsCustomerOnly(t *testing.T) {
+	tests := []struct {
+		roles []string
+		want  bool
+	}{
+		{[]string{ROLE_CUSTOMER}, true},
+		{nil, true},
+		{[]string{ROLE_CUSTOMER, ROLE_STAFF}, false},
+		{[]string{ROLE_ADMIN}, false},
+		{[]string{ROLE_SERVICE}, false},
+	}
+
+	for _, tt := range tests {
+		principal := Principal{Subject: "user-1", Roles: tt.roles}
+		if got := principal.IsCustomerOnly(); got != tt.want {
+			t.Errorf("IsCustomerOnly() with roles %v = %v, want %v", tt.roles, got, tt.want)
+		}
+	}
+}
+
+func TestPrincipal_HasAnyRole(t *testing.T) {
+	principal := Principal{Subject: "user-1", Roles: []string{ROLE_STAFF}}
+
+	if !principal.HasAnyRole(ROLE_ADMIN, ROLE_STAFF) {
+		t.Error("HasAnyRole(admin, staff) = false, want true")
+	}
+	if principal.HasAnyRole(ROLE_ADMIN, ROLE_SERVICE) {
+		t.Error("HasAnyRole(admin, service) = true, want false")
+	}
+}
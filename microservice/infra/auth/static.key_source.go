@@ -0,0 +1,14 @@
+package auth
+
+// Segredo HS256 único, para execuções locais e testes sem Cognito
+type StaticKeySource struct {
+	secret []byte
+}
+
+func NewStaticKeySource(secret string) *StaticKeySource {
+	return &StaticKeySource{secret: []byte(secret)}
+}
+
+func (s *StaticKeySource) Key(kid string) (any, error) {
+	return s.secret, nil
+}
@@ -0,0 +1,29 @@
+package catalog
+
+import (
+	"log"
+
+	"microservice/internal/interfaces"
+	"microservice/utils/config"
+)
+
+var (
+	dataSource interfaces.IProductCatalogDataSource
+)
+
+func Connect() {
+	cfg := config.LoadConfig()
+
+	if cfg.ProductCatalog.Type == "memory" {
+		log.Println("Using in-memory product catalog")
+		dataSource = NewInMemoryProductCatalogDataSource(DefaultProducts()...)
+		return
+	}
+
+	log.Printf("Using products service at %s", cfg.ProductCatalog.BaseURL)
+	dataSource = NewHTTPProductCatalogDataSource(cfg.ProductCatalog.BaseURL, cfg.ProductCatalog.Timeout)
+}
+
+func GetDataSource() interfaces.IProductCatalogDataSource {
+	return dataSource
+}
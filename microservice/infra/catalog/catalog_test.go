@@ -0,0 +1,48 @@
+package catalog
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"microservice/utils/config"
+)
+
+func TestConnect_Memory(t *testing.T) {
+	os.Setenv("GO_ENV", "test")
+	os.Setenv("API_PORT", "8080")
+	os.Setenv("API_HOST", "localhost")
+	os.Setenv("DB_RUN_MIGRATIONS", "false")
+	os.Setenv("DB_HOST", "localhost")
+	os.Setenv("DB_NAME", "test_db")
+	os.Setenv("DB_PORT", "5432")
+	os.Setenv("DB_USERNAME", "test_user")
+	os.Setenv("DB_PASSWORD", "test_pass")
+	os.Setenv("PRODUCT_CATALOG_TYPE", "memory")
+
+	defer func() {
+		os.Unsetenv("GO_ENV")
+		os.Unsetenv("API_PORT")
+		os.Unsetenv("API_HOST")
+		os.Unsetenv("DB_RUN_MIGRATIONS")
+		os.Unsetenv("DB_HOST")
+		os.Unsetenv("DB_NAME")
+		os.Unsetenv("DB_PORT")
+		os.Unsetenv("DB_USERNAME")
+		os.Unsetenv("DB_PASSWORD")
+		os.Unsetenv("PRODUCT_CATALOG_TYPE")
+		dataSource = nil
+	}()
+
+	config.LoadConfig()
+	Connect()
+
+	if _, ok := GetDataSource().(*InMemoryProductCatalogDataSource); !ok {
+		t.Fatalf("GetDataSource() = %T, want *InMemoryProductCatalogDataSource", GetDataSource())
+	}
+
+	product, err := GetDataSource().FindByID(context.Background(), DefaultProducts()[0].ID)
+	if err != nil || product == nil {
+		t.Errorf("FindByID() default product = %+v, %v", product, err)
+	}
+}
@@ -0,0 +1,140 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"microservice/internal/adapters/daos"
+	"microservice/internal/domain/value_objects"
+	"microservice/utils/correlation"
+)
+
+// Lê do serviço de produtos (GET /v1/products/:id)
+type HTTPProductCatalogDataSource struct {
+	baseURL string
+	client  *http.Client
+}
+
+type productResponse struct {
+	Name     string      `json:"name"`
+	Category string      `json:"category"`
+	ImageURL string      `json:"image_url"`
+	Notes    string      `json:"notes"`
+	Price    json.Number `json:"price"`
+	// Vazio usa a moeda padrão
+	Currency string `json:"currency"`
+	// Ausente é tratado como disponível
+	Available  *bool                      `json:"available"`
+	Modifiers  []productModifierResponse  `json:"modifiers"`
+	ComboItems []productComboItemResponse `json:"combo_items"`
+}
+
+type productComboItemResponse struct {
+	ProductID string `json:"product_id"`
+	// Ausente conta como 1
+	Quantity int `json:"quantity"`
+}
+
+type productModifierResponse struct {
+	ID         string      `json:"id"`
+	Name       string      `json:"name"`
+	PriceDelta json.Number `json:"price_delta"`
+}
+
+func NewHTTPProductCatalogDataSource(baseURL string, timeout time.Duration) *HTTPProductCatalogDataSource {
+	return &HTTPProductCatalogDataSource{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+// Repassa o request ID para ligar os logs do serviço de produtos ao pedido
+func (r *HTTPProductCatalogDataSource) FindByID(ctx context.Context, productID string) (*daos.ProductDAO, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, r.baseURL+"/v1/products/"+url.PathEscape(productID), nil)
+	if err != nil {
+		return nil, err
+	}
+	if requestID := correlation.ID(ctx); requestID != "" {
+		request.Header.Set(correlation.REQUEST_ID_HEADER, requestID)
+	}
+
+	response, err := r.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("products service returned status %d", response.StatusCode)
+	}
+
+	var body productResponse
+	if err := json.NewDecoder(response.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("invalid product response: %w", err)
+	}
+
+	currency := body.Currency
+	if currency == "" {
+		currency = value_objects.DEFAULT_CURRENCY
+	}
+
+	price, err := value_objects.ParseMoney(body.Price.String(), currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid price for product %s: %w", productID, err)
+	}
+
+	available := true
+	if body.Available != nil {
+		available = *body.Available
+	}
+
+	modifiers := make([]daos.ProductModifierDAO, len(body.Modifiers))
+	for i, modifier := range body.Modifiers {
+		// Sem price_delta o adicional é gratuito (ex: "sem cebola")
+		priceDelta := value_objects.Money{}
+		if modifier.PriceDelta != "" {
+			priceDelta, err = value_objects.ParseMoney(modifier.PriceDelta.String(), currency)
+			if err != nil {
+				return nil, fmt.Errorf("invalid price for modifier %s of product %s: %w", modifier.ID, productID, err)
+			}
+		}
+		modifiers[i] = daos.ProductModifierDAO{
+			ID:         modifier.ID,
+			Name:       modifier.Name,
+			PriceDelta: priceDelta.MinorUnits(),
+		}
+	}
+
+	comboItems := make([]daos.ProductComboItemDAO, len(body.ComboItems))
+	for i, comboItem := range body.ComboItems {
+		quantity := comboItem.Quantity
+		if quantity == 0 {
+			quantity = 1
+		}
+		comboItems[i] = daos.ProductComboItemDAO{
+			ProductID: comboItem.ProductID,
+			Quantity:  quantity,
+		}
+	}
+
+	return &daos.ProductDAO{
+		ID:         productID,
+		Name:       body.Name,
+		Category:   body.Category,
+		ImageURL:   body.ImageURL,
+		Notes:      body.Notes,
+		Price:      price.MinorUnits(),
+		Currency:   price.Currency(),
+		Available:  available,
+		Modifiers:  modifiers,
+		ComboItems: comboItems,
+	}, nil
+}
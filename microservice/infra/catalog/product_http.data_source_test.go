@@ -0,0 +1,199 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"microservice/internal/adapters/daos"
+	"microservice/utils/correlation"
+)
+
+func newProductsServer(t *testing.T, status int, body string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/products/product-1" {
+			t.Errorf("request path = %v, want /v1/products/product-1", r.URL.Path)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHTTPProductCatalogDataSource_FindByID_Success(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"decimal string price", `{"id":"product-1","name":"X-Burger","price":"25.90","currency":"BRL","available":true}`},
+		{"numeric price", `{"id":"product-1","name":"X-Burger","price":25.9}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newProductsServer(t, http.StatusOK, tt.body)
+			ds := NewHTTPProductCatalogDataSource(server.URL+"/", time.Second)
+
+			product, err := ds.FindByID(context.Background(), "product-1")
+
+			if err != nil {
+				t.Fatalf("FindByID() unexpected error: %v", err)
+			}
+			if product.ID != "product-1" || product.Name != "X-Burger" {
+				t.Errorf("FindByID() = %+v, want product-1 X-Burger", product)
+			}
+			if product.Price != 2590 || product.Currency != "BRL" || !product.Available {
+				t.Errorf("FindByID() Price = %v %v available=%v, want 2590 BRL available", product.Price, product.Currency, product.Available)
+			}
+		})
+	}
+}
+
+func TestHTTPProductCatalogDataSource_FindByID_ForwardsRequestID(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(correlation.REQUEST_ID_HEADER)
+		_, _ = w.Write([]byte(`{"name":"X-Burger","price":"25.90"}`))
+	}))
+	defer server.Close()
+	ds := NewHTTPProductCatalogDataSource(server.URL, time.Second)
+
+	if _, err := ds.FindByID(correlation.WithID(context.Background(), "req-123"), "product-1"); err != nil {
+		t.Fatalf("FindByID() unexpected error: %v", err)
+	}
+	if received != "req-123" {
+		t.Errorf("FindByID() %s header = %q, want req-123", correlation.REQUEST_ID_HEADER, received)
+	}
+}
+
+func TestHTTPProductCatalogDataSource_FindByID_CancelledContext(t *testing.T) {
+	server := newProductsServer(t, http.StatusOK, `{"name":"X-Burger","price":"25.90"}`)
+	ds := NewHTTPProductCatalogDataSource(server.URL, time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := ds.FindByID(ctx, "product-1"); !errors.Is(err, context.Canceled) {
+		t.Errorf("FindByID() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestHTTPProductCatalogDataSource_FindByID_ProductDetails(t *testing.T) {
+	server := newProductsServer(t, http.StatusOK, `{"name":"X-Burger","price":"25.90","category":"Lanche","image_url":"https://cdn.example.com/x-burger.png","notes":"Contém glúten"}`)
+	ds := NewHTTPProductCatalogDataSource(server.URL, time.Second)
+
+	product, err := ds.FindByID(context.Background(), "product-1")
+
+	if err != nil {
+		t.Fatalf("FindByID() unexpected error: %v", err)
+	}
+	if product.Category != "Lanche" || product.ImageURL != "https://cdn.example.com/x-burger.png" || product.Notes != "Contém glúten" {
+		t.Errorf("FindByID() details = %+v, want category, image and notes from catalog", product)
+	}
+}
+
+func TestHTTPProductCatalogDataSource_FindByID_Modifiers(t *testing.T) {
+	server := newProductsServer(t, http.StatusOK, `{"name":"X-Burger","price":"25.90","modifiers":[{"id":"queijo-extra","name":"Queijo extra","price_delta":"3.00"},{"id":"sem-cebola","name":"Sem cebola"}]}`)
+	ds := NewHTTPProductCatalogDataSource(server.URL, time.Second)
+
+	product, err := ds.FindByID(context.Background(), "product-1")
+
+	if err != nil {
+		t.Fatalf("FindByID() unexpected error: %v", err)
+	}
+	expected := []daos.ProductModifierDAO{
+		{ID: "queijo-extra", Name: "Queijo extra", PriceDelta: 300},
+		{ID: "sem-cebola", Name: "Sem cebola", PriceDelta: 0},
+	}
+	if !reflect.DeepEqual(product.Modifiers, expected) {
+		t.Errorf("FindByID() Modifiers = %+v, want %+v", product.Modifiers, expected)
+	}
+}
+
+func TestHTTPProductCatalogDataSource_FindByID_ComboItems(t *testing.T) {
+	server := newProductsServer(t, http.StatusOK, `{"name":"Combo X-Burger","price":"39.90","combo_items":[{"product_id":"burger-1"},{"product_id":"fries-1","quantity":2}]}`)
+	ds := NewHTTPProductCatalogDataSource(server.URL, time.Second)
+
+	product, err := ds.FindByID(context.Background(), "product-1")
+
+	if err != nil {
+		t.Fatalf("FindByID() unexpected error: %v", err)
+	}
+	expected := []daos.ProductComboItemDAO{
+		{ProductID: "burger-1", Quantity: 1},
+		{ProductID: "fries-1", Quantity: 2},
+	}
+	if !reflect.DeepEqual(product.ComboItems, expected) {
+		t.Errorf("FindByID() ComboItems = %+v, want %+v", product.ComboItems, expected)
+	}
+}
+
+func TestHTTPProductCatalogDataSource_FindByID_Unavailable(t *testing.T) {
+	server := newProductsServer(t, http.StatusOK, `{"name":"X-Burger","price":"25.90","available":false}`)
+
+	product, err := NewHTTPProductCatalogDataSource(server.URL, time.Second).FindByID(context.Background(), "product-1")
+
+	if err != nil {
+		t.Fatalf("FindByID() unexpected error: %v", err)
+	}
+	if product.Available {
+		t.Error("FindByID() Available = true, want false")
+	}
+}
+
+func TestHTTPProductCatalogDataSource_FindByID_NotFound(t *testing.T) {
+	server := newProductsServer(t, http.StatusNotFound, `{"error":"not found"}`)
+
+	product, err := NewHTTPProductCatalogDataSource(server.URL, time.Second).FindByID(context.Background(), "product-1")
+
+	if err != nil {
+		t.Fatalf("FindByID() unexpected error: %v", err)
+	}
+	if product != nil {
+		t.Errorf("FindByID() = %+v, want nil", product)
+	}
+}
+
+func TestHTTPProductCatalogDataSource_FindByID_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"server error", http.StatusInternalServerError, `{"error":"boom"}`},
+		{"invalid json", http.StatusOK, `not json`},
+		{"too many decimals", http.StatusOK, `{"name":"X-Burger","price":"25.999"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newProductsServer(t, tt.status, tt.body)
+
+			product, err := NewHTTPProductCatalogDataSource(server.URL, time.Second).FindByID(context.Background(), "product-1")
+
+			if err == nil {
+				t.Error("FindByID() expected error, got nil")
+			}
+			if product != nil {
+				t.Errorf("FindByID() = %+v, want nil", product)
+			}
+		})
+	}
+}
+
+func TestHTTPProductCatalogDataSource_FindByID_ConnectionError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	_, err := NewHTTPProductCatalogDataSource(server.URL, time.Second).FindByID(context.Background(), "product-1")
+
+	if err == nil {
+		t.Error("FindByID() expected error when the service is down, got nil")
+	}
+}
@@ -0,0 +1,66 @@
+package catalog
+
+import (
+	"context"
+	"sync"
+
+	"microservice/internal/adapters/daos"
+)
+
+// Catálogo fixo em processo, para execuções locais e testes
+type InMemoryProductCatalogDataSource struct {
+	mu       sync.RWMutex
+	products map[string]daos.ProductDAO
+}
+
+func NewInMemoryProductCatalogDataSource(products ...daos.ProductDAO) *InMemoryProductCatalogDataSource {
+	ds := &InMemoryProductCatalogDataSource{
+		products: make(map[string]daos.ProductDAO),
+	}
+	for _, product := range products {
+		ds.Save(product)
+	}
+	return ds
+}
+
+func (r *InMemoryProductCatalogDataSource) Save(product daos.ProductDAO) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.products[product.ID] = product
+}
+
+func (r *InMemoryProductCatalogDataSource) FindByID(ctx context.Context, productID string) (*daos.ProductDAO, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	product, ok := r.products[productID]
+	if !ok {
+		return nil, nil
+	}
+	return &product, nil
+}
+
+// Catálogo usado com PRODUCT_CATALOG_TYPE=memory
+func DefaultProducts() []daos.ProductDAO {
+	return []daos.ProductDAO{
+		{ID: "a0e1b2c3-0001-4000-8000-000000000001", Name: "X-Burger", Category: "Lanche", Notes: "Contém glúten e lactose", Price: 2590, Currency: "BRL", Available: true, Modifiers: burgerModifiers()},
+		{ID: "a0e1b2c3-0002-4000-8000-000000000002", Name: "X-Salada", Category: "Lanche", Notes: "Contém glúten e lactose", Price: 2790, Currency: "BRL", Available: true, Modifiers: burgerModifiers()},
+		{ID: "a0e1b2c3-0003-4000-8000-000000000003", Name: "Batata Frita", Category: "Acompanhamento", Price: 1290, Currency: "BRL", Available: true},
+		{ID: "a0e1b2c3-0004-4000-8000-000000000004", Name: "Refrigerante", Category: "Bebida", Price: 790, Currency: "BRL", Available: true},
+		{ID: "a0e1b2c3-0005-4000-8000-000000000005", Name: "Milkshake", Category: "Sobremesa", Notes: "Contém lactose", Price: 1590, Currency: "BRL", Available: false},
+		{ID: "a0e1b2c3-0006-4000-8000-000000000006", Name: "Combo X-Burger", Category: "Combo", Price: 3990, Currency: "BRL", Available: true, ComboItems: []daos.ProductComboItemDAO{
+			{ProductID: "a0e1b2c3-0001-4000-8000-000000000001", Quantity: 1},
+			{ProductID: "a0e1b2c3-0003-4000-8000-000000000003", Quantity: 1},
+			{ProductID: "a0e1b2c3-0004-4000-8000-000000000004", Quantity: 1},
+		}},
+	}
+}
+
+func burgerModifiers() []daos.ProductModifierDAO {
+	return []daos.ProductModifierDAO{
+		{ID: "queijo-extra", Name: "Queijo extra", PriceDelta: 300},
+		{ID: "bacon-extra", Name: "Bacon extra", PriceDelta: 450},
+		{ID: "sem-cebola", Name: "Sem cebola", PriceDelta: 0},
+	}
+}
@@ -0,0 +1,50 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"microservice/internal/adapters/daos"
+)
+
+func TestInMemoryProductCatalogDataSource_FindByID(t *testing.T) {
+	ds := NewInMemoryProductCatalogDataSource(daos.ProductDAO{ID: "product-1", Name: "X-Burger", Price: 2590, Available: true})
+
+	product, err := ds.FindByID(context.Background(), "product-1")
+	if err != nil {
+		t.Fatalf("FindByID() unexpected error: %v", err)
+	}
+	if product == nil || product.Price != 2590 {
+		t.Errorf("FindByID() = %+v, want product-1 at 2590", product)
+	}
+
+	missing, err := ds.FindByID(context.Background(), "product-2")
+	if err != nil || missing != nil {
+		t.Errorf("FindByID() missing product = %+v, %v, want nil, nil", missing, err)
+	}
+}
+
+func TestDefaultProducts_AreValid(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, product := range DefaultProducts() {
+		if product.ID == "" || product.Name == "" || product.Price <= 0 || product.Currency == "" {
+			t.Errorf("DefaultProducts() invalid product %+v", product)
+		}
+		if seen[product.ID] {
+			t.Errorf("DefaultProducts() duplicated id %v", product.ID)
+		}
+		seen[product.ID] = true
+	}
+}
+
+func TestDefaultProducts_CombosReferenceCatalogProducts(t *testing.T) {
+	ds := NewInMemoryProductCatalogDataSource(DefaultProducts()...)
+	for _, product := range DefaultProducts() {
+		for _, comboItem := range product.ComboItems {
+			component, _ := ds.FindByID(context.Background(), comboItem.ProductID)
+			if component == nil || len(component.ComboItems) > 0 || comboItem.Quantity <= 0 {
+				t.Errorf("DefaultProducts() combo %v has invalid item %+v", product.Name, comboItem)
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"microservice/internal/adapters/daos"
+)
+
+type idempotencyRecordKey struct {
+	scope string
+	key   string
+}
+
+// Usado nos testes
+type IdempotencyDataSource struct {
+	mu      sync.Mutex
+	records map[idempotencyRecordKey]daos.IdempotencyKeyDAO
+}
+
+func NewIdempotencyDataSource() *IdempotencyDataSource {
+	return &IdempotencyDataSource{
+		records: make(map[idempotencyRecordKey]daos.IdempotencyKeyDAO),
+	}
+}
+
+func (r *IdempotencyDataSource) FindByKey(ctx context.Context, scope string, key string) (*daos.IdempotencyKeyDAO, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, ok := r.records[idempotencyRecordKey{scope, key}]
+	if !ok {
+		return nil, nil
+	}
+	return &record, nil
+}
+
+func (r *IdempotencyDataSource) Reserve(ctx context.Context, record daos.IdempotencyKeyDAO, staleBefore time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	recordKey := idempotencyRecordKey{record.Scope, record.Key}
+	if existing, ok := r.records[recordKey]; ok {
+		stale := existing.CompletedAt == nil && existing.LockedAt != nil && !existing.LockedAt.After(staleBefore)
+		expired := !existing.ExpiresAt.After(record.CreatedAt)
+		if !stale && !expired {
+			return false, nil
+		}
+	}
+	r.records[recordKey] = record
+	return true, nil
+}
+
+func (r *IdempotencyDataSource) Complete(ctx context.Context, scope string, key string, statusCode int, responseBody []byte, completedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	recordKey := idempotencyRecordKey{scope, key}
+	record, ok := r.records[recordKey]
+	if !ok || record.CompletedAt != nil {
+		return nil
+	}
+	record.StatusCode = statusCode
+	record.ResponseBody = append([]byte(nil), responseBody...)
+	record.LockedAt = nil
+	record.CompletedAt = &completedAt
+	r.records[recordKey] = record
+	return nil
+}
+
+func (r *IdempotencyDataSource) Release(ctx context.Context, scope string, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	recordKey := idempotencyRecordKey{scope, key}
+	if record, ok := r.records[recordKey]; ok && record.CompletedAt == nil {
+		delete(r.records, recordKey)
+	}
+	return nil
+}
+
+func (r *IdempotencyDataSource) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var purged int64
+	for recordKey, record := range r.records {
+		if !record.ExpiresAt.After(now) {
+			delete(r.records, recordKey)
+			purged++
+		}
+	}
+	return purged, nil
+}
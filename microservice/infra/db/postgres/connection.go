@@ -19,6 +19,7 @@ var (
 	dbConnection *gorm.DB
 	instance     *gorm.DB
 	once         sync.Once
+	queryTimeout time.Duration
 )
 
 func GetDB() *gorm.DB {
@@ -28,6 +29,11 @@ func GetDB() *gorm.DB {
 	return instance
 }
 
+// Zero quando ilimitado
+func QueryTimeout() time.Duration {
+	return queryTimeout
+}
+
 func Connect() {
 	if dbConnection != nil {
 		log.Println("Database connection already established")
@@ -78,6 +84,7 @@ func Connect() {
 	}
 
 	dbConnection = db
+	queryTimeout = cfg.Database.QueryTimeout
 }
 
 func Close() {
@@ -95,10 +102,27 @@ func Close() {
 }
 
 func RunMigrations() {
+	if err := migrateMoneyToMinorUnits(dbConnection); err != nil {
+		log.Printf("Error converting money columns to minor units: %v", err)
+		return
+	}
+	if err := migrateIdempotencyKeys(dbConnection); err != nil {
+		log.Printf("Error migrating idempotency keys: %v", err)
+		return
+	}
+
 	if err := dbConnection.AutoMigrate(
 		&models.OrderModel{},
 		&models.OrderItemModel{},
+		&models.OrderItemModifierModel{},
 		&models.OrderStatusModel{},
+		&models.OrderStatusHistoryModel{},
+		&models.OutboxModel{},
+		&models.IdempotencyKeyModel{},
+		&models.ProcessedMessageModel{},
+		&models.CouponModel{},
+		&models.CouponRedemptionModel{},
+		&models.PickupSequenceModel{},
 	); err != nil {
 		log.Printf("Error running migrations: %v", err)
 		return
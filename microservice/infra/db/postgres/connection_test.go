@@ -146,6 +146,10 @@ func TestRunMigrations_WithConnection(t *testing.T) {
 		t.Error("Expected order_items table to be created")
 	}
 
+	if !testDB.Migrator().HasTable("order_item_modifiers") {
+		t.Error("Expected order_item_modifiers table to be created")
+	}
+
 	if !testDB.Migrator().HasTable("order_status") {
 		t.Error("Expected order_status table to be created")
 	}
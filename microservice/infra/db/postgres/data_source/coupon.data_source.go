@@ -0,0 +1,64 @@
+package data_source
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"microservice/infra/db/postgres"
+	"microservice/infra/db/postgres/models"
+	"microservice/internal/adapters/daos"
+	"microservice/internal/domain/exceptions"
+)
+
+type GormCouponDataSource struct {
+	db           *gorm.DB
+	queryTimeout time.Duration
+}
+
+func NewGormCouponDataSource() *GormCouponDataSource {
+	return &GormCouponDataSource{
+		db:           postgres.GetDB(),
+		queryTimeout: postgres.QueryTimeout(),
+	}
+}
+
+// Consultas param quando a requisição é cancelada
+func (r *GormCouponDataSource) session(ctx context.Context) (*gorm.DB, context.CancelFunc) {
+	return withQueryTimeout(ctx, r.db, r.queryTimeout)
+}
+
+func (r *GormCouponDataSource) Create(ctx context.Context, coupon daos.CouponDAO) error {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	var existing int64
+	if err := db.Model(&models.CouponModel{}).Where("code = ?", coupon.Code).Count(&existing).Error; err != nil {
+		return err
+	}
+	if existing > 0 {
+		return &exceptions.CouponAlreadyExistsException{}
+	}
+
+	model := FromCouponDAOToModel(coupon)
+	return db.Create(&model).Error
+}
+
+func (r *GormCouponDataSource) FindByCode(ctx context.Context, code string) (*daos.CouponDAO, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	var coupon models.CouponModel
+
+	if err := db.First(&coupon, "code = ?", code).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	result := FromCouponModelToDAO(coupon)
+	return &result, nil
+}
@@ -0,0 +1,163 @@
+package data_source
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"microservice/infra/db/postgres/models"
+	"microservice/internal/adapters/daos"
+	"microservice/internal/domain/exceptions"
+)
+
+func newSQLiteCoupon(code string, maxUses int) daos.CouponDAO {
+	return daos.CouponDAO{
+		Code:       code,
+		Type:       "percentage",
+		Percentage: 10,
+		Currency:   "BRL",
+		MaxUses:    maxUses,
+		CreatedAt:  time.Now(),
+	}
+}
+
+func TestGormCouponDataSource_CreateAndFindByCode(t *testing.T) {
+	ds := &GormCouponDataSource{db: setupSQLiteDB(t)}
+
+	assert.NoError(t, ds.Create(context.Background(), newSQLiteCoupon("PROMO10", 5)))
+
+	found, err := ds.FindByCode(context.Background(), "PROMO10")
+	assert.NoError(t, err)
+	assert.NotNil(t, found)
+	assert.Equal(t, 10, found.Percentage)
+	assert.Equal(t, 5, found.MaxUses)
+
+	missing, err := ds.FindByCode(context.Background(), "UNKNOWN")
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestGormCouponDataSource_Create_Duplicate(t *testing.T) {
+	ds := &GormCouponDataSource{db: setupSQLiteDB(t)}
+
+	assert.NoError(t, ds.Create(context.Background(), newSQLiteCoupon("PROMO10", 0)))
+
+	err := ds.Create(context.Background(), newSQLiteCoupon("PROMO10", 0))
+	assert.IsType(t, &exceptions.CouponAlreadyExistsException{}, err)
+}
+
+func TestGormOrderDataSource_ApplyCoupon(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+	coupons := &GormCouponDataSource{db: db}
+
+	assert.NoError(t, coupons.Create(context.Background(), newSQLiteCoupon("PROMO10", 1)))
+	assert.NoError(t, ds.Create(context.Background(), newSQLiteOrder("order-1")))
+	assert.NoError(t, ds.Create(context.Background(), newSQLiteOrder("order-2")))
+
+	couponCode := "PROMO10"
+	order := newSQLiteOrder("order-1")
+	order.Subtotal = 1000
+	order.Discount = 100
+	order.Amount = 900
+	order.CouponCode = &couponCode
+	assert.NoError(t, ds.ApplyCoupon(context.Background(), order, newOutboxMessage("event-1", "order-1", time.Now())))
+
+	found, err := ds.FindByID(context.Background(), "order-1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(900), found.Amount)
+	assert.Equal(t, int64(100), found.Discount)
+	assert.Equal(t, &couponCode, found.CouponCode)
+
+	coupon, err := coupons.FindByCode(context.Background(), "PROMO10")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, coupon.UsedCount)
+
+	var outboxCount int64
+	db.Model(&models.OutboxModel{}).Where("order_id = ?", "order-1").Count(&outboxCount)
+	assert.Equal(t, int64(1), outboxCount)
+
+	// Um cupom aplicado sobre uma leitura antiga não sobrescreve o pedido
+	assert.NoError(t, coupons.Create(context.Background(), newSQLiteCoupon("OTHER", 0)))
+	other := "OTHER"
+	stale := newSQLiteOrder("order-1")
+	stale.CouponCode = &other
+	err = ds.ApplyCoupon(context.Background(), stale)
+	assert.IsType(t, &exceptions.OrderConcurrentModificationException{}, err)
+	otherCoupon, _ := coupons.FindByCode(context.Background(), "OTHER")
+	assert.Equal(t, 0, otherCoupon.UsedCount)
+
+	// O limite de usos já foi atingido: o segundo pedido não pode ser alterado
+	second := newSQLiteOrder("order-2")
+	second.Subtotal = 1000
+	second.Discount = 100
+	second.Amount = 900
+	second.CouponCode = &couponCode
+	err = ds.ApplyCoupon(context.Background(), second)
+	assert.IsType(t, &exceptions.CouponUsageLimitReachedException{}, err)
+
+	unchanged, err := ds.FindByID(context.Background(), "order-2")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), unchanged.Amount)
+	assert.Nil(t, unchanged.CouponCode)
+}
+
+func TestGormOrderDataSource_ApplyCoupon_WithoutCoupon(t *testing.T) {
+	ds := &GormOrderDataSource{db: setupSQLiteDB(t)}
+
+	err := ds.ApplyCoupon(context.Background(), newSQLiteOrder("order-1"))
+	assert.IsType(t, &exceptions.CouponNotApplicableException{}, err)
+}
+
+func TestGormOrderDataSource_ApplyCoupon_FirstOrderCouponOncePerCustomer(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+	coupons := &GormCouponDataSource{db: db}
+
+	welcome := newSQLiteCoupon("BEMVINDO", 0)
+	welcome.FirstOrderOnly = true
+	assert.NoError(t, coupons.Create(context.Background(), welcome))
+
+	customerID := "customer-1"
+	couponCode := "BEMVINDO"
+	for _, id := range []string{"order-1", "order-2", "order-3"} {
+		order := newSQLiteOrder(id)
+		if id != "order-3" {
+			order.CustomerID = &customerID
+		}
+		assert.NoError(t, ds.Create(context.Background(), order))
+	}
+
+	first := newSQLiteOrder("order-1")
+	first.CustomerID = &customerID
+	first.CouponCode = &couponCode
+	assert.NoError(t, ds.ApplyCoupon(context.Background(), first))
+
+	var redemptions int64
+	db.Model(&models.CouponRedemptionModel{}).Where("coupon_code = ? AND customer_id = ?", couponCode, customerID).Count(&redemptions)
+	assert.Equal(t, int64(1), redemptions)
+
+	// Mesmo que o primeiro pedido seja cancelado, o cliente não resgata de novo
+	second := newSQLiteOrder("order-2")
+	second.CustomerID = &customerID
+	second.CouponCode = &couponCode
+	err := ds.ApplyCoupon(context.Background(), second)
+	assert.IsType(t, &exceptions.CouponNotApplicableException{}, err)
+
+	unchanged, err := ds.FindByID(context.Background(), "order-2")
+	assert.NoError(t, err)
+	assert.Nil(t, unchanged.CouponCode)
+	coupon, err := coupons.FindByCode(context.Background(), "BEMVINDO")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, coupon.UsedCount, "the failed redemption must not consume a use")
+
+	// Cupons comuns não registram resgate
+	assert.NoError(t, coupons.Create(context.Background(), newSQLiteCoupon("PROMO10", 0)))
+	promo := "PROMO10"
+	second.CouponCode = &promo
+	assert.NoError(t, ds.ApplyCoupon(context.Background(), second))
+	db.Model(&models.CouponRedemptionModel{}).Where("coupon_code = ?", promo).Count(&redemptions)
+	assert.Equal(t, int64(0), redemptions)
+}
@@ -1,6 +1,7 @@
 package data_source
 
 import (
+	"context"
 	"errors"
 	"regexp"
 	"testing"
@@ -48,7 +49,7 @@ func TestGormOrderStatusDataSource_FindAll_Success(t *testing.T) {
 
 	ds := &GormOrderStatusDataSource{db: db}
 
-	result, err := ds.FindAll()
+	result, err := ds.FindAll(context.Background())
 	if err != nil {
 		t.Fatalf("FindAll() unexpected error: %v", err)
 	}
@@ -77,7 +78,7 @@ func TestGormOrderStatusDataSource_FindAll_Error(t *testing.T) {
 
 	ds := &GormOrderStatusDataSource{db: db}
 
-	_, err := ds.FindAll()
+	_, err := ds.FindAll(context.Background())
 	if err == nil {
 		t.Error("FindAll() expected error, got nil")
 	}
@@ -97,7 +98,7 @@ func TestGormOrderStatusDataSource_FindByID_Success(t *testing.T) {
 
 	ds := &GormOrderStatusDataSource{db: db}
 
-	status, err := ds.FindByID("status-1")
+	status, err := ds.FindByID(context.Background(), "status-1")
 	if err != nil {
 		t.Fatalf("FindByID() unexpected error: %v", err)
 	}
@@ -119,7 +120,7 @@ func TestGormOrderStatusDataSource_FindByID_Error(t *testing.T) {
 
 	ds := &GormOrderStatusDataSource{db: db}
 
-	_, err := ds.FindByID("status-1")
+	_, err := ds.FindByID(context.Background(), "status-1")
 	if err == nil {
 		t.Error("FindByID() expected error, got nil")
 	}
@@ -139,7 +140,7 @@ func TestGormOrderStatusDataSource_FindByName_Success(t *testing.T) {
 
 	ds := &GormOrderStatusDataSource{db: db}
 
-	status, err := ds.FindByName("Pending")
+	status, err := ds.FindByName(context.Background(), "Pending")
 	if err != nil {
 		t.Fatalf("FindByName() unexpected error: %v", err)
 	}
@@ -161,7 +162,7 @@ func TestGormOrderStatusDataSource_FindByName_Error(t *testing.T) {
 
 	ds := &GormOrderStatusDataSource{db: db}
 
-	_, err := ds.FindByName("Pending")
+	_, err := ds.FindByName(context.Background(), "Pending")
 	if err == nil {
 		t.Error("FindByName() expected error, got nil")
 	}
@@ -0,0 +1,114 @@
+package data_source
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"microservice/infra/db/postgres"
+	"microservice/infra/db/postgres/models"
+	"microservice/internal/adapters/daos"
+)
+
+type GormIdempotencyDataSource struct {
+	db           *gorm.DB
+	queryTimeout time.Duration
+}
+
+func NewGormIdempotencyDataSource() *GormIdempotencyDataSource {
+	return &GormIdempotencyDataSource{
+		db:           postgres.GetDB(),
+		queryTimeout: postgres.QueryTimeout(),
+	}
+}
+
+// Consultas param quando a requisição é cancelada
+func (r *GormIdempotencyDataSource) session(ctx context.Context) (*gorm.DB, context.CancelFunc) {
+	return withQueryTimeout(ctx, r.db, r.queryTimeout)
+}
+
+func (r *GormIdempotencyDataSource) FindByKey(ctx context.Context, scope string, key string) (*daos.IdempotencyKeyDAO, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	var record models.IdempotencyKeyModel
+
+	if err := db.First(&record, "scope = ? AND key = ?", scope, key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	dao := FromIdempotencyModelToDAO(record)
+	return &dao, nil
+}
+
+// A chave primária (scope, key) impede que duas requisições com a mesma chave sejam
+// processadas; reservas abandonadas ou expiradas são retomadas num único update condicional
+func (r *GormIdempotencyDataSource) Reserve(ctx context.Context, record daos.IdempotencyKeyDAO, staleBefore time.Time) (bool, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	model := FromIdempotencyDAOToModel(record)
+
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&model)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 1 {
+		return true, nil
+	}
+
+	result = db.Model(&models.IdempotencyKeyModel{}).
+		Where("scope = ? AND key = ?", record.Scope, record.Key).
+		Where("(completed_at IS NULL AND locked_at <= ?) OR expires_at <= ?", staleBefore, record.CreatedAt).
+		Updates(map[string]interface{}{
+			"request_hash":  model.RequestHash,
+			"status_code":   0,
+			"response_body": nil,
+			"created_at":    model.CreatedAt,
+			"locked_at":     model.LockedAt,
+			"expires_at":    model.ExpiresAt,
+			"completed_at":  nil,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r *GormIdempotencyDataSource) Complete(ctx context.Context, scope string, key string, statusCode int, responseBody []byte, completedAt time.Time) error {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	return db.Model(&models.IdempotencyKeyModel{}).
+		Where("scope = ? AND key = ? AND completed_at IS NULL", scope, key).
+		Updates(map[string]interface{}{
+			"status_code":   statusCode,
+			"response_body": string(responseBody),
+			"locked_at":     nil,
+			"completed_at":  completedAt,
+		}).Error
+}
+
+func (r *GormIdempotencyDataSource) Release(ctx context.Context, scope string, key string) error {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	return db.
+		Where("scope = ? AND key = ? AND completed_at IS NULL", scope, key).
+		Delete(&models.IdempotencyKeyModel{}).Error
+}
+
+func (r *GormIdempotencyDataSource) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	result := db.Where("expires_at <= ?", now).Delete(&models.IdempotencyKeyModel{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,198 @@
+package data_source
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"microservice/infra/db/memory"
+	"microservice/internal/adapters/daos"
+	"microservice/internal/interfaces"
+)
+
+func idempotencyDataSources(t *testing.T) map[string]interfaces.IIdempotencyDataSource {
+	return map[string]interfaces.IIdempotencyDataSource{
+		"gorm":      &GormIdempotencyDataSource{db: setupSQLiteDB(t)},
+		"in-memory": memory.NewIdempotencyDataSource(),
+	}
+}
+
+func newIdempotencyKey(key string, requestHash string, now time.Time) daos.IdempotencyKeyDAO {
+	return daos.IdempotencyKeyDAO{
+		Scope:       "totem-1",
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		LockedAt:    &now,
+		ExpiresAt:   now.Add(24 * time.Hour),
+	}
+}
+
+func TestIdempotencyDataSource_ReserveAndComplete(t *testing.T) {
+	for name, ds := range idempotencyDataSources(t) {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now().UTC()
+			record := newIdempotencyKey("key-1", "hash-1", now)
+
+			reserved, err := ds.Reserve(context.Background(), record, now.Add(-time.Minute))
+			assert.NoError(t, err)
+			assert.True(t, reserved)
+
+			reserved, err = ds.Reserve(context.Background(), record, now.Add(-time.Minute))
+			assert.NoError(t, err)
+			assert.False(t, reserved, "second reservation with the same key must fail")
+
+			pending, err := ds.FindByKey(context.Background(), "totem-1", "key-1")
+			assert.NoError(t, err)
+			assert.NotNil(t, pending)
+			assert.NotNil(t, pending.LockedAt)
+			assert.Nil(t, pending.CompletedAt)
+
+			assert.NoError(t, ds.Complete(context.Background(), "totem-1", "key-1", 201, []byte(`{"id":"order-1"}`), now))
+
+			completed, err := ds.FindByKey(context.Background(), "totem-1", "key-1")
+			assert.NoError(t, err)
+			assert.Equal(t, "hash-1", completed.RequestHash)
+			assert.Equal(t, 201, completed.StatusCode)
+			assert.JSONEq(t, `{"id":"order-1"}`, string(completed.ResponseBody))
+			assert.Nil(t, completed.LockedAt)
+			assert.NotNil(t, completed.CompletedAt)
+		})
+	}
+}
+
+func TestIdempotencyDataSource_FindByKey_Missing(t *testing.T) {
+	for name, ds := range idempotencyDataSources(t) {
+		t.Run(name, func(t *testing.T) {
+			record, err := ds.FindByKey(context.Background(), "totem-1", "missing")
+			assert.NoError(t, err)
+			assert.Nil(t, record)
+		})
+	}
+}
+
+func TestIdempotencyDataSource_Release(t *testing.T) {
+	for name, ds := range idempotencyDataSources(t) {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now().UTC()
+			_, err := ds.Reserve(context.Background(), newIdempotencyKey("pending", "hash", now), now.Add(-time.Minute))
+			assert.NoError(t, err)
+			_, err = ds.Reserve(context.Background(), newIdempotencyKey("done", "hash", now), now.Add(-time.Minute))
+			assert.NoError(t, err)
+			assert.NoError(t, ds.Complete(context.Background(), "totem-1", "done", 201, []byte(`{}`), now))
+
+			assert.NoError(t, ds.Release(context.Background(), "totem-1", "pending"))
+			assert.NoError(t, ds.Release(context.Background(), "totem-1", "done"))
+
+			pending, err := ds.FindByKey(context.Background(), "totem-1", "pending")
+			assert.NoError(t, err)
+			assert.Nil(t, pending, "pending key should be released")
+
+			done, err := ds.FindByKey(context.Background(), "totem-1", "done")
+			assert.NoError(t, err)
+			assert.NotNil(t, done, "completed key must not be released")
+		})
+	}
+}
+
+func TestIdempotencyDataSource_KeysAreScoped(t *testing.T) {
+	for name, ds := range idempotencyDataSources(t) {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now().UTC()
+			reserved, err := ds.Reserve(context.Background(), newIdempotencyKey("key-1", "hash-1", now), now.Add(-time.Minute))
+			assert.NoError(t, err)
+			assert.True(t, reserved)
+			assert.NoError(t, ds.Complete(context.Background(), "totem-1", "key-1", 201, []byte(`{"id":"order-1"}`), now))
+
+			other, err := ds.FindByKey(context.Background(), "totem-2", "key-1")
+			assert.NoError(t, err)
+			assert.Nil(t, other, "another scope must not see the key")
+
+			record := newIdempotencyKey("key-1", "hash-2", now)
+			record.Scope = "totem-2"
+			reserved, err = ds.Reserve(context.Background(), record, now.Add(-time.Minute))
+			assert.NoError(t, err)
+			assert.True(t, reserved, "the same key must be reservable in another scope")
+		})
+	}
+}
+
+func TestIdempotencyDataSource_Reserve_TakesOverStaleLock(t *testing.T) {
+	for name, ds := range idempotencyDataSources(t) {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now().UTC()
+			stale := newIdempotencyKey("stale", "hash-1", now.Add(-time.Hour))
+			fresh := newIdempotencyKey("fresh", "hash-1", now.Add(-10*time.Second))
+			_, err := ds.Reserve(context.Background(), stale, now.Add(-time.Hour))
+			assert.NoError(t, err)
+			_, err = ds.Reserve(context.Background(), fresh, now.Add(-time.Hour))
+			assert.NoError(t, err)
+
+			reserved, err := ds.Reserve(context.Background(), newIdempotencyKey("stale", "hash-2", now), now.Add(-time.Minute))
+			assert.NoError(t, err)
+			assert.True(t, reserved, "a lock older than the timeout must be taken over")
+
+			reserved, err = ds.Reserve(context.Background(), newIdempotencyKey("fresh", "hash-2", now), now.Add(-time.Minute))
+			assert.NoError(t, err)
+			assert.False(t, reserved, "a recent lock must be kept")
+
+			record, err := ds.FindByKey(context.Background(), "totem-1", "stale")
+			assert.NoError(t, err)
+			assert.Equal(t, "hash-2", record.RequestHash)
+			assert.WithinDuration(t, now, *record.LockedAt, time.Second)
+		})
+	}
+}
+
+func TestIdempotencyDataSource_Reserve_KeepsCompletedKeyUntilExpired(t *testing.T) {
+	for name, ds := range idempotencyDataSources(t) {
+		t.Run(name, func(t *testing.T) {
+			createdAt := time.Now().UTC().Add(-2 * time.Hour)
+			record := newIdempotencyKey("key-1", "hash-1", createdAt)
+			record.ExpiresAt = createdAt.Add(time.Hour)
+			_, err := ds.Reserve(context.Background(), record, createdAt.Add(-time.Minute))
+			assert.NoError(t, err)
+			assert.NoError(t, ds.Complete(context.Background(), "totem-1", "key-1", 201, []byte(`{}`), createdAt))
+
+			// Concluída há mais que o timeout da trava, mas ainda dentro do TTL
+			now := createdAt.Add(30 * time.Minute)
+			reserved, err := ds.Reserve(context.Background(), newIdempotencyKey("key-1", "hash-2", now), now.Add(-time.Minute))
+			assert.NoError(t, err)
+			assert.False(t, reserved, "a completed key must be replayed until it expires")
+
+			now = createdAt.Add(2 * time.Hour)
+			reserved, err = ds.Reserve(context.Background(), newIdempotencyKey("key-1", "hash-2", now), now.Add(-time.Minute))
+			assert.NoError(t, err)
+			assert.True(t, reserved, "an expired key can be used again")
+		})
+	}
+}
+
+func TestIdempotencyDataSource_PurgeExpired(t *testing.T) {
+	for name, ds := range idempotencyDataSources(t) {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now().UTC()
+			expired := newIdempotencyKey("expired", "hash", now.Add(-25*time.Hour))
+			current := newIdempotencyKey("current", "hash", now.Add(-time.Hour))
+			_, err := ds.Reserve(context.Background(), expired, now.Add(-48*time.Hour))
+			assert.NoError(t, err)
+			_, err = ds.Reserve(context.Background(), current, now.Add(-48*time.Hour))
+			assert.NoError(t, err)
+			assert.NoError(t, ds.Complete(context.Background(), "totem-1", "expired", 201, []byte(`{}`), expired.CreatedAt))
+			assert.NoError(t, ds.Complete(context.Background(), "totem-1", "current", 201, []byte(`{}`), current.CreatedAt))
+
+			purged, err := ds.PurgeExpired(context.Background(), now)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), purged)
+
+			record, err := ds.FindByKey(context.Background(), "totem-1", "expired")
+			assert.NoError(t, err)
+			assert.Nil(t, record)
+			record, err = ds.FindByKey(context.Background(), "totem-1", "current")
+			assert.NoError(t, err)
+			assert.NotNil(t, record)
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package data_source
 
 import (
+	"time"
+
+	"gorm.io/gorm"
+
 	"microservice/infra/db/postgres/models"
 	"microservice/internal/adapters/daos"
 )
@@ -14,6 +18,16 @@ func FromDAOToModel(order daos.OrderDAO) models.OrderModel {
 			ProductID: item.ProductID,
 			Quantity:  item.Quantity,
 			UnitPrice: item.UnitPrice,
+
+			ProductName:     item.ProductName,
+			ProductCategory: item.ProductCategory,
+			ProductImageURL: item.ProductImageURL,
+			ProductNotes:    item.ProductNotes,
+
+			Notes:     item.Notes,
+			Modifiers: fromModifierDAOsToModels(item.Modifiers),
+
+			ParentItemID: item.ParentItemID,
 		}
 	}
 
@@ -21,6 +35,10 @@ func FromDAOToModel(order daos.OrderDAO) models.OrderModel {
 		ID:         order.ID,
 		CustomerID: order.CustomerID,
 		Amount:     order.Amount,
+		Currency:   order.Currency,
+		Subtotal:   order.Subtotal,
+		Discount:   order.Discount,
+		CouponCode: order.CouponCode,
 		StatusID:   order.Status.ID,
 		Status: models.OrderStatusModel{
 			ID:   order.Status.ID,
@@ -29,6 +47,16 @@ func FromDAOToModel(order daos.OrderDAO) models.OrderModel {
 		Items:     items,
 		CreatedAt: order.CreatedAt,
 		UpdatedAt: order.UpdatedAt,
+
+		CustomerName:       order.CustomerName,
+		PickupDate:         order.PickupDate,
+		PickupCode:         order.PickupCode,
+		CancellationReason: order.CancellationReason,
+		CancelledAt:        order.CancelledAt,
+		DeletedAt:          toGormDeletedAt(order.DeletedAt),
+
+		GuestTokenHash: order.GuestTokenHash,
+		Version:        order.Version,
 	}
 }
 
@@ -41,6 +69,16 @@ func FromModelToDAO(order models.OrderModel) daos.OrderDAO {
 			ProductID: item.ProductID,
 			Quantity:  item.Quantity,
 			UnitPrice: item.UnitPrice,
+
+			ProductName:     item.ProductName,
+			ProductCategory: item.ProductCategory,
+			ProductImageURL: item.ProductImageURL,
+			ProductNotes:    item.ProductNotes,
+
+			Notes:     item.Notes,
+			Modifiers: fromModifierModelsToDAOs(item.Modifiers),
+
+			ParentItemID: item.ParentItemID,
 		}
 	}
 
@@ -48,6 +86,10 @@ func FromModelToDAO(order models.OrderModel) daos.OrderDAO {
 		ID:         order.ID,
 		CustomerID: order.CustomerID,
 		Amount:     order.Amount,
+		Currency:   order.Currency,
+		Subtotal:   order.Subtotal,
+		Discount:   order.Discount,
+		CouponCode: order.CouponCode,
 		Status: daos.OrderStatusDAO{
 			ID:   order.Status.ID,
 			Name: order.Status.Name,
@@ -55,7 +97,59 @@ func FromModelToDAO(order models.OrderModel) daos.OrderDAO {
 		Items:     items,
 		CreatedAt: order.CreatedAt,
 		UpdatedAt: order.UpdatedAt,
+
+		CustomerName:       order.CustomerName,
+		PickupDate:         order.PickupDate,
+		PickupCode:         order.PickupCode,
+		CancellationReason: order.CancellationReason,
+		CancelledAt:        order.CancelledAt,
+		DeletedAt:          fromGormDeletedAt(order.DeletedAt),
+
+		GuestTokenHash: order.GuestTokenHash,
+		Version:        order.Version,
+	}
+}
+
+func toGormDeletedAt(deletedAt *time.Time) gorm.DeletedAt {
+	if deletedAt == nil {
+		return gorm.DeletedAt{}
 	}
+	return gorm.DeletedAt{Time: *deletedAt, Valid: true}
+}
+
+func fromGormDeletedAt(deletedAt gorm.DeletedAt) *time.Time {
+	if !deletedAt.Valid {
+		return nil
+	}
+	return &deletedAt.Time
+}
+
+func fromModifierDAOsToModels(modifiers []daos.OrderItemModifierDAO) []models.OrderItemModifierModel {
+	result := make([]models.OrderItemModifierModel, len(modifiers))
+	for i, modifier := range modifiers {
+		result[i] = models.OrderItemModifierModel{
+			ID:          modifier.ID,
+			OrderItemID: modifier.OrderItemID,
+			ModifierID:  modifier.ModifierID,
+			Name:        modifier.Name,
+			PriceDelta:  modifier.PriceDelta,
+		}
+	}
+	return result
+}
+
+func fromModifierModelsToDAOs(modifiers []models.OrderItemModifierModel) []daos.OrderItemModifierDAO {
+	result := make([]daos.OrderItemModifierDAO, len(modifiers))
+	for i, modifier := range modifiers {
+		result[i] = daos.OrderItemModifierDAO{
+			ID:          modifier.ID,
+			OrderItemID: modifier.OrderItemID,
+			ModifierID:  modifier.ModifierID,
+			Name:        modifier.Name,
+			PriceDelta:  modifier.PriceDelta,
+		}
+	}
+	return result
 }
 
 func FromModelArrayToDAOArray(orders []models.OrderModel) []daos.OrderDAO {
@@ -65,3 +159,162 @@ func FromModelArrayToDAOArray(orders []models.OrderModel) []daos.OrderDAO {
 	}
 	return result
 }
+
+func FromCouponDAOToModel(coupon daos.CouponDAO) models.CouponModel {
+	return models.CouponModel{
+		Code:           coupon.Code,
+		Type:           coupon.Type,
+		Percentage:     coupon.Percentage,
+		FixedAmount:    coupon.FixedAmount,
+		Currency:       coupon.Currency,
+		ProductID:      coupon.ProductID,
+		BuyQuantity:    coupon.BuyQuantity,
+		FreeQuantity:   coupon.FreeQuantity,
+		FirstOrderOnly: coupon.FirstOrderOnly,
+		ValidFrom:      coupon.ValidFrom,
+		ValidUntil:     coupon.ValidUntil,
+		MaxUses:        coupon.MaxUses,
+		UsedCount:      coupon.UsedCount,
+		CreatedAt:      coupon.CreatedAt,
+	}
+}
+
+func FromCouponModelToDAO(coupon models.CouponModel) daos.CouponDAO {
+	return daos.CouponDAO{
+		Code:           coupon.Code,
+		Type:           coupon.Type,
+		Percentage:     coupon.Percentage,
+		FixedAmount:    coupon.FixedAmount,
+		Currency:       coupon.Currency,
+		ProductID:      coupon.ProductID,
+		BuyQuantity:    coupon.BuyQuantity,
+		FreeQuantity:   coupon.FreeQuantity,
+		FirstOrderOnly: coupon.FirstOrderOnly,
+		ValidFrom:      coupon.ValidFrom,
+		ValidUntil:     coupon.ValidUntil,
+		MaxUses:        coupon.MaxUses,
+		UsedCount:      coupon.UsedCount,
+		CreatedAt:      coupon.CreatedAt,
+	}
+}
+
+func FromStatusHistoryDAOToModel(change daos.OrderStatusHistoryDAO) models.OrderStatusHistoryModel {
+	var previousStatusID *string
+	if change.PreviousStatus != nil {
+		previousStatusID = &change.PreviousStatus.ID
+	}
+
+	return models.OrderStatusHistoryModel{
+		ID:               change.ID,
+		OrderID:          change.OrderID,
+		PreviousStatusID: previousStatusID,
+		NewStatusID:      change.NewStatus.ID,
+		Source:           change.Source,
+		Actor:            change.Actor,
+		ChangedAt:        change.ChangedAt,
+	}
+}
+
+func FromStatusHistoryModelToDAO(change models.OrderStatusHistoryModel) daos.OrderStatusHistoryDAO {
+	var previousStatus *daos.OrderStatusDAO
+	if change.PreviousStatus != nil {
+		previousStatus = &daos.OrderStatusDAO{
+			ID:   change.PreviousStatus.ID,
+			Name: change.PreviousStatus.Name,
+		}
+	}
+
+	return daos.OrderStatusHistoryDAO{
+		ID:             change.ID,
+		OrderID:        change.OrderID,
+		PreviousStatus: previousStatus,
+		NewStatus: daos.OrderStatusDAO{
+			ID:   change.NewStatus.ID,
+			Name: change.NewStatus.Name,
+		},
+		Source:    change.Source,
+		Actor:     change.Actor,
+		ChangedAt: change.ChangedAt,
+	}
+}
+
+func FromOutboxDAOToModel(message daos.OutboxMessageDAO) models.OutboxModel {
+	return models.OutboxModel{
+		ID:            message.ID,
+		OrderID:       message.OrderID,
+		EventType:     message.EventType,
+		Payload:       string(message.Payload),
+		Attempts:      message.Attempts,
+		LastError:     message.LastError,
+		CreatedAt:     message.CreatedAt,
+		NextAttemptAt: message.NextAttemptAt,
+		SentAt:        message.SentAt,
+		DeadAt:        message.DeadAt,
+		LockedUntil:   message.LockedUntil,
+	}
+}
+
+func FromOutboxModelToDAO(message models.OutboxModel) daos.OutboxMessageDAO {
+	return daos.OutboxMessageDAO{
+		ID:            message.ID,
+		OrderID:       message.OrderID,
+		EventType:     message.EventType,
+		Payload:       []byte(message.Payload),
+		Attempts:      message.Attempts,
+		LastError:     message.LastError,
+		CreatedAt:     message.CreatedAt,
+		NextAttemptAt: message.NextAttemptAt,
+		SentAt:        message.SentAt,
+		DeadAt:        message.DeadAt,
+		LockedUntil:   message.LockedUntil,
+	}
+}
+
+func FromIdempotencyDAOToModel(record daos.IdempotencyKeyDAO) models.IdempotencyKeyModel {
+	var responseBody *string
+	if record.ResponseBody != nil {
+		body := string(record.ResponseBody)
+		responseBody = &body
+	}
+
+	return models.IdempotencyKeyModel{
+		Scope:        record.Scope,
+		Key:          record.Key,
+		RequestHash:  record.RequestHash,
+		StatusCode:   record.StatusCode,
+		ResponseBody: responseBody,
+		CreatedAt:    record.CreatedAt,
+		LockedAt:     record.LockedAt,
+		ExpiresAt:    record.ExpiresAt,
+		CompletedAt:  record.CompletedAt,
+	}
+}
+
+func FromIdempotencyModelToDAO(record models.IdempotencyKeyModel) daos.IdempotencyKeyDAO {
+	var responseBody []byte
+	if record.ResponseBody != nil {
+		responseBody = []byte(*record.ResponseBody)
+	}
+
+	return daos.IdempotencyKeyDAO{
+		Scope:        record.Scope,
+		Key:          record.Key,
+		RequestHash:  record.RequestHash,
+		StatusCode:   record.StatusCode,
+		ResponseBody: responseBody,
+		CreatedAt:    record.CreatedAt,
+		LockedAt:     record.LockedAt,
+		ExpiresAt:    record.ExpiresAt,
+		CompletedAt:  record.CompletedAt,
+	}
+}
+
+func FromProcessedMessageDAOToModel(message daos.ProcessedMessageDAO) models.ProcessedMessageModel {
+	return models.ProcessedMessageModel{
+		Consumer:    message.Consumer,
+		MessageID:   message.MessageID,
+		OrderID:     message.OrderID,
+		OccurredAt:  message.OccurredAt,
+		ProcessedAt: message.ProcessedAt,
+	}
+}
@@ -1,6 +1,7 @@
 package data_source
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
@@ -16,7 +17,7 @@ func TestFromDAOToModel(t *testing.T) {
 	dao := daos.OrderDAO{
 		ID:         "order-1",
 		CustomerID: &customerID,
-		Amount:     100.0,
+		Amount:     10000,
 		Status: daos.OrderStatusDAO{
 			ID:   "status-1",
 			Name: "Pending",
@@ -27,7 +28,15 @@ func TestFromDAOToModel(t *testing.T) {
 				OrderID:   "order-1",
 				ProductID: "product-1",
 				Quantity:  2,
-				UnitPrice: 50.0,
+				UnitPrice: 5000,
+
+				ProductName:     "X-Burger",
+				ProductCategory: "Lanche",
+				ProductImageURL: "https://cdn.example.com/x-burger.png",
+				ProductNotes:    "Contém glúten",
+
+				Notes:     "sem cebola",
+				Modifiers: []daos.OrderItemModifierDAO{{ID: "modifier-1", OrderItemID: "item-1", ModifierID: "queijo-extra", Name: "Queijo extra", PriceDelta: 300}},
 			},
 		},
 		CreatedAt: now,
@@ -42,7 +51,7 @@ func TestFromDAOToModel(t *testing.T) {
 	if *model.CustomerID != customerID {
 		t.Errorf("FromDAOToModel() CustomerID = %v, want %v", *model.CustomerID, customerID)
 	}
-	if model.Amount != 100.0 {
+	if model.Amount != 10000 {
 		t.Errorf("FromDAOToModel() Amount = %v, want 100.0", model.Amount)
 	}
 	if model.StatusID != "status-1" {
@@ -66,9 +75,17 @@ func TestFromDAOToModel(t *testing.T) {
 	if model.Items[0].Quantity != 2 {
 		t.Errorf("FromDAOToModel() Items[0].Quantity = %v, want 2", model.Items[0].Quantity)
 	}
-	if model.Items[0].UnitPrice != 50.0 {
+	if model.Items[0].UnitPrice != 5000 {
 		t.Errorf("FromDAOToModel() Items[0].UnitPrice = %v, want 50.0", model.Items[0].UnitPrice)
 	}
+	if model.Items[0].ProductName != "X-Burger" || model.Items[0].ProductCategory != "Lanche" ||
+		model.Items[0].ProductImageURL != "https://cdn.example.com/x-burger.png" || model.Items[0].ProductNotes != "Contém glúten" {
+		t.Errorf("FromDAOToModel() Items[0] product snapshot = %+v", model.Items[0])
+	}
+	expectedModifier := models.OrderItemModifierModel{ID: "modifier-1", OrderItemID: "item-1", ModifierID: "queijo-extra", Name: "Queijo extra", PriceDelta: 300}
+	if model.Items[0].Notes != "sem cebola" || len(model.Items[0].Modifiers) != 1 || model.Items[0].Modifiers[0] != expectedModifier {
+		t.Errorf("FromDAOToModel() Items[0] notes/modifiers = %q %+v", model.Items[0].Notes, model.Items[0].Modifiers)
+	}
 }
 
 func TestFromDAOToModel_NilCustomerID(t *testing.T) {
@@ -77,7 +94,7 @@ func TestFromDAOToModel_NilCustomerID(t *testing.T) {
 	dao := daos.OrderDAO{
 		ID:         "order-1",
 		CustomerID: nil,
-		Amount:     100.0,
+		Amount:     10000,
 		Status: daos.OrderStatusDAO{
 			ID:   "status-1",
 			Name: "Pending",
@@ -105,7 +122,7 @@ func TestFromModelToDAO(t *testing.T) {
 	model := models.OrderModel{
 		ID:         "order-1",
 		CustomerID: &customerID,
-		Amount:     100.0,
+		Amount:     10000,
 		StatusID:   "status-1",
 		Status: models.OrderStatusModel{
 			ID:   "status-1",
@@ -117,7 +134,15 @@ func TestFromModelToDAO(t *testing.T) {
 				OrderID:   "order-1",
 				ProductID: "product-1",
 				Quantity:  2,
-				UnitPrice: 50.0,
+				UnitPrice: 5000,
+
+				ProductName:     "X-Burger",
+				ProductCategory: "Lanche",
+				ProductImageURL: "https://cdn.example.com/x-burger.png",
+				ProductNotes:    "Contém glúten",
+
+				Notes:     "sem cebola",
+				Modifiers: []models.OrderItemModifierModel{{ID: "modifier-1", OrderItemID: "item-1", ModifierID: "queijo-extra", Name: "Queijo extra", PriceDelta: 300}},
 			},
 		},
 		CreatedAt: now,
@@ -132,7 +157,7 @@ func TestFromModelToDAO(t *testing.T) {
 	if *dao.CustomerID != customerID {
 		t.Errorf("FromModelToDAO() CustomerID = %v, want %v", *dao.CustomerID, customerID)
 	}
-	if dao.Amount != 100.0 {
+	if dao.Amount != 10000 {
 		t.Errorf("FromModelToDAO() Amount = %v, want 100.0", dao.Amount)
 	}
 	if dao.Status.ID != "status-1" {
@@ -147,6 +172,14 @@ func TestFromModelToDAO(t *testing.T) {
 	if dao.Items[0].ID != "item-1" {
 		t.Errorf("FromModelToDAO() Items[0].ID = %v, want item-1", dao.Items[0].ID)
 	}
+	if dao.Items[0].ProductName != "X-Burger" || dao.Items[0].ProductCategory != "Lanche" ||
+		dao.Items[0].ProductImageURL != "https://cdn.example.com/x-burger.png" || dao.Items[0].ProductNotes != "Contém glúten" {
+		t.Errorf("FromModelToDAO() Items[0] product snapshot = %+v", dao.Items[0])
+	}
+	expectedModifier := daos.OrderItemModifierDAO{ID: "modifier-1", OrderItemID: "item-1", ModifierID: "queijo-extra", Name: "Queijo extra", PriceDelta: 300}
+	if dao.Items[0].Notes != "sem cebola" || len(dao.Items[0].Modifiers) != 1 || dao.Items[0].Modifiers[0] != expectedModifier {
+		t.Errorf("FromModelToDAO() Items[0] notes/modifiers = %q %+v", dao.Items[0].Notes, dao.Items[0].Modifiers)
+	}
 }
 
 func TestFromModelToDAO_NilCustomerID(t *testing.T) {
@@ -155,7 +188,7 @@ func TestFromModelToDAO_NilCustomerID(t *testing.T) {
 	model := models.OrderModel{
 		ID:         "order-1",
 		CustomerID: nil,
-		Amount:     100.0,
+		Amount:     10000,
 		StatusID:   "status-1",
 		Status: models.OrderStatusModel{
 			ID:   "status-1",
@@ -184,7 +217,7 @@ func TestFromModelArrayToDAOArray(t *testing.T) {
 		{
 			ID:         "order-1",
 			CustomerID: &customerID,
-			Amount:     100.0,
+			Amount:     10000,
 			StatusID:   "status-1",
 			Status: models.OrderStatusModel{
 				ID:   "status-1",
@@ -196,7 +229,7 @@ func TestFromModelArrayToDAOArray(t *testing.T) {
 		{
 			ID:         "order-2",
 			CustomerID: &customerID,
-			Amount:     200.0,
+			Amount:     20000,
 			StatusID:   "status-2",
 			Status: models.OrderStatusModel{
 				ID:   "status-2",
@@ -228,3 +261,136 @@ func TestFromModelArrayToDAOArray_Empty(t *testing.T) {
 		t.Errorf("FromModelArrayToDAOArray() length = %v, want 0", len(daos))
 	}
 }
+
+func TestMappers_ParentItemID(t *testing.T) {
+	comboItemID := "item-1"
+	dao := daos.OrderDAO{
+		ID:     "order-1",
+		Status: daos.OrderStatusDAO{ID: "status-1", Name: "Pending"},
+		Items: []daos.OrderItemDAO{
+			{ID: "item-1", OrderID: "order-1", ProductID: "combo-1", Quantity: 1, UnitPrice: 3990},
+			{ID: "item-2", OrderID: "order-1", ProductID: "product-1", Quantity: 1, UnitPrice: 2590, ParentItemID: &comboItemID},
+		},
+	}
+
+	model := FromDAOToModel(dao)
+	if model.Items[0].ParentItemID != nil || model.Items[1].ParentItemID == nil || *model.Items[1].ParentItemID != "item-1" {
+		t.Errorf("FromDAOToModel() ParentItemID = %v, %v, want nil, item-1", model.Items[0].ParentItemID, model.Items[1].ParentItemID)
+	}
+
+	back := FromModelToDAO(model)
+	if back.Items[0].ParentItemID != nil || back.Items[1].ParentItemID == nil || *back.Items[1].ParentItemID != "item-1" {
+		t.Errorf("FromModelToDAO() ParentItemID = %v, %v, want nil, item-1", back.Items[0].ParentItemID, back.Items[1].ParentItemID)
+	}
+}
+
+func TestMappers_Discount(t *testing.T) {
+	couponCode := "PROMO10"
+	dao := daos.OrderDAO{
+		ID:         "order-1",
+		Amount:     900,
+		Subtotal:   1000,
+		Discount:   100,
+		CouponCode: &couponCode,
+		Status:     daos.OrderStatusDAO{ID: "status-1", Name: "Recebido"},
+	}
+
+	model := FromDAOToModel(dao)
+	if model.Subtotal != 1000 || model.Discount != 100 || model.CouponCode == nil || *model.CouponCode != "PROMO10" {
+		t.Errorf("FromDAOToModel() discount = %v/%v/%v, want 1000/100/PROMO10", model.Subtotal, model.Discount, model.CouponCode)
+	}
+
+	back := FromModelToDAO(model)
+	if back.Subtotal != 1000 || back.Discount != 100 || back.CouponCode == nil || *back.CouponCode != "PROMO10" {
+		t.Errorf("FromModelToDAO() discount = %v/%v/%v, want 1000/100/PROMO10", back.Subtotal, back.Discount, back.CouponCode)
+	}
+}
+
+func TestMappers_Cancellation(t *testing.T) {
+	reason := "Cliente desistiu"
+	cancelledAt := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	dao := daos.OrderDAO{
+		ID:                 "order-1",
+		Status:             daos.OrderStatusDAO{ID: "status-9", Name: "Cancelado"},
+		CancellationReason: &reason,
+		CancelledAt:        &cancelledAt,
+	}
+
+	back := FromModelToDAO(FromDAOToModel(dao))
+	if back.CancellationReason == nil || *back.CancellationReason != reason {
+		t.Errorf("CancellationReason = %v, want %v", back.CancellationReason, reason)
+	}
+	if back.CancelledAt == nil || !back.CancelledAt.Equal(cancelledAt) {
+		t.Errorf("CancelledAt = %v, want %v", back.CancelledAt, cancelledAt)
+	}
+}
+
+func TestMappers_DeletedAt(t *testing.T) {
+	deletedAt := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	model := FromDAOToModel(daos.OrderDAO{ID: "order-1", DeletedAt: &deletedAt})
+	if !model.DeletedAt.Valid || !model.DeletedAt.Time.Equal(deletedAt) {
+		t.Errorf("FromDAOToModel() DeletedAt = %v, want %v", model.DeletedAt, deletedAt)
+	}
+	back := FromModelToDAO(model)
+	if back.DeletedAt == nil || !back.DeletedAt.Equal(deletedAt) {
+		t.Errorf("FromModelToDAO() DeletedAt = %v, want %v", back.DeletedAt, deletedAt)
+	}
+
+	if active := FromModelToDAO(FromDAOToModel(daos.OrderDAO{ID: "order-2"})); active.DeletedAt != nil {
+		t.Errorf("FromModelToDAO() DeletedAt = %v, want nil", active.DeletedAt)
+	}
+}
+
+func TestMappers_Coupon(t *testing.T) {
+	validUntil := time.Date(2024, 12, 31, 23, 59, 0, 0, time.UTC)
+	dao := daos.CouponDAO{
+		Code:           "LEVE3PAGUE2",
+		Type:           "buy_x_get_y",
+		Currency:       "BRL",
+		ProductID:      "product-1",
+		BuyQuantity:    2,
+		FreeQuantity:   1,
+		FirstOrderOnly: true,
+		ValidUntil:     &validUntil,
+		MaxUses:        100,
+		UsedCount:      3,
+		CreatedAt:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	back := FromCouponModelToDAO(FromCouponDAOToModel(dao))
+	if !reflect.DeepEqual(back, dao) {
+		t.Errorf("coupon round trip = %+v, want %+v", back, dao)
+	}
+}
+
+func TestMappers_PickupCode(t *testing.T) {
+	day, code := "2026-10-16", "A042"
+
+	model := FromDAOToModel(daos.OrderDAO{ID: "order-1", PickupDate: &day, PickupCode: &code})
+	if model.PickupDate == nil || *model.PickupDate != day || model.PickupCode == nil || *model.PickupCode != code {
+		t.Errorf("FromDAOToModel() pickup = %v/%v, want %v/%v", model.PickupDate, model.PickupCode, day, code)
+	}
+	back := FromModelToDAO(model)
+	if back.PickupDate == nil || *back.PickupDate != day || back.PickupCode == nil || *back.PickupCode != code {
+		t.Errorf("FromModelToDAO() pickup = %v/%v, want %v/%v", back.PickupDate, back.PickupCode, day, code)
+	}
+}
+
+func TestMappers_CustomerName(t *testing.T) {
+	name := "Maria"
+
+	back := FromModelToDAO(FromDAOToModel(daos.OrderDAO{ID: "order-1", CustomerName: &name}))
+	if back.CustomerName == nil || *back.CustomerName != name {
+		t.Errorf("CustomerName = %v, want %v", back.CustomerName, name)
+	}
+}
+
+func TestMappers_GuestTokenHash(t *testing.T) {
+	hash := "5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"
+
+	back := FromModelToDAO(FromDAOToModel(daos.OrderDAO{ID: "order-1", GuestTokenHash: &hash}))
+	if back.GuestTokenHash == nil || *back.GuestTokenHash != hash {
+		t.Errorf("GuestTokenHash = %v, want %v", back.GuestTokenHash, hash)
+	}
+}
@@ -1,37 +1,105 @@
 package data_source
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"microservice/infra/db/postgres"
 	"microservice/infra/db/postgres/models"
 	"microservice/internal/adapters/daos"
 	"microservice/internal/adapters/dtos"
+	"microservice/internal/domain/exceptions"
 )
 
 type GormOrderDataSource struct {
-	db *gorm.DB
+	db           *gorm.DB
+	queryTimeout time.Duration
 }
 
 func NewGormOrderDataSource() *GormOrderDataSource {
 	return &GormOrderDataSource{
-		db: postgres.GetDB(),
+		db:           postgres.GetDB(),
+		queryTimeout: postgres.QueryTimeout(),
 	}
 }
 
-func (r *GormOrderDataSource) Create(order daos.OrderDAO) error {
+// Consultas param quando a requisição é cancelada
+func (r *GormOrderDataSource) session(ctx context.Context) (*gorm.DB, context.CancelFunc) {
+	return withQueryTimeout(ctx, r.db, r.queryTimeout)
+}
+
+func (r *GormOrderDataSource) Create(ctx context.Context, order daos.OrderDAO, outbox ...daos.OutboxMessageDAO) error {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
 	orderModel := FromDAOToModel(order)
-	return r.db.Create(&orderModel).Error
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&orderModel).Error; err != nil {
+			return err
+		}
+		if err := insertStatusHistory(tx, order.StatusChanges); err != nil {
+			return err
+		}
+		return insertOutboxMessages(tx, outbox)
+	})
 }
 
-func (r *GormOrderDataSource) FindAll(filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error) {
+func (r *GormOrderDataSource) FindAll(ctx context.Context, filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
 	var orders []models.OrderModel
 
-	query := r.db.
+	direction := "DESC"
+	keysetOperator := "<"
+	if filter.Sort == dtos.ORDER_SORT_CREATED_AT_ASC {
+		direction = "ASC"
+		keysetOperator = ">"
+	}
+
+	query := applyOrderFilter(db.Model(&models.OrderModel{}), filter).
 		Preload("Status").
-		Preload("Items").
-		Order("orders.created_at DESC")
+		Preload("Items.Modifiers").
+		Order("orders.created_at " + direction).
+		Order("orders.id " + direction)
+
+	// Paginação por keyset: continua a partir do último pedido da página anterior
+	if filter.After != nil {
+		query = query.Where(
+			"(orders.created_at "+keysetOperator+" ?) OR (orders.created_at = ? AND orders.id "+keysetOperator+" ?)",
+			filter.After.CreatedAt, filter.After.CreatedAt, filter.After.ID,
+		)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	if err := query.Find(&orders).Error; err != nil {
+		return nil, err
+	}
 
+	return FromModelArrayToDAOArray(orders), nil
+}
+
+func (r *GormOrderDataSource) Count(ctx context.Context, filter dtos.OrderFilterDTO) (int64, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	var total int64
+
+	if err := applyOrderFilter(db.Model(&models.OrderModel{}), filter).Count(&total).Error; err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func applyOrderFilter(query *gorm.DB, filter dtos.OrderFilterDTO) *gorm.DB {
 	if filter.CreatedAtFrom != nil {
 		query = query.Where("orders.created_at >= ?", *filter.CreatedAtFrom)
 	}
@@ -44,34 +112,371 @@ func (r *GormOrderDataSource) FindAll(filter dtos.OrderFilterDTO) ([]daos.OrderD
 	if filter.CustomerID != nil {
 		query = query.Where("orders.customer_id = ?", *filter.CustomerID)
 	}
+	if len(filter.ExcludedStatuses) > 0 {
+		query = query.Where("orders.status_id NOT IN (?)", query.Session(&gorm.Session{NewDB: true}).
+			Model(&models.OrderStatusModel{}).
+			Select("id").
+			Where("name IN ?", filter.ExcludedStatuses))
+	}
+	return query
+}
 
-	if err := query.Find(&orders).Error; err != nil {
-		return nil, err
+func (r *GormOrderDataSource) FindByID(ctx context.Context, id string) (daos.OrderDAO, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	var order models.OrderModel
+
+	if err := db.Preload("Status").Preload("Items.Modifiers").First(&order, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return daos.OrderDAO{}, &exceptions.OrderNotFoundException{}
+		}
+		return daos.OrderDAO{}, err
 	}
 
-	return FromModelArrayToDAOArray(orders), nil
+	return FromModelToDAO(order), nil
 }
 
-func (r *GormOrderDataSource) FindByID(id string) (daos.OrderDAO, error) {
+func (r *GormOrderDataSource) FindByPickupCode(ctx context.Context, day string, code string) (daos.OrderDAO, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
 	var order models.OrderModel
 
-	if err := r.db.Preload("Status").Preload("Items").First(&order, "id = ?", id).Error; err != nil {
+	err := db.Preload("Status").Preload("Items.Modifiers").
+		First(&order, "pickup_date = ? AND pickup_code = ?", day, code).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return daos.OrderDAO{}, &exceptions.OrderNotFoundException{}
+		}
 		return daos.OrderDAO{}, err
 	}
 
 	return FromModelToDAO(order), nil
 }
 
-func (r *GormOrderDataSource) Update(order daos.OrderDAO) error {
-	orderModel := FromDAOToModel(order)
-	return r.db.Save(&orderModel).Error
+func (r *GormOrderDataSource) FindBoard(ctx context.Context, statusIDs []string, createdFrom time.Time) ([]daos.OrderBoardEntryDAO, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	var entries []daos.OrderBoardEntryDAO
+
+	// Só as colunas do resumo; itens não são carregados
+	err := db.Model(&models.OrderModel{}).
+		Select("orders.id, orders.pickup_code, orders.customer_name, orders.status_id, order_status.name AS status_name, orders.created_at").
+		Joins("JOIN order_status ON order_status.id = orders.status_id").
+		Where("orders.status_id IN ?", statusIDs).
+		Where("orders.created_at >= ?", createdFrom).
+		Order("orders.created_at ASC").
+		Order("orders.id ASC").
+		Scan(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// O upsert trava a linha do dia, então pedidos concorrentes nunca recebem o mesmo número
+func (r *GormOrderDataSource) NextPickupSequence(ctx context.Context, day string) (int, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	var sequence int
+	err := db.Raw(
+		"INSERT INTO pickup_sequences (day, last_value) VALUES (?, 1) "+
+			"ON CONFLICT (day) DO UPDATE SET last_value = pickup_sequences.last_value + 1 "+
+			"RETURNING last_value",
+		day,
+	).Scan(&sequence).Error
+	if err != nil {
+		return 0, err
+	}
+	return sequence, nil
+}
+
+func (r *GormOrderDataSource) Update(ctx context.Context, order daos.OrderDAO, outbox ...daos.OutboxMessageDAO) error {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		return saveOrderUpdate(tx, order, outbox)
+	})
+}
+
+// Uma mensagem gravada em paralelo viola a chave primária e desfaz a atualização do pedido
+func (r *GormOrderDataSource) UpdateFromMessage(ctx context.Context, order daos.OrderDAO, message daos.ProcessedMessageDAO, outbox ...daos.OutboxMessageDAO) error {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	processedMessage := FromProcessedMessageDAOToModel(message)
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := saveOrderUpdate(tx, order, outbox); err != nil {
+			return err
+		}
+		return tx.Create(&processedMessage).Error
+	})
+}
+
+func saveOrderUpdate(tx *gorm.DB, order daos.OrderDAO, outbox []daos.OutboxMessageDAO) error {
+	err := updateOrderColumns(tx, order, map[string]any{
+		"status_id":           order.Status.ID,
+		"cancellation_reason": order.CancellationReason,
+		"cancelled_at":        order.CancelledAt,
+	})
+	if err != nil {
+		return err
+	}
+	if err := insertStatusHistory(tx, order.StatusChanges); err != nil {
+		return err
+	}
+	return insertOutboxMessages(tx, outbox)
+}
+
+// Na mesma transação, para que resgates concorrentes não passem de MaxUses
+func (r *GormOrderDataSource) ApplyCoupon(ctx context.Context, order daos.OrderDAO, outbox ...daos.OutboxMessageDAO) error {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	if order.CouponCode == nil {
+		return &exceptions.CouponNotApplicableException{Message: "Order has no coupon to redeem"}
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.CouponModel{}).
+			Where("code = ? AND (max_uses = 0 OR used_count < max_uses)", *order.CouponCode).
+			Update("used_count", gorm.Expr("used_count + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return &exceptions.CouponUsageLimitReachedException{}
+		}
+		if err := redeemFirstOrderCoupon(tx, order); err != nil {
+			return err
+		}
+
+		err := updateOrderColumns(tx, order, map[string]any{
+			"amount":      order.Amount,
+			"subtotal":    order.Subtotal,
+			"discount":    order.Discount,
+			"coupon_code": order.CouponCode,
+		})
+		if err != nil {
+			return err
+		}
+		return insertOutboxMessages(tx, outbox)
+	})
+}
+
+// Só grava se o pedido ainda está na versão lida; pedidos removidos também não casam
+func updateOrderColumns(tx *gorm.DB, order daos.OrderDAO, columns map[string]any) error {
+	// Como o Save fazia, updated_at é sempre o momento da gravação
+	columns["updated_at"] = time.Now()
+	columns["version"] = gorm.Expr("version + 1")
+	result := tx.Model(&models.OrderModel{}).
+		Where("id = ? AND version = ?", order.ID, order.Version).
+		Updates(columns)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return &exceptions.OrderConcurrentModificationException{}
+	}
+	return nil
+}
+
+// A chave (cupom, cliente) impede um segundo resgate, mesmo com o primeiro pedido cancelado
+func redeemFirstOrderCoupon(tx *gorm.DB, order daos.OrderDAO) error {
+	if order.CustomerID == nil {
+		return nil
+	}
+
+	var coupon models.CouponModel
+	if err := tx.Select("first_order_only").First(&coupon, "code = ?", *order.CouponCode).Error; err != nil {
+		return err
+	}
+	if !coupon.FirstOrderOnly {
+		return nil
+	}
+
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.CouponRedemptionModel{
+		CouponCode: *order.CouponCode,
+		CustomerID: *order.CustomerID,
+		OrderID:    order.ID,
+		RedeemedAt: time.Now(),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return &exceptions.CouponNotApplicableException{
+			Message: fmt.Sprintf("Coupon %s was already redeemed by this customer", *order.CouponCode),
+		}
+	}
+	return nil
+}
+
+// Só vale enquanto o pedido não tem cliente e ainda tem o token, então duas
+// reivindicações concorrentes não passam
+func (r *GormOrderDataSource) Claim(ctx context.Context, orderID string, customerID string, guestTokenHash string, claimedAt time.Time) error {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	result := db.Model(&models.OrderModel{}).
+		Where("id = ? AND customer_id IS NULL AND guest_token_hash = ?", orderID, guestTokenHash).
+		Updates(map[string]any{
+			"customer_id":      customerID,
+			"guest_token_hash": nil,
+			"updated_at":       claimedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return &exceptions.OrderAlreadyClaimedException{}
+	}
+	return nil
+}
+
+func (r *GormOrderDataSource) ReissueGuestToken(ctx context.Context, orderID string, guestTokenHash string) error {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	result := db.Model(&models.OrderModel{}).
+		Where("id = ? AND customer_id IS NULL", orderID).
+		Update("guest_token_hash", guestTokenHash)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return &exceptions.OrderAlreadyClaimedException{}
+	}
+	return nil
+}
+
+// Itens e histórico ficam para que o pedido possa ser restaurado até o expurgo
+func (r *GormOrderDataSource) Delete(ctx context.Context, id string, outbox ...daos.OutboxMessageDAO) error {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.OrderModel{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+		return insertOutboxMessages(tx, outbox)
+	})
+}
+
+func (r *GormOrderDataSource) Restore(ctx context.Context, id string, outbox ...daos.OutboxMessageDAO) error {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&models.OrderModel{}).
+			Where("id = ? AND deleted_at IS NOT NULL", id).
+			Update("deleted_at", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return &exceptions.OrderNotFoundException{Message: "Deleted order not found"}
+		}
+		return insertOutboxMessages(tx, outbox)
+	})
 }
 
-func (r *GormOrderDataSource) Delete(id string) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
-		if err := tx.Delete(&models.OrderItemModel{}, "order_id = ?", id).Error; err != nil {
+// Remove também itens, histórico, mensagens processadas e mensagens já enviadas;
+// os pedidos ficam travados para que um Restore concorrente espere
+func (r *GormOrderDataSource) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	var purged int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var ids []string
+		err := tx.Unscoped().Model(&models.OrderModel{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).
+			Order("deleted_at ASC").
+			Limit(limit).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
 			return err
 		}
-		return tx.Delete(&models.OrderModel{}, "id = ?", id).Error
+
+		itemIDs := tx.Model(&models.OrderItemModel{}).Select("id").Where("order_id IN ?", ids)
+		if err := tx.Delete(&models.OrderItemModifierModel{}, "order_item_id IN (?)", itemIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.OrderItemModel{}, "order_id IN ?", ids).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.OrderStatusHistoryModel{}, "order_id IN ?", ids).Error; err != nil {
+			return err
+		}
+		// Resgates de cupom ficam: apagá-los liberaria o cupom de primeiro pedido de novo
+		if err := tx.Delete(&models.ProcessedMessageModel{}, "order_id IN ?", ids).Error; err != nil {
+			return err
+		}
+		// Mensagens pendentes continuam até o relay publicá-las
+		if err := tx.Delete(&models.OutboxModel{}, "order_id IN ? AND sent_at IS NOT NULL", ids).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Delete(&models.OrderModel{}, "id IN ?", ids)
+		purged = result.RowsAffected
+		return result.Error
 	})
+	if err != nil {
+		return 0, err
+	}
+	return purged, nil
+}
+
+func (r *GormOrderDataSource) FindStatusHistory(ctx context.Context, orderID string) ([]daos.OrderStatusHistoryDAO, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	var history []models.OrderStatusHistoryModel
+
+	err := db.
+		Preload("PreviousStatus").
+		Preload("NewStatus").
+		Where("order_id = ?", orderID).
+		Order("changed_at ASC, id ASC").
+		Find(&history).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]daos.OrderStatusHistoryDAO, len(history))
+	for i, change := range history {
+		result[i] = FromStatusHistoryModelToDAO(change)
+	}
+	return result, nil
+}
+
+func insertStatusHistory(tx *gorm.DB, changes []daos.OrderStatusHistoryDAO) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	historyModels := make([]models.OrderStatusHistoryModel, len(changes))
+	for i, change := range changes {
+		historyModels[i] = FromStatusHistoryDAOToModel(change)
+	}
+	return tx.Create(&historyModels).Error
+}
+
+func insertOutboxMessages(tx *gorm.DB, outbox []daos.OutboxMessageDAO) error {
+	if len(outbox) == 0 {
+		return nil
+	}
+
+	outboxModels := make([]models.OutboxModel, len(outbox))
+	for i, message := range outbox {
+		outboxModels[i] = FromOutboxDAOToModel(message)
+	}
+	return tx.Create(&outboxModels).Error
 }
@@ -1,6 +1,7 @@
 package data_source
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -8,6 +9,8 @@ import (
 
 	"microservice/infra/db/postgres/models"
 	"microservice/internal/adapters/daos"
+	"microservice/internal/adapters/dtos"
+	"microservice/internal/domain/exceptions"
 )
 
 // ============================================================================
@@ -32,7 +35,7 @@ func TestFromDAOToModel_BasicConversion(t *testing.T) {
 	dao := daos.OrderDAO{
 		ID:         "order-1",
 		CustomerID: &customerID,
-		Amount:     100.0,
+		Amount:     10000,
 		Status: daos.OrderStatusDAO{
 			ID:   "status-1",
 			Name: "PENDING",
@@ -43,7 +46,7 @@ func TestFromDAOToModel_BasicConversion(t *testing.T) {
 				OrderID:   "order-1",
 				ProductID: "product-1",
 				Quantity:  2,
-				UnitPrice: 50.0,
+				UnitPrice: 5000,
 			},
 		},
 		CreatedAt: now,
@@ -53,13 +56,13 @@ func TestFromDAOToModel_BasicConversion(t *testing.T) {
 
 	assert.Equal(t, "order-1", model.ID)
 	assert.Equal(t, customerID, *model.CustomerID)
-	assert.Equal(t, 100.0, model.Amount)
+	assert.Equal(t, int64(10000), model.Amount)
 	assert.Equal(t, "status-1", model.StatusID)
 	assert.Equal(t, "PENDING", model.Status.Name)
 	assert.Len(t, model.Items, 1)
 	assert.Equal(t, "product-1", model.Items[0].ProductID)
 	assert.Equal(t, 2, model.Items[0].Quantity)
-	assert.Equal(t, 50.0, model.Items[0].UnitPrice)
+	assert.Equal(t, int64(5000), model.Items[0].UnitPrice)
 }
 
 func TestFromDAOToModel_NilCustomerIDUnit(t *testing.T) {
@@ -68,7 +71,7 @@ func TestFromDAOToModel_NilCustomerIDUnit(t *testing.T) {
 	dao := daos.OrderDAO{
 		ID:         "order-1",
 		CustomerID: nil,
-		Amount:     100.0,
+		Amount:     10000,
 		Status: daos.OrderStatusDAO{
 			ID:   "status-1",
 			Name: "PENDING",
@@ -90,7 +93,7 @@ func TestFromDAOToModel_EmptyItems(t *testing.T) {
 	dao := daos.OrderDAO{
 		ID:         "order-1",
 		CustomerID: &customerID,
-		Amount:     100.0,
+		Amount:     10000,
 		Status: daos.OrderStatusDAO{
 			ID:   "status-1",
 			Name: "PENDING",
@@ -111,15 +114,15 @@ func TestFromDAOToModel_MultipleItems(t *testing.T) {
 	dao := daos.OrderDAO{
 		ID:         "order-1",
 		CustomerID: &customerID,
-		Amount:     300.0,
+		Amount:     30000,
 		Status: daos.OrderStatusDAO{
 			ID:   "status-1",
 			Name: "PENDING",
 		},
 		Items: []daos.OrderItemDAO{
-			{ID: "item-1", OrderID: "order-1", ProductID: "product-1", Quantity: 2, UnitPrice: 50.0},
-			{ID: "item-2", OrderID: "order-1", ProductID: "product-2", Quantity: 1, UnitPrice: 100.0},
-			{ID: "item-3", OrderID: "order-1", ProductID: "product-3", Quantity: 5, UnitPrice: 20.0},
+			{ID: "item-1", OrderID: "order-1", ProductID: "product-1", Quantity: 2, UnitPrice: 5000},
+			{ID: "item-2", OrderID: "order-1", ProductID: "product-2", Quantity: 1, UnitPrice: 10000},
+			{ID: "item-3", OrderID: "order-1", ProductID: "product-3", Quantity: 5, UnitPrice: 2000},
 		},
 		CreatedAt: now,
 	}
@@ -140,7 +143,7 @@ func TestFromDAOToModel_WithUpdatedAt(t *testing.T) {
 	dao := daos.OrderDAO{
 		ID:         "order-1",
 		CustomerID: &customerID,
-		Amount:     100.0,
+		Amount:     10000,
 		Status: daos.OrderStatusDAO{
 			ID:   "status-1",
 			Name: "PENDING",
@@ -163,7 +166,7 @@ func TestFromDAOToModel_NilUpdatedAt(t *testing.T) {
 	dao := daos.OrderDAO{
 		ID:         "order-1",
 		CustomerID: &customerID,
-		Amount:     100.0,
+		Amount:     10000,
 		Status: daos.OrderStatusDAO{
 			ID:   "status-1",
 			Name: "PENDING",
@@ -181,12 +184,12 @@ func TestFromDAOToModel_NilUpdatedAt(t *testing.T) {
 func TestFromDAOToModel_DifferentAmounts(t *testing.T) {
 	tests := []struct {
 		name   string
-		amount float64
+		amount int64
 	}{
-		{"Zero amount", 0.0},
-		{"Small amount", 0.01},
-		{"Large amount", 999999.99},
-		{"Decimal amount", 123.45},
+		{"Zero amount", 0},
+		{"Small amount", 1},
+		{"Large amount", 99999999},
+		{"Decimal amount", 12345},
 	}
 
 	for _, tt := range tests {
@@ -224,7 +227,7 @@ func TestFromModelToDAO_BasicConversion(t *testing.T) {
 	model := models.OrderModel{
 		ID:         "order-1",
 		CustomerID: &customerID,
-		Amount:     100.0,
+		Amount:     10000,
 		StatusID:   "status-1",
 		Status: models.OrderStatusModel{
 			ID:   "status-1",
@@ -236,7 +239,7 @@ func TestFromModelToDAO_BasicConversion(t *testing.T) {
 				OrderID:   "order-1",
 				ProductID: "product-1",
 				Quantity:  2,
-				UnitPrice: 50.0,
+				UnitPrice: 5000,
 			},
 		},
 		CreatedAt: now,
@@ -246,7 +249,7 @@ func TestFromModelToDAO_BasicConversion(t *testing.T) {
 
 	assert.Equal(t, "order-1", dao.ID)
 	assert.Equal(t, customerID, *dao.CustomerID)
-	assert.Equal(t, 100.0, dao.Amount)
+	assert.Equal(t, int64(10000), dao.Amount)
 	assert.Equal(t, "PENDING", dao.Status.Name)
 	assert.Len(t, dao.Items, 1)
 }
@@ -257,7 +260,7 @@ func TestFromModelToDAO_NilCustomerIDUnit(t *testing.T) {
 	model := models.OrderModel{
 		ID:         "order-1",
 		CustomerID: nil,
-		Amount:     100.0,
+		Amount:     10000,
 		StatusID:   "status-1",
 		Status: models.OrderStatusModel{
 			ID:   "status-1",
@@ -279,7 +282,7 @@ func TestFromModelToDAO_EmptyItems(t *testing.T) {
 	model := models.OrderModel{
 		ID:         "order-1",
 		CustomerID: &customerID,
-		Amount:     100.0,
+		Amount:     10000,
 		StatusID:   "status-1",
 		Status: models.OrderStatusModel{
 			ID:   "status-1",
@@ -301,16 +304,16 @@ func TestFromModelToDAO_MultipleItems(t *testing.T) {
 	model := models.OrderModel{
 		ID:         "order-1",
 		CustomerID: &customerID,
-		Amount:     300.0,
+		Amount:     30000,
 		StatusID:   "status-1",
 		Status: models.OrderStatusModel{
 			ID:   "status-1",
 			Name: "PENDING",
 		},
 		Items: []models.OrderItemModel{
-			{ID: "item-1", OrderID: "order-1", ProductID: "product-1", Quantity: 2, UnitPrice: 50.0},
-			{ID: "item-2", OrderID: "order-1", ProductID: "product-2", Quantity: 1, UnitPrice: 100.0},
-			{ID: "item-3", OrderID: "order-1", ProductID: "product-3", Quantity: 5, UnitPrice: 20.0},
+			{ID: "item-1", OrderID: "order-1", ProductID: "product-1", Quantity: 2, UnitPrice: 5000},
+			{ID: "item-2", OrderID: "order-1", ProductID: "product-2", Quantity: 1, UnitPrice: 10000},
+			{ID: "item-3", OrderID: "order-1", ProductID: "product-3", Quantity: 5, UnitPrice: 2000},
 		},
 		CreatedAt: now,
 	}
@@ -331,7 +334,7 @@ func TestFromModelToDAO_WithUpdatedAt(t *testing.T) {
 	model := models.OrderModel{
 		ID:         "order-1",
 		CustomerID: &customerID,
-		Amount:     100.0,
+		Amount:     10000,
 		StatusID:   "status-1",
 		Status: models.OrderStatusModel{
 			ID:   "status-1",
@@ -355,7 +358,7 @@ func TestFromModelToDAO_NilUpdatedAt(t *testing.T) {
 	model := models.OrderModel{
 		ID:         "order-1",
 		CustomerID: &customerID,
-		Amount:     100.0,
+		Amount:     10000,
 		StatusID:   "status-1",
 		Status: models.OrderStatusModel{
 			ID:   "status-1",
@@ -383,7 +386,7 @@ func TestFromModelArrayToDAOArray_SingleOrder(t *testing.T) {
 		{
 			ID:         "order-1",
 			CustomerID: &customerID,
-			Amount:     100.0,
+			Amount:     10000,
 			StatusID:   "status-1",
 			Status: models.OrderStatusModel{
 				ID:   "status-1",
@@ -408,7 +411,7 @@ func TestFromModelArrayToDAOArray_MultipleOrders(t *testing.T) {
 		{
 			ID:         "order-1",
 			CustomerID: &customerID,
-			Amount:     100.0,
+			Amount:     10000,
 			StatusID:   "status-1",
 			Status: models.OrderStatusModel{
 				ID:   "status-1",
@@ -420,7 +423,7 @@ func TestFromModelArrayToDAOArray_MultipleOrders(t *testing.T) {
 		{
 			ID:         "order-2",
 			CustomerID: &customerID,
-			Amount:     200.0,
+			Amount:     20000,
 			StatusID:   "status-2",
 			Status: models.OrderStatusModel{
 				ID:   "status-2",
@@ -432,7 +435,7 @@ func TestFromModelArrayToDAOArray_MultipleOrders(t *testing.T) {
 		{
 			ID:         "order-3",
 			CustomerID: &customerID,
-			Amount:     300.0,
+			Amount:     30000,
 			StatusID:   "status-3",
 			Status: models.OrderStatusModel{
 				ID:   "status-3",
@@ -470,15 +473,15 @@ func TestFromModelArrayToDAOArray_WithItems(t *testing.T) {
 		{
 			ID:         "order-1",
 			CustomerID: &customerID,
-			Amount:     100.0,
+			Amount:     10000,
 			StatusID:   "status-1",
 			Status: models.OrderStatusModel{
 				ID:   "status-1",
 				Name: "PENDING",
 			},
 			Items: []models.OrderItemModel{
-				{ID: "item-1", OrderID: "order-1", ProductID: "product-1", Quantity: 2, UnitPrice: 50.0},
-				{ID: "item-2", OrderID: "order-1", ProductID: "product-2", Quantity: 1, UnitPrice: 100.0},
+				{ID: "item-1", OrderID: "order-1", ProductID: "product-1", Quantity: 2, UnitPrice: 5000},
+				{ID: "item-2", OrderID: "order-1", ProductID: "product-2", Quantity: 1, UnitPrice: 10000},
 			},
 			CreatedAt: now,
 		},
@@ -503,7 +506,7 @@ func TestRoundTripConversion_DAOToModelToDAO(t *testing.T) {
 	originalDAO := daos.OrderDAO{
 		ID:         "order-1",
 		CustomerID: &customerID,
-		Amount:     100.0,
+		Amount:     10000,
 		Status: daos.OrderStatusDAO{
 			ID:   "status-1",
 			Name: "PENDING",
@@ -514,7 +517,7 @@ func TestRoundTripConversion_DAOToModelToDAO(t *testing.T) {
 				OrderID:   "order-1",
 				ProductID: "product-1",
 				Quantity:  2,
-				UnitPrice: 50.0,
+				UnitPrice: 5000,
 			},
 		},
 		CreatedAt: now,
@@ -538,7 +541,7 @@ func TestRoundTripConversion_ModelToDAOToModel(t *testing.T) {
 	originalModel := models.OrderModel{
 		ID:         "order-1",
 		CustomerID: &customerID,
-		Amount:     100.0,
+		Amount:     10000,
 		StatusID:   "status-1",
 		Status: models.OrderStatusModel{
 			ID:   "status-1",
@@ -550,7 +553,7 @@ func TestRoundTripConversion_ModelToDAOToModel(t *testing.T) {
 				OrderID:   "order-1",
 				ProductID: "product-1",
 				Quantity:  2,
-				UnitPrice: 50.0,
+				UnitPrice: 5000,
 			},
 		},
 		CreatedAt: now,
@@ -577,7 +580,7 @@ func TestFromDAOToModel_LargeAmount(t *testing.T) {
 	dao := daos.OrderDAO{
 		ID:         "order-1",
 		CustomerID: &customerID,
-		Amount:     999999999.99,
+		Amount:     99999999999,
 		Status: daos.OrderStatusDAO{
 			ID:   "status-1",
 			Name: "PENDING",
@@ -588,7 +591,7 @@ func TestFromDAOToModel_LargeAmount(t *testing.T) {
 
 	model := FromDAOToModel(dao)
 
-	assert.Equal(t, 999999999.99, model.Amount)
+	assert.Equal(t, int64(99999999999), model.Amount)
 }
 
 func TestFromDAOToModel_SpecialCharactersInIDs(t *testing.T) {
@@ -598,7 +601,7 @@ func TestFromDAOToModel_SpecialCharactersInIDs(t *testing.T) {
 	dao := daos.OrderDAO{
 		ID:         "order-special-!@#",
 		CustomerID: &customerID,
-		Amount:     100.0,
+		Amount:     10000,
 		Status: daos.OrderStatusDAO{
 			ID:   "status-special-!@#",
 			Name: "PENDING",
@@ -623,7 +626,7 @@ func TestFromModelArrayToDAOArray_LargeArray(t *testing.T) {
 		modelArray[i] = models.OrderModel{
 			ID:         "order-" + string(rune(i)),
 			CustomerID: &customerID,
-			Amount:     float64(i) * 10.0,
+			Amount:     int64(i) * 1000,
 			StatusID:   "status-1",
 			Status: models.OrderStatusModel{
 				ID:   "status-1",
@@ -646,15 +649,15 @@ func TestFromDAOToModel_ItemsPreservation(t *testing.T) {
 	dao := daos.OrderDAO{
 		ID:         "order-1",
 		CustomerID: &customerID,
-		Amount:     100.0,
+		Amount:     10000,
 		Status: daos.OrderStatusDAO{
 			ID:   "status-1",
 			Name: "PENDING",
 		},
 		Items: []daos.OrderItemDAO{
-			{ID: "item-1", OrderID: "order-1", ProductID: "product-1", Quantity: 2, UnitPrice: 50.0},
-			{ID: "item-2", OrderID: "order-1", ProductID: "product-2", Quantity: 1, UnitPrice: 100.0},
-			{ID: "item-3", OrderID: "order-1", ProductID: "product-3", Quantity: 5, UnitPrice: 20.0},
+			{ID: "item-1", OrderID: "order-1", ProductID: "product-1", Quantity: 2, UnitPrice: 5000},
+			{ID: "item-2", OrderID: "order-1", ProductID: "product-2", Quantity: 1, UnitPrice: 10000},
+			{ID: "item-3", OrderID: "order-1", ProductID: "product-3", Quantity: 5, UnitPrice: 2000},
 		},
 		CreatedAt: now,
 	}
@@ -677,16 +680,16 @@ func TestFromModelToDAO_ItemsPreservation(t *testing.T) {
 	model := models.OrderModel{
 		ID:         "order-1",
 		CustomerID: &customerID,
-		Amount:     100.0,
+		Amount:     10000,
 		StatusID:   "status-1",
 		Status: models.OrderStatusModel{
 			ID:   "status-1",
 			Name: "PENDING",
 		},
 		Items: []models.OrderItemModel{
-			{ID: "item-1", OrderID: "order-1", ProductID: "product-1", Quantity: 2, UnitPrice: 50.0},
-			{ID: "item-2", OrderID: "order-1", ProductID: "product-2", Quantity: 1, UnitPrice: 100.0},
-			{ID: "item-3", OrderID: "order-1", ProductID: "product-3", Quantity: 5, UnitPrice: 20.0},
+			{ID: "item-1", OrderID: "order-1", ProductID: "product-1", Quantity: 2, UnitPrice: 5000},
+			{ID: "item-2", OrderID: "order-1", ProductID: "product-2", Quantity: 1, UnitPrice: 10000},
+			{ID: "item-3", OrderID: "order-1", ProductID: "product-3", Quantity: 5, UnitPrice: 2000},
 		},
 		CreatedAt: now,
 	}
@@ -716,7 +719,7 @@ func TestFromDAOToModel_StatusPreservation(t *testing.T) {
 		dao := daos.OrderDAO{
 			ID:         "order-1",
 			CustomerID: &customerID,
-			Amount:     100.0,
+			Amount:     10000,
 			Status:     status,
 			Items:      []daos.OrderItemDAO{},
 			CreatedAt:  now,
@@ -744,7 +747,7 @@ func TestFromModelToDAO_StatusPreservation(t *testing.T) {
 		model := models.OrderModel{
 			ID:         "order-1",
 			CustomerID: &customerID,
-			Amount:     100.0,
+			Amount:     10000,
 			StatusID:   status.ID,
 			Status:     status,
 			Items:      []models.OrderItemModel{},
@@ -766,7 +769,7 @@ func TestFromDAOToModel_TimestampPreservation(t *testing.T) {
 	dao := daos.OrderDAO{
 		ID:         "order-1",
 		CustomerID: &customerID,
-		Amount:     100.0,
+		Amount:     10000,
 		Status: daos.OrderStatusDAO{
 			ID:   "status-1",
 			Name: "PENDING",
@@ -790,7 +793,7 @@ func TestFromModelToDAO_TimestampPreservation(t *testing.T) {
 	model := models.OrderModel{
 		ID:         "order-1",
 		CustomerID: &customerID,
-		Amount:     100.0,
+		Amount:     10000,
 		StatusID:   "status-1",
 		Status: models.OrderStatusModel{
 			ID:   "status-1",
@@ -806,3 +809,598 @@ func TestFromModelToDAO_TimestampPreservation(t *testing.T) {
 	assert.Equal(t, now, dao.CreatedAt)
 	assert.Equal(t, updatedAt, *dao.UpdatedAt)
 }
+
+// ============================================================================
+// Tests for status history
+// ============================================================================
+
+func TestGormOrderDataSource_ItemModifiers_RoundTrip(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+
+	order := newSQLiteOrder("order-1")
+	order.Items[0].Notes = "sem cebola"
+	order.Items[0].Modifiers = []daos.OrderItemModifierDAO{
+		{ID: "modifier-1", OrderItemID: "order-1-item", ModifierID: "queijo-extra", Name: "Queijo extra", PriceDelta: 300},
+		{ID: "modifier-2", OrderItemID: "order-1-item", ModifierID: "sem-cebola", Name: "Sem cebola", PriceDelta: 0},
+	}
+	assert.NoError(t, ds.Create(context.Background(), order))
+
+	found, err := ds.FindByID(context.Background(), "order-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "sem cebola", found.Items[0].Notes)
+	assert.ElementsMatch(t, order.Items[0].Modifiers, found.Items[0].Modifiers)
+
+	all, err := ds.FindAll(context.Background(), dtos.OrderFilterDTO{})
+	assert.NoError(t, err)
+	assert.Len(t, all[0].Items[0].Modifiers, 2)
+
+	assert.NoError(t, ds.Delete(context.Background(), "order-1"))
+	var remaining int64
+	db.Model(&models.OrderItemModifierModel{}).Count(&remaining)
+	assert.Equal(t, int64(2), remaining, "soft delete keeps the modifiers")
+
+	_, err = ds.PurgeDeleted(context.Background(), time.Now().Add(time.Minute), 10)
+	assert.NoError(t, err)
+	db.Model(&models.OrderItemModifierModel{}).Count(&remaining)
+	assert.Zero(t, remaining)
+}
+
+func TestGormOrderDataSource_ComboComponents_RoundTrip(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+
+	comboItemID := "order-1-item"
+	order := newSQLiteOrder("order-1")
+	order.Items = append(order.Items, daos.OrderItemDAO{
+		ID: "order-1-component", OrderID: "order-1", ProductID: "product-2", Quantity: 1, UnitPrice: 790, ParentItemID: &comboItemID,
+	})
+	assert.NoError(t, ds.Create(context.Background(), order))
+
+	found, err := ds.FindByID(context.Background(), "order-1")
+	assert.NoError(t, err)
+	assert.Len(t, found.Items, 2)
+	for _, item := range found.Items {
+		if item.ID == "order-1-component" {
+			assert.Equal(t, &comboItemID, item.ParentItemID)
+		} else {
+			assert.Nil(t, item.ParentItemID)
+		}
+	}
+
+	assert.NoError(t, ds.Delete(context.Background(), "order-1"))
+	var remaining int64
+	db.Model(&models.OrderItemModel{}).Count(&remaining)
+	assert.Equal(t, int64(2), remaining, "soft delete keeps the items")
+
+	_, err = ds.PurgeDeleted(context.Background(), time.Now().Add(time.Minute), 10)
+	assert.NoError(t, err)
+	db.Model(&models.OrderItemModel{}).Count(&remaining)
+	assert.Zero(t, remaining)
+}
+
+func TestGormOrderDataSource_StatusHistory_WrittenWithOrder(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+
+	received := daos.OrderStatusDAO{ID: "status-1", Name: "Recebido"}
+	confirmed := daos.OrderStatusDAO{ID: "status-2", Name: "Confirmado"}
+	actor := "customer-1"
+	createdAt := time.Now().Add(-time.Minute)
+
+	order := newSQLiteOrder("order-1")
+	order.StatusChanges = []daos.OrderStatusHistoryDAO{
+		{ID: "change-1", OrderID: "order-1", NewStatus: received, Source: "rest", Actor: &actor, ChangedAt: createdAt},
+	}
+	assert.NoError(t, ds.Create(context.Background(), order))
+
+	order.Status = confirmed
+	order.StatusChanges = []daos.OrderStatusHistoryDAO{
+		{ID: "change-2", OrderID: "order-1", PreviousStatus: &received, NewStatus: confirmed, Source: "payment_consumer", ChangedAt: time.Now()},
+	}
+	assert.NoError(t, ds.Update(context.Background(), order))
+
+	history, err := ds.FindStatusHistory(context.Background(), "order-1")
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+
+	assert.Nil(t, history[0].PreviousStatus)
+	assert.Equal(t, "Recebido", history[0].NewStatus.Name)
+	assert.Equal(t, "rest", history[0].Source)
+	assert.Equal(t, &actor, history[0].Actor)
+
+	assert.Equal(t, "Recebido", history[1].PreviousStatus.Name)
+	assert.Equal(t, "Confirmado", history[1].NewStatus.Name)
+	assert.Equal(t, "payment_consumer", history[1].Source)
+	assert.Nil(t, history[1].Actor)
+}
+
+func TestGormOrderDataSource_StatusHistory_RolledBackWithOrder(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+
+	order := newSQLiteOrder("order-1")
+	order.StatusChanges = []daos.OrderStatusHistoryDAO{
+		{ID: "change-1", OrderID: "order-1", NewStatus: order.Status, Source: "rest", ChangedAt: time.Now()},
+	}
+	assert.NoError(t, ds.Create(context.Background(), order))
+
+	// Histórico com ID duplicado faz a transação inteira falhar
+	order.Status = daos.OrderStatusDAO{ID: "status-2", Name: "Confirmado"}
+	assert.Error(t, ds.Update(context.Background(), order))
+
+	var stored models.OrderModel
+	assert.NoError(t, db.First(&stored, "id = ?", "order-1").Error)
+	assert.Equal(t, "status-1", stored.StatusID)
+}
+
+func TestGormOrderDataSource_FindStatusHistory_Empty(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+
+	history, err := ds.FindStatusHistory(context.Background(), "missing-order")
+	assert.NoError(t, err)
+	assert.Empty(t, history)
+}
+
+// ============================================================================
+// Tests for pagination
+// ============================================================================
+
+func createSQLiteOrdersAt(t *testing.T, ds *GormOrderDataSource, base time.Time, ids ...string) {
+	for i, id := range ids {
+		order := newSQLiteOrder(id)
+		order.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+		assert.NoError(t, ds.Create(context.Background(), order))
+	}
+}
+
+func TestGormOrderDataSource_FindAll_KeysetPagination(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	createSQLiteOrdersAt(t, ds, base, "order-a", "order-b", "order-c", "order-d")
+
+	firstPage, err := ds.FindAll(context.Background(), dtos.OrderFilterDTO{Limit: 2, Sort: dtos.ORDER_SORT_CREATED_AT_DESC})
+	assert.NoError(t, err)
+	assert.Len(t, firstPage, 2)
+	assert.Equal(t, "order-d", firstPage[0].ID)
+	assert.Equal(t, "order-c", firstPage[1].ID)
+
+	last := firstPage[1]
+	secondPage, err := ds.FindAll(context.Background(), dtos.OrderFilterDTO{
+		Limit: 2,
+		Sort:  dtos.ORDER_SORT_CREATED_AT_DESC,
+		After: &dtos.OrderCursorDTO{CreatedAt: last.CreatedAt, ID: last.ID},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, secondPage, 2)
+	assert.Equal(t, "order-b", secondPage[0].ID)
+	assert.Equal(t, "order-a", secondPage[1].ID)
+}
+
+func TestGormOrderDataSource_FindAll_AscendingWithTiedTimestamps(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+	createdAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, id := range []string{"order-b", "order-a", "order-c"} {
+		order := newSQLiteOrder(id)
+		order.CreatedAt = createdAt
+		assert.NoError(t, ds.Create(context.Background(), order))
+	}
+
+	page, err := ds.FindAll(context.Background(), dtos.OrderFilterDTO{
+		Sort:  dtos.ORDER_SORT_CREATED_AT_ASC,
+		After: &dtos.OrderCursorDTO{CreatedAt: createdAt, ID: "order-a"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, page, 2)
+	assert.Equal(t, "order-b", page[0].ID)
+	assert.Equal(t, "order-c", page[1].ID)
+}
+
+func TestGormOrderDataSource_Count_IgnoresPagination(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	createSQLiteOrdersAt(t, ds, base, "order-a", "order-b", "order-c")
+
+	from := base.Add(time.Minute)
+	total, err := ds.Count(context.Background(), dtos.OrderFilterDTO{
+		CreatedAtFrom: &from,
+		Limit:         1,
+		After:         &dtos.OrderCursorDTO{CreatedAt: base.Add(2 * time.Minute), ID: "order-c"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+}
+
+// ============================================================================
+// Tests for soft delete and retention
+// ============================================================================
+
+func TestGormOrderDataSource_Delete_HidesOrder(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+	assert.NoError(t, ds.Create(context.Background(), newSQLiteOrder("order-1")))
+	assert.NoError(t, ds.Create(context.Background(), newSQLiteOrder("order-2")))
+
+	assert.NoError(t, ds.Delete(context.Background(), "order-1"))
+
+	_, err := ds.FindByID(context.Background(), "order-1")
+	assert.IsType(t, &exceptions.OrderNotFoundException{}, err)
+
+	all, err := ds.FindAll(context.Background(), dtos.OrderFilterDTO{})
+	assert.NoError(t, err)
+	assert.Len(t, all, 1)
+	assert.Equal(t, "order-2", all[0].ID)
+
+	total, err := ds.Count(context.Background(), dtos.OrderFilterDTO{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+
+	var deleted models.OrderModel
+	assert.NoError(t, db.Unscoped().First(&deleted, "id = ?", "order-1").Error)
+	assert.True(t, deleted.DeletedAt.Valid)
+}
+
+func TestGormOrderDataSource_Restore(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+	assert.NoError(t, ds.Create(context.Background(), newSQLiteOrder("order-1")))
+	assert.NoError(t, ds.Delete(context.Background(), "order-1"))
+
+	assert.NoError(t, ds.Restore(context.Background(), "order-1", newOutboxMessage("event-1", "order-1", time.Now())))
+
+	found, err := ds.FindByID(context.Background(), "order-1")
+	assert.NoError(t, err)
+	assert.Nil(t, found.DeletedAt)
+	assert.Len(t, found.Items, 1)
+
+	var count int64
+	db.Model(&models.OutboxModel{}).Where("id = ?", "event-1").Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestGormOrderDataSource_Restore_NotDeleted(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+	assert.NoError(t, ds.Create(context.Background(), newSQLiteOrder("order-1")))
+
+	err := ds.Restore(context.Background(), "order-1", newOutboxMessage("event-1", "order-1", time.Now()))
+	assert.IsType(t, &exceptions.OrderNotFoundException{}, err)
+
+	err = ds.Restore(context.Background(), "missing")
+	assert.IsType(t, &exceptions.OrderNotFoundException{}, err)
+
+	var count int64
+	db.Model(&models.OutboxModel{}).Count(&count)
+	assert.Zero(t, count)
+}
+
+func TestGormOrderDataSource_Claim(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+	hash := "guest-token-hash"
+	order := newSQLiteOrder("order-1")
+	order.GuestTokenHash = &hash
+	assert.NoError(t, ds.Create(context.Background(), order))
+	claimedAt := time.Now().Truncate(time.Second)
+
+	assert.NoError(t, ds.Claim(context.Background(), "order-1", "customer-123", hash, claimedAt))
+
+	found, err := ds.FindByID(context.Background(), "order-1")
+	assert.NoError(t, err)
+	if assert.NotNil(t, found.CustomerID) {
+		assert.Equal(t, "customer-123", *found.CustomerID)
+	}
+	assert.Nil(t, found.GuestTokenHash)
+	if assert.NotNil(t, found.UpdatedAt) {
+		assert.True(t, claimedAt.Equal(*found.UpdatedAt))
+	}
+
+	// Uma segunda reivindicação, mesmo com o token certo, não troca o cliente
+	err = ds.Claim(context.Background(), "order-1", "customer-456", hash, claimedAt)
+	assert.IsType(t, &exceptions.OrderAlreadyClaimedException{}, err)
+
+	found, _ = ds.FindByID(context.Background(), "order-1")
+	assert.Equal(t, "customer-123", *found.CustomerID)
+}
+
+func TestGormOrderDataSource_Update_KeepsConcurrentClaim(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+	hash := "guest-token-hash"
+	order := newSQLiteOrder("order-1")
+	order.GuestTokenHash = &hash
+	assert.NoError(t, ds.Create(context.Background(), order))
+
+	// O consumidor leu o pedido antes da reivindicação
+	assert.NoError(t, ds.Claim(context.Background(), "order-1", "customer-123", hash, time.Now()))
+	order.Status = daos.OrderStatusDAO{ID: "status-2", Name: "Confirmado"}
+	assert.NoError(t, ds.Update(context.Background(), order))
+
+	found, err := ds.FindByID(context.Background(), "order-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "status-2", found.Status.ID)
+	if assert.NotNil(t, found.CustomerID) {
+		assert.Equal(t, "customer-123", *found.CustomerID)
+	}
+	assert.Nil(t, found.GuestTokenHash)
+	assert.Equal(t, 2, found.Version)
+}
+
+func TestGormOrderDataSource_Update_StaleVersion(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+	assert.NoError(t, ds.Create(context.Background(), newSQLiteOrder("order-1")))
+
+	confirmed := newSQLiteOrder("order-1")
+	confirmed.Status = daos.OrderStatusDAO{ID: "status-2", Name: "Confirmado"}
+	assert.NoError(t, ds.Update(context.Background(), confirmed))
+
+	// Segunda escrita a partir da mesma leitura
+	reason := "Cliente desistiu"
+	cancelled := newSQLiteOrder("order-1")
+	cancelled.Status = daos.OrderStatusDAO{ID: "status-3", Name: "Cancelado"}
+	cancelled.CancellationReason = &reason
+	err := ds.Update(context.Background(), cancelled, newOutboxMessage("event-1", "order-1", time.Now()))
+	assert.IsType(t, &exceptions.OrderConcurrentModificationException{}, err)
+
+	found, err := ds.FindByID(context.Background(), "order-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "status-2", found.Status.ID)
+	assert.Nil(t, found.CancellationReason)
+
+	var outboxCount int64
+	db.Model(&models.OutboxModel{}).Where("order_id = ?", "order-1").Count(&outboxCount)
+	assert.Equal(t, int64(0), outboxCount)
+}
+
+func TestGormOrderDataSource_Update_MovesUpdatedAt(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+	order := newSQLiteOrder("order-1")
+	past := time.Now().Add(-time.Hour)
+	order.UpdatedAt = &past
+	assert.NoError(t, ds.Create(context.Background(), order))
+
+	// DAO ainda carrega o updated_at lido, como no consumer da cozinha
+	order.Status = daos.OrderStatusDAO{ID: "status-2", Name: "Confirmado"}
+	assert.NoError(t, ds.Update(context.Background(), order))
+
+	var stored models.OrderModel
+	assert.NoError(t, db.First(&stored, "id = ?", "order-1").Error)
+	if assert.NotNil(t, stored.UpdatedAt) {
+		assert.True(t, stored.UpdatedAt.After(past), "updated_at = %v, want after %v", *stored.UpdatedAt, past)
+	}
+	assert.Equal(t, 2, stored.Version)
+}
+
+func TestGormOrderDataSource_Update_DeletedOrder(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+	order := newSQLiteOrder("order-1")
+	assert.NoError(t, ds.Create(context.Background(), order))
+	assert.NoError(t, ds.Delete(context.Background(), "order-1"))
+
+	order.Status = daos.OrderStatusDAO{ID: "status-2", Name: "Confirmado"}
+	err := ds.Update(context.Background(), order)
+	assert.IsType(t, &exceptions.OrderConcurrentModificationException{}, err)
+}
+
+func TestGormOrderDataSource_Claim_WrongToken(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+	hash := "guest-token-hash"
+	order := newSQLiteOrder("order-1")
+	order.GuestTokenHash = &hash
+	assert.NoError(t, ds.Create(context.Background(), order))
+
+	err := ds.Claim(context.Background(), "order-1", "customer-123", "other-hash", time.Now())
+	assert.IsType(t, &exceptions.OrderAlreadyClaimedException{}, err)
+
+	found, err := ds.FindByID(context.Background(), "order-1")
+	assert.NoError(t, err)
+	assert.Nil(t, found.CustomerID)
+	assert.NotNil(t, found.GuestTokenHash)
+}
+
+func TestGormOrderDataSource_ReissueGuestToken(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+	hash := "guest-token-hash"
+	order := newSQLiteOrder("order-1")
+	order.GuestTokenHash = &hash
+	assert.NoError(t, ds.Create(context.Background(), order))
+
+	assert.NoError(t, ds.ReissueGuestToken(context.Background(), "order-1", "new-hash"))
+
+	found, err := ds.FindByID(context.Background(), "order-1")
+	assert.NoError(t, err)
+	if assert.NotNil(t, found.GuestTokenHash) {
+		assert.Equal(t, "new-hash", *found.GuestTokenHash)
+	}
+
+	// Depois de reivindicado o pedido não recebe outro token
+	assert.NoError(t, ds.Claim(context.Background(), "order-1", "customer-123", "new-hash", time.Now()))
+	err = ds.ReissueGuestToken(context.Background(), "order-1", "other-hash")
+	assert.IsType(t, &exceptions.OrderAlreadyClaimedException{}, err)
+
+	found, _ = ds.FindByID(context.Background(), "order-1")
+	assert.Nil(t, found.GuestTokenHash)
+}
+
+func TestGormOrderDataSource_PurgeDeleted(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+	now := time.Now()
+
+	for _, id := range []string{"old-1", "old-2", "recent", "active"} {
+		order := newSQLiteOrder(id)
+		order.StatusChanges = []daos.OrderStatusHistoryDAO{
+			{ID: id + "-change", OrderID: id, NewStatus: daos.OrderStatusDAO{ID: "status-1"}, Source: "rest", ChangedAt: now},
+		}
+		assert.NoError(t, ds.Create(context.Background(), order, newOutboxMessage(id+"-created", id, now)))
+	}
+	for _, id := range []string{"old-1", "active"} {
+		saveProcessedMessage(t, db, newSQLiteProcessedMessage(id+"-message", id))
+	}
+	redemption := models.CouponRedemptionModel{CouponCode: "BEMVINDO", CustomerID: "customer-1", OrderID: "old-1", RedeemedAt: now}
+	assert.NoError(t, db.Create(&redemption).Error)
+	assert.NoError(t, ds.Delete(context.Background(), "old-1"))
+	assert.NoError(t, ds.Delete(context.Background(), "old-2"))
+	assert.NoError(t, ds.Delete(context.Background(), "recent"))
+	db.Unscoped().Model(&models.OrderModel{}).Where("id IN ?", []string{"old-1", "old-2"}).Update("deleted_at", now.Add(-48*time.Hour))
+	db.Model(&models.OutboxModel{}).Where("id = ?", "old-1-created").Update("sent_at", now)
+
+	purged, err := ds.PurgeDeleted(context.Background(), now.Add(-24*time.Hour), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	purged, err = ds.PurgeDeleted(context.Background(), now.Add(-24*time.Hour), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	var remaining []string
+	db.Unscoped().Model(&models.OrderModel{}).Order("id").Pluck("id", &remaining)
+	assert.Equal(t, []string{"active", "recent"}, remaining)
+
+	var items, history int64
+	db.Model(&models.OrderItemModel{}).Count(&items)
+	db.Model(&models.OrderStatusHistoryModel{}).Count(&history)
+	assert.Equal(t, int64(2), items)
+	assert.Equal(t, int64(2), history)
+
+	var processed []string
+	db.Model(&models.ProcessedMessageModel{}).Pluck("message_id", &processed)
+	assert.Equal(t, []string{"active-message"}, processed)
+
+	// O resgate continua impedindo o cliente de usar o cupom de primeiro pedido de novo
+	var redemptions int64
+	db.Model(&models.CouponRedemptionModel{}).Where("order_id = ?", "old-1").Count(&redemptions)
+	assert.Equal(t, int64(1), redemptions)
+
+	// Mensagens ainda não publicadas não são apagadas
+	var outbox []string
+	db.Model(&models.OutboxModel{}).Order("id").Pluck("id", &outbox)
+	assert.Equal(t, []string{"active-created", "old-2-created", "recent-created"}, outbox)
+}
+
+func TestGormOrderDataSource_NextPickupSequence_PerDay(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+
+	for want := 1; want <= 3; want++ {
+		got, err := ds.NextPickupSequence(context.Background(), "2026-10-16")
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	got, err := ds.NextPickupSequence(context.Background(), "2026-10-17")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, got)
+}
+
+func TestGormOrderDataSource_FindByPickupCode(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+	day, code := "2026-10-16", "A001"
+	order := newSQLiteOrder("order-1")
+	order.PickupDate, order.PickupCode = &day, &code
+	assert.NoError(t, ds.Create(context.Background(), order))
+
+	found, err := ds.FindByPickupCode(context.Background(), day, code)
+	assert.NoError(t, err)
+	assert.Equal(t, "order-1", found.ID)
+	assert.Len(t, found.Items, 1)
+
+	_, err = ds.FindByPickupCode(context.Background(), "2026-10-17", code)
+	assert.IsType(t, &exceptions.OrderNotFoundException{}, err)
+}
+
+func TestGormOrderDataSource_PickupCode_UniquePerDay(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+	day, otherDay, code := "2026-10-16", "2026-10-17", "A001"
+
+	first := newSQLiteOrder("order-1")
+	first.PickupDate, first.PickupCode = &day, &code
+	assert.NoError(t, ds.Create(context.Background(), first))
+
+	duplicate := newSQLiteOrder("order-2")
+	duplicate.PickupDate, duplicate.PickupCode = &day, &code
+	assert.Error(t, ds.Create(context.Background(), duplicate))
+
+	nextDay := newSQLiteOrder("order-3")
+	nextDay.PickupDate, nextDay.PickupCode = &otherDay, &code
+	assert.NoError(t, ds.Create(context.Background(), nextDay))
+
+	// Pedidos antigos sem código não conflitam entre si
+	assert.NoError(t, ds.Create(context.Background(), newSQLiteOrder("legacy-1")))
+	assert.NoError(t, ds.Create(context.Background(), newSQLiteOrder("legacy-2")))
+}
+
+func TestGormOrderDataSource_FindBoard(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+	now := time.Now()
+	day, code, name := "2026-10-16", "A002", "Maria"
+
+	newOrder := func(id string, statusID string, createdAt time.Time) daos.OrderDAO {
+		order := newSQLiteOrder(id)
+		order.Status = daos.OrderStatusDAO{ID: statusID}
+		order.CreatedAt = createdAt
+		return order
+	}
+	named := newOrder("named", "status-2", now.Add(-10*time.Minute))
+	named.PickupDate, named.PickupCode, named.CustomerName = &day, &code, &name
+	assert.NoError(t, ds.Create(context.Background(), named))
+	assert.NoError(t, ds.Create(context.Background(), newOrder("oldest", "status-1", now.Add(-50*time.Minute))))
+	assert.NoError(t, ds.Create(context.Background(), newOrder("too-old", "status-1", now.Add(-3*time.Hour))))
+	assert.NoError(t, ds.Create(context.Background(), newOrder("deleted", "status-1", now.Add(-5*time.Minute))))
+	assert.NoError(t, ds.Delete(context.Background(), "deleted"))
+	db.Create(&models.OrderStatusModel{ID: "status-5", Name: "Entregue"})
+	assert.NoError(t, ds.Create(context.Background(), newOrder("delivered", "status-5", now.Add(-5*time.Minute))))
+
+	entries, err := ds.FindBoard(context.Background(), []string{"status-1", "status-2"}, now.Add(-time.Hour))
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "oldest", entries[0].ID)
+	assert.Equal(t, "Recebido", entries[0].StatusName)
+	assert.Nil(t, entries[0].PickupCode)
+	assert.Equal(t, daos.OrderBoardEntryDAO{
+		ID:           "named",
+		PickupCode:   &code,
+		CustomerName: &name,
+		StatusID:     "status-2",
+		StatusName:   "Confirmado",
+		CreatedAt:    entries[1].CreatedAt,
+	}, entries[1])
+	assert.WithinDuration(t, named.CreatedAt, entries[1].CreatedAt, time.Second)
+}
+
+func TestGormOrderDataSource_Count_ExcludedStatuses(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+	db.Create(&models.OrderStatusModel{ID: "status-6", Name: "Cancelado"})
+
+	customerID := "customer-1"
+	received := newSQLiteOrder("order-1")
+	received.CustomerID = &customerID
+	cancelled := newSQLiteOrder("order-2")
+	cancelled.CustomerID = &customerID
+	cancelled.Status = daos.OrderStatusDAO{ID: "status-6", Name: "Cancelado"}
+	assert.NoError(t, ds.Create(context.Background(), received))
+	assert.NoError(t, ds.Create(context.Background(), cancelled))
+
+	total, err := ds.Count(context.Background(), dtos.OrderFilterDTO{CustomerID: &customerID})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+
+	total, err = ds.Count(context.Background(), dtos.OrderFilterDTO{CustomerID: &customerID, ExcludedStatuses: []string{"Cancelado", "Falhou"}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+}
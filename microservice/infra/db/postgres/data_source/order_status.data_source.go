@@ -1,27 +1,42 @@
 package data_source
 
 import (
+	"context"
+	"errors"
+	"time"
+
 	"gorm.io/gorm"
 
 	"microservice/infra/db/postgres"
 	"microservice/infra/db/postgres/models"
 	"microservice/internal/adapters/daos"
+	"microservice/internal/domain/exceptions"
 )
 
 type GormOrderStatusDataSource struct {
-	db *gorm.DB
+	db           *gorm.DB
+	queryTimeout time.Duration
 }
 
 func NewGormOrderStatusDataSource() *GormOrderStatusDataSource {
 	return &GormOrderStatusDataSource{
-		db: postgres.GetDB(),
+		db:           postgres.GetDB(),
+		queryTimeout: postgres.QueryTimeout(),
 	}
 }
 
-func (r *GormOrderStatusDataSource) FindAll() ([]daos.OrderStatusDAO, error) {
+// Consultas param quando a requisição é cancelada
+func (r *GormOrderStatusDataSource) session(ctx context.Context) (*gorm.DB, context.CancelFunc) {
+	return withQueryTimeout(ctx, r.db, r.queryTimeout)
+}
+
+func (r *GormOrderStatusDataSource) FindAll(ctx context.Context) ([]daos.OrderStatusDAO, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
 	var statuses []models.OrderStatusModel
 
-	if err := r.db.Find(&statuses).Error; err != nil {
+	if err := db.Find(&statuses).Error; err != nil {
 		return nil, err
 	}
 
@@ -36,10 +51,16 @@ func (r *GormOrderStatusDataSource) FindAll() ([]daos.OrderStatusDAO, error) {
 	return result, nil
 }
 
-func (r *GormOrderStatusDataSource) FindByID(id string) (daos.OrderStatusDAO, error) {
+func (r *GormOrderStatusDataSource) FindByID(ctx context.Context, id string) (daos.OrderStatusDAO, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
 	var status models.OrderStatusModel
 
-	if err := r.db.First(&status, "id = ?", id).Error; err != nil {
+	if err := db.First(&status, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return daos.OrderStatusDAO{}, &exceptions.OrderStatusNotFoundException{}
+		}
 		return daos.OrderStatusDAO{}, err
 	}
 
@@ -49,10 +70,16 @@ func (r *GormOrderStatusDataSource) FindByID(id string) (daos.OrderStatusDAO, er
 	}, nil
 }
 
-func (r *GormOrderStatusDataSource) FindByName(name string) (daos.OrderStatusDAO, error) {
+func (r *GormOrderStatusDataSource) FindByName(ctx context.Context, name string) (daos.OrderStatusDAO, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
 	var status models.OrderStatusModel
 
-	if err := r.db.First(&status, "name = ?", name).Error; err != nil {
+	if err := db.First(&status, "name = ?", name).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return daos.OrderStatusDAO{}, &exceptions.OrderStatusNotFoundException{}
+		}
 		return daos.OrderStatusDAO{}, err
 	}
 
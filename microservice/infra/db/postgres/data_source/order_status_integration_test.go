@@ -1,6 +1,7 @@
 package data_source
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -49,7 +50,7 @@ func TestGormOrderStatusDataSource_FindByName_Integration(t *testing.T) {
 	}
 	
 	// Test finding existing status by name
-	status, err := dataSource.FindByName("Recebido")
+	status, err := dataSource.FindByName(context.Background(), "Recebido")
 	if err != nil {
 		// If status doesn't exist, create it for testing
 		testStatus := daos.OrderStatusDAO{
@@ -64,7 +65,7 @@ func TestGormOrderStatusDataSource_FindByName_Integration(t *testing.T) {
 		}
 		
 		// Try finding again
-		status, err = dataSource.FindByName("Recebido")
+		status, err = dataSource.FindByName(context.Background(), "Recebido")
 	}
 	
 	if err == nil {
@@ -94,7 +95,7 @@ func TestGormOrderStatusDataSource_FindByName_NotFound(t *testing.T) {
 	}
 	
 	// Test finding non-existent status
-	_, err := dataSource.FindByName("NonExistentStatus")
+	_, err := dataSource.FindByName(context.Background(), "NonExistentStatus")
 	assert.Error(t, err)
 }
 
@@ -117,7 +118,7 @@ func TestGormOrderStatusDataSource_FindByName_EmptyName(t *testing.T) {
 	}
 	
 	// Test finding with empty name
-	_, err := dataSource.FindByName("")
+	_, err := dataSource.FindByName(context.Background(), "")
 	assert.Error(t, err)
 }
 
@@ -150,7 +151,7 @@ func TestGormOrderStatusDataSource_FindByName_ValidNames(t *testing.T) {
 	
 	for _, name := range validNames {
 		t.Run("FindByName_"+name, func(t *testing.T) {
-			status, err := dataSource.FindByName(name)
+			status, err := dataSource.FindByName(context.Background(), name)
 			if err == nil {
 				assert.NotEmpty(t, status.ID)
 				assert.Equal(t, name, status.Name)
@@ -178,7 +179,7 @@ func TestGormOrderStatusDataSource_Methods_Coverage(t *testing.T) {
 	}
 	
 	// Test FindAll method exists
-	statuses, err := dataSource.FindAll()
+	statuses, err := dataSource.FindAll(context.Background())
 	if err != nil {
 		t.Logf("FindAll error (expected in test environment): %v", err)
 	} else {
@@ -186,10 +187,10 @@ func TestGormOrderStatusDataSource_Methods_Coverage(t *testing.T) {
 	}
 	
 	// Test FindByID method exists
-	_, err = dataSource.FindByID("test-id")
+	_, err = dataSource.FindByID(context.Background(), "test-id")
 	assert.Error(t, err) // Should error for non-existent ID
 	
 	// Test FindByName method exists
-	_, err = dataSource.FindByName("TestStatus")
+	_, err = dataSource.FindByName(context.Background(), "TestStatus")
 	assert.Error(t, err) // Should error for non-existent name
 }
\ No newline at end of file
@@ -0,0 +1,121 @@
+package data_source
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"microservice/infra/db/postgres"
+	"microservice/infra/db/postgres/models"
+	"microservice/internal/adapters/daos"
+)
+
+type GormOutboxDataSource struct {
+	db           *gorm.DB
+	queryTimeout time.Duration
+}
+
+func NewGormOutboxDataSource() *GormOutboxDataSource {
+	return &GormOutboxDataSource{
+		db:           postgres.GetDB(),
+		queryTimeout: postgres.QueryTimeout(),
+	}
+}
+
+func (r *GormOutboxDataSource) session(ctx context.Context) (*gorm.DB, context.CancelFunc) {
+	return withQueryTimeout(ctx, r.db, r.queryTimeout)
+}
+
+// Trava a primeira mensagem pendente de cada pedido com SKIP LOCKED e reserva as demais
+// até lockedUntil; assim cada mensagem sai por um único relay e na ordem do pedido.
+// Reservas expiradas podem ser retomadas
+func (r *GormOutboxDataSource) ClaimPending(ctx context.Context, limit int, now time.Time, lockedUntil time.Time) ([]daos.OutboxMessageDAO, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	var messages []models.OutboxModel
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var orderIDs []string
+		err := tx.Model(&models.OutboxModel{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("outbox.sent_at IS NULL AND outbox.dead_at IS NULL").
+			Where("outbox.next_attempt_at <= ?", now).
+			Where("outbox.locked_until IS NULL OR outbox.locked_until <= ?", now).
+			Where("NOT EXISTS (?)", tx.Model(&models.OutboxModel{}).
+				Select("1").
+				Table("outbox AS earlier").
+				Where("earlier.order_id = outbox.order_id").
+				Where("earlier.sent_at IS NULL AND earlier.dead_at IS NULL").
+				Where("earlier.created_at < outbox.created_at OR (earlier.created_at = outbox.created_at AND earlier.id < outbox.id)")).
+			Order("outbox.created_at ASC").
+			Order("outbox.id ASC").
+			Limit(limit).
+			Pluck("outbox.order_id", &orderIDs).Error
+		if err != nil || len(orderIDs) == 0 {
+			return err
+		}
+
+		pending := tx.Model(&models.OutboxModel{}).
+			Where("order_id IN ? AND sent_at IS NULL AND dead_at IS NULL", orderIDs)
+		if err := pending.Update("locked_until", lockedUntil).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("order_id IN ? AND sent_at IS NULL AND dead_at IS NULL", orderIDs).
+			Order("created_at ASC").
+			Order("id ASC").
+			Find(&messages).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]daos.OutboxMessageDAO, len(messages))
+	for i, message := range messages {
+		result[i] = FromOutboxModelToDAO(message)
+	}
+	return result, nil
+}
+
+func (r *GormOutboxDataSource) MarkSent(ctx context.Context, id string, sentAt time.Time) error {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	return db.Model(&models.OutboxModel{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"sent_at":      sentAt,
+			"locked_until": nil,
+		}).Error
+}
+
+func (r *GormOutboxDataSource) MarkFailed(ctx context.Context, id string, lastError string, nextAttemptAt time.Time) error {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	return db.Model(&models.OutboxModel{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"last_error":      lastError,
+			"next_attempt_at": nextAttemptAt,
+			"locked_until":    nil,
+		}).Error
+}
+
+func (r *GormOutboxDataSource) MarkDead(ctx context.Context, id string, lastError string, deadAt time.Time) error {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	return db.Model(&models.OutboxModel{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"attempts":     gorm.Expr("attempts + 1"),
+			"last_error":   lastError,
+			"dead_at":      deadAt,
+			"locked_until": nil,
+		}).Error
+}
@@ -0,0 +1,263 @@
+package data_source
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"microservice/infra/db/postgres/models"
+	"microservice/internal/adapters/daos"
+)
+
+func setupSQLiteDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+
+	if err := db.AutoMigrate(
+		&models.OrderStatusModel{},
+		&models.OrderModel{},
+		&models.OrderItemModel{},
+		&models.OrderItemModifierModel{},
+		&models.OrderStatusHistoryModel{},
+		&models.OutboxModel{},
+		&models.IdempotencyKeyModel{},
+		&models.ProcessedMessageModel{},
+		&models.CouponModel{},
+		&models.CouponRedemptionModel{},
+		&models.PickupSequenceModel{},
+	); err != nil {
+		t.Fatalf("failed to migrate sqlite: %v", err)
+	}
+
+	db.Create(&models.OrderStatusModel{ID: "status-1", Name: "Recebido"})
+	db.Create(&models.OrderStatusModel{ID: "status-2", Name: "Confirmado"})
+	return db
+}
+
+func newOutboxMessage(id string, orderID string, createdAt time.Time) daos.OutboxMessageDAO {
+	return daos.OutboxMessageDAO{
+		ID:            id,
+		OrderID:       orderID,
+		EventType:     "order.created",
+		Payload:       []byte(`{"id":"` + id + `"}`),
+		CreatedAt:     createdAt,
+		NextAttemptAt: createdAt,
+	}
+}
+
+func newSQLiteOrder(id string) daos.OrderDAO {
+	return daos.OrderDAO{
+		ID:     id,
+		Amount: 1000,
+		Status: daos.OrderStatusDAO{ID: "status-1", Name: "Recebido"},
+		Items: []daos.OrderItemDAO{
+			{ID: id + "-item", OrderID: id, ProductID: "product-1", Quantity: 1, UnitPrice: 1000},
+		},
+		CreatedAt: time.Now(),
+		Version:   1,
+	}
+}
+
+func TestGormOrderDataSource_Create_WritesOutboxInSameTransaction(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+
+	err := ds.Create(context.Background(), newSQLiteOrder("order-1"), newOutboxMessage("event-1", "order-1", time.Now()))
+	assert.NoError(t, err)
+
+	var count int64
+	db.Model(&models.OutboxModel{}).Where("order_id = ?", "order-1").Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestGormOrderDataSource_Create_RollsBackOrderWhenOutboxFails(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+
+	// Mensagem duplicada viola a chave primária da outbox
+	_ = ds.Create(context.Background(), newSQLiteOrder("order-1"), newOutboxMessage("event-1", "order-1", time.Now()))
+	err := ds.Create(context.Background(), newSQLiteOrder("order-2"), newOutboxMessage("event-1", "order-2", time.Now()))
+	assert.Error(t, err)
+
+	var order models.OrderModel
+	err = db.First(&order, "id = ?", "order-2").Error
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+}
+
+func TestGormOrderDataSource_Delete_WritesOutbox(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+	_ = ds.Create(context.Background(), newSQLiteOrder("order-1"))
+
+	err := ds.Delete(context.Background(), "order-1", newOutboxMessage("event-2", "order-1", time.Now()))
+	assert.NoError(t, err)
+
+	var count int64
+	db.Model(&models.OutboxModel{}).Where("id = ?", "event-2").Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func newSQLiteProcessedMessage(messageID string, orderID string) daos.ProcessedMessageDAO {
+	return daos.ProcessedMessageDAO{
+		Consumer:    "order_updates",
+		MessageID:   messageID,
+		OrderID:     orderID,
+		ProcessedAt: time.Now(),
+	}
+}
+
+func TestGormOrderDataSource_UpdateFromMessage_WritesProcessedMessageInSameTransaction(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+	_ = ds.Create(context.Background(), newSQLiteOrder("order-1"))
+
+	order := newSQLiteOrder("order-1")
+	order.Status = daos.OrderStatusDAO{ID: "status-2", Name: "Confirmado"}
+	err := ds.UpdateFromMessage(context.Background(), order, newSQLiteProcessedMessage("message-1", "order-1"), newOutboxMessage("event-1", "order-1", time.Now()))
+	assert.NoError(t, err)
+
+	exists, err := (&GormProcessedMessageDataSource{db: db}).Exists(context.Background(), "order_updates", "message-1")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	var outboxCount int64
+	db.Model(&models.OutboxModel{}).Where("order_id = ?", "order-1").Count(&outboxCount)
+	assert.Equal(t, int64(1), outboxCount)
+}
+
+func TestGormOrderDataSource_UpdateFromMessage_RollsBackOrderWhenMessageWasProcessed(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+	_ = ds.Create(context.Background(), newSQLiteOrder("order-1"))
+	// Outra entrega da mesma mensagem já foi gravada
+	saveProcessedMessage(t, db, newSQLiteProcessedMessage("message-1", "order-1"))
+
+	order := newSQLiteOrder("order-1")
+	order.Status = daos.OrderStatusDAO{ID: "status-2", Name: "Confirmado"}
+	err := ds.UpdateFromMessage(context.Background(), order, newSQLiteProcessedMessage("message-1", "order-1"), newOutboxMessage("event-1", "order-1", time.Now()))
+	assert.Error(t, err)
+
+	var saved models.OrderModel
+	assert.NoError(t, db.First(&saved, "id = ?", "order-1").Error)
+	assert.Equal(t, "status-1", saved.StatusID, "the order update must be rolled back")
+
+	var outboxCount int64
+	db.Model(&models.OutboxModel{}).Where("order_id = ?", "order-1").Count(&outboxCount)
+	assert.Equal(t, int64(0), outboxCount)
+}
+
+func TestGormOutboxDataSource_ClaimPending_OrderedByCreation(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOutboxDataSource{db: db}
+	now := time.Now()
+
+	_ = insertOutboxMessages(db, []daos.OutboxMessageDAO{
+		newOutboxMessage("event-2", "order-1", now),
+		newOutboxMessage("event-1", "order-1", now.Add(-time.Minute)),
+	})
+
+	pending, err := ds.ClaimPending(context.Background(), 10, now, now.Add(time.Minute))
+
+	assert.NoError(t, err)
+	assert.Len(t, pending, 2)
+	assert.Equal(t, "event-1", pending[0].ID)
+	assert.Equal(t, "event-2", pending[1].ID)
+	assert.Equal(t, `{"id":"event-1"}`, string(pending[0].Payload))
+}
+
+func TestGormOutboxDataSource_MarkSent(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOutboxDataSource{db: db}
+	now := time.Now()
+	_ = insertOutboxMessages(db, []daos.OutboxMessageDAO{newOutboxMessage("event-1", "order-1", now)})
+
+	err := ds.MarkSent(context.Background(), "event-1", now)
+	assert.NoError(t, err)
+
+	pending, _ := ds.ClaimPending(context.Background(), 10, now, now.Add(time.Minute))
+	assert.Empty(t, pending)
+}
+
+func TestGormOutboxDataSource_MarkFailed(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOutboxDataSource{db: db}
+	now := time.Now()
+	_ = insertOutboxMessages(db, []daos.OutboxMessageDAO{newOutboxMessage("event-1", "order-1", now)})
+	_, _ = ds.ClaimPending(context.Background(), 10, now, now.Add(time.Minute))
+	nextAttemptAt := now.Add(time.Minute)
+
+	err := ds.MarkFailed(context.Background(), "event-1", "broker unavailable", nextAttemptAt)
+	assert.NoError(t, err)
+
+	// Só volta a ser reservada quando a próxima tentativa vence
+	pending, _ := ds.ClaimPending(context.Background(), 10, now, now.Add(time.Minute))
+	assert.Empty(t, pending)
+
+	pending, _ = ds.ClaimPending(context.Background(), 10, nextAttemptAt, nextAttemptAt.Add(time.Minute))
+	assert.Len(t, pending, 1)
+	assert.Equal(t, 1, pending[0].Attempts)
+	assert.Equal(t, "broker unavailable", *pending[0].LastError)
+	assert.WithinDuration(t, nextAttemptAt, pending[0].NextAttemptAt, time.Second)
+}
+
+func TestGormOutboxDataSource_ClaimPending_SkipsLeasedOrders(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOutboxDataSource{db: db}
+	now := time.Now()
+	_ = insertOutboxMessages(db, []daos.OutboxMessageDAO{
+		newOutboxMessage("event-1", "order-1", now.Add(-2*time.Minute)),
+		newOutboxMessage("event-2", "order-1", now.Add(-time.Minute)),
+		newOutboxMessage("event-3", "order-2", now.Add(-time.Minute)),
+	})
+
+	first, err := ds.ClaimPending(context.Background(), 1, now, now.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Len(t, first, 2, "every pending message of the claimed order")
+	assert.Equal(t, "order-1", first[0].OrderID)
+	assert.Equal(t, "order-1", first[1].OrderID)
+
+	second, err := ds.ClaimPending(context.Background(), 10, now, now.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Len(t, second, 1)
+	assert.Equal(t, "event-3", second[0].ID)
+
+	// A reserva vencida de uma instância parada pode ser retomada
+	later := now.Add(2 * time.Minute)
+	reclaimed, err := ds.ClaimPending(context.Background(), 10, later, later.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Len(t, reclaimed, 3)
+}
+
+func TestGormOutboxDataSource_MarkDead(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOutboxDataSource{db: db}
+	now := time.Now()
+	_ = insertOutboxMessages(db, []daos.OutboxMessageDAO{
+		newOutboxMessage("event-1", "order-1", now.Add(-time.Minute)),
+		newOutboxMessage("event-2", "order-1", now),
+	})
+	_, _ = ds.ClaimPending(context.Background(), 10, now, now.Add(time.Minute))
+
+	err := ds.MarkDead(context.Background(), "event-1", "invalid payload", now)
+	assert.NoError(t, err)
+	_ = ds.MarkFailed(context.Background(), "event-2", "broker unavailable", now)
+
+	pending, err := ds.ClaimPending(context.Background(), 10, now, now.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+	assert.Equal(t, "event-2", pending[0].ID)
+
+	var dead models.OutboxModel
+	db.First(&dead, "id = ?", "event-1")
+	assert.NotNil(t, dead.DeadAt)
+	assert.Nil(t, dead.LockedUntil)
+	assert.Equal(t, 1, dead.Attempts)
+	assert.Equal(t, "invalid payload", *dead.LastError)
+}
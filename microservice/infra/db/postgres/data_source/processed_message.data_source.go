@@ -0,0 +1,63 @@
+package data_source
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"microservice/infra/db/postgres"
+	"microservice/infra/db/postgres/models"
+)
+
+type GormProcessedMessageDataSource struct {
+	db           *gorm.DB
+	queryTimeout time.Duration
+}
+
+func NewGormProcessedMessageDataSource() *GormProcessedMessageDataSource {
+	return &GormProcessedMessageDataSource{
+		db:           postgres.GetDB(),
+		queryTimeout: postgres.QueryTimeout(),
+	}
+}
+
+func (r *GormProcessedMessageDataSource) session(ctx context.Context) (*gorm.DB, context.CancelFunc) {
+	return withQueryTimeout(ctx, r.db, r.queryTimeout)
+}
+
+func (r *GormProcessedMessageDataSource) Exists(ctx context.Context, consumer string, messageID string) (bool, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	var count int64
+
+	if err := db.Model(&models.ProcessedMessageModel{}).
+		Where("consumer = ? AND message_id = ?", consumer, messageID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *GormProcessedMessageDataSource) FindLatestOccurredAt(ctx context.Context, consumer string, orderID string) (*time.Time, error) {
+	db, cancel := r.session(ctx)
+	defer cancel()
+
+	var message models.ProcessedMessageModel
+
+	err := db.
+		Where("consumer = ? AND order_id = ? AND occurred_at IS NOT NULL", consumer, orderID).
+		Order("occurred_at DESC").
+		First(&message).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return message.OccurredAt, nil
+}
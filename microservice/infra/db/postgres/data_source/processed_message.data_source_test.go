@@ -0,0 +1,61 @@
+package data_source
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"microservice/internal/adapters/daos"
+)
+
+// Mensagens processadas são gravadas pelo order datasource, junto com o pedido
+func saveProcessedMessage(t *testing.T, db *gorm.DB, message daos.ProcessedMessageDAO) {
+	model := FromProcessedMessageDAOToModel(message)
+	assert.NoError(t, db.Create(&model).Error)
+}
+
+func TestGormProcessedMessageDataSource_Exists(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormProcessedMessageDataSource{db: db}
+	occurredAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	message := daos.ProcessedMessageDAO{
+		Consumer:    "order_updates",
+		MessageID:   "message-1",
+		OrderID:     "order-1",
+		OccurredAt:  &occurredAt,
+		ProcessedAt: time.Now(),
+	}
+	saveProcessedMessage(t, db, message)
+
+	exists, err := ds.Exists(context.Background(), "order_updates", "message-1")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = ds.Exists(context.Background(), "payment_confirmations", "message-1")
+	assert.NoError(t, err)
+	assert.False(t, exists, "messages are tracked per consumer")
+}
+
+func TestGormProcessedMessageDataSource_FindLatestOccurredAt(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormProcessedMessageDataSource{db: db}
+	older := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Minute)
+
+	saveProcessedMessage(t, db, daos.ProcessedMessageDAO{Consumer: "order_updates", MessageID: "m-2", OrderID: "order-1", OccurredAt: &newer, ProcessedAt: time.Now()})
+	saveProcessedMessage(t, db, daos.ProcessedMessageDAO{Consumer: "order_updates", MessageID: "m-1", OrderID: "order-1", OccurredAt: &older, ProcessedAt: time.Now()})
+	saveProcessedMessage(t, db, daos.ProcessedMessageDAO{Consumer: "order_updates", MessageID: "m-3", OrderID: "order-1", ProcessedAt: time.Now()})
+
+	latest, err := ds.FindLatestOccurredAt(context.Background(), "order_updates", "order-1")
+	assert.NoError(t, err)
+	assert.NotNil(t, latest)
+	assert.True(t, newer.Equal(*latest), "latest = %v, want %v", latest, newer)
+
+	missing, err := ds.FindLatestOccurredAt(context.Background(), "order_updates", "order-2")
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+}
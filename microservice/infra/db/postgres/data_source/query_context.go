@@ -0,0 +1,18 @@
+package data_source
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// O cancel retornado deve ser chamado quando as consultas terminarem
+func withQueryTimeout(ctx context.Context, db *gorm.DB, timeout time.Duration) (*gorm.DB, context.CancelFunc) {
+	if timeout <= 0 {
+		return db.WithContext(ctx), func() {}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return db.WithContext(ctx), cancel
+}
@@ -0,0 +1,73 @@
+package data_source
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithQueryTimeout(t *testing.T) {
+	db := setupSQLiteDB(t)
+
+	limited, cancel := withQueryTimeout(context.Background(), db, time.Second)
+	defer cancel()
+	deadline, ok := limited.Statement.Context.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+
+	unlimited, cancel := withQueryTimeout(context.Background(), db, 0)
+	defer cancel()
+	_, ok = unlimited.Statement.Context.Deadline()
+	assert.False(t, ok, "zero timeout keeps the context without a deadline")
+}
+
+func TestGormOrderDataSource_CancelledContextStopsQuery(t *testing.T) {
+	db := setupSQLiteDB(t)
+	ds := &GormOrderDataSource{db: db}
+	assert.NoError(t, ds.Create(context.Background(), newSQLiteOrder("order-1")))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := ds.FindByID(ctx, "order-1")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = ds.FindByID(context.Background(), "order-1")
+	assert.NoError(t, err)
+}
+
+func TestGormOrderStatusDataSource_QueryTimeout(t *testing.T) {
+	ds := &GormOrderStatusDataSource{db: setupSQLiteDB(t), queryTimeout: time.Nanosecond}
+
+	_, err := ds.FindAll(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	ds.queryTimeout = time.Second
+	statuses, err := ds.FindAll(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 2)
+}
+
+func TestGormCouponDataSource_CancelledContextStopsQuery(t *testing.T) {
+	ds := &GormCouponDataSource{db: setupSQLiteDB(t)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := ds.FindByCode(ctx, "PROMO10")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestGormIdempotencyDataSource_QueryTimeout(t *testing.T) {
+	ds := &GormIdempotencyDataSource{db: setupSQLiteDB(t), queryTimeout: time.Nanosecond}
+
+	_, err := ds.FindByKey(context.Background(), "totem-1", "key-1")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	ds.queryTimeout = time.Second
+	record, err := ds.FindByKey(context.Background(), "totem-1", "key-1")
+	assert.NoError(t, err)
+	assert.Nil(t, record)
+}
@@ -0,0 +1,25 @@
+package postgres
+
+import (
+	"log"
+
+	"gorm.io/gorm"
+
+	"microservice/infra/db/postgres/models"
+)
+
+// Apaga tabelas anteriores às chaves por escopo com expiração: o AutoMigrate manteria a
+// chave primária antiga e não consegue adicionar expires_at not null com linhas.
+// As linhas só servem a retentativas, então perdê-las é seguro
+func migrateIdempotencyKeys(db *gorm.DB) error {
+	model := &models.IdempotencyKeyModel{}
+	if !db.Migrator().HasTable(model) {
+		return nil
+	}
+	if db.Migrator().HasColumn(model, "scope") && db.Migrator().HasColumn(model, "expires_at") {
+		return nil
+	}
+
+	log.Println("Recreating idempotency_keys scoped by caller and with expiration")
+	return db.Migrator().DropTable(model)
+}
@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"microservice/infra/db/postgres/models"
+)
+
+func TestMigrateIdempotencyKeys(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+
+	if err := migrateIdempotencyKeys(db); err != nil {
+		t.Fatalf("migrateIdempotencyKeys() on empty database error = %v", err)
+	}
+
+	if err := db.Exec("CREATE TABLE idempotency_keys (key varchar(255) PRIMARY KEY, request_hash varchar(64))").Error; err != nil {
+		t.Fatalf("Failed to create legacy table: %v", err)
+	}
+	if err := migrateIdempotencyKeys(db); err != nil {
+		t.Fatalf("migrateIdempotencyKeys() on legacy table error = %v", err)
+	}
+	if db.Migrator().HasTable(&models.IdempotencyKeyModel{}) {
+		t.Error("migrateIdempotencyKeys() should drop the legacy table")
+	}
+
+	// Já com escopo, mas ainda sem expiração
+	if err := db.Exec("CREATE TABLE idempotency_keys (scope varchar(255), key varchar(255), request_hash varchar(64), PRIMARY KEY (scope, key))").Error; err != nil {
+		t.Fatalf("Failed to create scoped table: %v", err)
+	}
+	if err := migrateIdempotencyKeys(db); err != nil {
+		t.Fatalf("migrateIdempotencyKeys() on scoped table error = %v", err)
+	}
+	if db.Migrator().HasTable(&models.IdempotencyKeyModel{}) {
+		t.Error("migrateIdempotencyKeys() should drop the table without expires_at")
+	}
+
+	if err := db.AutoMigrate(&models.IdempotencyKeyModel{}); err != nil {
+		t.Fatalf("AutoMigrate() error = %v", err)
+	}
+	if err := migrateIdempotencyKeys(db); err != nil {
+		t.Errorf("migrateIdempotencyKeys() on migrated table error = %v", err)
+	}
+	if !db.Migrator().HasTable(&models.IdempotencyKeyModel{}) {
+		t.Error("migrateIdempotencyKeys() should keep the scoped table")
+	}
+}
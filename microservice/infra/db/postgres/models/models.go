@@ -1,34 +1,129 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type OrderModel struct {
-	ID         string           `gorm:"primaryKey;size:36"`
+	ID         string           `gorm:"primaryKey;size:36;index:idx_orders_created_at_id,priority:2"`
 	CustomerID *string          `gorm:"size:36"`
-	Amount     float64          `gorm:"not null"`
-	StatusID   string           `gorm:"not null;size:36"`
+	Amount     int64            `gorm:"not null"`
+	Currency   string           `gorm:"not null;size:3;default:BRL"`
+	Subtotal   int64            `gorm:"not null;default:0"`
+	Discount   int64            `gorm:"not null;default:0"`
+	CouponCode *string          `gorm:"size:30"`
+	StatusID   string           `gorm:"not null;size:36;index:idx_orders_status_created_at,priority:1"`
 	Status     OrderStatusModel `gorm:"foreignKey:StatusID;references:ID"`
 	Items      []OrderItemModel `gorm:"foreignKey:OrderID;references:ID"`
-	CreatedAt  time.Time        `gorm:"not null"`
+	CreatedAt  time.Time        `gorm:"not null;index:idx_orders_created_at_id,priority:1;index:idx_orders_status_created_at,priority:2"`
 	UpdatedAt  *time.Time
+
+	// Nulo quando o cliente não informou o nome
+	CustomerName *string `gorm:"size:50"`
+
+	// SHA-256 do token de convidado; nulo em pedidos com cliente
+	GuestTokenHash *string `gorm:"size:64"`
+
+	// Nulos em pedidos anteriores ao código de retirada
+	PickupDate *string `gorm:"size:10;uniqueIndex:idx_orders_pickup_code,priority:1"`
+	PickupCode *string `gorm:"size:4;uniqueIndex:idx_orders_pickup_code,priority:2"`
+
+	CancellationReason *string `gorm:"size:255"`
+	CancelledAt        *time.Time
+
+	// Pedidos removidos ficam ocultos das consultas até serem expurgados
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	// Incrementada a cada atualização; trava otimista contra escritas concorrentes
+	Version int `gorm:"not null;default:1"`
 }
 
 func (OrderModel) TableName() string {
 	return "orders"
 }
 
+// Conta os pedidos de cada dia para distribuir os códigos de retirada
+type PickupSequenceModel struct {
+	Day       string `gorm:"primaryKey;size:10"`
+	LastValue int    `gorm:"not null"`
+}
+
+func (PickupSequenceModel) TableName() string {
+	return "pickup_sequences"
+}
+
 type OrderItemModel struct {
-	ID        string  `gorm:"primaryKey;size:36"`
-	OrderID   string  `gorm:"not null;size:36"`
-	ProductID string  `gorm:"not null;size:36"`
-	Quantity  int     `gorm:"not null"`
-	UnitPrice float64 `gorm:"not null"`
+	ID        string `gorm:"primaryKey;size:36"`
+	OrderID   string `gorm:"not null;size:36"`
+	ProductID string `gorm:"not null;size:36"`
+	Quantity  int    `gorm:"not null"`
+	UnitPrice int64  `gorm:"not null"`
+
+	// Snapshot do produto; vazio em itens anteriores ao snapshot
+	ProductName     string `gorm:"not null;size:255;default:''"`
+	ProductCategory string `gorm:"not null;size:100;default:''"`
+	ProductImageURL string `gorm:"not null;size:1024;default:''"`
+	ProductNotes    string `gorm:"not null;type:text;default:''"`
+
+	Notes     string                   `gorm:"not null;size:200;default:''"`
+	Modifiers []OrderItemModifierModel `gorm:"foreignKey:OrderItemID;references:ID"`
+
+	// Componentes de combo apontam para o item do combo
+	ParentItemID *string `gorm:"size:36;index"`
 }
 
 func (OrderItemModel) TableName() string {
 	return "order_items"
 }
 
+type OrderItemModifierModel struct {
+	ID          string `gorm:"primaryKey;size:36"`
+	OrderItemID string `gorm:"not null;size:36;index"`
+	ModifierID  string `gorm:"not null;size:100"`
+	Name        string `gorm:"not null;size:255"`
+	PriceDelta  int64  `gorm:"not null;default:0"`
+}
+
+func (OrderItemModifierModel) TableName() string {
+	return "order_item_modifiers"
+}
+
+type CouponModel struct {
+	Code           string `gorm:"primaryKey;size:30"`
+	Type           string `gorm:"not null;size:20"`
+	Percentage     int    `gorm:"not null;default:0"`
+	FixedAmount    int64  `gorm:"not null;default:0"`
+	Currency       string `gorm:"not null;size:3;default:BRL"`
+	ProductID      string `gorm:"not null;size:36"`
+	BuyQuantity    int    `gorm:"not null;default:0"`
+	FreeQuantity   int    `gorm:"not null;default:0"`
+	FirstOrderOnly bool   `gorm:"not null;default:false"`
+	ValidFrom      *time.Time
+	ValidUntil     *time.Time
+	MaxUses        int       `gorm:"not null;default:0"`
+	UsedCount      int       `gorm:"not null;default:0"`
+	CreatedAt      time.Time `gorm:"not null"`
+}
+
+func (CouponModel) TableName() string {
+	return "coupons"
+}
+
+// Resgates de cupons de primeiro pedido; a chave impede que o mesmo cliente
+// use o cupom de novo depois de cancelar o pedido
+type CouponRedemptionModel struct {
+	CouponCode string    `gorm:"primaryKey;size:30"`
+	CustomerID string    `gorm:"primaryKey;size:36"`
+	OrderID    string    `gorm:"not null;size:36"`
+	RedeemedAt time.Time `gorm:"not null"`
+}
+
+func (CouponRedemptionModel) TableName() string {
+	return "coupon_redemptions"
+}
+
 type OrderStatusModel struct {
 	ID   string `gorm:"primaryKey;size:36"`
 	Name string `gorm:"not null;size:100"`
@@ -37,3 +132,70 @@ type OrderStatusModel struct {
 func (OrderStatusModel) TableName() string {
 	return "order_status"
 }
+
+type OrderStatusHistoryModel struct {
+	ID               string            `gorm:"primaryKey;size:36"`
+	OrderID          string            `gorm:"not null;size:36;index"`
+	PreviousStatusID *string           `gorm:"size:36"`
+	PreviousStatus   *OrderStatusModel `gorm:"foreignKey:PreviousStatusID;references:ID"`
+	NewStatusID      string            `gorm:"not null;size:36"`
+	NewStatus        OrderStatusModel  `gorm:"foreignKey:NewStatusID;references:ID"`
+	Source           string            `gorm:"not null;size:50"`
+	Actor            *string           `gorm:"size:100"`
+	ChangedAt        time.Time         `gorm:"not null;index"`
+}
+
+func (OrderStatusHistoryModel) TableName() string {
+	return "order_status_history"
+}
+
+type OutboxModel struct {
+	ID            string     `gorm:"primaryKey;size:36"`
+	OrderID       string     `gorm:"not null;size:36;index"`
+	EventType     string     `gorm:"not null;size:100"`
+	Payload       string     `gorm:"not null;type:text"`
+	Attempts      int        `gorm:"not null;default:0"`
+	LastError     *string    `gorm:"type:text"`
+	CreatedAt     time.Time  `gorm:"not null;index"`
+	NextAttemptAt time.Time  `gorm:"not null"`
+	SentAt        *time.Time `gorm:"index"`
+	// Mensagens que esgotaram as tentativas; não bloqueiam mais o pedido
+	DeadAt *time.Time `gorm:"index"`
+	// Reserva da instância do relay que está publicando a mensagem
+	LockedUntil *time.Time
+}
+
+func (OutboxModel) TableName() string {
+	return "outbox"
+}
+
+type IdempotencyKeyModel struct {
+	// Chaves são únicas por quem fez a requisição, não globalmente
+	Scope        string    `gorm:"primaryKey;size:255"`
+	Key          string    `gorm:"primaryKey;size:255"`
+	RequestHash  string    `gorm:"not null;size:64"`
+	StatusCode   int       `gorm:"not null;default:0"`
+	ResponseBody *string   `gorm:"type:text"`
+	CreatedAt    time.Time `gorm:"not null;index"`
+	// Requisição em andamento; travas antigas podem ser retomadas
+	LockedAt *time.Time
+	// Depois disso a chave é expurgada e pode ser reutilizada
+	ExpiresAt   time.Time `gorm:"not null;index"`
+	CompletedAt *time.Time
+}
+
+func (IdempotencyKeyModel) TableName() string {
+	return "idempotency_keys"
+}
+
+type ProcessedMessageModel struct {
+	Consumer    string     `gorm:"primaryKey;size:100;index:idx_processed_messages_order,priority:1"`
+	MessageID   string     `gorm:"primaryKey;size:255"`
+	OrderID     string     `gorm:"not null;size:36;index:idx_processed_messages_order,priority:2"`
+	OccurredAt  *time.Time `gorm:"index:idx_processed_messages_order,priority:3"`
+	ProcessedAt time.Time  `gorm:"not null"`
+}
+
+func (ProcessedMessageModel) TableName() string {
+	return "processed_messages"
+}
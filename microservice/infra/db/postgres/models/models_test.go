@@ -22,6 +22,15 @@ func TestOrderItemModel_TableName(t *testing.T) {
 	}
 }
 
+func TestOrderItemModifierModel_TableName(t *testing.T) {
+	model := OrderItemModifierModel{}
+	tableName := model.TableName()
+
+	if tableName != "order_item_modifiers" {
+		t.Errorf("OrderItemModifierModel.TableName() = %v, want order_item_modifiers", tableName)
+	}
+}
+
 func TestOrderStatusModel_TableName(t *testing.T) {
 	model := OrderStatusModel{}
 	tableName := model.TableName()
@@ -30,3 +39,48 @@ func TestOrderStatusModel_TableName(t *testing.T) {
 		t.Errorf("OrderStatusModel.TableName() = %v, want order_status", tableName)
 	}
 }
+
+func TestOrderStatusHistoryModel_TableName(t *testing.T) {
+	model := OrderStatusHistoryModel{}
+	tableName := model.TableName()
+
+	if tableName != "order_status_history" {
+		t.Errorf("OrderStatusHistoryModel.TableName() = %v, want order_status_history", tableName)
+	}
+}
+
+func TestOutboxModel_TableName(t *testing.T) {
+	model := OutboxModel{}
+	tableName := model.TableName()
+
+	if tableName != "outbox" {
+		t.Errorf("OutboxModel.TableName() = %v, want outbox", tableName)
+	}
+}
+
+func TestIdempotencyKeyModel_TableName(t *testing.T) {
+	model := IdempotencyKeyModel{}
+	tableName := model.TableName()
+
+	if tableName != "idempotency_keys" {
+		t.Errorf("IdempotencyKeyModel.TableName() = %v, want idempotency_keys", tableName)
+	}
+}
+
+func TestProcessedMessageModel_TableName(t *testing.T) {
+	model := ProcessedMessageModel{}
+	tableName := model.TableName()
+
+	if tableName != "processed_messages" {
+		t.Errorf("ProcessedMessageModel.TableName() = %v, want processed_messages", tableName)
+	}
+}
+
+func TestCouponModel_TableName(t *testing.T) {
+	model := CouponModel{}
+	tableName := model.TableName()
+
+	if tableName != "coupons" {
+		t.Errorf("CouponModel.TableName() = %v, want coupons", tableName)
+	}
+}
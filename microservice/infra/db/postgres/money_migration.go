@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"microservice/infra/db/postgres/models"
+)
+
+// Colunas monetárias que antes eram double precision e passaram a guardar centavos
+var minorUnitsColumns = []struct {
+	model  interface{}
+	table  string
+	column string
+}{
+	{&models.OrderModel{}, "orders", "amount"},
+	{&models.OrderItemModel{}, "order_items", "unit_price"},
+}
+
+// Roda antes do AutoMigrate, que só trocaria o tipo da coluna e truncaria os valores
+func migrateMoneyToMinorUnits(db *gorm.DB) error {
+	for _, target := range minorUnitsColumns {
+		if !db.Migrator().HasTable(target.model) {
+			continue
+		}
+
+		columnTypes, err := db.Migrator().ColumnTypes(target.model)
+		if err != nil {
+			return err
+		}
+
+		for _, columnType := range columnTypes {
+			if columnType.Name() != target.column || !needsMinorUnitsMigration(columnType.DatabaseTypeName()) {
+				continue
+			}
+
+			log.Printf("Converting %s.%s to minor units", target.table, target.column)
+			statement := fmt.Sprintf(
+				"ALTER TABLE %s ALTER COLUMN %s TYPE bigint USING ROUND(%s * 100)::bigint",
+				target.table, target.column, target.column,
+			)
+			if err := db.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func needsMinorUnitsMigration(databaseTypeName string) bool {
+	switch strings.ToLower(databaseTypeName) {
+	case "float4", "float8", "real", "double precision", "numeric", "decimal":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"microservice/infra/db/postgres/models"
+)
+
+func TestNeedsMinorUnitsMigration(t *testing.T) {
+	tests := []struct {
+		typeName string
+		expected bool
+	}{
+		{"float8", true},
+		{"FLOAT8", true},
+		{"double precision", true},
+		{"numeric", true},
+		{"int8", false},
+		{"bigint", false},
+		{"integer", false},
+	}
+
+	for _, tt := range tests {
+		if got := needsMinorUnitsMigration(tt.typeName); got != tt.expected {
+			t.Errorf("needsMinorUnitsMigration(%q) = %v, want %v", tt.typeName, got, tt.expected)
+		}
+	}
+}
+
+func TestMigrateMoneyToMinorUnits_SkipsMissingAndMigratedTables(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+
+	if err := migrateMoneyToMinorUnits(db); err != nil {
+		t.Fatalf("migrateMoneyToMinorUnits() on empty database error = %v", err)
+	}
+
+	if err := db.AutoMigrate(&models.OrderModel{}, &models.OrderItemModel{}); err != nil {
+		t.Fatalf("AutoMigrate() error = %v", err)
+	}
+
+	if err := migrateMoneyToMinorUnits(db); err != nil {
+		t.Errorf("migrateMoneyToMinorUnits() on migrated tables error = %v", err)
+	}
+}
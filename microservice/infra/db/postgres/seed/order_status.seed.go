@@ -14,6 +14,8 @@ const (
 	ORDER_STATUS_PREPARING_ID = "5a8b2b16-9b47-4e35-ae27-28f7994ef456"
 	ORDER_STATUS_READY_ID     = "bd91a1ee-1234-4cde-9c2a-efb1d2a3a789"
 	ORDER_STATUS_DELIVERED_ID = "f1e2d3c4-5b6a-7c8d-9e0f-1a2b3c4d5e6f"
+	ORDER_STATUS_CANCELLED_ID = "9c4e7f21-6d3a-4b8e-a1f5-2e7d9b3c6a14"
+	ORDER_STATUS_FAILED_ID    = "e7b2c9d4-3f1a-4e6b-8c5d-7a9f1b2e4c36"
 )
 
 func SeedOrderStatus(db *gorm.DB) {
@@ -23,6 +25,8 @@ func SeedOrderStatus(db *gorm.DB) {
 		{ID: ORDER_STATUS_PREPARING_ID, Name: "Em preparação"},
 		{ID: ORDER_STATUS_READY_ID, Name: "Pronto"},
 		{ID: ORDER_STATUS_DELIVERED_ID, Name: "Entregue"},
+		{ID: ORDER_STATUS_CANCELLED_ID, Name: "Cancelado"},
+		{ID: ORDER_STATUS_FAILED_ID, Name: "Falhou"},
 	}
 
 	for _, status := range defaults {
@@ -31,8 +31,8 @@ func TestSeedOrderStatus_NewDatabase(t *testing.T) {
 	var count int64
 	db.Model(&models.OrderStatusModel{}).Count(&count)
 
-	if count != 5 {
-		t.Errorf("Expected 5 order statuses, got %d", count)
+	if count != 7 {
+		t.Errorf("Expected 7 order statuses, got %d", count)
 	}
 
 	expectedStatuses := map[string]string{
@@ -41,6 +41,8 @@ func TestSeedOrderStatus_NewDatabase(t *testing.T) {
 		ORDER_STATUS_PREPARING_ID: "Em preparação",
 		ORDER_STATUS_READY_ID:     "Pronto",
 		ORDER_STATUS_DELIVERED_ID: "Entregue",
+		ORDER_STATUS_CANCELLED_ID: "Cancelado",
+		ORDER_STATUS_FAILED_ID:    "Falhou",
 	}
 
 	for id, name := range expectedStatuses {
@@ -70,8 +72,8 @@ func TestSeedOrderStatus_ExistingData(t *testing.T) {
 	var count int64
 	db.Model(&models.OrderStatusModel{}).Count(&count)
 
-	if count != 5 {
-		t.Errorf("Expected 5 order statuses, got %d", count)
+	if count != 7 {
+		t.Errorf("Expected 7 order statuses, got %d", count)
 	}
 
 	var receivedStatuses []models.OrderStatusModel
@@ -89,6 +91,8 @@ func TestSeedOrderStatus_Constants(t *testing.T) {
 		ORDER_STATUS_PREPARING_ID,
 		ORDER_STATUS_READY_ID,
 		ORDER_STATUS_DELIVERED_ID,
+		ORDER_STATUS_CANCELLED_ID,
+		ORDER_STATUS_FAILED_ID,
 	}
 
 	for _, constant := range constants {
@@ -20,15 +20,33 @@ func Connect() error {
 	brokerConfig := brokers.BrokerConfig{
 		Type: cfg.MessageBroker.Type,
 		// SQS
-		SQSOrdersQueueURL: cfg.MessageBroker.SQS.OrdersQueueURL,
-		AWSRegion:         cfg.MessageBroker.SQS.AWSRegion,
+		SQSOrdersQueueURL:      cfg.MessageBroker.SQS.OrdersQueueURL,
+		SQSPaymentsQueueURL:    cfg.MessageBroker.SQS.PaymentsQueueURL,
+		SQSKitchenQueueURL:     cfg.MessageBroker.SQS.KitchenQueueURL,
+		SQSOrderEventsQueueURL: cfg.MessageBroker.SQS.OrderEventsQueueURL,
+		SQSDeadLetterQueueURL:  cfg.MessageBroker.SQS.DeadLetterQueueURL,
+		AWSRegion:              cfg.MessageBroker.SQS.AWSRegion,
 		// RabbitMQ
-		RabbitMQURL:         buildRabbitMQURL(cfg),
-		RabbitMQOrdersQueue: cfg.MessageBroker.RabbitMQ.OrdersQueue,
+		RabbitMQURL:                 buildRabbitMQURL(cfg),
+		RabbitMQOrdersQueue:         cfg.MessageBroker.RabbitMQ.OrdersQueue,
+		RabbitMQPaymentsQueue:       cfg.MessageBroker.RabbitMQ.PaymentsQueue,
+		RabbitMQKitchenQueue:        cfg.MessageBroker.RabbitMQ.KitchenQueue,
+		RabbitMQOrderEventsExchange: cfg.MessageBroker.RabbitMQ.OrderEventsExchange,
+		RabbitMQDeadLetterExchange:  cfg.MessageBroker.RabbitMQ.DeadLetterExchange,
+
+		RetryPolicy: brokers.NewRetryPolicy(
+			cfg.MessageBroker.Retry.MaxAttempts,
+			cfg.MessageBroker.Retry.InitialBackoff,
+			cfg.MessageBroker.Retry.MaxBackoff,
+		),
 	}
 
 	if cfg.MessageBroker.Type == "sqs" {
 		log.Printf("SQS Config - Orders Queue: %s", brokerConfig.SQSOrdersQueueURL)
+		log.Printf("SQS Config - Payments Queue: %s", brokerConfig.SQSPaymentsQueueURL)
+		log.Printf("SQS Config - Kitchen Queue: %s", brokerConfig.SQSKitchenQueueURL)
+		log.Printf("SQS Config - Order Events Queue: %s", brokerConfig.SQSOrderEventsQueueURL)
+		log.Printf("SQS Config - Dead Letter Queue: %s", brokerConfig.SQSDeadLetterQueueURL)
 		log.Printf("SQS Config - AWS Region: %s", brokerConfig.AWSRegion)
 	}
 
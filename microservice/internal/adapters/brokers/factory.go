@@ -17,6 +17,8 @@ func (f *Factory) CreateBroker(config BrokerConfig) (MessageBroker, error) {
 		return NewSQSBroker(config)
 	case "rabbitmq":
 		return NewRabbitMQBroker(config)
+	case "memory":
+		return NewInMemoryBroker(), nil
 	default:
 		return nil, fmt.Errorf("unsupported broker type: %s", config.Type)
 	}
@@ -102,3 +102,16 @@ func TestFactory_CreateBroker_UnsupportedType(t *testing.T) {
 		t.Errorf("Expected error '%s', got '%s'", expectedError, err.Error())
 	}
 }
+
+func TestFactory_CreateBroker_Memory(t *testing.T) {
+	factory := NewFactory()
+
+	broker, err := factory.CreateBroker(BrokerConfig{Type: "memory"})
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if _, ok := broker.(*InMemoryBroker); !ok {
+		t.Error("Expected InMemoryBroker type")
+	}
+}
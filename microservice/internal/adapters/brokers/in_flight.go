@@ -0,0 +1,29 @@
+package brokers
+
+import "sync"
+
+// Permite que Close espere as mensagens em processamento e impede novas depois disso
+type inFlightTracker struct {
+	mu     sync.RWMutex
+	closed bool
+}
+
+// Todo begin que retorna true precisa de um end
+func (t *inFlightTracker) begin() bool {
+	t.mu.RLock()
+	if t.closed {
+		t.mu.RUnlock()
+		return false
+	}
+	return true
+}
+
+func (t *inFlightTracker) end() {
+	t.mu.RUnlock()
+}
+
+func (t *inFlightTracker) closeAndWait() {
+	t.mu.Lock()
+	t.closed = true
+	t.mu.Unlock()
+}
@@ -0,0 +1,40 @@
+package brokers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInFlightTracker_CloseWaitsForMessagesBeingHandled(t *testing.T) {
+	tracker := &inFlightTracker{}
+	assert.True(t, tracker.begin())
+
+	closed := make(chan struct{})
+	go func() {
+		tracker.closeAndWait()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+		t.Fatal("closeAndWait returned while a message was being handled")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	tracker.end()
+
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("closeAndWait did not return after the message was handled")
+	}
+}
+
+func TestInFlightTracker_RejectsMessagesAfterClose(t *testing.T) {
+	tracker := &inFlightTracker{}
+	tracker.closeAndWait()
+
+	assert.False(t, tracker.begin())
+}
@@ -2,10 +2,15 @@ package brokers
 
 import (
 	"context"
+	"encoding/json"
 	"time"
+
+	"microservice/internal/domain/value_objects"
 )
 
 type OrderUpdateMessage struct {
+	// Preenchido pelo broker quando o corpo não traz um ID próprio
+	MessageID string                 `json:"message_id,omitempty"`
 	Type      string                 `json:"type"`
 	OrderID   string                 `json:"order_id"`
 	Status    string                 `json:"status"`
@@ -13,23 +18,79 @@ type OrderUpdateMessage struct {
 	Metadata  map[string]interface{} `json:"metadata,omitempty"`
 }
 
+type PaymentConfirmationMessage struct {
+	OrderID   string `json:"order_id"`
+	PaymentID string `json:"payment_id"`
+	Status    string `json:"status"`
+	// Lido de "amount" e "currency" (BRL quando ausente) por UnmarshalJSON
+	Amount        value_objects.Money `json:"-"`
+	PaymentMethod string              `json:"payment_method"`
+	ProcessedAt   time.Time           `json:"processed_at"`
+}
+
+// Lê o valor como decimal exato, não float64
+func (m *PaymentConfirmationMessage) UnmarshalJSON(data []byte) error {
+	type message PaymentConfirmationMessage
+	var raw struct {
+		message
+		Amount   json.Number `json:"amount"`
+		Currency string      `json:"currency"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*m = PaymentConfirmationMessage(raw.message)
+	if raw.Amount == "" {
+		return nil
+	}
+
+	currency := raw.Currency
+	if currency == "" {
+		currency = value_objects.DEFAULT_CURRENCY
+	}
+	amount, err := value_objects.ParseMoney(raw.Amount.String(), currency)
+	if err != nil {
+		return err
+	}
+	m.Amount = amount
+	return nil
+}
+
 type MessageBroker interface {
 	ConsumeOrderUpdates(ctx context.Context, handler OrderUpdateHandler) error
+	ConsumePaymentConfirmations(ctx context.Context, handler PaymentConfirmationHandler) error
+	PublishOrderEvent(ctx context.Context, event OrderEvent) error
 	Close() error
 }
 
-type OrderUpdateHandler func(message OrderUpdateMessage) error
+// Os handlers recebem um contexto com o request_id da mensagem, ou um novo
+// quando ela não traz um
+type OrderUpdateHandler func(ctx context.Context, message OrderUpdateMessage) error
+
+type PaymentConfirmationHandler func(ctx context.Context, message PaymentConfirmationMessage) error
 
 type BrokerConfig struct {
 	Type string
 
 	// SQS Config
-	SQSOrdersQueueURL string
-	AWSRegion         string
+	SQSOrdersQueueURL      string
+	SQSPaymentsQueueURL    string
+	SQSKitchenQueueURL     string
+	SQSOrderEventsQueueURL string
+	SQSDeadLetterQueueURL  string
+	AWSRegion              string
 
 	// RabbitMQ
-	RabbitMQURL         string
-	RabbitMQOrdersQueue string
+	RabbitMQURL                 string
+	RabbitMQOrdersQueue         string
+	RabbitMQPaymentsQueue       string
+	RabbitMQKitchenQueue        string
+	RabbitMQOrderEventsExchange string
+	RabbitMQDeadLetterExchange  string
+
+	// Aplicada às mensagens consumidas por qualquer broker
+	RetryPolicy RetryPolicy
 }
 
 type BrokerFactory interface {
@@ -0,0 +1,51 @@
+package brokers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"microservice/internal/domain/exceptions"
+)
+
+func TestPaymentConfirmationMessage_UnmarshalJSON(t *testing.T) {
+	var message PaymentConfirmationMessage
+	err := json.Unmarshal([]byte(`{"order_id":"order-1","payment_id":"payment-1","status":"confirmed","amount":25.90,"payment_method":"pix"}`), &message)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "order-1", message.OrderID)
+	assert.Equal(t, "payment-1", message.PaymentID)
+	assert.Equal(t, "confirmed", message.Status)
+	assert.Equal(t, "pix", message.PaymentMethod)
+	assert.Equal(t, int64(2590), message.Amount.MinorUnits())
+	assert.Equal(t, "BRL", message.Amount.Currency())
+}
+
+func TestPaymentConfirmationMessage_UnmarshalJSON_Currency(t *testing.T) {
+	var message PaymentConfirmationMessage
+	err := json.Unmarshal([]byte(`{"order_id":"order-1","amount":10,"currency":"USD"}`), &message)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), message.Amount.MinorUnits())
+	assert.Equal(t, "USD", message.Amount.Currency())
+}
+
+func TestPaymentConfirmationMessage_UnmarshalJSON_InvalidAmount(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"more decimal places than the currency", `{"order_id":"order-1","amount":25.001}`},
+		{"exponent", `{"order_id":"order-1","amount":2.59e1}`},
+		{"unsupported currency", `{"order_id":"order-1","amount":25,"currency":"XYZ"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var message PaymentConfirmationMessage
+			err := json.Unmarshal([]byte(tt.body), &message)
+			assert.IsType(t, &exceptions.AmountNotValidException{}, err)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package brokers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Tudo em processo; usado em execuções locais e testes
+type InMemoryBroker struct {
+	mu              sync.Mutex
+	published       []OrderEvent
+	handlers        []OrderUpdateHandler
+	paymentHandlers []PaymentConfirmationHandler
+	closed          bool
+}
+
+func NewInMemoryBroker() *InMemoryBroker {
+	return &InMemoryBroker{}
+}
+
+func (b *InMemoryBroker) ConsumeOrderUpdates(ctx context.Context, handler OrderUpdateHandler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return fmt.Errorf("in-memory broker is closed")
+	}
+
+	b.handlers = append(b.handlers, handler)
+	return nil
+}
+
+func (b *InMemoryBroker) ConsumePaymentConfirmations(ctx context.Context, handler PaymentConfirmationHandler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return fmt.Errorf("in-memory broker is closed")
+	}
+
+	b.paymentHandlers = append(b.paymentHandlers, handler)
+	return nil
+}
+
+func (b *InMemoryBroker) PublishOrderEvent(ctx context.Context, event OrderEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return fmt.Errorf("in-memory broker is closed")
+	}
+
+	b.published = append(b.published, event)
+	return nil
+}
+
+func (b *InMemoryBroker) DeliverOrderUpdate(ctx context.Context, message OrderUpdateMessage) error {
+	b.mu.Lock()
+	handlers := append([]OrderUpdateHandler(nil), b.handlers...)
+	b.mu.Unlock()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *InMemoryBroker) DeliverPaymentConfirmation(ctx context.Context, message PaymentConfirmationMessage) error {
+	b.mu.Lock()
+	handlers := append([]PaymentConfirmationHandler(nil), b.paymentHandlers...)
+	b.mu.Unlock()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *InMemoryBroker) PublishedEvents() []OrderEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]OrderEvent(nil), b.published...)
+}
+
+func (b *InMemoryBroker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	return nil
+}
@@ -0,0 +1,78 @@
+package brokers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryBroker_PublishOrderEvent(t *testing.T) {
+	broker := NewInMemoryBroker()
+
+	event, err := NewOrderEvent(context.Background(), ORDER_CREATED_EVENT, "order-123", OrderCreatedPayload{OrderID: "order-123"})
+	assert.NoError(t, err)
+
+	err = broker.PublishOrderEvent(context.Background(), event)
+	assert.NoError(t, err)
+
+	published := broker.PublishedEvents()
+	assert.Len(t, published, 1)
+	assert.Equal(t, ORDER_CREATED_EVENT, published[0].Type)
+	assert.Equal(t, "order-123", published[0].OrderID)
+}
+
+func TestInMemoryBroker_DeliverOrderUpdate(t *testing.T) {
+	broker := NewInMemoryBroker()
+
+	var received OrderUpdateMessage
+	err := broker.ConsumeOrderUpdates(context.Background(), func(ctx context.Context, message OrderUpdateMessage) error {
+		received = message
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = broker.DeliverOrderUpdate(context.Background(), OrderUpdateMessage{OrderID: "order-123", Status: "Pronto"})
+	assert.NoError(t, err)
+	assert.Equal(t, "order-123", received.OrderID)
+	assert.Equal(t, "Pronto", received.Status)
+}
+
+func TestInMemoryBroker_DeliverOrderUpdate_HandlerError(t *testing.T) {
+	broker := NewInMemoryBroker()
+
+	_ = broker.ConsumeOrderUpdates(context.Background(), func(ctx context.Context, message OrderUpdateMessage) error {
+		return errors.New("handler failed")
+	})
+
+	err := broker.DeliverOrderUpdate(context.Background(), OrderUpdateMessage{OrderID: "order-123"})
+	assert.Error(t, err)
+}
+
+func TestInMemoryBroker_DeliverPaymentConfirmation(t *testing.T) {
+	broker := NewInMemoryBroker()
+
+	var received PaymentConfirmationMessage
+	err := broker.ConsumePaymentConfirmations(context.Background(), func(ctx context.Context, message PaymentConfirmationMessage) error {
+		received = message
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = broker.DeliverPaymentConfirmation(context.Background(), PaymentConfirmationMessage{OrderID: "order-123", PaymentID: "payment-1", Status: "confirmed"})
+	assert.NoError(t, err)
+	assert.Equal(t, "order-123", received.OrderID)
+	assert.Equal(t, "confirmed", received.Status)
+}
+
+func TestInMemoryBroker_Closed(t *testing.T) {
+	broker := NewInMemoryBroker()
+	assert.NoError(t, broker.Close())
+
+	err := broker.PublishOrderEvent(context.Background(), OrderEvent{})
+	assert.Error(t, err)
+
+	err = broker.ConsumeOrderUpdates(context.Background(), func(ctx context.Context, message OrderUpdateMessage) error { return nil })
+	assert.Error(t, err)
+}
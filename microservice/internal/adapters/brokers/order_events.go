@@ -0,0 +1,137 @@
+package brokers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"microservice/utils/correlation"
+	identityUtils "microservice/utils/identity"
+)
+
+// Versão 2: valores monetários como strings decimais acompanhados da moeda
+const ORDER_EVENT_VERSION = 2
+
+const (
+	ORDER_CREATED_EVENT        = "order.created"
+	ORDER_STATUS_CHANGED_EVENT = "order.status_changed"
+	ORDER_DELETED_EVENT        = "order.deleted"
+	ORDER_COUPON_APPLIED_EVENT = "order.coupon_applied"
+	ORDER_CANCELLED_EVENT      = "order.cancelled"
+	ORDER_RESTORED_EVENT       = "order.restored"
+
+	// Enviado para a fila da cozinha e não para o canal de eventos de pedidos
+	KITCHEN_ORDER_REQUESTED_EVENT = "kitchen.order_requested"
+)
+
+type OrderEvent struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Version    int             `json:"version"`
+	OrderID    string          `json:"order_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Payload    json.RawMessage `json:"payload"`
+
+	// Request que originou o evento; segue no cabeçalho request_id das mensagens
+	CorrelationID string `json:"correlation_id,omitempty"`
+}
+
+type OrderItemPayload struct {
+	ID              string `json:"id"`
+	ProductID       string `json:"product_id"`
+	ProductName     string `json:"product_name,omitempty"`
+	ProductCategory string `json:"product_category,omitempty"`
+	ProductNotes    string `json:"product_notes,omitempty"`
+	Quantity        int    `json:"quantity"`
+	UnitPrice       string `json:"unit_price"`
+	Notes           string `json:"notes,omitempty"`
+
+	Modifiers []OrderItemModifierPayload `json:"modifiers,omitempty"`
+	// Itens de um combo, para a cozinha; o preço cobrado é o do combo
+	Components []OrderItemPayload `json:"components,omitempty"`
+}
+
+type OrderItemModifierPayload struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	PriceDelta string `json:"price_delta"`
+}
+
+type OrderCreatedPayload struct {
+	OrderID    string             `json:"order_id"`
+	CustomerID *string            `json:"customer_id,omitempty"`
+	PickupCode string             `json:"pickup_code,omitempty"`
+	Amount     string             `json:"amount"`
+	Currency   string             `json:"currency"`
+	Status     string             `json:"status"`
+	Items      []OrderItemPayload `json:"items"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+type OrderStatusChangedPayload struct {
+	OrderID        string    `json:"order_id"`
+	PreviousStatus string    `json:"previous_status"`
+	Status         string    `json:"status"`
+	ChangedAt      time.Time `json:"changed_at"`
+}
+
+type OrderDeletedPayload struct {
+	OrderID   string    `json:"order_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type OrderRestoredPayload struct {
+	OrderID    string    `json:"order_id"`
+	RestoredAt time.Time `json:"restored_at"`
+}
+
+type OrderCouponAppliedPayload struct {
+	OrderID    string    `json:"order_id"`
+	CouponCode string    `json:"coupon_code"`
+	Subtotal   string    `json:"subtotal"`
+	Discount   string    `json:"discount"`
+	Amount     string    `json:"amount"`
+	Currency   string    `json:"currency"`
+	AppliedAt  time.Time `json:"applied_at"`
+}
+
+// Diz ao pagamento se deve estornar e à cozinha se já tinha recebido o pedido
+type OrderCancelledPayload struct {
+	OrderID         string    `json:"order_id"`
+	CustomerID      *string   `json:"customer_id,omitempty"`
+	PreviousStatus  string    `json:"previous_status"`
+	Reason          string    `json:"reason"`
+	Amount          string    `json:"amount"`
+	Currency        string    `json:"currency"`
+	RefundRequired  bool      `json:"refund_required"`
+	KitchenNotified bool      `json:"kitchen_notified"`
+	CancelledAt     time.Time `json:"cancelled_at"`
+}
+
+type KitchenOrderRequestPayload struct {
+	OrderID     string             `json:"order_id"`
+	CustomerID  *string            `json:"customer_id,omitempty"`
+	PickupCode  string             `json:"pickup_code,omitempty"`
+	Currency    string             `json:"currency"`
+	Items       []OrderItemPayload `json:"items"`
+	RequestedAt time.Time          `json:"requested_at"`
+}
+
+func NewOrderEvent(ctx context.Context, eventType string, orderID string, payload interface{}) (OrderEvent, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return OrderEvent{}, fmt.Errorf("failed to marshal %s payload: %w", eventType, err)
+	}
+
+	return OrderEvent{
+		ID:         identityUtils.NewUUIDV4(),
+		Type:       eventType,
+		Version:    ORDER_EVENT_VERSION,
+		OrderID:    orderID,
+		OccurredAt: time.Now().UTC(),
+		Payload:    body,
+
+		CorrelationID: correlation.ID(ctx),
+	}, nil
+}
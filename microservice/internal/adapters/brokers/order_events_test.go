@@ -0,0 +1,65 @@
+package brokers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"microservice/utils/correlation"
+)
+
+func TestNewOrderEvent(t *testing.T) {
+	payload := OrderStatusChangedPayload{
+		OrderID:        "order-123",
+		PreviousStatus: "Recebido",
+		Status:         "Confirmado",
+	}
+
+	event, err := NewOrderEvent(context.Background(), ORDER_STATUS_CHANGED_EVENT, "order-123", payload)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, event.ID)
+	assert.Equal(t, ORDER_STATUS_CHANGED_EVENT, event.Type)
+	assert.Equal(t, ORDER_EVENT_VERSION, event.Version)
+	assert.Equal(t, "order-123", event.OrderID)
+	assert.False(t, event.OccurredAt.IsZero())
+
+	var decoded OrderStatusChangedPayload
+	assert.NoError(t, json.Unmarshal(event.Payload, &decoded))
+	assert.Equal(t, payload, decoded)
+}
+
+func TestNewOrderEvent_InvalidPayload(t *testing.T) {
+	_, err := NewOrderEvent(context.Background(), ORDER_CREATED_EVENT, "order-123", make(chan int))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to marshal order.created payload")
+}
+
+func TestOrderEvent_JSONEnvelope(t *testing.T) {
+	event, _ := NewOrderEvent(context.Background(), ORDER_DELETED_EVENT, "order-123", OrderDeletedPayload{OrderID: "order-123"})
+
+	body, err := json.Marshal(event)
+	assert.NoError(t, err)
+
+	var envelope map[string]interface{}
+	assert.NoError(t, json.Unmarshal(body, &envelope))
+	assert.Equal(t, "order.deleted", envelope["type"])
+	assert.Equal(t, float64(2), envelope["version"])
+	assert.Equal(t, "order-123", envelope["order_id"])
+	assert.Contains(t, envelope, "payload")
+}
+
+func TestNewOrderEvent_CarriesRequestID(t *testing.T) {
+	ctx := correlation.WithID(context.Background(), "req-1")
+
+	event, err := NewOrderEvent(ctx, ORDER_DELETED_EVENT, "order-123", OrderDeletedPayload{OrderID: "order-123"})
+	assert.NoError(t, err)
+	assert.Equal(t, "req-1", event.CorrelationID)
+
+	withoutID, _ := NewOrderEvent(context.Background(), ORDER_DELETED_EVENT, "order-123", OrderDeletedPayload{OrderID: "order-123"})
+	body, _ := json.Marshal(withoutID)
+	assert.NotContains(t, string(body), "correlation_id")
+}
@@ -5,15 +5,53 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
-	"strings"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/streadway/amqp"
+
+	"microservice/utils/correlation"
 )
 
+const DEFAULT_ORDER_EVENTS_EXCHANGE = "orders.events"
+const DEFAULT_DEAD_LETTER_EXCHANGE = "orders.dlx"
+
+// Cabeçalhos usados no controle de retentativas
+const (
+	RETRY_COUNT_HEADER = "x-retry-count"
+	LAST_ERROR_HEADER  = "x-last-error"
+)
+
+// Tempo máximo de espera pela confirmação do broker após uma publicação
+const PUBLISH_CONFIRM_TIMEOUT = 10 * time.Second
+
+type rabbitMQChannel interface {
+	QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error)
+	QueueBind(name, key, exchange string, noWait bool, args amqp.Table) error
+	ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error
+	Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error)
+	Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+	Confirm(noWait bool) error
+	NotifyPublish(confirm chan amqp.Confirmation) chan amqp.Confirmation
+	Close() error
+}
+
 type RabbitMQBroker struct {
-	conn        *amqp.Connection
-	channel     *amqp.Channel
-	ordersQueue string
+	conn                *amqp.Connection
+	channel             rabbitMQChannel
+	ordersQueue         string
+	paymentsQueue       string
+	kitchenQueue        string
+	orderEventsExchange string
+	deadLetterExchange  string
+	retryPolicy         RetryPolicy
+	inFlight            inFlightTracker
+
+	// Publicações são serializadas para casar cada uma com sua confirmação
+	publishMu   sync.Mutex
+	confirms    chan amqp.Confirmation
+	deliveryTag uint64
 }
 
 func NewRabbitMQBroker(brokerConfig BrokerConfig) (*RabbitMQBroker, error) {
@@ -36,10 +74,35 @@ func NewRabbitMQBroker(brokerConfig BrokerConfig) (*RabbitMQBroker, error) {
 		return nil, fmt.Errorf("failed to open channel: %w", err)
 	}
 
+	orderEventsExchange := brokerConfig.RabbitMQOrderEventsExchange
+	if orderEventsExchange == "" {
+		orderEventsExchange = DEFAULT_ORDER_EVENTS_EXCHANGE
+	}
+
+	deadLetterExchange := brokerConfig.RabbitMQDeadLetterExchange
+	if deadLetterExchange == "" {
+		deadLetterExchange = DEFAULT_DEAD_LETTER_EXCHANGE
+	}
+
+	retryPolicy := brokerConfig.RetryPolicy
+	if retryPolicy.MaxAttempts == 0 {
+		retryPolicy = DefaultRetryPolicy()
+	}
+
 	broker := &RabbitMQBroker{
-		conn:        conn,
-		channel:     channel,
-		ordersQueue: brokerConfig.RabbitMQOrdersQueue,
+		conn:                conn,
+		channel:             channel,
+		ordersQueue:         brokerConfig.RabbitMQOrdersQueue,
+		paymentsQueue:       brokerConfig.RabbitMQPaymentsQueue,
+		kitchenQueue:        brokerConfig.RabbitMQKitchenQueue,
+		orderEventsExchange: orderEventsExchange,
+		deadLetterExchange:  deadLetterExchange,
+		retryPolicy:         retryPolicy,
+	}
+
+	if err := broker.enableConfirms(); err != nil {
+		broker.Close()
+		return nil, err
 	}
 
 	if err := broker.declareQueues(); err != nil {
@@ -50,6 +113,15 @@ func NewRabbitMQBroker(brokerConfig BrokerConfig) (*RabbitMQBroker, error) {
 	return broker, nil
 }
 
+// Com o canal em modo de confirmação, a publicação só é dada como feita após o ack do broker
+func (r *RabbitMQBroker) enableConfirms() error {
+	if err := r.channel.Confirm(false); err != nil {
+		return fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+	r.confirms = r.channel.NotifyPublish(make(chan amqp.Confirmation, 1))
+	return nil
+}
+
 func (r *RabbitMQBroker) declareQueues() error {
 	// Declarar fila de atualizações de pedidos
 	_, err := r.channel.QueueDeclare(
@@ -64,9 +136,78 @@ func (r *RabbitMQBroker) declareQueues() error {
 		return fmt.Errorf("failed to declare orders queue: %w", err)
 	}
 
+	// Declarar filas opcionais de pagamentos e da cozinha
+	for _, queue := range []string{r.paymentsQueue, r.kitchenQueue} {
+		if queue == "" {
+			continue
+		}
+		if _, err := r.channel.QueueDeclare(queue, true, false, false, false, nil); err != nil {
+			return fmt.Errorf("failed to declare queue %s: %w", queue, err)
+		}
+	}
+
+	// Declarar exchange de eventos de pedidos (routing key = tipo do evento)
+	err = r.channel.ExchangeDeclare(
+		r.orderEventsExchange, // name
+		amqp.ExchangeTopic,    // kind
+		true,                  // durable
+		false,                 // auto-deleted
+		false,                 // internal
+		false,                 // no-wait
+		nil,                   // arguments
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare order events exchange: %w", err)
+	}
+
+	// Declarar exchange de mensagens mortas (routing key = fila de origem)
+	err = r.channel.ExchangeDeclare(r.deadLetterExchange, amqp.ExchangeDirect, true, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to declare dead letter exchange: %w", err)
+	}
+
+	for _, queue := range []string{r.ordersQueue, r.paymentsQueue} {
+		if queue == "" {
+			continue
+		}
+		if err := r.declareRetryQueues(queue); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// A fila de retentativa devolve as mensagens expiradas para a fila original
+func (r *RabbitMQBroker) declareRetryQueues(queue string) error {
+	deadLetterQueue := deadLetterQueueName(queue)
+	if _, err := r.channel.QueueDeclare(deadLetterQueue, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare dead letter queue %s: %w", deadLetterQueue, err)
+	}
+	if err := r.channel.QueueBind(deadLetterQueue, queue, r.deadLetterExchange, false, nil); err != nil {
+		return fmt.Errorf("failed to bind dead letter queue %s: %w", deadLetterQueue, err)
+	}
+
+	retryQueue := retryQueueName(queue)
+	_, err := r.channel.QueueDeclare(retryQueue, true, false, false, false, amqp.Table{
+		"x-dead-letter-exchange":    "",
+		"x-dead-letter-routing-key": queue,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to declare retry queue %s: %w", retryQueue, err)
+	}
+
 	return nil
 }
 
+func deadLetterQueueName(queue string) string {
+	return queue + ".dlq"
+}
+
+func retryQueueName(queue string) string {
+	return queue + ".retry"
+}
+
 func (r *RabbitMQBroker) ConsumeOrderUpdates(ctx context.Context, handler OrderUpdateHandler) error {
 	msgs, err := r.channel.Consume(
 		r.ordersQueue, // queue
@@ -95,16 +236,20 @@ func (r *RabbitMQBroker) ConsumeOrderUpdates(ctx context.Context, handler OrderU
 					return
 				}
 
-				if err := r.processOrderUpdateMessage(msg, handler); err != nil {
-					log.Printf("RabbitMQ: Error processing order update message: %v", err)
-					if nackErr := msg.Nack(false, false); nackErr != nil {
-						log.Printf("RabbitMQ: Error nacking order update message: %v", nackErr)
-					}
+				// Mensagens não confirmadas voltam para a fila quando o canal é fechado
+				if !r.inFlight.begin() {
+					return
+				}
+				msgCtx := rabbitMQMessageContext(ctx, msg)
+				if err := r.processOrderUpdateMessage(msgCtx, msg, handler); err != nil {
+					correlation.Logf(msgCtx, "RabbitMQ: Error processing order update message: %v", err)
+					r.handleFailedDelivery(r.ordersQueue, msg, err)
 				} else {
 					if ackErr := msg.Ack(false); ackErr != nil {
 						log.Printf("RabbitMQ: Error acknowledging order update message: %v", ackErr)
 					}
 				}
+				r.inFlight.end()
 			}
 		}
 	}()
@@ -112,39 +257,231 @@ func (r *RabbitMQBroker) ConsumeOrderUpdates(ctx context.Context, handler OrderU
 	return nil
 }
 
-func (r *RabbitMQBroker) processOrderUpdateMessage(msg amqp.Delivery, handler OrderUpdateHandler) error {
+func (r *RabbitMQBroker) processOrderUpdateMessage(ctx context.Context, msg amqp.Delivery, handler OrderUpdateHandler) error {
 	var updateMsg OrderUpdateMessage
 	if err := json.Unmarshal(msg.Body, &updateMsg); err != nil {
-		return fmt.Errorf("failed to unmarshal order update message: %w", err)
+		return &MalformedMessageError{Err: fmt.Errorf("failed to unmarshal order update message: %w", err)}
+	}
+	if updateMsg.MessageID == "" {
+		updateMsg.MessageID = msg.MessageId
 	}
 
-	log.Printf("RabbitMQ: Processing order update for order %s", updateMsg.OrderID)
+	correlation.Logf(ctx, "RabbitMQ: Processing order update for order %s", updateMsg.OrderID)
 
-	return handler(updateMsg)
+	return handler(ctx, updateMsg)
 }
 
-func (r *RabbitMQBroker) shouldDiscardMessage(err error) bool {
-	errorMsg := err.Error()
+func (r *RabbitMQBroker) ConsumePaymentConfirmations(ctx context.Context, handler PaymentConfirmationHandler) error {
+	if r.paymentsQueue == "" {
+		return fmt.Errorf("RabbitMQ payments queue is not configured")
+	}
 
-	nonRecoverableErrors := []string{
-		"Order not found",
-		"Invalid order ID",
-		"Invalid payment confirmation",
-		"order ID is required",
-		"payment ID is required",
-		"payment status is required",
+	msgs, err := r.channel.Consume(
+		r.paymentsQueue, // queue
+		"",              // consumer
+		false,           // auto-ack
+		false,           // exclusive
+		false,           // no-local
+		false,           // no-wait
+		nil,             // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register payment confirmations consumer: %w", err)
 	}
 
-	for _, nonRecoverableError := range nonRecoverableErrors {
-		if strings.Contains(errorMsg, nonRecoverableError) {
-			return true
+	log.Printf("RabbitMQ: Consuming payment confirmations from queue: %s", r.paymentsQueue)
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				log.Println("RabbitMQ: Stopping payment confirmations consumer")
+				return
+			case msg, ok := <-msgs:
+				if !ok {
+					log.Println("RabbitMQ: Payment confirmations channel closed")
+					return
+				}
+
+				if !r.inFlight.begin() {
+					return
+				}
+				msgCtx := rabbitMQMessageContext(ctx, msg)
+				if err := r.processPaymentConfirmationMessage(msgCtx, msg, handler); err != nil {
+					correlation.Logf(msgCtx, "RabbitMQ: Error processing payment confirmation message: %v", err)
+					r.handleFailedDelivery(r.paymentsQueue, msg, err)
+				} else {
+					if ackErr := msg.Ack(false); ackErr != nil {
+						log.Printf("RabbitMQ: Error acknowledging payment confirmation message: %v", ackErr)
+					}
+				}
+				r.inFlight.end()
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (r *RabbitMQBroker) processPaymentConfirmationMessage(ctx context.Context, msg amqp.Delivery, handler PaymentConfirmationHandler) error {
+	var paymentMsg PaymentConfirmationMessage
+	if err := json.Unmarshal(msg.Body, &paymentMsg); err != nil {
+		return &MalformedMessageError{Err: fmt.Errorf("failed to unmarshal payment confirmation message: %w", err)}
+	}
+
+	correlation.Logf(ctx, "RabbitMQ: Processing payment confirmation for order %s", paymentMsg.OrderID)
+
+	return handler(ctx, paymentMsg)
+}
+
+func (r *RabbitMQBroker) PublishOrderEvent(ctx context.Context, event OrderEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal order event: %w", err)
+	}
+
+	// Pedidos para a cozinha vão direto para a fila dela pela exchange padrão
+	exchange, routingKey := r.orderEventsExchange, event.Type
+	if event.Type == KITCHEN_ORDER_REQUESTED_EVENT {
+		if r.kitchenQueue == "" {
+			return fmt.Errorf("RabbitMQ kitchen queue is not configured")
+		}
+		exchange, routingKey = "", r.kitchenQueue
+	}
+
+	headers := amqp.Table{
+		"event_version": int32(event.Version),
+		"order_id":      event.OrderID,
+	}
+	if event.CorrelationID != "" {
+		headers[correlation.REQUEST_ID_ATTRIBUTE] = event.CorrelationID
+	}
+
+	err = r.publish(ctx, exchange, routingKey, amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		MessageId:    event.ID,
+		Type:         event.Type,
+		Timestamp:    event.OccurredAt,
+		Headers:      headers,
+		Body:         body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish order event %s: %w", event.Type, err)
+	}
+
+	correlation.Logf(correlation.WithID(ctx, event.CorrelationID), "RabbitMQ: Published %s event for order %s", event.Type, event.OrderID)
+	return nil
+}
+
+// publish só retorna nil depois que o broker confirma a mensagem
+func (r *RabbitMQBroker) publish(ctx context.Context, exchange, routingKey string, msg amqp.Publishing) error {
+	r.publishMu.Lock()
+	defer r.publishMu.Unlock()
+
+	if err := r.channel.Publish(exchange, routingKey, false, false, msg); err != nil {
+		return err
+	}
+	r.deliveryTag++
+
+	timer := time.NewTimer(PUBLISH_CONFIRM_TIMEOUT)
+	defer timer.Stop()
+
+	for {
+		select {
+		case confirmation, ok := <-r.confirms:
+			if !ok {
+				return fmt.Errorf("channel closed before the broker confirmed the message")
+			}
+			// Confirmações atrasadas de publicações que já expiraram são descartadas
+			if confirmation.DeliveryTag < r.deliveryTag {
+				continue
+			}
+			if !confirmation.Ack {
+				return fmt.Errorf("broker rejected the message")
+			}
+			return nil
+		case <-timer.C:
+			return fmt.Errorf("timed out waiting for the broker to confirm the message")
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Falhas transitórias voltam com backoff exponencial; permanentes ou esgotadas vão para a DLX
+func (r *RabbitMQBroker) handleFailedDelivery(queue string, msg amqp.Delivery, handlerErr error) {
+	attempt := deliveryAttempt(msg)
+
+	headers := amqp.Table{}
+	for key, value := range msg.Headers {
+		headers[key] = value
+	}
+	headers[RETRY_COUNT_HEADER] = int32(attempt)
+	headers[LAST_ERROR_HEADER] = handlerErr.Error()
+
+	publishing := amqp.Publishing{
+		Headers:      headers,
+		ContentType:  msg.ContentType,
+		DeliveryMode: amqp.Persistent,
+		MessageId:    msg.MessageId,
+		Timestamp:    msg.Timestamp,
+		Type:         msg.Type,
+		Body:         msg.Body,
+	}
+
+	exchange, routingKey := r.deadLetterExchange, queue
+	retry := r.retryPolicy.ShouldRetry(handlerErr, attempt)
+	if retry {
+		// A mensagem expira na fila de retentativa e volta para a fila original
+		exchange, routingKey = "", retryQueueName(queue)
+		publishing.Expiration = strconv.FormatInt(r.retryPolicy.Backoff(attempt).Milliseconds(), 10)
+	}
+
+	if err := r.publish(context.Background(), exchange, routingKey, publishing); err != nil {
+		log.Printf("RabbitMQ: Error rerouting failed message from %s: %v", queue, err)
+		if nackErr := msg.Nack(false, true); nackErr != nil {
+			log.Printf("RabbitMQ: Error nacking failed message: %v", nackErr)
 		}
+		return
+	}
+
+	if retry {
+		log.Printf("RabbitMQ: Message from %s will be retried in %s (attempt %d of %d)",
+			queue, r.retryPolicy.Backoff(attempt), attempt, r.retryPolicy.MaxAttempts)
+	} else {
+		log.Printf("RabbitMQ: Message from %s moved to dead letter queue after %d attempt(s): %v", queue, attempt, handlerErr)
+	}
+
+	if ackErr := msg.Ack(false); ackErr != nil {
+		log.Printf("RabbitMQ: Error acknowledging failed message: %v", ackErr)
 	}
+}
 
-	return false
+// Usa o request_id da mensagem ou gera um novo; o processamento não é cancelado junto com o consumidor
+func rabbitMQMessageContext(ctx context.Context, msg amqp.Delivery) context.Context {
+	received, _ := msg.Headers[correlation.REQUEST_ID_ATTRIBUTE].(string)
+	msgCtx, _ := correlation.Ensure(context.WithoutCancel(ctx), received)
+	return msgCtx
 }
 
+// Começa em 1
+func deliveryAttempt(msg amqp.Delivery) int {
+	switch count := msg.Headers[RETRY_COUNT_HEADER].(type) {
+	case int32:
+		return int(count) + 1
+	case int64:
+		return int(count) + 1
+	case int:
+		return count + 1
+	}
+	return 1
+}
+
+// Cancele o contexto de consumo antes, para que nenhuma mensagem nova seja recebida
 func (r *RabbitMQBroker) Close() error {
+	r.inFlight.closeAndWait()
+
 	if r.channel != nil {
 		r.channel.Close()
 	}
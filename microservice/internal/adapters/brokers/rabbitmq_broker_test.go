@@ -8,6 +8,8 @@ import (
 
 	"github.com/streadway/amqp"
 	"github.com/stretchr/testify/assert"
+
+	"microservice/utils/correlation"
 )
 
 func TestNewRabbitMQBroker_ValidConfig(t *testing.T) {
@@ -78,37 +80,6 @@ func TestBrokerConfig_Structure(t *testing.T) {
 	assert.Equal(t, "orders-queue", config.RabbitMQOrdersQueue)
 }
 
-func TestRabbitMQBroker_shouldDiscardMessage(t *testing.T) {
-	broker := &RabbitMQBroker{}
-
-	// Test recoverable errors (should not discard)
-	recoverableErrors := []string{
-		"network error",
-		"temporary failure",
-		"connection lost",
-	}
-
-	for _, errMsg := range recoverableErrors {
-		err := &mockError{message: errMsg}
-		assert.False(t, broker.shouldDiscardMessage(err))
-	}
-
-	// Test non-recoverable errors (should discard)
-	nonRecoverableErrors := []string{
-		"Order not found",
-		"Invalid order ID",
-		"Invalid payment confirmation",
-		"order ID is required",
-		"payment ID is required",
-		"payment status is required",
-	}
-
-	for _, errMsg := range nonRecoverableErrors {
-		err := &mockError{message: errMsg}
-		assert.True(t, broker.shouldDiscardMessage(err))
-	}
-}
-
 func TestRabbitMQBroker_Close_NilChannel(t *testing.T) {
 	broker := &RabbitMQBroker{
 		channel: nil,
@@ -133,43 +104,6 @@ func TestRabbitMQBroker_Structure(t *testing.T) {
 	assert.Equal(t, "orders.updates", broker.ordersQueue)
 }
 
-func TestRabbitMQBroker_shouldDiscardMessage_RecoverableErrors(t *testing.T) {
-	broker := &RabbitMQBroker{}
-
-	recoverableErrors := []string{
-		"connection refused",
-		"timeout",
-		"network unreachable",
-		"temporary failure",
-	}
-
-	for _, errMsg := range recoverableErrors {
-		t.Run(errMsg, func(t *testing.T) {
-			err := &mockError{message: errMsg}
-			assert.False(t, broker.shouldDiscardMessage(err))
-		})
-	}
-}
-
-func TestRabbitMQBroker_shouldDiscardMessage_NonRecoverableErrors(t *testing.T) {
-	broker := &RabbitMQBroker{}
-
-	nonRecoverableErrors := []string{
-		"Order not found",
-		"Invalid order ID",
-		"Invalid payment confirmation",
-		"order ID is required",
-		"payment ID is required",
-		"payment status is required",
-	}
-
-	for _, errMsg := range nonRecoverableErrors {
-		t.Run(errMsg, func(t *testing.T) {
-			err := &mockError{message: errMsg}
-			assert.True(t, broker.shouldDiscardMessage(err))
-		})
-	}
-}
 func TestRabbitMQBroker_ConsumeOrderUpdates_Integration(t *testing.T) {
 	config := BrokerConfig{
 		RabbitMQURL:         "amqp://localhost:5672",
@@ -185,7 +119,7 @@ func TestRabbitMQBroker_ConsumeOrderUpdates_Integration(t *testing.T) {
 
 	// Test ConsumeOrderUpdates method
 	ctx := context.Background()
-	handler := func(message OrderUpdateMessage) error {
+	handler := func(ctx context.Context, message OrderUpdateMessage) error {
 		return nil
 	}
 
@@ -211,18 +145,45 @@ func TestRabbitMQBroker_processOrderUpdateMessage_ValidJSON(t *testing.T) {
 	}
 	
 	handlerCalled := false
-	handler := func(message OrderUpdateMessage) error {
+	handler := func(ctx context.Context, message OrderUpdateMessage) error {
 		handlerCalled = true
 		assert.Equal(t, "order-123", message.OrderID)
 		assert.Equal(t, "Em preparação", message.Status)
 		return nil
 	}
 
-	err := broker.processOrderUpdateMessage(mockDelivery, handler)
+	err := broker.processOrderUpdateMessage(context.Background(), mockDelivery, handler)
 	assert.NoError(t, err)
 	assert.True(t, handlerCalled)
 }
 
+func TestRabbitMQBroker_processOrderUpdateMessage_UsesDeliveryMessageID(t *testing.T) {
+	broker := &RabbitMQBroker{}
+
+	mockDelivery := amqp.Delivery{
+		MessageId: "delivery-1",
+		Body:      []byte(`{"order_id": "order-123", "status": "Pronto"}`),
+	}
+
+	var received OrderUpdateMessage
+	err := broker.processOrderUpdateMessage(context.Background(), mockDelivery, func(ctx context.Context, message OrderUpdateMessage) error {
+		received = message
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "delivery-1", received.MessageID)
+
+	mockDelivery.Body = []byte(`{"message_id": "body-1", "order_id": "order-123", "status": "Pronto"}`)
+	err = broker.processOrderUpdateMessage(context.Background(), mockDelivery, func(ctx context.Context, message OrderUpdateMessage) error {
+		received = message
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "body-1", received.MessageID)
+}
+
 func TestRabbitMQBroker_processOrderUpdateMessage_InvalidJSON(t *testing.T) {
 	broker := &RabbitMQBroker{}
 
@@ -232,12 +193,12 @@ func TestRabbitMQBroker_processOrderUpdateMessage_InvalidJSON(t *testing.T) {
 		Body: []byte(invalidJSON),
 	}
 	
-	handler := func(message OrderUpdateMessage) error {
+	handler := func(ctx context.Context, message OrderUpdateMessage) error {
 		t.Error("Handler should not be called for invalid JSON")
 		return nil
 	}
 
-	err := broker.processOrderUpdateMessage(mockDelivery, handler)
+	err := broker.processOrderUpdateMessage(context.Background(), mockDelivery, handler)
 	assert.Error(t, err)
 }
 
@@ -251,11 +212,11 @@ func TestRabbitMQBroker_processOrderUpdateMessage_HandlerError(t *testing.T) {
 	}
 	
 	expectedError := errors.New("handler error")
-	handler := func(message OrderUpdateMessage) error {
+	handler := func(ctx context.Context, message OrderUpdateMessage) error {
 		return expectedError
 	}
 
-	err := broker.processOrderUpdateMessage(mockDelivery, handler)
+	err := broker.processOrderUpdateMessage(context.Background(), mockDelivery, handler)
 	assert.Error(t, err)
 	assert.Equal(t, expectedError, err)
 }
@@ -295,7 +256,7 @@ func TestRabbitMQBroker_ConsumeOrderUpdates_ContextCancellation(t *testing.T) {
 	// Test context cancellation
 	ctx, cancel := context.WithCancel(context.Background())
 	
-	handler := func(message OrderUpdateMessage) error {
+	handler := func(ctx context.Context, message OrderUpdateMessage) error {
 		return nil
 	}
 
@@ -351,3 +312,247 @@ func TestRabbitMQBroker_Close_WithValidChannel(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+
+type publishedMessage struct {
+	exchange   string
+	routingKey string
+	msg        amqp.Publishing
+}
+
+type fakeRabbitMQChannel struct {
+	published  []publishedMessage
+	publishErr error
+	declared   map[string]amqp.Table
+	bindings   map[string]string
+	confirms   chan amqp.Confirmation
+	nack       bool
+	noConfirm  bool
+}
+
+func newFakeRabbitMQChannel() *fakeRabbitMQChannel {
+	return &fakeRabbitMQChannel{
+		declared: make(map[string]amqp.Table),
+		bindings: make(map[string]string),
+	}
+}
+
+func (c *fakeRabbitMQChannel) QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error) {
+	c.declared[name] = args
+	return amqp.Queue{Name: name}, nil
+}
+
+func (c *fakeRabbitMQChannel) QueueBind(name, key, exchange string, noWait bool, args amqp.Table) error {
+	c.bindings[name] = exchange + ":" + key
+	return nil
+}
+
+func (c *fakeRabbitMQChannel) ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error {
+	return nil
+}
+
+func (c *fakeRabbitMQChannel) Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error) {
+	return make(chan amqp.Delivery), nil
+}
+
+func (c *fakeRabbitMQChannel) Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	if c.publishErr != nil {
+		return c.publishErr
+	}
+	c.published = append(c.published, publishedMessage{exchange: exchange, routingKey: key, msg: msg})
+	if c.noConfirm {
+		close(c.confirms)
+		return nil
+	}
+	c.confirms <- amqp.Confirmation{DeliveryTag: uint64(len(c.published)), Ack: !c.nack}
+	return nil
+}
+
+func (c *fakeRabbitMQChannel) Confirm(noWait bool) error {
+	return nil
+}
+
+func (c *fakeRabbitMQChannel) NotifyPublish(confirm chan amqp.Confirmation) chan amqp.Confirmation {
+	c.confirms = confirm
+	return confirm
+}
+
+func (c *fakeRabbitMQChannel) Close() error {
+	return nil
+}
+
+type fakeAcknowledger struct {
+	acked   bool
+	nacked  bool
+	requeue bool
+}
+
+func (a *fakeAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.acked = true
+	return nil
+}
+
+func (a *fakeAcknowledger) Nack(tag uint64, multiple bool, requeue bool) error {
+	a.nacked = true
+	a.requeue = requeue
+	return nil
+}
+
+func (a *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+func newTestRabbitMQBroker(channel *fakeRabbitMQChannel) *RabbitMQBroker {
+	broker := &RabbitMQBroker{
+		channel:            channel,
+		ordersQueue:        "orders.updates",
+		paymentsQueue:      "orders.payments",
+		deadLetterExchange: DEFAULT_DEAD_LETTER_EXCHANGE,
+		retryPolicy:        NewRetryPolicy(3, time.Second, time.Minute),
+	}
+	broker.enableConfirms()
+	return broker
+}
+
+func TestRabbitMQBroker_declareQueues_DeclaresRetryAndDeadLetterQueues(t *testing.T) {
+	channel := newFakeRabbitMQChannel()
+	broker := newTestRabbitMQBroker(channel)
+	broker.orderEventsExchange = DEFAULT_ORDER_EVENTS_EXCHANGE
+
+	err := broker.declareQueues()
+
+	assert.NoError(t, err)
+	assert.Contains(t, channel.declared, "orders.updates.dlq")
+	assert.Contains(t, channel.declared, "orders.payments.dlq")
+	assert.Equal(t, "orders.dlx:orders.updates", channel.bindings["orders.updates.dlq"])
+	assert.Equal(t, "orders.updates", channel.declared["orders.updates.retry"]["x-dead-letter-routing-key"])
+	assert.Equal(t, "", channel.declared["orders.updates.retry"]["x-dead-letter-exchange"])
+}
+
+func TestRabbitMQBroker_handleFailedDelivery_RetriesTransientError(t *testing.T) {
+	channel := newFakeRabbitMQChannel()
+	broker := newTestRabbitMQBroker(channel)
+	ack := &fakeAcknowledger{}
+	msg := amqp.Delivery{Acknowledger: ack, Body: []byte(`{}`), Headers: amqp.Table{RETRY_COUNT_HEADER: int32(1)}}
+
+	broker.handleFailedDelivery("orders.updates", msg, &mockError{message: "connection refused"})
+
+	assert.True(t, ack.acked)
+	assert.Len(t, channel.published, 1)
+	assert.Equal(t, "", channel.published[0].exchange)
+	assert.Equal(t, "orders.updates.retry", channel.published[0].routingKey)
+	assert.Equal(t, "2000", channel.published[0].msg.Expiration)
+	assert.Equal(t, int32(2), channel.published[0].msg.Headers[RETRY_COUNT_HEADER])
+}
+
+func TestRabbitMQBroker_handleFailedDelivery_DeadLettersPermanentError(t *testing.T) {
+	channel := newFakeRabbitMQChannel()
+	broker := newTestRabbitMQBroker(channel)
+	ack := &fakeAcknowledger{}
+	msg := amqp.Delivery{Acknowledger: ack, Body: []byte(`invalid`)}
+
+	broker.handleFailedDelivery("orders.updates", msg, &MalformedMessageError{Err: errors.New("invalid json")})
+
+	assert.True(t, ack.acked)
+	assert.Len(t, channel.published, 1)
+	assert.Equal(t, DEFAULT_DEAD_LETTER_EXCHANGE, channel.published[0].exchange)
+	assert.Equal(t, "orders.updates", channel.published[0].routingKey)
+	assert.Empty(t, channel.published[0].msg.Expiration)
+	assert.Equal(t, "invalid json", channel.published[0].msg.Headers[LAST_ERROR_HEADER])
+}
+
+func TestRabbitMQBroker_handleFailedDelivery_DeadLettersAfterMaxAttempts(t *testing.T) {
+	channel := newFakeRabbitMQChannel()
+	broker := newTestRabbitMQBroker(channel)
+	ack := &fakeAcknowledger{}
+	msg := amqp.Delivery{Acknowledger: ack, Headers: amqp.Table{RETRY_COUNT_HEADER: int32(2)}}
+
+	broker.handleFailedDelivery("orders.payments", msg, &mockError{message: "timeout"})
+
+	assert.Len(t, channel.published, 1)
+	assert.Equal(t, DEFAULT_DEAD_LETTER_EXCHANGE, channel.published[0].exchange)
+	assert.Equal(t, "orders.payments", channel.published[0].routingKey)
+	assert.Equal(t, int32(3), channel.published[0].msg.Headers[RETRY_COUNT_HEADER])
+}
+
+func TestRabbitMQBroker_handleFailedDelivery_RequeuesWhenPublishFails(t *testing.T) {
+	channel := newFakeRabbitMQChannel()
+	channel.publishErr = errors.New("channel closed")
+	broker := newTestRabbitMQBroker(channel)
+	ack := &fakeAcknowledger{}
+	msg := amqp.Delivery{Acknowledger: ack}
+
+	broker.handleFailedDelivery("orders.updates", msg, &mockError{message: "timeout"})
+
+	assert.False(t, ack.acked)
+	assert.True(t, ack.nacked)
+	assert.True(t, ack.requeue)
+}
+
+func TestRabbitMQBroker_processOrderUpdateMessage_InvalidJSONIsPermanent(t *testing.T) {
+	broker := &RabbitMQBroker{}
+
+	err := broker.processOrderUpdateMessage(context.Background(), amqp.Delivery{Body: []byte(`invalid`)}, func(ctx context.Context, message OrderUpdateMessage) error {
+		return nil
+	})
+
+	assert.True(t, IsPermanentError(err))
+}
+
+func TestRabbitMQBroker_PublishOrderEvent_CarriesRequestID(t *testing.T) {
+	channel := newFakeRabbitMQChannel()
+	broker := newTestRabbitMQBroker(channel)
+	broker.orderEventsExchange = DEFAULT_ORDER_EVENTS_EXCHANGE
+
+	err := broker.PublishOrderEvent(context.Background(), OrderEvent{ID: "event-1", Type: ORDER_CREATED_EVENT, OrderID: "order-1", CorrelationID: "req-1"})
+
+	assert.NoError(t, err)
+	assert.Len(t, channel.published, 1)
+	assert.Equal(t, "req-1", channel.published[0].msg.Headers[correlation.REQUEST_ID_ATTRIBUTE])
+	assert.Equal(t, "order-1", channel.published[0].msg.Headers["order_id"])
+}
+
+func TestRabbitMQBroker_PublishOrderEvent_FailsWhenBrokerNacks(t *testing.T) {
+	channel := newFakeRabbitMQChannel()
+	channel.nack = true
+	broker := newTestRabbitMQBroker(channel)
+
+	err := broker.PublishOrderEvent(context.Background(), OrderEvent{ID: "event-1", Type: ORDER_CREATED_EVENT, OrderID: "order-1"})
+
+	assert.Error(t, err)
+}
+
+func TestRabbitMQBroker_PublishOrderEvent_FailsWhenChannelClosesBeforeConfirm(t *testing.T) {
+	channel := newFakeRabbitMQChannel()
+	channel.noConfirm = true
+	broker := newTestRabbitMQBroker(channel)
+
+	err := broker.PublishOrderEvent(context.Background(), OrderEvent{ID: "event-1", Type: ORDER_CREATED_EVENT, OrderID: "order-1"})
+
+	assert.Error(t, err)
+}
+
+func TestRabbitMQBroker_handleFailedDelivery_RequeuesWhenBrokerNacks(t *testing.T) {
+	channel := newFakeRabbitMQChannel()
+	channel.nack = true
+	broker := newTestRabbitMQBroker(channel)
+	ack := &fakeAcknowledger{}
+	msg := amqp.Delivery{Acknowledger: ack}
+
+	broker.handleFailedDelivery("orders.updates", msg, &mockError{message: "timeout"})
+
+	assert.False(t, ack.acked)
+	assert.True(t, ack.nacked)
+	assert.True(t, ack.requeue)
+}
+
+func TestRabbitMQMessageContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	msgCtx := rabbitMQMessageContext(ctx, amqp.Delivery{Headers: amqp.Table{correlation.REQUEST_ID_ATTRIBUTE: "req-kitchen-1"}})
+	assert.Equal(t, "req-kitchen-1", correlation.ID(msgCtx))
+	assert.NoError(t, msgCtx.Err(), "handling must not be cancelled with the consumer")
+
+	generated := rabbitMQMessageContext(context.Background(), amqp.Delivery{})
+	assert.NotEmpty(t, correlation.ID(generated))
+}
@@ -0,0 +1,97 @@
+package brokers
+
+import (
+	"errors"
+	"time"
+
+	"microservice/internal/domain/exceptions"
+)
+
+const (
+	DEFAULT_MAX_DELIVERY_ATTEMPTS = 5
+	DEFAULT_RETRY_INITIAL_BACKOFF = 5 * time.Second
+	DEFAULT_RETRY_MAX_BACKOFF     = 5 * time.Minute
+)
+
+// Decide se uma mensagem que falhou é reentregue ou vai para a dead-letter
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// Mensagens que nunca poderão ser processadas, como um JSON inválido
+type MalformedMessageError struct {
+	Err error
+}
+
+func (e *MalformedMessageError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *MalformedMessageError) Unwrap() error {
+	return e.Err
+}
+
+func NewRetryPolicy(maxAttempts int, initialBackoff time.Duration, maxBackoff time.Duration) RetryPolicy {
+	if maxAttempts <= 0 {
+		maxAttempts = DEFAULT_MAX_DELIVERY_ATTEMPTS
+	}
+	if initialBackoff <= 0 {
+		initialBackoff = DEFAULT_RETRY_INITIAL_BACKOFF
+	}
+	if maxBackoff < initialBackoff {
+		maxBackoff = initialBackoff
+	}
+
+	return RetryPolicy{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: initialBackoff,
+		MaxBackoff:     maxBackoff,
+	}
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return NewRetryPolicy(DEFAULT_MAX_DELIVERY_ATTEMPTS, DEFAULT_RETRY_INITIAL_BACKOFF, DEFAULT_RETRY_MAX_BACKOFF)
+}
+
+// attempt começa em 1
+func (p RetryPolicy) ShouldRetry(err error, attempt int) bool {
+	if IsPermanentError(err) {
+		return false
+	}
+	return attempt < p.MaxAttempts
+}
+
+// Dobra a cada falha
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	return delay
+}
+
+// Falhas que não passam numa nova tentativa; as demais (banco, rede) são transitórias
+func IsPermanentError(err error) bool {
+	var malformed *MalformedMessageError
+	var orderNotFound *exceptions.OrderNotFoundException
+	var statusNotFound *exceptions.OrderStatusNotFoundException
+	var invalidOrder *exceptions.InvalidOrderDataException
+	var invalidItem *exceptions.InvalidOrderItemData
+	var invalidAmount *exceptions.AmountNotValidException
+	var invalidTransition *exceptions.InvalidStatusTransitionException
+	var invalidPayment *exceptions.InvalidPaymentConfirmationException
+
+	return errors.As(err, &malformed) ||
+		errors.As(err, &orderNotFound) ||
+		errors.As(err, &statusNotFound) ||
+		errors.As(err, &invalidOrder) ||
+		errors.As(err, &invalidItem) ||
+		errors.As(err, &invalidAmount) ||
+		errors.As(err, &invalidTransition) ||
+		errors.As(err, &invalidPayment)
+}
@@ -0,0 +1,71 @@
+package brokers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"microservice/internal/domain/exceptions"
+)
+
+func TestNewRetryPolicy_Defaults(t *testing.T) {
+	policy := NewRetryPolicy(0, 0, 0)
+
+	assert.Equal(t, DEFAULT_MAX_DELIVERY_ATTEMPTS, policy.MaxAttempts)
+	assert.Equal(t, DEFAULT_RETRY_INITIAL_BACKOFF, policy.InitialBackoff)
+	assert.Equal(t, DEFAULT_RETRY_INITIAL_BACKOFF, policy.MaxBackoff)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := NewRetryPolicy(10, time.Second, 10*time.Second)
+
+	assert.Equal(t, time.Second, policy.Backoff(1))
+	assert.Equal(t, 2*time.Second, policy.Backoff(2))
+	assert.Equal(t, 4*time.Second, policy.Backoff(3))
+	assert.Equal(t, 8*time.Second, policy.Backoff(4))
+	assert.Equal(t, 10*time.Second, policy.Backoff(5))
+	assert.Equal(t, 10*time.Second, policy.Backoff(50))
+}
+
+func TestRetryPolicy_ShouldRetry(t *testing.T) {
+	policy := NewRetryPolicy(3, time.Second, time.Minute)
+	transient := errors.New("connection reset by peer")
+
+	assert.True(t, policy.ShouldRetry(transient, 1))
+	assert.True(t, policy.ShouldRetry(transient, 2))
+	assert.False(t, policy.ShouldRetry(transient, 3), "attempts exhausted")
+	assert.False(t, policy.ShouldRetry(&exceptions.OrderNotFoundException{}, 1), "permanent errors are not retried")
+}
+
+func TestIsPermanentError(t *testing.T) {
+	var syntaxErr *json.SyntaxError
+	_ = json.Unmarshal([]byte("{"), &struct{}{})
+
+	tests := []struct {
+		name      string
+		err       error
+		permanent bool
+	}{
+		{"malformed message", &MalformedMessageError{Err: fmt.Errorf("bad json: %w", syntaxErr)}, true},
+		{"order not found", &exceptions.OrderNotFoundException{}, true},
+		{"wrapped order not found", fmt.Errorf("failed to find order: %w", &exceptions.OrderNotFoundException{}), true},
+		{"status not found", &exceptions.OrderStatusNotFoundException{}, true},
+		{"invalid transition", &exceptions.InvalidStatusTransitionException{}, true},
+		{"invalid order data", &exceptions.InvalidOrderDataException{}, true},
+		{"invalid payment confirmation", &exceptions.InvalidPaymentConfirmationException{Message: "payment ID is required"}, true},
+		{"database error", errors.New("connection refused"), false},
+		{"wrapped database error", fmt.Errorf("failed to update order: %w", errors.New("deadlock detected")), false},
+		// Antes a classificação era por texto; mensagens parecidas não bastam mais
+		{"untyped not found text", errors.New("Order not found"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.permanent, IsPermanentError(tt.err))
+		})
+	}
+}
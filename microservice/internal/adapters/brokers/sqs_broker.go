@@ -5,16 +5,37 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+
+	"microservice/utils/correlation"
 )
 
+// Maior visibility timeout aceito pelo SQS
+const SQS_MAX_VISIBILITY_TIMEOUT = 12 * time.Hour
+
+type sqsAPI interface {
+	ReceiveMessage(ctx context.Context, params *sqs.ReceiveMessageInput, optFns ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error)
+	DeleteMessage(ctx context.Context, params *sqs.DeleteMessageInput, optFns ...func(*sqs.Options)) (*sqs.DeleteMessageOutput, error)
+	SendMessage(ctx context.Context, params *sqs.SendMessageInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageOutput, error)
+	ChangeMessageVisibility(ctx context.Context, params *sqs.ChangeMessageVisibilityInput, optFns ...func(*sqs.Options)) (*sqs.ChangeMessageVisibilityOutput, error)
+}
+
 type SQSBroker struct {
-	client         *sqs.Client
-	ordersQueueURL string
+	client              sqsAPI
+	ordersQueueURL      string
+	paymentsQueueURL    string
+	kitchenQueueURL     string
+	orderEventsQueueURL string
+	deadLetterQueueURL  string
+	retryPolicy         RetryPolicy
+	inFlight            inFlightTracker
 }
 
 func NewSQSBroker(brokerConfig BrokerConfig) (*SQSBroker, error) {
@@ -29,9 +50,28 @@ func NewSQSBroker(brokerConfig BrokerConfig) (*SQSBroker, error) {
 
 	log.Printf("SQS: Configured with orders queue: %s", brokerConfig.SQSOrdersQueueURL)
 
+	if brokerConfig.SQSOrderEventsQueueURL != "" {
+		log.Printf("SQS: Publishing order events to queue: %s", brokerConfig.SQSOrderEventsQueueURL)
+	} else {
+		log.Println("SQS: Order events queue not configured, order events will stay pending in the outbox")
+	}
+	if brokerConfig.SQSDeadLetterQueueURL == "" {
+		log.Println("SQS: Dead letter queue is not configured, failed messages will be discarded")
+	}
+
+	retryPolicy := brokerConfig.RetryPolicy
+	if retryPolicy.MaxAttempts == 0 {
+		retryPolicy = DefaultRetryPolicy()
+	}
+
 	return &SQSBroker{
-		client:         sqs.NewFromConfig(cfg),
-		ordersQueueURL: brokerConfig.SQSOrdersQueueURL,
+		client:              sqs.NewFromConfig(cfg),
+		ordersQueueURL:      brokerConfig.SQSOrdersQueueURL,
+		paymentsQueueURL:    brokerConfig.SQSPaymentsQueueURL,
+		kitchenQueueURL:     brokerConfig.SQSKitchenQueueURL,
+		orderEventsQueueURL: brokerConfig.SQSOrderEventsQueueURL,
+		deadLetterQueueURL:  brokerConfig.SQSDeadLetterQueueURL,
+		retryPolicy:         retryPolicy,
 	}, nil
 }
 
@@ -47,7 +87,7 @@ func (s *SQSBroker) ConsumeOrderUpdates(ctx context.Context, handler OrderUpdate
 			default:
 				if err := s.pollOrderUpdateMessages(ctx, handler); err != nil {
 					log.Printf("SQS: Error polling order update messages: %v", err)
-					time.Sleep(5 * time.Second)
+					waitBeforeRetry(ctx, 5*time.Second)
 				}
 			}
 		}
@@ -58,40 +98,59 @@ func (s *SQSBroker) ConsumeOrderUpdates(ctx context.Context, handler OrderUpdate
 
 func (s *SQSBroker) pollOrderUpdateMessages(ctx context.Context, handler OrderUpdateHandler) error {
 	input := &sqs.ReceiveMessageInput{
-		QueueUrl:              &s.ordersQueueURL,
-		MaxNumberOfMessages:   10,
-		WaitTimeSeconds:       10, // Long polling
-		MessageAttributeNames: []string{"All"},
+		QueueUrl:                    &s.ordersQueueURL,
+		MaxNumberOfMessages:         10,
+		WaitTimeSeconds:             10, // Long polling
+		MessageAttributeNames:       []string{"All"},
+		MessageSystemAttributeNames: []types.MessageSystemAttributeName{types.MessageSystemAttributeNameApproximateReceiveCount},
 	}
 
 	result, err := s.client.ReceiveMessage(ctx, input)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
 		return fmt.Errorf("failed to receive order update messages: %w", err)
 	}
 
 	for _, message := range result.Messages {
-		if err := s.processOrderUpdateMessage(ctx, message, handler); err != nil {
-			log.Printf("SQS: Error processing order update message: %v", err)
-			continue
-		}
-
-		if err := s.deleteOrderUpdateMessage(ctx, message); err != nil {
-			log.Printf("SQS: Error deleting order update message: %v", err)
+		// Mensagens ainda não iniciadas voltam para a fila após o visibility timeout
+		if ctx.Err() != nil || !s.inFlight.begin() {
+			return nil
 		}
+		s.handleOrderUpdateMessage(context.WithoutCancel(ctx), message, handler)
+		s.inFlight.end()
 	}
 
 	return nil
 }
 
+func (s *SQSBroker) handleOrderUpdateMessage(ctx context.Context, message types.Message, handler OrderUpdateHandler) {
+	ctx = sqsMessageContext(ctx, message)
+
+	if err := s.processOrderUpdateMessage(ctx, message, handler); err != nil {
+		correlation.Logf(ctx, "SQS: Error processing order update message: %v", err)
+		s.handleFailedMessage(ctx, s.ordersQueueURL, message, err)
+		return
+	}
+
+	if err := s.deleteOrderUpdateMessage(ctx, message); err != nil {
+		correlation.Logf(ctx, "SQS: Error deleting order update message: %v", err)
+	}
+}
+
 func (s *SQSBroker) processOrderUpdateMessage(ctx context.Context, message types.Message, handler OrderUpdateHandler) error {
 	var updateMsg OrderUpdateMessage
 	if err := json.Unmarshal([]byte(*message.Body), &updateMsg); err != nil {
-		return fmt.Errorf("failed to unmarshal order update message: %w", err)
+		return &MalformedMessageError{Err: fmt.Errorf("failed to unmarshal order update message: %w", err)}
+	}
+	if updateMsg.MessageID == "" && message.MessageId != nil {
+		updateMsg.MessageID = *message.MessageId
 	}
 
-	log.Printf("SQS: Processing order update for order %s", updateMsg.OrderID)
+	correlation.Logf(ctx, "SQS: Processing order update for order %s", updateMsg.OrderID)
 
-	return handler(updateMsg)
+	return handler(ctx, updateMsg)
 }
 
 func (s *SQSBroker) deleteOrderUpdateMessage(ctx context.Context, message types.Message) error {
@@ -104,6 +163,250 @@ func (s *SQSBroker) deleteOrderUpdateMessage(ctx context.Context, message types.
 	return err
 }
 
+func (s *SQSBroker) ConsumePaymentConfirmations(ctx context.Context, handler PaymentConfirmationHandler) error {
+	if s.paymentsQueueURL == "" {
+		return fmt.Errorf("SQS payments queue URL is not configured")
+	}
+
+	log.Printf("SQS: Starting payment confirmations consumer on payments queue: %s", s.paymentsQueueURL)
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				log.Println("SQS: Stopping payment confirmations consumer")
+				return
+			default:
+				if err := s.pollPaymentConfirmationMessages(ctx, handler); err != nil {
+					log.Printf("SQS: Error polling payment confirmation messages: %v", err)
+					waitBeforeRetry(ctx, 5*time.Second)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (s *SQSBroker) pollPaymentConfirmationMessages(ctx context.Context, handler PaymentConfirmationHandler) error {
+	input := &sqs.ReceiveMessageInput{
+		QueueUrl:                    &s.paymentsQueueURL,
+		MaxNumberOfMessages:         10,
+		WaitTimeSeconds:             10, // Long polling
+		MessageAttributeNames:       []string{"All"},
+		MessageSystemAttributeNames: []types.MessageSystemAttributeName{types.MessageSystemAttributeNameApproximateReceiveCount},
+	}
+
+	result, err := s.client.ReceiveMessage(ctx, input)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return fmt.Errorf("failed to receive payment confirmation messages: %w", err)
+	}
+
+	for _, message := range result.Messages {
+		if ctx.Err() != nil || !s.inFlight.begin() {
+			return nil
+		}
+		s.handlePaymentConfirmationMessage(context.WithoutCancel(ctx), message, handler)
+		s.inFlight.end()
+	}
+
+	return nil
+}
+
+func (s *SQSBroker) handlePaymentConfirmationMessage(ctx context.Context, message types.Message, handler PaymentConfirmationHandler) {
+	ctx = sqsMessageContext(ctx, message)
+
+	if err := s.processPaymentConfirmationMessage(ctx, message, handler); err != nil {
+		correlation.Logf(ctx, "SQS: Error processing payment confirmation message: %v", err)
+		s.handleFailedMessage(ctx, s.paymentsQueueURL, message, err)
+		return
+	}
+
+	_, err := s.client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      &s.paymentsQueueURL,
+		ReceiptHandle: message.ReceiptHandle,
+	})
+	if err != nil {
+		correlation.Logf(ctx, "SQS: Error deleting payment confirmation message: %v", err)
+	}
+}
+
+func (s *SQSBroker) processPaymentConfirmationMessage(ctx context.Context, message types.Message, handler PaymentConfirmationHandler) error {
+	var paymentMsg PaymentConfirmationMessage
+	if err := json.Unmarshal([]byte(*message.Body), &paymentMsg); err != nil {
+		return &MalformedMessageError{Err: fmt.Errorf("failed to unmarshal payment confirmation message: %w", err)}
+	}
+
+	correlation.Logf(ctx, "SQS: Processing payment confirmation for order %s", paymentMsg.OrderID)
+
+	return handler(ctx, paymentMsg)
+}
+
+// Sem fila configurada o evento falha e fica no outbox até ir para dead letter
+func (s *SQSBroker) PublishOrderEvent(ctx context.Context, event OrderEvent) error {
+	queueURL := s.orderEventsQueueURL
+	if event.Type == KITCHEN_ORDER_REQUESTED_EVENT {
+		queueURL = s.kitchenQueueURL
+	}
+
+	if queueURL == "" {
+		return fmt.Errorf("SQS queue URL for %s events is not configured", event.Type)
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal order event: %w", err)
+	}
+
+	input := &sqs.SendMessageInput{
+		QueueUrl:    &queueURL,
+		MessageBody: aws.String(string(body)),
+		MessageAttributes: map[string]types.MessageAttributeValue{
+			"event_type": {
+				DataType:    aws.String("String"),
+				StringValue: aws.String(event.Type),
+			},
+			"event_version": {
+				DataType:    aws.String("Number"),
+				StringValue: aws.String(strconv.Itoa(event.Version)),
+			},
+		},
+	}
+
+	if event.CorrelationID != "" {
+		input.MessageAttributes[correlation.REQUEST_ID_ATTRIBUTE] = types.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String(event.CorrelationID),
+		}
+	}
+
+	// Filas FIFO mantêm a sequência dos eventos de um mesmo pedido
+	if strings.HasSuffix(queueURL, ".fifo") {
+		input.MessageGroupId = aws.String(event.OrderID)
+		input.MessageDeduplicationId = aws.String(event.ID)
+	}
+
+	if _, err := s.client.SendMessage(ctx, input); err != nil {
+		return fmt.Errorf("failed to publish order event %s: %w", event.Type, err)
+	}
+
+	correlation.Logf(correlation.WithID(ctx, event.CorrelationID), "SQS: Published %s event for order %s", event.Type, event.OrderID)
+	return nil
+}
+
+// Falhas transitórias voltam com backoff exponencial; permanentes ou esgotadas vão para a DLQ
+func (s *SQSBroker) handleFailedMessage(ctx context.Context, queueURL string, message types.Message, handlerErr error) {
+	attempt := sqsReceiveCount(message)
+
+	if s.retryPolicy.ShouldRetry(handlerErr, attempt) {
+		delay := s.retryPolicy.Backoff(attempt)
+		if delay > SQS_MAX_VISIBILITY_TIMEOUT {
+			delay = SQS_MAX_VISIBILITY_TIMEOUT
+		}
+
+		_, err := s.client.ChangeMessageVisibility(ctx, &sqs.ChangeMessageVisibilityInput{
+			QueueUrl:          &queueURL,
+			ReceiptHandle:     message.ReceiptHandle,
+			VisibilityTimeout: int32(delay.Seconds()),
+		})
+		if err != nil {
+			log.Printf("SQS: Error delaying retry of message %s: %v", aws.ToString(message.MessageId), err)
+			return
+		}
+
+		log.Printf("SQS: Message %s will be retried in %s (attempt %d of %d)",
+			aws.ToString(message.MessageId), delay, attempt, s.retryPolicy.MaxAttempts)
+		return
+	}
+
+	if err := s.deadLetter(ctx, queueURL, message, handlerErr, attempt); err != nil {
+		// Mantém a mensagem na fila para não perdê-la
+		log.Printf("SQS: Error dead-lettering message %s: %v", aws.ToString(message.MessageId), err)
+		return
+	}
+
+	_, err := s.client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      &queueURL,
+		ReceiptHandle: message.ReceiptHandle,
+	})
+	if err != nil {
+		log.Printf("SQS: Error deleting dead-lettered message %s: %v", aws.ToString(message.MessageId), err)
+	}
+}
+
+func (s *SQSBroker) deadLetter(ctx context.Context, queueURL string, message types.Message, handlerErr error, attempt int) error {
+	if s.deadLetterQueueURL == "" {
+		log.Printf("SQS: Discarding message %s after %d attempt(s): %v", aws.ToString(message.MessageId), attempt, handlerErr)
+		return nil
+	}
+
+	input := &sqs.SendMessageInput{
+		QueueUrl:    &s.deadLetterQueueURL,
+		MessageBody: message.Body,
+		MessageAttributes: map[string]types.MessageAttributeValue{
+			"source_queue": {
+				DataType:    aws.String("String"),
+				StringValue: aws.String(queueURL),
+			},
+			"error": {
+				DataType:    aws.String("String"),
+				StringValue: aws.String(handlerErr.Error()),
+			},
+			"attempts": {
+				DataType:    aws.String("Number"),
+				StringValue: aws.String(strconv.Itoa(attempt)),
+			},
+		},
+	}
+	if requestID, ok := message.MessageAttributes[correlation.REQUEST_ID_ATTRIBUTE]; ok {
+		input.MessageAttributes[correlation.REQUEST_ID_ATTRIBUTE] = requestID
+	}
+	if strings.HasSuffix(s.deadLetterQueueURL, ".fifo") {
+		input.MessageGroupId = aws.String(queueURL)
+		input.MessageDeduplicationId = message.MessageId
+	}
+
+	if _, err := s.client.SendMessage(ctx, input); err != nil {
+		return err
+	}
+
+	log.Printf("SQS: Message %s moved to dead letter queue after %d attempt(s): %v", aws.ToString(message.MessageId), attempt, handlerErr)
+	return nil
+}
+
+// Usa o atributo request_id da mensagem ou gera um novo
+func sqsMessageContext(ctx context.Context, message types.Message) context.Context {
+	var received string
+	if attribute, ok := message.MessageAttributes[correlation.REQUEST_ID_ATTRIBUTE]; ok {
+		received = aws.ToString(attribute.StringValue)
+	}
+	ctx, _ = correlation.Ensure(ctx, received)
+	return ctx
+}
+
+func sqsReceiveCount(message types.Message) int {
+	count, err := strconv.Atoi(message.Attributes[string(types.MessageSystemAttributeNameApproximateReceiveCount)])
+	if err != nil || count < 1 {
+		return 1
+	}
+	return count
+}
+
+// Cancele o contexto de consumo antes, para que nenhuma mensagem nova seja recebida
 func (s *SQSBroker) Close() error {
+	s.inFlight.closeAndWait()
 	return nil
 }
+
+func waitBeforeRetry(ctx context.Context, delay time.Duration) {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
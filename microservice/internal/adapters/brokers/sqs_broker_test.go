@@ -7,8 +7,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
 	"github.com/stretchr/testify/assert"
+
+	"microservice/utils/correlation"
 )
 
 func TestNewSQSBroker_MissingOrdersQueueURL(t *testing.T) {
@@ -71,7 +75,7 @@ func TestSQSBroker_ConsumeOrderUpdates_ContextCancellation(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel() // Cancel immediately
 
-	handler := func(message OrderUpdateMessage) error {
+	handler := func(ctx context.Context, message OrderUpdateMessage) error {
 		return nil
 	}
 
@@ -100,7 +104,7 @@ func TestSQSBroker_processOrderUpdateMessage_ValidJSON(t *testing.T) {
 
 	handlerCalled := false
 	var receivedMessage OrderUpdateMessage
-	handler := func(msg OrderUpdateMessage) error {
+	handler := func(ctx context.Context, msg OrderUpdateMessage) error {
 		handlerCalled = true
 		receivedMessage = msg
 		return nil
@@ -113,6 +117,26 @@ func TestSQSBroker_processOrderUpdateMessage_ValidJSON(t *testing.T) {
 	assert.Equal(t, "Em preparação", receivedMessage.Status)
 }
 
+func TestSQSBroker_processOrderUpdateMessage_UsesSQSMessageID(t *testing.T) {
+	broker := &SQSBroker{}
+
+	messageID := "sqs-message-1"
+	messageBody := `{"order_id":"order-123","status":"Pronto"}`
+	message := types.Message{
+		MessageId: &messageID,
+		Body:      &messageBody,
+	}
+
+	var received OrderUpdateMessage
+	err := broker.processOrderUpdateMessage(context.Background(), message, func(ctx context.Context, msg OrderUpdateMessage) error {
+		received = msg
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "sqs-message-1", received.MessageID)
+}
+
 func TestSQSBroker_processOrderUpdateMessage_InvalidJSON(t *testing.T) {
 	config := BrokerConfig{
 		SQSOrdersQueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/orders-queue",
@@ -130,7 +154,7 @@ func TestSQSBroker_processOrderUpdateMessage_InvalidJSON(t *testing.T) {
 		Body: &messageBody,
 	}
 
-	handler := func(msg OrderUpdateMessage) error {
+	handler := func(ctx context.Context, msg OrderUpdateMessage) error {
 		return nil
 	}
 
@@ -156,7 +180,7 @@ func TestSQSBroker_processOrderUpdateMessage_HandlerError(t *testing.T) {
 		Body: &messageBody,
 	}
 
-	handler := func(msg OrderUpdateMessage) error {
+	handler := func(ctx context.Context, msg OrderUpdateMessage) error {
 		return errors.New("handler processing error")
 	}
 
@@ -195,7 +219,7 @@ func TestSQSBroker_processOrderUpdateMessage_DifferentStatuses(t *testing.T) {
 			}
 
 			var receivedMessage OrderUpdateMessage
-			handler := func(msg OrderUpdateMessage) error {
+			handler := func(ctx context.Context, msg OrderUpdateMessage) error {
 				receivedMessage = msg
 				return nil
 			}
@@ -231,7 +255,7 @@ func TestSQSBroker_pollOrderUpdateMessages_Coverage(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
 	defer cancel()
 
-	handler := func(message OrderUpdateMessage) error {
+	handler := func(ctx context.Context, message OrderUpdateMessage) error {
 		return nil
 	}
 
@@ -279,7 +303,7 @@ func TestSQSBroker_ConsumeOrderUpdates_LongRunning(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
 	defer cancel()
 
-	handler := func(message OrderUpdateMessage) error {
+	handler := func(ctx context.Context, message OrderUpdateMessage) error {
 		return nil
 	}
 
@@ -317,7 +341,7 @@ func TestSQSBroker_processOrderUpdateMessage_EmptyMessage(t *testing.T) {
 		Body: &emptyBody,
 	}
 	
-	handler := func(message OrderUpdateMessage) error {
+	handler := func(ctx context.Context, message OrderUpdateMessage) error {
 		t.Error("Handler should not be called for empty message")
 		return nil
 	}
@@ -364,7 +388,7 @@ func TestSQSBroker_processOrderUpdateMessage_MissingFields(t *testing.T) {
 				Body: &tc.message,
 			}
 			
-			handler := func(message OrderUpdateMessage) error {
+			handler := func(ctx context.Context, message OrderUpdateMessage) error {
 				// Allow handler to be called, but check if fields are empty
 				if message.OrderID == "" || message.Status == "" {
 					return errors.New("missing required fields")
@@ -408,7 +432,7 @@ func TestSQSBroker_processOrderUpdateMessage_AllStatuses(t *testing.T) {
 			}
 			
 			handlerCalled := false
-			handler := func(msg OrderUpdateMessage) error {
+			handler := func(ctx context.Context, msg OrderUpdateMessage) error {
 				handlerCalled = true
 				assert.Equal(t, "order-123", msg.OrderID)
 				assert.Equal(t, status, msg.Status)
@@ -486,3 +510,179 @@ func TestSQSBroker_Configuration_Validation(t *testing.T) {
 	}
 }
 
+
+func TestSQSBroker_PublishOrderEvent_WithoutOrderEventsQueueFails(t *testing.T) {
+	client := &fakeSQSClient{}
+	broker := &SQSBroker{client: client, kitchenQueueURL: "kitchen-url"}
+
+	err := broker.PublishOrderEvent(context.Background(), OrderEvent{ID: "event-1", Type: ORDER_CREATED_EVENT, OrderID: "order-1"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ORDER_CREATED_EVENT)
+	assert.Empty(t, client.sent)
+
+	err = broker.PublishOrderEvent(context.Background(), OrderEvent{ID: "event-2", Type: KITCHEN_ORDER_REQUESTED_EVENT, OrderID: "order-1"})
+	assert.NoError(t, err)
+	assert.Len(t, client.sent, 1)
+	assert.Equal(t, "kitchen-url", *client.sent[0].QueueUrl)
+}
+
+func TestSQSBroker_PublishOrderEvent_KitchenRequestNeedsKitchenQueue(t *testing.T) {
+	broker := &SQSBroker{orderEventsQueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/order-events"}
+
+	err := broker.PublishOrderEvent(context.Background(), OrderEvent{Type: KITCHEN_ORDER_REQUESTED_EVENT})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "kitchen.order_requested")
+}
+
+func TestSQSBroker_ConsumePaymentConfirmations_MissingQueueURL(t *testing.T) {
+	broker := &SQSBroker{}
+
+	err := broker.ConsumePaymentConfirmations(context.Background(), func(ctx context.Context, message PaymentConfirmationMessage) error { return nil })
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "SQS payments queue URL is not configured")
+}
+
+type fakeSQSClient struct {
+	sent              []*sqs.SendMessageInput
+	deleted           []*sqs.DeleteMessageInput
+	visibilityChanges []*sqs.ChangeMessageVisibilityInput
+	sendErr           error
+}
+
+func (c *fakeSQSClient) ReceiveMessage(ctx context.Context, params *sqs.ReceiveMessageInput, optFns ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error) {
+	return &sqs.ReceiveMessageOutput{}, nil
+}
+
+func (c *fakeSQSClient) DeleteMessage(ctx context.Context, params *sqs.DeleteMessageInput, optFns ...func(*sqs.Options)) (*sqs.DeleteMessageOutput, error) {
+	c.deleted = append(c.deleted, params)
+	return &sqs.DeleteMessageOutput{}, nil
+}
+
+func (c *fakeSQSClient) SendMessage(ctx context.Context, params *sqs.SendMessageInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageOutput, error) {
+	if c.sendErr != nil {
+		return nil, c.sendErr
+	}
+	c.sent = append(c.sent, params)
+	return &sqs.SendMessageOutput{}, nil
+}
+
+func (c *fakeSQSClient) ChangeMessageVisibility(ctx context.Context, params *sqs.ChangeMessageVisibilityInput, optFns ...func(*sqs.Options)) (*sqs.ChangeMessageVisibilityOutput, error) {
+	c.visibilityChanges = append(c.visibilityChanges, params)
+	return &sqs.ChangeMessageVisibilityOutput{}, nil
+}
+
+func newFailedSQSMessage(receiveCount string) types.Message {
+	return types.Message{
+		MessageId:     aws.String("message-1"),
+		ReceiptHandle: aws.String("receipt-1"),
+		Body:          aws.String(`{"order_id":"order-1"}`),
+		Attributes: map[string]string{
+			string(types.MessageSystemAttributeNameApproximateReceiveCount): receiveCount,
+		},
+	}
+}
+
+func TestSQSBroker_handleFailedMessage_DelaysTransientError(t *testing.T) {
+	client := &fakeSQSClient{}
+	broker := &SQSBroker{client: client, deadLetterQueueURL: "dlq-url", retryPolicy: NewRetryPolicy(3, 10*time.Second, time.Minute)}
+
+	broker.handleFailedMessage(context.Background(), "orders-url", newFailedSQSMessage("2"), errors.New("timeout"))
+
+	assert.Len(t, client.visibilityChanges, 1)
+	assert.Equal(t, int32(20), client.visibilityChanges[0].VisibilityTimeout)
+	assert.Empty(t, client.sent)
+	assert.Empty(t, client.deleted)
+}
+
+func TestSQSBroker_handleFailedMessage_DeadLettersPermanentError(t *testing.T) {
+	client := &fakeSQSClient{}
+	broker := &SQSBroker{client: client, deadLetterQueueURL: "dlq-url", retryPolicy: NewRetryPolicy(3, 10*time.Second, time.Minute)}
+
+	broker.handleFailedMessage(context.Background(), "orders-url", newFailedSQSMessage("1"), &MalformedMessageError{Err: errors.New("invalid json")})
+
+	assert.Empty(t, client.visibilityChanges)
+	assert.Len(t, client.sent, 1)
+	assert.Equal(t, "dlq-url", *client.sent[0].QueueUrl)
+	assert.Equal(t, `{"order_id":"order-1"}`, *client.sent[0].MessageBody)
+	assert.Equal(t, "orders-url", *client.sent[0].MessageAttributes["source_queue"].StringValue)
+	assert.Equal(t, "1", *client.sent[0].MessageAttributes["attempts"].StringValue)
+	assert.Nil(t, client.sent[0].MessageGroupId)
+	assert.Len(t, client.deleted, 1)
+}
+
+func TestSQSBroker_handleFailedMessage_DeadLettersAfterMaxAttempts(t *testing.T) {
+	client := &fakeSQSClient{}
+	broker := &SQSBroker{client: client, deadLetterQueueURL: "dlq-url.fifo", retryPolicy: NewRetryPolicy(3, 10*time.Second, time.Minute)}
+
+	broker.handleFailedMessage(context.Background(), "orders-url", newFailedSQSMessage("3"), errors.New("timeout"))
+
+	assert.Empty(t, client.visibilityChanges)
+	assert.Len(t, client.sent, 1)
+	assert.Equal(t, "orders-url", *client.sent[0].MessageGroupId)
+	assert.Len(t, client.deleted, 1)
+}
+
+func TestSQSBroker_handleFailedMessage_KeepsMessageWhenDeadLetterFails(t *testing.T) {
+	client := &fakeSQSClient{sendErr: errors.New("access denied")}
+	broker := &SQSBroker{client: client, deadLetterQueueURL: "dlq-url", retryPolicy: NewRetryPolicy(3, 10*time.Second, time.Minute)}
+
+	broker.handleFailedMessage(context.Background(), "orders-url", newFailedSQSMessage("3"), errors.New("timeout"))
+
+	assert.Empty(t, client.deleted)
+}
+
+func TestSQSBroker_handleFailedMessage_DiscardsWithoutDeadLetterQueue(t *testing.T) {
+	client := &fakeSQSClient{}
+	broker := &SQSBroker{client: client, retryPolicy: NewRetryPolicy(3, 10*time.Second, time.Minute)}
+
+	broker.handleFailedMessage(context.Background(), "orders-url", newFailedSQSMessage("1"), &MalformedMessageError{Err: errors.New("invalid json")})
+
+	assert.Empty(t, client.sent)
+	assert.Len(t, client.deleted, 1)
+}
+
+func TestSQSBroker_PublishOrderEvent_CarriesRequestID(t *testing.T) {
+	client := &fakeSQSClient{}
+	broker := &SQSBroker{client: client, orderEventsQueueURL: "events-url"}
+	event := OrderEvent{ID: "event-1", Type: ORDER_CREATED_EVENT, OrderID: "order-1", CorrelationID: "req-1"}
+
+	err := broker.PublishOrderEvent(context.Background(), event)
+
+	assert.NoError(t, err)
+	assert.Len(t, client.sent, 1)
+	assert.Equal(t, "req-1", *client.sent[0].MessageAttributes[correlation.REQUEST_ID_ATTRIBUTE].StringValue)
+}
+
+func TestSQSBroker_handleOrderUpdateMessage_PassesRequestID(t *testing.T) {
+	message := newFailedSQSMessage("1")
+	message.MessageAttributes = map[string]types.MessageAttributeValue{
+		correlation.REQUEST_ID_ATTRIBUTE: {DataType: aws.String("String"), StringValue: aws.String("req-kitchen-1")},
+	}
+
+	var received []string
+	handler := func(ctx context.Context, msg OrderUpdateMessage) error {
+		received = append(received, correlation.ID(ctx))
+		return nil
+	}
+	broker := &SQSBroker{client: &fakeSQSClient{}, ordersQueueURL: "orders-url"}
+
+	broker.handleOrderUpdateMessage(context.Background(), message, handler)
+	broker.handleOrderUpdateMessage(context.Background(), newFailedSQSMessage("1"), handler)
+
+	assert.Equal(t, "req-kitchen-1", received[0])
+	assert.NotEmpty(t, received[1], "messages without request_id get a new one")
+}
+
+func TestSQSBroker_handleFailedMessage_DeadLetterKeepsRequestID(t *testing.T) {
+	client := &fakeSQSClient{}
+	broker := &SQSBroker{client: client, deadLetterQueueURL: "dlq-url", retryPolicy: NewRetryPolicy(3, 10*time.Second, time.Minute)}
+	message := newFailedSQSMessage("1")
+	message.MessageAttributes = map[string]types.MessageAttributeValue{
+		correlation.REQUEST_ID_ATTRIBUTE: {DataType: aws.String("String"), StringValue: aws.String("req-1")},
+	}
+
+	broker.handleFailedMessage(context.Background(), "orders-url", message, &MalformedMessageError{Err: errors.New("invalid json")})
+
+	assert.Len(t, client.sent, 1)
+	assert.Equal(t, "req-1", *client.sent[0].MessageAttributes[correlation.REQUEST_ID_ATTRIBUTE].StringValue)
+}
@@ -3,23 +3,31 @@ package consumers
 import (
 	"context"
 	"log"
+	"time"
 
 	"microservice/internal/adapters/brokers"
+	"microservice/internal/adapters/dtos"
+	"microservice/internal/domain/entities"
 	"microservice/internal/use_cases"
 	"microservice/internal/interfaces"
+	"microservice/utils/correlation"
 )
 
+const ORDER_UPDATES_CONSUMER_NAME = "order_updates"
+
 type OrderUpdatesConsumer struct {
 	broker                   brokers.MessageBroker
 	updateOrderStatusUseCase *use_cases.UpdateOrderStatusUseCase
+	processedMessages        interfaces.IProcessedMessageDataSource
 }
 
-func NewOrderUpdatesConsumer(broker brokers.MessageBroker, orderGateway interfaces.IOrderGateway, orderStatusGateway interfaces.IOrderStatusGateway) *OrderUpdatesConsumer {
+func NewOrderUpdatesConsumer(broker brokers.MessageBroker, orderGateway interfaces.IOrderGateway, orderStatusGateway interfaces.IOrderStatusGateway, processedMessages interfaces.IProcessedMessageDataSource) *OrderUpdatesConsumer {
 	updateOrderStatusUseCase := use_cases.NewUpdateOrderStatusUseCase(orderGateway, orderStatusGateway)
 	
 	return &OrderUpdatesConsumer{
 		broker:                   broker,
 		updateOrderStatusUseCase: updateOrderStatusUseCase,
+		processedMessages:        processedMessages,
 	}
 }
 
@@ -29,22 +37,94 @@ func (c *OrderUpdatesConsumer) Start(ctx context.Context) error {
 	return c.broker.ConsumeOrderUpdates(ctx, c.processOrderUpdate)
 }
 
-func (c *OrderUpdatesConsumer) processOrderUpdate(message brokers.OrderUpdateMessage) error {
-	log.Printf("Processing order update for order %s: %s", message.OrderID, message.Status)
+func (c *OrderUpdatesConsumer) processOrderUpdate(ctx context.Context, message brokers.OrderUpdateMessage) error {
+	correlation.Logf(ctx, "Processing order update for order %s: %s", message.OrderID, message.Status)
+
+	skip, err := c.shouldSkip(ctx, message)
+	if err != nil {
+		correlation.Logf(ctx, "Error checking processed messages for order %s: %v", message.OrderID, err)
+		return err
+	}
+	if skip {
+		return nil
+	}
 
 	// Criar DTO para o use case
 	updateDTO := use_cases.UpdateOrderStatusDTO{
 		OrderID: message.OrderID,
 		Status:  message.Status,
+		Source:  entities.STATUS_CHANGE_SOURCE_KITCHEN,
+		// A mensagem é registrada junto com o pedido; uma reentrega concorrente falha e é descartada na próxima tentativa
+		ProcessedMessage: processedOrderUpdate(message),
 	}
 
 	// Executar a atualização do status
-	result, err := c.updateOrderStatusUseCase.Execute(updateDTO)
+	result, err := c.updateOrderStatusUseCase.Execute(ctx, updateDTO)
 	if err != nil {
-		log.Printf("Error updating order %s status: %v", message.OrderID, err)
+		correlation.Logf(ctx, "Error updating order %s status: %v", message.OrderID, err)
 		return err
 	}
 
-	log.Printf("Order %s status successfully updated to: %s", result.Order.ID, result.Order.Status.Name)
+	correlation.Logf(ctx, "Order %s status successfully updated to: %s", result.Order.ID, result.Order.Status.Name)
 	return nil
-}
\ No newline at end of file
+}
+
+// Descarta duplicadas e atualizações mais antigas que a última aplicada ao pedido
+func (c *OrderUpdatesConsumer) shouldSkip(ctx context.Context, message brokers.OrderUpdateMessage) (bool, error) {
+	if messageID := orderUpdateMessageID(message); messageID != "" {
+		processed, err := c.processedMessages.Exists(ctx, ORDER_UPDATES_CONSUMER_NAME, messageID)
+		if err != nil {
+			return false, err
+		}
+		if processed {
+			correlation.Logf(ctx, "Skipping duplicate order update %s for order %s", messageID, message.OrderID)
+			return true, nil
+		}
+	}
+
+	if message.UpdatedAt.IsZero() {
+		return false, nil
+	}
+
+	latest, err := c.processedMessages.FindLatestOccurredAt(ctx, ORDER_UPDATES_CONSUMER_NAME, message.OrderID)
+	if err != nil {
+		return false, err
+	}
+	if latest != nil && message.UpdatedAt.Before(*latest) {
+		correlation.Logf(ctx, "Skipping stale order update for order %s: %s is older than %s",
+			message.OrderID, message.UpdatedAt.Format(time.RFC3339Nano), latest.Format(time.RFC3339Nano))
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func processedOrderUpdate(message brokers.OrderUpdateMessage) *dtos.ProcessedMessageDTO {
+	messageID := orderUpdateMessageID(message)
+	if messageID == "" {
+		return nil
+	}
+
+	var occurredAt *time.Time
+	if !message.UpdatedAt.IsZero() {
+		updatedAt := message.UpdatedAt
+		occurredAt = &updatedAt
+	}
+
+	return &dtos.ProcessedMessageDTO{
+		Consumer:   ORDER_UPDATES_CONSUMER_NAME,
+		MessageID:  messageID,
+		OccurredAt: occurredAt,
+	}
+}
+
+// Sem ID do broker, usa pedido, status e horário
+func orderUpdateMessageID(message brokers.OrderUpdateMessage) string {
+	if message.MessageID != "" {
+		return message.MessageID
+	}
+	if message.UpdatedAt.IsZero() {
+		return ""
+	}
+	return message.OrderID + ":" + message.Status + ":" + message.UpdatedAt.UTC().Format(time.RFC3339Nano)
+}
@@ -3,11 +3,15 @@ package consumers
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"microservice/internal/adapters/brokers"
+	"microservice/internal/adapters/daos"
 	"microservice/internal/adapters/dtos"
 	"microservice/internal/domain/entities"
+	"microservice/internal/domain/value_objects"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -24,6 +28,14 @@ func (m *mockBroker) ConsumeOrderUpdates(ctx context.Context, handler brokers.Or
 	return nil
 }
 
+func (m *mockBroker) ConsumePaymentConfirmations(ctx context.Context, handler brokers.PaymentConfirmationHandler) error {
+	return nil
+}
+
+func (m *mockBroker) PublishOrderEvent(ctx context.Context, event brokers.OrderEvent) error {
+	return nil
+}
+
 func (m *mockBroker) Close() error {
 	return nil
 }
@@ -31,59 +43,152 @@ func (m *mockBroker) Close() error {
 type mockOrderGateway struct {
 	findByIDFunc func(id string) (*entities.Order, error)
 	updateFunc   func(order entities.Order) error
+	// processed recebe as mensagens gravadas junto com o pedido
+	processed *mockProcessedMessages
 }
 
-func (m *mockOrderGateway) FindByID(id string) (*entities.Order, error) {
+func (m *mockOrderGateway) FindByID(ctx context.Context, id string) (*entities.Order, error) {
 	if m.findByIDFunc != nil {
 		return m.findByIDFunc(id)
 	}
 	return nil, nil
 }
 
-func (m *mockOrderGateway) Update(order entities.Order) error {
+func (m *mockOrderGateway) Update(ctx context.Context, order entities.Order, events ...brokers.OrderEvent) error {
 	if m.updateFunc != nil {
 		return m.updateFunc(order)
 	}
 	return nil
 }
 
-func (m *mockOrderGateway) Create(order entities.Order) error {
+func (m *mockOrderGateway) UpdateFromMessage(ctx context.Context, order entities.Order, message dtos.ProcessedMessageDTO, events ...brokers.OrderEvent) error {
+	if err := m.Update(ctx, order, events...); err != nil {
+		return err
+	}
+	if m.processed != nil {
+		return m.processed.save(daos.ProcessedMessageDAO{
+			Consumer:    message.Consumer,
+			MessageID:   message.MessageID,
+			OrderID:     order.ID,
+			OccurredAt:  message.OccurredAt,
+			ProcessedAt: time.Now().UTC(),
+		})
+	}
 	return nil
 }
 
-func (m *mockOrderGateway) FindAll(filter dtos.OrderFilterDTO) ([]entities.Order, error) {
+func (m *mockOrderGateway) ApplyCoupon(ctx context.Context, order entities.Order, events ...brokers.OrderEvent) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockOrderGateway) Create(ctx context.Context, order entities.Order, events ...brokers.OrderEvent) error {
+	return nil
+}
+
+func (m *mockOrderGateway) FindAll(ctx context.Context, filter dtos.OrderFilterDTO) ([]entities.Order, error) {
 	return nil, nil
 }
 
-func (m *mockOrderGateway) Delete(id string) error {
+func (m *mockOrderGateway) Count(ctx context.Context, filter dtos.OrderFilterDTO) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockOrderGateway) Delete(ctx context.Context, id string, events ...brokers.OrderEvent) error {
+	return nil
+}
+
+func (m *mockOrderGateway) FindByPickupCode(ctx context.Context, day string, code value_objects.PickupCode) (*entities.Order, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockOrderGateway) FindBoard(ctx context.Context, statuses []entities.OrderStatus, createdFrom time.Time) ([]entities.OrderBoardEntry, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockOrderGateway) NextPickupCode(ctx context.Context, day string) (value_objects.PickupCode, error) {
+	return value_objects.PickupCode{}, errors.New("not implemented")
+}
+
+func (m *mockOrderGateway) Restore(ctx context.Context, id string, events ...brokers.OrderEvent) error {
+	return nil
+}
+
+func (m *mockOrderGateway) Claim(ctx context.Context, orderID string, customerID string, guestTokenHash string, claimedAt time.Time) error {
+	return nil
+}
+
+func (m *mockOrderGateway) ReissueGuestToken(ctx context.Context, orderID string, guestTokenHash string) error {
 	return nil
 }
 
+func (m *mockOrderGateway) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockOrderGateway) FindStatusHistory(ctx context.Context, orderID string) ([]entities.OrderStatusChange, error) {
+	return nil, nil
+}
+
 type mockOrderStatusGateway struct {
 	findByNameFunc func(name string) (*entities.OrderStatus, error)
 }
 
-func (m *mockOrderStatusGateway) FindByName(name string) (*entities.OrderStatus, error) {
+func (m *mockOrderStatusGateway) FindByName(ctx context.Context, name string) (*entities.OrderStatus, error) {
 	if m.findByNameFunc != nil {
 		return m.findByNameFunc(name)
 	}
 	return nil, nil
 }
 
-func (m *mockOrderStatusGateway) FindByID(id string) (*entities.OrderStatus, error) {
+func (m *mockOrderStatusGateway) FindByID(ctx context.Context, id string) (*entities.OrderStatus, error) {
 	return nil, nil
 }
 
-func (m *mockOrderStatusGateway) FindAll() ([]entities.OrderStatus, error) {
+func (m *mockOrderStatusGateway) FindAll(ctx context.Context) ([]entities.OrderStatus, error) {
 	return nil, nil
 }
 
+type mockProcessedMessages struct {
+	messages  map[string]daos.ProcessedMessageDAO
+	existsErr error
+}
+
+func newMockProcessedMessages() *mockProcessedMessages {
+	return &mockProcessedMessages{messages: make(map[string]daos.ProcessedMessageDAO)}
+}
+
+func (m *mockProcessedMessages) Exists(ctx context.Context, consumer string, messageID string) (bool, error) {
+	if m.existsErr != nil {
+		return false, m.existsErr
+	}
+	_, ok := m.messages[consumer+"/"+messageID]
+	return ok, nil
+}
+
+func (m *mockProcessedMessages) FindLatestOccurredAt(ctx context.Context, consumer string, orderID string) (*time.Time, error) {
+	var latest *time.Time
+	for _, message := range m.messages {
+		if message.Consumer != consumer || message.OrderID != orderID || message.OccurredAt == nil {
+			continue
+		}
+		if latest == nil || message.OccurredAt.After(*latest) {
+			latest = message.OccurredAt
+		}
+	}
+	return latest, nil
+}
+
+func (m *mockProcessedMessages) save(message daos.ProcessedMessageDAO) error {
+	m.messages[message.Consumer+"/"+message.MessageID] = message
+	return nil
+}
+
 func TestNewOrderUpdatesConsumer(t *testing.T) {
 	broker := &mockBroker{}
 	orderGateway := &mockOrderGateway{}
 	statusGateway := &mockOrderStatusGateway{}
 	
-	consumer := NewOrderUpdatesConsumer(broker, orderGateway, statusGateway)
+	consumer := NewOrderUpdatesConsumer(broker, orderGateway, statusGateway, newMockProcessedMessages())
 	
 	assert.NotNil(t, consumer)
 	assert.Equal(t, broker, consumer.broker)
@@ -100,7 +205,7 @@ func TestOrderUpdatesConsumer_Start_Success(t *testing.T) {
 	orderGateway := &mockOrderGateway{}
 	statusGateway := &mockOrderStatusGateway{}
 	
-	consumer := NewOrderUpdatesConsumer(broker, orderGateway, statusGateway)
+	consumer := NewOrderUpdatesConsumer(broker, orderGateway, statusGateway, newMockProcessedMessages())
 	
 	ctx := context.Background()
 	err := consumer.Start(ctx)
@@ -117,7 +222,7 @@ func TestOrderUpdatesConsumer_Start_BrokerError(t *testing.T) {
 	orderGateway := &mockOrderGateway{}
 	statusGateway := &mockOrderStatusGateway{}
 	
-	consumer := NewOrderUpdatesConsumer(broker, orderGateway, statusGateway)
+	consumer := NewOrderUpdatesConsumer(broker, orderGateway, statusGateway, newMockProcessedMessages())
 	
 	ctx := context.Background()
 	err := consumer.Start(ctx)
@@ -129,7 +234,7 @@ func TestOrderUpdatesConsumer_Start_BrokerError(t *testing.T) {
 func TestOrderUpdatesConsumer_processOrderUpdate_Success(t *testing.T) {
 	customerID := "customer-123"
 	order, _ := entities.NewOrder("order-123", &customerID)
-	oldStatus, _ := entities.NewOrderStatus("status-1", "Recebido")
+	oldStatus, _ := entities.NewOrderStatus("status-1", "Confirmado")
 	newStatus, _ := entities.NewOrderStatus("status-2", "Em preparação")
 	order.Status = *oldStatus
 
@@ -162,7 +267,7 @@ func TestOrderUpdatesConsumer_processOrderUpdate_Success(t *testing.T) {
 		},
 	}
 	
-	consumer := NewOrderUpdatesConsumer(broker, orderGateway, statusGateway)
+	consumer := NewOrderUpdatesConsumer(broker, orderGateway, statusGateway, newMockProcessedMessages())
 	
 	ctx := context.Background()
 	err := consumer.Start(ctx)
@@ -174,7 +279,7 @@ func TestOrderUpdatesConsumer_processOrderUpdate_Success(t *testing.T) {
 		Status:  "Em preparação",
 	}
 	
-	err = capturedHandler(message)
+	err = capturedHandler(context.Background(), message)
 	assert.NoError(t, err)
 }
 
@@ -195,7 +300,7 @@ func TestOrderUpdatesConsumer_processOrderUpdate_OrderNotFound(t *testing.T) {
 		},
 	}
 	
-	consumer := NewOrderUpdatesConsumer(broker, orderGateway, statusGateway)
+	consumer := NewOrderUpdatesConsumer(broker, orderGateway, statusGateway, newMockProcessedMessages())
 	
 	ctx := context.Background()
 	err := consumer.Start(ctx)
@@ -207,7 +312,7 @@ func TestOrderUpdatesConsumer_processOrderUpdate_OrderNotFound(t *testing.T) {
 		Status:  "Em preparação",
 	}
 	
-	err = capturedHandler(message)
+	err = capturedHandler(context.Background(), message)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to find order")
 }
@@ -238,7 +343,7 @@ func TestOrderUpdatesConsumer_processOrderUpdate_StatusNotFound(t *testing.T) {
 		},
 	}
 	
-	consumer := NewOrderUpdatesConsumer(broker, orderGateway, statusGateway)
+	consumer := NewOrderUpdatesConsumer(broker, orderGateway, statusGateway, newMockProcessedMessages())
 	
 	ctx := context.Background()
 	err := consumer.Start(ctx)
@@ -250,7 +355,7 @@ func TestOrderUpdatesConsumer_processOrderUpdate_StatusNotFound(t *testing.T) {
 		Status:  "Status Inexistente",
 	}
 	
-	err = capturedHandler(message)
+	err = capturedHandler(context.Background(), message)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to find order status")
 }
@@ -258,7 +363,7 @@ func TestOrderUpdatesConsumer_processOrderUpdate_StatusNotFound(t *testing.T) {
 func TestOrderUpdatesConsumer_processOrderUpdate_UpdateError(t *testing.T) {
 	customerID := "customer-123"
 	order, _ := entities.NewOrder("order-123", &customerID)
-	oldStatus, _ := entities.NewOrderStatus("status-1", "Recebido")
+	oldStatus, _ := entities.NewOrderStatus("status-1", "Confirmado")
 	newStatus, _ := entities.NewOrderStatus("status-2", "Em preparação")
 	order.Status = *oldStatus
 
@@ -285,7 +390,7 @@ func TestOrderUpdatesConsumer_processOrderUpdate_UpdateError(t *testing.T) {
 		},
 	}
 	
-	consumer := NewOrderUpdatesConsumer(broker, orderGateway, statusGateway)
+	consumer := NewOrderUpdatesConsumer(broker, orderGateway, statusGateway, newMockProcessedMessages())
 	
 	ctx := context.Background()
 	err := consumer.Start(ctx)
@@ -297,11 +402,176 @@ func TestOrderUpdatesConsumer_processOrderUpdate_UpdateError(t *testing.T) {
 		Status:  "Em preparação",
 	}
 	
-	err = capturedHandler(message)
+	err = capturedHandler(context.Background(), message)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to update order")
 }
 
+func newDedupTestConsumer(order *entities.Order, updates *int) *OrderUpdatesConsumer {
+	statuses := map[string]*entities.OrderStatus{}
+	for i, name := range []string{entities.ORDER_STATUS_CONFIRMED, entities.ORDER_STATUS_PREPARING, entities.ORDER_STATUS_READY, entities.ORDER_STATUS_DELIVERED} {
+		status, _ := entities.NewOrderStatus(fmt.Sprintf("status-%d", i+2), name)
+		statuses[name] = status
+	}
+
+	processed := newMockProcessedMessages()
+	orderGateway := &mockOrderGateway{
+		findByIDFunc: func(id string) (*entities.Order, error) {
+			current := *order
+			return &current, nil
+		},
+		updateFunc: func(o entities.Order) error {
+			*updates++
+			order.Status = o.Status
+			return nil
+		},
+		processed: processed,
+	}
+	statusGateway := &mockOrderStatusGateway{
+		findByNameFunc: func(name string) (*entities.OrderStatus, error) {
+			return statuses[name], nil
+		},
+	}
+
+	return NewOrderUpdatesConsumer(&mockBroker{}, orderGateway, statusGateway, processed)
+}
+
+func newConfirmedTestOrder() *entities.Order {
+	order, _ := entities.NewOrder("order-123", nil)
+	status, _ := entities.NewOrderStatus("status-2", entities.ORDER_STATUS_CONFIRMED)
+	order.Status = *status
+	return order
+}
+
+func TestOrderUpdatesConsumer_processOrderUpdate_SkipsDuplicateMessage(t *testing.T) {
+	order := newConfirmedTestOrder()
+	updates := 0
+	consumer := newDedupTestConsumer(order, &updates)
+
+	message := brokers.OrderUpdateMessage{
+		MessageID: "message-1",
+		OrderID:   "order-123",
+		Status:    entities.ORDER_STATUS_PREPARING,
+		UpdatedAt: time.Now(),
+	}
+
+	assert.NoError(t, consumer.processOrderUpdate(context.Background(), message))
+	assert.NoError(t, consumer.processOrderUpdate(context.Background(), message))
+
+	assert.Equal(t, 1, updates)
+	assert.Equal(t, entities.ORDER_STATUS_PREPARING, order.Status.Name.Value())
+}
+
+func TestOrderUpdatesConsumer_processOrderUpdate_RecordsMessageWithOrderUpdate(t *testing.T) {
+	order := newConfirmedTestOrder()
+	processed := newMockProcessedMessages()
+	var recorded bool
+	orderGateway := &mockOrderGateway{
+		findByIDFunc: func(id string) (*entities.Order, error) {
+			return order, nil
+		},
+		updateFunc: func(o entities.Order) error {
+			// A mensagem ainda não pode estar gravada antes da atualização do pedido
+			recorded = len(processed.messages) > 0
+			return nil
+		},
+		processed: processed,
+	}
+	consumer := NewOrderUpdatesConsumer(&mockBroker{}, orderGateway, &mockOrderStatusGateway{
+		findByNameFunc: func(name string) (*entities.OrderStatus, error) {
+			return entities.NewOrderStatus("status-3", name)
+		},
+	}, processed)
+	updatedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	err := consumer.processOrderUpdate(context.Background(), brokers.OrderUpdateMessage{
+		MessageID: "message-1",
+		OrderID:   "order-123",
+		Status:    entities.ORDER_STATUS_PREPARING,
+		UpdatedAt: updatedAt,
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, recorded)
+	message, ok := processed.messages[ORDER_UPDATES_CONSUMER_NAME+"/message-1"]
+	assert.True(t, ok, "the message must be recorded by the order update")
+	assert.Equal(t, "order-123", message.OrderID)
+	assert.True(t, updatedAt.Equal(*message.OccurredAt))
+}
+
+func TestOrderUpdatesConsumer_processOrderUpdate_IgnoresStaleUpdate(t *testing.T) {
+	order := newConfirmedTestOrder()
+	updates := 0
+	consumer := newDedupTestConsumer(order, &updates)
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	preparing := brokers.OrderUpdateMessage{MessageID: "message-1", OrderID: "order-123", Status: entities.ORDER_STATUS_PREPARING, UpdatedAt: base}
+	ready := brokers.OrderUpdateMessage{MessageID: "message-2", OrderID: "order-123", Status: entities.ORDER_STATUS_READY, UpdatedAt: base.Add(2 * time.Minute)}
+	late := brokers.OrderUpdateMessage{MessageID: "message-3", OrderID: "order-123", Status: entities.ORDER_STATUS_PREPARING, UpdatedAt: base.Add(time.Minute)}
+
+	assert.NoError(t, consumer.processOrderUpdate(context.Background(), preparing))
+	assert.NoError(t, consumer.processOrderUpdate(context.Background(), ready))
+	assert.NoError(t, consumer.processOrderUpdate(context.Background(), late))
+
+	assert.Equal(t, 2, updates)
+	assert.Equal(t, entities.ORDER_STATUS_READY, order.Status.Name.Value())
+}
+
+func TestOrderUpdatesConsumer_processOrderUpdate_DeduplicatesWithoutMessageID(t *testing.T) {
+	order := newConfirmedTestOrder()
+	updates := 0
+	consumer := newDedupTestConsumer(order, &updates)
+
+	message := brokers.OrderUpdateMessage{
+		OrderID:   "order-123",
+		Status:    entities.ORDER_STATUS_PREPARING,
+		UpdatedAt: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	assert.NoError(t, consumer.processOrderUpdate(context.Background(), message))
+	assert.NoError(t, consumer.processOrderUpdate(context.Background(), message))
+
+	assert.Equal(t, 1, updates)
+}
+
+func TestOrderUpdatesConsumer_processOrderUpdate_FailedUpdateIsNotRecorded(t *testing.T) {
+	order := newConfirmedTestOrder()
+	processed := newMockProcessedMessages()
+	consumer := NewOrderUpdatesConsumer(&mockBroker{}, &mockOrderGateway{
+		findByIDFunc: func(id string) (*entities.Order, error) {
+			return order, nil
+		},
+		updateFunc: func(o entities.Order) error {
+			return errors.New("database update failed")
+		},
+		processed: processed,
+	}, &mockOrderStatusGateway{
+		findByNameFunc: func(name string) (*entities.OrderStatus, error) {
+			return entities.NewOrderStatus("status-3", name)
+		},
+	}, processed)
+
+	err := consumer.processOrderUpdate(context.Background(), brokers.OrderUpdateMessage{
+		MessageID: "message-1",
+		OrderID:   "order-123",
+		Status:    entities.ORDER_STATUS_PREPARING,
+		UpdatedAt: time.Now(),
+	})
+
+	assert.Error(t, err)
+	assert.Empty(t, processed.messages, "a failed update must be retried on redelivery")
+}
+
+func TestOrderUpdatesConsumer_processOrderUpdate_StoreError(t *testing.T) {
+	processed := newMockProcessedMessages()
+	processed.existsErr = errors.New("database unavailable")
+	consumer := NewOrderUpdatesConsumer(&mockBroker{}, &mockOrderGateway{}, &mockOrderStatusGateway{}, processed)
+
+	err := consumer.processOrderUpdate(context.Background(), brokers.OrderUpdateMessage{MessageID: "message-1", OrderID: "order-123", Status: entities.ORDER_STATUS_PREPARING})
+
+	assert.Error(t, err)
+}
+
 func TestOrderUpdatesConsumer_Structure(t *testing.T) {
 	consumer := &OrderUpdatesConsumer{
 		broker:                   nil,
@@ -0,0 +1,60 @@
+package consumers
+
+import (
+	"context"
+	"log"
+
+	"microservice/internal/adapters/brokers"
+	"microservice/internal/use_cases"
+	"microservice/utils/correlation"
+)
+
+type PaymentConfirmationsConsumer struct {
+	broker                            brokers.MessageBroker
+	processPaymentConfirmationUseCase IProcessPaymentConfirmationUseCase
+}
+
+func NewPaymentConfirmationsConsumer(broker brokers.MessageBroker, processPaymentConfirmationUseCase IProcessPaymentConfirmationUseCase) *PaymentConfirmationsConsumer {
+	return &PaymentConfirmationsConsumer{
+		broker:                            broker,
+		processPaymentConfirmationUseCase: processPaymentConfirmationUseCase,
+	}
+}
+
+func (c *PaymentConfirmationsConsumer) Start(ctx context.Context) error {
+	log.Println("Starting payment confirmations consumer...")
+
+	return c.broker.ConsumePaymentConfirmations(ctx, c.processPaymentConfirmation)
+}
+
+func (c *PaymentConfirmationsConsumer) processPaymentConfirmation(ctx context.Context, message brokers.PaymentConfirmationMessage) error {
+	correlation.Logf(ctx, "Processing payment confirmation for order %s: %s", message.OrderID, message.Status)
+
+	// Criar DTO para o use case
+	confirmationDTO := use_cases.PaymentConfirmationDTO{
+		OrderID:       message.OrderID,
+		PaymentID:     message.PaymentID,
+		Status:        message.Status,
+		Amount:        message.Amount,
+		PaymentMethod: message.PaymentMethod,
+		ProcessedAt:   message.ProcessedAt,
+	}
+
+	result, err := c.processPaymentConfirmationUseCase.Execute(ctx, confirmationDTO)
+	if err != nil {
+		correlation.Logf(ctx, "Error processing payment confirmation for order %s: %v", message.OrderID, err)
+		return err
+	}
+
+	if !result.StatusChanged {
+		correlation.Logf(ctx, "Payment confirmation for order %s ignored: %s", message.OrderID, result.Message)
+		return nil
+	}
+
+	if result.ShouldNotifyKitchen {
+		correlation.Logf(ctx, "Order %s paid, kitchen request queued", result.Order.ID)
+	}
+
+	correlation.Logf(ctx, "Order %s status successfully updated to: %s", result.Order.ID, result.Order.Status.Name.Value())
+	return nil
+}
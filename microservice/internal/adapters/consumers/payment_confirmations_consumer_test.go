@@ -0,0 +1,80 @@
+package consumers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"microservice/internal/adapters/brokers"
+	"microservice/internal/domain/entities"
+	"microservice/internal/domain/value_objects"
+	"microservice/internal/use_cases"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockProcessPaymentConfirmationUseCase struct {
+	received use_cases.PaymentConfirmationDTO
+	result   *use_cases.PaymentConfirmationResult
+	err      error
+}
+
+func (m *mockProcessPaymentConfirmationUseCase) Execute(ctx context.Context, dto use_cases.PaymentConfirmationDTO) (*use_cases.PaymentConfirmationResult, error) {
+	m.received = dto
+	return m.result, m.err
+}
+
+func TestPaymentConfirmationsConsumer_Start(t *testing.T) {
+	broker := brokers.NewInMemoryBroker()
+	status, _ := entities.NewOrderStatus("paid", "Paid")
+	useCase := &mockProcessPaymentConfirmationUseCase{
+		result: &use_cases.PaymentConfirmationResult{
+			Order:               entities.Order{ID: "order-123", Status: *status},
+			StatusChanged:       true,
+			ShouldNotifyKitchen: true,
+		},
+	}
+
+	consumer := NewPaymentConfirmationsConsumer(broker, useCase)
+	assert.NoError(t, consumer.Start(context.Background()))
+
+	amount, _ := value_objects.ParseMoney("25.00", value_objects.DEFAULT_CURRENCY)
+	err := broker.DeliverPaymentConfirmation(context.Background(), brokers.PaymentConfirmationMessage{
+		OrderID:       "order-123",
+		PaymentID:     "payment-1",
+		Status:        "confirmed",
+		Amount:        amount,
+		PaymentMethod: "pix",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "order-123", useCase.received.OrderID)
+	assert.Equal(t, "payment-1", useCase.received.PaymentID)
+	assert.Equal(t, "confirmed", useCase.received.Status)
+	assert.Equal(t, amount, useCase.received.Amount)
+	assert.Equal(t, "pix", useCase.received.PaymentMethod)
+}
+
+func TestPaymentConfirmationsConsumer_UseCaseError(t *testing.T) {
+	broker := brokers.NewInMemoryBroker()
+	useCase := &mockProcessPaymentConfirmationUseCase{err: errors.New("order not found")}
+
+	consumer := NewPaymentConfirmationsConsumer(broker, useCase)
+	assert.NoError(t, consumer.Start(context.Background()))
+
+	err := broker.DeliverPaymentConfirmation(context.Background(), brokers.PaymentConfirmationMessage{OrderID: "order-123"})
+	assert.Error(t, err)
+}
+
+func TestPaymentConfirmationsConsumer_StatusUnchanged(t *testing.T) {
+	broker := brokers.NewInMemoryBroker()
+	useCase := &mockProcessPaymentConfirmationUseCase{
+		result: &use_cases.PaymentConfirmationResult{StatusChanged: false, Message: "already paid"},
+	}
+
+	consumer := NewPaymentConfirmationsConsumer(broker, useCase)
+	assert.NoError(t, consumer.Start(context.Background()))
+
+	err := broker.DeliverPaymentConfirmation(context.Background(), brokers.PaymentConfirmationMessage{OrderID: "order-123"})
+	assert.NoError(t, err)
+}
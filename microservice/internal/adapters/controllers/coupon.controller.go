@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	"context"
+
+	"microservice/internal/adapters/dtos"
+	"microservice/internal/adapters/gateways"
+	"microservice/internal/adapters/presenters"
+	"microservice/internal/interfaces"
+	"microservice/internal/use_cases"
+)
+
+type CouponController struct {
+	couponDataSource interfaces.ICouponDataSource
+	couponGateway    *gateways.CouponGateway
+}
+
+func NewCouponController(couponDataSource interfaces.ICouponDataSource) *CouponController {
+	return &CouponController{
+		couponDataSource: couponDataSource,
+		couponGateway:    gateways.NewCouponGateway(couponDataSource),
+	}
+}
+
+func (c *CouponController) Create(ctx context.Context, dto dtos.CreateCouponDTO) (dtos.CouponResponseDTO, error) {
+	useCase := use_cases.NewCreateCouponUseCase(c.couponGateway)
+	coupon, err := useCase.Execute(ctx, dto)
+	if err != nil {
+		return dtos.CouponResponseDTO{}, err
+	}
+	return presenters.ToCouponResponse(coupon), nil
+}
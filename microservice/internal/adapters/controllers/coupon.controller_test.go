@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"microservice/internal/adapters/daos"
+	"microservice/internal/adapters/dtos"
+	"microservice/internal/domain/exceptions"
+)
+
+func TestNewCouponController(t *testing.T) {
+	mockCouponDS := &MockCouponDataSource{}
+
+	controller := NewCouponController(mockCouponDS)
+
+	assert.NotNil(t, controller)
+	assert.Equal(t, mockCouponDS, controller.couponDataSource)
+	assert.NotNil(t, controller.couponGateway)
+}
+
+func TestCouponController_Create_Success(t *testing.T) {
+	mockCouponDS := &MockCouponDataSource{}
+	controller := NewCouponController(mockCouponDS)
+
+	mockCouponDS.On("Create", mock.MatchedBy(func(coupon daos.CouponDAO) bool {
+		return coupon.Code == "MENOS5" && coupon.FixedAmount == 500 && coupon.Currency == "BRL"
+	})).Return(nil)
+
+	result, err := controller.Create(context.Background(), dtos.CreateCouponDTO{Code: "menos5", Type: "fixed_amount", Amount: "5.00"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "MENOS5", result.Code)
+	assert.Equal(t, "5.00", *result.Amount)
+	mockCouponDS.AssertExpectations(t)
+}
+
+func TestCouponController_Create_Error(t *testing.T) {
+	mockCouponDS := &MockCouponDataSource{}
+	controller := NewCouponController(mockCouponDS)
+
+	mockCouponDS.On("Create", mock.Anything).Return(&exceptions.CouponAlreadyExistsException{})
+
+	result, err := controller.Create(context.Background(), dtos.CreateCouponDTO{Code: "PROMO10", Type: "percentage", Percentage: 10})
+
+	assert.IsType(t, &exceptions.CouponAlreadyExistsException{}, err)
+	assert.Equal(t, dtos.CouponResponseDTO{}, result)
+}
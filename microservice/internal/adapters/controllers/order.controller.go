@@ -1,10 +1,13 @@
 package controllers
 
 import (
-	"microservice/internal/adapters/brokers"
+	"context"
+	"time"
+
 	"microservice/internal/adapters/dtos"
 	"microservice/internal/adapters/gateways"
 	"microservice/internal/adapters/presenters"
+	"microservice/internal/domain/entities"
 	"microservice/internal/interfaces"
 	"microservice/internal/use_cases"
 )
@@ -14,60 +17,124 @@ type OrderController struct {
 	orderStatusDataSource interfaces.IOrderStatusDataSource
 	orderGateway          *gateways.OrderGateway
 	orderStatusGateway    *gateways.OrderStatusGateway
-	messageBroker         brokers.MessageBroker
+	productCatalogGateway *gateways.ProductCatalogGateway
+	couponGateway         *gateways.CouponGateway
+	pickupLocation        *time.Location
 }
 
-func NewOrderController(orderDataSource interfaces.IOrderDataSource, orderStatusDataSource interfaces.IOrderStatusDataSource, messageBroker brokers.MessageBroker) *OrderController {
+func NewOrderController(orderDataSource interfaces.IOrderDataSource, orderStatusDataSource interfaces.IOrderStatusDataSource, productCatalogDataSource interfaces.IProductCatalogDataSource, couponDataSource interfaces.ICouponDataSource, pickupLocation *time.Location) *OrderController {
 	return &OrderController{
 		orderDataSource:       orderDataSource,
 		orderStatusDataSource: orderStatusDataSource,
 		orderGateway:          gateways.NewOrderGateway(orderDataSource),
 		orderStatusGateway:    gateways.NewOrderStatusGateway(orderStatusDataSource),
-		messageBroker:         messageBroker,
+		productCatalogGateway: gateways.NewProductCatalogGateway(productCatalogDataSource),
+		couponGateway:         gateways.NewCouponGateway(couponDataSource),
+		pickupLocation:        pickupLocation,
 	}
 }
 
-func (c *OrderController) Create(dto dtos.CreateOrderDTO) (dtos.OrderResponseDTO, error) {
-	useCase := use_cases.NewCreateOrderUseCase(c.orderGateway, c.orderStatusGateway, c.messageBroker)
-	order, err := useCase.Execute(dto.CustomerID, dto.Items)
+func (c *OrderController) Create(ctx context.Context, dto dtos.CreateOrderDTO) (dtos.OrderResponseDTO, error) {
+	useCase := use_cases.NewCreateOrderUseCase(c.orderGateway, c.orderStatusGateway, c.productCatalogGateway, c.pickupLocation)
+	order, err := useCase.Execute(ctx, dto)
 	if err != nil {
 		return dtos.OrderResponseDTO{}, err
 	}
 	return presenters.ToOrderResponse(order), nil
 }
 
-func (c *OrderController) FindAll(filter dtos.OrderFilterDTO) ([]dtos.OrderResponseDTO, error) {
+func (c *OrderController) FindAll(ctx context.Context, filter dtos.OrderFilterDTO) (dtos.OrderPageResponseDTO, error) {
 	useCase := use_cases.NewFindAllOrdersUseCase(c.orderGateway)
-	orders, err := useCase.Execute(filter)
+	page, err := useCase.Execute(ctx, filter)
 	if err != nil {
-		return nil, err
+		return dtos.OrderPageResponseDTO{}, err
 	}
-	return presenters.ToOrderResponseList(orders), nil
+	return dtos.OrderPageResponseDTO{
+		Orders:     presenters.ToOrderResponseList(page.Orders),
+		NextCursor: page.NextCursor,
+		Total:      page.Total,
+	}, nil
 }
 
-func (c *OrderController) FindByID(id string) (dtos.OrderResponseDTO, error) {
+func (c *OrderController) FindBoard(ctx context.Context, maxAge time.Duration) (dtos.OrderBoardResponseDTO, error) {
+	useCase := use_cases.NewFindOrderBoardUseCase(c.orderGateway, c.orderStatusGateway)
+	board, err := useCase.Execute(ctx, maxAge)
+	if err != nil {
+		return dtos.OrderBoardResponseDTO{}, err
+	}
+	return presenters.ToOrderBoardResponse(board), nil
+}
+
+func (c *OrderController) FindByID(ctx context.Context, id string) (dtos.OrderResponseDTO, error) {
 	useCase := use_cases.NewFindOrderByIDUseCase(c.orderGateway)
-	order, err := useCase.Execute(id)
+	order, err := useCase.Execute(ctx, id)
 	if err != nil {
 		return dtos.OrderResponseDTO{}, err
 	}
 	return presenters.ToOrderResponse(order), nil
 }
 
-func (c *OrderController) Update(dto dtos.UpdateOrderDTO) (dtos.OrderResponseDTO, error) {
+func (c *OrderController) Update(ctx context.Context, dto dtos.UpdateOrderDTO) (dtos.OrderResponseDTO, error) {
 	useCase := use_cases.NewUpdateOrderUseCase(c.orderGateway, c.orderStatusGateway)
-	order, err := useCase.Execute(dto)
+	order, err := useCase.Execute(ctx, dto)
+	if err != nil {
+		return dtos.OrderResponseDTO{}, err
+	}
+	return presenters.ToOrderResponse(order), nil
+}
+
+func (c *OrderController) FindByPickupCode(ctx context.Context, code string) (dtos.OrderResponseDTO, error) {
+	useCase := use_cases.NewFindOrderByPickupCodeUseCase(c.orderGateway, c.pickupLocation)
+	order, err := useCase.Execute(ctx, code)
+	if err != nil {
+		return dtos.OrderResponseDTO{}, err
+	}
+	return presenters.ToOrderResponse(order), nil
+}
+
+func (c *OrderController) ApplyCoupon(ctx context.Context, dto dtos.ApplyCouponDTO) (dtos.OrderResponseDTO, error) {
+	useCase := use_cases.NewApplyCouponUseCase(c.orderGateway, c.couponGateway)
+	order, err := useCase.Execute(ctx, dto)
+	if err != nil {
+		return dtos.OrderResponseDTO{}, err
+	}
+	return presenters.ToOrderResponse(order), nil
+}
+
+func (c *OrderController) FindGuestOrder(ctx context.Context, id string, guestToken string) (dtos.OrderResponseDTO, error) {
+	useCase := use_cases.NewFindGuestOrderUseCase(c.orderGateway)
+	order, err := useCase.Execute(ctx, id, guestToken)
+	if err != nil {
+		return dtos.OrderResponseDTO{}, err
+	}
+	return presenters.ToOrderResponse(order), nil
+}
+
+func (c *OrderController) Claim(ctx context.Context, dto dtos.ClaimOrderDTO) (dtos.OrderResponseDTO, error) {
+	useCase := use_cases.NewClaimOrderUseCase(c.orderGateway)
+	order, err := useCase.Execute(ctx, dto)
+	if err != nil {
+		return dtos.OrderResponseDTO{}, err
+	}
+	return presenters.ToOrderResponse(order), nil
+}
+
+func (c *OrderController) ReissueGuestToken(ctx context.Context, id string) (dtos.OrderResponseDTO, error) {
+	useCase := use_cases.NewReissueGuestTokenUseCase(c.orderGateway)
+	order, err := useCase.Execute(ctx, id)
 	if err != nil {
 		return dtos.OrderResponseDTO{}, err
 	}
 	return presenters.ToOrderResponse(order), nil
 }
 
-func (c *OrderController) UpdateStatus(dto dtos.UpdateOrderStatusDTO) (dtos.OrderResponseDTO, error) {
+func (c *OrderController) UpdateStatus(ctx context.Context, dto dtos.UpdateOrderStatusDTO) (dtos.OrderResponseDTO, error) {
 	useCase := use_cases.NewUpdateOrderStatusUseCase(c.orderGateway, c.orderStatusGateway)
-	result, err := useCase.Execute(use_cases.UpdateOrderStatusDTO{
+	result, err := useCase.Execute(ctx, use_cases.UpdateOrderStatusDTO{
 		OrderID: dto.OrderID,
 		Status:  dto.Status,
+		Source:  entities.STATUS_CHANGE_SOURCE_REST,
+		Actor:   dto.Actor,
 	})
 	if err != nil {
 		return dtos.OrderResponseDTO{}, err
@@ -75,14 +142,42 @@ func (c *OrderController) UpdateStatus(dto dtos.UpdateOrderStatusDTO) (dtos.Orde
 	return presenters.ToOrderResponse(result.Order), nil
 }
 
-func (c *OrderController) Delete(id string) error {
+func (c *OrderController) Cancel(ctx context.Context, dto dtos.CancelOrderDTO) (dtos.OrderResponseDTO, error) {
+	useCase := use_cases.NewCancelOrderUseCase(c.orderGateway, c.orderStatusGateway)
+	order, err := useCase.Execute(ctx, dto)
+	if err != nil {
+		return dtos.OrderResponseDTO{}, err
+	}
+	return presenters.ToOrderResponse(order), nil
+}
+
+func (c *OrderController) Delete(ctx context.Context, id string) error {
 	useCase := use_cases.NewDeleteOrderUseCase(c.orderGateway)
-	return useCase.Execute(id)
+	return useCase.Execute(ctx, id)
+}
+
+func (c *OrderController) Restore(ctx context.Context, id string) (dtos.OrderResponseDTO, error) {
+	useCase := use_cases.NewRestoreOrderUseCase(c.orderGateway)
+	order, err := useCase.Execute(ctx, id)
+	if err != nil {
+		return dtos.OrderResponseDTO{}, err
+	}
+	return presenters.ToOrderResponse(order), nil
+}
+
+func (c *OrderController) FindStatusHistory(ctx context.Context, id string) ([]dtos.OrderStatusHistoryResponseDTO, error) {
+	useCase := use_cases.NewFindOrderStatusHistoryUseCase(c.orderGateway)
+	history, err := useCase.Execute(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return presenters.ToOrderStatusHistoryResponseList(history), nil
 }
 
-func (c *OrderController) FindAllStatus() ([]dtos.OrderStatusResponseDTO, error) {
+func (c *OrderController) FindAllStatus(ctx context.Context) ([]dtos.OrderStatusResponseDTO, error) {
 	useCase := use_cases.NewFindAllOrderStatusUseCase(c.orderStatusGateway)
-	statuses, err := useCase.Execute()
+	statuses, err := useCase.Execute(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -3,9 +3,11 @@ package controllers
 import (
 	"context"
 	"errors"
-	"microservice/internal/adapters/brokers"
 	"microservice/internal/adapters/daos"
 	"microservice/internal/adapters/dtos"
+	"microservice/internal/domain/entities"
+	"microservice/internal/domain/exceptions"
+	"microservice/internal/domain/value_objects"
 	"testing"
 	"time"
 
@@ -18,85 +20,151 @@ type MockOrderDataSource struct {
 	mock.Mock
 }
 
-func (m *MockOrderDataSource) Create(order daos.OrderDAO) error {
+func (m *MockOrderDataSource) Create(ctx context.Context, order daos.OrderDAO, outbox ...daos.OutboxMessageDAO) error {
 	args := m.Called(order)
 	return args.Error(0)
 }
 
-func (m *MockOrderDataSource) FindAll(filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error) {
+func (m *MockOrderDataSource) FindAll(ctx context.Context, filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error) {
 	args := m.Called(filter)
 	return args.Get(0).([]daos.OrderDAO), args.Error(1)
 }
 
-func (m *MockOrderDataSource) FindByID(id string) (daos.OrderDAO, error) {
+func (m *MockOrderDataSource) Count(ctx context.Context, filter dtos.OrderFilterDTO) (int64, error) {
+	args := m.Called(filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockOrderDataSource) FindByID(ctx context.Context, id string) (daos.OrderDAO, error) {
 	args := m.Called(id)
 	return args.Get(0).(daos.OrderDAO), args.Error(1)
 }
 
-func (m *MockOrderDataSource) Update(order daos.OrderDAO) error {
+func (m *MockOrderDataSource) Update(ctx context.Context, order daos.OrderDAO, outbox ...daos.OutboxMessageDAO) error {
 	args := m.Called(order)
 	return args.Error(0)
 }
 
-func (m *MockOrderDataSource) Delete(id string) error {
+func (m *MockOrderDataSource) UpdateFromMessage(ctx context.Context, order daos.OrderDAO, message daos.ProcessedMessageDAO, outbox ...daos.OutboxMessageDAO) error {
+	args := m.Called(order, message)
+	return args.Error(0)
+}
+
+func (m *MockOrderDataSource) ApplyCoupon(ctx context.Context, order daos.OrderDAO, outbox ...daos.OutboxMessageDAO) error {
+	args := m.Called(order)
+	return args.Error(0)
+}
+
+func (m *MockOrderDataSource) Delete(ctx context.Context, id string, outbox ...daos.OutboxMessageDAO) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockOrderDataSource) FindByPickupCode(ctx context.Context, day string, code string) (daos.OrderDAO, error) {
+	args := m.Called(day, code)
+	return args.Get(0).(daos.OrderDAO), args.Error(1)
+}
+
+func (m *MockOrderDataSource) FindBoard(ctx context.Context, statusIDs []string, createdFrom time.Time) ([]daos.OrderBoardEntryDAO, error) {
+	args := m.Called(statusIDs, createdFrom)
+	return args.Get(0).([]daos.OrderBoardEntryDAO), args.Error(1)
+}
+
+func (m *MockOrderDataSource) NextPickupSequence(ctx context.Context, day string) (int, error) {
+	args := m.Called(day)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockOrderDataSource) Restore(ctx context.Context, id string, outbox ...daos.OutboxMessageDAO) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
+func (m *MockOrderDataSource) Claim(ctx context.Context, orderID string, customerID string, guestTokenHash string, claimedAt time.Time) error {
+	args := m.Called(orderID, customerID, guestTokenHash, claimedAt)
+	return args.Error(0)
+}
+
+func (m *MockOrderDataSource) ReissueGuestToken(ctx context.Context, orderID string, guestTokenHash string) error {
+	args := m.Called(orderID, guestTokenHash)
+	return args.Error(0)
+}
+
+func (m *MockOrderDataSource) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	args := m.Called(deletedBefore, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockOrderDataSource) FindStatusHistory(ctx context.Context, orderID string) ([]daos.OrderStatusHistoryDAO, error) {
+	args := m.Called(orderID)
+	return args.Get(0).([]daos.OrderStatusHistoryDAO), args.Error(1)
+}
+
 type MockOrderStatusDataSource struct {
 	mock.Mock
 }
 
-func (m *MockOrderStatusDataSource) FindByID(id string) (daos.OrderStatusDAO, error) {
+func (m *MockOrderStatusDataSource) FindByID(ctx context.Context, id string) (daos.OrderStatusDAO, error) {
 	args := m.Called(id)
 	return args.Get(0).(daos.OrderStatusDAO), args.Error(1)
 }
 
-func (m *MockOrderStatusDataSource) FindByName(name string) (daos.OrderStatusDAO, error) {
+func (m *MockOrderStatusDataSource) FindByName(ctx context.Context, name string) (daos.OrderStatusDAO, error) {
 	args := m.Called(name)
 	return args.Get(0).(daos.OrderStatusDAO), args.Error(1)
 }
 
-func (m *MockOrderStatusDataSource) FindAll() ([]daos.OrderStatusDAO, error) {
+func (m *MockOrderStatusDataSource) FindAll(ctx context.Context) ([]daos.OrderStatusDAO, error) {
 	args := m.Called()
 	return args.Get(0).([]daos.OrderStatusDAO), args.Error(1)
 }
 
-type MockMessageBroker struct {
+type MockProductCatalogDataSource struct {
 	mock.Mock
 }
 
-func (m *MockMessageBroker) ConsumeOrderUpdates(ctx context.Context, handler brokers.OrderUpdateHandler) error {
-	args := m.Called(ctx, handler)
-	return args.Error(0)
+func (m *MockProductCatalogDataSource) FindByID(ctx context.Context, productID string) (*daos.ProductDAO, error) {
+	args := m.Called(productID)
+	product, _ := args.Get(0).(*daos.ProductDAO)
+	return product, args.Error(1)
 }
 
-func (m *MockMessageBroker) Close() error {
-	args := m.Called()
+type MockCouponDataSource struct {
+	mock.Mock
+}
+
+func (m *MockCouponDataSource) Create(ctx context.Context, coupon daos.CouponDAO) error {
+	args := m.Called(coupon)
 	return args.Error(0)
 }
 
+func (m *MockCouponDataSource) FindByCode(ctx context.Context, code string) (*daos.CouponDAO, error) {
+	args := m.Called(code)
+	coupon, _ := args.Get(0).(*daos.CouponDAO)
+	return coupon, args.Error(1)
+}
+
 func TestNewOrderController(t *testing.T) {
 	mockOrderDS := &MockOrderDataSource{}
 	mockOrderStatusDS := &MockOrderStatusDataSource{}
-	mockBroker := &MockMessageBroker{}
+	mockProductCatalogDS := &MockProductCatalogDataSource{}
 
-	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockBroker)
+	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockProductCatalogDS, &MockCouponDataSource{}, time.UTC)
 
 	assert.NotNil(t, controller)
 	assert.Equal(t, mockOrderDS, controller.orderDataSource)
 	assert.Equal(t, mockOrderStatusDS, controller.orderStatusDataSource)
-	assert.Equal(t, mockBroker, controller.messageBroker)
 	assert.NotNil(t, controller.orderGateway)
 	assert.NotNil(t, controller.orderStatusGateway)
+	assert.NotNil(t, controller.productCatalogGateway)
 }
 
 func TestOrderController_Create_Success(t *testing.T) {
 	mockOrderDS := &MockOrderDataSource{}
 	mockOrderStatusDS := &MockOrderStatusDataSource{}
-	mockBroker := &MockMessageBroker{}
+	mockProductCatalogDS := &MockProductCatalogDataSource{}
 
-	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockBroker)
+	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockProductCatalogDS, &MockCouponDataSource{}, time.UTC)
 
 	customerID := "customer-123"
 	createDTO := dtos.CreateOrderDTO{
@@ -105,7 +173,7 @@ func TestOrderController_Create_Success(t *testing.T) {
 			{
 				ProductID: "product-1",
 				Quantity:  2,
-				Price:     10.50,
+				Price:     "10.50",
 			},
 		},
 	}
@@ -116,27 +184,38 @@ func TestOrderController_Create_Success(t *testing.T) {
 		Name: "PENDING",
 	}, nil)
 
+	mockProductCatalogDS.On("FindByID", "product-1").Return(&daos.ProductDAO{
+		ID:        "product-1",
+		Name:      "X-Burger",
+		Price:     1050,
+		Currency:  "BRL",
+		Available: true,
+	}, nil)
+
+	mockOrderDS.On("NextPickupSequence", mock.AnythingOfType("string")).Return(42, nil)
 	mockOrderDS.On("Create", mock.AnythingOfType("daos.OrderDAO")).Return(nil)
 
-	result, err := controller.Create(createDTO)
+	result, err := controller.Create(context.Background(), createDTO)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, result.ID)
+	assert.Equal(t, "A042", result.PickupCode)
 	assert.Equal(t, customerID, *result.CustomerID)
-	assert.Equal(t, float64(21), result.Amount) // 2 * 10.50
+	assert.Equal(t, "21.00", result.Amount) // 2 * 10.50
 	assert.Equal(t, "PENDING", result.Status.Name)
 	assert.Len(t, result.Items, 1)
 
 	mockOrderDS.AssertExpectations(t)
 	mockOrderStatusDS.AssertExpectations(t)
+	mockProductCatalogDS.AssertExpectations(t)
 }
 
 func TestOrderController_Create_Error(t *testing.T) {
 	mockOrderDS := &MockOrderDataSource{}
 	mockOrderStatusDS := &MockOrderStatusDataSource{}
-	mockBroker := &MockMessageBroker{}
+	mockProductCatalogDS := &MockProductCatalogDataSource{}
 
-	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockBroker)
+	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockProductCatalogDS, &MockCouponDataSource{}, time.UTC)
 
 	customerID := "customer-123"
 	createDTO := dtos.CreateOrderDTO{
@@ -145,7 +224,7 @@ func TestOrderController_Create_Error(t *testing.T) {
 			{
 				ProductID: "product-1",
 				Quantity:  2,
-				Price:     10.50,
+				Price:     "10.50",
 			},
 		},
 	}
@@ -153,7 +232,7 @@ func TestOrderController_Create_Error(t *testing.T) {
 	// Mock expectations - simulate error
 	mockOrderStatusDS.On("FindByID", "56d3b3c3-1801-49cd-bae7-972c78082012").Return(daos.OrderStatusDAO{}, errors.New("status not found"))
 
-	result, err := controller.Create(createDTO)
+	result, err := controller.Create(context.Background(), createDTO)
 
 	assert.Error(t, err)
 	assert.Equal(t, dtos.OrderResponseDTO{}, result)
@@ -165,9 +244,9 @@ func TestOrderController_Create_Error(t *testing.T) {
 func TestOrderController_FindAll_Success(t *testing.T) {
 	mockOrderDS := &MockOrderDataSource{}
 	mockOrderStatusDS := &MockOrderStatusDataSource{}
-	mockBroker := &MockMessageBroker{}
+	mockProductCatalogDS := &MockProductCatalogDataSource{}
 
-	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockBroker)
+	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockProductCatalogDS, &MockCouponDataSource{}, time.UTC)
 
 	filter := dtos.OrderFilterDTO{}
 	now := time.Now()
@@ -176,7 +255,7 @@ func TestOrderController_FindAll_Success(t *testing.T) {
 		{
 			ID:         "order-1",
 			CustomerID: stringPtr("customer-1"),
-			Amount:     25.50,
+			Amount:     2550,
 			Status: daos.OrderStatusDAO{
 				ID:   "status-1",
 				Name: "PENDING",
@@ -188,22 +267,25 @@ func TestOrderController_FindAll_Success(t *testing.T) {
 					ProductID: "product-1",
 					OrderID:   "order-1",
 					Quantity:  2,
-					UnitPrice: 12.75,
+					UnitPrice: 1275,
 				},
 			},
 		},
 	}
 
-	mockOrderDS.On("FindAll", filter).Return(mockOrders, nil)
+	mockOrderDS.On("Count", mock.Anything).Return(int64(1), nil)
+	mockOrderDS.On("FindAll", mock.Anything).Return(mockOrders, nil)
 
-	result, err := controller.FindAll(filter)
+	result, err := controller.FindAll(context.Background(), filter)
 
 	assert.NoError(t, err)
-	assert.Len(t, result, 1)
-	assert.Equal(t, "order-1", result[0].ID)
-	assert.Equal(t, "customer-1", *result[0].CustomerID)
-	assert.Equal(t, float64(25.50), result[0].Amount)
-	assert.Equal(t, "PENDING", result[0].Status.Name)
+	assert.Len(t, result.Orders, 1)
+	assert.Equal(t, int64(1), result.Total)
+	assert.Nil(t, result.NextCursor)
+	assert.Equal(t, "order-1", result.Orders[0].ID)
+	assert.Equal(t, "customer-1", *result.Orders[0].CustomerID)
+	assert.Equal(t, "25.50", result.Orders[0].Amount)
+	assert.Equal(t, "PENDING", result.Orders[0].Status.Name)
 
 	mockOrderDS.AssertExpectations(t)
 }
@@ -211,18 +293,19 @@ func TestOrderController_FindAll_Success(t *testing.T) {
 func TestOrderController_FindAll_Error(t *testing.T) {
 	mockOrderDS := &MockOrderDataSource{}
 	mockOrderStatusDS := &MockOrderStatusDataSource{}
-	mockBroker := &MockMessageBroker{}
+	mockProductCatalogDS := &MockProductCatalogDataSource{}
 
-	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockBroker)
+	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockProductCatalogDS, &MockCouponDataSource{}, time.UTC)
 
 	filter := dtos.OrderFilterDTO{}
 
-	mockOrderDS.On("FindAll", filter).Return([]daos.OrderDAO{}, errors.New("database error"))
+	mockOrderDS.On("Count", mock.Anything).Return(int64(0), nil)
+	mockOrderDS.On("FindAll", mock.Anything).Return([]daos.OrderDAO{}, errors.New("database error"))
 
-	result, err := controller.FindAll(filter)
+	result, err := controller.FindAll(context.Background(), filter)
 
 	assert.Error(t, err)
-	assert.Nil(t, result)
+	assert.Nil(t, result.Orders)
 	assert.Contains(t, err.Error(), "database error")
 
 	mockOrderDS.AssertExpectations(t)
@@ -231,9 +314,9 @@ func TestOrderController_FindAll_Error(t *testing.T) {
 func TestOrderController_FindByID_Success(t *testing.T) {
 	mockOrderDS := &MockOrderDataSource{}
 	mockOrderStatusDS := &MockOrderStatusDataSource{}
-	mockBroker := &MockMessageBroker{}
+	mockProductCatalogDS := &MockProductCatalogDataSource{}
 
-	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockBroker)
+	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockProductCatalogDS, &MockCouponDataSource{}, time.UTC)
 
 	orderID := "550e8400-e29b-41d4-a716-446655440000" // Valid UUID
 	now := time.Now()
@@ -241,7 +324,7 @@ func TestOrderController_FindByID_Success(t *testing.T) {
 	mockOrder := daos.OrderDAO{
 		ID:         orderID,
 		CustomerID: stringPtr("customer-1"),
-		Amount:     15.75,
+		Amount:     1575,
 		Status: daos.OrderStatusDAO{
 			ID:   "status-1",
 			Name: "PENDING",
@@ -253,19 +336,19 @@ func TestOrderController_FindByID_Success(t *testing.T) {
 				ProductID: "product-1",
 				OrderID:   orderID,
 				Quantity:  1,
-				UnitPrice: 15.75,
+				UnitPrice: 1575,
 			},
 		},
 	}
 
 	mockOrderDS.On("FindByID", orderID).Return(mockOrder, nil)
 
-	result, err := controller.FindByID(orderID)
+	result, err := controller.FindByID(context.Background(), orderID)
 
 	assert.NoError(t, err)
 	assert.Equal(t, orderID, result.ID)
 	assert.Equal(t, "customer-1", *result.CustomerID)
-	assert.Equal(t, float64(15.75), result.Amount)
+	assert.Equal(t, "15.75", result.Amount)
 	assert.Equal(t, "PENDING", result.Status.Name)
 
 	mockOrderDS.AssertExpectations(t)
@@ -274,13 +357,13 @@ func TestOrderController_FindByID_Success(t *testing.T) {
 func TestOrderController_FindByID_Error(t *testing.T) {
 	mockOrderDS := &MockOrderDataSource{}
 	mockOrderStatusDS := &MockOrderStatusDataSource{}
-	mockBroker := &MockMessageBroker{}
+	mockProductCatalogDS := &MockProductCatalogDataSource{}
 
-	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockBroker)
+	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockProductCatalogDS, &MockCouponDataSource{}, time.UTC)
 
 	orderID := "invalid-order-id" // Invalid UUID
 
-	result, err := controller.FindByID(orderID)
+	result, err := controller.FindByID(context.Background(), orderID)
 
 	assert.Error(t, err)
 	assert.Equal(t, dtos.OrderResponseDTO{}, result)
@@ -290,9 +373,9 @@ func TestOrderController_FindByID_Error(t *testing.T) {
 func TestOrderController_Update_Success(t *testing.T) {
 	mockOrderDS := &MockOrderDataSource{}
 	mockOrderStatusDS := &MockOrderStatusDataSource{}
-	mockBroker := &MockMessageBroker{}
+	mockProductCatalogDS := &MockProductCatalogDataSource{}
 
-	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockBroker)
+	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockProductCatalogDS, &MockCouponDataSource{}, time.UTC)
 
 	updateDTO := dtos.UpdateOrderDTO{
 		ID:       "550e8400-e29b-41d4-a716-446655440000", // Valid UUID
@@ -303,10 +386,10 @@ func TestOrderController_Update_Success(t *testing.T) {
 	mockOrder := daos.OrderDAO{
 		ID:         "550e8400-e29b-41d4-a716-446655440000",
 		CustomerID: stringPtr("customer-1"),
-		Amount:     20.00,
+		Amount:     2000,
 		Status: daos.OrderStatusDAO{
 			ID:   "status-1",
-			Name: "PENDING",
+			Name: "Recebido",
 		},
 		CreatedAt: now,
 		Items:     []daos.OrderItemDAO{},
@@ -315,15 +398,15 @@ func TestOrderController_Update_Success(t *testing.T) {
 	mockOrderDS.On("FindByID", "550e8400-e29b-41d4-a716-446655440000").Return(mockOrder, nil)
 	mockOrderStatusDS.On("FindByID", "status-2").Return(daos.OrderStatusDAO{
 		ID:   "status-2",
-		Name: "CONFIRMED",
+		Name: "Confirmado",
 	}, nil)
 	mockOrderDS.On("Update", mock.AnythingOfType("daos.OrderDAO")).Return(nil)
 
-	result, err := controller.Update(updateDTO)
+	result, err := controller.Update(context.Background(), updateDTO)
 
 	assert.NoError(t, err)
 	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", result.ID)
-	assert.Equal(t, "CONFIRMED", result.Status.Name)
+	assert.Equal(t, "Confirmado", result.Status.Name)
 
 	mockOrderDS.AssertExpectations(t)
 	mockOrderStatusDS.AssertExpectations(t)
@@ -332,40 +415,40 @@ func TestOrderController_Update_Success(t *testing.T) {
 func TestOrderController_UpdateStatus_Success(t *testing.T) {
 	mockOrderDS := &MockOrderDataSource{}
 	mockOrderStatusDS := &MockOrderStatusDataSource{}
-	mockBroker := &MockMessageBroker{}
+	mockProductCatalogDS := &MockProductCatalogDataSource{}
 
-	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockBroker)
+	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockProductCatalogDS, &MockCouponDataSource{}, time.UTC)
 
 	updateDTO := dtos.UpdateOrderStatusDTO{
 		OrderID: "550e8400-e29b-41d4-a716-446655440000", // Valid UUID
-		Status:  "CONFIRMED",
+		Status:  "Confirmado",
 	}
 
 	now := time.Now()
 	mockOrder := daos.OrderDAO{
 		ID:         "550e8400-e29b-41d4-a716-446655440000",
 		CustomerID: stringPtr("customer-1"),
-		Amount:     20.00,
+		Amount:     2000,
 		Status: daos.OrderStatusDAO{
 			ID:   "status-1",
-			Name: "PENDING",
+			Name: "Recebido",
 		},
 		CreatedAt: now,
 		Items:     []daos.OrderItemDAO{},
 	}
 
 	mockOrderDS.On("FindByID", "550e8400-e29b-41d4-a716-446655440000").Return(mockOrder, nil)
-	mockOrderStatusDS.On("FindByName", "CONFIRMED").Return(daos.OrderStatusDAO{
+	mockOrderStatusDS.On("FindByName", "Confirmado").Return(daos.OrderStatusDAO{
 		ID:   "status-2",
-		Name: "CONFIRMED",
+		Name: "Confirmado",
 	}, nil)
 	mockOrderDS.On("Update", mock.AnythingOfType("daos.OrderDAO")).Return(nil)
 
-	result, err := controller.UpdateStatus(updateDTO)
+	result, err := controller.UpdateStatus(context.Background(), updateDTO)
 
 	assert.NoError(t, err)
 	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", result.ID)
-	assert.Equal(t, "CONFIRMED", result.Status.Name)
+	assert.Equal(t, "Confirmado", result.Status.Name)
 
 	mockOrderDS.AssertExpectations(t)
 	mockOrderStatusDS.AssertExpectations(t)
@@ -374,9 +457,9 @@ func TestOrderController_UpdateStatus_Success(t *testing.T) {
 func TestOrderController_Delete_Success(t *testing.T) {
 	mockOrderDS := &MockOrderDataSource{}
 	mockOrderStatusDS := &MockOrderStatusDataSource{}
-	mockBroker := &MockMessageBroker{}
+	mockProductCatalogDS := &MockProductCatalogDataSource{}
 
-	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockBroker)
+	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockProductCatalogDS, &MockCouponDataSource{}, time.UTC)
 
 	orderID := "550e8400-e29b-41d4-a716-446655440000" // Valid UUID
 	now := time.Now()
@@ -384,7 +467,7 @@ func TestOrderController_Delete_Success(t *testing.T) {
 	mockOrder := daos.OrderDAO{
 		ID:         orderID,
 		CustomerID: stringPtr("customer-1"),
-		Amount:     25.50,
+		Amount:     2550,
 		Status: daos.OrderStatusDAO{
 			ID:   "status-1",
 			Name: "PENDING",
@@ -396,7 +479,7 @@ func TestOrderController_Delete_Success(t *testing.T) {
 				ProductID: "product-1",
 				OrderID:   orderID,
 				Quantity:  2,
-				UnitPrice: 12.75,
+				UnitPrice: 1275,
 			},
 		},
 	}
@@ -404,7 +487,7 @@ func TestOrderController_Delete_Success(t *testing.T) {
 	mockOrderDS.On("FindByID", orderID).Return(mockOrder, nil)
 	mockOrderDS.On("Delete", orderID).Return(nil)
 
-	err := controller.Delete(orderID)
+	err := controller.Delete(context.Background(), orderID)
 
 	assert.NoError(t, err)
 
@@ -414,13 +497,13 @@ func TestOrderController_Delete_Success(t *testing.T) {
 func TestOrderController_Delete_Error(t *testing.T) {
 	mockOrderDS := &MockOrderDataSource{}
 	mockOrderStatusDS := &MockOrderStatusDataSource{}
-	mockBroker := &MockMessageBroker{}
+	mockProductCatalogDS := &MockProductCatalogDataSource{}
 
-	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockBroker)
+	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockProductCatalogDS, &MockCouponDataSource{}, time.UTC)
 
 	orderID := "invalid-order-id" // Invalid UUID
 
-	err := controller.Delete(orderID)
+	err := controller.Delete(context.Background(), orderID)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "Invalid order ID")
@@ -429,9 +512,9 @@ func TestOrderController_Delete_Error(t *testing.T) {
 func TestOrderController_FindAllStatus_Success(t *testing.T) {
 	mockOrderDS := &MockOrderDataSource{}
 	mockOrderStatusDS := &MockOrderStatusDataSource{}
-	mockBroker := &MockMessageBroker{}
+	mockProductCatalogDS := &MockProductCatalogDataSource{}
 
-	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockBroker)
+	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockProductCatalogDS, &MockCouponDataSource{}, time.UTC)
 
 	mockStatuses := []daos.OrderStatusDAO{
 		{ID: "status-1", Name: "PENDING"},
@@ -441,7 +524,7 @@ func TestOrderController_FindAllStatus_Success(t *testing.T) {
 
 	mockOrderStatusDS.On("FindAll").Return(mockStatuses, nil)
 
-	result, err := controller.FindAllStatus()
+	result, err := controller.FindAllStatus(context.Background())
 
 	assert.NoError(t, err)
 	assert.Len(t, result, 3)
@@ -455,13 +538,13 @@ func TestOrderController_FindAllStatus_Success(t *testing.T) {
 func TestOrderController_FindAllStatus_Error(t *testing.T) {
 	mockOrderDS := &MockOrderDataSource{}
 	mockOrderStatusDS := &MockOrderStatusDataSource{}
-	mockBroker := &MockMessageBroker{}
+	mockProductCatalogDS := &MockProductCatalogDataSource{}
 
-	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockBroker)
+	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockProductCatalogDS, &MockCouponDataSource{}, time.UTC)
 
 	mockOrderStatusDS.On("FindAll").Return([]daos.OrderStatusDAO{}, errors.New("database error"))
 
-	result, err := controller.FindAllStatus()
+	result, err := controller.FindAllStatus(context.Background())
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -473,4 +556,284 @@ func TestOrderController_FindAllStatus_Error(t *testing.T) {
 // Helper function
 func stringPtr(s string) *string {
 	return &s
-}
\ No newline at end of file
+}
+func TestOrderController_FindStatusHistory_Success(t *testing.T) {
+	mockOrderDS := &MockOrderDataSource{}
+	mockOrderStatusDS := &MockOrderStatusDataSource{}
+	mockProductCatalogDS := &MockProductCatalogDataSource{}
+
+	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockProductCatalogDS, &MockCouponDataSource{}, time.UTC)
+
+	orderID := "550e8400-e29b-41d4-a716-446655440000"
+	now := time.Now()
+
+	mockOrderDS.On("FindByID", orderID).Return(daos.OrderDAO{
+		ID:        orderID,
+		Amount:    1000,
+		Status:    daos.OrderStatusDAO{ID: "status-2", Name: "Confirmado"},
+		CreatedAt: now,
+	}, nil)
+	mockOrderDS.On("FindStatusHistory", orderID).Return([]daos.OrderStatusHistoryDAO{
+		{ID: "change-1", OrderID: orderID, NewStatus: daos.OrderStatusDAO{ID: "status-1", Name: "Recebido"}, Source: "rest", ChangedAt: now},
+		{ID: "change-2", OrderID: orderID, PreviousStatus: &daos.OrderStatusDAO{ID: "status-1", Name: "Recebido"}, NewStatus: daos.OrderStatusDAO{ID: "status-2", Name: "Confirmado"}, Source: "payment_consumer", Actor: stringPtr("payment-1"), ChangedAt: now},
+	}, nil)
+
+	result, err := controller.FindStatusHistory(context.Background(), orderID)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Nil(t, result[0].PreviousStatus)
+	assert.Equal(t, "Recebido", result[1].PreviousStatus.Name)
+	assert.Equal(t, "Confirmado", result[1].NewStatus.Name)
+	assert.Equal(t, "payment-1", *result[1].Actor)
+
+	mockOrderDS.AssertExpectations(t)
+}
+
+func TestOrderController_FindStatusHistory_InvalidID(t *testing.T) {
+	mockOrderDS := &MockOrderDataSource{}
+	mockOrderStatusDS := &MockOrderStatusDataSource{}
+	mockProductCatalogDS := &MockProductCatalogDataSource{}
+
+	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockProductCatalogDS, &MockCouponDataSource{}, time.UTC)
+
+	result, err := controller.FindStatusHistory(context.Background(), "invalid-order-id")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "Invalid order ID")
+}
+
+func TestOrderController_ApplyCoupon_Success(t *testing.T) {
+	mockOrderDS := &MockOrderDataSource{}
+	mockOrderStatusDS := &MockOrderStatusDataSource{}
+	mockProductCatalogDS := &MockProductCatalogDataSource{}
+	mockCouponDS := &MockCouponDataSource{}
+
+	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, mockProductCatalogDS, mockCouponDS, time.UTC)
+
+	orderID := "550e8400-e29b-41d4-a716-446655440000"
+	mockOrderDS.On("FindByID", orderID).Return(daos.OrderDAO{
+		ID:       orderID,
+		Amount:   2000,
+		Currency: "BRL",
+		Status:   daos.OrderStatusDAO{ID: "status-1", Name: "Recebido"},
+		Items: []daos.OrderItemDAO{
+			{ID: "item-1", ProductID: "product-1", OrderID: orderID, Quantity: 2, UnitPrice: 1000},
+		},
+		CreatedAt: time.Now(),
+	}, nil)
+	mockCouponDS.On("FindByCode", "PROMO10").Return(&daos.CouponDAO{Code: "PROMO10", Type: "percentage", Percentage: 10, Currency: "BRL"}, nil)
+	mockOrderDS.On("ApplyCoupon", mock.MatchedBy(func(order daos.OrderDAO) bool {
+		return order.Amount == 1800 && order.Discount == 200 && order.CouponCode != nil && *order.CouponCode == "PROMO10"
+	})).Return(nil)
+
+	result, err := controller.ApplyCoupon(context.Background(), dtos.ApplyCouponDTO{OrderID: orderID, Code: "promo10"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "20.00", result.Subtotal)
+	assert.Equal(t, "2.00", result.Discount)
+	assert.Equal(t, "18.00", result.Amount)
+	assert.Equal(t, "PROMO10", *result.CouponCode)
+
+	mockOrderDS.AssertExpectations(t)
+	mockCouponDS.AssertExpectations(t)
+}
+
+func TestOrderController_Cancel_Success(t *testing.T) {
+	mockOrderDS := &MockOrderDataSource{}
+	mockOrderStatusDS := &MockOrderStatusDataSource{}
+
+	controller := NewOrderController(mockOrderDS, mockOrderStatusDS, &MockProductCatalogDataSource{}, &MockCouponDataSource{}, time.UTC)
+
+	orderID := "550e8400-e29b-41d4-a716-446655440000"
+	mockOrderDS.On("FindByID", orderID).Return(daos.OrderDAO{
+		ID:       orderID,
+		Amount:   2000,
+		Currency: "BRL",
+		Status:   daos.OrderStatusDAO{ID: "status-2", Name: "Confirmado"},
+		Items: []daos.OrderItemDAO{
+			{ID: "item-1", ProductID: "product-1", OrderID: orderID, Quantity: 2, UnitPrice: 1000},
+		},
+		CreatedAt: time.Now(),
+	}, nil)
+	mockOrderStatusDS.On("FindByName", "Cancelado").Return(daos.OrderStatusDAO{ID: "status-9", Name: "Cancelado"}, nil)
+	mockOrderDS.On("Update", mock.MatchedBy(func(order daos.OrderDAO) bool {
+		return order.Status.ID == "status-9" && order.CancellationReason != nil && *order.CancellationReason == "Cliente desistiu" && order.CancelledAt != nil
+	})).Return(nil)
+
+	result, err := controller.Cancel(context.Background(), dtos.CancelOrderDTO{OrderID: orderID, Reason: "Cliente desistiu"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Cancelado", result.Status.Name)
+	assert.Equal(t, "Cliente desistiu", *result.CancellationReason)
+	assert.NotNil(t, result.CancelledAt)
+
+	mockOrderDS.AssertExpectations(t)
+	mockOrderStatusDS.AssertExpectations(t)
+}
+
+func TestOrderController_Restore(t *testing.T) {
+	mockOrderDS := &MockOrderDataSource{}
+	controller := NewOrderController(mockOrderDS, &MockOrderStatusDataSource{}, &MockProductCatalogDataSource{}, &MockCouponDataSource{}, time.UTC)
+
+	orderID := "550e8400-e29b-41d4-a716-446655440000"
+	mockOrderDS.On("Restore", orderID).Return(nil)
+	mockOrderDS.On("FindByID", orderID).Return(daos.OrderDAO{
+		ID:       orderID,
+		Amount:   2000,
+		Currency: "BRL",
+		Status:   daos.OrderStatusDAO{ID: "status-1", Name: "Recebido"},
+		Items: []daos.OrderItemDAO{
+			{ID: "item-1", ProductID: "product-1", OrderID: orderID, Quantity: 2, UnitPrice: 1000},
+		},
+		CreatedAt: time.Now(),
+	}, nil)
+
+	result, err := controller.Restore(context.Background(), orderID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, orderID, result.ID)
+	mockOrderDS.AssertExpectations(t)
+}
+
+func TestOrderController_Restore_NotDeleted(t *testing.T) {
+	mockOrderDS := &MockOrderDataSource{}
+	controller := NewOrderController(mockOrderDS, &MockOrderStatusDataSource{}, &MockProductCatalogDataSource{}, &MockCouponDataSource{}, time.UTC)
+
+	orderID := "550e8400-e29b-41d4-a716-446655440000"
+	mockOrderDS.On("Restore", orderID).Return(&exceptions.OrderNotFoundException{})
+
+	_, err := controller.Restore(context.Background(), orderID)
+
+	assert.IsType(t, &exceptions.OrderNotFoundException{}, err)
+	mockOrderDS.AssertNotCalled(t, "FindByID", orderID)
+}
+
+func TestOrderController_FindByPickupCode(t *testing.T) {
+	mockOrderDS := &MockOrderDataSource{}
+	controller := NewOrderController(mockOrderDS, &MockOrderStatusDataSource{}, &MockProductCatalogDataSource{}, &MockCouponDataSource{}, time.UTC)
+
+	orderID := "550e8400-e29b-41d4-a716-446655440000"
+	day, code := entities.PickupDay(time.Now(), time.UTC), "A042"
+	mockOrderDS.On("FindByPickupCode", day, code).Return(daos.OrderDAO{
+		ID:       orderID,
+		Amount:   2000,
+		Currency: "BRL",
+		Status:   daos.OrderStatusDAO{ID: "status-1", Name: "Recebido"},
+		Items: []daos.OrderItemDAO{
+			{ID: "item-1", ProductID: "product-1", OrderID: orderID, Quantity: 2, UnitPrice: 1000},
+		},
+		PickupDate: &day,
+		PickupCode: &code,
+		CreatedAt:  time.Now(),
+	}, nil)
+
+	result, err := controller.FindByPickupCode(context.Background(), "a042")
+
+	assert.NoError(t, err)
+	assert.Equal(t, orderID, result.ID)
+	assert.Equal(t, code, result.PickupCode)
+	mockOrderDS.AssertExpectations(t)
+}
+
+func TestOrderController_FindByPickupCode_InvalidCode(t *testing.T) {
+	mockOrderDS := &MockOrderDataSource{}
+	controller := NewOrderController(mockOrderDS, &MockOrderStatusDataSource{}, &MockProductCatalogDataSource{}, &MockCouponDataSource{}, time.UTC)
+
+	_, err := controller.FindByPickupCode(context.Background(), "A0000")
+
+	assert.IsType(t, &exceptions.InvalidOrderDataException{}, err)
+	mockOrderDS.AssertNotCalled(t, "FindByPickupCode", mock.Anything, mock.Anything)
+}
+
+func TestOrderController_FindBoard(t *testing.T) {
+	mockOrderDS := &MockOrderDataSource{}
+	mockStatusDS := &MockOrderStatusDataSource{}
+	controller := NewOrderController(mockOrderDS, mockStatusDS, &MockProductCatalogDataSource{}, &MockCouponDataSource{}, time.UTC)
+
+	code := "A001"
+	mockStatusDS.On("FindAll").Return([]daos.OrderStatusDAO{
+		{ID: "status-1", Name: "Recebido"},
+		{ID: "status-4", Name: "Pronto"},
+		{ID: "status-5", Name: "Entregue"},
+	}, nil)
+	mockOrderDS.On("FindBoard", []string{"status-1", "status-4"}, mock.AnythingOfType("time.Time")).Return([]daos.OrderBoardEntryDAO{
+		{ID: "order-1", PickupCode: &code, StatusID: "status-1", StatusName: "Recebido", CreatedAt: time.Now().Add(-time.Minute)},
+	}, nil)
+
+	result, err := controller.FindBoard(context.Background(), 30*time.Minute)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Groups, 2)
+	assert.Equal(t, "Pronto", result.Groups[0].Status.Name)
+	assert.Empty(t, result.Groups[0].Orders)
+	assert.Equal(t, "Recebido", result.Groups[1].Status.Name)
+	assert.Equal(t, "A001", result.Groups[1].Orders[0].PickupCode)
+	assert.GreaterOrEqual(t, result.Groups[1].Orders[0].WaitSeconds, int64(60))
+	mockOrderDS.AssertExpectations(t)
+}
+
+func TestOrderController_FindBoard_Error(t *testing.T) {
+	mockOrderDS := &MockOrderDataSource{}
+	mockStatusDS := &MockOrderStatusDataSource{}
+	controller := NewOrderController(mockOrderDS, mockStatusDS, &MockProductCatalogDataSource{}, &MockCouponDataSource{}, time.UTC)
+
+	mockStatusDS.On("FindAll").Return([]daos.OrderStatusDAO{{ID: "status-1", Name: "Recebido"}}, nil)
+	mockOrderDS.On("FindBoard", mock.Anything, mock.Anything).Return([]daos.OrderBoardEntryDAO(nil), errors.New("database error"))
+
+	_, err := controller.FindBoard(context.Background(), 0)
+
+	assert.Error(t, err)
+}
+
+func TestOrderController_FindGuestOrder(t *testing.T) {
+	mockOrderDS := &MockOrderDataSource{}
+	controller := NewOrderController(mockOrderDS, &MockOrderStatusDataSource{}, &MockProductCatalogDataSource{}, &MockCouponDataSource{}, time.UTC)
+
+	orderID := "550e8400-e29b-41d4-a716-446655440000"
+	token, _ := value_objects.NewGuestToken()
+	hash := token.Hash()
+	mockOrderDS.On("FindByID", orderID).Return(daos.OrderDAO{
+		ID:             orderID,
+		Amount:         2000,
+		Currency:       "BRL",
+		Status:         daos.OrderStatusDAO{ID: "status-1", Name: "Recebido"},
+		GuestTokenHash: &hash,
+		CreatedAt:      time.Now(),
+	}, nil)
+
+	result, err := controller.FindGuestOrder(context.Background(), orderID, token.Value())
+	assert.NoError(t, err)
+	assert.Equal(t, orderID, result.ID)
+	assert.Empty(t, result.GuestToken)
+
+	_, err = controller.FindGuestOrder(context.Background(), orderID, "wrong-token")
+	assert.IsType(t, &exceptions.OrderNotFoundException{}, err)
+}
+
+func TestOrderController_Claim(t *testing.T) {
+	mockOrderDS := &MockOrderDataSource{}
+	controller := NewOrderController(mockOrderDS, &MockOrderStatusDataSource{}, &MockProductCatalogDataSource{}, &MockCouponDataSource{}, time.UTC)
+
+	orderID := "550e8400-e29b-41d4-a716-446655440000"
+	token, _ := value_objects.NewGuestToken()
+	hash := token.Hash()
+	mockOrderDS.On("FindByID", orderID).Return(daos.OrderDAO{
+		ID:             orderID,
+		Amount:         2000,
+		Currency:       "BRL",
+		Status:         daos.OrderStatusDAO{ID: "status-1", Name: "Recebido"},
+		GuestTokenHash: &hash,
+		CreatedAt:      time.Now(),
+	}, nil)
+	mockOrderDS.On("Claim", orderID, "customer-123", hash, mock.AnythingOfType("time.Time")).Return(nil)
+
+	result, err := controller.Claim(context.Background(), dtos.ClaimOrderDTO{OrderID: orderID, GuestToken: token.Value(), CustomerID: "customer-123"})
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, result.CustomerID) {
+		assert.Equal(t, "customer-123", *result.CustomerID)
+	}
+	mockOrderDS.AssertExpectations(t)
+}
@@ -0,0 +1,20 @@
+package daos
+
+import "time"
+
+type CouponDAO struct {
+	Code           string
+	Type           string
+	Percentage     int
+	FixedAmount    int64 // em unidades menores de Currency
+	Currency       string
+	ProductID      string
+	BuyQuantity    int
+	FreeQuantity   int
+	FirstOrderOnly bool
+	ValidFrom      *time.Time
+	ValidUntil     *time.Time
+	MaxUses        int
+	UsedCount      int
+	CreatedAt      time.Time
+}
@@ -0,0 +1,17 @@
+package daos
+
+import "time"
+
+type IdempotencyKeyDAO struct {
+	// Isola as chaves de cada chamador, para nunca repetir a resposta de outro
+	Scope        string
+	Key          string
+	RequestHash  string
+	StatusCode   int
+	ResponseBody []byte
+	CreatedAt    time.Time
+	// Nulo depois de concluída
+	LockedAt    *time.Time
+	ExpiresAt   time.Time
+	CompletedAt *time.Time
+}
@@ -5,11 +5,41 @@ import "time"
 type OrderDAO struct {
 	ID         string
 	CustomerID *string
-	Amount     float64
+	Amount     int64 // em unidades menores da moeda (centavos), assim como UnitPrice
+	Currency   string
+	// Zero em pedidos anteriores aos cupons, quando Subtotal = Amount
+	Subtotal   int64
+	Discount   int64
+	CouponCode *string
 	Status     OrderStatusDAO
 	Items      []OrderItemDAO
 	CreatedAt  time.Time
 	UpdatedAt  *time.Time
+
+	CustomerName *string
+
+	GuestTokenHash *string
+
+	PickupCode *string
+	PickupDate *string
+
+	CancellationReason *string
+	CancelledAt        *time.Time
+	DeletedAt          *time.Time
+
+	Version int
+
+	StatusChanges []OrderStatusHistoryDAO
+}
+
+// Resumo lido para o painel de retirada, sem itens
+type OrderBoardEntryDAO struct {
+	ID           string
+	PickupCode   *string
+	CustomerName *string
+	StatusID     string
+	StatusName   string
+	CreatedAt    time.Time
 }
 
 type OrderItemDAO struct {
@@ -17,10 +47,40 @@ type OrderItemDAO struct {
 	OrderID   string
 	ProductID string
 	Quantity  int
-	UnitPrice float64
+	UnitPrice int64
+
+	// Dados do produto no momento do pedido
+	ProductName     string
+	ProductCategory string
+	ProductImageURL string
+	ProductNotes    string
+
+	Notes     string
+	Modifiers []OrderItemModifierDAO
+
+	// Preenchido nos componentes de um combo com o ID do item do combo
+	ParentItemID *string
+}
+
+type OrderItemModifierDAO struct {
+	ID          string
+	OrderItemID string
+	ModifierID  string
+	Name        string
+	PriceDelta  int64 // na moeda do pedido, em unidades menores
 }
 
 type OrderStatusDAO struct {
 	ID   string
 	Name string
 }
+
+type OrderStatusHistoryDAO struct {
+	ID             string
+	OrderID        string
+	PreviousStatus *OrderStatusDAO
+	NewStatus      OrderStatusDAO
+	Source         string
+	Actor          *string
+	ChangedAt      time.Time
+}
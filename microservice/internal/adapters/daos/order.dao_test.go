@@ -11,7 +11,7 @@ func TestOrderDAO_Structure(t *testing.T) {
 	orderDAO := OrderDAO{
 		ID:         "order-123",
 		CustomerID: stringPtr("customer-456"),
-		Amount:     99.99,
+		Amount:     9999,
 		Status: OrderStatusDAO{
 			ID:   "status-1",
 			Name: "pending",
@@ -22,7 +22,7 @@ func TestOrderDAO_Structure(t *testing.T) {
 				OrderID:   "order-123",
 				ProductID: "product-1",
 				Quantity:  2,
-				UnitPrice: 49.99,
+				UnitPrice: 4999,
 			},
 		},
 		CreatedAt: time.Now(),
@@ -31,7 +31,7 @@ func TestOrderDAO_Structure(t *testing.T) {
 
 	assert.Equal(t, "order-123", orderDAO.ID)
 	assert.Equal(t, "customer-456", *orderDAO.CustomerID)
-	assert.Equal(t, 99.99, orderDAO.Amount)
+	assert.Equal(t, int64(9999), orderDAO.Amount)
 	assert.Equal(t, "status-1", orderDAO.Status.ID)
 	assert.Equal(t, "pending", orderDAO.Status.Name)
 	assert.Len(t, orderDAO.Items, 1)
@@ -39,7 +39,7 @@ func TestOrderDAO_Structure(t *testing.T) {
 	assert.Equal(t, "order-123", orderDAO.Items[0].OrderID)
 	assert.Equal(t, "product-1", orderDAO.Items[0].ProductID)
 	assert.Equal(t, 2, orderDAO.Items[0].Quantity)
-	assert.Equal(t, 49.99, orderDAO.Items[0].UnitPrice)
+	assert.Equal(t, int64(4999), orderDAO.Items[0].UnitPrice)
 	assert.NotNil(t, orderDAO.CreatedAt)
 	assert.NotNil(t, orderDAO.UpdatedAt)
 }
@@ -50,14 +50,14 @@ func TestOrderItemDAO_Structure(t *testing.T) {
 		OrderID:   "order-456",
 		ProductID: "product-789",
 		Quantity:  3,
-		UnitPrice: 25.50,
+		UnitPrice: 2550,
 	}
 
 	assert.Equal(t, "item-123", itemDAO.ID)
 	assert.Equal(t, "order-456", itemDAO.OrderID)
 	assert.Equal(t, "product-789", itemDAO.ProductID)
 	assert.Equal(t, 3, itemDAO.Quantity)
-	assert.Equal(t, 25.50, itemDAO.UnitPrice)
+	assert.Equal(t, int64(2550), itemDAO.UnitPrice)
 }
 
 func TestOrderStatusDAO_Structure(t *testing.T) {
@@ -74,7 +74,7 @@ func TestOrderDAO_WithNilValues(t *testing.T) {
 	orderDAO := OrderDAO{
 		ID:         "order-123",
 		CustomerID: nil,
-		Amount:     0.0,
+		Amount:     0,
 		Status: OrderStatusDAO{
 			ID:   "",
 			Name: "",
@@ -86,7 +86,7 @@ func TestOrderDAO_WithNilValues(t *testing.T) {
 
 	assert.Equal(t, "order-123", orderDAO.ID)
 	assert.Nil(t, orderDAO.CustomerID)
-	assert.Equal(t, 0.0, orderDAO.Amount)
+	assert.Equal(t, int64(0), orderDAO.Amount)
 	assert.Equal(t, "", orderDAO.Status.ID)
 	assert.Equal(t, "", orderDAO.Status.Name)
 	assert.Empty(t, orderDAO.Items)
@@ -101,21 +101,21 @@ func TestOrderDAO_MultipleItems(t *testing.T) {
 			OrderID:   "order-123",
 			ProductID: "product-1",
 			Quantity:  1,
-			UnitPrice: 10.00,
+			UnitPrice: 1000,
 		},
 		{
 			ID:        "item-2",
 			OrderID:   "order-123",
 			ProductID: "product-2",
 			Quantity:  2,
-			UnitPrice: 15.00,
+			UnitPrice: 1500,
 		},
 	}
 
 	orderDAO := OrderDAO{
 		ID:         "order-123",
 		CustomerID: stringPtr("customer-456"),
-		Amount:     40.00,
+		Amount:     4000,
 		Status: OrderStatusDAO{
 			ID:   "status-1",
 			Name: "pending",
@@ -0,0 +1,17 @@
+package daos
+
+import "time"
+
+type OutboxMessageDAO struct {
+	ID            string
+	OrderID       string
+	EventType     string
+	Payload       []byte
+	Attempts      int
+	LastError     *string
+	CreatedAt     time.Time
+	NextAttemptAt time.Time
+	SentAt        *time.Time
+	DeadAt        *time.Time
+	LockedUntil   *time.Time
+}
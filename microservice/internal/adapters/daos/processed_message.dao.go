@@ -0,0 +1,11 @@
+package daos
+
+import "time"
+
+type ProcessedMessageDAO struct {
+	Consumer    string
+	MessageID   string
+	OrderID     string
+	OccurredAt  *time.Time
+	ProcessedAt time.Time
+}
@@ -0,0 +1,26 @@
+package daos
+
+type ProductDAO struct {
+	ID        string
+	Name      string
+	Category  string
+	ImageURL  string
+	Notes     string
+	Price     int64 // em unidades menores da moeda (centavos)
+	Currency  string
+	Available bool
+	Modifiers []ProductModifierDAO
+	// Composição do combo; vazio para produtos avulsos
+	ComboItems []ProductComboItemDAO
+}
+
+type ProductComboItemDAO struct {
+	ProductID string
+	Quantity  int
+}
+
+type ProductModifierDAO struct {
+	ID         string
+	Name       string
+	PriceDelta int64 // na moeda do produto, em unidades menores
+}
@@ -0,0 +1,39 @@
+package dtos
+
+import "time"
+
+type CreateCouponDTO struct {
+	Code string
+	Type string
+	// percentage
+	Percentage int
+	// fixed_amount: valor decimal, na moeda Currency (vazio usa a moeda padrão)
+	Amount   string
+	Currency string
+	// buy_x_get_y
+	ProductID    string
+	BuyQuantity  int
+	FreeQuantity int
+
+	FirstOrderOnly bool
+	ValidFrom      *time.Time
+	ValidUntil     *time.Time
+	MaxUses        int
+}
+
+type CouponResponseDTO struct {
+	Code           string
+	Type           string
+	Percentage     int
+	Amount         *string
+	Currency       string
+	ProductID      string
+	BuyQuantity    int
+	FreeQuantity   int
+	FirstOrderOnly bool
+	ValidFrom      *time.Time
+	ValidUntil     *time.Time
+	MaxUses        int
+	UsedCount      int
+	CreatedAt      time.Time
+}
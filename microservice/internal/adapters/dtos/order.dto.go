@@ -2,10 +2,13 @@ package dtos
 
 import "time"
 
+// Valores monetários trafegam como strings decimais (ex: "19.90") para não perder precisão
+
 type OrderDTO struct {
 	ID         string
 	CustomerID *string
-	Amount     float64
+	Amount     string
+	Currency   string
 	Items      []OrderItemDTO
 }
 
@@ -14,35 +17,104 @@ type OrderItemDTO struct {
 	ProductID string
 	OrderID   string
 	Quantity  int
-	UnitPrice float64
+	UnitPrice string
+	Product   ProductSnapshotDTO
+	Notes     string
+	Modifiers []OrderItemModifierDTO
+	// Itens do combo; o preço cobrado é o UnitPrice do combo
+	Components []OrderItemDTO
+}
+
+type OrderItemModifierDTO struct {
+	ModifierID string
+	Name       string
+	PriceDelta string
+}
+
+type ProductSnapshotDTO struct {
+	Name     string
+	Category string
+	ImageURL string
+	Notes    string
 }
 
 type CreateOrderDTO struct {
-	CustomerID *string
-	Items      []CreateOrderItemDTO
+	CustomerID   *string
+	CustomerName string
+	// Vazio usa a moeda padrão
+	Currency string
+	Items    []CreateOrderItemDTO
 }
 
 type CreateOrderItemDTO struct {
 	ProductID string
 	Quantity  int
-	Price     float64
+	// Opcional: o preço vem do catálogo e, se enviado, precisa ser igual a ele
+	Price     string
+	Notes     string
+	Modifiers []CreateOrderItemModifierDTO
+}
+
+type CreateOrderItemModifierDTO struct {
+	ModifierID string
+	// Opcional, conferido com o catálogo assim como Price
+	PriceDelta string
 }
 
 type UpdateOrderDTO struct {
 	ID       string
 	StatusID string
+	// Quem alterou, registrado no histórico de status
+	Actor *string
+}
+
+type ApplyCouponDTO struct {
+	OrderID string
+	Code    string
+}
+
+type ClaimOrderDTO struct {
+	OrderID    string
+	GuestToken string
+	CustomerID string
+}
+
+type CancelOrderDTO struct {
+	OrderID string
+	Reason  string
+	// Quem cancelou, registrado no histórico de status
+	Actor *string
 }
 
 type UpdateOrderStatusDTO struct {
 	OrderID string
 	Status  string
+	// Quem alterou, registrado no histórico de status
+	Actor *string
 }
 
+const (
+	ORDER_SORT_CREATED_AT_ASC  = "created_at"
+	ORDER_SORT_CREATED_AT_DESC = "-created_at"
+)
+
 type OrderFilterDTO struct {
 	CreatedAtFrom *time.Time
 	CreatedAtTo   *time.Time
 	StatusID      *string
 	CustomerID    *string
+	Limit         int
+	Cursor        *string
+	Sort          string
+	// Preenchido pelo caso de uso a partir do Cursor
+	After *OrderCursorDTO
+	// Nomes dos status cujos pedidos ficam de fora
+	ExcludedStatuses []string
+}
+
+type OrderCursorDTO struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
 }
 
 type OrderStatusDTO struct {
@@ -53,14 +125,60 @@ type OrderStatusDTO struct {
 type OrderResponseDTO struct {
 	ID         string
 	CustomerID *string
-	Amount     float64
+	Amount     string
+	Currency   string
+	Subtotal   string
+	Discount   string
+	CouponCode *string
+	PickupCode string
 	Status     OrderStatusDTO
 	Items      []OrderItemDTO
 	CreatedAt  time.Time
 	UpdatedAt  *time.Time
+
+	CustomerName string
+	// Só no pedido recém-criado sem cliente
+	GuestToken string
+
+	CancellationReason *string
+	CancelledAt        *time.Time
+}
+
+type OrderBoardResponseDTO struct {
+	GeneratedAt time.Time
+	Groups      []OrderBoardGroupDTO
+}
+
+type OrderBoardGroupDTO struct {
+	Status OrderStatusDTO
+	Orders []OrderBoardEntryDTO
+}
+
+type OrderBoardEntryDTO struct {
+	ID           string
+	PickupCode   string
+	CustomerName string
+	CreatedAt    time.Time
+	WaitSeconds  int64
+}
+
+type OrderPageResponseDTO struct {
+	Orders     []OrderResponseDTO
+	NextCursor *string
+	Total      int64
 }
 
 type OrderStatusResponseDTO struct {
 	ID   string
 	Name string
 }
+
+type OrderStatusHistoryResponseDTO struct {
+	ID             string
+	OrderID        string
+	PreviousStatus *OrderStatusDTO
+	NewStatus      OrderStatusDTO
+	Source         string
+	Actor          *string
+	ChangedAt      time.Time
+}
@@ -11,21 +11,21 @@ func TestOrderDTO_Structure(t *testing.T) {
 	orderDTO := OrderDTO{
 		ID:         "order-123",
 		CustomerID: stringPtr("customer-456"),
-		Amount:     99.99,
+		Amount:     "99.99",
 		Items: []OrderItemDTO{
 			{
 				ID:        "item-1",
 				ProductID: "product-1",
 				OrderID:   "order-123",
 				Quantity:  2,
-				UnitPrice: 49.99,
+				UnitPrice: "49.99",
 			},
 		},
 	}
 
 	assert.Equal(t, "order-123", orderDTO.ID)
 	assert.Equal(t, "customer-456", *orderDTO.CustomerID)
-	assert.Equal(t, 99.99, orderDTO.Amount)
+	assert.Equal(t, "99.99", orderDTO.Amount)
 	assert.Len(t, orderDTO.Items, 1)
 	assert.Equal(t, "item-1", orderDTO.Items[0].ID)
 }
@@ -36,14 +36,14 @@ func TestOrderItemDTO_Structure(t *testing.T) {
 		ProductID: "product-789",
 		OrderID:   "order-456",
 		Quantity:  3,
-		UnitPrice: 25.50,
+		UnitPrice: "25.50",
 	}
 
 	assert.Equal(t, "item-123", itemDTO.ID)
 	assert.Equal(t, "product-789", itemDTO.ProductID)
 	assert.Equal(t, "order-456", itemDTO.OrderID)
 	assert.Equal(t, 3, itemDTO.Quantity)
-	assert.Equal(t, 25.50, itemDTO.UnitPrice)
+	assert.Equal(t, "25.50", itemDTO.UnitPrice)
 }
 
 func TestCreateOrderDTO_Structure(t *testing.T) {
@@ -53,7 +53,7 @@ func TestCreateOrderDTO_Structure(t *testing.T) {
 			{
 				ProductID: "product-1",
 				Quantity:  2,
-				Price:     15.99,
+				Price:     "15.99",
 			},
 		},
 	}
@@ -62,19 +62,19 @@ func TestCreateOrderDTO_Structure(t *testing.T) {
 	assert.Len(t, createOrderDTO.Items, 1)
 	assert.Equal(t, "product-1", createOrderDTO.Items[0].ProductID)
 	assert.Equal(t, 2, createOrderDTO.Items[0].Quantity)
-	assert.Equal(t, 15.99, createOrderDTO.Items[0].Price)
+	assert.Equal(t, "15.99", createOrderDTO.Items[0].Price)
 }
 
 func TestCreateOrderItemDTO_Structure(t *testing.T) {
 	createItemDTO := CreateOrderItemDTO{
 		ProductID: "product-456",
 		Quantity:  5,
-		Price:     12.50,
+		Price:     "12.50",
 	}
 
 	assert.Equal(t, "product-456", createItemDTO.ProductID)
 	assert.Equal(t, 5, createItemDTO.Quantity)
-	assert.Equal(t, 12.50, createItemDTO.Price)
+	assert.Equal(t, "12.50", createItemDTO.Price)
 }
 
 func TestUpdateOrderDTO_Structure(t *testing.T) {
@@ -135,7 +135,7 @@ func TestOrderResponseDTO_Structure(t *testing.T) {
 	responseDTO := OrderResponseDTO{
 		ID:         "order-123",
 		CustomerID: stringPtr("customer-456"),
-		Amount:     150.75,
+		Amount:     "150.75",
 		Status: OrderStatusDTO{
 			ID:   "status-1",
 			Name: "pending",
@@ -146,7 +146,7 @@ func TestOrderResponseDTO_Structure(t *testing.T) {
 				ProductID: "product-1",
 				OrderID:   "order-123",
 				Quantity:  3,
-				UnitPrice: 50.25,
+				UnitPrice: "50.25",
 			},
 		},
 		CreatedAt: now,
@@ -155,7 +155,7 @@ func TestOrderResponseDTO_Structure(t *testing.T) {
 
 	assert.Equal(t, "order-123", responseDTO.ID)
 	assert.Equal(t, "customer-456", *responseDTO.CustomerID)
-	assert.Equal(t, 150.75, responseDTO.Amount)
+	assert.Equal(t, "150.75", responseDTO.Amount)
 	assert.Equal(t, "status-1", responseDTO.Status.ID)
 	assert.Equal(t, "pending", responseDTO.Status.Name)
 	assert.Len(t, responseDTO.Items, 1)
@@ -178,12 +178,12 @@ func TestCreateOrderDTO_WithMultipleItems(t *testing.T) {
 		{
 			ProductID: "product-1",
 			Quantity:  1,
-			Price:     10.00,
+			Price:     "10.00",
 		},
 		{
 			ProductID: "product-2",
 			Quantity:  2,
-			Price:     15.00,
+			Price:     "15.00",
 		},
 	}
 
@@ -205,7 +205,7 @@ func TestCreateOrderDTO_WithNilCustomer(t *testing.T) {
 			{
 				ProductID: "product-1",
 				Quantity:  1,
-				Price:     10.00,
+				Price:     "10.00",
 			},
 		},
 	}
@@ -0,0 +1,10 @@
+package dtos
+
+import "time"
+
+// Mensagem do broker gravada junto com o pedido, para reconhecer reentregas
+type ProcessedMessageDTO struct {
+	Consumer   string
+	MessageID  string
+	OccurredAt *time.Time
+}
@@ -0,0 +1,90 @@
+package gateways
+
+import (
+	"context"
+	"fmt"
+
+	"microservice/internal/adapters/daos"
+	"microservice/internal/domain/entities"
+	"microservice/internal/domain/exceptions"
+	"microservice/internal/domain/value_objects"
+	"microservice/internal/interfaces"
+)
+
+type CouponGateway struct {
+	datasource interfaces.ICouponDataSource
+}
+
+func NewCouponGateway(datasource interfaces.ICouponDataSource) *CouponGateway {
+	return &CouponGateway{datasource: datasource}
+}
+
+func (g *CouponGateway) Create(ctx context.Context, coupon entities.Coupon) error {
+	return g.datasource.Create(ctx, toCouponDAO(coupon))
+}
+
+func (g *CouponGateway) FindByCode(ctx context.Context, code string) (*entities.Coupon, error) {
+	couponDAO, err := g.datasource.FindByCode(ctx, entities.NormalizeCouponCode(code))
+	if err != nil {
+		return nil, err
+	}
+	if couponDAO == nil {
+		return nil, &exceptions.CouponNotFoundException{
+			Message: fmt.Sprintf("Coupon %s not found", entities.NormalizeCouponCode(code)),
+		}
+	}
+
+	return toCouponEntity(*couponDAO)
+}
+
+func toCouponDAO(coupon entities.Coupon) daos.CouponDAO {
+	currency := coupon.FixedAmount.Currency()
+	if currency == "" {
+		currency = value_objects.DEFAULT_CURRENCY
+	}
+
+	return daos.CouponDAO{
+		Code:           coupon.Code,
+		Type:           coupon.Type,
+		Percentage:     coupon.Percentage,
+		FixedAmount:    coupon.FixedAmount.MinorUnits(),
+		Currency:       currency,
+		ProductID:      coupon.ProductID,
+		BuyQuantity:    coupon.BuyQuantity,
+		FreeQuantity:   coupon.FreeQuantity,
+		FirstOrderOnly: coupon.FirstOrderOnly,
+		ValidFrom:      coupon.ValidFrom,
+		ValidUntil:     coupon.ValidUntil,
+		MaxUses:        coupon.MaxUses,
+		UsedCount:      coupon.UsedCount,
+		CreatedAt:      coupon.CreatedAt,
+	}
+}
+
+func toCouponEntity(couponDAO daos.CouponDAO) (*entities.Coupon, error) {
+	currency := couponDAO.Currency
+	if currency == "" {
+		currency = value_objects.DEFAULT_CURRENCY
+	}
+
+	fixedAmount, err := value_objects.NewMoney(couponDAO.FixedAmount, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.NewCoupon(entities.Coupon{
+		Code:           couponDAO.Code,
+		Type:           couponDAO.Type,
+		Percentage:     couponDAO.Percentage,
+		FixedAmount:    fixedAmount,
+		ProductID:      couponDAO.ProductID,
+		BuyQuantity:    couponDAO.BuyQuantity,
+		FreeQuantity:   couponDAO.FreeQuantity,
+		FirstOrderOnly: couponDAO.FirstOrderOnly,
+		ValidFrom:      couponDAO.ValidFrom,
+		ValidUntil:     couponDAO.ValidUntil,
+		MaxUses:        couponDAO.MaxUses,
+		UsedCount:      couponDAO.UsedCount,
+		CreatedAt:      couponDAO.CreatedAt,
+	})
+}
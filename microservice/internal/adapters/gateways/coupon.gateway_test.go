@@ -0,0 +1,91 @@
+package gateways
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"microservice/internal/adapters/daos"
+	"microservice/internal/domain/entities"
+	"microservice/internal/domain/exceptions"
+)
+
+type mockCouponDataSource struct {
+	createFunc     func(coupon daos.CouponDAO) error
+	findByCodeFunc func(code string) (*daos.CouponDAO, error)
+}
+
+func (m *mockCouponDataSource) Create(ctx context.Context, coupon daos.CouponDAO) error {
+	if m.createFunc != nil {
+		return m.createFunc(coupon)
+	}
+	return nil
+}
+
+func (m *mockCouponDataSource) FindByCode(ctx context.Context, code string) (*daos.CouponDAO, error) {
+	if m.findByCodeFunc != nil {
+		return m.findByCodeFunc(code)
+	}
+	return nil, nil
+}
+
+func TestCouponGateway_Create(t *testing.T) {
+	var saved daos.CouponDAO
+	ds := &mockCouponDataSource{
+		createFunc: func(coupon daos.CouponDAO) error {
+			saved = coupon
+			return nil
+		},
+	}
+
+	coupon := entities.Coupon{Code: "MENOS5", Type: entities.COUPON_TYPE_FIXED_AMOUNT, FixedAmount: brl("5.00"), MaxUses: 10}
+	if err := NewCouponGateway(ds).Create(context.Background(), coupon); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+
+	if saved.Code != "MENOS5" || saved.FixedAmount != 500 || saved.Currency != "BRL" || saved.MaxUses != 10 {
+		t.Errorf("Create() saved = %+v, want MENOS5 500 BRL max 10", saved)
+	}
+}
+
+func TestCouponGateway_FindByCode_Success(t *testing.T) {
+	var requested string
+	ds := &mockCouponDataSource{
+		findByCodeFunc: func(code string) (*daos.CouponDAO, error) {
+			requested = code
+			return &daos.CouponDAO{Code: code, Type: entities.COUPON_TYPE_PERCENTAGE, Percentage: 10, Currency: "BRL", UsedCount: 3}, nil
+		},
+	}
+
+	coupon, err := NewCouponGateway(ds).FindByCode(context.Background(), " promo10 ")
+	if err != nil {
+		t.Fatalf("FindByCode() unexpected error: %v", err)
+	}
+	if requested != "PROMO10" {
+		t.Errorf("FindByCode() looked up %q, want PROMO10", requested)
+	}
+	if coupon.Percentage != 10 || coupon.UsedCount != 3 {
+		t.Errorf("FindByCode() = %+v, want 10%% used 3 times", coupon)
+	}
+}
+
+func TestCouponGateway_FindByCode_NotFound(t *testing.T) {
+	_, err := NewCouponGateway(&mockCouponDataSource{}).FindByCode(context.Background(), "PROMO10")
+
+	if _, ok := err.(*exceptions.CouponNotFoundException); !ok {
+		t.Errorf("FindByCode() expected CouponNotFoundException, got %T", err)
+	}
+}
+
+func TestCouponGateway_FindByCode_DataSourceError(t *testing.T) {
+	ds := &mockCouponDataSource{
+		findByCodeFunc: func(code string) (*daos.CouponDAO, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+
+	_, err := NewCouponGateway(ds).FindByCode(context.Background(), "PROMO10")
+	if err == nil {
+		t.Error("FindByCode() expected error")
+	}
+}
@@ -1,6 +1,7 @@
 package gateways
 
 import (
+	"context"
 	"microservice/internal/domain/entities"
 	"microservice/internal/interfaces"
 )
@@ -13,8 +14,8 @@ func NewOrderStatusGateway(datasource interfaces.IOrderStatusDataSource) *OrderS
 	return &OrderStatusGateway{datasource: datasource}
 }
 
-func (g *OrderStatusGateway) FindAll() ([]entities.OrderStatus, error) {
-	statusDAOs, err := g.datasource.FindAll()
+func (g *OrderStatusGateway) FindAll(ctx context.Context) ([]entities.OrderStatus, error) {
+	statusDAOs, err := g.datasource.FindAll(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -31,8 +32,8 @@ func (g *OrderStatusGateway) FindAll() ([]entities.OrderStatus, error) {
 	return statuses, nil
 }
 
-func (g *OrderStatusGateway) FindByID(id string) (*entities.OrderStatus, error) {
-	statusDAO, err := g.datasource.FindByID(id)
+func (g *OrderStatusGateway) FindByID(ctx context.Context, id string) (*entities.OrderStatus, error) {
+	statusDAO, err := g.datasource.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -45,8 +46,8 @@ func (g *OrderStatusGateway) FindByID(id string) (*entities.OrderStatus, error)
 	return status, nil
 }
 
-func (g *OrderStatusGateway) FindByName(name string) (*entities.OrderStatus, error) {
-	statusDAO, err := g.datasource.FindByName(name)
+func (g *OrderStatusGateway) FindByName(ctx context.Context, name string) (*entities.OrderStatus, error) {
+	statusDAO, err := g.datasource.FindByName(ctx, name)
 	if err != nil {
 		return nil, err
 	}
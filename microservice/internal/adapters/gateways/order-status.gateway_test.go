@@ -1,6 +1,7 @@
 package gateways
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -13,21 +14,21 @@ type mockOrderStatusDataSource struct {
 	findByNameFunc func(name string) (daos.OrderStatusDAO, error)
 }
 
-func (m *mockOrderStatusDataSource) FindAll() ([]daos.OrderStatusDAO, error) {
+func (m *mockOrderStatusDataSource) FindAll(ctx context.Context) ([]daos.OrderStatusDAO, error) {
 	if m.findAllFunc != nil {
 		return m.findAllFunc()
 	}
 	return []daos.OrderStatusDAO{}, nil
 }
 
-func (m *mockOrderStatusDataSource) FindByID(id string) (daos.OrderStatusDAO, error) {
+func (m *mockOrderStatusDataSource) FindByID(ctx context.Context, id string) (daos.OrderStatusDAO, error) {
 	if m.findByIDFunc != nil {
 		return m.findByIDFunc(id)
 	}
 	return daos.OrderStatusDAO{}, nil
 }
 
-func (m *mockOrderStatusDataSource) FindByName(name string) (daos.OrderStatusDAO, error) {
+func (m *mockOrderStatusDataSource) FindByName(ctx context.Context, name string) (daos.OrderStatusDAO, error) {
 	if m.findByNameFunc != nil {
 		return m.findByNameFunc(name)
 	}
@@ -60,7 +61,7 @@ func TestOrderStatusGateway_FindAll_Success(t *testing.T) {
 	}
 
 	gateway := NewOrderStatusGateway(ds)
-	statuses, err := gateway.FindAll()
+	statuses, err := gateway.FindAll(context.Background())
 
 	if err != nil {
 		t.Errorf("FindAll() unexpected error: %v", err)
@@ -79,7 +80,7 @@ func TestOrderStatusGateway_FindAll_Error(t *testing.T) {
 	}
 
 	gateway := NewOrderStatusGateway(ds)
-	_, err := gateway.FindAll()
+	_, err := gateway.FindAll(context.Background())
 
 	if err == nil {
 		t.Error("FindAll() expected error, got nil")
@@ -96,7 +97,7 @@ func TestOrderStatusGateway_FindAll_InvalidStatus(t *testing.T) {
 	}
 
 	gateway := NewOrderStatusGateway(ds)
-	_, err := gateway.FindAll()
+	_, err := gateway.FindAll(context.Background())
 
 	if err == nil {
 		t.Error("FindAll() expected error for invalid status name, got nil")
@@ -114,7 +115,7 @@ func TestOrderStatusGateway_FindByID_Success(t *testing.T) {
 	}
 
 	gateway := NewOrderStatusGateway(ds)
-	status, err := gateway.FindByID("status-1")
+	status, err := gateway.FindByID(context.Background(), "status-1")
 
 	if err != nil {
 		t.Errorf("FindByID() unexpected error: %v", err)
@@ -137,7 +138,7 @@ func TestOrderStatusGateway_FindByID_Error(t *testing.T) {
 	}
 
 	gateway := NewOrderStatusGateway(ds)
-	_, err := gateway.FindByID("status-1")
+	_, err := gateway.FindByID(context.Background(), "status-1")
 
 	if err == nil {
 		t.Error("FindByID() expected error, got nil")
@@ -155,7 +156,7 @@ func TestOrderStatusGateway_FindByID_InvalidStatus(t *testing.T) {
 	}
 
 	gateway := NewOrderStatusGateway(ds)
-	_, err := gateway.FindByID("status-1")
+	_, err := gateway.FindByID(context.Background(), "status-1")
 
 	if err == nil {
 		t.Error("FindByID() expected error for invalid status name, got nil")
@@ -173,7 +174,7 @@ func TestOrderStatusGateway_FindByName_Success(t *testing.T) {
 	}
 
 	gateway := NewOrderStatusGateway(ds)
-	status, err := gateway.FindByName("Pending")
+	status, err := gateway.FindByName(context.Background(), "Pending")
 
 	if err != nil {
 		t.Errorf("FindByName() unexpected error: %v", err)
@@ -192,7 +193,7 @@ func TestOrderStatusGateway_FindByName_Error(t *testing.T) {
 	}
 
 	gateway := NewOrderStatusGateway(ds)
-	_, err := gateway.FindByName("Pending")
+	_, err := gateway.FindByName(context.Background(), "Pending")
 
 	if err == nil {
 		t.Error("FindByName() expected error, got nil")
@@ -210,7 +211,7 @@ func TestOrderStatusGateway_FindByName_InvalidStatus(t *testing.T) {
 	}
 
 	gateway := NewOrderStatusGateway(ds)
-	_, err := gateway.FindByName("ab")
+	_, err := gateway.FindByName(context.Background(), "ab")
 
 	if err == nil {
 		t.Error("FindByName() expected error for invalid status name, got nil")
@@ -1,9 +1,17 @@
 package gateways
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"microservice/internal/adapters/brokers"
 	"microservice/internal/adapters/daos"
 	"microservice/internal/adapters/dtos"
 	"microservice/internal/domain/entities"
+	"microservice/internal/domain/exceptions"
+	"microservice/internal/domain/value_objects"
 	"microservice/internal/interfaces"
 )
 
@@ -15,62 +23,254 @@ func NewOrderGateway(datasource interfaces.IOrderDataSource) *OrderGateway {
 	return &OrderGateway{datasource: datasource}
 }
 
-func (g *OrderGateway) Create(order entities.Order) error {
-	items := make([]daos.OrderItemDAO, len(order.Items))
-	for i, item := range order.Items {
-		items[i] = daos.OrderItemDAO{
-			ID:        item.ID,
-			OrderID:   item.OrderID,
-			ProductID: item.ProductID.Value(),
-			Quantity:  item.Quantity.Value(),
-			UnitPrice: item.UnitPrice.Value(),
+func (g *OrderGateway) Create(ctx context.Context, order entities.Order, events ...brokers.OrderEvent) error {
+	outbox, err := toOutboxMessages(events)
+	if err != nil {
+		return err
+	}
+
+	return g.datasource.Create(ctx, toOrderDAO(order), outbox...)
+}
+
+func (g *OrderGateway) FindByID(ctx context.Context, id string) (*entities.Order, error) {
+	orderDAO, err := g.datasource.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOrderEntity(orderDAO)
+}
+
+func (g *OrderGateway) FindByPickupCode(ctx context.Context, day string, code value_objects.PickupCode) (*entities.Order, error) {
+	orderDAO, err := g.datasource.FindByPickupCode(ctx, day, code.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	return toOrderEntity(orderDAO)
+}
+
+func (g *OrderGateway) NextPickupCode(ctx context.Context, day string) (value_objects.PickupCode, error) {
+	sequence, err := g.datasource.NextPickupSequence(ctx, day)
+	if err != nil {
+		return value_objects.PickupCode{}, err
+	}
+
+	return value_objects.NewPickupCode(sequence)
+}
+
+func (g *OrderGateway) FindBoard(ctx context.Context, statuses []entities.OrderStatus, createdFrom time.Time) ([]entities.OrderBoardEntry, error) {
+	statusIDs := make([]string, len(statuses))
+	for i, status := range statuses {
+		statusIDs[i] = status.ID
+	}
+
+	entryDAOs, err := g.datasource.FindBoard(ctx, statusIDs, createdFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]entities.OrderBoardEntry, len(entryDAOs))
+	for i, entryDAO := range entryDAOs {
+		entry, err := toOrderBoardEntry(entryDAO)
+		if err != nil {
+			return nil, err
+		}
+		entries[i] = entry
+	}
+	return entries, nil
+}
+
+func (g *OrderGateway) Count(ctx context.Context, filter dtos.OrderFilterDTO) (int64, error) {
+	return g.datasource.Count(ctx, filter)
+}
+
+func (g *OrderGateway) FindAll(ctx context.Context, filter dtos.OrderFilterDTO) ([]entities.Order, error) {
+	orderDAOs, err := g.datasource.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]entities.Order, 0, len(orderDAOs))
+	for _, orderDAO := range orderDAOs {
+		order, err := toOrderEntity(orderDAO)
+		if err != nil {
+			return nil, err
 		}
+
+		orders = append(orders, *order)
+	}
+
+	return orders, nil
+}
+
+func (g *OrderGateway) Update(ctx context.Context, order entities.Order, events ...brokers.OrderEvent) error {
+	outbox, err := toOutboxMessages(events)
+	if err != nil {
+		return err
 	}
 
-	return g.datasource.Create(daos.OrderDAO{
+	return g.datasource.Update(ctx, toOrderDAO(order), outbox...)
+}
+
+func (g *OrderGateway) UpdateFromMessage(ctx context.Context, order entities.Order, message dtos.ProcessedMessageDTO, events ...brokers.OrderEvent) error {
+	outbox, err := toOutboxMessages(events)
+	if err != nil {
+		return err
+	}
+
+	return g.datasource.UpdateFromMessage(ctx, toOrderDAO(order), toProcessedMessageDAO(order.ID, message), outbox...)
+}
+
+func (g *OrderGateway) ApplyCoupon(ctx context.Context, order entities.Order, events ...brokers.OrderEvent) error {
+	outbox, err := toOutboxMessages(events)
+	if err != nil {
+		return err
+	}
+
+	return g.datasource.ApplyCoupon(ctx, toOrderDAO(order), outbox...)
+}
+
+func (g *OrderGateway) Claim(ctx context.Context, orderID string, customerID string, guestTokenHash string, claimedAt time.Time) error {
+	return g.datasource.Claim(ctx, orderID, customerID, guestTokenHash, claimedAt)
+}
+
+func (g *OrderGateway) ReissueGuestToken(ctx context.Context, orderID string, guestTokenHash string) error {
+	return g.datasource.ReissueGuestToken(ctx, orderID, guestTokenHash)
+}
+
+// Valores em unidades menores; componentes de combo são gravados como itens que apontam para o combo
+func toOrderDAO(order entities.Order) daos.OrderDAO {
+	items := make([]daos.OrderItemDAO, 0, len(order.Items))
+	for _, item := range order.Items {
+		items = append(items, toOrderItemDAO(item, nil))
+
+		comboItemID := item.ID
+		for _, component := range item.Components {
+			items = append(items, toOrderItemDAO(component, &comboItemID))
+		}
+	}
+
+	return daos.OrderDAO{
 		ID:         order.ID,
 		CustomerID: order.CustomerID,
-		Amount:     order.Amount.Value(),
+		Amount:     order.Amount.Value().MinorUnits(),
+		Currency:   order.Amount.Value().Currency(),
+		Subtotal:   order.Subtotal.MinorUnits(),
+		Discount:   order.Discount.MinorUnits(),
+		CouponCode: order.CouponCode,
 		Status: daos.OrderStatusDAO{
 			ID:   order.Status.ID,
 			Name: order.Status.Name.Value(),
 		},
-		Items:     items,
-		CreatedAt: order.CreatedAt,
-		UpdatedAt: order.UpdatedAt,
-	})
+		Items:         items,
+		CreatedAt:     order.CreatedAt,
+		UpdatedAt:     order.UpdatedAt,
+		StatusChanges: toStatusHistoryDAOs(order.StatusChanges),
+
+		CustomerName: toCustomerNameDAO(order.CustomerName),
+		PickupCode:   toPickupCodeDAO(order.PickupCode),
+		PickupDate:   toPickupDateDAO(order),
+
+		GuestTokenHash: order.GuestTokenHash,
+
+		CancellationReason: order.CancellationReason,
+		CancelledAt:        order.CancelledAt,
+
+		Version: order.Version,
+	}
+}
+
+func toCustomerNameDAO(name string) *string {
+	if name == "" {
+		return nil
+	}
+	return &name
+}
+
+func toPickupCodeDAO(code value_objects.PickupCode) *string {
+	if code.IsZero() {
+		return nil
+	}
+	value := code.Value()
+	return &value
+}
+
+func toPickupDateDAO(order entities.Order) *string {
+	if order.PickupCode.IsZero() {
+		return nil
+	}
+	return &order.PickupDate
+}
+
+func toOrderItemDAO(item entities.OrderItem, parentItemID *string) daos.OrderItemDAO {
+	return daos.OrderItemDAO{
+		ID:        item.ID,
+		OrderID:   item.OrderID,
+		ProductID: item.ProductID.Value(),
+		Quantity:  item.Quantity.Value(),
+		UnitPrice: item.UnitPrice.Value().MinorUnits(),
+
+		ProductName:     item.Product.Name,
+		ProductCategory: item.Product.Category,
+		ProductImageURL: item.Product.ImageURL,
+		ProductNotes:    item.Product.Notes,
+
+		Notes:     item.Notes,
+		Modifiers: toOrderItemModifierDAOs(item),
+
+		ParentItemID: parentItemID,
+	}
 }
 
-func (g *OrderGateway) FindByID(id string) (*entities.Order, error) {
-	orderDAO, err := g.datasource.FindByID(id)
+func toOrderBoardEntry(entryDAO daos.OrderBoardEntryDAO) (entities.OrderBoardEntry, error) {
+	status, err := entities.NewOrderStatus(entryDAO.StatusID, entryDAO.StatusName)
 	if err != nil {
-		return nil, err
+		return entities.OrderBoardEntry{}, err
 	}
 
+	entry := entities.OrderBoardEntry{
+		OrderID:   entryDAO.ID,
+		Status:    *status,
+		CreatedAt: entryDAO.CreatedAt,
+	}
+	if entryDAO.CustomerName != nil {
+		entry.CustomerName = *entryDAO.CustomerName
+	}
+	if entryDAO.PickupCode != nil {
+		entry.PickupCode, err = value_objects.ParsePickupCode(*entryDAO.PickupCode)
+		if err != nil {
+			return entities.OrderBoardEntry{}, err
+		}
+	}
+	return entry, nil
+}
+
+func toOrderEntity(orderDAO daos.OrderDAO) (*entities.Order, error) {
 	status, err := entities.NewOrderStatus(orderDAO.Status.ID, orderDAO.Status.Name)
 	if err != nil {
 		return nil, err
 	}
 
-	items := make([]entities.OrderItem, len(orderDAO.Items))
-	for i, itemDAO := range orderDAO.Items {
-		item, err := entities.NewOrderItem(
-			itemDAO.ID,
-			itemDAO.ProductID,
-			itemDAO.OrderID,
-			itemDAO.Quantity,
-			itemDAO.UnitPrice,
-		)
-		if err != nil {
-			return nil, err
-		}
-		items[i] = *item
+	currency := orderDAO.Currency
+	if currency == "" {
+		currency = value_objects.DEFAULT_CURRENCY
+	}
+
+	items, err := toOrderItemEntities(orderDAO.Items, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := value_objects.NewMoney(orderDAO.Amount, currency)
+	if err != nil {
+		return nil, err
 	}
 
 	order, err := entities.NewOrderWithItems(
 		orderDAO.ID,
 		orderDAO.CustomerID,
-		orderDAO.Amount,
+		amount,
 		*status,
 		items,
 		orderDAO.CreatedAt,
@@ -79,83 +279,261 @@ func (g *OrderGateway) FindByID(id string) (*entities.Order, error) {
 	if err != nil {
 		return nil, err
 	}
+	order.CancellationReason = orderDAO.CancellationReason
+	order.CancelledAt = orderDAO.CancelledAt
+	order.GuestTokenHash = orderDAO.GuestTokenHash
+	order.Version = orderDAO.Version
+	if orderDAO.CustomerName != nil {
+		order.CustomerName = *orderDAO.CustomerName
+	}
+
+	if orderDAO.PickupCode != nil && orderDAO.PickupDate != nil {
+		order.PickupCode, err = value_objects.ParsePickupCode(*orderDAO.PickupCode)
+		if err != nil {
+			return nil, err
+		}
+		order.PickupDate = *orderDAO.PickupDate
+	}
+
+	// Pedidos anteriores aos cupons não têm subtotal gravado
+	if orderDAO.Subtotal == 0 {
+		return order, nil
+	}
+
+	subtotal, err := value_objects.NewMoney(orderDAO.Subtotal, currency)
+	if err != nil {
+		return nil, err
+	}
+	discount, err := value_objects.NewMoney(orderDAO.Discount, currency)
+	if err != nil {
+		return nil, err
+	}
+	if err := order.SetDiscount(subtotal, discount, orderDAO.CouponCode); err != nil {
+		return nil, err
+	}
 
 	return order, nil
 }
 
-func (g *OrderGateway) FindAll(filter dtos.OrderFilterDTO) ([]entities.Order, error) {
-	orderDAOs, err := g.datasource.FindAll(filter)
+// Reagrupa os componentes sob o item do combo, mantendo a ordem gravada
+func toOrderItemEntities(itemDAOs []daos.OrderItemDAO, currency string) ([]entities.OrderItem, error) {
+	items := make([]entities.OrderItem, 0, len(itemDAOs))
+	comboIndexes := make(map[string]int)
+	var components []daos.OrderItemDAO
+
+	for _, itemDAO := range itemDAOs {
+		if itemDAO.ParentItemID != nil {
+			components = append(components, itemDAO)
+			continue
+		}
+
+		item, err := toOrderItemEntity(itemDAO, currency)
+		if err != nil {
+			return nil, err
+		}
+		comboIndexes[item.ID] = len(items)
+		items = append(items, *item)
+	}
+
+	for _, componentDAO := range components {
+		index, ok := comboIndexes[*componentDAO.ParentItemID]
+		if !ok {
+			return nil, &exceptions.InvalidOrderItemData{
+				Message: fmt.Sprintf("Combo item %s of component %s not found", *componentDAO.ParentItemID, componentDAO.ID),
+			}
+		}
+
+		component, err := toOrderItemEntity(componentDAO, currency)
+		if err != nil {
+			return nil, err
+		}
+		if err := items[index].AddComponent(*component); err != nil {
+			return nil, err
+		}
+	}
+
+	return items, nil
+}
+
+func toOrderItemEntity(itemDAO daos.OrderItemDAO, currency string) (*entities.OrderItem, error) {
+	unitPrice, err := value_objects.NewMoney(itemDAO.UnitPrice, currency)
 	if err != nil {
 		return nil, err
 	}
 
-	orders := make([]entities.Order, 0, len(orderDAOs))
-	for _, orderDAO := range orderDAOs {
-		status, err := entities.NewOrderStatus(orderDAO.Status.ID, orderDAO.Status.Name)
+	modifiers, err := toOrderItemModifierEntities(itemDAO.Modifiers, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := entities.NewOrderItem(
+		itemDAO.ID,
+		itemDAO.ProductID,
+		itemDAO.OrderID,
+		itemDAO.Quantity,
+		unitPrice,
+		itemDAO.Notes,
+		modifiers,
+	)
+	if err != nil {
+		return nil, err
+	}
+	item.Product = entities.ProductSnapshot{
+		Name:     itemDAO.ProductName,
+		Category: itemDAO.ProductCategory,
+		ImageURL: itemDAO.ProductImageURL,
+		Notes:    itemDAO.ProductNotes,
+	}
+	return item, nil
+}
+
+func toOrderItemModifierDAOs(item entities.OrderItem) []daos.OrderItemModifierDAO {
+	modifiers := make([]daos.OrderItemModifierDAO, len(item.Modifiers))
+	for i, modifier := range item.Modifiers {
+		modifiers[i] = daos.OrderItemModifierDAO{
+			ID:          modifier.ID,
+			OrderItemID: item.ID,
+			ModifierID:  modifier.ModifierID,
+			Name:        modifier.Name,
+			PriceDelta:  modifier.PriceDelta.MinorUnits(),
+		}
+	}
+	return modifiers
+}
+
+func toOrderItemModifierEntities(modifierDAOs []daos.OrderItemModifierDAO, currency string) ([]entities.OrderItemModifier, error) {
+	modifiers := make([]entities.OrderItemModifier, len(modifierDAOs))
+	for i, modifierDAO := range modifierDAOs {
+		priceDelta, err := value_objects.NewMoney(modifierDAO.PriceDelta, currency)
 		if err != nil {
 			return nil, err
 		}
 
-		items := make([]entities.OrderItem, len(orderDAO.Items))
-		for i, itemDAO := range orderDAO.Items {
-			item, err := entities.NewOrderItem(
-				itemDAO.ID,
-				itemDAO.ProductID,
-				itemDAO.OrderID,
-				itemDAO.Quantity,
-				itemDAO.UnitPrice,
-			)
+		modifiers[i] = entities.OrderItemModifier{
+			ID:         modifierDAO.ID,
+			ModifierID: modifierDAO.ModifierID,
+			Name:       modifierDAO.Name,
+			PriceDelta: priceDelta,
+		}
+	}
+	return modifiers, nil
+}
+
+func (g *OrderGateway) Delete(ctx context.Context, id string, events ...brokers.OrderEvent) error {
+	outbox, err := toOutboxMessages(events)
+	if err != nil {
+		return err
+	}
+
+	return g.datasource.Delete(ctx, id, outbox...)
+}
+
+func (g *OrderGateway) Restore(ctx context.Context, id string, events ...brokers.OrderEvent) error {
+	outbox, err := toOutboxMessages(events)
+	if err != nil {
+		return err
+	}
+
+	return g.datasource.Restore(ctx, id, outbox...)
+}
+
+func (g *OrderGateway) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	return g.datasource.PurgeDeleted(ctx, deletedBefore, limit)
+}
+
+func (g *OrderGateway) FindStatusHistory(ctx context.Context, orderID string) ([]entities.OrderStatusChange, error) {
+	historyDAOs, err := g.datasource.FindStatusHistory(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]entities.OrderStatusChange, 0, len(historyDAOs))
+	for _, changeDAO := range historyDAOs {
+		var previousStatus *entities.OrderStatus
+		if changeDAO.PreviousStatus != nil {
+			previousStatus, err = entities.NewOrderStatus(changeDAO.PreviousStatus.ID, changeDAO.PreviousStatus.Name)
 			if err != nil {
 				return nil, err
 			}
-			items[i] = *item
 		}
 
-		order, err := entities.NewOrderWithItems(
-			orderDAO.ID,
-			orderDAO.CustomerID,
-			orderDAO.Amount,
-			*status,
-			items,
-			orderDAO.CreatedAt,
-			orderDAO.UpdatedAt,
+		newStatus, err := entities.NewOrderStatus(changeDAO.NewStatus.ID, changeDAO.NewStatus.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		change, err := entities.NewOrderStatusChange(
+			changeDAO.ID,
+			changeDAO.OrderID,
+			previousStatus,
+			*newStatus,
+			changeDAO.Source,
+			changeDAO.Actor,
+			changeDAO.ChangedAt,
 		)
 		if err != nil {
 			return nil, err
 		}
 
-		orders = append(orders, *order)
+		history = append(history, *change)
 	}
 
-	return orders, nil
+	return history, nil
 }
 
-func (g *OrderGateway) Update(order entities.Order) error {
-	items := make([]daos.OrderItemDAO, len(order.Items))
-	for i, item := range order.Items {
-		items[i] = daos.OrderItemDAO{
-			ID:        item.ID,
-			OrderID:   item.OrderID,
-			ProductID: item.ProductID.Value(),
-			Quantity:  item.Quantity.Value(),
-			UnitPrice: item.UnitPrice.Value(),
+func toStatusHistoryDAOs(changes []entities.OrderStatusChange) []daos.OrderStatusHistoryDAO {
+	history := make([]daos.OrderStatusHistoryDAO, len(changes))
+	for i, change := range changes {
+		var previousStatus *daos.OrderStatusDAO
+		if change.PreviousStatus != nil {
+			previousStatus = &daos.OrderStatusDAO{
+				ID:   change.PreviousStatus.ID,
+				Name: change.PreviousStatus.Name.Value(),
+			}
+		}
+
+		history[i] = daos.OrderStatusHistoryDAO{
+			ID:             change.ID,
+			OrderID:        change.OrderID,
+			PreviousStatus: previousStatus,
+			NewStatus: daos.OrderStatusDAO{
+				ID:   change.NewStatus.ID,
+				Name: change.NewStatus.Name.Value(),
+			},
+			Source:    change.Source,
+			Actor:     change.Actor,
+			ChangedAt: change.ChangedAt,
 		}
 	}
+	return history
+}
 
-	return g.datasource.Update(daos.OrderDAO{
-		ID:         order.ID,
-		CustomerID: order.CustomerID,
-		Amount:     order.Amount.Value(),
-		Status: daos.OrderStatusDAO{
-			ID:   order.Status.ID,
-			Name: order.Status.Name.Value(),
-		},
-		Items:     items,
-		CreatedAt: order.CreatedAt,
-		UpdatedAt: order.UpdatedAt,
-	})
+func toProcessedMessageDAO(orderID string, message dtos.ProcessedMessageDTO) daos.ProcessedMessageDAO {
+	return daos.ProcessedMessageDAO{
+		Consumer:    message.Consumer,
+		MessageID:   message.MessageID,
+		OrderID:     orderID,
+		OccurredAt:  message.OccurredAt,
+		ProcessedAt: time.Now().UTC(),
+	}
 }
 
-func (g *OrderGateway) Delete(id string) error {
-	return g.datasource.Delete(id)
+func toOutboxMessages(events []brokers.OrderEvent) ([]daos.OutboxMessageDAO, error) {
+	outbox := make([]daos.OutboxMessageDAO, len(events))
+	for i, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s event: %w", event.Type, err)
+		}
+
+		outbox[i] = daos.OutboxMessageDAO{
+			ID:            event.ID,
+			OrderID:       event.OrderID,
+			EventType:     event.Type,
+			Payload:       payload,
+			CreatedAt:     event.OccurredAt,
+			NextAttemptAt: event.OccurredAt,
+		}
+	}
+	return outbox, nil
 }
@@ -1,58 +1,165 @@
 package gateways
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
 
+	"microservice/internal/adapters/brokers"
 	"microservice/internal/adapters/daos"
 	"microservice/internal/adapters/dtos"
 	"microservice/internal/domain/entities"
+	"microservice/internal/domain/exceptions"
+	"microservice/internal/domain/value_objects"
 )
 
 type mockOrderDataSource struct {
 	createFunc   func(order daos.OrderDAO) error
 	findAllFunc  func(filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error)
+	countFunc    func(filter dtos.OrderFilterDTO) (int64, error)
 	findByIDFunc func(id string) (daos.OrderDAO, error)
 	updateFunc   func(order daos.OrderDAO) error
 	deleteFunc   func(id string) error
+	outbox       []daos.OutboxMessageDAO
+	contexts     []context.Context
+
+	processedMessages []daos.ProcessedMessageDAO
+
+	findStatusHistoryFunc func(orderID string) ([]daos.OrderStatusHistoryDAO, error)
+	applyCouponFunc       func(order daos.OrderDAO) error
+	restoreFunc           func(id string) error
+	purgeDeletedFunc      func(deletedBefore time.Time, limit int) (int64, error)
+	claimFunc             func(orderID string, customerID string, guestTokenHash string, claimedAt time.Time) error
+	reissueGuestTokenFunc func(orderID string, guestTokenHash string) error
+
+	findByPickupCodeFunc   func(day string, code string) (daos.OrderDAO, error)
+	nextPickupSequenceFunc func(day string) (int, error)
+	findBoardFunc          func(statusIDs []string, createdFrom time.Time) ([]daos.OrderBoardEntryDAO, error)
 }
 
-func (m *mockOrderDataSource) Create(order daos.OrderDAO) error {
+func brl(value string) value_objects.Money {
+	money, _ := value_objects.ParseMoney(value, value_objects.DEFAULT_CURRENCY)
+	return money
+}
+
+func (m *mockOrderDataSource) Create(ctx context.Context, order daos.OrderDAO, outbox ...daos.OutboxMessageDAO) error {
+	m.outbox = append(m.outbox, outbox...)
 	if m.createFunc != nil {
 		return m.createFunc(order)
 	}
 	return nil
 }
 
-func (m *mockOrderDataSource) FindAll(filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error) {
+func (m *mockOrderDataSource) FindAll(ctx context.Context, filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error) {
 	if m.findAllFunc != nil {
 		return m.findAllFunc(filter)
 	}
 	return []daos.OrderDAO{}, nil
 }
 
-func (m *mockOrderDataSource) FindByID(id string) (daos.OrderDAO, error) {
+func (m *mockOrderDataSource) Count(ctx context.Context, filter dtos.OrderFilterDTO) (int64, error) {
+	if m.countFunc != nil {
+		return m.countFunc(filter)
+	}
+	return 0, nil
+}
+
+func (m *mockOrderDataSource) FindByID(ctx context.Context, id string) (daos.OrderDAO, error) {
+	m.contexts = append(m.contexts, ctx)
 	if m.findByIDFunc != nil {
 		return m.findByIDFunc(id)
 	}
 	return daos.OrderDAO{}, nil
 }
 
-func (m *mockOrderDataSource) Update(order daos.OrderDAO) error {
+func (m *mockOrderDataSource) Update(ctx context.Context, order daos.OrderDAO, outbox ...daos.OutboxMessageDAO) error {
+	m.outbox = append(m.outbox, outbox...)
 	if m.updateFunc != nil {
 		return m.updateFunc(order)
 	}
 	return nil
 }
 
-func (m *mockOrderDataSource) Delete(id string) error {
+func (m *mockOrderDataSource) UpdateFromMessage(ctx context.Context, order daos.OrderDAO, message daos.ProcessedMessageDAO, outbox ...daos.OutboxMessageDAO) error {
+	m.processedMessages = append(m.processedMessages, message)
+	return m.Update(ctx, order, outbox...)
+}
+
+func (m *mockOrderDataSource) ApplyCoupon(ctx context.Context, order daos.OrderDAO, outbox ...daos.OutboxMessageDAO) error {
+	m.outbox = append(m.outbox, outbox...)
+	if m.applyCouponFunc != nil {
+		return m.applyCouponFunc(order)
+	}
+	return nil
+}
+
+func (m *mockOrderDataSource) Delete(ctx context.Context, id string, outbox ...daos.OutboxMessageDAO) error {
+	m.outbox = append(m.outbox, outbox...)
 	if m.deleteFunc != nil {
 		return m.deleteFunc(id)
 	}
 	return nil
 }
 
+func (m *mockOrderDataSource) FindByPickupCode(ctx context.Context, day string, code string) (daos.OrderDAO, error) {
+	if m.findByPickupCodeFunc != nil {
+		return m.findByPickupCodeFunc(day, code)
+	}
+	return daos.OrderDAO{}, nil
+}
+
+func (m *mockOrderDataSource) FindBoard(ctx context.Context, statusIDs []string, createdFrom time.Time) ([]daos.OrderBoardEntryDAO, error) {
+	if m.findBoardFunc != nil {
+		return m.findBoardFunc(statusIDs, createdFrom)
+	}
+	return []daos.OrderBoardEntryDAO{}, nil
+}
+
+func (m *mockOrderDataSource) NextPickupSequence(ctx context.Context, day string) (int, error) {
+	if m.nextPickupSequenceFunc != nil {
+		return m.nextPickupSequenceFunc(day)
+	}
+	return 1, nil
+}
+
+func (m *mockOrderDataSource) Restore(ctx context.Context, id string, outbox ...daos.OutboxMessageDAO) error {
+	m.outbox = append(m.outbox, outbox...)
+	if m.restoreFunc != nil {
+		return m.restoreFunc(id)
+	}
+	return nil
+}
+
+func (m *mockOrderDataSource) Claim(ctx context.Context, orderID string, customerID string, guestTokenHash string, claimedAt time.Time) error {
+	if m.claimFunc != nil {
+		return m.claimFunc(orderID, customerID, guestTokenHash, claimedAt)
+	}
+	return nil
+}
+
+func (m *mockOrderDataSource) ReissueGuestToken(ctx context.Context, orderID string, guestTokenHash string) error {
+	if m.reissueGuestTokenFunc != nil {
+		return m.reissueGuestTokenFunc(orderID, guestTokenHash)
+	}
+	return nil
+}
+
+func (m *mockOrderDataSource) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	if m.purgeDeletedFunc != nil {
+		return m.purgeDeletedFunc(deletedBefore, limit)
+	}
+	return 0, nil
+}
+
+func (m *mockOrderDataSource) FindStatusHistory(ctx context.Context, orderID string) ([]daos.OrderStatusHistoryDAO, error) {
+	if m.findStatusHistoryFunc != nil {
+		return m.findStatusHistoryFunc(orderID)
+	}
+	return nil, nil
+}
+
 func TestNewOrderGateway(t *testing.T) {
 	ds := &mockOrderDataSource{}
 	gateway := NewOrderGateway(ds)
@@ -65,9 +172,9 @@ func TestNewOrderGateway(t *testing.T) {
 func createTestOrderEntity() entities.Order {
 	customerID := "customer-123"
 	status, _ := entities.NewOrderStatus("status-1", "Pending")
-	item, _ := entities.NewOrderItem("item-1", "product-1", "order-1", 2, 10.0)
+	item, _ := entities.NewOrderItem("item-1", "product-1", "order-1", 2, brl("10.00"), "", nil)
 	now := time.Now()
-	order, _ := entities.NewOrderWithItems("order-1", &customerID, 20.0, *status, []entities.OrderItem{*item}, now, nil)
+	order, _ := entities.NewOrderWithItems("order-1", &customerID, brl("20.00"), *status, []entities.OrderItem{*item}, now, nil)
 	return *order
 }
 
@@ -83,7 +190,7 @@ func TestOrderGateway_Create_Success(t *testing.T) {
 	gateway := NewOrderGateway(ds)
 	order := createTestOrderEntity()
 
-	err := gateway.Create(order)
+	err := gateway.Create(context.Background(), order)
 
 	if err != nil {
 		t.Errorf("Create() unexpected error: %v", err)
@@ -93,6 +200,104 @@ func TestOrderGateway_Create_Success(t *testing.T) {
 	}
 }
 
+func TestOrderGateway_ItemNotesAndModifiers_RoundTrip(t *testing.T) {
+	var stored daos.OrderDAO
+	ds := &mockOrderDataSource{
+		createFunc: func(order daos.OrderDAO) error {
+			stored = order
+			return nil
+		},
+		findByIDFunc: func(id string) (daos.OrderDAO, error) {
+			return stored, nil
+		},
+	}
+	gateway := NewOrderGateway(ds)
+
+	status, _ := entities.NewOrderStatus("status-1", "Pending")
+	item, _ := entities.NewOrderItem("item-1", "product-1", "order-1", 1, brl("10.00"), "sem cebola", []entities.OrderItemModifier{
+		{ID: "modifier-1", ModifierID: "queijo-extra", Name: "Queijo extra", PriceDelta: brl("3.00")},
+	})
+	order, _ := entities.NewOrderWithItems("order-1", nil, brl("13.00"), *status, []entities.OrderItem{*item}, time.Now(), nil)
+
+	if err := gateway.Create(context.Background(), *order); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	expectedDAO := daos.OrderItemModifierDAO{ID: "modifier-1", OrderItemID: "item-1", ModifierID: "queijo-extra", Name: "Queijo extra", PriceDelta: 300}
+	if stored.Items[0].Notes != "sem cebola" || len(stored.Items[0].Modifiers) != 1 || stored.Items[0].Modifiers[0] != expectedDAO {
+		t.Errorf("Create() stored item = %+v, want notes and modifier %+v", stored.Items[0], expectedDAO)
+	}
+
+	found, err := gateway.FindByID(context.Background(), "order-1")
+	if err != nil {
+		t.Fatalf("FindByID() unexpected error: %v", err)
+	}
+	if found.Items[0].Notes != "sem cebola" || len(found.Items[0].Modifiers) != 1 || found.Items[0].Modifiers[0] != item.Modifiers[0] {
+		t.Errorf("FindByID() item = %+v, want notes and modifiers back", found.Items[0])
+	}
+}
+
+func TestOrderGateway_ComboComponents_RoundTrip(t *testing.T) {
+	var stored daos.OrderDAO
+	ds := &mockOrderDataSource{
+		createFunc: func(order daos.OrderDAO) error {
+			stored = order
+			return nil
+		},
+		findByIDFunc: func(id string) (daos.OrderDAO, error) {
+			return stored, nil
+		},
+	}
+	gateway := NewOrderGateway(ds)
+
+	status, _ := entities.NewOrderStatus("status-1", "Pending")
+	combo, _ := entities.NewOrderItem("item-1", "combo-1", "order-1", 1, brl("30.00"), "", nil)
+	component, _ := entities.NewOrderItem("item-2", "product-1", "order-1", 1, brl("25.90"), "", nil)
+	_ = combo.AddComponent(*component)
+	drink, _ := entities.NewOrderItem("item-3", "product-2", "order-1", 1, brl("7.90"), "", nil)
+	order, _ := entities.NewOrderWithItems("order-1", nil, brl("37.90"), *status, []entities.OrderItem{*combo, *drink}, time.Now(), nil)
+
+	if err := gateway.Create(context.Background(), *order); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	if len(stored.Items) != 3 || stored.Items[1].ParentItemID == nil || *stored.Items[1].ParentItemID != "item-1" {
+		t.Fatalf("Create() stored items = %+v, want the component pointing to item-1", stored.Items)
+	}
+	if stored.Items[0].ParentItemID != nil || stored.Items[2].ParentItemID != nil {
+		t.Error("Create() top-level items should not have a parent")
+	}
+
+	found, err := gateway.FindByID(context.Background(), "order-1")
+	if err != nil {
+		t.Fatalf("FindByID() unexpected error: %v", err)
+	}
+	if len(found.Items) != 2 || len(found.Items[0].Components) != 1 || found.Items[0].Components[0].ID != "item-2" {
+		t.Errorf("FindByID() items = %+v, want the component nested under the combo", found.Items)
+	}
+}
+
+func TestOrderGateway_FindByID_ComponentWithoutCombo(t *testing.T) {
+	comboItemID := "item-404"
+	ds := &mockOrderDataSource{
+		findByIDFunc: func(id string) (daos.OrderDAO, error) {
+			return daos.OrderDAO{
+				ID:     "order-1",
+				Amount: 1000,
+				Status: daos.OrderStatusDAO{ID: "status-1", Name: "Pending"},
+				Items: []daos.OrderItemDAO{
+					{ID: "item-1", OrderID: "order-1", ProductID: "product-1", Quantity: 1, UnitPrice: 1000},
+					{ID: "item-2", OrderID: "order-1", ProductID: "product-2", Quantity: 1, UnitPrice: 500, ParentItemID: &comboItemID},
+				},
+			}, nil
+		},
+	}
+
+	_, err := NewOrderGateway(ds).FindByID(context.Background(), "order-1")
+
+	if _, ok := err.(*exceptions.InvalidOrderItemData); !ok {
+		t.Errorf("FindByID() error = %v, want InvalidOrderItemData", err)
+	}
+}
+
 func TestOrderGateway_Create_Error(t *testing.T) {
 	ds := &mockOrderDataSource{
 		createFunc: func(order daos.OrderDAO) error {
@@ -103,7 +308,7 @@ func TestOrderGateway_Create_Error(t *testing.T) {
 	gateway := NewOrderGateway(ds)
 	order := createTestOrderEntity()
 
-	err := gateway.Create(order)
+	err := gateway.Create(context.Background(), order)
 
 	if err == nil {
 		t.Error("Create() expected error, got nil")
@@ -119,10 +324,10 @@ func TestOrderGateway_FindByID_Success(t *testing.T) {
 			return daos.OrderDAO{
 				ID:         "order-1",
 				CustomerID: &customerID,
-				Amount:     20.0,
+				Amount:     2000,
 				Status:     daos.OrderStatusDAO{ID: "status-1", Name: "Pending"},
 				Items: []daos.OrderItemDAO{
-					{ID: "item-1", OrderID: "order-1", ProductID: "product-1", Quantity: 2, UnitPrice: 10.0},
+					{ID: "item-1", OrderID: "order-1", ProductID: "product-1", Quantity: 2, UnitPrice: 1000, ProductName: "X-Burger", ProductCategory: "Lanche"},
 				},
 				CreatedAt: now,
 			}, nil
@@ -130,7 +335,7 @@ func TestOrderGateway_FindByID_Success(t *testing.T) {
 	}
 
 	gateway := NewOrderGateway(ds)
-	order, err := gateway.FindByID("order-1")
+	order, err := gateway.FindByID(context.Background(), "order-1")
 
 	if err != nil {
 		t.Errorf("FindByID() unexpected error: %v", err)
@@ -144,11 +349,30 @@ func TestOrderGateway_FindByID_Success(t *testing.T) {
 	if *order.CustomerID != customerID {
 		t.Errorf("FindByID() CustomerID = %v, want %v", *order.CustomerID, customerID)
 	}
-	if order.Amount.Value() != 20.0 {
+	if order.Amount.Value().String() != "20.00" {
 		t.Errorf("FindByID() Amount = %v, want 20.0", order.Amount.Value())
 	}
 	if len(order.Items) != 1 {
-		t.Errorf("FindByID() Items length = %v, want 1", len(order.Items))
+		t.Fatalf("FindByID() Items length = %v, want 1", len(order.Items))
+	}
+	if order.Items[0].Product.Name != "X-Burger" || order.Items[0].Product.Category != "Lanche" {
+		t.Errorf("FindByID() Items[0].Product = %+v, want X-Burger/Lanche", order.Items[0].Product)
+	}
+}
+
+func TestOrderGateway_FindByID_PassesContext(t *testing.T) {
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "request-1")
+	ds := &mockOrderDataSource{
+		findByIDFunc: func(id string) (daos.OrderDAO, error) {
+			return daos.OrderDAO{}, errors.New("not found")
+		},
+	}
+
+	_, _ = NewOrderGateway(ds).FindByID(ctx, "order-1")
+
+	if len(ds.contexts) != 1 || ds.contexts[0].Value(key{}) != "request-1" {
+		t.Errorf("FindByID() did not pass the request context to the datasource")
 	}
 }
 
@@ -160,7 +384,7 @@ func TestOrderGateway_FindByID_Error(t *testing.T) {
 	}
 
 	gateway := NewOrderGateway(ds)
-	_, err := gateway.FindByID("order-1")
+	_, err := gateway.FindByID(context.Background(), "order-1")
 
 	if err == nil {
 		t.Error("FindByID() expected error, got nil")
@@ -174,7 +398,7 @@ func TestOrderGateway_FindByID_InvalidStatus(t *testing.T) {
 		findByIDFunc: func(id string) (daos.OrderDAO, error) {
 			return daos.OrderDAO{
 				ID:        "order-1",
-				Amount:    20.0,
+				Amount:    2000,
 				Status:    daos.OrderStatusDAO{ID: "status-1", Name: "ab"}, // Invalid name
 				Items:     []daos.OrderItemDAO{},
 				CreatedAt: now,
@@ -183,7 +407,7 @@ func TestOrderGateway_FindByID_InvalidStatus(t *testing.T) {
 	}
 
 	gateway := NewOrderGateway(ds)
-	_, err := gateway.FindByID("order-1")
+	_, err := gateway.FindByID(context.Background(), "order-1")
 
 	if err == nil {
 		t.Error("FindByID() expected error for invalid status name, got nil")
@@ -197,10 +421,10 @@ func TestOrderGateway_FindByID_InvalidItem(t *testing.T) {
 		findByIDFunc: func(id string) (daos.OrderDAO, error) {
 			return daos.OrderDAO{
 				ID:     "order-1",
-				Amount: 20.0,
+				Amount: 2000,
 				Status: daos.OrderStatusDAO{ID: "status-1", Name: "Pending"},
 				Items: []daos.OrderItemDAO{
-					{ID: "item-1", OrderID: "order-1", ProductID: "", Quantity: 2, UnitPrice: 10.0}, // Invalid: empty ProductID
+					{ID: "item-1", OrderID: "order-1", ProductID: "", Quantity: 2, UnitPrice: 1000}, // Invalid: empty ProductID
 				},
 				CreatedAt: now,
 			}, nil
@@ -208,7 +432,7 @@ func TestOrderGateway_FindByID_InvalidItem(t *testing.T) {
 	}
 
 	gateway := NewOrderGateway(ds)
-	_, err := gateway.FindByID("order-1")
+	_, err := gateway.FindByID(context.Background(), "order-1")
 
 	if err == nil {
 		t.Error("FindByID() expected error for invalid item, got nil")
@@ -222,7 +446,7 @@ func TestOrderGateway_FindByID_InvalidAmount(t *testing.T) {
 		findByIDFunc: func(id string) (daos.OrderDAO, error) {
 			return daos.OrderDAO{
 				ID:        "order-1",
-				Amount:    -10.0, // Invalid amount
+				Amount:    -1000, // Invalid amount
 				Status:    daos.OrderStatusDAO{ID: "status-1", Name: "Pending"},
 				Items:     []daos.OrderItemDAO{},
 				CreatedAt: now,
@@ -231,7 +455,7 @@ func TestOrderGateway_FindByID_InvalidAmount(t *testing.T) {
 	}
 
 	gateway := NewOrderGateway(ds)
-	_, err := gateway.FindByID("order-1")
+	_, err := gateway.FindByID(context.Background(), "order-1")
 
 	if err == nil {
 		t.Error("FindByID() expected error for invalid amount, got nil")
@@ -248,10 +472,10 @@ func TestOrderGateway_FindAll_Success(t *testing.T) {
 				{
 					ID:         "order-1",
 					CustomerID: &customerID,
-					Amount:     20.0,
+					Amount:     2000,
 					Status:     daos.OrderStatusDAO{ID: "status-1", Name: "Pending"},
 					Items: []daos.OrderItemDAO{
-						{ID: "item-1", OrderID: "order-1", ProductID: "product-1", Quantity: 2, UnitPrice: 10.0},
+						{ID: "item-1", OrderID: "order-1", ProductID: "product-1", Quantity: 2, UnitPrice: 1000},
 					},
 					CreatedAt: now,
 				},
@@ -260,7 +484,7 @@ func TestOrderGateway_FindAll_Success(t *testing.T) {
 	}
 
 	gateway := NewOrderGateway(ds)
-	orders, err := gateway.FindAll(dtos.OrderFilterDTO{})
+	orders, err := gateway.FindAll(context.Background(), dtos.OrderFilterDTO{})
 
 	if err != nil {
 		t.Errorf("FindAll() unexpected error: %v", err)
@@ -278,7 +502,7 @@ func TestOrderGateway_FindAll_Error(t *testing.T) {
 	}
 
 	gateway := NewOrderGateway(ds)
-	_, err := gateway.FindAll(dtos.OrderFilterDTO{})
+	_, err := gateway.FindAll(context.Background(), dtos.OrderFilterDTO{})
 
 	if err == nil {
 		t.Error("FindAll() expected error, got nil")
@@ -293,7 +517,7 @@ func TestOrderGateway_FindAll_InvalidStatus(t *testing.T) {
 			return []daos.OrderDAO{
 				{
 					ID:        "order-1",
-					Amount:    20.0,
+					Amount:    2000,
 					Status:    daos.OrderStatusDAO{ID: "status-1", Name: "ab"}, // Invalid
 					Items:     []daos.OrderItemDAO{},
 					CreatedAt: now,
@@ -303,7 +527,7 @@ func TestOrderGateway_FindAll_InvalidStatus(t *testing.T) {
 	}
 
 	gateway := NewOrderGateway(ds)
-	_, err := gateway.FindAll(dtos.OrderFilterDTO{})
+	_, err := gateway.FindAll(context.Background(), dtos.OrderFilterDTO{})
 
 	if err == nil {
 		t.Error("FindAll() expected error for invalid status, got nil")
@@ -318,10 +542,10 @@ func TestOrderGateway_FindAll_InvalidItem(t *testing.T) {
 			return []daos.OrderDAO{
 				{
 					ID:     "order-1",
-					Amount: 20.0,
+					Amount: 2000,
 					Status: daos.OrderStatusDAO{ID: "status-1", Name: "Pending"},
 					Items: []daos.OrderItemDAO{
-						{ID: "item-1", OrderID: "order-1", ProductID: "", Quantity: 2, UnitPrice: 10.0}, // Invalid
+						{ID: "item-1", OrderID: "order-1", ProductID: "", Quantity: 2, UnitPrice: 1000}, // Invalid
 					},
 					CreatedAt: now,
 				},
@@ -330,7 +554,7 @@ func TestOrderGateway_FindAll_InvalidItem(t *testing.T) {
 	}
 
 	gateway := NewOrderGateway(ds)
-	_, err := gateway.FindAll(dtos.OrderFilterDTO{})
+	_, err := gateway.FindAll(context.Background(), dtos.OrderFilterDTO{})
 
 	if err == nil {
 		t.Error("FindAll() expected error for invalid item, got nil")
@@ -345,7 +569,7 @@ func TestOrderGateway_FindAll_InvalidOrderAmount(t *testing.T) {
 			return []daos.OrderDAO{
 				{
 					ID:        "order-1",
-					Amount:    -10.0, // Invalid amount
+					Amount:    -1000, // Invalid amount
 					Status:    daos.OrderStatusDAO{ID: "status-1", Name: "Pending"},
 					Items:     []daos.OrderItemDAO{},
 					CreatedAt: now,
@@ -355,7 +579,7 @@ func TestOrderGateway_FindAll_InvalidOrderAmount(t *testing.T) {
 	}
 
 	gateway := NewOrderGateway(ds)
-	_, err := gateway.FindAll(dtos.OrderFilterDTO{})
+	_, err := gateway.FindAll(context.Background(), dtos.OrderFilterDTO{})
 
 	if err == nil {
 		t.Error("FindAll() expected error for invalid amount, got nil")
@@ -374,7 +598,7 @@ func TestOrderGateway_Update_Success(t *testing.T) {
 	gateway := NewOrderGateway(ds)
 	order := createTestOrderEntity()
 
-	err := gateway.Update(order)
+	err := gateway.Update(context.Background(), order)
 
 	if err != nil {
 		t.Errorf("Update() unexpected error: %v", err)
@@ -394,13 +618,40 @@ func TestOrderGateway_Update_Error(t *testing.T) {
 	gateway := NewOrderGateway(ds)
 	order := createTestOrderEntity()
 
-	err := gateway.Update(order)
+	err := gateway.Update(context.Background(), order)
 
 	if err == nil {
 		t.Error("Update() expected error, got nil")
 	}
 }
 
+func TestOrderGateway_UpdateFromMessage(t *testing.T) {
+	ds := &mockOrderDataSource{}
+	gateway := NewOrderGateway(ds)
+	order := createTestOrderEntity()
+	occurredAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	err := gateway.UpdateFromMessage(context.Background(), order, dtos.ProcessedMessageDTO{
+		Consumer:   "order_updates",
+		MessageID:  "message-1",
+		OccurredAt: &occurredAt,
+	})
+
+	if err != nil {
+		t.Fatalf("UpdateFromMessage() unexpected error: %v", err)
+	}
+	if len(ds.processedMessages) != 1 {
+		t.Fatalf("UpdateFromMessage() recorded %d messages, want 1", len(ds.processedMessages))
+	}
+	message := ds.processedMessages[0]
+	if message.Consumer != "order_updates" || message.MessageID != "message-1" || message.OrderID != order.ID {
+		t.Errorf("UpdateFromMessage() recorded %+v", message)
+	}
+	if message.OccurredAt == nil || !message.OccurredAt.Equal(occurredAt) || message.ProcessedAt.IsZero() {
+		t.Errorf("UpdateFromMessage() timestamps = %v, %v", message.OccurredAt, message.ProcessedAt)
+	}
+}
+
 func TestOrderGateway_Delete_Success(t *testing.T) {
 	deleteCalled := false
 	ds := &mockOrderDataSource{
@@ -411,7 +662,7 @@ func TestOrderGateway_Delete_Success(t *testing.T) {
 	}
 
 	gateway := NewOrderGateway(ds)
-	err := gateway.Delete("order-1")
+	err := gateway.Delete(context.Background(), "order-1")
 
 	if err != nil {
 		t.Errorf("Delete() unexpected error: %v", err)
@@ -429,9 +680,504 @@ func TestOrderGateway_Delete_Error(t *testing.T) {
 	}
 
 	gateway := NewOrderGateway(ds)
-	err := gateway.Delete("order-1")
+	err := gateway.Delete(context.Background(), "order-1")
 
 	if err == nil {
 		t.Error("Delete() expected error, got nil")
 	}
 }
+
+func TestOrderGateway_Restore_WritesEvents(t *testing.T) {
+	var restoredID string
+	ds := &mockOrderDataSource{
+		restoreFunc: func(id string) error {
+			restoredID = id
+			return nil
+		},
+	}
+	event, _ := brokers.NewOrderEvent(context.Background(), brokers.ORDER_RESTORED_EVENT, "order-1", brokers.OrderRestoredPayload{OrderID: "order-1"})
+
+	if err := NewOrderGateway(ds).Restore(context.Background(), "order-1", event); err != nil {
+		t.Fatalf("Restore() unexpected error: %v", err)
+	}
+	if restoredID != "order-1" {
+		t.Errorf("Restore() id = %v, want order-1", restoredID)
+	}
+	if len(ds.outbox) != 1 || ds.outbox[0].EventType != brokers.ORDER_RESTORED_EVENT {
+		t.Errorf("Restore() outbox = %+v, want one order.restored message", ds.outbox)
+	}
+}
+
+func TestOrderGateway_PurgeDeleted(t *testing.T) {
+	deletedBefore := time.Now().Add(-time.Hour)
+	ds := &mockOrderDataSource{
+		purgeDeletedFunc: func(before time.Time, limit int) (int64, error) {
+			if !before.Equal(deletedBefore) || limit != 50 {
+				t.Errorf("PurgeDeleted() called with %v/%v, want %v/50", before, limit, deletedBefore)
+			}
+			return 7, nil
+		},
+	}
+
+	purged, err := NewOrderGateway(ds).PurgeDeleted(context.Background(), deletedBefore, 50)
+	if err != nil || purged != 7 {
+		t.Errorf("PurgeDeleted() = %v, %v, want 7, nil", purged, err)
+	}
+}
+
+func TestOrderGateway_Create_WithEvents(t *testing.T) {
+	ds := &mockOrderDataSource{}
+	gateway := NewOrderGateway(ds)
+	event, _ := brokers.NewOrderEvent(context.Background(), brokers.ORDER_CREATED_EVENT, "order-1", brokers.OrderCreatedPayload{OrderID: "order-1"})
+
+	err := gateway.Create(context.Background(), createTestOrderEntity(), event)
+	if err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+
+	if len(ds.outbox) != 1 {
+		t.Fatalf("Create() outbox len = %d, want 1", len(ds.outbox))
+	}
+
+	message := ds.outbox[0]
+	if message.ID != event.ID || message.OrderID != "order-1" || message.EventType != brokers.ORDER_CREATED_EVENT {
+		t.Errorf("Create() outbox message = %+v, want event %s for order-1", message, event.ID)
+	}
+	if !message.NextAttemptAt.Equal(event.OccurredAt) {
+		t.Errorf("Create() NextAttemptAt = %v, want %v", message.NextAttemptAt, event.OccurredAt)
+	}
+
+	var decoded brokers.OrderEvent
+	if err := json.Unmarshal(message.Payload, &decoded); err != nil {
+		t.Fatalf("outbox payload is not a valid event envelope: %v", err)
+	}
+	if decoded.ID != event.ID || decoded.Version != brokers.ORDER_EVENT_VERSION {
+		t.Errorf("decoded event = %+v, want %+v", decoded, event)
+	}
+}
+
+func TestOrderGateway_Delete_WithEvents(t *testing.T) {
+	ds := &mockOrderDataSource{}
+	gateway := NewOrderGateway(ds)
+	event, _ := brokers.NewOrderEvent(context.Background(), brokers.ORDER_DELETED_EVENT, "order-1", brokers.OrderDeletedPayload{OrderID: "order-1"})
+
+	if err := gateway.Delete(context.Background(), "order-1", event); err != nil {
+		t.Fatalf("Delete() unexpected error: %v", err)
+	}
+
+	if len(ds.outbox) != 1 || ds.outbox[0].EventType != brokers.ORDER_DELETED_EVENT {
+		t.Errorf("Delete() outbox = %+v, want one order.deleted message", ds.outbox)
+	}
+}
+
+func TestOrderGateway_Update_PassesStatusChanges(t *testing.T) {
+	var saved daos.OrderDAO
+	ds := &mockOrderDataSource{
+		updateFunc: func(order daos.OrderDAO) error {
+			saved = order
+			return nil
+		},
+	}
+
+	gateway := NewOrderGateway(ds)
+	order := createTestOrderEntity()
+	confirmed, _ := entities.NewOrderStatus("status-2", entities.ORDER_STATUS_CONFIRMED)
+	received, _ := entities.NewOrderStatus("status-1", entities.ORDER_STATUS_RECEIVED)
+	order.Status = *received
+	_ = order.ChangeStatus(*confirmed, entities.STATUS_CHANGE_SOURCE_REST, nil)
+
+	if err := gateway.Update(context.Background(), order); err != nil {
+		t.Fatalf("Update() unexpected error: %v", err)
+	}
+
+	if len(saved.StatusChanges) != 1 {
+		t.Fatalf("Update() StatusChanges len = %d, want 1", len(saved.StatusChanges))
+	}
+	change := saved.StatusChanges[0]
+	if change.PreviousStatus == nil || change.PreviousStatus.Name != entities.ORDER_STATUS_RECEIVED {
+		t.Errorf("Update() PreviousStatus = %+v, want %s", change.PreviousStatus, entities.ORDER_STATUS_RECEIVED)
+	}
+	if change.NewStatus.ID != "status-2" || change.Source != entities.STATUS_CHANGE_SOURCE_REST {
+		t.Errorf("Update() change = %+v, want status-2 from rest", change)
+	}
+}
+
+func TestOrderGateway_FindStatusHistory_Success(t *testing.T) {
+	actor := "kitchen"
+	ds := &mockOrderDataSource{
+		findStatusHistoryFunc: func(orderID string) ([]daos.OrderStatusHistoryDAO, error) {
+			return []daos.OrderStatusHistoryDAO{
+				{ID: "change-1", OrderID: orderID, NewStatus: daos.OrderStatusDAO{ID: "status-1", Name: "Recebido"}, Source: "rest", ChangedAt: time.Now()},
+				{ID: "change-2", OrderID: orderID, PreviousStatus: &daos.OrderStatusDAO{ID: "status-1", Name: "Recebido"}, NewStatus: daos.OrderStatusDAO{ID: "status-2", Name: "Confirmado"}, Source: "kitchen_consumer", Actor: &actor, ChangedAt: time.Now()},
+			}, nil
+		},
+	}
+
+	gateway := NewOrderGateway(ds)
+	history, err := gateway.FindStatusHistory(context.Background(), "order-1")
+
+	if err != nil {
+		t.Fatalf("FindStatusHistory() unexpected error: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("FindStatusHistory() len = %d, want 2", len(history))
+	}
+	if history[0].PreviousStatus != nil {
+		t.Error("FindStatusHistory() first entry should have no previous status")
+	}
+	if history[1].PreviousStatus.Name.Value() != "Recebido" || history[1].NewStatus.Name.Value() != "Confirmado" {
+		t.Errorf("FindStatusHistory() second entry = %+v", history[1])
+	}
+	if *history[1].Actor != "kitchen" {
+		t.Errorf("FindStatusHistory() Actor = %v, want kitchen", *history[1].Actor)
+	}
+}
+
+func TestOrderGateway_FindStatusHistory_Error(t *testing.T) {
+	ds := &mockOrderDataSource{
+		findStatusHistoryFunc: func(orderID string) ([]daos.OrderStatusHistoryDAO, error) {
+			return nil, errors.New("database error")
+		},
+	}
+
+	gateway := NewOrderGateway(ds)
+	_, err := gateway.FindStatusHistory(context.Background(), "order-1")
+
+	if err == nil {
+		t.Error("FindStatusHistory() expected error, got nil")
+	}
+}
+
+func TestOrderGateway_ApplyCoupon_SavesDiscount(t *testing.T) {
+	var saved daos.OrderDAO
+	ds := &mockOrderDataSource{
+		applyCouponFunc: func(order daos.OrderDAO) error {
+			saved = order
+			return nil
+		},
+	}
+
+	gateway := NewOrderGateway(ds)
+	order := createTestOrderEntity()
+	received, _ := entities.NewOrderStatus("status-1", entities.ORDER_STATUS_RECEIVED)
+	order.Status = *received
+	if err := order.CalcTotalAmount(); err != nil {
+		t.Fatalf("CalcTotalAmount() unexpected error: %v", err)
+	}
+	coupon := entities.Coupon{Code: "MENOS5", Type: entities.COUPON_TYPE_FIXED_AMOUNT, FixedAmount: brl("5.00")}
+	if err := order.ApplyCoupon(coupon, time.Now(), false); err != nil {
+		t.Fatalf("ApplyCoupon() unexpected error: %v", err)
+	}
+	event, _ := brokers.NewOrderEvent(context.Background(), brokers.ORDER_COUPON_APPLIED_EVENT, order.ID, brokers.OrderCouponAppliedPayload{OrderID: order.ID})
+
+	if err := gateway.ApplyCoupon(context.Background(), order, event); err != nil {
+		t.Fatalf("ApplyCoupon() unexpected error: %v", err)
+	}
+
+	if saved.Discount != 500 || saved.Subtotal != saved.Amount+500 {
+		t.Errorf("ApplyCoupon() subtotal/discount/amount = %d/%d/%d, want discount 500", saved.Subtotal, saved.Discount, saved.Amount)
+	}
+	if saved.CouponCode == nil || *saved.CouponCode != "MENOS5" {
+		t.Errorf("ApplyCoupon() CouponCode = %v, want MENOS5", saved.CouponCode)
+	}
+	if len(ds.outbox) != 1 || ds.outbox[0].EventType != brokers.ORDER_COUPON_APPLIED_EVENT {
+		t.Errorf("ApplyCoupon() outbox = %+v, want one order.coupon_applied message", ds.outbox)
+	}
+}
+
+func TestOrderGateway_FindByID_RestoresDiscount(t *testing.T) {
+	couponCode := "MENOS5"
+	ds := &mockOrderDataSource{
+		findByIDFunc: func(id string) (daos.OrderDAO, error) {
+			return daos.OrderDAO{
+				ID:         id,
+				Amount:     1500,
+				Currency:   "BRL",
+				Subtotal:   2000,
+				Discount:   500,
+				CouponCode: &couponCode,
+				Status:     daos.OrderStatusDAO{ID: "status-1", Name: entities.ORDER_STATUS_RECEIVED},
+			}, nil
+		},
+	}
+
+	order, err := NewOrderGateway(ds).FindByID(context.Background(), "order-1")
+	if err != nil {
+		t.Fatalf("FindByID() unexpected error: %v", err)
+	}
+	if order.Subtotal.String() != "20.00" || order.Discount.String() != "5.00" || order.Amount.Value().String() != "15.00" {
+		t.Errorf("FindByID() subtotal/discount/amount = %v/%v/%v, want 20.00/5.00/15.00", order.Subtotal, order.Discount, order.Amount.Value())
+	}
+	if order.CouponCode == nil || *order.CouponCode != "MENOS5" {
+		t.Errorf("FindByID() CouponCode = %v, want MENOS5", order.CouponCode)
+	}
+}
+
+func TestOrderGateway_Cancellation_RoundTrip(t *testing.T) {
+	var saved daos.OrderDAO
+	ds := &mockOrderDataSource{
+		updateFunc: func(order daos.OrderDAO) error {
+			saved = order
+			return nil
+		},
+	}
+	ds.findByIDFunc = func(id string) (daos.OrderDAO, error) {
+		return saved, nil
+	}
+	gateway := NewOrderGateway(ds)
+
+	confirmed, _ := entities.NewOrderStatus("status-2", entities.ORDER_STATUS_CONFIRMED)
+	cancelled, _ := entities.NewOrderStatus("status-9", entities.ORDER_STATUS_CANCELLED)
+	order, _ := entities.NewOrder("550e8400-e29b-41d4-a716-446655440000", nil)
+	order.Status = *confirmed
+	item, _ := entities.NewOrderItem("item-1", "product-1", order.ID, 1, brl("10.00"), "", nil)
+	order.AddItem(*item)
+	_ = order.CalcTotalAmount()
+	order.CreatedAt = time.Now()
+	if err := order.Cancel(*cancelled, "Cliente desistiu", entities.STATUS_CHANGE_SOURCE_REST, nil, time.Now()); err != nil {
+		t.Fatalf("Cancel() unexpected error: %v", err)
+	}
+
+	if err := gateway.Update(context.Background(), *order); err != nil {
+		t.Fatalf("Update() unexpected error: %v", err)
+	}
+	found, err := gateway.FindByID(context.Background(), order.ID)
+	if err != nil {
+		t.Fatalf("FindByID() unexpected error: %v", err)
+	}
+	if found.CancellationReason == nil || *found.CancellationReason != "Cliente desistiu" {
+		t.Errorf("FindByID() CancellationReason = %v, want Cliente desistiu", found.CancellationReason)
+	}
+	if found.CancelledAt == nil || !found.CancelledAt.Equal(*order.CancelledAt) {
+		t.Errorf("FindByID() CancelledAt = %v, want %v", found.CancelledAt, order.CancelledAt)
+	}
+}
+
+func TestOrderGateway_FindByID_OrderWithoutSubtotal(t *testing.T) {
+	ds := &mockOrderDataSource{
+		findByIDFunc: func(id string) (daos.OrderDAO, error) {
+			return daos.OrderDAO{
+				ID:       id,
+				Amount:   1500,
+				Currency: "BRL",
+				Status:   daos.OrderStatusDAO{ID: "status-1", Name: entities.ORDER_STATUS_RECEIVED},
+			}, nil
+		},
+	}
+
+	order, err := NewOrderGateway(ds).FindByID(context.Background(), "order-1")
+	if err != nil {
+		t.Fatalf("FindByID() unexpected error: %v", err)
+	}
+	if order.Subtotal.String() != "15.00" || !order.Discount.IsZero() || order.CouponCode != nil {
+		t.Errorf("FindByID() subtotal/discount/coupon = %v/%v/%v, want 15.00/0/nil", order.Subtotal, order.Discount, order.CouponCode)
+	}
+}
+
+func TestOrderGateway_PickupCode_RoundTrip(t *testing.T) {
+	var saved daos.OrderDAO
+	ds := &mockOrderDataSource{
+		createFunc: func(order daos.OrderDAO) error {
+			saved = order
+			return nil
+		},
+		nextPickupSequenceFunc: func(day string) (int, error) {
+			return 1000, nil
+		},
+	}
+	ds.findByPickupCodeFunc = func(day string, code string) (daos.OrderDAO, error) {
+		if day != "2026-10-16" || code != "B001" {
+			t.Errorf("FindByPickupCode() called with %s/%s, want 2026-10-16/B001", day, code)
+		}
+		return saved, nil
+	}
+	gateway := NewOrderGateway(ds)
+
+	code, err := gateway.NextPickupCode(context.Background(), "2026-10-16")
+	if err != nil {
+		t.Fatalf("NextPickupCode() unexpected error: %v", err)
+	}
+	if code.Value() != "B001" {
+		t.Errorf("NextPickupCode() = %s, want B001", code.Value())
+	}
+
+	order := createTestOrderEntity()
+	order.PickupCode = code
+	order.PickupDate = "2026-10-16"
+
+	if err := gateway.Create(context.Background(), order); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	found, err := gateway.FindByPickupCode(context.Background(), "2026-10-16", code)
+	if err != nil {
+		t.Fatalf("FindByPickupCode() unexpected error: %v", err)
+	}
+	if found.PickupCode != code || found.PickupDate != "2026-10-16" {
+		t.Errorf("FindByPickupCode() pickup = %s/%s, want B001/2026-10-16", found.PickupCode.Value(), found.PickupDate)
+	}
+}
+
+func TestOrderGateway_Create_WithoutPickupCode(t *testing.T) {
+	var saved daos.OrderDAO
+	ds := &mockOrderDataSource{
+		createFunc: func(order daos.OrderDAO) error {
+			saved = order
+			return nil
+		},
+	}
+	gateway := NewOrderGateway(ds)
+
+	if err := gateway.Create(context.Background(), createTestOrderEntity()); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	if saved.PickupCode != nil || saved.PickupDate != nil {
+		t.Errorf("Create() pickup = %v/%v, want nil/nil", saved.PickupCode, saved.PickupDate)
+	}
+}
+
+func TestOrderGateway_NextPickupCode_Error(t *testing.T) {
+	ds := &mockOrderDataSource{
+		nextPickupSequenceFunc: func(day string) (int, error) {
+			return 0, errors.New("database error")
+		},
+	}
+	gateway := NewOrderGateway(ds)
+
+	if _, err := gateway.NextPickupCode(context.Background(), "2026-10-16"); err == nil {
+		t.Error("NextPickupCode() expected error")
+	}
+}
+
+func TestOrderGateway_FindBoard(t *testing.T) {
+	code, name := "A007", "Maria"
+	createdAt := time.Now()
+	var gotStatusIDs []string
+	ds := &mockOrderDataSource{
+		findBoardFunc: func(statusIDs []string, createdFrom time.Time) ([]daos.OrderBoardEntryDAO, error) {
+			gotStatusIDs = statusIDs
+			return []daos.OrderBoardEntryDAO{
+				{ID: "order-1", PickupCode: &code, CustomerName: &name, StatusID: "status-4", StatusName: "Pronto", CreatedAt: createdAt},
+				{ID: "order-2", StatusID: "status-1", StatusName: "Recebido", CreatedAt: createdAt},
+			}, nil
+		},
+	}
+	gateway := NewOrderGateway(ds)
+
+	ready, _ := entities.NewOrderStatus("status-4", entities.ORDER_STATUS_READY)
+	received, _ := entities.NewOrderStatus("status-1", entities.ORDER_STATUS_RECEIVED)
+	entries, err := gateway.FindBoard(context.Background(), []entities.OrderStatus{*ready, *received}, createdAt.Add(-time.Hour))
+
+	if err != nil {
+		t.Fatalf("FindBoard() unexpected error: %v", err)
+	}
+	if len(gotStatusIDs) != 2 || gotStatusIDs[0] != "status-4" || gotStatusIDs[1] != "status-1" {
+		t.Errorf("FindBoard() status IDs = %v, want [status-4 status-1]", gotStatusIDs)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("FindBoard() entries = %d, want 2", len(entries))
+	}
+	if entries[0].PickupCode.Value() != code || entries[0].CustomerName != name || entries[0].Status.Name.Value() != "Pronto" {
+		t.Errorf("FindBoard() entry = %+v, want %s/%s/Pronto", entries[0], code, name)
+	}
+	if !entries[1].PickupCode.IsZero() || entries[1].CustomerName != "" {
+		t.Errorf("FindBoard() entry without code = %+v", entries[1])
+	}
+}
+
+func TestOrderGateway_FindBoard_InvalidPickupCode(t *testing.T) {
+	code := "invalid"
+	ds := &mockOrderDataSource{
+		findBoardFunc: func(statusIDs []string, createdFrom time.Time) ([]daos.OrderBoardEntryDAO, error) {
+			return []daos.OrderBoardEntryDAO{
+				{ID: "order-1", PickupCode: &code, StatusID: "status-1", StatusName: "Recebido"},
+			}, nil
+		},
+	}
+
+	if _, err := NewOrderGateway(ds).FindBoard(context.Background(), nil, time.Now()); err == nil {
+		t.Error("FindBoard() expected error for an invalid pickup code")
+	}
+}
+
+func TestOrderGateway_CustomerName_RoundTrip(t *testing.T) {
+	var saved daos.OrderDAO
+	ds := &mockOrderDataSource{
+		createFunc: func(order daos.OrderDAO) error {
+			saved = order
+			return nil
+		},
+	}
+	ds.findByIDFunc = func(id string) (daos.OrderDAO, error) {
+		return saved, nil
+	}
+	gateway := NewOrderGateway(ds)
+
+	order := createTestOrderEntity()
+	order.CustomerName = "Maria"
+	if err := gateway.Create(context.Background(), order); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	found, err := gateway.FindByID(context.Background(), order.ID)
+	if err != nil {
+		t.Fatalf("FindByID() unexpected error: %v", err)
+	}
+	if found.CustomerName != "Maria" {
+		t.Errorf("FindByID() CustomerName = %q, want Maria", found.CustomerName)
+	}
+}
+
+func TestOrderGateway_GuestToken_RoundTrip(t *testing.T) {
+	var saved daos.OrderDAO
+	ds := &mockOrderDataSource{
+		createFunc: func(order daos.OrderDAO) error {
+			saved = order
+			return nil
+		},
+		findByIDFunc: func(id string) (daos.OrderDAO, error) {
+			return saved, nil
+		},
+	}
+	gateway := NewOrderGateway(ds)
+
+	order := createTestOrderEntity()
+	order.CustomerID = nil
+	if err := order.IssueGuestToken(); err != nil {
+		t.Fatalf("IssueGuestToken() unexpected error: %v", err)
+	}
+
+	if err := gateway.Create(context.Background(), order); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	found, err := gateway.FindByID(context.Background(), order.ID)
+	if err != nil {
+		t.Fatalf("FindByID() unexpected error: %v", err)
+	}
+	if !found.HasGuestToken(order.GuestToken) {
+		t.Error("FindByID() order does not accept its guest token")
+	}
+	if found.GuestToken != "" {
+		t.Error("FindByID() loaded the guest token itself, only its hash is stored")
+	}
+}
+
+func TestOrderGateway_Claim(t *testing.T) {
+	claimedAt := time.Now()
+	var called bool
+	ds := &mockOrderDataSource{
+		claimFunc: func(orderID string, customerID string, guestTokenHash string, at time.Time) error {
+			called = true
+			if orderID != "order-1" || customerID != "customer-123" || guestTokenHash != "hash" || !at.Equal(claimedAt) {
+				t.Errorf("Claim() called with %s/%s/%s/%v", orderID, customerID, guestTokenHash, at)
+			}
+			return &exceptions.OrderAlreadyClaimedException{}
+		},
+	}
+
+	err := NewOrderGateway(ds).Claim(context.Background(), "order-1", "customer-123", "hash", claimedAt)
+	if !called {
+		t.Fatal("Claim() did not call the data source")
+	}
+	if _, ok := err.(*exceptions.OrderAlreadyClaimedException); !ok {
+		t.Errorf("Claim() error = %T, want *exceptions.OrderAlreadyClaimedException", err)
+	}
+}
@@ -0,0 +1,72 @@
+package gateways
+
+import (
+	"context"
+	"fmt"
+
+	"microservice/internal/domain/entities"
+	"microservice/internal/domain/exceptions"
+	"microservice/internal/domain/value_objects"
+	"microservice/internal/interfaces"
+)
+
+type ProductCatalogGateway struct {
+	datasource interfaces.IProductCatalogDataSource
+}
+
+func NewProductCatalogGateway(datasource interfaces.IProductCatalogDataSource) *ProductCatalogGateway {
+	return &ProductCatalogGateway{datasource: datasource}
+}
+
+func (g *ProductCatalogGateway) FindByID(ctx context.Context, productID string) (*entities.Product, error) {
+	productDAO, err := g.datasource.FindByID(ctx, productID)
+	if err != nil {
+		return nil, &exceptions.ProductCatalogUnavailableException{
+			Cause: fmt.Errorf("could not fetch product %s from catalog: %w", productID, err),
+		}
+	}
+	if productDAO == nil {
+		return nil, &exceptions.ProductNotFoundException{
+			Message: fmt.Sprintf("Product %s not found", productID),
+		}
+	}
+
+	currency := productDAO.Currency
+	if currency == "" {
+		currency = value_objects.DEFAULT_CURRENCY
+	}
+
+	price, err := value_objects.NewMoney(productDAO.Price, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := entities.NewProduct(productDAO.ID, productDAO.Name, price, productDAO.Available)
+	if err != nil {
+		return nil, err
+	}
+	product.Category = productDAO.Category
+	product.ImageURL = productDAO.ImageURL
+	product.Notes = productDAO.Notes
+
+	for _, modifierDAO := range productDAO.Modifiers {
+		priceDelta, err := value_objects.NewMoney(modifierDAO.PriceDelta, currency)
+		if err != nil {
+			return nil, err
+		}
+		product.Modifiers = append(product.Modifiers, entities.ProductModifier{
+			ID:         modifierDAO.ID,
+			Name:       modifierDAO.Name,
+			PriceDelta: priceDelta,
+		})
+	}
+
+	for _, comboItemDAO := range productDAO.ComboItems {
+		product.ComboItems = append(product.ComboItems, entities.ProductComboItem{
+			ProductID: comboItemDAO.ProductID,
+			Quantity:  comboItemDAO.Quantity,
+		})
+	}
+
+	return product, nil
+}
@@ -0,0 +1,128 @@
+package gateways
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"microservice/internal/adapters/daos"
+	"microservice/internal/domain/exceptions"
+)
+
+type mockProductCatalogDataSource struct {
+	findByIDFunc func(productID string) (*daos.ProductDAO, error)
+}
+
+func (m *mockProductCatalogDataSource) FindByID(ctx context.Context, productID string) (*daos.ProductDAO, error) {
+	if m.findByIDFunc != nil {
+		return m.findByIDFunc(productID)
+	}
+	return nil, nil
+}
+
+func TestProductCatalogGateway_FindByID_Success(t *testing.T) {
+	ds := &mockProductCatalogDataSource{
+		findByIDFunc: func(productID string) (*daos.ProductDAO, error) {
+			return &daos.ProductDAO{ID: productID, Name: "X-Burger", Price: 2590, Available: true}, nil
+		},
+	}
+
+	product, err := NewProductCatalogGateway(ds).FindByID(context.Background(), "product-1")
+
+	if err != nil {
+		t.Fatalf("FindByID() unexpected error: %v", err)
+	}
+	if product.ID.Value() != "product-1" || product.Name != "X-Burger" || !product.Available {
+		t.Errorf("FindByID() = %+v, want product-1 X-Burger available", product)
+	}
+	if product.Price.String() != "25.90" || product.Price.Currency() != "BRL" {
+		t.Errorf("FindByID() Price = %v %v, want 25.90 BRL", product.Price, product.Price.Currency())
+	}
+}
+
+func TestProductCatalogGateway_FindByID_NotFound(t *testing.T) {
+	ds := &mockProductCatalogDataSource{}
+
+	_, err := NewProductCatalogGateway(ds).FindByID(context.Background(), "product-1")
+
+	if _, ok := err.(*exceptions.ProductNotFoundException); !ok {
+		t.Errorf("FindByID() expected ProductNotFoundException, got %T", err)
+	}
+}
+
+func TestProductCatalogGateway_FindByID_DataSourceError(t *testing.T) {
+	dsErr := errors.New("connection refused")
+	ds := &mockProductCatalogDataSource{
+		findByIDFunc: func(productID string) (*daos.ProductDAO, error) {
+			return nil, dsErr
+		},
+	}
+
+	_, err := NewProductCatalogGateway(ds).FindByID(context.Background(), "product-1")
+
+	if _, ok := err.(*exceptions.ProductCatalogUnavailableException); !ok {
+		t.Errorf("FindByID() expected ProductCatalogUnavailableException, got %T", err)
+	}
+	// A causa fica disponível para o log, mas fora da mensagem
+	if !errors.Is(err, dsErr) || strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("FindByID() error = %q, want the cause wrapped but not in the message", err)
+	}
+}
+
+func TestProductCatalogGateway_FindByID_InvalidPrice(t *testing.T) {
+	ds := &mockProductCatalogDataSource{
+		findByIDFunc: func(productID string) (*daos.ProductDAO, error) {
+			return &daos.ProductDAO{ID: productID, Name: "X-Burger", Price: 0, Available: true}, nil
+		},
+	}
+
+	_, err := NewProductCatalogGateway(ds).FindByID(context.Background(), "product-1")
+
+	if err == nil {
+		t.Error("FindByID() expected error for zero catalog price, got nil")
+	}
+}
+
+func TestProductCatalogGateway_FindByID_Modifiers(t *testing.T) {
+	ds := &mockProductCatalogDataSource{
+		findByIDFunc: func(productID string) (*daos.ProductDAO, error) {
+			return &daos.ProductDAO{ID: productID, Name: "X-Burger", Price: 2590, Available: true, Modifiers: []daos.ProductModifierDAO{
+				{ID: "queijo-extra", Name: "Queijo extra", PriceDelta: 300},
+			}}, nil
+		},
+	}
+
+	product, err := NewProductCatalogGateway(ds).FindByID(context.Background(), "product-1")
+
+	if err != nil {
+		t.Fatalf("FindByID() unexpected error: %v", err)
+	}
+	if len(product.Modifiers) != 1 {
+		t.Fatalf("FindByID() Modifiers length = %v, want 1", len(product.Modifiers))
+	}
+	modifier := product.Modifiers[0]
+	if modifier.ID != "queijo-extra" || modifier.Name != "Queijo extra" || modifier.PriceDelta.String() != "3.00" || modifier.PriceDelta.Currency() != "BRL" {
+		t.Errorf("FindByID() Modifiers[0] = %+v, want queijo-extra 3.00 BRL", modifier)
+	}
+}
+
+func TestProductCatalogGateway_FindByID_ComboItems(t *testing.T) {
+	ds := &mockProductCatalogDataSource{
+		findByIDFunc: func(productID string) (*daos.ProductDAO, error) {
+			return &daos.ProductDAO{ID: productID, Name: "Combo X-Burger", Price: 3990, Available: true, ComboItems: []daos.ProductComboItemDAO{
+				{ProductID: "product-1", Quantity: 1},
+				{ProductID: "product-3", Quantity: 2},
+			}}, nil
+		},
+	}
+
+	product, err := NewProductCatalogGateway(ds).FindByID(context.Background(), "combo-1")
+
+	if err != nil {
+		t.Fatalf("FindByID() unexpected error: %v", err)
+	}
+	if !product.IsCombo() || len(product.ComboItems) != 2 || product.ComboItems[1].ProductID != "product-3" || product.ComboItems[1].Quantity != 2 {
+		t.Errorf("FindByID() ComboItems = %+v, want product-1 x1 and product-3 x2", product.ComboItems)
+	}
+}
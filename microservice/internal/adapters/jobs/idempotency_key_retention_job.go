@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Remove periodicamente as chaves de idempotência expiradas
+type IdempotencyKeyRetentionJob struct {
+	purger   IExpiredIdempotencyKeysPurger
+	interval time.Duration
+	now      func() time.Time
+}
+
+func NewIdempotencyKeyRetentionJob(purger IExpiredIdempotencyKeysPurger, interval time.Duration) *IdempotencyKeyRetentionJob {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	return &IdempotencyKeyRetentionJob{
+		purger:   purger,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+func (j *IdempotencyKeyRetentionJob) Start(ctx context.Context) {
+	log.Printf("Starting idempotency key retention job (interval: %s)", j.interval)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			log.Println("Stopping idempotency key retention job")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *IdempotencyKeyRetentionJob) RunOnce(ctx context.Context) int64 {
+	purged, err := j.purger.PurgeExpired(ctx, j.now().UTC())
+	if err != nil {
+		log.Printf("Idempotency key retention job: failed to purge expired keys: %v", err)
+		return 0
+	}
+	if purged > 0 {
+		log.Printf("Idempotency key retention job: purged %d expired keys", purged)
+	}
+	return purged
+}
@@ -0,0 +1,43 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeIdempotencyKeyPurger struct {
+	calls  []time.Time
+	purged int64
+	err    error
+}
+
+func (p *fakeIdempotencyKeyPurger) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	p.calls = append(p.calls, now)
+	return p.purged, p.err
+}
+
+func TestIdempotencyKeyRetentionJob_RunOnce(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	purger := &fakeIdempotencyKeyPurger{purged: 4}
+	job := NewIdempotencyKeyRetentionJob(purger, time.Minute)
+	job.now = func() time.Time { return now }
+
+	assert.Equal(t, int64(4), job.RunOnce(context.Background()))
+	assert.Equal(t, []time.Time{now}, purger.calls)
+}
+
+func TestIdempotencyKeyRetentionJob_RunOnce_Error(t *testing.T) {
+	purger := &fakeIdempotencyKeyPurger{purged: 4, err: errors.New("database unavailable")}
+	job := NewIdempotencyKeyRetentionJob(purger, time.Minute)
+
+	assert.Equal(t, int64(0), job.RunOnce(context.Background()))
+}
+
+func TestIdempotencyKeyRetentionJob_DefaultInterval(t *testing.T) {
+	job := NewIdempotencyKeyRetentionJob(&fakeIdempotencyKeyPurger{}, 0)
+	assert.Equal(t, time.Hour, job.interval)
+}
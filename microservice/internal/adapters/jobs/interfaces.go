@@ -0,0 +1,14 @@
+package jobs
+
+import (
+	"context"
+	"time"
+)
+
+type IPurgeDeletedOrdersUseCase interface {
+	Execute(ctx context.Context, now time.Time) (int64, error)
+}
+
+type IExpiredIdempotencyKeysPurger interface {
+	PurgeExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Remove periodicamente os pedidos excluídos cujo prazo de retenção terminou
+type OrderRetentionJob struct {
+	purgeDeletedOrdersUseCase IPurgeDeletedOrdersUseCase
+	interval                  time.Duration
+	now                       func() time.Time
+}
+
+func NewOrderRetentionJob(purgeDeletedOrdersUseCase IPurgeDeletedOrdersUseCase, interval time.Duration) *OrderRetentionJob {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	return &OrderRetentionJob{
+		purgeDeletedOrdersUseCase: purgeDeletedOrdersUseCase,
+		interval:                  interval,
+		now:                       time.Now,
+	}
+}
+
+func (j *OrderRetentionJob) Start(ctx context.Context) {
+	log.Printf("Starting order retention job (interval: %s)", j.interval)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			log.Println("Stopping order retention job")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *OrderRetentionJob) RunOnce(ctx context.Context) int64 {
+	purged, err := j.purgeDeletedOrdersUseCase.Execute(ctx, j.now())
+	if err != nil {
+		log.Printf("Order retention job: purged %d orders before failing: %v", purged, err)
+		return purged
+	}
+	if purged > 0 {
+		log.Printf("Order retention job: purged %d deleted orders", purged)
+	}
+	return purged
+}
@@ -0,0 +1,74 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePurgeUseCase struct {
+	mu     sync.Mutex
+	calls  []time.Time
+	purged int64
+	err    error
+}
+
+func (uc *fakePurgeUseCase) Execute(ctx context.Context, now time.Time) (int64, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.calls = append(uc.calls, now)
+	return uc.purged, uc.err
+}
+
+func (uc *fakePurgeUseCase) callCount() int {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	return len(uc.calls)
+}
+
+func TestOrderRetentionJob_RunOnce(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	useCase := &fakePurgeUseCase{purged: 3}
+	job := NewOrderRetentionJob(useCase, time.Minute)
+	job.now = func() time.Time { return now }
+
+	assert.Equal(t, int64(3), job.RunOnce(context.Background()))
+	assert.Equal(t, []time.Time{now}, useCase.calls)
+}
+
+func TestOrderRetentionJob_RunOnce_ReturnsPartialCountOnError(t *testing.T) {
+	useCase := &fakePurgeUseCase{purged: 2, err: errors.New("database unavailable")}
+	job := NewOrderRetentionJob(useCase, time.Minute)
+
+	assert.Equal(t, int64(2), job.RunOnce(context.Background()))
+}
+
+func TestOrderRetentionJob_DefaultInterval(t *testing.T) {
+	job := NewOrderRetentionJob(&fakePurgeUseCase{}, 0)
+	assert.Equal(t, time.Hour, job.interval)
+}
+
+func TestOrderRetentionJob_Start_RunsUntilCancelled(t *testing.T) {
+	useCase := &fakePurgeUseCase{}
+	job := NewOrderRetentionJob(useCase, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		job.Start(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool { return useCase.callCount() >= 2 }, time.Second, 5*time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Start() did not return after the context was cancelled")
+	}
+}
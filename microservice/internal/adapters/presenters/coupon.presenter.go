@@ -0,0 +1,31 @@
+package presenters
+
+import (
+	"microservice/internal/adapters/dtos"
+	"microservice/internal/domain/entities"
+)
+
+func ToCouponResponse(coupon entities.Coupon) dtos.CouponResponseDTO {
+	var amount *string
+	if coupon.Type == entities.COUPON_TYPE_FIXED_AMOUNT {
+		value := coupon.FixedAmount.String()
+		amount = &value
+	}
+
+	return dtos.CouponResponseDTO{
+		Code:           coupon.Code,
+		Type:           coupon.Type,
+		Percentage:     coupon.Percentage,
+		Amount:         amount,
+		Currency:       coupon.FixedAmount.Currency(),
+		ProductID:      coupon.ProductID,
+		BuyQuantity:    coupon.BuyQuantity,
+		FreeQuantity:   coupon.FreeQuantity,
+		FirstOrderOnly: coupon.FirstOrderOnly,
+		ValidFrom:      coupon.ValidFrom,
+		ValidUntil:     coupon.ValidUntil,
+		MaxUses:        coupon.MaxUses,
+		UsedCount:      coupon.UsedCount,
+		CreatedAt:      coupon.CreatedAt,
+	}
+}
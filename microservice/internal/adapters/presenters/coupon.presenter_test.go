@@ -0,0 +1,33 @@
+package presenters
+
+import (
+	"testing"
+
+	"microservice/internal/domain/entities"
+)
+
+func TestToCouponResponse_FixedAmount(t *testing.T) {
+	coupon := entities.Coupon{Code: "MENOS5", Type: entities.COUPON_TYPE_FIXED_AMOUNT, FixedAmount: brl("5.00"), MaxUses: 10, UsedCount: 2}
+
+	response := ToCouponResponse(coupon)
+
+	if response.Amount == nil || *response.Amount != "5.00" || response.Currency != "BRL" {
+		t.Errorf("ToCouponResponse() Amount = %v %v, want 5.00 BRL", response.Amount, response.Currency)
+	}
+	if response.MaxUses != 10 || response.UsedCount != 2 {
+		t.Errorf("ToCouponResponse() uses = %v/%v, want 2/10", response.UsedCount, response.MaxUses)
+	}
+}
+
+func TestToCouponResponse_Percentage(t *testing.T) {
+	coupon := entities.Coupon{Code: "PROMO10", Type: entities.COUPON_TYPE_PERCENTAGE, Percentage: 10, FixedAmount: brl("0")}
+
+	response := ToCouponResponse(coupon)
+
+	if response.Amount != nil {
+		t.Errorf("ToCouponResponse() Amount = %v, want nil for percentage coupons", *response.Amount)
+	}
+	if response.Percentage != 10 {
+		t.Errorf("ToCouponResponse() Percentage = %v, want 10", response.Percentage)
+	}
+}
@@ -11,26 +11,61 @@ func ToOrderResponse(order entities.Order) dtos.OrderResponseDTO {
 		Name: order.Status.Name.Value(),
 	}
 
-	items := make([]dtos.OrderItemDTO, len(order.Items))
-	for i, item := range order.Items {
-		items[i] = dtos.OrderItemDTO{
+	return dtos.OrderResponseDTO{
+		ID:         order.ID,
+		CustomerID: order.CustomerID,
+		Amount:     order.Amount.Value().String(),
+		Currency:   order.Amount.Value().Currency(),
+		Subtotal:   order.Subtotal.String(),
+		Discount:   order.Discount.String(),
+		CouponCode: order.CouponCode,
+		PickupCode: order.PickupCode.Value(),
+		Status:     status,
+		Items:      toOrderItemResponses(order.Items),
+		CreatedAt:  order.CreatedAt,
+		UpdatedAt:  order.UpdatedAt,
+
+		CustomerName: order.CustomerName,
+		GuestToken:   order.GuestToken,
+
+		CancellationReason: order.CancellationReason,
+		CancelledAt:        order.CancelledAt,
+	}
+}
+
+func toOrderItemResponses(items []entities.OrderItem) []dtos.OrderItemDTO {
+	responses := make([]dtos.OrderItemDTO, len(items))
+	for i, item := range items {
+		responses[i] = dtos.OrderItemDTO{
 			ID:        item.ID,
 			ProductID: item.ProductID.Value(),
 			OrderID:   item.OrderID,
 			Quantity:  item.Quantity.Value(),
-			UnitPrice: item.UnitPrice.Value(),
+			UnitPrice: item.UnitPrice.Value().String(),
+			Product: dtos.ProductSnapshotDTO{
+				Name:     item.Product.Name,
+				Category: item.Product.Category,
+				ImageURL: item.Product.ImageURL,
+				Notes:    item.Product.Notes,
+			},
+			Notes:      item.Notes,
+			Modifiers:  toOrderItemModifierResponses(item.Modifiers),
+			Components: toOrderItemResponses(item.Components),
 		}
 	}
+	return responses
+}
 
-	return dtos.OrderResponseDTO{
-		ID:         order.ID,
-		CustomerID: order.CustomerID,
-		Amount:     order.Amount.Value(),
-		Status:     status,
-		Items:      items,
-		CreatedAt:  order.CreatedAt,
-		UpdatedAt:  order.UpdatedAt,
+func toOrderItemModifierResponses(modifiers []entities.OrderItemModifier) []dtos.OrderItemModifierDTO {
+	responses := make([]dtos.OrderItemModifierDTO, len(modifiers))
+	for i, modifier := range modifiers {
+		responses[i] = dtos.OrderItemModifierDTO{
+			ModifierID: modifier.ModifierID,
+			Name:       modifier.Name,
+			PriceDelta: modifier.PriceDelta.String(),
+		}
 	}
+	return responses
 }
 
 func ToOrderResponseList(orders []entities.Order) []dtos.OrderResponseDTO {
@@ -41,6 +76,35 @@ func ToOrderResponseList(orders []entities.Order) []dtos.OrderResponseDTO {
 	return responses
 }
 
+// Tempos de espera medidos no momento da leitura do painel
+func ToOrderBoardResponse(board entities.OrderBoard) dtos.OrderBoardResponseDTO {
+	groups := make([]dtos.OrderBoardGroupDTO, len(board.Groups))
+	for i, group := range board.Groups {
+		orders := make([]dtos.OrderBoardEntryDTO, len(group.Orders))
+		for j, entry := range group.Orders {
+			orders[j] = dtos.OrderBoardEntryDTO{
+				ID:           entry.OrderID,
+				PickupCode:   entry.PickupCode.Value(),
+				CustomerName: entry.CustomerName,
+				CreatedAt:    entry.CreatedAt,
+				WaitSeconds:  int64(entry.WaitTime(board.GeneratedAt).Seconds()),
+			}
+		}
+		groups[i] = dtos.OrderBoardGroupDTO{
+			Status: dtos.OrderStatusDTO{
+				ID:   group.Status.ID,
+				Name: group.Status.Name.Value(),
+			},
+			Orders: orders,
+		}
+	}
+
+	return dtos.OrderBoardResponseDTO{
+		GeneratedAt: board.GeneratedAt,
+		Groups:      groups,
+	}
+}
+
 func ToOrderStatusResponse(status entities.OrderStatus) dtos.OrderStatusResponseDTO {
 	return dtos.OrderStatusResponseDTO{
 		ID:   status.ID,
@@ -55,3 +119,34 @@ func ToOrderStatusResponseList(statuses []entities.OrderStatus) []dtos.OrderStat
 	}
 	return responses
 }
+
+func ToOrderStatusHistoryResponse(change entities.OrderStatusChange) dtos.OrderStatusHistoryResponseDTO {
+	var previousStatus *dtos.OrderStatusDTO
+	if change.PreviousStatus != nil {
+		previousStatus = &dtos.OrderStatusDTO{
+			ID:   change.PreviousStatus.ID,
+			Name: change.PreviousStatus.Name.Value(),
+		}
+	}
+
+	return dtos.OrderStatusHistoryResponseDTO{
+		ID:             change.ID,
+		OrderID:        change.OrderID,
+		PreviousStatus: previousStatus,
+		NewStatus: dtos.OrderStatusDTO{
+			ID:   change.NewStatus.ID,
+			Name: change.NewStatus.Name.Value(),
+		},
+		Source:    change.Source,
+		Actor:     change.Actor,
+		ChangedAt: change.ChangedAt,
+	}
+}
+
+func ToOrderStatusHistoryResponseList(history []entities.OrderStatusChange) []dtos.OrderStatusHistoryResponseDTO {
+	responses := make([]dtos.OrderStatusHistoryResponseDTO, len(history))
+	for i, change := range history {
+		responses[i] = ToOrderStatusHistoryResponse(change)
+	}
+	return responses
+}
@@ -1,21 +1,32 @@
 package presenters
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
+	"microservice/internal/adapters/dtos"
 	"microservice/internal/domain/entities"
+	"microservice/internal/domain/value_objects"
 )
 
+func brl(value string) value_objects.Money {
+	money, _ := value_objects.ParseMoney(value, value_objects.DEFAULT_CURRENCY)
+	return money
+}
+
 func TestToOrderResponse(t *testing.T) {
 	customerID := "customer-123"
 	status, _ := entities.NewOrderStatus("status-1", "Pending")
-	item, _ := entities.NewOrderItem("item-1", "product-1", "order-1", 2, 10.0)
+	item, _ := entities.NewOrderItem("item-1", "product-1", "order-1", 2, brl("10.00"), "sem cebola", []entities.OrderItemModifier{
+		{ID: "modifier-1", ModifierID: "queijo-extra", Name: "Queijo extra", PriceDelta: brl("3.00")},
+	})
+	item.Product = entities.ProductSnapshot{Name: "X-Burger", Category: "Lanche", ImageURL: "https://cdn.example.com/x-burger.png", Notes: "Contém glúten"}
 	now := time.Now()
 	order, _ := entities.NewOrderWithItems(
 		"order-1",
 		&customerID,
-		20.0,
+		brl("20.00"),
 		*status,
 		[]entities.OrderItem{*item},
 		now,
@@ -30,7 +41,7 @@ func TestToOrderResponse(t *testing.T) {
 	if *response.CustomerID != customerID {
 		t.Errorf("ToOrderResponse() CustomerID = %v, want %v", *response.CustomerID, customerID)
 	}
-	if response.Amount != 20.0 {
+	if response.Amount != "20.00" {
 		t.Errorf("ToOrderResponse() Amount = %v, want 20.0", response.Amount)
 	}
 	if response.Status.ID != "status-1" {
@@ -51,21 +62,48 @@ func TestToOrderResponse(t *testing.T) {
 	if response.Items[0].Quantity != 2 {
 		t.Errorf("ToOrderResponse() Items[0].Quantity = %v, want 2", response.Items[0].Quantity)
 	}
-	if response.Items[0].UnitPrice != 10.0 {
+	if response.Items[0].UnitPrice != "10.00" {
 		t.Errorf("ToOrderResponse() Items[0].UnitPrice = %v, want 10.0", response.Items[0].UnitPrice)
 	}
+	expectedProduct := dtos.ProductSnapshotDTO{Name: "X-Burger", Category: "Lanche", ImageURL: "https://cdn.example.com/x-burger.png", Notes: "Contém glúten"}
+	if response.Items[0].Product != expectedProduct {
+		t.Errorf("ToOrderResponse() Items[0].Product = %+v, want %+v", response.Items[0].Product, expectedProduct)
+	}
+	if response.Items[0].Notes != "sem cebola" {
+		t.Errorf("ToOrderResponse() Items[0].Notes = %q, want sem cebola", response.Items[0].Notes)
+	}
+	expectedModifiers := []dtos.OrderItemModifierDTO{{ModifierID: "queijo-extra", Name: "Queijo extra", PriceDelta: "3.00"}}
+	if !reflect.DeepEqual(response.Items[0].Modifiers, expectedModifiers) {
+		t.Errorf("ToOrderResponse() Items[0].Modifiers = %+v, want %+v", response.Items[0].Modifiers, expectedModifiers)
+	}
+}
+
+func TestToOrderResponse_WithDiscount(t *testing.T) {
+	status, _ := entities.NewOrderStatus("status-1", "Recebido")
+	order, _ := entities.NewOrderWithItems("order-1", nil, brl("18.00"), *status, nil, time.Now(), nil)
+	couponCode := "PROMO10"
+	_ = order.SetDiscount(brl("20.00"), brl("2.00"), &couponCode)
+
+	response := ToOrderResponse(*order)
+
+	if response.Subtotal != "20.00" || response.Discount != "2.00" || response.Amount != "18.00" {
+		t.Errorf("ToOrderResponse() subtotal/discount/amount = %v/%v/%v, want 20.00/2.00/18.00", response.Subtotal, response.Discount, response.Amount)
+	}
+	if response.CouponCode == nil || *response.CouponCode != "PROMO10" {
+		t.Errorf("ToOrderResponse() CouponCode = %v, want PROMO10", response.CouponCode)
+	}
 }
 
 func TestToOrderResponse_WithUpdatedAt(t *testing.T) {
 	customerID := "customer-123"
 	status, _ := entities.NewOrderStatus("status-1", "Pending")
-	item, _ := entities.NewOrderItem("item-1", "product-1", "order-1", 1, 10.0)
+	item, _ := entities.NewOrderItem("item-1", "product-1", "order-1", 1, brl("10.00"), "", nil)
 	now := time.Now()
 	updatedAt := now.Add(time.Hour)
 	order, _ := entities.NewOrderWithItems(
 		"order-1",
 		&customerID,
-		10.0,
+		brl("10.00"),
 		*status,
 		[]entities.OrderItem{*item},
 		now,
@@ -81,12 +119,12 @@ func TestToOrderResponse_WithUpdatedAt(t *testing.T) {
 
 func TestToOrderResponse_NilCustomerID(t *testing.T) {
 	status, _ := entities.NewOrderStatus("status-1", "Pending")
-	item, _ := entities.NewOrderItem("item-1", "product-1", "order-1", 1, 10.0)
+	item, _ := entities.NewOrderItem("item-1", "product-1", "order-1", 1, brl("10.00"), "", nil)
 	now := time.Now()
 	order, _ := entities.NewOrderWithItems(
 		"order-1",
 		nil,
-		10.0,
+		brl("10.00"),
 		*status,
 		[]entities.OrderItem{*item},
 		now,
@@ -103,12 +141,12 @@ func TestToOrderResponse_NilCustomerID(t *testing.T) {
 func TestToOrderResponseList(t *testing.T) {
 	customerID := "customer-123"
 	status, _ := entities.NewOrderStatus("status-1", "Pending")
-	item1, _ := entities.NewOrderItem("item-1", "product-1", "order-1", 1, 10.0)
-	item2, _ := entities.NewOrderItem("item-2", "product-2", "order-2", 2, 20.0)
+	item1, _ := entities.NewOrderItem("item-1", "product-1", "order-1", 1, brl("10.00"), "", nil)
+	item2, _ := entities.NewOrderItem("item-2", "product-2", "order-2", 2, brl("20.00"), "", nil)
 	now := time.Now()
 
-	order1, _ := entities.NewOrderWithItems("order-1", &customerID, 10.0, *status, []entities.OrderItem{*item1}, now, nil)
-	order2, _ := entities.NewOrderWithItems("order-2", &customerID, 40.0, *status, []entities.OrderItem{*item2}, now, nil)
+	order1, _ := entities.NewOrderWithItems("order-1", &customerID, brl("10.00"), *status, []entities.OrderItem{*item1}, now, nil)
+	order2, _ := entities.NewOrderWithItems("order-2", &customerID, brl("40.00"), *status, []entities.OrderItem{*item2}, now, nil)
 
 	orders := []entities.Order{*order1, *order2}
 	responses := ToOrderResponseList(orders)
@@ -173,3 +211,85 @@ func TestToOrderStatusResponseList_Empty(t *testing.T) {
 		t.Errorf("ToOrderStatusResponseList() length = %v, want 0", len(responses))
 	}
 }
+
+func TestToOrderStatusHistoryResponse(t *testing.T) {
+	previous, _ := entities.NewOrderStatus("status-1", "Pronto")
+	next, _ := entities.NewOrderStatus("status-2", "Entregue")
+	actor := "staff-1"
+	change, _ := entities.NewOrderStatusChange("change-1", "order-1", previous, *next, entities.STATUS_CHANGE_SOURCE_REST, &actor, time.Now())
+
+	response := ToOrderStatusHistoryResponse(*change)
+
+	if response.PreviousStatus == nil || response.PreviousStatus.Name != "Pronto" {
+		t.Errorf("ToOrderStatusHistoryResponse() PreviousStatus = %v, want Pronto", response.PreviousStatus)
+	}
+	if response.NewStatus.Name != "Entregue" {
+		t.Errorf("ToOrderStatusHistoryResponse() NewStatus.Name = %v, want Entregue", response.NewStatus.Name)
+	}
+	if response.Source != entities.STATUS_CHANGE_SOURCE_REST {
+		t.Errorf("ToOrderStatusHistoryResponse() Source = %v, want %v", response.Source, entities.STATUS_CHANGE_SOURCE_REST)
+	}
+	if *response.Actor != "staff-1" {
+		t.Errorf("ToOrderStatusHistoryResponse() Actor = %v, want staff-1", *response.Actor)
+	}
+}
+
+func TestToOrderStatusHistoryResponseList_InitialEntry(t *testing.T) {
+	received, _ := entities.NewOrderStatus("status-1", "Recebido")
+	change, _ := entities.NewOrderStatusChange("change-1", "order-1", nil, *received, entities.STATUS_CHANGE_SOURCE_REST, nil, time.Now())
+
+	responses := ToOrderStatusHistoryResponseList([]entities.OrderStatusChange{*change})
+
+	if len(responses) != 1 {
+		t.Fatalf("ToOrderStatusHistoryResponseList() length = %v, want 1", len(responses))
+	}
+	if responses[0].PreviousStatus != nil {
+		t.Error("ToOrderStatusHistoryResponseList() initial entry should have no previous status")
+	}
+}
+
+func TestToOrderResponse_ComboComponents(t *testing.T) {
+	status, _ := entities.NewOrderStatus("status-1", "Pending")
+	combo, _ := entities.NewOrderItem("item-1", "combo-1", "order-1", 1, brl("39.90"), "", nil)
+	component, _ := entities.NewOrderItem("item-2", "product-1", "order-1", 1, brl("25.90"), "", nil)
+	component.Product = entities.ProductSnapshot{Name: "X-Burger"}
+	_ = combo.AddComponent(*component)
+	order, _ := entities.NewOrderWithItems("order-1", nil, brl("39.90"), *status, []entities.OrderItem{*combo}, time.Now(), nil)
+
+	response := ToOrderResponse(*order)
+
+	if len(response.Items) != 1 || len(response.Items[0].Components) != 1 {
+		t.Fatalf("ToOrderResponse() Items = %+v, want one combo with one component", response.Items)
+	}
+	got := response.Items[0].Components[0]
+	if got.ID != "item-2" || got.Product.Name != "X-Burger" || got.UnitPrice != "25.90" {
+		t.Errorf("ToOrderResponse() component = %+v, want item-2 X-Burger 25.90", got)
+	}
+}
+
+func TestToOrderBoardResponse(t *testing.T) {
+	ready, _ := entities.NewOrderStatus("status-4", entities.ORDER_STATUS_READY)
+	code, _ := value_objects.NewPickupCode(7)
+	generatedAt := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	createdAt := generatedAt.Add(-90 * time.Second)
+	board := entities.NewOrderBoard([]entities.OrderStatus{*ready}, []entities.OrderBoardEntry{
+		{OrderID: "order-1", PickupCode: code, CustomerName: "Maria", Status: *ready, CreatedAt: createdAt},
+	}, generatedAt)
+
+	response := ToOrderBoardResponse(board)
+
+	expected := dtos.OrderBoardResponseDTO{
+		GeneratedAt: generatedAt,
+		Groups: []dtos.OrderBoardGroupDTO{
+			{
+				Status: dtos.OrderStatusDTO{ID: "status-4", Name: entities.ORDER_STATUS_READY},
+				Orders: []dtos.OrderBoardEntryDTO{
+					{ID: "order-1", PickupCode: "A007", CustomerName: "Maria", CreatedAt: createdAt, WaitSeconds: 90},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(response, expected) {
+		t.Errorf("ToOrderBoardResponse() = %+v, want %+v", response, expected)
+	}
+}
@@ -0,0 +1,207 @@
+package relays
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"microservice/internal/adapters/brokers"
+	"microservice/internal/adapters/daos"
+	"microservice/internal/interfaces"
+)
+
+type OutboxRelayConfig struct {
+	PollInterval time.Duration
+	// Quantidade de pedidos reservados por vez
+	BatchSize   int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// Tentativas antes de a mensagem ser marcada como morta
+	MaxAttempts int
+	// Tempo que os pedidos reservados ficam com esta instância
+	LeaseDuration time.Duration
+	// Limite de cada publicação; só se publica se ela termina dentro da reserva
+	PublishTimeout time.Duration
+}
+
+func DefaultOutboxRelayConfig() OutboxRelayConfig {
+	return OutboxRelayConfig{
+		PollInterval:   2 * time.Second,
+		BatchSize:      100,
+		BaseBackoff:    time.Second,
+		MaxBackoff:     5 * time.Minute,
+		MaxAttempts:    10,
+		LeaseDuration:  time.Minute,
+		PublishTimeout: brokers.PUBLISH_CONFIRM_TIMEOUT,
+	}
+}
+
+// Publica o outbox na ordem de inserção; uma mensagem com falha segura as seguintes
+// do mesmo pedido até ser enviada ou marcada como morta. Os pedidos são reservados
+// antes de publicar, então várias instâncias podem rodar o relay
+type OutboxRelay struct {
+	broker     brokers.MessageBroker
+	datasource interfaces.IOutboxDataSource
+	config     OutboxRelayConfig
+	now        func() time.Time
+}
+
+func NewOutboxRelay(broker brokers.MessageBroker, datasource interfaces.IOutboxDataSource, config OutboxRelayConfig) *OutboxRelay {
+	defaults := DefaultOutboxRelayConfig()
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaults.PollInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+	if config.BaseBackoff <= 0 {
+		config.BaseBackoff = defaults.BaseBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = defaults.MaxBackoff
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaults.MaxAttempts
+	}
+	if config.LeaseDuration <= 0 {
+		config.LeaseDuration = defaults.LeaseDuration
+	}
+	if config.PublishTimeout <= 0 {
+		config.PublishTimeout = defaults.PublishTimeout
+	}
+	if config.LeaseDuration <= config.PublishTimeout {
+		log.Printf("Outbox relay: lease %s is not longer than the publish timeout %s, using %s", config.LeaseDuration, config.PublishTimeout, 2*config.PublishTimeout)
+		config.LeaseDuration = 2 * config.PublishTimeout
+	}
+
+	return &OutboxRelay{
+		broker:     broker,
+		datasource: datasource,
+		config:     config,
+		now:        time.Now,
+	}
+}
+
+func (r *OutboxRelay) Start(ctx context.Context) {
+	log.Printf("Starting outbox relay (poll interval: %s, batch size: %d)", r.config.PollInterval, r.config.BatchSize)
+
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.RelayPending(ctx); err != nil {
+			log.Printf("Outbox relay: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("Stopping outbox relay")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Retorna quantas mensagens foram enviadas
+func (r *OutboxRelay) RelayPending(ctx context.Context) (int, error) {
+	now := r.now()
+	lockedUntil := now.Add(r.config.LeaseDuration)
+	messages, err := r.datasource.ClaimPending(ctx, r.config.BatchSize, now, lockedUntil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load pending outbox messages: %w", err)
+	}
+
+	// O resultado de uma publicação feita é gravado mesmo durante o desligamento
+	markCtx := context.WithoutCancel(ctx)
+
+	sent := 0
+	blockedOrders := make(map[string]bool)
+
+	for _, message := range messages {
+		if ctx.Err() != nil {
+			return sent, ctx.Err()
+		}
+
+		if blockedOrders[message.OrderID] {
+			continue
+		}
+
+		if message.NextAttemptAt.After(r.now()) {
+			blockedOrders[message.OrderID] = true
+			continue
+		}
+
+		// Depois da reserva outra instância pode pegar os mesmos pedidos; o restante
+		// fica para a próxima rodada
+		if r.now().Add(r.config.PublishTimeout).After(lockedUntil) {
+			log.Printf("Outbox relay: lease is about to expire, leaving %s of order %s for the next run", message.ID, message.OrderID)
+			break
+		}
+
+		if err := r.publish(ctx, message); err != nil {
+			if message.Attempts+1 >= r.config.MaxAttempts {
+				r.markDead(markCtx, message, err)
+				continue
+			}
+			blockedOrders[message.OrderID] = true
+			r.markFailed(markCtx, message, err)
+			continue
+		}
+
+		if err := r.datasource.MarkSent(markCtx, message.ID, r.now()); err != nil {
+			// O evento já foi publicado; será reenviado e os consumidores devem deduplicar pelo ID
+			blockedOrders[message.OrderID] = true
+			log.Printf("Outbox relay: failed to mark message %s as sent: %v", message.ID, err)
+			continue
+		}
+
+		sent++
+	}
+
+	return sent, nil
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, message daos.OutboxMessageDAO) error {
+	var event brokers.OrderEvent
+	if err := json.Unmarshal(message.Payload, &event); err != nil {
+		return fmt.Errorf("failed to unmarshal outbox message: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.config.PublishTimeout)
+	defer cancel()
+	return r.broker.PublishOrderEvent(ctx, event)
+}
+
+func (r *OutboxRelay) markFailed(ctx context.Context, message daos.OutboxMessageDAO, publishErr error) {
+	nextAttemptAt := r.now().Add(r.backoff(message.Attempts + 1))
+
+	log.Printf("Outbox relay: failed to publish %s for order %s (attempt %d), retrying at %s: %v",
+		message.EventType, message.OrderID, message.Attempts+1, nextAttemptAt.Format(time.RFC3339), publishErr)
+
+	if err := r.datasource.MarkFailed(ctx, message.ID, publishErr.Error(), nextAttemptAt); err != nil {
+		log.Printf("Outbox relay: failed to record failure for message %s: %v", message.ID, err)
+	}
+}
+
+// Desiste da mensagem para liberar as seguintes do pedido
+func (r *OutboxRelay) markDead(ctx context.Context, message daos.OutboxMessageDAO, publishErr error) {
+	log.Printf("Outbox relay: giving up on %s %s for order %s after %d attempts: %v",
+		message.EventType, message.ID, message.OrderID, message.Attempts+1, publishErr)
+
+	if err := r.datasource.MarkDead(ctx, message.ID, publishErr.Error(), r.now()); err != nil {
+		log.Printf("Outbox relay: failed to mark message %s as dead: %v", message.ID, err)
+	}
+}
+
+func (r *OutboxRelay) backoff(attempts int) time.Duration {
+	delay := r.config.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= r.config.MaxBackoff {
+			return r.config.MaxBackoff
+		}
+	}
+	return delay
+}
@@ -0,0 +1,247 @@
+package relays
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"microservice/internal/adapters/brokers"
+	"microservice/internal/adapters/daos"
+)
+
+type fakeOutboxDataSource struct {
+	messages []daos.OutboxMessageDAO
+}
+
+func (ds *fakeOutboxDataSource) add(t *testing.T, orderID string, eventType string, createdAt time.Time) string {
+	event, err := brokers.NewOrderEvent(context.Background(), eventType, orderID, map[string]string{"order_id": orderID})
+	assert.NoError(t, err)
+	payload, _ := json.Marshal(event)
+
+	ds.messages = append(ds.messages, daos.OutboxMessageDAO{
+		ID:            event.ID,
+		OrderID:       orderID,
+		EventType:     eventType,
+		Payload:       payload,
+		CreatedAt:     createdAt,
+		NextAttemptAt: createdAt,
+	})
+	return event.ID
+}
+
+func (ds *fakeOutboxDataSource) find(id string) *daos.OutboxMessageDAO {
+	for i := range ds.messages {
+		if ds.messages[i].ID == id {
+			return &ds.messages[i]
+		}
+	}
+	return nil
+}
+
+func (ds *fakeOutboxDataSource) ClaimPending(ctx context.Context, limit int, now time.Time, lockedUntil time.Time) ([]daos.OutboxMessageDAO, error) {
+	result := []daos.OutboxMessageDAO{}
+	for _, message := range ds.messages {
+		if message.SentAt == nil && message.DeadAt == nil && len(result) < limit {
+			result = append(result, message)
+		}
+	}
+	return result, nil
+}
+
+func (ds *fakeOutboxDataSource) MarkSent(ctx context.Context, id string, sentAt time.Time) error {
+	ds.find(id).SentAt = &sentAt
+	return nil
+}
+
+func (ds *fakeOutboxDataSource) MarkFailed(ctx context.Context, id string, lastError string, nextAttemptAt time.Time) error {
+	message := ds.find(id)
+	message.Attempts++
+	message.LastError = &lastError
+	message.NextAttemptAt = nextAttemptAt
+	return nil
+}
+
+func (ds *fakeOutboxDataSource) MarkDead(ctx context.Context, id string, lastError string, deadAt time.Time) error {
+	message := ds.find(id)
+	message.Attempts++
+	message.LastError = &lastError
+	message.DeadAt = &deadAt
+	return nil
+}
+
+type flakyBroker struct {
+	*brokers.InMemoryBroker
+	failures map[string]int
+}
+
+func (b *flakyBroker) PublishOrderEvent(ctx context.Context, event brokers.OrderEvent) error {
+	if b.failures[event.OrderID] > 0 {
+		b.failures[event.OrderID]--
+		return errors.New("broker unavailable")
+	}
+	return b.InMemoryBroker.PublishOrderEvent(ctx, event)
+}
+
+func newTestRelay(broker brokers.MessageBroker, ds *fakeOutboxDataSource, now time.Time) *OutboxRelay {
+	relay := NewOutboxRelay(broker, ds, OutboxRelayConfig{BatchSize: 10, BaseBackoff: time.Second, MaxBackoff: 8 * time.Second})
+	relay.now = func() time.Time { return now }
+	return relay
+}
+
+func TestOutboxRelay_RelayPending_PublishesInOrderAndMarksSent(t *testing.T) {
+	now := time.Now()
+	ds := &fakeOutboxDataSource{}
+	ds.add(t, "order-1", brokers.ORDER_CREATED_EVENT, now.Add(-2*time.Second))
+	ds.add(t, "order-1", brokers.ORDER_STATUS_CHANGED_EVENT, now.Add(-time.Second))
+	broker := brokers.NewInMemoryBroker()
+
+	sent, err := newTestRelay(broker, ds, now).RelayPending(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	published := broker.PublishedEvents()
+	assert.Len(t, published, 2)
+	assert.Equal(t, brokers.ORDER_CREATED_EVENT, published[0].Type)
+	assert.Equal(t, brokers.ORDER_STATUS_CHANGED_EVENT, published[1].Type)
+	for _, message := range ds.messages {
+		assert.NotNil(t, message.SentAt)
+	}
+}
+
+func TestOutboxRelay_RelayPending_FailureHoldsBackSameOrder(t *testing.T) {
+	now := time.Now()
+	ds := &fakeOutboxDataSource{}
+	firstID := ds.add(t, "order-1", brokers.ORDER_CREATED_EVENT, now.Add(-3*time.Second))
+	ds.add(t, "order-1", brokers.ORDER_STATUS_CHANGED_EVENT, now.Add(-2*time.Second))
+	ds.add(t, "order-2", brokers.ORDER_CREATED_EVENT, now.Add(-time.Second))
+	broker := &flakyBroker{InMemoryBroker: brokers.NewInMemoryBroker(), failures: map[string]int{"order-1": 1}}
+
+	sent, err := newTestRelay(broker, ds, now).RelayPending(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	published := broker.PublishedEvents()
+	assert.Len(t, published, 1)
+	assert.Equal(t, "order-2", published[0].OrderID)
+
+	failed := ds.find(firstID)
+	assert.Equal(t, 1, failed.Attempts)
+	assert.Equal(t, "broker unavailable", *failed.LastError)
+	assert.Equal(t, now.Add(time.Second), failed.NextAttemptAt)
+}
+
+func TestOutboxRelay_RelayPending_RetriesAfterBackoff(t *testing.T) {
+	now := time.Now()
+	ds := &fakeOutboxDataSource{}
+	ds.add(t, "order-1", brokers.ORDER_CREATED_EVENT, now.Add(-2*time.Second))
+	ds.add(t, "order-1", brokers.ORDER_DELETED_EVENT, now.Add(-time.Second))
+	broker := &flakyBroker{InMemoryBroker: brokers.NewInMemoryBroker(), failures: map[string]int{"order-1": 1}}
+
+	_, _ = newTestRelay(broker, ds, now).RelayPending(context.Background())
+
+	// Antes do backoff expirar nada é publicado
+	sent, _ := newTestRelay(broker, ds, now.Add(500*time.Millisecond)).RelayPending(context.Background())
+	assert.Equal(t, 0, sent)
+
+	sent, err := newTestRelay(broker, ds, now.Add(2*time.Second)).RelayPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	published := broker.PublishedEvents()
+	assert.Equal(t, brokers.ORDER_CREATED_EVENT, published[0].Type)
+	assert.Equal(t, brokers.ORDER_DELETED_EVENT, published[1].Type)
+}
+
+func TestOutboxRelay_RelayPending_DeadMessageNoLongerHoldsBackOrder(t *testing.T) {
+	now := time.Now()
+	ds := &fakeOutboxDataSource{}
+	poisonID := ds.add(t, "order-1", brokers.ORDER_CREATED_EVENT, now.Add(-2*time.Second))
+	ds.add(t, "order-1", brokers.KITCHEN_ORDER_REQUESTED_EVENT, now.Add(-time.Second))
+	ds.find(poisonID).Payload = []byte("not json")
+	ds.find(poisonID).Attempts = 2
+	broker := brokers.NewInMemoryBroker()
+
+	relay := NewOutboxRelay(broker, ds, OutboxRelayConfig{BatchSize: 10, MaxAttempts: 3})
+	relay.now = func() time.Time { return now }
+	sent, err := relay.RelayPending(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	poison := ds.find(poisonID)
+	assert.NotNil(t, poison.DeadAt)
+	assert.Equal(t, 3, poison.Attempts)
+	assert.Contains(t, *poison.LastError, "unmarshal")
+	published := broker.PublishedEvents()
+	assert.Len(t, published, 1)
+	assert.Equal(t, brokers.KITCHEN_ORDER_REQUESTED_EVENT, published[0].Type)
+}
+
+// Cada publicação consome parte da reserva
+type slowBroker struct {
+	*brokers.InMemoryBroker
+	clock *time.Time
+	delay time.Duration
+}
+
+func (b *slowBroker) PublishOrderEvent(ctx context.Context, event brokers.OrderEvent) error {
+	*b.clock = b.clock.Add(b.delay)
+	return b.InMemoryBroker.PublishOrderEvent(ctx, event)
+}
+
+func TestOutboxRelay_RelayPending_StopsBeforeLeaseExpires(t *testing.T) {
+	now := time.Now()
+	ds := &fakeOutboxDataSource{}
+	ds.add(t, "order-1", brokers.ORDER_CREATED_EVENT, now.Add(-3*time.Second))
+	ds.add(t, "order-2", brokers.ORDER_CREATED_EVENT, now.Add(-2*time.Second))
+	lastID := ds.add(t, "order-3", brokers.ORDER_CREATED_EVENT, now.Add(-time.Second))
+	clock := now
+	broker := &slowBroker{InMemoryBroker: brokers.NewInMemoryBroker(), clock: &clock, delay: 15 * time.Second}
+
+	relay := NewOutboxRelay(broker, ds, OutboxRelayConfig{BatchSize: 10, LeaseDuration: 30 * time.Second, PublishTimeout: 10 * time.Second})
+	relay.now = func() time.Time { return clock }
+	sent, err := relay.RelayPending(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	last := ds.find(lastID)
+	assert.Nil(t, last.SentAt)
+	assert.Equal(t, 0, last.Attempts)
+}
+
+func TestNewOutboxRelay_LeaseLongerThanPublishTimeout(t *testing.T) {
+	relay := NewOutboxRelay(nil, &fakeOutboxDataSource{}, OutboxRelayConfig{LeaseDuration: 5 * time.Second, PublishTimeout: 10 * time.Second})
+
+	assert.Equal(t, 20*time.Second, relay.config.LeaseDuration)
+}
+
+func TestOutboxRelay_Backoff(t *testing.T) {
+	relay := NewOutboxRelay(nil, &fakeOutboxDataSource{}, OutboxRelayConfig{BaseBackoff: time.Second, MaxBackoff: 5 * time.Second})
+
+	assert.Equal(t, time.Second, relay.backoff(1))
+	assert.Equal(t, 2*time.Second, relay.backoff(2))
+	assert.Equal(t, 4*time.Second, relay.backoff(3))
+	assert.Equal(t, 5*time.Second, relay.backoff(4))
+	assert.Equal(t, 5*time.Second, relay.backoff(20))
+}
+
+func TestOutboxRelay_Start_StopsOnContextCancel(t *testing.T) {
+	relay := NewOutboxRelay(brokers.NewInMemoryBroker(), &fakeOutboxDataSource{}, OutboxRelayConfig{PollInterval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		relay.Start(ctx)
+		close(done)
+	}()
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("relay did not stop after context cancellation")
+	}
+}
@@ -0,0 +1,166 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"microservice/internal/domain/exceptions"
+	"microservice/internal/domain/value_objects"
+)
+
+const (
+	COUPON_TYPE_PERCENTAGE   = "percentage"
+	COUPON_TYPE_FIXED_AMOUNT = "fixed_amount"
+	COUPON_TYPE_BUY_X_GET_Y  = "buy_x_get_y"
+)
+
+var couponCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,30}$`)
+
+// BuyXGetY dá FreeQuantity unidades de ProductID a cada BuyQuantity compradas
+type Coupon struct {
+	Code string
+	Type string
+
+	Percentage   int                 // percentage: de 1 a 99
+	FixedAmount  value_objects.Money // fixed_amount
+	ProductID    string              // buy_x_get_y
+	BuyQuantity  int                 // buy_x_get_y
+	FreeQuantity int                 // buy_x_get_y
+
+	FirstOrderOnly bool
+	ValidFrom      *time.Time
+	ValidUntil     *time.Time
+	// Zero é ilimitado
+	MaxUses   int
+	UsedCount int
+	CreatedAt time.Time
+}
+
+func NewCoupon(coupon Coupon) (*Coupon, error) {
+	coupon.Code = NormalizeCouponCode(coupon.Code)
+	if !couponCodePattern.MatchString(coupon.Code) {
+		return nil, &exceptions.InvalidCouponDataException{
+			Message: "Coupon code must have 3 to 30 letters, digits, '-' or '_'",
+		}
+	}
+
+	switch coupon.Type {
+	case COUPON_TYPE_PERCENTAGE:
+		if coupon.Percentage < 1 || coupon.Percentage > 99 {
+			return nil, &exceptions.InvalidCouponDataException{
+				Message: "Coupon percentage must be between 1 and 99",
+			}
+		}
+	case COUPON_TYPE_FIXED_AMOUNT:
+		if !coupon.FixedAmount.IsPositive() {
+			return nil, &exceptions.InvalidCouponDataException{
+				Message: "Coupon amount must be greater than 0",
+			}
+		}
+	case COUPON_TYPE_BUY_X_GET_Y:
+		if coupon.ProductID == "" || coupon.BuyQuantity < 1 || coupon.FreeQuantity < 1 {
+			return nil, &exceptions.InvalidCouponDataException{
+				Message: "Buy X get Y coupons need a product and quantities greater than 0",
+			}
+		}
+	default:
+		return nil, &exceptions.InvalidCouponDataException{
+			Message: fmt.Sprintf("Invalid coupon type %q", coupon.Type),
+		}
+	}
+
+	if coupon.ValidFrom != nil && coupon.ValidUntil != nil && !coupon.ValidUntil.After(*coupon.ValidFrom) {
+		return nil, &exceptions.InvalidCouponDataException{
+			Message: "Coupon valid_until must be after valid_from",
+		}
+	}
+
+	if coupon.MaxUses < 0 || coupon.UsedCount < 0 {
+		return nil, &exceptions.InvalidCouponDataException{
+			Message: "Coupon usage limits cannot be negative",
+		}
+	}
+
+	return &coupon, nil
+}
+
+func NormalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (c Coupon) CheckRedeemable(now time.Time) error {
+	if c.ValidFrom != nil && now.Before(*c.ValidFrom) {
+		return &exceptions.CouponNotApplicableException{
+			Message: fmt.Sprintf("Coupon %s is not valid yet", c.Code),
+		}
+	}
+	if c.ValidUntil != nil && now.After(*c.ValidUntil) {
+		return &exceptions.CouponExpiredException{
+			Message: fmt.Sprintf("Coupon %s has expired", c.Code),
+		}
+	}
+	if c.MaxUses > 0 && c.UsedCount >= c.MaxUses {
+		return &exceptions.CouponUsageLimitReachedException{
+			Message: fmt.Sprintf("Coupon %s has no uses left", c.Code),
+		}
+	}
+	return nil
+}
+
+// Cupons cujo desconto cobriria o pedido inteiro não são aplicáveis
+func (c Coupon) CalculateDiscount(order Order) (value_objects.Money, error) {
+	subtotal := order.Subtotal
+
+	var discount value_objects.Money
+	switch c.Type {
+	case COUPON_TYPE_PERCENTAGE:
+		discount = subtotal.Percentage(c.Percentage)
+	case COUPON_TYPE_FIXED_AMOUNT:
+		if c.FixedAmount.Currency() != subtotal.Currency() {
+			return value_objects.Money{}, &exceptions.CouponNotApplicableException{
+				Message: fmt.Sprintf("Coupon %s is in %s, not %s", c.Code, c.FixedAmount.Currency(), subtotal.Currency()),
+			}
+		}
+		discount = c.FixedAmount
+	case COUPON_TYPE_BUY_X_GET_Y:
+		discount = c.buyXGetYDiscount(order)
+	}
+
+	if !discount.IsPositive() {
+		return value_objects.Money{}, &exceptions.CouponNotApplicableException{
+			Message: fmt.Sprintf("Order has no items eligible for coupon %s", c.Code),
+		}
+	}
+
+	remaining, err := subtotal.Subtract(discount)
+	if err != nil {
+		return value_objects.Money{}, err
+	}
+	if !remaining.IsPositive() {
+		return value_objects.Money{}, &exceptions.CouponNotApplicableException{
+			Message: fmt.Sprintf("Coupon %s discount %s covers the whole order", c.Code, discount),
+		}
+	}
+
+	return discount, nil
+}
+
+// Componentes de combo não contam, pois não são cobrados à parte
+func (c Coupon) buyXGetYDiscount(order Order) value_objects.Money {
+	units := 0
+	var unitPrice value_objects.Money
+	for _, item := range order.Items {
+		if item.ProductID.Value() != c.ProductID {
+			continue
+		}
+		units += item.Quantity.Value()
+		if unitPrice.IsZero() || item.UnitPrice.Value().MinorUnits() < unitPrice.MinorUnits() {
+			unitPrice = item.UnitPrice.Value()
+		}
+	}
+
+	freeUnits := units / (c.BuyQuantity + c.FreeQuantity) * c.FreeQuantity
+	return unitPrice.Multiply(freeUnits)
+}
@@ -0,0 +1,160 @@
+package entities
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"microservice/internal/domain/exceptions"
+)
+
+func newTestOrderWithItems(t *testing.T, items ...*OrderItem) *Order {
+	t.Helper()
+	received, _ := NewOrderStatus("status-1", ORDER_STATUS_RECEIVED)
+	order, _ := NewOrder("550e8400-e29b-41d4-a716-446655440000", nil)
+	order.Status = *received
+	for _, item := range items {
+		order.AddItem(*item)
+	}
+	if err := order.CalcTotalAmount(); err != nil {
+		t.Fatalf("CalcTotalAmount() unexpected error: %v", err)
+	}
+	return order
+}
+
+func TestNewCoupon_Valid(t *testing.T) {
+	tests := []struct {
+		name   string
+		coupon Coupon
+	}{
+		{"percentage", Coupon{Code: "promo10", Type: COUPON_TYPE_PERCENTAGE, Percentage: 10}},
+		{"fixed amount", Coupon{Code: "MENOS5", Type: COUPON_TYPE_FIXED_AMOUNT, FixedAmount: brl("5.00")}},
+		{"buy x get y", Coupon{Code: "LEVE3-PAGUE2", Type: COUPON_TYPE_BUY_X_GET_Y, ProductID: "product-1", BuyQuantity: 2, FreeQuantity: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coupon, err := NewCoupon(tt.coupon)
+			if err != nil {
+				t.Fatalf("NewCoupon() unexpected error: %v", err)
+			}
+			if coupon.Code != NormalizeCouponCode(tt.coupon.Code) {
+				t.Errorf("NewCoupon() Code = %v, want upper case", coupon.Code)
+			}
+		})
+	}
+}
+
+func TestNewCoupon_InvalidData(t *testing.T) {
+	validFrom := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	validUntil := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		coupon Coupon
+	}{
+		{"short code", Coupon{Code: "AB", Type: COUPON_TYPE_PERCENTAGE, Percentage: 10}},
+		{"code with spaces", Coupon{Code: "PROMO 10", Type: COUPON_TYPE_PERCENTAGE, Percentage: 10}},
+		{"unknown type", Coupon{Code: "PROMO10", Type: "free_shipping"}},
+		{"zero percentage", Coupon{Code: "PROMO10", Type: COUPON_TYPE_PERCENTAGE}},
+		{"full percentage", Coupon{Code: "PROMO10", Type: COUPON_TYPE_PERCENTAGE, Percentage: 100}},
+		{"zero amount", Coupon{Code: "MENOS5", Type: COUPON_TYPE_FIXED_AMOUNT, FixedAmount: brl("0")}},
+		{"buy x get y without product", Coupon{Code: "LEVE3", Type: COUPON_TYPE_BUY_X_GET_Y, BuyQuantity: 2, FreeQuantity: 1}},
+		{"buy x get y without free units", Coupon{Code: "LEVE3", Type: COUPON_TYPE_BUY_X_GET_Y, ProductID: "product-1", BuyQuantity: 2}},
+		{"inverted validity", Coupon{Code: "PROMO10", Type: COUPON_TYPE_PERCENTAGE, Percentage: 10, ValidFrom: &validFrom, ValidUntil: &validUntil}},
+		{"negative max uses", Coupon{Code: "PROMO10", Type: COUPON_TYPE_PERCENTAGE, Percentage: 10, MaxUses: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCoupon(tt.coupon)
+			if _, ok := err.(*exceptions.InvalidCouponDataException); !ok {
+				t.Errorf("NewCoupon() error = %T, want *exceptions.InvalidCouponDataException", err)
+			}
+		})
+	}
+}
+
+func TestCoupon_CheckRedeemable(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	before := now.Add(-time.Hour)
+	after := now.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		coupon   Coupon
+		expected error
+	}{
+		{"within validity", Coupon{Code: "PROMO10", ValidFrom: &before, ValidUntil: &after, MaxUses: 2, UsedCount: 1}, nil},
+		{"not valid yet", Coupon{Code: "PROMO10", ValidFrom: &after}, &exceptions.CouponNotApplicableException{}},
+		{"expired", Coupon{Code: "PROMO10", ValidUntil: &before}, &exceptions.CouponExpiredException{}},
+		{"no uses left", Coupon{Code: "PROMO10", MaxUses: 2, UsedCount: 2}, &exceptions.CouponUsageLimitReachedException{}},
+		{"unlimited uses", Coupon{Code: "PROMO10", UsedCount: 1000}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.coupon.CheckRedeemable(now)
+			if tt.expected == nil {
+				if err != nil {
+					t.Errorf("CheckRedeemable() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || fmt.Sprintf("%T", err) != fmt.Sprintf("%T", tt.expected) {
+				t.Errorf("CheckRedeemable() error = %T, want %T", err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCoupon_CalculateDiscount(t *testing.T) {
+	burger, _ := NewOrderItem("item-1", "product-1", "order-1", 3, brl("10.00"), "", nil)
+	fries, _ := NewOrderItem("item-2", "product-2", "order-1", 1, brl("5.00"), "", nil)
+	order := newTestOrderWithItems(t, burger, fries) // subtotal 35.00
+
+	tests := []struct {
+		name     string
+		coupon   Coupon
+		expected string
+	}{
+		{"percentage", Coupon{Code: "PROMO10", Type: COUPON_TYPE_PERCENTAGE, Percentage: 10}, "3.50"},
+		{"fixed amount", Coupon{Code: "MENOS5", Type: COUPON_TYPE_FIXED_AMOUNT, FixedAmount: brl("5.00")}, "5.00"},
+		{"buy 2 get 1", Coupon{Code: "LEVE3", Type: COUPON_TYPE_BUY_X_GET_Y, ProductID: "product-1", BuyQuantity: 2, FreeQuantity: 1}, "10.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discount, err := tt.coupon.CalculateDiscount(*order)
+			if err != nil {
+				t.Fatalf("CalculateDiscount() unexpected error: %v", err)
+			}
+			if discount.String() != tt.expected {
+				t.Errorf("CalculateDiscount() = %v, want %v", discount, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCoupon_CalculateDiscount_NotApplicable(t *testing.T) {
+	burger, _ := NewOrderItem("item-1", "product-1", "order-1", 1, brl("10.00"), "", nil)
+	order := newTestOrderWithItems(t, burger)
+
+	tests := []struct {
+		name   string
+		coupon Coupon
+	}{
+		{"buy x get y without enough units", Coupon{Code: "LEVE3", Type: COUPON_TYPE_BUY_X_GET_Y, ProductID: "product-1", BuyQuantity: 2, FreeQuantity: 1}},
+		{"buy x get y for another product", Coupon{Code: "LEVE2", Type: COUPON_TYPE_BUY_X_GET_Y, ProductID: "product-2", BuyQuantity: 1, FreeQuantity: 1}},
+		{"fixed amount covering the order", Coupon{Code: "MENOS10", Type: COUPON_TYPE_FIXED_AMOUNT, FixedAmount: brl("10.00")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.coupon.CalculateDiscount(*order)
+			if _, ok := err.(*exceptions.CouponNotApplicableException); !ok {
+				t.Errorf("CalculateDiscount() error = %T, want *exceptions.CouponNotApplicableException", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package entities
+
+import (
+	"time"
+
+	"microservice/internal/domain/value_objects"
+)
+
+// Colunas do painel de retirada, na ordem de exibição. Pedidos finalizados
+// (Entregue, Cancelado, Falhou) não aparecem.
+var ORDER_BOARD_STATUSES = []string{
+	ORDER_STATUS_READY,
+	ORDER_STATUS_PREPARING,
+	ORDER_STATUS_CONFIRMED,
+	ORDER_STATUS_RECEIVED,
+}
+
+type OrderBoardEntry struct {
+	OrderID      string
+	PickupCode   value_objects.PickupCode
+	CustomerName string
+	Status       OrderStatus
+	CreatedAt    time.Time
+}
+
+func (e OrderBoardEntry) WaitTime(now time.Time) time.Duration {
+	if now.Before(e.CreatedAt) {
+		return 0
+	}
+	return now.Sub(e.CreatedAt)
+}
+
+type OrderBoardGroup struct {
+	Status OrderStatus
+	Orders []OrderBoardEntry
+}
+
+type OrderBoard struct {
+	GeneratedAt time.Time
+	Groups      []OrderBoardGroup
+}
+
+// Todo status recebe um grupo, mesmo sem pedidos
+func NewOrderBoard(statuses []OrderStatus, entries []OrderBoardEntry, generatedAt time.Time) OrderBoard {
+	groups := make([]OrderBoardGroup, 0, len(statuses))
+	positions := make(map[string]int, len(statuses))
+	for _, name := range ORDER_BOARD_STATUSES {
+		for _, status := range statuses {
+			if status.Name.Value() == name {
+				positions[status.ID] = len(groups)
+				groups = append(groups, OrderBoardGroup{Status: status, Orders: []OrderBoardEntry{}})
+			}
+		}
+	}
+
+	for _, entry := range entries {
+		if position, ok := positions[entry.Status.ID]; ok {
+			groups[position].Orders = append(groups[position].Orders, entry)
+		}
+	}
+
+	return OrderBoard{GeneratedAt: generatedAt, Groups: groups}
+}
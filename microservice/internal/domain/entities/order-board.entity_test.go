@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewOrderBoard_GroupsByStatusPriority(t *testing.T) {
+	received, _ := NewOrderStatus("status-1", ORDER_STATUS_RECEIVED)
+	preparing, _ := NewOrderStatus("status-3", ORDER_STATUS_PREPARING)
+	ready, _ := NewOrderStatus("status-4", ORDER_STATUS_READY)
+	now := time.Now()
+
+	entries := []OrderBoardEntry{
+		{OrderID: "order-1", Status: *received, CreatedAt: now.Add(-30 * time.Minute)},
+		{OrderID: "order-2", Status: *ready, CreatedAt: now.Add(-20 * time.Minute)},
+		{OrderID: "order-3", Status: *received, CreatedAt: now.Add(-10 * time.Minute)},
+	}
+
+	board := NewOrderBoard([]OrderStatus{*received, *preparing, *ready}, entries, now)
+
+	if len(board.Groups) != 3 {
+		t.Fatalf("NewOrderBoard() groups = %d, want 3", len(board.Groups))
+	}
+	wantStatuses := []string{ORDER_STATUS_READY, ORDER_STATUS_PREPARING, ORDER_STATUS_RECEIVED}
+	for i, want := range wantStatuses {
+		if got := board.Groups[i].Status.Name.Value(); got != want {
+			t.Errorf("NewOrderBoard() group %d = %s, want %s", i, got, want)
+		}
+	}
+	if len(board.Groups[0].Orders) != 1 || board.Groups[0].Orders[0].OrderID != "order-2" {
+		t.Errorf("NewOrderBoard() ready orders = %+v, want order-2", board.Groups[0].Orders)
+	}
+	if board.Groups[1].Orders == nil || len(board.Groups[1].Orders) != 0 {
+		t.Errorf("NewOrderBoard() preparing orders = %+v, want empty", board.Groups[1].Orders)
+	}
+	if len(board.Groups[2].Orders) != 2 || board.Groups[2].Orders[0].OrderID != "order-1" || board.Groups[2].Orders[1].OrderID != "order-3" {
+		t.Errorf("NewOrderBoard() received orders = %+v, want order-1, order-3", board.Groups[2].Orders)
+	}
+	if !board.GeneratedAt.Equal(now) {
+		t.Errorf("NewOrderBoard() GeneratedAt = %v, want %v", board.GeneratedAt, now)
+	}
+}
+
+func TestNewOrderBoard_IgnoresStatusesOffTheBoard(t *testing.T) {
+	delivered, _ := NewOrderStatus("status-5", ORDER_STATUS_DELIVERED)
+	entries := []OrderBoardEntry{{OrderID: "order-1", Status: *delivered}}
+
+	board := NewOrderBoard([]OrderStatus{*delivered}, entries, time.Now())
+
+	if len(board.Groups) != 0 {
+		t.Errorf("NewOrderBoard() groups = %+v, want none", board.Groups)
+	}
+}
+
+func TestOrderBoardEntry_WaitTime(t *testing.T) {
+	now := time.Now()
+	entry := OrderBoardEntry{CreatedAt: now.Add(-5 * time.Minute)}
+
+	if got := entry.WaitTime(now); got != 5*time.Minute {
+		t.Errorf("WaitTime() = %v, want 5m", got)
+	}
+	// Relógios fora de sincronia não geram espera negativa
+	if got := entry.WaitTime(now.Add(-10 * time.Minute)); got != 0 {
+		t.Errorf("WaitTime() = %v, want 0", got)
+	}
+}
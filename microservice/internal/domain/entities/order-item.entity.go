@@ -1,6 +1,18 @@
 package entities
 
-import "microservice/internal/domain/value_objects"
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"microservice/internal/domain/exceptions"
+	"microservice/internal/domain/value_objects"
+)
+
+const (
+	MAX_ORDER_ITEM_NOTES_LENGTH = 200
+	MAX_ORDER_ITEM_MODIFIERS    = 10
+)
 
 type OrderItem struct {
 	ID        string
@@ -8,9 +20,33 @@ type OrderItem struct {
 	ProductID value_objects.ProductID
 	Quantity  value_objects.Quantity
 	UnitPrice value_objects.UnitPrice
+	Product   ProductSnapshot
+
+	// Observação do cliente para a cozinha (ex: "sem cebola")
+	Notes     string
+	Modifiers []OrderItemModifier
+
+	// Itens que compõem um combo; só o preço do combo é cobrado
+	Components []OrderItem
+}
+
+// Dados do produto no momento do pedido, independentes do catálogo
+type ProductSnapshot struct {
+	Name     string
+	Category string
+	ImageURL string
+	Notes    string
+}
+
+// Adicional escolhido para o item; PriceDelta pode ser zero
+type OrderItemModifier struct {
+	ID         string
+	ModifierID string
+	Name       string
+	PriceDelta value_objects.Money
 }
 
-func NewOrderItem(id string, productID string, orderID string, quantity int, unitPrice float64) (*OrderItem, error) {
+func NewOrderItem(id string, productID string, orderID string, quantity int, unitPrice value_objects.Money, notes string, modifiers []OrderItemModifier) (*OrderItem, error) {
 	productIDValueObject, err := value_objects.NewProductID(productID)
 	if err != nil {
 		return nil, err
@@ -26,15 +62,99 @@ func NewOrderItem(id string, productID string, orderID string, quantity int, uni
 		return nil, err
 	}
 
+	notes = strings.TrimSpace(notes)
+	if utf8.RuneCountInString(notes) > MAX_ORDER_ITEM_NOTES_LENGTH {
+		return nil, &exceptions.InvalidOrderItemData{
+			Message: fmt.Sprintf("Item notes must have at most %d characters", MAX_ORDER_ITEM_NOTES_LENGTH),
+		}
+	}
+
+	if err := validateModifiers(modifiers, unitPrice.Currency()); err != nil {
+		return nil, err
+	}
+
 	return &OrderItem{
 		ID:        id,
 		OrderID:   orderID,
 		ProductID: productIDValueObject,
 		Quantity:  quantityValueObject,
 		UnitPrice: unitPriceValueObject,
+		Notes:     notes,
+		Modifiers: modifiers,
 	}, nil
 }
 
-func (oi *OrderItem) GetTotal() float64 {
-	return oi.UnitPrice.Value() * float64(oi.Quantity.Value())
+func validateModifiers(modifiers []OrderItemModifier, currency string) error {
+	if len(modifiers) > MAX_ORDER_ITEM_MODIFIERS {
+		return &exceptions.InvalidOrderItemData{
+			Message: fmt.Sprintf("An item can have at most %d modifiers", MAX_ORDER_ITEM_MODIFIERS),
+		}
+	}
+
+	seen := make(map[string]bool, len(modifiers))
+	for _, modifier := range modifiers {
+		if modifier.ModifierID == "" || strings.TrimSpace(modifier.Name) == "" {
+			return &exceptions.InvalidOrderItemData{
+				Message: "Modifier ID and name are required",
+			}
+		}
+		if seen[modifier.ModifierID] {
+			return &exceptions.InvalidOrderItemData{
+				Message: fmt.Sprintf("Modifier %s is repeated", modifier.ModifierID),
+			}
+		}
+		seen[modifier.ModifierID] = true
+
+		if modifier.PriceDelta.IsNegative() {
+			return &exceptions.InvalidOrderItemData{
+				Message: fmt.Sprintf("Modifier %s price cannot be negative", modifier.ModifierID),
+			}
+		}
+		if modifier.PriceDelta.Currency() != currency {
+			return &exceptions.InvalidOrderItemData{
+				Message: fmt.Sprintf("Modifier %s is priced in %s, not %s", modifier.ModifierID, modifier.PriceDelta.Currency(), currency),
+			}
+		}
+	}
+
+	return nil
+}
+
+// Componentes não podem ser combos e usam a moeda do pedido
+func (oi *OrderItem) AddComponent(component OrderItem) error {
+	if component.OrderID != oi.OrderID {
+		return &exceptions.InvalidOrderItemData{
+			Message: fmt.Sprintf("Combo component %s belongs to another order", component.ID),
+		}
+	}
+	if component.IsCombo() {
+		return &exceptions.InvalidOrderItemData{
+			Message: fmt.Sprintf("Combo component %s cannot be a combo", component.ID),
+		}
+	}
+	if component.UnitPrice.Value().Currency() != oi.UnitPrice.Value().Currency() {
+		return &exceptions.InvalidOrderItemData{
+			Message: fmt.Sprintf("Combo component %s is priced in %s, not %s", component.ID, component.UnitPrice.Value().Currency(), oi.UnitPrice.Value().Currency()),
+		}
+	}
+
+	oi.Components = append(oi.Components, component)
+	return nil
+}
+
+func (oi *OrderItem) IsCombo() bool {
+	return len(oi.Components) > 0
+}
+
+// (preço unitário + adicionais) * quantidade; em combos só o preço do combo conta
+func (oi *OrderItem) GetTotal() (value_objects.Money, error) {
+	unitTotal := oi.UnitPrice.Value()
+	for _, modifier := range oi.Modifiers {
+		var err error
+		unitTotal, err = unitTotal.Add(modifier.PriceDelta)
+		if err != nil {
+			return value_objects.Money{}, err
+		}
+	}
+	return unitTotal.Multiply(oi.Quantity.Value()), nil
 }
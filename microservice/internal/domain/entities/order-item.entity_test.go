@@ -1,11 +1,20 @@
 package entities
 
 import (
+	"strings"
 	"testing"
+
+	"microservice/internal/domain/exceptions"
+	"microservice/internal/domain/value_objects"
 )
 
+func brl(value string) value_objects.Money {
+	money, _ := value_objects.ParseMoney(value, value_objects.DEFAULT_CURRENCY)
+	return money
+}
+
 func TestNewOrderItem_ValidItem(t *testing.T) {
-	item, err := NewOrderItem("item-1", "product-123", "order-123", 2, 25.50)
+	item, err := NewOrderItem("item-1", "product-123", "order-123", 2, brl("25.50"), "", nil)
 
 	if err != nil {
 		t.Errorf("NewOrderItem() unexpected error: %v", err)
@@ -25,13 +34,13 @@ func TestNewOrderItem_ValidItem(t *testing.T) {
 	if item.Quantity.Value() != 2 {
 		t.Errorf("NewOrderItem() Quantity = %v, want 2", item.Quantity.Value())
 	}
-	if item.UnitPrice.Value() != 25.50 {
+	if item.UnitPrice.Value().String() != "25.50" {
 		t.Errorf("NewOrderItem() UnitPrice = %v, want 25.50", item.UnitPrice.Value())
 	}
 }
 
 func TestNewOrderItem_InvalidProductID(t *testing.T) {
-	_, err := NewOrderItem("item-1", "", "order-123", 2, 25.50)
+	_, err := NewOrderItem("item-1", "", "order-123", 2, brl("25.50"), "", nil)
 
 	if err == nil {
 		t.Error("NewOrderItem() with empty productID expected error, got nil")
@@ -49,7 +58,7 @@ func TestNewOrderItem_InvalidQuantity(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := NewOrderItem("item-1", "product-123", "order-123", tt.quantity, 25.50)
+			_, err := NewOrderItem("item-1", "product-123", "order-123", tt.quantity, brl("25.50"), "", nil)
 			if err == nil {
 				t.Errorf("NewOrderItem() with quantity %d expected error, got nil", tt.quantity)
 			}
@@ -60,15 +69,15 @@ func TestNewOrderItem_InvalidQuantity(t *testing.T) {
 func TestNewOrderItem_InvalidUnitPrice(t *testing.T) {
 	tests := []struct {
 		name      string
-		unitPrice float64
+		unitPrice string
 	}{
-		{"zero price", 0.0},
-		{"negative price", -10.0},
+		{"zero price", "0"},
+		{"negative price", "-10.00"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := NewOrderItem("item-1", "product-123", "order-123", 2, tt.unitPrice)
+			_, err := NewOrderItem("item-1", "product-123", "order-123", 2, brl(tt.unitPrice), "", nil)
 			if err == nil {
 				t.Errorf("NewOrderItem() with unitPrice %v expected error, got nil", tt.unitPrice)
 			}
@@ -80,26 +89,137 @@ func TestOrderItem_GetTotal(t *testing.T) {
 	tests := []struct {
 		name      string
 		quantity  int
-		unitPrice float64
-		expected  float64
+		unitPrice string
+		expected  string
 	}{
-		{"single item", 1, 10.0, 10.0},
-		{"multiple items", 3, 15.50, 46.50},
-		{"large quantity", 100, 5.0, 500.0},
-		{"decimal price", 2, 19.99, 39.98},
+		{"single item", 1, "10.00", "10.00"},
+		{"multiple items", 3, "15.50", "46.50"},
+		{"large quantity", 100, "5.00", "500.00"},
+		{"decimal price", 3, "19.99", "59.97"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			item, err := NewOrderItem("item-1", "product-123", "order-123", tt.quantity, tt.unitPrice)
+			item, err := NewOrderItem("item-1", "product-123", "order-123", tt.quantity, brl(tt.unitPrice), "", nil)
 			if err != nil {
 				t.Fatalf("NewOrderItem() unexpected error: %v", err)
 			}
 
-			total := item.GetTotal()
-			if total != tt.expected {
+			total, err := item.GetTotal()
+			if err != nil {
+				t.Fatalf("GetTotal() unexpected error: %v", err)
+			}
+			if total.String() != tt.expected {
 				t.Errorf("GetTotal() = %v, want %v", total, tt.expected)
 			}
 		})
 	}
 }
+
+func TestNewOrderItem_WithNotesAndModifiers(t *testing.T) {
+	modifiers := []OrderItemModifier{
+		{ID: "modifier-1", ModifierID: "queijo-extra", Name: "Queijo extra", PriceDelta: brl("3.00")},
+		{ID: "modifier-2", ModifierID: "sem-cebola", Name: "Sem cebola", PriceDelta: brl("0")},
+	}
+
+	item, err := NewOrderItem("item-1", "product-123", "order-123", 2, brl("25.50"), "  bem passado  ", modifiers)
+
+	if err != nil {
+		t.Fatalf("NewOrderItem() unexpected error: %v", err)
+	}
+	if item.Notes != "bem passado" {
+		t.Errorf("NewOrderItem() Notes = %q, want trimmed notes", item.Notes)
+	}
+	if len(item.Modifiers) != 2 {
+		t.Errorf("NewOrderItem() Modifiers length = %v, want 2", len(item.Modifiers))
+	}
+
+	total, err := item.GetTotal()
+	if err != nil {
+		t.Fatalf("GetTotal() unexpected error: %v", err)
+	}
+	if total.String() != "57.00" {
+		t.Errorf("GetTotal() = %v, want 57.00 with modifiers", total)
+	}
+}
+
+func TestNewOrderItem_InvalidNotesAndModifiers(t *testing.T) {
+	usd, _ := value_objects.ParseMoney("1.00", "USD")
+	tooManyModifiers := make([]OrderItemModifier, MAX_ORDER_ITEM_MODIFIERS+1)
+	for i := range tooManyModifiers {
+		tooManyModifiers[i] = OrderItemModifier{ModifierID: string(rune('a' + i)), Name: "Adicional", PriceDelta: brl("1.00")}
+	}
+
+	tests := []struct {
+		name      string
+		notes     string
+		modifiers []OrderItemModifier
+	}{
+		{"notes too long", strings.Repeat("a", MAX_ORDER_ITEM_NOTES_LENGTH+1), nil},
+		{"too many modifiers", "", tooManyModifiers},
+		{"modifier without name", "", []OrderItemModifier{{ModifierID: "queijo-extra", PriceDelta: brl("3.00")}}},
+		{"repeated modifier", "", []OrderItemModifier{
+			{ModifierID: "queijo-extra", Name: "Queijo extra", PriceDelta: brl("3.00")},
+			{ModifierID: "queijo-extra", Name: "Queijo extra", PriceDelta: brl("3.00")},
+		}},
+		{"negative price delta", "", []OrderItemModifier{{ModifierID: "sem-pao", Name: "Sem pão", PriceDelta: brl("-2.00")}}},
+		{"different currency", "", []OrderItemModifier{{ModifierID: "queijo-extra", Name: "Queijo extra", PriceDelta: usd}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewOrderItem("item-1", "product-123", "order-123", 1, brl("25.50"), tt.notes, tt.modifiers)
+			if _, ok := err.(*exceptions.InvalidOrderItemData); !ok {
+				t.Errorf("NewOrderItem() error = %v, want InvalidOrderItemData", err)
+			}
+		})
+	}
+}
+
+func TestOrderItem_AddComponent(t *testing.T) {
+	combo, _ := NewOrderItem("item-1", "combo-1", "order-123", 2, brl("39.90"), "", nil)
+	fries, _ := NewOrderItem("item-2", "fries-1", "order-123", 2, brl("12.90"), "", nil)
+
+	if err := combo.AddComponent(*fries); err != nil {
+		t.Fatalf("AddComponent() unexpected error: %v", err)
+	}
+	if !combo.IsCombo() || len(combo.Components) != 1 {
+		t.Errorf("AddComponent() Components = %+v, want fries", combo.Components)
+	}
+
+	total, _ := combo.GetTotal()
+	if total.String() != "79.80" {
+		t.Errorf("GetTotal() = %v, want 79.80 (combo price only)", total)
+	}
+}
+
+func TestOrderItem_AddComponent_Invalid(t *testing.T) {
+	usd, _ := value_objects.ParseMoney("12.90", "USD")
+	otherOrder, _ := NewOrderItem("item-2", "fries-1", "order-456", 1, brl("12.90"), "", nil)
+	otherCurrency, _ := NewOrderItem("item-2", "fries-1", "order-123", 1, usd, "", nil)
+	nestedCombo, _ := NewOrderItem("item-2", "combo-2", "order-123", 1, brl("29.90"), "", nil)
+	drink, _ := NewOrderItem("item-3", "drink-1", "order-123", 1, brl("7.90"), "", nil)
+	_ = nestedCombo.AddComponent(*drink)
+
+	tests := []struct {
+		name      string
+		component *OrderItem
+	}{
+		{"component of another order", otherOrder},
+		{"component in another currency", otherCurrency},
+		{"combo inside combo", nestedCombo},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			combo, _ := NewOrderItem("item-1", "combo-1", "order-123", 1, brl("39.90"), "", nil)
+			err := combo.AddComponent(*tt.component)
+			if _, ok := err.(*exceptions.InvalidOrderItemData); !ok {
+				t.Errorf("AddComponent() error = %v, want InvalidOrderItemData", err)
+			}
+			if combo.IsCombo() {
+				t.Error("AddComponent() should not add an invalid component")
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"time"
+
+	"microservice/internal/domain/exceptions"
+)
+
+// Origem de uma mudança de status
+const (
+	STATUS_CHANGE_SOURCE_REST    = "rest"
+	STATUS_CHANGE_SOURCE_KITCHEN = "kitchen_consumer"
+	STATUS_CHANGE_SOURCE_PAYMENT = "payment_consumer"
+)
+
+type OrderStatusChange struct {
+	ID             string
+	OrderID        string
+	PreviousStatus *OrderStatus
+	NewStatus      OrderStatus
+	Source         string
+	Actor          *string
+	ChangedAt      time.Time
+}
+
+func NewOrderStatusChange(id string, orderID string, previousStatus *OrderStatus, newStatus OrderStatus, source string, actor *string, changedAt time.Time) (*OrderStatusChange, error) {
+	if !isValidStatusChangeSource(source) {
+		return nil, &exceptions.InvalidOrderDataException{
+			Message: "Invalid status change source",
+		}
+	}
+
+	return &OrderStatusChange{
+		ID:             id,
+		OrderID:        orderID,
+		PreviousStatus: previousStatus,
+		NewStatus:      newStatus,
+		Source:         source,
+		Actor:          actor,
+		ChangedAt:      changedAt,
+	}, nil
+}
+
+func isValidStatusChangeSource(source string) bool {
+	switch source {
+	case STATUS_CHANGE_SOURCE_REST, STATUS_CHANGE_SOURCE_KITCHEN, STATUS_CHANGE_SOURCE_PAYMENT:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"microservice/internal/domain/exceptions"
+)
+
+func TestNewOrderStatusChange_Valid(t *testing.T) {
+	previous, _ := NewOrderStatus("status-1", ORDER_STATUS_READY)
+	next, _ := NewOrderStatus("status-2", ORDER_STATUS_DELIVERED)
+	actor := "staff-1"
+	now := time.Now()
+
+	change, err := NewOrderStatusChange("change-1", "order-1", previous, *next, STATUS_CHANGE_SOURCE_REST, &actor, now)
+
+	if err != nil {
+		t.Fatalf("NewOrderStatusChange() unexpected error: %v", err)
+	}
+	if change.PreviousStatus.ID != "status-1" {
+		t.Errorf("NewOrderStatusChange() PreviousStatus.ID = %v, want status-1", change.PreviousStatus.ID)
+	}
+	if change.NewStatus.ID != "status-2" {
+		t.Errorf("NewOrderStatusChange() NewStatus.ID = %v, want status-2", change.NewStatus.ID)
+	}
+	if *change.Actor != "staff-1" {
+		t.Errorf("NewOrderStatusChange() Actor = %v, want staff-1", *change.Actor)
+	}
+	if !change.ChangedAt.Equal(now) {
+		t.Errorf("NewOrderStatusChange() ChangedAt = %v, want %v", change.ChangedAt, now)
+	}
+}
+
+func TestNewOrderStatusChange_InvalidSource(t *testing.T) {
+	next, _ := NewOrderStatus("status-1", ORDER_STATUS_RECEIVED)
+
+	_, err := NewOrderStatusChange("change-1", "order-1", nil, *next, "unknown", nil, time.Now())
+
+	if _, ok := err.(*exceptions.InvalidOrderDataException); !ok {
+		t.Errorf("NewOrderStatusChange() error = %T, want *exceptions.InvalidOrderDataException", err)
+	}
+}
@@ -2,6 +2,24 @@ package entities
 
 import "microservice/internal/domain/value_objects"
 
+const (
+	ORDER_STATUS_RECEIVED  = "Recebido"
+	ORDER_STATUS_CONFIRMED = "Confirmado"
+	ORDER_STATUS_PREPARING = "Em preparação"
+	ORDER_STATUS_READY     = "Pronto"
+	ORDER_STATUS_DELIVERED = "Entregue"
+	ORDER_STATUS_CANCELLED = "Cancelado"
+	ORDER_STATUS_FAILED    = "Falhou"
+)
+
+// Transições permitidas a partir de cada status. Entregue, Cancelado e Falhou são finais.
+var orderStatusTransitions = map[string][]string{
+	ORDER_STATUS_RECEIVED:  {ORDER_STATUS_CONFIRMED, ORDER_STATUS_CANCELLED, ORDER_STATUS_FAILED},
+	ORDER_STATUS_CONFIRMED: {ORDER_STATUS_PREPARING, ORDER_STATUS_CANCELLED},
+	ORDER_STATUS_PREPARING: {ORDER_STATUS_READY, ORDER_STATUS_CANCELLED},
+	ORDER_STATUS_READY:     {ORDER_STATUS_DELIVERED},
+}
+
 type OrderStatus struct {
 	ID   string
 	Name value_objects.Name
@@ -18,3 +36,25 @@ func NewOrderStatus(id string, name string) (*OrderStatus, error) {
 		Name: nameValueObject,
 	}, nil
 }
+
+// Permanecer no mesmo status é sempre permitido
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	if s.ID == next.ID {
+		return true
+	}
+	return CanTransitionOrderStatus(s.Name.Value(), next.Name.Value())
+}
+
+func (s OrderStatus) IsFinal() bool {
+	_, hasTransitions := orderStatusTransitions[s.Name.Value()]
+	return !hasTransitions
+}
+
+func CanTransitionOrderStatus(from string, to string) bool {
+	for _, allowed := range orderStatusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
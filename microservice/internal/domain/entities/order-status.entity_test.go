@@ -53,3 +53,68 @@ func TestNewOrderStatus_InvalidName(t *testing.T) {
 		})
 	}
 }
+
+func TestOrderStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from     string
+		to       string
+		expected bool
+	}{
+		{"Recebido", "Confirmado", true},
+		{"Recebido", "Cancelado", true},
+		{"Recebido", "Falhou", true},
+		{"Recebido", "Em preparação", false},
+		{"Confirmado", "Em preparação", true},
+		{"Confirmado", "Cancelado", true},
+		{"Confirmado", "Recebido", false},
+		{"Em preparação", "Pronto", true},
+		{"Em preparação", "Cancelado", true},
+		{"Pronto", "Entregue", true},
+		{"Pronto", "Cancelado", false},
+		{"Entregue", "Pronto", false},
+		{"Cancelado", "Confirmado", false},
+		{"Falhou", "Confirmado", false},
+		{"Desconhecido", "Confirmado", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+"_to_"+tt.to, func(t *testing.T) {
+			from, _ := NewOrderStatus("from", tt.from)
+			to, _ := NewOrderStatus("to", tt.to)
+
+			if got := from.CanTransitionTo(*to); got != tt.expected {
+				t.Errorf("CanTransitionTo() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOrderStatus_CanTransitionTo_SameStatus(t *testing.T) {
+	status, _ := NewOrderStatus("status-1", ORDER_STATUS_DELIVERED)
+
+	if !status.CanTransitionTo(*status) {
+		t.Error("CanTransitionTo() should allow staying in the same status")
+	}
+}
+
+func TestOrderStatus_IsFinal(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{ORDER_STATUS_RECEIVED, false},
+		{ORDER_STATUS_READY, false},
+		{ORDER_STATUS_DELIVERED, true},
+		{ORDER_STATUS_CANCELLED, true},
+		{ORDER_STATUS_FAILED, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, _ := NewOrderStatus("status-1", tt.name)
+			if got := status.IsFinal(); got != tt.expected {
+				t.Errorf("IsFinal() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
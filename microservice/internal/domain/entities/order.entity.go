@@ -1,21 +1,61 @@
 package entities
 
 import (
+	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"microservice/internal/domain/exceptions"
 	"microservice/internal/domain/value_objects"
 	identityUtils "microservice/utils/identity"
 )
 
+const (
+	MAX_CANCELLATION_REASON_LENGTH = 255
+	MAX_CUSTOMER_NAME_LENGTH       = 50
+)
+
 type Order struct {
 	ID         string
 	CustomerID *string
+	// Nome chamado na retirada; opcional
+	CustomerName string
+	// Total a pagar: Subtotal - Discount
 	Amount     value_objects.Amount
+	Subtotal   value_objects.Money
+	Discount   value_objects.Money
+	CouponCode *string
 	Status     OrderStatus
 	Items      []OrderItem
 	CreatedAt  time.Time
 	UpdatedAt  *time.Time
+
+	// Código mostrado na tela de retirada; só é único dentro de PickupDate
+	PickupCode value_objects.PickupCode
+	PickupDate string
+
+	// Hash do token do convidado; nulo quando o pedido tem cliente
+	// O token em si só existe em GuestToken logo após IssueGuestToken
+	GuestTokenHash *string
+	GuestToken     string
+
+	// Preenchidos quando o pedido é cancelado
+	CancellationReason *string
+	CancelledAt        *time.Time
+
+	// Mudanças de status feitas desde que o pedido foi carregado, gravadas junto com ele
+	StatusChanges []OrderStatusChange
+
+	// Versão lida do banco; a atualização falha se outra escrita a alterou
+	Version int
+}
+
+const PICKUP_DATE_LAYOUT = "2006-01-02"
+
+// Dia em que os códigos de retirada são contados, no fuso do restaurante
+func PickupDay(t time.Time, location *time.Location) string {
+	return t.In(location).Format(PICKUP_DATE_LAYOUT)
 }
 
 func NewOrder(id string, customerID *string) (*Order, error) {
@@ -28,7 +68,7 @@ func NewOrder(id string, customerID *string) (*Order, error) {
 	}, nil
 }
 
-func NewOrderWithItems(id string, customerID *string, amount float64, status OrderStatus, items []OrderItem, createdAt time.Time, updatedAt *time.Time) (*Order, error) {
+func NewOrderWithItems(id string, customerID *string, amount value_objects.Money, status OrderStatus, items []OrderItem, createdAt time.Time, updatedAt *time.Time) (*Order, error) {
 	order, _ := NewOrder(id, customerID)
 	order.Items = items
 	order.Status = status
@@ -39,23 +79,236 @@ func NewOrderWithItems(id string, customerID *string, amount float64, status Ord
 	if err != nil {
 		return nil, err
 	}
+	// Sem desconto até que SetDiscount seja chamado
+	order.Subtotal = amount
+	order.Discount, _ = value_objects.NewMoney(0, amount.Currency())
 	return order, nil
 }
 
+// Restaura subtotal e desconto de um pedido gravado; o total deve ser subtotal - desconto
+func (o *Order) SetDiscount(subtotal value_objects.Money, discount value_objects.Money, couponCode *string) error {
+	total, err := subtotal.Subtract(discount)
+	if err != nil {
+		return err
+	}
+	if discount.IsNegative() || total != o.Amount.Value() {
+		return &exceptions.AmountNotValidException{
+			Message: fmt.Sprintf("Order total %s does not match subtotal %s minus discount %s", o.Amount.Value(), subtotal, discount),
+		}
+	}
+
+	o.Subtotal = subtotal
+	o.Discount = discount
+	o.CouponCode = couponCode
+	return nil
+}
+
 func (o *Order) AddItem(item OrderItem) {
 	o.Items = append(o.Items, item)
 }
 
+// Combos contam pelo preço do combo
 func (o *Order) CalcTotalAmount() error {
-	total := 0.0
-	for _, item := range o.Items {
-		total += item.GetTotal()
+	var total value_objects.Money
+	for i, item := range o.Items {
+		itemTotal, err := item.GetTotal()
+		if err != nil {
+			return err
+		}
+
+		if i == 0 {
+			total = itemTotal
+			continue
+		}
+
+		total, err = total.Add(itemTotal)
+		if err != nil {
+			return err
+		}
 	}
 	amount, err := value_objects.NewAmount(total)
 	if err != nil {
 		return err
 	}
 	o.Amount = amount
+	o.Subtotal = total
+	o.Discount, _ = value_objects.NewMoney(0, total.Currency())
+	o.CouponCode = nil
+	return nil
+}
+
+// Só pedidos ainda não pagos aceitam cupom, e apenas um
+func (o *Order) ApplyCoupon(coupon Coupon, now time.Time, isFirstOrder bool) error {
+	if o.Status.Name.Value() != ORDER_STATUS_RECEIVED {
+		return &exceptions.CouponNotApplicableException{
+			Message: fmt.Sprintf("Coupons can only be applied to orders in status %s", ORDER_STATUS_RECEIVED),
+		}
+	}
+	if o.CouponCode != nil {
+		return &exceptions.CouponNotApplicableException{
+			Message: fmt.Sprintf("Order already has coupon %s", *o.CouponCode),
+		}
+	}
+
+	if err := coupon.CheckRedeemable(now); err != nil {
+		return err
+	}
+	if coupon.FirstOrderOnly && !isFirstOrder {
+		return &exceptions.CouponNotApplicableException{
+			Message: fmt.Sprintf("Coupon %s is only valid for the first order", coupon.Code),
+		}
+	}
+
+	discount, err := coupon.CalculateDiscount(*o)
+	if err != nil {
+		return err
+	}
+
+	total, err := o.Subtotal.Subtract(discount)
+	if err != nil {
+		return err
+	}
+	amount, err := value_objects.NewAmount(total)
+	if err != nil {
+		return err
+	}
+
+	code := coupon.Code
+	o.Amount = amount
+	o.Discount = discount
+	o.CouponCode = &code
+	o.UpdatedAt = &now
+	return nil
+}
+
+func (o *Order) SetInitialStatus(status OrderStatus, source string, actor *string) error {
+	if err := o.recordStatusChange(nil, status, source, actor); err != nil {
+		return err
+	}
+
+	o.Status = status
+	return nil
+}
+
+// Cancelamentos passam por Cancel, que registra o motivo
+func (o *Order) ChangeStatus(status OrderStatus, source string, actor *string) error {
+	if status.Name.Value() == ORDER_STATUS_CANCELLED && o.Status.ID != status.ID {
+		return &exceptions.InvalidStatusTransitionException{
+			Message: "Orders can only be cancelled through the cancel operation, which requires a reason",
+		}
+	}
+	return o.changeStatus(status, source, actor)
+}
+
+func (o *Order) changeStatus(status OrderStatus, source string, actor *string) error {
+	if !o.Status.CanTransitionTo(status) {
+		return &exceptions.InvalidStatusTransitionException{
+			Message: fmt.Sprintf("Order cannot change status from %s to %s", o.Status.Name.Value(), status.Name.Value()),
+		}
+	}
+
+	if o.Status.ID == status.ID {
+		return nil
+	}
+
+	previousStatus := o.Status
+	if err := o.recordStatusChange(&previousStatus, status, source, actor); err != nil {
+		return err
+	}
+
+	o.Status = status
+	o.UpdatedAt = &o.StatusChanges[len(o.StatusChanges)-1].ChangedAt
+	return nil
+}
+
+func (o *Order) SetCustomerName(name string) error {
+	name = strings.TrimSpace(name)
+	if utf8.RuneCountInString(name) > MAX_CUSTOMER_NAME_LENGTH {
+		return &exceptions.InvalidOrderDataException{
+			Message: fmt.Sprintf("Customer name must have at most %d characters", MAX_CUSTOMER_NAME_LENGTH),
+		}
+	}
+	o.CustomerName = name
+	return nil
+}
+
+func (o *Order) Cancel(cancelled OrderStatus, reason string, source string, actor *string, now time.Time) error {
+	if cancelled.Name.Value() != ORDER_STATUS_CANCELLED {
+		return &exceptions.InvalidOrderDataException{
+			Message: fmt.Sprintf("Cannot cancel an order using status %s", cancelled.Name.Value()),
+		}
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return &exceptions.InvalidOrderDataException{Message: "Cancellation reason is required"}
+	}
+	if utf8.RuneCountInString(reason) > MAX_CANCELLATION_REASON_LENGTH {
+		return &exceptions.InvalidOrderDataException{
+			Message: fmt.Sprintf("Cancellation reason must have at most %d characters", MAX_CANCELLATION_REASON_LENGTH),
+		}
+	}
+
+	if o.Status.Name.Value() == ORDER_STATUS_CANCELLED {
+		return &exceptions.InvalidStatusTransitionException{Message: "Order is already cancelled"}
+	}
+	if err := o.changeStatus(cancelled, source, actor); err != nil {
+		return err
+	}
+
+	o.CancellationReason = &reason
+	o.CancelledAt = &now
+	o.UpdatedAt = &now
+	return nil
+}
+
+// Pedidos com cliente não recebem token
+func (o *Order) IssueGuestToken() error {
+	if o.CustomerID != nil {
+		return nil
+	}
+
+	token, err := value_objects.NewGuestToken()
+	if err != nil {
+		return err
+	}
+	hash := token.Hash()
+	o.GuestToken = token.Value()
+	o.GuestTokenHash = &hash
+	return nil
+}
+
+func (o *Order) HasGuestToken(token string) bool {
+	return o.GuestTokenHash != nil && value_objects.MatchesGuestTokenHash(token, *o.GuestTokenHash)
+}
+
+func (o *Order) IsOwnedBy(customerID string) bool {
+	return o.CustomerID != nil && *o.CustomerID == customerID
+}
+
+// O token de convidado deixa de valer depois da reivindicação
+func (o *Order) Claim(customerID string, now time.Time) error {
+	if strings.TrimSpace(customerID) == "" {
+		return &exceptions.InvalidOrderDataException{Message: "Customer ID is required to claim an order"}
+	}
+	if o.CustomerID != nil {
+		return &exceptions.OrderAlreadyClaimedException{}
+	}
+
+	o.CustomerID = &customerID
+	o.GuestTokenHash = nil
+	o.GuestToken = ""
+	o.UpdatedAt = &now
+	return nil
+}
+
+func (o *Order) recordStatusChange(previousStatus *OrderStatus, status OrderStatus, source string, actor *string) error {
+	change, err := NewOrderStatusChange(identityUtils.NewUUIDV4(), o.ID, previousStatus, status, source, actor, time.Now())
+	if err != nil {
+		return err
+	}
+
+	o.StatusChanges = append(o.StatusChanges, *change)
 	return nil
 }
 
@@ -1,8 +1,12 @@
 package entities
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
+
+	"microservice/internal/domain/exceptions"
 )
 
 func TestNewOrder_ValidOrder(t *testing.T) {
@@ -41,7 +45,7 @@ func TestOrder_AddItem(t *testing.T) {
 	customerID := "customer-123"
 	order, _ := NewOrder("550e8400-e29b-41d4-a716-446655440000", &customerID)
 
-	item, _ := NewOrderItem("item-1", "product-1", order.ID, 2, 10.0)
+	item, _ := NewOrderItem("item-1", "product-1", order.ID, 2, brl("10.00"), "", nil)
 	order.AddItem(*item)
 
 	if len(order.Items) != 1 {
@@ -53,8 +57,8 @@ func TestOrder_CalcTotalAmount(t *testing.T) {
 	customerID := "customer-123"
 	order, _ := NewOrder("550e8400-e29b-41d4-a716-446655440000", &customerID)
 
-	item1, _ := NewOrderItem("item-1", "product-1", order.ID, 2, 10.0)
-	item2, _ := NewOrderItem("item-2", "product-2", order.ID, 3, 15.0)
+	item1, _ := NewOrderItem("item-1", "product-1", order.ID, 2, brl("10.00"), "", nil)
+	item2, _ := NewOrderItem("item-2", "product-2", order.ID, 3, brl("15.00"), "", nil)
 
 	order.AddItem(*item1)
 	order.AddItem(*item2)
@@ -64,8 +68,53 @@ func TestOrder_CalcTotalAmount(t *testing.T) {
 		t.Errorf("CalcTotalAmount() unexpected error: %v", err)
 	}
 
-	expectedTotal := (2 * 10.0) + (3 * 15.0) // 20 + 45 = 65
-	if order.Amount.Value() != expectedTotal {
+	expectedTotal := "65.00" // 2 * 10.00 + 3 * 15.00
+	if order.Amount.Value().String() != expectedTotal {
+		t.Errorf("CalcTotalAmount() Amount = %v, want %v", order.Amount.Value(), expectedTotal)
+	}
+}
+
+func TestOrder_CalcTotalAmount_WithModifiers(t *testing.T) {
+	order, _ := NewOrder("550e8400-e29b-41d4-a716-446655440000", nil)
+
+	item1, _ := NewOrderItem("item-1", "product-1", order.ID, 2, brl("10.00"), "", []OrderItemModifier{
+		{ID: "modifier-1", ModifierID: "queijo-extra", Name: "Queijo extra", PriceDelta: brl("3.00")},
+		{ID: "modifier-2", ModifierID: "bacon-extra", Name: "Bacon extra", PriceDelta: brl("4.50")},
+	})
+	item2, _ := NewOrderItem("item-2", "product-2", order.ID, 1, brl("15.00"), "", nil)
+
+	order.AddItem(*item1)
+	order.AddItem(*item2)
+
+	if err := order.CalcTotalAmount(); err != nil {
+		t.Fatalf("CalcTotalAmount() unexpected error: %v", err)
+	}
+
+	expectedTotal := "50.00" // 2 * (10.00 + 3.00 + 4.50) + 15.00
+	if order.Amount.Value().String() != expectedTotal {
+		t.Errorf("CalcTotalAmount() Amount = %v, want %v", order.Amount.Value(), expectedTotal)
+	}
+}
+
+func TestOrder_CalcTotalAmount_WithCombo(t *testing.T) {
+	order, _ := NewOrder("550e8400-e29b-41d4-a716-446655440000", nil)
+
+	combo, _ := NewOrderItem("item-1", "combo-1", order.ID, 1, brl("39.90"), "", nil)
+	burger, _ := NewOrderItem("item-2", "burger-1", order.ID, 1, brl("25.90"), "", nil)
+	fries, _ := NewOrderItem("item-3", "fries-1", order.ID, 1, brl("12.90"), "", nil)
+	_ = combo.AddComponent(*burger)
+	_ = combo.AddComponent(*fries)
+	drink, _ := NewOrderItem("item-4", "drink-1", order.ID, 1, brl("7.90"), "", nil)
+
+	order.AddItem(*combo)
+	order.AddItem(*drink)
+
+	if err := order.CalcTotalAmount(); err != nil {
+		t.Fatalf("CalcTotalAmount() unexpected error: %v", err)
+	}
+
+	expectedTotal := "47.80" // 39.90 do combo + 7.90; componentes não são cobrados
+	if order.Amount.Value().String() != expectedTotal {
 		t.Errorf("CalcTotalAmount() Amount = %v, want %v", order.Amount.Value(), expectedTotal)
 	}
 }
@@ -139,14 +188,14 @@ func TestValidateID_InvalidUUID(t *testing.T) {
 func TestNewOrderWithItems_ValidOrder(t *testing.T) {
 	customerID := "customer-123"
 	status, _ := NewOrderStatus("status-1", "Pending")
-	item, _ := NewOrderItem("item-1", "product-1", "order-1", 2, 10.0)
+	item, _ := NewOrderItem("item-1", "product-1", "order-1", 2, brl("10.00"), "", nil)
 	now := time.Now()
 	updatedAt := now.Add(time.Hour)
 
 	order, err := NewOrderWithItems(
 		"550e8400-e29b-41d4-a716-446655440000",
 		&customerID,
-		20.0,
+		brl("20.00"),
 		*status,
 		[]OrderItem{*item},
 		now,
@@ -165,8 +214,8 @@ func TestNewOrderWithItems_ValidOrder(t *testing.T) {
 	if *order.CustomerID != customerID {
 		t.Errorf("NewOrderWithItems() CustomerID = %v, want %v", *order.CustomerID, customerID)
 	}
-	if order.Amount.Value() != 20.0 {
-		t.Errorf("NewOrderWithItems() Amount = %v, want 20.0", order.Amount.Value())
+	if order.Amount.Value().String() != "20.00" {
+		t.Errorf("NewOrderWithItems() Amount = %v, want 20.00", order.Amount.Value())
 	}
 	if order.Status.ID != "status-1" {
 		t.Errorf("NewOrderWithItems() Status.ID = %v, want status-1", order.Status.ID)
@@ -187,7 +236,7 @@ func TestNewOrderWithItems_InvalidAmount(t *testing.T) {
 	_, err := NewOrderWithItems(
 		"550e8400-e29b-41d4-a716-446655440000",
 		&customerID,
-		-10.0, // Invalid amount
+		brl("-10.00"), // Invalid amount
 		*status,
 		[]OrderItem{},
 		now,
@@ -201,13 +250,13 @@ func TestNewOrderWithItems_InvalidAmount(t *testing.T) {
 
 func TestNewOrderWithItems_NilCustomerID(t *testing.T) {
 	status, _ := NewOrderStatus("status-1", "Pending")
-	item, _ := NewOrderItem("item-1", "product-1", "order-1", 1, 10.0)
+	item, _ := NewOrderItem("item-1", "product-1", "order-1", 1, brl("10.00"), "", nil)
 	now := time.Now()
 
 	order, err := NewOrderWithItems(
 		"550e8400-e29b-41d4-a716-446655440000",
 		nil,
-		10.0,
+		brl("10.00"),
 		*status,
 		[]OrderItem{*item},
 		now,
@@ -225,14 +274,14 @@ func TestNewOrderWithItems_NilCustomerID(t *testing.T) {
 func TestNewOrderWithItems_MultipleItems(t *testing.T) {
 	customerID := "customer-123"
 	status, _ := NewOrderStatus("status-1", "Pending")
-	item1, _ := NewOrderItem("item-1", "product-1", "order-1", 2, 10.0)
-	item2, _ := NewOrderItem("item-2", "product-2", "order-1", 3, 15.0)
+	item1, _ := NewOrderItem("item-1", "product-1", "order-1", 2, brl("10.00"), "", nil)
+	item2, _ := NewOrderItem("item-2", "product-2", "order-1", 3, brl("15.00"), "", nil)
 	now := time.Now()
 
 	order, err := NewOrderWithItems(
 		"550e8400-e29b-41d4-a716-446655440000",
 		&customerID,
-		65.0, // 2*10 + 3*15
+		brl("65.00"), // 2*10 + 3*15
 		*status,
 		[]OrderItem{*item1, *item2},
 		now,
@@ -255,7 +304,7 @@ func TestNewOrderWithItems_ZeroAmount(t *testing.T) {
 	_, err := NewOrderWithItems(
 		"550e8400-e29b-41d4-a716-446655440000",
 		&customerID,
-		0.0, // Zero amount - invalid
+		brl("0"), // Zero amount - invalid
 		*status,
 		[]OrderItem{},
 		now,
@@ -266,3 +315,362 @@ func TestNewOrderWithItems_ZeroAmount(t *testing.T) {
 		t.Error("NewOrderWithItems() with zero amount expected error, got nil")
 	}
 }
+
+func TestOrder_ChangeStatus_Allowed(t *testing.T) {
+	received, _ := NewOrderStatus("status-1", ORDER_STATUS_RECEIVED)
+	confirmed, _ := NewOrderStatus("status-2", ORDER_STATUS_CONFIRMED)
+	order, _ := NewOrder("550e8400-e29b-41d4-a716-446655440000", nil)
+	order.Status = *received
+
+	if err := order.ChangeStatus(*confirmed, STATUS_CHANGE_SOURCE_REST, nil); err != nil {
+		t.Errorf("ChangeStatus() unexpected error: %v", err)
+	}
+	if order.Status.ID != "status-2" {
+		t.Errorf("ChangeStatus() Status.ID = %v, want status-2", order.Status.ID)
+	}
+}
+
+func TestOrder_ChangeStatus_NotAllowed(t *testing.T) {
+	delivered, _ := NewOrderStatus("status-1", ORDER_STATUS_DELIVERED)
+	ready, _ := NewOrderStatus("status-2", ORDER_STATUS_READY)
+	order, _ := NewOrder("550e8400-e29b-41d4-a716-446655440000", nil)
+	order.Status = *delivered
+
+	err := order.ChangeStatus(*ready, STATUS_CHANGE_SOURCE_KITCHEN, nil)
+	if _, ok := err.(*exceptions.InvalidStatusTransitionException); !ok {
+		t.Errorf("ChangeStatus() error = %T, want *exceptions.InvalidStatusTransitionException", err)
+	}
+	if order.Status.ID != "status-1" {
+		t.Errorf("ChangeStatus() should keep the current status, got %v", order.Status.ID)
+	}
+}
+
+func TestOrder_ChangeStatus_RejectsCancellation(t *testing.T) {
+	confirmed, _ := NewOrderStatus("status-1", ORDER_STATUS_CONFIRMED)
+	cancelled, _ := NewOrderStatus("status-2", ORDER_STATUS_CANCELLED)
+	order, _ := NewOrder("550e8400-e29b-41d4-a716-446655440000", nil)
+	order.Status = *confirmed
+
+	err := order.ChangeStatus(*cancelled, STATUS_CHANGE_SOURCE_KITCHEN, nil)
+	if _, ok := err.(*exceptions.InvalidStatusTransitionException); !ok {
+		t.Errorf("ChangeStatus() error = %T, want *exceptions.InvalidStatusTransitionException", err)
+	}
+	if order.Status.ID != "status-1" || len(order.StatusChanges) != 0 {
+		t.Errorf("ChangeStatus() should keep the order untouched, got status %v", order.Status.ID)
+	}
+
+	if err := order.Cancel(*cancelled, "Cliente desistiu", STATUS_CHANGE_SOURCE_REST, nil, time.Now()); err != nil {
+		t.Errorf("Cancel() unexpected error: %v", err)
+	}
+}
+
+func TestOrder_SetInitialStatus_RecordsFirstChange(t *testing.T) {
+	received, _ := NewOrderStatus("status-1", ORDER_STATUS_RECEIVED)
+	customerID := "customer-123"
+	order, _ := NewOrder("550e8400-e29b-41d4-a716-446655440000", &customerID)
+
+	if err := order.SetInitialStatus(*received, STATUS_CHANGE_SOURCE_REST, &customerID); err != nil {
+		t.Fatalf("SetInitialStatus() unexpected error: %v", err)
+	}
+	if len(order.StatusChanges) != 1 {
+		t.Fatalf("SetInitialStatus() StatusChanges length = %v, want 1", len(order.StatusChanges))
+	}
+	if order.StatusChanges[0].PreviousStatus != nil {
+		t.Error("SetInitialStatus() first change should have no previous status")
+	}
+	if order.StatusChanges[0].OrderID != order.ID {
+		t.Errorf("SetInitialStatus() change OrderID = %v, want %v", order.StatusChanges[0].OrderID, order.ID)
+	}
+}
+
+func TestOrder_ChangeStatus_RecordsChange(t *testing.T) {
+	received, _ := NewOrderStatus("status-1", ORDER_STATUS_RECEIVED)
+	confirmed, _ := NewOrderStatus("status-2", ORDER_STATUS_CONFIRMED)
+	order, _ := NewOrder("550e8400-e29b-41d4-a716-446655440000", nil)
+	order.Status = *received
+
+	paymentID := "payment-1"
+	_ = order.ChangeStatus(*confirmed, STATUS_CHANGE_SOURCE_PAYMENT, &paymentID)
+
+	if len(order.StatusChanges) != 1 {
+		t.Fatalf("ChangeStatus() StatusChanges length = %v, want 1", len(order.StatusChanges))
+	}
+	change := order.StatusChanges[0]
+	if change.PreviousStatus.ID != "status-1" || change.NewStatus.ID != "status-2" {
+		t.Errorf("ChangeStatus() recorded %v -> %v, want status-1 -> status-2", change.PreviousStatus.ID, change.NewStatus.ID)
+	}
+	if change.Source != STATUS_CHANGE_SOURCE_PAYMENT {
+		t.Errorf("ChangeStatus() Source = %v, want %v", change.Source, STATUS_CHANGE_SOURCE_PAYMENT)
+	}
+	if order.UpdatedAt == nil || !order.UpdatedAt.Equal(change.ChangedAt) {
+		t.Errorf("ChangeStatus() UpdatedAt = %v, want %v", order.UpdatedAt, change.ChangedAt)
+	}
+}
+
+func TestOrder_ChangeStatus_SameStatusRecordsNothing(t *testing.T) {
+	received, _ := NewOrderStatus("status-1", ORDER_STATUS_RECEIVED)
+	order, _ := NewOrder("550e8400-e29b-41d4-a716-446655440000", nil)
+	order.Status = *received
+
+	if err := order.ChangeStatus(*received, STATUS_CHANGE_SOURCE_REST, nil); err != nil {
+		t.Errorf("ChangeStatus() unexpected error: %v", err)
+	}
+	if len(order.StatusChanges) != 0 {
+		t.Errorf("ChangeStatus() StatusChanges length = %v, want 0", len(order.StatusChanges))
+	}
+}
+
+func TestOrder_Cancel(t *testing.T) {
+	confirmed, _ := NewOrderStatus("status-2", ORDER_STATUS_CONFIRMED)
+	cancelled, _ := NewOrderStatus("status-9", ORDER_STATUS_CANCELLED)
+	order, _ := NewOrder("550e8400-e29b-41d4-a716-446655440000", nil)
+	order.Status = *confirmed
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	if err := order.Cancel(*cancelled, "  Cliente desistiu ", STATUS_CHANGE_SOURCE_REST, nil, now); err != nil {
+		t.Fatalf("Cancel() unexpected error: %v", err)
+	}
+	if order.Status.ID != "status-9" {
+		t.Errorf("Cancel() Status.ID = %v, want status-9", order.Status.ID)
+	}
+	if order.CancellationReason == nil || *order.CancellationReason != "Cliente desistiu" {
+		t.Errorf("Cancel() CancellationReason = %v, want Cliente desistiu", order.CancellationReason)
+	}
+	if order.CancelledAt == nil || !order.CancelledAt.Equal(now) {
+		t.Errorf("Cancel() CancelledAt = %v, want %v", order.CancelledAt, now)
+	}
+	if len(order.StatusChanges) != 1 {
+		t.Errorf("Cancel() StatusChanges length = %v, want 1", len(order.StatusChanges))
+	}
+}
+
+func TestOrder_Cancel_Rejected(t *testing.T) {
+	cancelled, _ := NewOrderStatus("status-9", ORDER_STATUS_CANCELLED)
+	ready, _ := NewOrderStatus("status-4", ORDER_STATUS_READY)
+
+	tests := []struct {
+		name    string
+		current string
+		target  OrderStatus
+		reason  string
+		wantErr string
+	}{
+		{"empty reason", ORDER_STATUS_RECEIVED, *cancelled, "   ", "*exceptions.InvalidOrderDataException"},
+		{"reason too long", ORDER_STATUS_RECEIVED, *cancelled, strings.Repeat("a", MAX_CANCELLATION_REASON_LENGTH+1), "*exceptions.InvalidOrderDataException"},
+		{"not the cancelled status", ORDER_STATUS_PREPARING, *ready, "Cliente desistiu", "*exceptions.InvalidOrderDataException"},
+		{"ready order", ORDER_STATUS_READY, *cancelled, "Cliente desistiu", "*exceptions.InvalidStatusTransitionException"},
+		{"delivered order", ORDER_STATUS_DELIVERED, *cancelled, "Cliente desistiu", "*exceptions.InvalidStatusTransitionException"},
+		{"already cancelled", ORDER_STATUS_CANCELLED, *cancelled, "Cliente desistiu", "*exceptions.InvalidStatusTransitionException"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, _ := NewOrderStatus("status-current", tt.current)
+			order, _ := NewOrder("550e8400-e29b-41d4-a716-446655440000", nil)
+			order.Status = *current
+
+			err := order.Cancel(tt.target, tt.reason, STATUS_CHANGE_SOURCE_REST, nil, time.Now())
+			if got := fmt.Sprintf("%T", err); got != tt.wantErr {
+				t.Errorf("Cancel() error = %v, want %v", got, tt.wantErr)
+			}
+			if order.Status.ID != "status-current" || order.CancellationReason != nil {
+				t.Error("Cancel() should leave the order untouched on error")
+			}
+		})
+	}
+}
+
+func TestOrder_ApplyCoupon(t *testing.T) {
+	now := time.Now()
+	item, _ := NewOrderItem("item-1", "product-1", "order-1", 2, brl("10.00"), "", nil)
+	order := newTestOrderWithItems(t, item)
+	coupon := Coupon{Code: "PROMO10", Type: COUPON_TYPE_PERCENTAGE, Percentage: 10}
+
+	if err := order.ApplyCoupon(coupon, now, false); err != nil {
+		t.Fatalf("ApplyCoupon() unexpected error: %v", err)
+	}
+
+	if order.Subtotal.String() != "20.00" || order.Discount.String() != "2.00" || order.Amount.Value().String() != "18.00" {
+		t.Errorf("ApplyCoupon() subtotal/discount/amount = %v/%v/%v, want 20.00/2.00/18.00", order.Subtotal, order.Discount, order.Amount.Value())
+	}
+	if order.CouponCode == nil || *order.CouponCode != "PROMO10" {
+		t.Errorf("ApplyCoupon() CouponCode = %v, want PROMO10", order.CouponCode)
+	}
+	if order.UpdatedAt == nil || !order.UpdatedAt.Equal(now) {
+		t.Errorf("ApplyCoupon() UpdatedAt = %v, want %v", order.UpdatedAt, now)
+	}
+
+	err := order.ApplyCoupon(Coupon{Code: "MENOS5", Type: COUPON_TYPE_FIXED_AMOUNT, FixedAmount: brl("5.00")}, now, false)
+	if _, ok := err.(*exceptions.CouponNotApplicableException); !ok {
+		t.Errorf("ApplyCoupon() second coupon error = %T, want *exceptions.CouponNotApplicableException", err)
+	}
+}
+
+func TestOrder_ApplyCoupon_Rejected(t *testing.T) {
+	now := time.Now()
+	yesterday := now.Add(-24 * time.Hour)
+	confirmed, _ := NewOrderStatus("status-2", ORDER_STATUS_CONFIRMED)
+
+	tests := []struct {
+		name         string
+		coupon       Coupon
+		isFirstOrder bool
+		paid         bool
+		expected     string
+	}{
+		{"paid order", Coupon{Code: "PROMO10", Type: COUPON_TYPE_PERCENTAGE, Percentage: 10}, false, true, "*exceptions.CouponNotApplicableException"},
+		{"expired coupon", Coupon{Code: "PROMO10", Type: COUPON_TYPE_PERCENTAGE, Percentage: 10, ValidUntil: &yesterday}, false, false, "*exceptions.CouponExpiredException"},
+		{"first order only", Coupon{Code: "BEMVINDO", Type: COUPON_TYPE_PERCENTAGE, Percentage: 10, FirstOrderOnly: true}, false, false, "*exceptions.CouponNotApplicableException"},
+		{"no uses left", Coupon{Code: "PROMO10", Type: COUPON_TYPE_PERCENTAGE, Percentage: 10, MaxUses: 1, UsedCount: 1}, true, false, "*exceptions.CouponUsageLimitReachedException"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, _ := NewOrderItem("item-1", "product-1", "order-1", 2, brl("10.00"), "", nil)
+			order := newTestOrderWithItems(t, item)
+			if tt.paid {
+				order.Status = *confirmed
+			}
+
+			err := order.ApplyCoupon(tt.coupon, now, tt.isFirstOrder)
+			if fmt.Sprintf("%T", err) != tt.expected {
+				t.Errorf("ApplyCoupon() error = %T, want %v", err, tt.expected)
+			}
+			if order.CouponCode != nil || order.Amount.Value().String() != "20.00" {
+				t.Errorf("ApplyCoupon() changed the order on error")
+			}
+		})
+	}
+}
+
+func TestOrder_ApplyCoupon_FirstOrder(t *testing.T) {
+	item, _ := NewOrderItem("item-1", "product-1", "order-1", 2, brl("10.00"), "", nil)
+	order := newTestOrderWithItems(t, item)
+
+	coupon := Coupon{Code: "BEMVINDO", Type: COUPON_TYPE_FIXED_AMOUNT, FixedAmount: brl("5.00"), FirstOrderOnly: true}
+	if err := order.ApplyCoupon(coupon, time.Now(), true); err != nil {
+		t.Fatalf("ApplyCoupon() unexpected error: %v", err)
+	}
+	if order.Amount.Value().String() != "15.00" {
+		t.Errorf("ApplyCoupon() Amount = %v, want 15.00", order.Amount.Value())
+	}
+}
+
+func TestOrder_SetDiscount(t *testing.T) {
+	received, _ := NewOrderStatus("status-1", ORDER_STATUS_RECEIVED)
+	order, _ := NewOrderWithItems("order-1", nil, brl("18.00"), *received, nil, time.Now(), nil)
+	couponCode := "PROMO10"
+
+	if err := order.SetDiscount(brl("20.00"), brl("2.00"), &couponCode); err != nil {
+		t.Fatalf("SetDiscount() unexpected error: %v", err)
+	}
+	if order.Subtotal.String() != "20.00" || order.Discount.String() != "2.00" || *order.CouponCode != "PROMO10" {
+		t.Errorf("SetDiscount() subtotal/discount/coupon = %v/%v/%v", order.Subtotal, order.Discount, order.CouponCode)
+	}
+
+	err := order.SetDiscount(brl("20.00"), brl("1.00"), &couponCode)
+	if _, ok := err.(*exceptions.AmountNotValidException); !ok {
+		t.Errorf("SetDiscount() mismatched total error = %T, want *exceptions.AmountNotValidException", err)
+	}
+}
+
+func TestOrder_SetCustomerName(t *testing.T) {
+	order, _ := NewOrder("550e8400-e29b-41d4-a716-446655440000", nil)
+
+	if err := order.SetCustomerName("  Maria "); err != nil {
+		t.Fatalf("SetCustomerName() unexpected error: %v", err)
+	}
+	if order.CustomerName != "Maria" {
+		t.Errorf("SetCustomerName() CustomerName = %q, want Maria", order.CustomerName)
+	}
+
+	err := order.SetCustomerName(strings.Repeat("á", MAX_CUSTOMER_NAME_LENGTH+1))
+	if _, ok := err.(*exceptions.InvalidOrderDataException); !ok {
+		t.Errorf("SetCustomerName() error = %T, want *exceptions.InvalidOrderDataException", err)
+	}
+	if order.CustomerName != "Maria" {
+		t.Errorf("SetCustomerName() should keep the previous name, got %q", order.CustomerName)
+	}
+}
+
+func TestOrder_IssueGuestToken(t *testing.T) {
+	guestOrder, _ := NewOrder("550e8400-e29b-41d4-a716-446655440000", nil)
+	if err := guestOrder.IssueGuestToken(); err != nil {
+		t.Fatalf("IssueGuestToken() error = %v", err)
+	}
+	if guestOrder.GuestToken == "" || guestOrder.GuestTokenHash == nil {
+		t.Fatal("IssueGuestToken() did not issue a token for a guest order")
+	}
+	if !guestOrder.HasGuestToken(guestOrder.GuestToken) {
+		t.Error("HasGuestToken() = false for the issued token")
+	}
+	if guestOrder.HasGuestToken("other-token") {
+		t.Error("HasGuestToken() = true for another token")
+	}
+
+	customerID := "customer-123"
+	customerOrder, _ := NewOrder("550e8400-e29b-41d4-a716-446655440001", &customerID)
+	if err := customerOrder.IssueGuestToken(); err != nil {
+		t.Fatalf("IssueGuestToken() error = %v", err)
+	}
+	if customerOrder.GuestToken != "" || customerOrder.GuestTokenHash != nil {
+		t.Error("IssueGuestToken() issued a token for an order with customer")
+	}
+	if customerOrder.HasGuestToken("") {
+		t.Error("HasGuestToken() = true for an order without token")
+	}
+}
+
+func TestOrder_Claim(t *testing.T) {
+	order, _ := NewOrder("550e8400-e29b-41d4-a716-446655440000", nil)
+	_ = order.IssueGuestToken()
+	token := order.GuestToken
+	now := time.Now()
+
+	if err := order.Claim("customer-123", now); err != nil {
+		t.Fatalf("Claim() error = %v", err)
+	}
+	if !order.IsOwnedBy("customer-123") {
+		t.Errorf("Claim() customer = %v, want customer-123", order.CustomerID)
+	}
+	if order.GuestTokenHash != nil || order.HasGuestToken(token) {
+		t.Error("Claim() kept the guest token valid")
+	}
+	if order.UpdatedAt == nil || !order.UpdatedAt.Equal(now) {
+		t.Errorf("Claim() UpdatedAt = %v, want %v", order.UpdatedAt, now)
+	}
+
+	err := order.Claim("customer-456", now)
+	if _, ok := err.(*exceptions.OrderAlreadyClaimedException); !ok {
+		t.Errorf("Claim() of a claimed order error = %v, want OrderAlreadyClaimedException", err)
+	}
+}
+
+func TestOrder_Claim_WithoutCustomer(t *testing.T) {
+	order, _ := NewOrder("550e8400-e29b-41d4-a716-446655440000", nil)
+
+	err := order.Claim("  ", time.Now())
+	if _, ok := err.(*exceptions.InvalidOrderDataException); !ok {
+		t.Errorf("Claim() error = %v, want InvalidOrderDataException", err)
+	}
+	if order.CustomerID != nil {
+		t.Error("Claim() set a blank customer")
+	}
+}
+
+func TestPickupDay_UsesRestaurantTimeZone(t *testing.T) {
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatalf("LoadLocation() unexpected error: %v", err)
+	}
+
+	// 23:30 em São Paulo já é o dia seguinte em UTC
+	placedAt := time.Date(2026, 3, 10, 2, 30, 0, 0, time.UTC)
+
+	if got := PickupDay(placedAt, saoPaulo); got != "2026-03-09" {
+		t.Errorf("PickupDay() = %s, want 2026-03-09", got)
+	}
+	if got := PickupDay(placedAt, time.UTC); got != "2026-03-10" {
+		t.Errorf("PickupDay() in UTC = %s, want 2026-03-10", got)
+	}
+}
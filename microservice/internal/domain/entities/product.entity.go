@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"microservice/internal/domain/exceptions"
+	"microservice/internal/domain/value_objects"
+)
+
+// Visão do catálogo no momento do pedido; o catálogo pertence ao serviço de produtos
+type Product struct {
+	ID        value_objects.ProductID
+	Name      string
+	Category  string
+	ImageURL  string
+	Notes     string // observações do catálogo (ex: alergênicos)
+	Price     value_objects.Money
+	Available bool
+	Modifiers []ProductModifier
+	// Produtos que compõem o combo; vazio para produtos avulsos
+	ComboItems []ProductComboItem
+}
+
+type ProductComboItem struct {
+	ProductID string
+	Quantity  int
+}
+
+type ProductModifier struct {
+	ID         string
+	Name       string
+	PriceDelta value_objects.Money
+}
+
+func NewProduct(id string, name string, price value_objects.Money, available bool) (*Product, error) {
+	productIDValueObject, err := value_objects.NewProductID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !price.IsPositive() {
+		return nil, &exceptions.InvalidOrderItemData{
+			Message: "Product price must be greater than 0",
+		}
+	}
+
+	return &Product{
+		ID:        productIDValueObject,
+		Name:      name,
+		Price:     price,
+		Available: available,
+	}, nil
+}
+
+func (p Product) Snapshot() ProductSnapshot {
+	return ProductSnapshot{
+		Name:     p.Name,
+		Category: p.Category,
+		ImageURL: p.ImageURL,
+		Notes:    p.Notes,
+	}
+}
+
+func (p Product) FindModifier(modifierID string) (ProductModifier, bool) {
+	for _, modifier := range p.Modifiers {
+		if modifier.ID == modifierID {
+			return modifier, true
+		}
+	}
+	return ProductModifier{}, false
+}
+
+func (p Product) IsCombo() bool {
+	return len(p.ComboItems) > 0
+}
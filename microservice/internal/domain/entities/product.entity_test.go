@@ -0,0 +1,89 @@
+package entities
+
+import (
+	"testing"
+)
+
+func TestNewProduct_ValidProduct(t *testing.T) {
+	product, err := NewProduct("product-1", "X-Burger", brl("25.90"), true)
+
+	if err != nil {
+		t.Fatalf("NewProduct() unexpected error: %v", err)
+	}
+	if product.ID.Value() != "product-1" {
+		t.Errorf("NewProduct() ID = %v, want product-1", product.ID.Value())
+	}
+	if product.Name != "X-Burger" {
+		t.Errorf("NewProduct() Name = %v, want X-Burger", product.Name)
+	}
+	if product.Price.String() != "25.90" {
+		t.Errorf("NewProduct() Price = %v, want 25.90", product.Price)
+	}
+	if !product.Available {
+		t.Error("NewProduct() Available = false, want true")
+	}
+}
+
+func TestNewProduct_InvalidData(t *testing.T) {
+	tests := []struct {
+		name  string
+		id    string
+		price string
+	}{
+		{"empty id", "", "10.00"},
+		{"zero price", "product-1", "0.00"},
+		{"negative price", "product-1", "-1.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product, err := NewProduct(tt.id, "X-Burger", brl(tt.price), true)
+			if err == nil {
+				t.Error("NewProduct() expected error, got nil")
+			}
+			if product != nil {
+				t.Error("NewProduct() expected nil product on error")
+			}
+		})
+	}
+}
+
+func TestProduct_Snapshot(t *testing.T) {
+	product, _ := NewProduct("product-1", "X-Burger", brl("25.90"), true)
+	product.Category = "Lanche"
+	product.ImageURL = "https://cdn.example.com/x-burger.png"
+	product.Notes = "Contém glúten"
+
+	snapshot := product.Snapshot()
+
+	expected := ProductSnapshot{Name: "X-Burger", Category: "Lanche", ImageURL: "https://cdn.example.com/x-burger.png", Notes: "Contém glúten"}
+	if snapshot != expected {
+		t.Errorf("Snapshot() = %+v, want %+v", snapshot, expected)
+	}
+}
+
+func TestProduct_FindModifier(t *testing.T) {
+	product, _ := NewProduct("product-1", "X-Burger", brl("25.90"), true)
+	product.Modifiers = []ProductModifier{{ID: "queijo-extra", Name: "Queijo extra", PriceDelta: brl("3.00")}}
+
+	modifier, ok := product.FindModifier("queijo-extra")
+	if !ok || modifier.Name != "Queijo extra" {
+		t.Errorf("FindModifier(queijo-extra) = %+v, %v, want Queijo extra", modifier, ok)
+	}
+
+	if _, ok := product.FindModifier("bacon-extra"); ok {
+		t.Error("FindModifier(bacon-extra) found a modifier the product does not offer")
+	}
+}
+
+func TestProduct_IsCombo(t *testing.T) {
+	product, _ := NewProduct("combo-1", "Combo X-Burger", brl("39.90"), true)
+	if product.IsCombo() {
+		t.Error("IsCombo() = true for a product without combo items")
+	}
+
+	product.ComboItems = []ProductComboItem{{ProductID: "product-1", Quantity: 1}}
+	if !product.IsCombo() {
+		t.Error("IsCombo() = false for a product with combo items")
+	}
+}
@@ -0,0 +1,67 @@
+package exceptions
+
+type CouponNotFoundException struct {
+	Message string
+}
+
+type InvalidCouponDataException struct {
+	Message string
+}
+
+type CouponAlreadyExistsException struct {
+	Message string
+}
+
+type CouponExpiredException struct {
+	Message string
+}
+
+type CouponUsageLimitReachedException struct {
+	Message string
+}
+
+type CouponNotApplicableException struct {
+	Message string
+}
+
+func (e *CouponNotFoundException) Error() string {
+	if e.Message == "" {
+		return "Coupon not found"
+	}
+	return e.Message
+}
+
+func (e *InvalidCouponDataException) Error() string {
+	if e.Message == "" {
+		return "Invalid coupon data"
+	}
+	return e.Message
+}
+
+func (e *CouponAlreadyExistsException) Error() string {
+	if e.Message == "" {
+		return "Coupon already exists"
+	}
+	return e.Message
+}
+
+func (e *CouponExpiredException) Error() string {
+	if e.Message == "" {
+		return "Coupon has expired"
+	}
+	return e.Message
+}
+
+func (e *CouponUsageLimitReachedException) Error() string {
+	if e.Message == "" {
+		return "Coupon usage limit reached"
+	}
+	return e.Message
+}
+
+func (e *CouponNotApplicableException) Error() string {
+	if e.Message == "" {
+		return "Coupon cannot be applied to this order"
+	}
+	return e.Message
+}
@@ -0,0 +1,34 @@
+package exceptions
+
+import (
+	"testing"
+)
+
+func TestCouponExceptions_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"coupon not found default", &CouponNotFoundException{}, "Coupon not found"},
+		{"coupon not found custom", &CouponNotFoundException{Message: "Coupon X not found"}, "Coupon X not found"},
+		{"invalid coupon default", &InvalidCouponDataException{}, "Invalid coupon data"},
+		{"invalid coupon custom", &InvalidCouponDataException{Message: "Invalid code"}, "Invalid code"},
+		{"coupon exists default", &CouponAlreadyExistsException{}, "Coupon already exists"},
+		{"coupon exists custom", &CouponAlreadyExistsException{Message: "Code taken"}, "Code taken"},
+		{"coupon expired default", &CouponExpiredException{}, "Coupon has expired"},
+		{"coupon expired custom", &CouponExpiredException{Message: "Expired yesterday"}, "Expired yesterday"},
+		{"usage limit default", &CouponUsageLimitReachedException{}, "Coupon usage limit reached"},
+		{"usage limit custom", &CouponUsageLimitReachedException{Message: "Sold out"}, "Sold out"},
+		{"not applicable default", &CouponNotApplicableException{}, "Coupon cannot be applied to this order"},
+		{"not applicable custom", &CouponNotApplicableException{Message: "First order only"}, "First order only"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err.Error() != tt.expected {
+				t.Errorf("Error() = %v, want %v", tt.err.Error(), tt.expected)
+			}
+		})
+	}
+}
@@ -16,6 +16,26 @@ type OrderStatusNotFoundException struct {
 	Message string
 }
 
+type InvalidStatusTransitionException struct {
+	Message string
+}
+
+type InvalidOrderFilterException struct {
+	Message string
+}
+
+type InvalidPaymentConfirmationException struct {
+	Message string
+}
+
+type OrderAlreadyClaimedException struct {
+	Message string
+}
+
+type OrderConcurrentModificationException struct {
+	Message string
+}
+
 func (e *OrderNotFoundException) Error() string {
 	if e.Message == "" {
 		return "Order not found"
@@ -43,3 +63,38 @@ func (e *OrderStatusNotFoundException) Error() string {
 	}
 	return e.Message
 }
+
+func (e *InvalidStatusTransitionException) Error() string {
+	if e.Message == "" {
+		return "Invalid order status transition"
+	}
+	return e.Message
+}
+
+func (e *InvalidOrderFilterException) Error() string {
+	if e.Message == "" {
+		return "Invalid order filter"
+	}
+	return e.Message
+}
+
+func (e *InvalidPaymentConfirmationException) Error() string {
+	if e.Message == "" {
+		return "Invalid payment confirmation"
+	}
+	return e.Message
+}
+
+func (e *OrderAlreadyClaimedException) Error() string {
+	if e.Message == "" {
+		return "Order already belongs to a customer"
+	}
+	return e.Message
+}
+
+func (e *OrderConcurrentModificationException) Error() string {
+	if e.Message == "" {
+		return "Order was modified by another request, reload it and try again"
+	}
+	return e.Message
+}
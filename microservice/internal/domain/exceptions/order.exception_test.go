@@ -83,3 +83,103 @@ func TestOrderStatusNotFoundException_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestInvalidStatusTransitionException_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected string
+	}{
+		{"with custom message", "Order cannot change status from Entregue to Pronto", "Order cannot change status from Entregue to Pronto"},
+		{"with empty message", "", "Invalid order status transition"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &InvalidStatusTransitionException{Message: tt.message}
+			if err.Error() != tt.expected {
+				t.Errorf("Error() = %v, want %v", err.Error(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestInvalidOrderFilterException_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected string
+	}{
+		{"with custom message", "Invalid cursor", "Invalid cursor"},
+		{"with empty message", "", "Invalid order filter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &InvalidOrderFilterException{Message: tt.message}
+			if err.Error() != tt.expected {
+				t.Errorf("Error() = %v, want %v", err.Error(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestInvalidPaymentConfirmationException_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected string
+	}{
+		{"with custom message", "payment ID is required", "payment ID is required"},
+		{"with empty message", "", "Invalid payment confirmation"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &InvalidPaymentConfirmationException{Message: tt.message}
+			if err.Error() != tt.expected {
+				t.Errorf("Error() = %v, want %v", err.Error(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestOrderAlreadyClaimedException_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected string
+	}{
+		{"with custom message", "Order 123 already claimed", "Order 123 already claimed"},
+		{"with empty message", "", "Order already belongs to a customer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &OrderAlreadyClaimedException{Message: tt.message}
+			if err.Error() != tt.expected {
+				t.Errorf("Error() = %v, want %v", err.Error(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestOrderConcurrentModificationException_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected string
+	}{
+		{"with custom message", "Order 123 changed", "Order 123 changed"},
+		{"with empty message", "", "Order was modified by another request, reload it and try again"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &OrderConcurrentModificationException{Message: tt.message}
+			if err.Error() != tt.expected {
+				t.Errorf("Error() = %v, want %v", err.Error(), tt.expected)
+			}
+		})
+	}
+}
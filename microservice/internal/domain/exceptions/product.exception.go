@@ -0,0 +1,62 @@
+package exceptions
+
+type ProductNotFoundException struct {
+	Message string
+}
+
+type ProductUnavailableException struct {
+	Message string
+}
+
+type ProductPriceMismatchException struct {
+	Message string
+}
+
+type ProductCatalogUnavailableException struct {
+	Message string
+	// Erro de acesso ao catálogo (URL, host, conexão); vai só para o log
+	Cause error
+}
+
+type ProductModifierNotFoundException struct {
+	Message string
+}
+
+func (e *ProductNotFoundException) Error() string {
+	if e.Message == "" {
+		return "Product not found"
+	}
+	return e.Message
+}
+
+func (e *ProductUnavailableException) Error() string {
+	if e.Message == "" {
+		return "Product is not available"
+	}
+	return e.Message
+}
+
+func (e *ProductPriceMismatchException) Error() string {
+	if e.Message == "" {
+		return "Product price does not match the catalog price"
+	}
+	return e.Message
+}
+
+func (e *ProductCatalogUnavailableException) Error() string {
+	if e.Message == "" {
+		return "Product catalog is temporarily unavailable"
+	}
+	return e.Message
+}
+
+func (e *ProductCatalogUnavailableException) Unwrap() error {
+	return e.Cause
+}
+
+func (e *ProductModifierNotFoundException) Error() string {
+	if e.Message == "" {
+		return "Modifier not offered for this product"
+	}
+	return e.Message
+}
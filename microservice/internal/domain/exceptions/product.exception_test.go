@@ -0,0 +1,32 @@
+package exceptions
+
+import (
+	"testing"
+)
+
+func TestProductExceptions_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"product not found default", &ProductNotFoundException{}, "Product not found"},
+		{"product not found custom", &ProductNotFoundException{Message: "Product p1 not found"}, "Product p1 not found"},
+		{"product unavailable default", &ProductUnavailableException{}, "Product is not available"},
+		{"product unavailable custom", &ProductUnavailableException{Message: "Out of stock"}, "Out of stock"},
+		{"price mismatch default", &ProductPriceMismatchException{}, "Product price does not match the catalog price"},
+		{"price mismatch custom", &ProductPriceMismatchException{Message: "Price changed"}, "Price changed"},
+		{"catalog unavailable default", &ProductCatalogUnavailableException{}, "Product catalog is temporarily unavailable"},
+		{"catalog unavailable custom", &ProductCatalogUnavailableException{Message: "timeout"}, "timeout"},
+		{"modifier not found default", &ProductModifierNotFoundException{}, "Modifier not offered for this product"},
+		{"modifier not found custom", &ProductModifierNotFoundException{Message: "No bacon"}, "No bacon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err.Error() != tt.expected {
+				t.Errorf("Error() = %v, want %v", tt.err.Error(), tt.expected)
+			}
+		})
+	}
+}
@@ -3,11 +3,11 @@ package value_objects
 import "microservice/internal/domain/exceptions"
 
 type Amount struct {
-	value float64
+	value Money
 }
 
-func NewAmount(a float64) (Amount, error) {
-	if a <= 0 {
+func NewAmount(a Money) (Amount, error) {
+	if !a.IsPositive() {
 		return Amount{}, &exceptions.AmountNotValidException{
 			Message: "Amount must be greater than zero",
 		}
@@ -15,6 +15,6 @@ func NewAmount(a float64) (Amount, error) {
 	return Amount{value: a}, nil
 }
 
-func (a Amount) Value() float64 {
+func (a Amount) Value() Money {
 	return a.value
 }
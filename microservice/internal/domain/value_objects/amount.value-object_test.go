@@ -9,23 +9,23 @@ import (
 func TestNewAmount_ValidAmount(t *testing.T) {
 	tests := []struct {
 		name     string
-		value    float64
-		expected float64
+		value    int64
+		expected string
 	}{
-		{"positive integer", 100.0, 100.0},
-		{"positive decimal", 99.99, 99.99},
-		{"small positive", 0.01, 0.01},
-		{"large amount", 999999.99, 999999.99},
+		{"positive integer", 10000, "100.00"},
+		{"positive decimal", 9999, "99.99"},
+		{"small positive", 1, "0.01"},
+		{"large amount", 99999999, "999999.99"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			amount, err := NewAmount(tt.value)
+			amount, err := NewAmount(mustMoney(t, tt.value))
 			if err != nil {
 				t.Errorf("NewAmount(%v) unexpected error: %v", tt.value, err)
 			}
-			if amount.Value() != tt.expected {
-				t.Errorf("NewAmount(%v).Value() = %v, want %v", tt.value, amount.Value(), tt.expected)
+			if amount.Value().String() != tt.expected {
+				t.Errorf("NewAmount(%v).Value() = %v, want %v", tt.value, amount.Value().String(), tt.expected)
 			}
 		})
 	}
@@ -34,16 +34,16 @@ func TestNewAmount_ValidAmount(t *testing.T) {
 func TestNewAmount_InvalidAmount(t *testing.T) {
 	tests := []struct {
 		name  string
-		value float64
+		value int64
 	}{
-		{"zero", 0.0},
-		{"negative", -10.0},
-		{"negative decimal", -0.01},
+		{"zero", 0},
+		{"negative", -1000},
+		{"negative decimal", -1},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := NewAmount(tt.value)
+			_, err := NewAmount(mustMoney(t, tt.value))
 			if err == nil {
 				t.Errorf("NewAmount(%v) expected error, got nil", tt.value)
 			}
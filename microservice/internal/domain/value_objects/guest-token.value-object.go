@@ -0,0 +1,47 @@
+package value_objects
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// 32 bytes aleatórios, codificados em 43 caracteres base64url
+const GUEST_TOKEN_BYTES = 32
+
+// Só o hash é gravado; o token aparece uma única vez, na criação do pedido
+type GuestToken struct {
+	value string
+}
+
+func NewGuestToken() (GuestToken, error) {
+	random := make([]byte, GUEST_TOKEN_BYTES)
+	if _, err := rand.Read(random); err != nil {
+		return GuestToken{}, fmt.Errorf("failed to generate guest token: %w", err)
+	}
+	return GuestToken{value: base64.RawURLEncoding.EncodeToString(random)}, nil
+}
+
+func (t GuestToken) Value() string {
+	return t.value
+}
+
+func (t GuestToken) Hash() string {
+	return HashGuestToken(t.value)
+}
+
+func HashGuestToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Comparação em tempo constante, para que o hash não seja adivinhado byte a byte
+func MatchesGuestTokenHash(token string, hash string) bool {
+	if token == "" || hash == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(HashGuestToken(token)), []byte(hash)) == 1
+}
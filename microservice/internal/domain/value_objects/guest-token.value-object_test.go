@@ -0,0 +1,47 @@
+package value_objects
+
+import "testing"
+
+func TestNewGuestToken(t *testing.T) {
+	token1, err := NewGuestToken()
+	if err != nil {
+		t.Fatalf("NewGuestToken() error = %v", err)
+	}
+	token2, _ := NewGuestToken()
+
+	if len(token1.Value()) != 43 {
+		t.Errorf("NewGuestToken() length = %d, want 43", len(token1.Value()))
+	}
+	if token1.Value() == token2.Value() {
+		t.Error("NewGuestToken() should generate unique tokens")
+	}
+	if token1.Hash() == token1.Value() || len(token1.Hash()) != 64 {
+		t.Errorf("Hash() = %q, want a sha256 hex digest", token1.Hash())
+	}
+}
+
+func TestMatchesGuestTokenHash(t *testing.T) {
+	token, _ := NewGuestToken()
+	other, _ := NewGuestToken()
+
+	tests := []struct {
+		name  string
+		token string
+		hash  string
+		want  bool
+	}{
+		{"same token", token.Value(), token.Hash(), true},
+		{"other token", other.Value(), token.Hash(), false},
+		{"empty token", "", token.Hash(), false},
+		{"empty hash", token.Value(), "", false},
+		{"hash as token", token.Hash(), token.Hash(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchesGuestTokenHash(tt.token, tt.hash); got != tt.want {
+				t.Errorf("MatchesGuestTokenHash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package value_objects
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"microservice/internal/domain/exceptions"
+)
+
+const DEFAULT_CURRENCY = "BRL"
+
+// Casas decimais das moedas aceitas (ISO 4217)
+var currencyMinorUnits = map[string]int{
+	"BRL": 2,
+	"USD": 2,
+	"EUR": 2,
+}
+
+var decimalPattern = regexp.MustCompile(`^(-)?(\d+)(?:\.(\d+))?$`)
+
+// Valor na menor unidade da moeda (ex: centavos), para somas e multiplicações exatas
+type Money struct {
+	amount   int64
+	currency string
+}
+
+func NewMoney(minorUnits int64, currency string) (Money, error) {
+	if _, err := currencyExponent(currency); err != nil {
+		return Money{}, err
+	}
+	return Money{amount: minorUnits, currency: currency}, nil
+}
+
+// Valores com mais casas decimais do que a moeda permite são rejeitados, não arredondados
+func ParseMoney(value string, currency string) (Money, error) {
+	exponent, err := currencyExponent(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	matches := decimalPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if matches == nil {
+		return Money{}, &exceptions.AmountNotValidException{
+			Message: fmt.Sprintf("Amount %q is not a valid decimal", value),
+		}
+	}
+
+	sign, units, fraction := matches[1], matches[2], matches[3]
+	if len(fraction) > exponent {
+		return Money{}, &exceptions.AmountNotValidException{
+			Message: fmt.Sprintf("Amount %q has more than %d decimal places for %s", value, exponent, currency),
+		}
+	}
+	fraction += strings.Repeat("0", exponent-len(fraction))
+
+	amount, err := strconv.ParseInt(sign+units+fraction, 10, 64)
+	if err != nil {
+		return Money{}, &exceptions.AmountNotValidException{
+			Message: fmt.Sprintf("Amount %q is out of range", value),
+		}
+	}
+
+	return Money{amount: amount, currency: currency}, nil
+}
+
+func currencyExponent(currency string) (int, error) {
+	exponent, ok := currencyMinorUnits[currency]
+	if !ok {
+		return 0, &exceptions.AmountNotValidException{
+			Message: fmt.Sprintf("Currency %q is not supported", currency),
+		}
+	}
+	return exponent, nil
+}
+
+func (m Money) MinorUnits() int64 {
+	return m.amount
+}
+
+func (m Money) Currency() string {
+	return m.currency
+}
+
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.amount > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if m.currency != other.currency {
+		return Money{}, &exceptions.AmountNotValidException{
+			Message: fmt.Sprintf("Cannot add %s to %s", other.currency, m.currency),
+		}
+	}
+	return Money{amount: m.amount + other.amount, currency: m.currency}, nil
+}
+
+func (m Money) Subtract(other Money) (Money, error) {
+	if m.currency != other.currency {
+		return Money{}, &exceptions.AmountNotValidException{
+			Message: fmt.Sprintf("Cannot subtract %s from %s", other.currency, m.currency),
+		}
+	}
+	return Money{amount: m.amount - other.amount, currency: m.currency}, nil
+}
+
+// Arredonda a metade para longe do zero
+func (m Money) Percentage(percent int) Money {
+	scaled := m.amount * int64(percent)
+	rounded := (scaled + 50) / 100
+	if scaled < 0 {
+		rounded = (scaled - 50) / 100
+	}
+	return Money{amount: rounded, currency: m.currency}
+}
+
+func (m Money) Multiply(factor int) Money {
+	return Money{amount: m.amount * int64(factor), currency: m.currency}
+}
+
+// Formata com as casas decimais da moeda, ex: "59.97"
+func (m Money) String() string {
+	exponent := currencyMinorUnits[m.currency]
+
+	amount := m.amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	if exponent == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+}
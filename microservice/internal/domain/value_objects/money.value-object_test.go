@@ -0,0 +1,163 @@
+package value_objects
+
+import (
+	"testing"
+
+	"microservice/internal/domain/exceptions"
+)
+
+func mustMoney(t *testing.T, minorUnits int64) Money {
+	t.Helper()
+	money, err := NewMoney(minorUnits, DEFAULT_CURRENCY)
+	if err != nil {
+		t.Fatalf("NewMoney(%v) unexpected error: %v", minorUnits, err)
+	}
+	return money
+}
+
+func TestNewMoney_UnsupportedCurrency(t *testing.T) {
+	_, err := NewMoney(100, "XYZ")
+	if _, ok := err.(*exceptions.AmountNotValidException); !ok {
+		t.Errorf("NewMoney() expected AmountNotValidException, got %T", err)
+	}
+}
+
+func TestParseMoney_Valid(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected int64
+	}{
+		{"19.99", 1999},
+		{"19.9", 1990},
+		{"19", 1900},
+		{"0.01", 1},
+		{"-5.50", -550},
+		{" 10.00 ", 1000},
+		{"92233720368547758.07", 9223372036854775807},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			money, err := ParseMoney(tt.value, DEFAULT_CURRENCY)
+			if err != nil {
+				t.Fatalf("ParseMoney(%q) unexpected error: %v", tt.value, err)
+			}
+			if money.MinorUnits() != tt.expected {
+				t.Errorf("ParseMoney(%q).MinorUnits() = %v, want %v", tt.value, money.MinorUnits(), tt.expected)
+			}
+			if money.Currency() != DEFAULT_CURRENCY {
+				t.Errorf("ParseMoney(%q).Currency() = %v, want %v", tt.value, money.Currency(), DEFAULT_CURRENCY)
+			}
+		})
+	}
+}
+
+func TestParseMoney_Invalid(t *testing.T) {
+	tests := []string{"", "abc", "1,99", "1.999", "1e3", ".5", "92233720368547758.08"}
+
+	for _, value := range tests {
+		t.Run(value, func(t *testing.T) {
+			_, err := ParseMoney(value, DEFAULT_CURRENCY)
+			if _, ok := err.(*exceptions.AmountNotValidException); !ok {
+				t.Errorf("ParseMoney(%q) expected AmountNotValidException, got %T", value, err)
+			}
+		})
+	}
+}
+
+func TestMoney_MultiplyAndAddAreExact(t *testing.T) {
+	price := mustMoney(t, 1999)
+
+	total, err := price.Multiply(3).Add(mustMoney(t, 1))
+	if err != nil {
+		t.Fatalf("Add() unexpected error: %v", err)
+	}
+	if total.String() != "59.98" {
+		t.Errorf("total = %v, want 59.98", total.String())
+	}
+}
+
+func TestMoney_AddDifferentCurrencies(t *testing.T) {
+	usd, _ := NewMoney(100, "USD")
+
+	_, err := mustMoney(t, 100).Add(usd)
+	if _, ok := err.(*exceptions.AmountNotValidException); !ok {
+		t.Errorf("Add() expected AmountNotValidException, got %T", err)
+	}
+}
+
+func TestMoney_Sign(t *testing.T) {
+	tests := []struct {
+		minorUnits int64
+		zero       bool
+		positive   bool
+		negative   bool
+	}{
+		{0, true, false, false},
+		{1, false, true, false},
+		{-1, false, false, true},
+	}
+
+	for _, tt := range tests {
+		money := mustMoney(t, tt.minorUnits)
+		if money.IsZero() != tt.zero || money.IsPositive() != tt.positive || money.IsNegative() != tt.negative {
+			t.Errorf("Money(%d) zero=%v positive=%v negative=%v", tt.minorUnits, money.IsZero(), money.IsPositive(), money.IsNegative())
+		}
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	tests := []struct {
+		minorUnits int64
+		expected   string
+	}{
+		{0, "0.00"},
+		{5, "0.05"},
+		{99, "0.99"},
+		{100, "1.00"},
+		{5997, "59.97"},
+		{-550, "-5.50"},
+	}
+
+	for _, tt := range tests {
+		if got := mustMoney(t, tt.minorUnits).String(); got != tt.expected {
+			t.Errorf("Money(%v).String() = %v, want %v", tt.minorUnits, got, tt.expected)
+		}
+	}
+}
+
+func TestMoney_Subtract(t *testing.T) {
+	result, err := mustMoney(t, 1000).Subtract(mustMoney(t, 250))
+	if err != nil {
+		t.Fatalf("Subtract() unexpected error: %v", err)
+	}
+	if result.MinorUnits() != 750 {
+		t.Errorf("Subtract() = %v, want 750", result.MinorUnits())
+	}
+
+	usd, _ := NewMoney(100, "USD")
+	if _, err := mustMoney(t, 100).Subtract(usd); err == nil {
+		t.Error("Subtract() expected error for different currencies")
+	}
+}
+
+func TestMoney_Percentage_RoundsHalfAwayFromZero(t *testing.T) {
+	tests := []struct {
+		minorUnits int64
+		percent    int
+		expected   int64
+	}{
+		{1000, 10, 100},
+		{1995, 10, 200}, // 199.5 -> 200
+		{1994, 10, 199},
+		{999, 15, 150}, // 149.85 -> 150
+		{-1995, 10, -200},
+	}
+
+	for _, tt := range tests {
+		result := mustMoney(t, tt.minorUnits).Percentage(tt.percent)
+		if result.MinorUnits() != tt.expected {
+			t.Errorf("Money(%d).Percentage(%d) = %d, want %d", tt.minorUnits, tt.percent, result.MinorUnits(), tt.expected)
+		}
+	}
+}
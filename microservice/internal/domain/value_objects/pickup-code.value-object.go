@@ -0,0 +1,61 @@
+package value_objects
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"microservice/internal/domain/exceptions"
+)
+
+// Cada letra cobre 999 pedidos: A001..A999, B001..B999 e assim por diante
+const (
+	PICKUP_CODE_NUMBERS_PER_LETTER = 999
+	MAX_PICKUP_SEQUENCE            = 26 * PICKUP_CODE_NUMBERS_PER_LETTER
+)
+
+var pickupCodePattern = regexp.MustCompile(`^[A-Z][0-9]{3}$`)
+
+// Número curto da tela de retirada, ex: "A042"; só é único dentro do dia
+type PickupCode struct {
+	value string
+}
+
+// sequence começa em 1
+func NewPickupCode(sequence int) (PickupCode, error) {
+	if sequence < 1 || sequence > MAX_PICKUP_SEQUENCE {
+		return PickupCode{}, &exceptions.InvalidOrderDataException{
+			Message: fmt.Sprintf("pickup sequence must be between 1 and %d, got %d", MAX_PICKUP_SEQUENCE, sequence),
+		}
+	}
+
+	letter := 'A' + rune((sequence-1)/PICKUP_CODE_NUMBERS_PER_LETTER)
+	number := (sequence-1)%PICKUP_CODE_NUMBERS_PER_LETTER + 1
+	return PickupCode{value: fmt.Sprintf("%c%03d", letter, number)}, nil
+}
+
+// Ignora maiúsculas/minúsculas e espaços
+func ParsePickupCode(code string) (PickupCode, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if !pickupCodePattern.MatchString(code) {
+		return PickupCode{}, &exceptions.InvalidOrderDataException{
+			Message: fmt.Sprintf("pickup code must be a letter followed by 3 digits (ex: A042), got %q", code),
+		}
+	}
+	if number, _ := strconv.Atoi(code[1:]); number == 0 {
+		return PickupCode{}, &exceptions.InvalidOrderDataException{
+			Message: fmt.Sprintf("pickup code %s does not exist", code),
+		}
+	}
+	return PickupCode{value: code}, nil
+}
+
+func (p PickupCode) Value() string {
+	return p.value
+}
+
+// Pedidos anteriores aos códigos de retirada não têm código
+func (p PickupCode) IsZero() bool {
+	return p.value == ""
+}
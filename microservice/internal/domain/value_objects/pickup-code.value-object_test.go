@@ -0,0 +1,87 @@
+package value_objects
+
+import (
+	"testing"
+
+	"microservice/internal/domain/exceptions"
+)
+
+func TestNewPickupCode(t *testing.T) {
+	tests := []struct {
+		sequence int
+		expected string
+	}{
+		{1, "A001"},
+		{42, "A042"},
+		{999, "A999"},
+		{1000, "B001"},
+		{1998, "B999"},
+		{MAX_PICKUP_SEQUENCE, "Z999"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			code, err := NewPickupCode(tt.sequence)
+			if err != nil {
+				t.Fatalf("NewPickupCode(%d) unexpected error: %v", tt.sequence, err)
+			}
+			if code.Value() != tt.expected {
+				t.Errorf("NewPickupCode(%d) = %v, want %v", tt.sequence, code.Value(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewPickupCode_OutOfRange(t *testing.T) {
+	for _, sequence := range []int{0, -1, MAX_PICKUP_SEQUENCE + 1} {
+		_, err := NewPickupCode(sequence)
+		if _, ok := err.(*exceptions.InvalidOrderDataException); !ok {
+			t.Errorf("NewPickupCode(%d) error = %T, want *exceptions.InvalidOrderDataException", sequence, err)
+		}
+	}
+}
+
+func TestParsePickupCode(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		valid    bool
+	}{
+		{"A042", "A042", true},
+		{" b100 ", "B100", true},
+		{"Z999", "Z999", true},
+		{"A000", "", false},
+		{"A42", "", false},
+		{"AA042", "", false},
+		{"1042", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			code, err := ParsePickupCode(tt.input)
+			if !tt.valid {
+				if _, ok := err.(*exceptions.InvalidOrderDataException); !ok {
+					t.Errorf("ParsePickupCode(%q) error = %T, want *exceptions.InvalidOrderDataException", tt.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePickupCode(%q) unexpected error: %v", tt.input, err)
+			}
+			if code.Value() != tt.expected {
+				t.Errorf("ParsePickupCode(%q) = %v, want %v", tt.input, code.Value(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestPickupCode_IsZero(t *testing.T) {
+	if !(PickupCode{}).IsZero() {
+		t.Error("zero PickupCode should be zero")
+	}
+	code, _ := NewPickupCode(1)
+	if code.IsZero() {
+		t.Error("assigned PickupCode should not be zero")
+	}
+}
@@ -1,18 +1,20 @@
 package value_objects
 
-import "fmt"
+import "microservice/internal/domain/exceptions"
 
 type UnitPrice struct {
-	value float64
+	value Money
 }
 
-func NewUnitPrice(value float64) (UnitPrice, error) {
-	if value <= 0 {
-		return UnitPrice{}, fmt.Errorf("unit price must be greater than 0")
+func NewUnitPrice(value Money) (UnitPrice, error) {
+	if !value.IsPositive() {
+		return UnitPrice{}, &exceptions.InvalidOrderItemData{
+			Message: "unit price must be greater than 0",
+		}
 	}
 	return UnitPrice{value: value}, nil
 }
 
-func (p *UnitPrice) Value() float64 {
+func (p *UnitPrice) Value() Money {
 	return p.value
 }
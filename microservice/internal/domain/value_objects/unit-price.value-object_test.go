@@ -7,23 +7,23 @@ import (
 func TestNewUnitPrice_ValidPrice(t *testing.T) {
 	tests := []struct {
 		name     string
-		value    float64
-		expected float64
+		value    int64
+		expected string
 	}{
-		{"positive integer", 10.0, 10.0},
-		{"positive decimal", 19.99, 19.99},
-		{"small positive", 0.01, 0.01},
-		{"large price", 9999.99, 9999.99},
+		{"positive integer", 1000, "10.00"},
+		{"positive decimal", 1999, "19.99"},
+		{"small positive", 1, "0.01"},
+		{"large price", 999999, "9999.99"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			price, err := NewUnitPrice(tt.value)
+			price, err := NewUnitPrice(mustMoney(t, tt.value))
 			if err != nil {
 				t.Errorf("NewUnitPrice(%v) unexpected error: %v", tt.value, err)
 			}
-			if price.Value() != tt.expected {
-				t.Errorf("NewUnitPrice(%v).Value() = %v, want %v", tt.value, price.Value(), tt.expected)
+			if price.Value().String() != tt.expected {
+				t.Errorf("NewUnitPrice(%v).Value() = %v, want %v", tt.value, price.Value().String(), tt.expected)
 			}
 		})
 	}
@@ -32,16 +32,16 @@ func TestNewUnitPrice_ValidPrice(t *testing.T) {
 func TestNewUnitPrice_InvalidPrice(t *testing.T) {
 	tests := []struct {
 		name  string
-		value float64
+		value int64
 	}{
-		{"zero", 0.0},
-		{"negative", -10.0},
-		{"negative decimal", -0.01},
+		{"zero", 0},
+		{"negative", -1000},
+		{"negative decimal", -1},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := NewUnitPrice(tt.value)
+			_, err := NewUnitPrice(mustMoney(t, tt.value))
 			if err == nil {
 				t.Errorf("NewUnitPrice(%v) expected error, got nil", tt.value)
 			}
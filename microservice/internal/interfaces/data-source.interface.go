@@ -1,20 +1,83 @@
 package interfaces
 
 import (
+	"context"
+	"time"
+
 	"microservice/internal/adapters/daos"
 	"microservice/internal/adapters/dtos"
 )
 
 type IOrderDataSource interface {
-	Create(order daos.OrderDAO) error
-	FindAll(filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error)
-	FindByID(id string) (daos.OrderDAO, error)
-	Update(order daos.OrderDAO) error
-	Delete(id string) error
+	Create(ctx context.Context, order daos.OrderDAO, outbox ...daos.OutboxMessageDAO) error
+	FindAll(ctx context.Context, filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error)
+	Count(ctx context.Context, filter dtos.OrderFilterDTO) (int64, error)
+	FindByID(ctx context.Context, id string) (daos.OrderDAO, error)
+	FindByPickupCode(ctx context.Context, day string, code string) (daos.OrderDAO, error)
+	// Próximo número de retirada do dia, começando em 1
+	NextPickupSequence(ctx context.Context, day string) (int, error)
+	// Mais antigos primeiro
+	FindBoard(ctx context.Context, statusIDs []string, createdFrom time.Time) ([]daos.OrderBoardEntryDAO, error)
+	Update(ctx context.Context, order daos.OrderDAO, outbox ...daos.OutboxMessageDAO) error
+	// Na mesma transação; o pedido não muda se a mensagem já foi processada
+	UpdateFromMessage(ctx context.Context, order daos.OrderDAO, message daos.ProcessedMessageDAO, outbox ...daos.OutboxMessageDAO) error
+	// Na mesma transação; falha sem usos restantes ou se o cliente já resgatou o cupom de primeiro pedido
+	ApplyCoupon(ctx context.Context, order daos.OrderDAO, outbox ...daos.OutboxMessageDAO) error
+	// OrderAlreadyClaimedException se o pedido já tem cliente ou o hash não confere
+	Claim(ctx context.Context, orderID string, customerID string, guestTokenHash string, claimedAt time.Time) error
+	// OrderAlreadyClaimedException se o pedido já tem cliente
+	ReissueGuestToken(ctx context.Context, orderID string, guestTokenHash string) error
+	// Oculta o pedido de todas as consultas até ser restaurado ou expurgado
+	Delete(ctx context.Context, id string, outbox ...daos.OutboxMessageDAO) error
+	// OrderNotFoundException se o pedido não está excluído
+	Restore(ctx context.Context, id string, outbox ...daos.OutboxMessageDAO) error
+	// Retorna quantos pedidos foram removidos
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error)
+	FindStatusHistory(ctx context.Context, orderID string) ([]daos.OrderStatusHistoryDAO, error)
+}
+
+type ICouponDataSource interface {
+	Create(ctx context.Context, coupon daos.CouponDAO) error
+	// Nil quando o cupom não existe
+	FindByCode(ctx context.Context, code string) (*daos.CouponDAO, error)
 }
 
 type IOrderStatusDataSource interface {
-	FindByID(id string) (daos.OrderStatusDAO, error)
-	FindByName(name string) (daos.OrderStatusDAO, error)
-	FindAll() ([]daos.OrderStatusDAO, error)
+	FindByID(ctx context.Context, id string) (daos.OrderStatusDAO, error)
+	FindByName(ctx context.Context, name string) (daos.OrderStatusDAO, error)
+	FindAll(ctx context.Context) ([]daos.OrderStatusDAO, error)
+}
+
+type IOutboxDataSource interface {
+	// Reserva até lockedUntil as mensagens de até limit pedidos, pulando os reservados
+	// por outro relay, na ordem de inserção
+	ClaimPending(ctx context.Context, limit int, now time.Time, lockedUntil time.Time) ([]daos.OutboxMessageDAO, error)
+	MarkSent(ctx context.Context, id string, sentAt time.Time) error
+	// Libera a mensagem para nova tentativa em nextAttemptAt
+	MarkFailed(ctx context.Context, id string, lastError string, nextAttemptAt time.Time) error
+	// Desiste da mensagem, que deixa de segurar as seguintes do pedido
+	MarkDead(ctx context.Context, id string, lastError string, deadAt time.Time) error
+}
+
+type IIdempotencyDataSource interface {
+	// Nil quando a chave nunca foi usada no escopo
+	FindByKey(ctx context.Context, scope string, key string) (*daos.IdempotencyKeyDAO, error)
+	// Retorna false se a chave já está em uso no escopo; chaves expiradas ou em
+	// andamento desde antes de staleBefore são retomadas
+	Reserve(ctx context.Context, record daos.IdempotencyKeyDAO, staleBefore time.Time) (bool, error)
+	Complete(ctx context.Context, scope string, key string, statusCode int, responseBody []byte, completedAt time.Time) error
+	Release(ctx context.Context, scope string, key string) error
+	// Retorna quantas chaves foram removidas
+	PurgeExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type IProcessedMessageDataSource interface {
+	Exists(ctx context.Context, consumer string, messageID string) (bool, error)
+	// Nil quando nenhuma mensagem com horário foi processada para o pedido
+	FindLatestOccurredAt(ctx context.Context, consumer string, orderID string) (*time.Time, error)
+}
+
+type IProductCatalogDataSource interface {
+	// Nil quando o produto não existe no catálogo
+	FindByID(ctx context.Context, productID string) (*daos.ProductDAO, error)
 }
@@ -1,7 +1,9 @@
 package interfaces
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -14,46 +16,101 @@ type MockOrderDataSource struct {
 	mock.Mock
 }
 
-func (m *MockOrderDataSource) Create(order daos.OrderDAO) error {
+func (m *MockOrderDataSource) Create(ctx context.Context, order daos.OrderDAO, outbox ...daos.OutboxMessageDAO) error {
 	args := m.Called(order)
 	return args.Error(0)
 }
 
-func (m *MockOrderDataSource) FindAll(filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error) {
+func (m *MockOrderDataSource) FindAll(ctx context.Context, filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error) {
 	args := m.Called(filter)
 	return args.Get(0).([]daos.OrderDAO), args.Error(1)
 }
 
-func (m *MockOrderDataSource) FindByID(id string) (daos.OrderDAO, error) {
+func (m *MockOrderDataSource) Count(ctx context.Context, filter dtos.OrderFilterDTO) (int64, error) {
+	args := m.Called(filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockOrderDataSource) FindByID(ctx context.Context, id string) (daos.OrderDAO, error) {
 	args := m.Called(id)
 	return args.Get(0).(daos.OrderDAO), args.Error(1)
 }
 
-func (m *MockOrderDataSource) Update(order daos.OrderDAO) error {
+func (m *MockOrderDataSource) Update(ctx context.Context, order daos.OrderDAO, outbox ...daos.OutboxMessageDAO) error {
+	args := m.Called(order)
+	return args.Error(0)
+}
+
+func (m *MockOrderDataSource) UpdateFromMessage(ctx context.Context, order daos.OrderDAO, message daos.ProcessedMessageDAO, outbox ...daos.OutboxMessageDAO) error {
+	args := m.Called(order, message)
+	return args.Error(0)
+}
+
+func (m *MockOrderDataSource) ApplyCoupon(ctx context.Context, order daos.OrderDAO, outbox ...daos.OutboxMessageDAO) error {
 	args := m.Called(order)
 	return args.Error(0)
 }
 
-func (m *MockOrderDataSource) Delete(id string) error {
+func (m *MockOrderDataSource) Delete(ctx context.Context, id string, outbox ...daos.OutboxMessageDAO) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
+func (m *MockOrderDataSource) FindByPickupCode(ctx context.Context, day string, code string) (daos.OrderDAO, error) {
+	args := m.Called(day, code)
+	return args.Get(0).(daos.OrderDAO), args.Error(1)
+}
+
+func (m *MockOrderDataSource) FindBoard(ctx context.Context, statusIDs []string, createdFrom time.Time) ([]daos.OrderBoardEntryDAO, error) {
+	args := m.Called(statusIDs, createdFrom)
+	return args.Get(0).([]daos.OrderBoardEntryDAO), args.Error(1)
+}
+
+func (m *MockOrderDataSource) NextPickupSequence(ctx context.Context, day string) (int, error) {
+	args := m.Called(day)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockOrderDataSource) Restore(ctx context.Context, id string, outbox ...daos.OutboxMessageDAO) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockOrderDataSource) Claim(ctx context.Context, orderID string, customerID string, guestTokenHash string, claimedAt time.Time) error {
+	args := m.Called(orderID, customerID, guestTokenHash, claimedAt)
+	return args.Error(0)
+}
+
+func (m *MockOrderDataSource) ReissueGuestToken(ctx context.Context, orderID string, guestTokenHash string) error {
+	args := m.Called(orderID, guestTokenHash)
+	return args.Error(0)
+}
+
+func (m *MockOrderDataSource) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	args := m.Called(deletedBefore, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockOrderDataSource) FindStatusHistory(ctx context.Context, orderID string) ([]daos.OrderStatusHistoryDAO, error) {
+	args := m.Called(orderID)
+	return args.Get(0).([]daos.OrderStatusHistoryDAO), args.Error(1)
+}
+
 type MockOrderStatusDataSource struct {
 	mock.Mock
 }
 
-func (m *MockOrderStatusDataSource) FindByID(id string) (daos.OrderStatusDAO, error) {
+func (m *MockOrderStatusDataSource) FindByID(ctx context.Context, id string) (daos.OrderStatusDAO, error) {
 	args := m.Called(id)
 	return args.Get(0).(daos.OrderStatusDAO), args.Error(1)
 }
 
-func (m *MockOrderStatusDataSource) FindByName(name string) (daos.OrderStatusDAO, error) {
+func (m *MockOrderStatusDataSource) FindByName(ctx context.Context, name string) (daos.OrderStatusDAO, error) {
 	args := m.Called(name)
 	return args.Get(0).(daos.OrderStatusDAO), args.Error(1)
 }
 
-func (m *MockOrderStatusDataSource) FindAll() ([]daos.OrderStatusDAO, error) {
+func (m *MockOrderStatusDataSource) FindAll(ctx context.Context) ([]daos.OrderStatusDAO, error) {
 	args := m.Called()
 	return args.Get(0).([]daos.OrderStatusDAO), args.Error(1)
 }
@@ -66,7 +123,7 @@ func TestIOrderDataSource_Interface(t *testing.T) {
 
 	order := daos.OrderDAO{ID: "test-order"}
 	mockDS.On("Create", order).Return(nil)
-	err := mockDS.Create(order)
+	err := mockDS.Create(context.Background(), order)
 	assert.NoError(t, err)
 	mockDS.AssertExpectations(t)
 
@@ -74,7 +131,7 @@ func TestIOrderDataSource_Interface(t *testing.T) {
 	filter := dtos.OrderFilterDTO{}
 	expectedOrders := []daos.OrderDAO{{ID: "order1"}, {ID: "order2"}}
 	mockDS2.On("FindAll", filter).Return(expectedOrders, nil)
-	orders, err := mockDS2.FindAll(filter)
+	orders, err := mockDS2.FindAll(context.Background(), filter)
 	assert.NoError(t, err)
 	assert.Len(t, orders, 2)
 	mockDS2.AssertExpectations(t)
@@ -82,7 +139,7 @@ func TestIOrderDataSource_Interface(t *testing.T) {
 	mockDS3 := &MockOrderDataSource{}
 	expectedOrder := daos.OrderDAO{ID: "test-order"}
 	mockDS3.On("FindByID", "test-order").Return(expectedOrder, nil)
-	foundOrder, err := mockDS3.FindByID("test-order")
+	foundOrder, err := mockDS3.FindByID(context.Background(), "test-order")
 	assert.NoError(t, err)
 	assert.Equal(t, "test-order", foundOrder.ID)
 	mockDS3.AssertExpectations(t)
@@ -90,13 +147,13 @@ func TestIOrderDataSource_Interface(t *testing.T) {
 	mockDS4 := &MockOrderDataSource{}
 	updateOrder := daos.OrderDAO{ID: "update-order"}
 	mockDS4.On("Update", updateOrder).Return(nil)
-	err = mockDS4.Update(updateOrder)
+	err = mockDS4.Update(context.Background(), updateOrder)
 	assert.NoError(t, err)
 	mockDS4.AssertExpectations(t)
 
 	mockDS5 := &MockOrderDataSource{}
 	mockDS5.On("Delete", "delete-order").Return(nil)
-	err = mockDS5.Delete("delete-order")
+	err = mockDS5.Delete(context.Background(), "delete-order")
 	assert.NoError(t, err)
 	mockDS5.AssertExpectations(t)
 }
@@ -109,7 +166,7 @@ func TestIOrderStatusDataSource_Interface(t *testing.T) {
 
 	expectedStatus := daos.OrderStatusDAO{ID: "status-1", Name: "pending"}
 	mockSDS.On("FindByID", "status-1").Return(expectedStatus, nil)
-	status, err := mockSDS.FindByID("status-1")
+	status, err := mockSDS.FindByID(context.Background(), "status-1")
 	assert.NoError(t, err)
 	assert.Equal(t, "status-1", status.ID)
 	assert.Equal(t, "pending", status.Name)
@@ -121,7 +178,7 @@ func TestIOrderStatusDataSource_Interface(t *testing.T) {
 		{ID: "status-2", Name: "confirmed"},
 	}
 	mockSDS2.On("FindAll").Return(expectedStatuses, nil)
-	statuses, err := mockSDS2.FindAll()
+	statuses, err := mockSDS2.FindAll(context.Background())
 	assert.NoError(t, err)
 	assert.Len(t, statuses, 2)
 	assert.Equal(t, "pending", statuses[0].Name)
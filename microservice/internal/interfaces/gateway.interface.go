@@ -1,20 +1,46 @@
 package interfaces
 
 import (
+	"context"
+	"time"
+
+	"microservice/internal/adapters/brokers"
 	"microservice/internal/adapters/dtos"
 	"microservice/internal/domain/entities"
+	"microservice/internal/domain/value_objects"
 )
 
 type IOrderGateway interface {
-	Create(order entities.Order) error
-	FindByID(id string) (*entities.Order, error)
-	FindAll(filter dtos.OrderFilterDTO) ([]entities.Order, error)
-	Update(order entities.Order) error
-	Delete(id string) error
+	Create(ctx context.Context, order entities.Order, events ...brokers.OrderEvent) error
+	FindByID(ctx context.Context, id string) (*entities.Order, error)
+	FindByPickupCode(ctx context.Context, day string, code value_objects.PickupCode) (*entities.Order, error)
+	NextPickupCode(ctx context.Context, day string) (value_objects.PickupCode, error)
+	FindAll(ctx context.Context, filter dtos.OrderFilterDTO) ([]entities.Order, error)
+	FindBoard(ctx context.Context, statuses []entities.OrderStatus, createdFrom time.Time) ([]entities.OrderBoardEntry, error)
+	Count(ctx context.Context, filter dtos.OrderFilterDTO) (int64, error)
+	Update(ctx context.Context, order entities.Order, events ...brokers.OrderEvent) error
+	// Na mesma transação; falha se a mensagem já foi registrada
+	UpdateFromMessage(ctx context.Context, order entities.Order, message dtos.ProcessedMessageDTO, events ...brokers.OrderEvent) error
+	ApplyCoupon(ctx context.Context, order entities.Order, events ...brokers.OrderEvent) error
+	Claim(ctx context.Context, orderID string, customerID string, guestTokenHash string, claimedAt time.Time) error
+	ReissueGuestToken(ctx context.Context, orderID string, guestTokenHash string) error
+	Delete(ctx context.Context, id string, events ...brokers.OrderEvent) error
+	Restore(ctx context.Context, id string, events ...brokers.OrderEvent) error
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error)
+	FindStatusHistory(ctx context.Context, orderID string) ([]entities.OrderStatusChange, error)
 }
 
 type IOrderStatusGateway interface {
-	FindAll() ([]entities.OrderStatus, error)
-	FindByID(id string) (*entities.OrderStatus, error)
-	FindByName(name string) (*entities.OrderStatus, error)
+	FindAll(ctx context.Context) ([]entities.OrderStatus, error)
+	FindByID(ctx context.Context, id string) (*entities.OrderStatus, error)
+	FindByName(ctx context.Context, name string) (*entities.OrderStatus, error)
+}
+
+type ICouponGateway interface {
+	Create(ctx context.Context, coupon entities.Coupon) error
+	FindByCode(ctx context.Context, code string) (*entities.Coupon, error)
+}
+
+type IProductCatalogGateway interface {
+	FindByID(ctx context.Context, productID string) (*entities.Product, error)
 }
@@ -0,0 +1,76 @@
+package use_cases
+
+import (
+	"context"
+	"time"
+
+	"microservice/internal/adapters/dtos"
+	"microservice/internal/domain/entities"
+	"microservice/internal/domain/exceptions"
+	"microservice/internal/interfaces"
+)
+
+type ApplyCouponUseCase struct {
+	orderGateway  interfaces.IOrderGateway
+	couponGateway interfaces.ICouponGateway
+}
+
+func NewApplyCouponUseCase(orderGateway interfaces.IOrderGateway, couponGateway interfaces.ICouponGateway) *ApplyCouponUseCase {
+	return &ApplyCouponUseCase{
+		orderGateway:  orderGateway,
+		couponGateway: couponGateway,
+	}
+}
+
+func (uc *ApplyCouponUseCase) Execute(ctx context.Context, dto dtos.ApplyCouponDTO) (entities.Order, error) {
+	err := entities.ValidateID(dto.OrderID)
+	if err != nil {
+		return entities.Order{}, err
+	}
+
+	order, err := uc.orderGateway.FindByID(ctx, dto.OrderID)
+	if err != nil {
+		return entities.Order{}, &exceptions.OrderNotFoundException{}
+	}
+
+	coupon, err := uc.couponGateway.FindByCode(ctx, dto.Code)
+	if err != nil {
+		return entities.Order{}, err
+	}
+
+	isFirstOrder, err := uc.isFirstOrder(ctx, *order)
+	if err != nil {
+		return entities.Order{}, err
+	}
+
+	if err := order.ApplyCoupon(*coupon, time.Now(), isFirstOrder); err != nil {
+		return entities.Order{}, err
+	}
+
+	event, err := newOrderCouponAppliedEvent(ctx, *order)
+	if err != nil {
+		return entities.Order{}, err
+	}
+
+	if err := uc.orderGateway.ApplyCoupon(ctx, *order, event); err != nil {
+		return entities.Order{}, err
+	}
+
+	return *order, nil
+}
+
+// Pedidos cancelados ou que falharam não contam; pedidos sem cliente nunca são o primeiro
+func (uc *ApplyCouponUseCase) isFirstOrder(ctx context.Context, order entities.Order) (bool, error) {
+	if order.CustomerID == nil {
+		return false, nil
+	}
+
+	total, err := uc.orderGateway.Count(ctx, dtos.OrderFilterDTO{
+		CustomerID:       order.CustomerID,
+		ExcludedStatuses: []string{entities.ORDER_STATUS_CANCELLED, entities.ORDER_STATUS_FAILED},
+	})
+	if err != nil {
+		return false, err
+	}
+	return total <= 1, nil
+}
@@ -0,0 +1,161 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"microservice/internal/adapters/dtos"
+	"microservice/internal/domain/entities"
+	"microservice/internal/domain/exceptions"
+)
+
+func newCouponTestOrder(t *testing.T, id string, customerID *string) *entities.Order {
+	t.Helper()
+	received, _ := entities.NewOrderStatus("status-1", entities.ORDER_STATUS_RECEIVED)
+	order, _ := entities.NewOrder(id, customerID)
+	order.Status = *received
+	order.CreatedAt = time.Now()
+	item, _ := entities.NewOrderItem("item-"+id, "product-1", id, 2, brl("10.00"), "", nil)
+	order.AddItem(*item)
+	if err := order.CalcTotalAmount(); err != nil {
+		t.Fatalf("CalcTotalAmount() unexpected error: %v", err)
+	}
+	return order
+}
+
+func TestApplyCouponUseCase_Execute_Success(t *testing.T) {
+	orderGateway := NewMockOrderGateway()
+	order := newCouponTestOrder(t, "550e8400-e29b-41d4-a716-446655440000", nil)
+	orderGateway.AddOrder(order)
+	couponGateway := NewMockCouponGateway()
+	couponGateway.AddCoupon(entities.Coupon{Code: "PROMO10", Type: entities.COUPON_TYPE_PERCENTAGE, Percentage: 10})
+
+	result, err := NewApplyCouponUseCase(orderGateway, couponGateway).Execute(context.Background(), dtos.ApplyCouponDTO{
+		OrderID: order.ID,
+		Code:    "promo10",
+	})
+
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if result.Subtotal.String() != "20.00" || result.Discount.String() != "2.00" || result.Amount.Value().String() != "18.00" {
+		t.Errorf("Execute() subtotal/discount/amount = %v/%v/%v, want 20.00/2.00/18.00", result.Subtotal, result.Discount, result.Amount.Value())
+	}
+	if result.CouponCode == nil || *result.CouponCode != "PROMO10" {
+		t.Errorf("Execute() CouponCode = %v, want PROMO10", result.CouponCode)
+	}
+}
+
+func TestApplyCouponUseCase_Execute_FirstOrderOnly(t *testing.T) {
+	customerID := "customer-1"
+	coupon := entities.Coupon{Code: "BEMVINDO", Type: entities.COUPON_TYPE_FIXED_AMOUNT, FixedAmount: brl("5.00"), FirstOrderOnly: true}
+
+	t.Run("first order", func(t *testing.T) {
+		orderGateway := NewMockOrderGateway()
+		order := newCouponTestOrder(t, "550e8400-e29b-41d4-a716-446655440000", &customerID)
+		orderGateway.AddOrder(order)
+		couponGateway := NewMockCouponGateway()
+		couponGateway.AddCoupon(coupon)
+
+		result, err := NewApplyCouponUseCase(orderGateway, couponGateway).Execute(context.Background(), dtos.ApplyCouponDTO{OrderID: order.ID, Code: "BEMVINDO"})
+		if err != nil {
+			t.Fatalf("Execute() unexpected error: %v", err)
+		}
+		if result.Amount.Value().String() != "15.00" {
+			t.Errorf("Execute() Amount = %v, want 15.00", result.Amount.Value())
+		}
+	})
+
+	t.Run("returning customer", func(t *testing.T) {
+		orderGateway := NewMockOrderGateway()
+		order := newCouponTestOrder(t, "550e8400-e29b-41d4-a716-446655440000", &customerID)
+		orderGateway.AddOrder(order)
+		orderGateway.AddOrder(newCouponTestOrder(t, "660e8400-e29b-41d4-a716-446655440000", &customerID))
+		couponGateway := NewMockCouponGateway()
+		couponGateway.AddCoupon(coupon)
+
+		_, err := NewApplyCouponUseCase(orderGateway, couponGateway).Execute(context.Background(), dtos.ApplyCouponDTO{OrderID: order.ID, Code: "BEMVINDO"})
+		if _, ok := err.(*exceptions.CouponNotApplicableException); !ok {
+			t.Errorf("Execute() error = %T, want *exceptions.CouponNotApplicableException", err)
+		}
+	})
+
+	t.Run("previous orders cancelled or failed", func(t *testing.T) {
+		orderGateway := NewMockOrderGateway()
+		order := newCouponTestOrder(t, "550e8400-e29b-41d4-a716-446655440000", &customerID)
+		orderGateway.AddOrder(order)
+		cancelled, _ := entities.NewOrderStatus("status-6", entities.ORDER_STATUS_CANCELLED)
+		failed, _ := entities.NewOrderStatus("status-7", entities.ORDER_STATUS_FAILED)
+		cancelledOrder := newCouponTestOrder(t, "660e8400-e29b-41d4-a716-446655440000", &customerID)
+		cancelledOrder.Status = *cancelled
+		failedOrder := newCouponTestOrder(t, "770e8400-e29b-41d4-a716-446655440000", &customerID)
+		failedOrder.Status = *failed
+		orderGateway.AddOrder(cancelledOrder)
+		orderGateway.AddOrder(failedOrder)
+		couponGateway := NewMockCouponGateway()
+		couponGateway.AddCoupon(coupon)
+
+		result, err := NewApplyCouponUseCase(orderGateway, couponGateway).Execute(context.Background(), dtos.ApplyCouponDTO{OrderID: order.ID, Code: "BEMVINDO"})
+		if err != nil {
+			t.Fatalf("Execute() unexpected error: %v", err)
+		}
+		if result.Amount.Value().String() != "15.00" {
+			t.Errorf("Execute() Amount = %v, want 15.00", result.Amount.Value())
+		}
+	})
+
+	t.Run("order without customer", func(t *testing.T) {
+		orderGateway := NewMockOrderGateway()
+		order := newCouponTestOrder(t, "550e8400-e29b-41d4-a716-446655440000", nil)
+		orderGateway.AddOrder(order)
+		couponGateway := NewMockCouponGateway()
+		couponGateway.AddCoupon(coupon)
+
+		_, err := NewApplyCouponUseCase(orderGateway, couponGateway).Execute(context.Background(), dtos.ApplyCouponDTO{OrderID: order.ID, Code: "BEMVINDO"})
+		if _, ok := err.(*exceptions.CouponNotApplicableException); !ok {
+			t.Errorf("Execute() error = %T, want *exceptions.CouponNotApplicableException", err)
+		}
+	})
+}
+
+func TestApplyCouponUseCase_Execute_Errors(t *testing.T) {
+	orderID := "550e8400-e29b-41d4-a716-446655440000"
+
+	t.Run("invalid order id", func(t *testing.T) {
+		_, err := NewApplyCouponUseCase(NewMockOrderGateway(), NewMockCouponGateway()).Execute(context.Background(), dtos.ApplyCouponDTO{OrderID: "invalid", Code: "PROMO10"})
+		if err == nil {
+			t.Error("Execute() expected error for invalid order id")
+		}
+	})
+
+	t.Run("order not found", func(t *testing.T) {
+		_, err := NewApplyCouponUseCase(NewMockOrderGateway(), NewMockCouponGateway()).Execute(context.Background(), dtos.ApplyCouponDTO{OrderID: orderID, Code: "PROMO10"})
+		if _, ok := err.(*exceptions.OrderNotFoundException); !ok {
+			t.Errorf("Execute() error = %T, want *exceptions.OrderNotFoundException", err)
+		}
+	})
+
+	t.Run("coupon not found", func(t *testing.T) {
+		orderGateway := NewMockOrderGateway()
+		orderGateway.AddOrder(newCouponTestOrder(t, orderID, nil))
+
+		_, err := NewApplyCouponUseCase(orderGateway, NewMockCouponGateway()).Execute(context.Background(), dtos.ApplyCouponDTO{OrderID: orderID, Code: "PROMO10"})
+		if _, ok := err.(*exceptions.CouponNotFoundException); !ok {
+			t.Errorf("Execute() error = %T, want *exceptions.CouponNotFoundException", err)
+		}
+	})
+
+	t.Run("usage limit reached while saving", func(t *testing.T) {
+		orderGateway := NewMockOrderGateway()
+		orderGateway.AddOrder(newCouponTestOrder(t, orderID, nil))
+		orderGateway.shouldFailApplyCoupon = true
+		couponGateway := NewMockCouponGateway()
+		couponGateway.AddCoupon(entities.Coupon{Code: "PROMO10", Type: entities.COUPON_TYPE_PERCENTAGE, Percentage: 10, MaxUses: 1})
+
+		_, err := NewApplyCouponUseCase(orderGateway, couponGateway).Execute(context.Background(), dtos.ApplyCouponDTO{OrderID: orderID, Code: "PROMO10"})
+		if _, ok := err.(*exceptions.CouponUsageLimitReachedException); !ok {
+			t.Errorf("Execute() error = %T, want *exceptions.CouponUsageLimitReachedException", err)
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package use_cases
+
+import (
+	"context"
+	"time"
+
+	"microservice/internal/adapters/dtos"
+	"microservice/internal/domain/entities"
+	"microservice/internal/domain/exceptions"
+	"microservice/internal/interfaces"
+)
+
+type CancelOrderUseCase struct {
+	orderGateway       interfaces.IOrderGateway
+	orderStatusGateway interfaces.IOrderStatusGateway
+}
+
+func NewCancelOrderUseCase(orderGateway interfaces.IOrderGateway, orderStatusGateway interfaces.IOrderStatusGateway) *CancelOrderUseCase {
+	return &CancelOrderUseCase{
+		orderGateway:       orderGateway,
+		orderStatusGateway: orderStatusGateway,
+	}
+}
+
+func (uc *CancelOrderUseCase) Execute(ctx context.Context, dto dtos.CancelOrderDTO) (entities.Order, error) {
+	err := entities.ValidateID(dto.OrderID)
+	if err != nil {
+		return entities.Order{}, err
+	}
+
+	order, err := uc.orderGateway.FindByID(ctx, dto.OrderID)
+	if err != nil {
+		return entities.Order{}, &exceptions.OrderNotFoundException{}
+	}
+
+	cancelled, err := uc.orderStatusGateway.FindByName(ctx, entities.ORDER_STATUS_CANCELLED)
+	if err != nil {
+		return entities.Order{}, &exceptions.OrderStatusNotFoundException{}
+	}
+
+	previousStatus := order.Status
+	if err := order.Cancel(*cancelled, dto.Reason, entities.STATUS_CHANGE_SOURCE_REST, dto.Actor, time.Now()); err != nil {
+		return entities.Order{}, err
+	}
+
+	statusChanged, err := newOrderStatusChangedEvent(ctx, *order, previousStatus)
+	if err != nil {
+		return entities.Order{}, err
+	}
+	orderCancelled, err := newOrderCancelledEvent(ctx, *order, previousStatus)
+	if err != nil {
+		return entities.Order{}, err
+	}
+
+	if err := uc.orderGateway.Update(ctx, *order, statusChanged, orderCancelled); err != nil {
+		return entities.Order{}, err
+	}
+
+	return *order, nil
+}
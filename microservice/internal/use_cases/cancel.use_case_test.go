@@ -0,0 +1,140 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+
+	"microservice/internal/adapters/dtos"
+	"microservice/internal/domain/entities"
+	"microservice/internal/domain/exceptions"
+)
+
+func newCancelTestGateways(t *testing.T, statusName string) (*MockOrderGateway, *MockOrderStatusGateway, *entities.Order) {
+	t.Helper()
+	orderGateway := NewMockOrderGateway()
+	order := newCouponTestOrder(t, "550e8400-e29b-41d4-a716-446655440000", nil)
+	status, _ := entities.NewOrderStatus("status-current", statusName)
+	order.Status = *status
+	orderGateway.AddOrder(order)
+
+	statusGateway := NewMockOrderStatusGateway()
+	cancelled, _ := entities.NewOrderStatus("status-cancelled", entities.ORDER_STATUS_CANCELLED)
+	statusGateway.AddStatus(cancelled)
+	return orderGateway, statusGateway, order
+}
+
+func TestCancelOrderUseCase_Execute_Success(t *testing.T) {
+	for _, statusName := range []string{entities.ORDER_STATUS_RECEIVED, entities.ORDER_STATUS_CONFIRMED, entities.ORDER_STATUS_PREPARING} {
+		t.Run(statusName, func(t *testing.T) {
+			orderGateway, statusGateway, order := newCancelTestGateways(t, statusName)
+
+			result, err := NewCancelOrderUseCase(orderGateway, statusGateway).Execute(context.Background(), dtos.CancelOrderDTO{
+				OrderID: order.ID,
+				Reason:  "  Cliente desistiu  ",
+			})
+
+			if err != nil {
+				t.Fatalf("Execute() unexpected error: %v", err)
+			}
+			if result.Status.Name.Value() != entities.ORDER_STATUS_CANCELLED {
+				t.Errorf("Execute() status = %v, want %v", result.Status.Name.Value(), entities.ORDER_STATUS_CANCELLED)
+			}
+			if result.CancellationReason == nil || *result.CancellationReason != "Cliente desistiu" {
+				t.Errorf("Execute() CancellationReason = %v, want Cliente desistiu", result.CancellationReason)
+			}
+			if result.CancelledAt == nil {
+				t.Error("Execute() CancelledAt should be set")
+			}
+
+			stored, _ := orderGateway.FindByID(context.Background(), order.ID)
+			if stored.Status.Name.Value() != entities.ORDER_STATUS_CANCELLED {
+				t.Errorf("stored status = %v, want %v", stored.Status.Name.Value(), entities.ORDER_STATUS_CANCELLED)
+			}
+		})
+	}
+}
+
+func TestCancelOrderUseCase_Execute_RecordsActor(t *testing.T) {
+	orderGateway, statusGateway, order := newCancelTestGateways(t, entities.ORDER_STATUS_RECEIVED)
+	actor := "staff-1"
+
+	result, err := NewCancelOrderUseCase(orderGateway, statusGateway).Execute(context.Background(), dtos.CancelOrderDTO{
+		OrderID: order.ID,
+		Reason:  "Cliente desistiu",
+		Actor:   &actor,
+	})
+
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	last := result.StatusChanges[len(result.StatusChanges)-1]
+	if last.Actor == nil || *last.Actor != "staff-1" {
+		t.Errorf("Execute() history actor = %v, want staff-1", last.Actor)
+	}
+}
+
+func TestCancelOrderUseCase_Execute_NotAllowedFromStatus(t *testing.T) {
+	for _, statusName := range []string{entities.ORDER_STATUS_READY, entities.ORDER_STATUS_DELIVERED, entities.ORDER_STATUS_CANCELLED, entities.ORDER_STATUS_FAILED} {
+		t.Run(statusName, func(t *testing.T) {
+			orderGateway, statusGateway, order := newCancelTestGateways(t, statusName)
+
+			_, err := NewCancelOrderUseCase(orderGateway, statusGateway).Execute(context.Background(), dtos.CancelOrderDTO{
+				OrderID: order.ID,
+				Reason:  "Cliente desistiu",
+			})
+
+			if _, ok := err.(*exceptions.InvalidStatusTransitionException); !ok {
+				t.Errorf("Execute() error = %T, want *exceptions.InvalidStatusTransitionException", err)
+			}
+		})
+	}
+}
+
+func TestCancelOrderUseCase_Execute_Errors(t *testing.T) {
+	t.Run("invalid id", func(t *testing.T) {
+		orderGateway, statusGateway, _ := newCancelTestGateways(t, entities.ORDER_STATUS_RECEIVED)
+		_, err := NewCancelOrderUseCase(orderGateway, statusGateway).Execute(context.Background(), dtos.CancelOrderDTO{OrderID: "invalid", Reason: "x"})
+		if _, ok := err.(*exceptions.InvalidOrderDataException); !ok {
+			t.Errorf("Execute() error = %T, want *exceptions.InvalidOrderDataException", err)
+		}
+	})
+
+	t.Run("order not found", func(t *testing.T) {
+		_, statusGateway, _ := newCancelTestGateways(t, entities.ORDER_STATUS_RECEIVED)
+		_, err := NewCancelOrderUseCase(NewMockOrderGateway(), statusGateway).Execute(context.Background(), dtos.CancelOrderDTO{
+			OrderID: "550e8400-e29b-41d4-a716-446655440000",
+			Reason:  "Cliente desistiu",
+		})
+		if _, ok := err.(*exceptions.OrderNotFoundException); !ok {
+			t.Errorf("Execute() error = %T, want *exceptions.OrderNotFoundException", err)
+		}
+	})
+
+	t.Run("cancelled status missing", func(t *testing.T) {
+		orderGateway, _, order := newCancelTestGateways(t, entities.ORDER_STATUS_RECEIVED)
+		_, err := NewCancelOrderUseCase(orderGateway, NewMockOrderStatusGateway()).Execute(context.Background(), dtos.CancelOrderDTO{
+			OrderID: order.ID,
+			Reason:  "Cliente desistiu",
+		})
+		if _, ok := err.(*exceptions.OrderStatusNotFoundException); !ok {
+			t.Errorf("Execute() error = %T, want *exceptions.OrderStatusNotFoundException", err)
+		}
+	})
+
+	t.Run("missing reason", func(t *testing.T) {
+		orderGateway, statusGateway, order := newCancelTestGateways(t, entities.ORDER_STATUS_RECEIVED)
+		_, err := NewCancelOrderUseCase(orderGateway, statusGateway).Execute(context.Background(), dtos.CancelOrderDTO{OrderID: order.ID, Reason: "   "})
+		if _, ok := err.(*exceptions.InvalidOrderDataException); !ok {
+			t.Errorf("Execute() error = %T, want *exceptions.InvalidOrderDataException", err)
+		}
+	})
+
+	t.Run("update fails", func(t *testing.T) {
+		orderGateway, statusGateway, order := newCancelTestGateways(t, entities.ORDER_STATUS_RECEIVED)
+		orderGateway.SetShouldFailUpdate(true)
+		_, err := NewCancelOrderUseCase(orderGateway, statusGateway).Execute(context.Background(), dtos.CancelOrderDTO{OrderID: order.ID, Reason: "Cliente desistiu"})
+		if err == nil {
+			t.Error("Execute() expected error, got nil")
+		}
+	})
+}
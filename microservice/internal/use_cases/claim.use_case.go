@@ -0,0 +1,53 @@
+package use_cases
+
+import (
+	"context"
+	"time"
+
+	"microservice/internal/adapters/dtos"
+	"microservice/internal/domain/entities"
+	"microservice/internal/domain/exceptions"
+	"microservice/internal/interfaces"
+)
+
+type ClaimOrderUseCase struct {
+	orderGateway interfaces.IOrderGateway
+	now          func() time.Time
+}
+
+func NewClaimOrderUseCase(orderGateway interfaces.IOrderGateway) *ClaimOrderUseCase {
+	return &ClaimOrderUseCase{
+		orderGateway: orderGateway,
+		now:          time.Now,
+	}
+}
+
+// Reivindicar de novo um pedido que já é do cliente o retorna sem alterações
+func (uc *ClaimOrderUseCase) Execute(ctx context.Context, dto dtos.ClaimOrderDTO) (entities.Order, error) {
+	err := entities.ValidateID(dto.OrderID)
+	if err != nil {
+		return entities.Order{}, err
+	}
+
+	order, err := uc.orderGateway.FindByID(ctx, dto.OrderID)
+	if err != nil {
+		return entities.Order{}, &exceptions.OrderNotFoundException{}
+	}
+	if order.IsOwnedBy(dto.CustomerID) {
+		return *order, nil
+	}
+	if !order.HasGuestToken(dto.GuestToken) {
+		return entities.Order{}, &exceptions.OrderNotFoundException{}
+	}
+
+	guestTokenHash := *order.GuestTokenHash
+	if err := order.Claim(dto.CustomerID, uc.now()); err != nil {
+		return entities.Order{}, err
+	}
+
+	if err := uc.orderGateway.Claim(ctx, order.ID, dto.CustomerID, guestTokenHash, *order.UpdatedAt); err != nil {
+		return entities.Order{}, err
+	}
+
+	return *order, nil
+}
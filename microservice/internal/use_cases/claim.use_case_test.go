@@ -0,0 +1,111 @@
+package use_cases
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"microservice/internal/adapters/dtos"
+	"microservice/internal/domain/entities"
+	"microservice/internal/domain/exceptions"
+)
+
+func createGuestOrder(t *testing.T) (entities.Order, *MockOrderGateway) {
+	t.Helper()
+
+	createUC, orderGateway := newPricingTestUseCase(NewMockProductCatalogGateway())
+	created, err := createUC.Execute(context.Background(), dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{{ProductID: "product-1", Quantity: 1}}})
+	if err != nil {
+		t.Fatalf("Create unexpected error: %v", err)
+	}
+	return created, orderGateway
+}
+
+func TestClaimOrderUseCase_Execute_Success(t *testing.T) {
+	created, orderGateway := createGuestOrder(t)
+	claimedAt := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	uc := NewClaimOrderUseCase(orderGateway)
+	uc.now = func() time.Time { return claimedAt }
+
+	order, err := uc.Execute(context.Background(), dtos.ClaimOrderDTO{OrderID: created.ID, GuestToken: created.GuestToken, CustomerID: "customer-123"})
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if !order.IsOwnedBy("customer-123") {
+		t.Errorf("Execute() customer = %v, want customer-123", order.CustomerID)
+	}
+	if order.UpdatedAt == nil || !order.UpdatedAt.Equal(claimedAt) {
+		t.Errorf("Execute() UpdatedAt = %v, want %v", order.UpdatedAt, claimedAt)
+	}
+
+	stored, _ := orderGateway.FindByID(context.Background(), created.ID)
+	if !stored.IsOwnedBy("customer-123") || stored.GuestTokenHash != nil {
+		t.Errorf("stored order customer = %v, guest token hash = %v", stored.CustomerID, stored.GuestTokenHash)
+	}
+
+	// O token deixa de valer para leitura depois de reivindicado
+	if _, err := NewFindGuestOrderUseCase(orderGateway).Execute(context.Background(), created.ID, created.GuestToken); err == nil {
+		t.Error("FindGuestOrder accepted the token of a claimed order")
+	}
+}
+
+func TestClaimOrderUseCase_Execute_AlreadyOwned(t *testing.T) {
+	created, orderGateway := createGuestOrder(t)
+	uc := NewClaimOrderUseCase(orderGateway)
+	dto := dtos.ClaimOrderDTO{OrderID: created.ID, GuestToken: created.GuestToken, CustomerID: "customer-123"}
+
+	if _, err := uc.Execute(context.Background(), dto); err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	// Repetir a chamada (ex: retry do app) devolve o pedido sem erro
+	order, err := uc.Execute(context.Background(), dto)
+	if err != nil {
+		t.Fatalf("Execute() retry unexpected error: %v", err)
+	}
+	if !order.IsOwnedBy("customer-123") {
+		t.Errorf("Execute() retry customer = %v, want customer-123", order.CustomerID)
+	}
+
+	dto.CustomerID = "customer-456"
+	if _, err := uc.Execute(context.Background(), dto); err == nil {
+		t.Error("Execute() let another customer claim an order already claimed")
+	}
+}
+
+func TestClaimOrderUseCase_Execute_Errors(t *testing.T) {
+	created, orderGateway := createGuestOrder(t)
+	uc := NewClaimOrderUseCase(orderGateway)
+
+	tests := []struct {
+		name    string
+		dto     dtos.ClaimOrderDTO
+		wantErr error
+	}{
+		{"invalid id", dtos.ClaimOrderDTO{OrderID: "invalid-id", GuestToken: created.GuestToken, CustomerID: "customer-123"}, &exceptions.InvalidOrderDataException{}},
+		{"unknown order", dtos.ClaimOrderDTO{OrderID: "550e8400-e29b-41d4-a716-446655440099", GuestToken: created.GuestToken, CustomerID: "customer-123"}, &exceptions.OrderNotFoundException{}},
+		{"wrong token", dtos.ClaimOrderDTO{OrderID: created.ID, GuestToken: "wrong-token", CustomerID: "customer-123"}, &exceptions.OrderNotFoundException{}},
+		{"without customer", dtos.ClaimOrderDTO{OrderID: created.ID, GuestToken: created.GuestToken}, &exceptions.InvalidOrderDataException{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.Execute(context.Background(), tt.dto)
+			if reflect.TypeOf(err) != reflect.TypeOf(tt.wantErr) {
+				t.Errorf("Execute() error = %v, want %T", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClaimOrderUseCase_Execute_ConcurrentClaim(t *testing.T) {
+	created, orderGateway := createGuestOrder(t)
+	orderGateway.shouldFailClaim = true
+
+	_, err := NewClaimOrderUseCase(orderGateway).Execute(context.Background(), dtos.ClaimOrderDTO{OrderID: created.ID, GuestToken: created.GuestToken, CustomerID: "customer-123"})
+	if _, ok := err.(*exceptions.OrderAlreadyClaimedException); !ok {
+		t.Errorf("Execute() error = %T, want *exceptions.OrderAlreadyClaimedException", err)
+	}
+}
@@ -1,12 +1,14 @@
 package use_cases
 
 import (
+	"context"
+	"fmt"
 	"time"
 
-	"microservice/internal/adapters/brokers"
 	"microservice/internal/adapters/dtos"
 	"microservice/internal/domain/entities"
 	"microservice/internal/domain/exceptions"
+	"microservice/internal/domain/value_objects"
 	"microservice/internal/interfaces"
 	identityUtils "microservice/utils/identity"
 )
@@ -14,40 +16,100 @@ import (
 const INITIAL_ORDER_STATUS_ID = "56d3b3c3-1801-49cd-bae7-972c78082012"
 
 type CreateOrderUseCase struct {
-	orderGateway       interfaces.IOrderGateway
-	orderStatusGateway interfaces.IOrderStatusGateway
-	messageBroker      brokers.MessageBroker
+	orderGateway          interfaces.IOrderGateway
+	orderStatusGateway    interfaces.IOrderStatusGateway
+	productCatalogGateway interfaces.IProductCatalogGateway
+	// Fuso do restaurante; o código de retirada reinicia à meia-noite dele
+	pickupLocation *time.Location
 }
 
-func NewCreateOrderUseCase(orderGateway interfaces.IOrderGateway, orderStatusGateway interfaces.IOrderStatusGateway, messageBroker brokers.MessageBroker) *CreateOrderUseCase {
+func NewCreateOrderUseCase(orderGateway interfaces.IOrderGateway, orderStatusGateway interfaces.IOrderStatusGateway, productCatalogGateway interfaces.IProductCatalogGateway, pickupLocation *time.Location) *CreateOrderUseCase {
 	return &CreateOrderUseCase{
-		orderGateway:       orderGateway,
-		orderStatusGateway: orderStatusGateway,
-		messageBroker:      messageBroker,
+		orderGateway:          orderGateway,
+		orderStatusGateway:    orderStatusGateway,
+		productCatalogGateway: productCatalogGateway,
+		pickupLocation:        pickupLocation,
 	}
 }
 
-func (uc *CreateOrderUseCase) Execute(customerID *string, items []dtos.CreateOrderItemDTO) (entities.Order, error) {
-	status, err := uc.orderStatusGateway.FindByID(INITIAL_ORDER_STATUS_ID)
+func (uc *CreateOrderUseCase) Execute(ctx context.Context, dto dtos.CreateOrderDTO) (entities.Order, error) {
+	customerID := dto.CustomerID
+	currency := dto.Currency
+	if currency == "" {
+		currency = value_objects.DEFAULT_CURRENCY
+	}
+
+	status, err := uc.orderStatusGateway.FindByID(ctx, INITIAL_ORDER_STATUS_ID)
 	if err != nil {
 		return entities.Order{}, &exceptions.OrderStatusNotFoundException{}
 	}
 
 	order, _ := entities.NewOrder(identityUtils.NewUUIDV4(), customerID)
-	order.Status = *status
 	order.CreatedAt = time.Now()
+	if err := order.SetCustomerName(dto.CustomerName); err != nil {
+		return entities.Order{}, err
+	}
+	if err := order.SetInitialStatus(*status, entities.STATUS_CHANGE_SOURCE_REST, customerID); err != nil {
+		return entities.Order{}, err
+	}
+	// Pedidos sem cliente recebem o token que permite consultá-los e reivindicá-los
+	if err := order.IssueGuestToken(); err != nil {
+		return entities.Order{}, err
+	}
+
+	// O preço vem sempre do catálogo; o enviado pelo cliente só é conferido
+	products := make(map[string]*entities.Product)
+	findProduct := func(productID string) (*entities.Product, error) {
+		if product, ok := products[productID]; ok {
+			return product, nil
+		}
+		product, err := uc.findOrderableProduct(ctx, productID, currency)
+		if err != nil {
+			return nil, err
+		}
+		products[productID] = product
+		return product, nil
+	}
+
+	for _, item := range dto.Items {
+		product, err := findProduct(item.ProductID)
+		if err != nil {
+			return entities.Order{}, err
+		}
+
+		if err := checkClientPrice(item, product); err != nil {
+			return entities.Order{}, err
+		}
+
+		modifiers, err := resolveModifiers(item, product)
+		if err != nil {
+			return entities.Order{}, err
+		}
 
-	for _, item := range items {
 		orderItem, err := entities.NewOrderItem(
 			identityUtils.NewUUIDV4(),
 			item.ProductID,
 			order.ID,
 			item.Quantity,
-			item.Price,
+			product.Price,
+			item.Notes,
+			modifiers,
 		)
 		if err != nil {
 			return entities.Order{}, err
 		}
+		orderItem.Product = product.Snapshot()
+
+		for _, comboItem := range product.ComboItems {
+			component, err := newComboComponent(order.ID, comboItem, item.Quantity, findProduct)
+			if err != nil {
+				return entities.Order{}, err
+			}
+			if err := orderItem.AddComponent(*component); err != nil {
+				return entities.Order{}, err
+			}
+		}
+
 		order.AddItem(*orderItem)
 	}
 
@@ -56,10 +118,120 @@ func (uc *CreateOrderUseCase) Execute(customerID *string, items []dtos.CreateOrd
 		return entities.Order{}, err
 	}
 
-	err = uc.orderGateway.Create(*order)
+	pickupDay := entities.PickupDay(order.CreatedAt, uc.pickupLocation)
+	pickupCode, err := uc.orderGateway.NextPickupCode(ctx, pickupDay)
+	if err != nil {
+		return entities.Order{}, err
+	}
+	order.PickupCode = pickupCode
+	order.PickupDate = pickupDay
+
+	event, err := newOrderCreatedEvent(ctx, *order)
+	if err != nil {
+		return entities.Order{}, err
+	}
+
+	err = uc.orderGateway.Create(ctx, *order, event)
 	if err != nil {
 		return entities.Order{}, err
 	}
 
 	return *order, nil
 }
+
+func (uc *CreateOrderUseCase) findOrderableProduct(ctx context.Context, productID string, currency string) (*entities.Product, error) {
+	product, err := uc.productCatalogGateway.FindByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !product.Available {
+		return nil, &exceptions.ProductUnavailableException{
+			Message: fmt.Sprintf("Product %s is not available", productID),
+		}
+	}
+
+	if product.Price.Currency() != currency {
+		return nil, &exceptions.AmountNotValidException{
+			Message: fmt.Sprintf("Product %s is priced in %s, not %s", productID, product.Price.Currency(), currency),
+		}
+	}
+
+	return product, nil
+}
+
+// A quantidade é o total da linha: 2 combos com 1 batata cada geram 2 batatas
+func newComboComponent(orderID string, comboItem entities.ProductComboItem, comboQuantity int, findProduct func(string) (*entities.Product, error)) (*entities.OrderItem, error) {
+	product, err := findProduct(comboItem.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	component, err := entities.NewOrderItem(
+		identityUtils.NewUUIDV4(),
+		comboItem.ProductID,
+		orderID,
+		comboItem.Quantity*comboQuantity,
+		product.Price,
+		"",
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	component.Product = product.Snapshot()
+	return component, nil
+}
+
+// Itens sem preço aceitam o do catálogo
+func checkClientPrice(item dtos.CreateOrderItemDTO, product *entities.Product) error {
+	if item.Price == "" {
+		return nil
+	}
+
+	clientPrice, err := value_objects.ParseMoney(item.Price, product.Price.Currency())
+	if err != nil {
+		return err
+	}
+
+	if clientPrice != product.Price {
+		return &exceptions.ProductPriceMismatchException{
+			Message: fmt.Sprintf("Price %s for product %s does not match the catalog price %s", clientPrice, item.ProductID, product.Price),
+		}
+	}
+
+	return nil
+}
+
+// O preço dos adicionais vem do catálogo; o enviado pelo cliente só é conferido
+func resolveModifiers(item dtos.CreateOrderItemDTO, product *entities.Product) ([]entities.OrderItemModifier, error) {
+	modifiers := make([]entities.OrderItemModifier, 0, len(item.Modifiers))
+	for _, requested := range item.Modifiers {
+		modifier, ok := product.FindModifier(requested.ModifierID)
+		if !ok {
+			return nil, &exceptions.ProductModifierNotFoundException{
+				Message: fmt.Sprintf("Modifier %s is not offered for product %s", requested.ModifierID, item.ProductID),
+			}
+		}
+
+		if requested.PriceDelta != "" {
+			clientDelta, err := value_objects.ParseMoney(requested.PriceDelta, product.Price.Currency())
+			if err != nil {
+				return nil, err
+			}
+			if clientDelta != modifier.PriceDelta {
+				return nil, &exceptions.ProductPriceMismatchException{
+					Message: fmt.Sprintf("Price %s for modifier %s does not match the catalog price %s", clientDelta, requested.ModifierID, modifier.PriceDelta),
+				}
+			}
+		}
+
+		modifiers = append(modifiers, entities.OrderItemModifier{
+			ID:         identityUtils.NewUUIDV4(),
+			ModifierID: modifier.ID,
+			Name:       modifier.Name,
+			PriceDelta: modifier.PriceDelta,
+		})
+	}
+	return modifiers, nil
+}
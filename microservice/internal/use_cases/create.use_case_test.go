@@ -2,32 +2,35 @@ package use_cases
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
-	"microservice/internal/adapters/brokers"
 	"microservice/internal/adapters/dtos"
 	"microservice/internal/domain/entities"
+	"microservice/internal/domain/exceptions"
+	"microservice/internal/domain/value_objects"
 )
 
 func TestCreateOrderUseCase_ValidatesItemQuantity(t *testing.T) {
-	_, err := entities.NewOrderItem("item-1", "product-1", "order-1", 0, 10.0)
+	_, err := entities.NewOrderItem("item-1", "product-1", "order-1", 0, brl("10.00"), "", nil)
 	if err == nil {
 		t.Error("Expected error for zero quantity, got nil")
 	}
 
-	_, err = entities.NewOrderItem("item-1", "product-1", "order-1", -1, 10.0)
+	_, err = entities.NewOrderItem("item-1", "product-1", "order-1", -1, brl("10.00"), "", nil)
 	if err == nil {
 		t.Error("Expected error for negative quantity, got nil")
 	}
 }
 
 func TestCreateOrderUseCase_ValidatesItemPrice(t *testing.T) {
-	_, err := entities.NewOrderItem("item-1", "product-1", "order-1", 1, 0.0)
+	_, err := entities.NewOrderItem("item-1", "product-1", "order-1", 1, brl("0.00"), "", nil)
 	if err == nil {
 		t.Error("Expected error for zero price, got nil")
 	}
 
-	_, err = entities.NewOrderItem("item-1", "product-1", "order-1", 1, -10.0)
+	_, err = entities.NewOrderItem("item-1", "product-1", "order-1", 1, brl("-10.00"), "", nil)
 	if err == nil {
 		t.Error("Expected error for negative price, got nil")
 	}
@@ -35,14 +38,14 @@ func TestCreateOrderUseCase_ValidatesItemPrice(t *testing.T) {
 
 func TestCreateOrderUseCase_ValidatesItemIDs(t *testing.T) {
 	// Test that valid IDs work
-	_, err := entities.NewOrderItem("item-1", "product-1", "order-1", 1, 10.0)
+	_, err := entities.NewOrderItem("item-1", "product-1", "order-1", 1, brl("10.00"), "", nil)
 	if err != nil {
 		t.Errorf("Expected no error for valid IDs, got %v", err)
 	}
 }
 
 func TestCreateOrderUseCase_OrderItemCreation(t *testing.T) {
-	item, err := entities.NewOrderItem("item-1", "product-1", "order-1", 2, 15.0)
+	item, err := entities.NewOrderItem("item-1", "product-1", "order-1", 2, brl("15.00"), "", nil)
 	if err != nil {
 		t.Errorf("NewOrderItem() unexpected error: %v", err)
 	}
@@ -59,8 +62,8 @@ func TestCreateOrderUseCase_OrderItemCreation(t *testing.T) {
 	if item.Quantity.Value() != 2 {
 		t.Errorf("OrderItem.Quantity = %v, want 2", item.Quantity.Value())
 	}
-	if item.UnitPrice.Value() != 15.0 {
-		t.Errorf("OrderItem.UnitPrice = %v, want 15.0", item.UnitPrice.Value())
+	if item.UnitPrice.Value().String() != "15.00" {
+		t.Errorf("OrderItem.UnitPrice = %v, want 15.00", item.UnitPrice.Value())
 	}
 }
 
@@ -87,7 +90,7 @@ func TestCreateOrderUseCase_DTOStructure(t *testing.T) {
 			{
 				ProductID: "product-1",
 				Quantity:  2,
-				Price:     10.0,
+				Price:     "10.00",
 			},
 		},
 	}
@@ -104,7 +107,7 @@ func TestCreateOrderUseCase_ItemDTOStructure(t *testing.T) {
 	dto := dtos.CreateOrderItemDTO{
 		ProductID: "product-1",
 		Quantity:  2,
-		Price:     15.0,
+		Price:     "15.00",
 	}
 
 	if dto.ProductID != "product-1" {
@@ -113,8 +116,8 @@ func TestCreateOrderUseCase_ItemDTOStructure(t *testing.T) {
 	if dto.Quantity != 2 {
 		t.Errorf("CreateOrderItemDTO.Quantity = %v, want 2", dto.Quantity)
 	}
-	if dto.Price != 15.0 {
-		t.Errorf("CreateOrderItemDTO.Price = %v, want 15.0", dto.Price)
+	if dto.Price != "15.00" {
+		t.Errorf("CreateOrderItemDTO.Price = %v, want 15.00", dto.Price)
 	}
 }
 
@@ -122,8 +125,8 @@ func TestCreateOrderUseCase_OrderAmountCalculation(t *testing.T) {
 	customerID := "customer-123"
 	order, _ := entities.NewOrder("order-1", &customerID)
 
-	item1, _ := entities.NewOrderItem("item-1", "product-1", "order-1", 2, 10.0)
-	item2, _ := entities.NewOrderItem("item-2", "product-2", "order-1", 1, 15.0)
+	item1, _ := entities.NewOrderItem("item-1", "product-1", "order-1", 2, brl("10.00"), "", nil)
+	item2, _ := entities.NewOrderItem("item-2", "product-2", "order-1", 1, brl("15.00"), "", nil)
 
 	order.AddItem(*item1)
 	order.AddItem(*item2)
@@ -133,31 +136,19 @@ func TestCreateOrderUseCase_OrderAmountCalculation(t *testing.T) {
 		t.Errorf("CalcTotalAmount() unexpected error: %v", err)
 	}
 
-	expectedAmount := 2*10.0 + 1*15.0 // 35.0
-	if order.Amount.Value() != expectedAmount {
+	expectedAmount := "35.00" // 2*10.00 + 1*15.00
+	if order.Amount.Value().String() != expectedAmount {
 		t.Errorf("Order.Amount = %v, want %v", order.Amount.Value(), expectedAmount)
 	}
 }
 
-// Mock message broker for testing
-type MockMessageBroker struct{}
-
-func (m *MockMessageBroker) ConsumeOrderUpdates(ctx context.Context, handler brokers.OrderUpdateHandler) error {
-	return nil
-}
-
-func (m *MockMessageBroker) Close() error {
-	return nil
-}
-
 // Comprehensive tests using mocks for full coverage
 
 func TestCreateOrderUseCase_NewCreateOrderUseCase(t *testing.T) {
 	mockOrderGateway := NewMockOrderGateway()
 	mockStatusGateway := NewMockOrderStatusGateway()
-	mockBroker := &MockMessageBroker{}
 
-	uc := NewCreateOrderUseCase(mockOrderGateway, mockStatusGateway, mockBroker)
+	uc := NewCreateOrderUseCase(mockOrderGateway, mockStatusGateway, NewMockProductCatalogGateway(), time.UTC)
 
 	if uc == nil {
 		t.Error("Expected use case to be created")
@@ -167,24 +158,23 @@ func TestCreateOrderUseCase_NewCreateOrderUseCase(t *testing.T) {
 func TestCreateOrderUseCase_Execute_Success(t *testing.T) {
 	mockOrderGateway := NewMockOrderGateway()
 	mockStatusGateway := NewMockOrderStatusGateway()
-	mockBroker := &MockMessageBroker{}
 
 	// Add initial status
 	initialStatus, _ := entities.NewOrderStatus(INITIAL_ORDER_STATUS_ID, "Pending")
 	mockStatusGateway.AddStatus(initialStatus)
 
-	uc := NewCreateOrderUseCase(mockOrderGateway, mockStatusGateway, mockBroker)
+	uc := NewCreateOrderUseCase(mockOrderGateway, mockStatusGateway, NewMockProductCatalogGateway(), time.UTC)
 
 	customerID := "customer-123"
 	items := []dtos.CreateOrderItemDTO{
 		{
 			ProductID: "product-1",
 			Quantity:  2,
-			Price:     10.0,
+			Price:     "10.00",
 		},
 	}
 
-	order, err := uc.Execute(&customerID, items)
+	order, err := uc.Execute(context.Background(), dtos.CreateOrderDTO{CustomerID: &customerID, Items: items})
 	if err != nil {
 		t.Errorf("Expected no error for successful create, got %v", err)
 	}
@@ -201,31 +191,30 @@ func TestCreateOrderUseCase_Execute_Success(t *testing.T) {
 		t.Errorf("Expected 1 item, got %d", len(order.Items))
 	}
 
-	if order.Amount.Value() != 20.0 {
-		t.Errorf("Expected amount 20.0, got %f", order.Amount.Value())
+	if order.Amount.Value().String() != "20.00" {
+		t.Errorf("Expected amount 20.0, got %v", order.Amount.Value())
 	}
 }
 
 func TestCreateOrderUseCase_Execute_StatusNotFound(t *testing.T) {
 	mockOrderGateway := NewMockOrderGateway()
 	mockStatusGateway := NewMockOrderStatusGateway()
-	mockBroker := &MockMessageBroker{}
 
 	// Don't add the initial status to simulate not found
 	mockStatusGateway.SetShouldFailFindByID(true)
 
-	uc := NewCreateOrderUseCase(mockOrderGateway, mockStatusGateway, mockBroker)
+	uc := NewCreateOrderUseCase(mockOrderGateway, mockStatusGateway, NewMockProductCatalogGateway(), time.UTC)
 
 	customerID := "customer-123"
 	items := []dtos.CreateOrderItemDTO{
 		{
 			ProductID: "product-1",
 			Quantity:  2,
-			Price:     10.0,
+			Price:     "10.00",
 		},
 	}
 
-	order, err := uc.Execute(&customerID, items)
+	order, err := uc.Execute(context.Background(), dtos.CreateOrderDTO{CustomerID: &customerID, Items: items})
 	if err == nil {
 		t.Error("Expected error when status not found")
 	}
@@ -238,7 +227,6 @@ func TestCreateOrderUseCase_Execute_StatusNotFound(t *testing.T) {
 func TestCreateOrderUseCase_Execute_CreateError(t *testing.T) {
 	mockOrderGateway := NewMockOrderGateway()
 	mockStatusGateway := NewMockOrderStatusGateway()
-	mockBroker := &MockMessageBroker{}
 
 	// Add initial status
 	initialStatus, _ := entities.NewOrderStatus(INITIAL_ORDER_STATUS_ID, "Pending")
@@ -247,18 +235,18 @@ func TestCreateOrderUseCase_Execute_CreateError(t *testing.T) {
 	// Make create fail
 	mockOrderGateway.SetShouldFailCreate(true)
 
-	uc := NewCreateOrderUseCase(mockOrderGateway, mockStatusGateway, mockBroker)
+	uc := NewCreateOrderUseCase(mockOrderGateway, mockStatusGateway, NewMockProductCatalogGateway(), time.UTC)
 
 	customerID := "customer-123"
 	items := []dtos.CreateOrderItemDTO{
 		{
 			ProductID: "product-1",
 			Quantity:  2,
-			Price:     10.0,
+			Price:     "10.00",
 		},
 	}
 
-	order, err := uc.Execute(&customerID, items)
+	order, err := uc.Execute(context.Background(), dtos.CreateOrderDTO{CustomerID: &customerID, Items: items})
 	if err == nil {
 		t.Error("Expected error when create fails")
 	}
@@ -266,4 +254,240 @@ func TestCreateOrderUseCase_Execute_CreateError(t *testing.T) {
 	if !order.IsEmpty() {
 		t.Error("Expected empty order when create fails")
 	}
-}
\ No newline at end of file
+}
+func newPricingTestUseCase(catalog *MockProductCatalogGateway) (*CreateOrderUseCase, *MockOrderGateway) {
+	mockOrderGateway := NewMockOrderGateway()
+	mockStatusGateway := NewMockOrderStatusGateway()
+	initialStatus, _ := entities.NewOrderStatus(INITIAL_ORDER_STATUS_ID, "Pending")
+	mockStatusGateway.AddStatus(initialStatus)
+
+	return NewCreateOrderUseCase(mockOrderGateway, mockStatusGateway, catalog, time.UTC), mockOrderGateway
+}
+
+func TestCreateOrderUseCase_Execute_UsesCatalogPriceWhenClientSendsNone(t *testing.T) {
+	uc, _ := newPricingTestUseCase(NewMockProductCatalogGateway())
+
+	order, err := uc.Execute(context.Background(), dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{
+		{ProductID: "product-1", Quantity: 2},
+		{ProductID: "product-2", Quantity: 1},
+		{ProductID: "product-1", Quantity: 1},
+	}})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if order.Items[0].UnitPrice.Value().String() != "10.00" {
+		t.Errorf("Expected unit price 10.00 from catalog, got %v", order.Items[0].UnitPrice.Value())
+	}
+	if order.Items[0].Product.Name != "Product product-1" || order.Items[0].Product.Category != "Lanche" {
+		t.Errorf("Expected product snapshot from catalog, got %+v", order.Items[0].Product)
+	}
+	if order.Amount.Value().String() != "55.00" {
+		t.Errorf("Expected amount 55.00, got %v", order.Amount.Value())
+	}
+}
+
+func TestCreateOrderUseCase_Execute_RejectsClientPriceDifferentFromCatalog(t *testing.T) {
+	uc, mockOrderGateway := newPricingTestUseCase(NewMockProductCatalogGateway())
+
+	_, err := uc.Execute(context.Background(), dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{
+		{ProductID: "product-1", Quantity: 1, Price: "0.01"},
+	}})
+
+	if _, ok := err.(*exceptions.ProductPriceMismatchException); !ok {
+		t.Errorf("Expected ProductPriceMismatchException, got %T (%v)", err, err)
+	}
+	if len(mockOrderGateway.orders) != 0 {
+		t.Error("Expected no order to be created")
+	}
+}
+
+func TestCreateOrderUseCase_Execute_CatalogErrors(t *testing.T) {
+	unavailable := NewMockProductCatalogGateway()
+	unavailable.AddProduct("product-1", "10.00", false)
+
+	failing := NewMockProductCatalogGateway()
+	failing.SetShouldFailFindByID(true)
+
+	usd := NewMockProductCatalogGateway()
+	usdPrice, _ := value_objects.NewMoney(1000, "USD")
+	usdProduct, _ := entities.NewProduct("product-1", "Burger", usdPrice, true)
+	usd.products["product-1"] = usdProduct
+
+	tests := []struct {
+		name      string
+		catalog   *MockProductCatalogGateway
+		productID string
+		check     func(err error) bool
+	}{
+		{"unknown product", NewMockProductCatalogGateway(), "product-404", func(err error) bool {
+			_, ok := err.(*exceptions.ProductNotFoundException)
+			return ok
+		}},
+		{"unavailable product", unavailable, "product-1", func(err error) bool {
+			_, ok := err.(*exceptions.ProductUnavailableException)
+			return ok
+		}},
+		{"catalog down", failing, "product-1", func(err error) bool {
+			_, ok := err.(*exceptions.ProductCatalogUnavailableException)
+			return ok
+		}},
+		{"catalog in another currency", usd, "product-1", func(err error) bool {
+			_, ok := err.(*exceptions.AmountNotValidException)
+			return ok
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, _ := newPricingTestUseCase(tt.catalog)
+
+			order, err := uc.Execute(context.Background(), dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{
+				{ProductID: tt.productID, Quantity: 1},
+			}})
+
+			if !tt.check(err) {
+				t.Errorf("Unexpected error %T (%v)", err, err)
+			}
+			if !order.IsEmpty() {
+				t.Error("Expected empty order on error")
+			}
+		})
+	}
+}
+
+func TestCreateOrderUseCase_Execute_ItemNotesAndModifiers(t *testing.T) {
+	catalog := NewMockProductCatalogGateway()
+	catalog.AddModifier("product-1", "queijo-extra", "3.00")
+	catalog.AddModifier("product-1", "sem-cebola", "0")
+	uc, _ := newPricingTestUseCase(catalog)
+
+	order, err := uc.Execute(context.Background(), dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{
+		{ProductID: "product-1", Quantity: 2, Notes: "bem passado", Modifiers: []dtos.CreateOrderItemModifierDTO{
+			{ModifierID: "queijo-extra", PriceDelta: "3.00"},
+			{ModifierID: "sem-cebola"},
+		}},
+	}})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	item := order.Items[0]
+	if item.Notes != "bem passado" {
+		t.Errorf("Expected item notes, got %q", item.Notes)
+	}
+	if len(item.Modifiers) != 2 || item.Modifiers[0].Name != "Modifier queijo-extra" || item.Modifiers[0].ID == "" {
+		t.Errorf("Expected modifiers from catalog, got %+v", item.Modifiers)
+	}
+	if order.Amount.Value().String() != "26.00" {
+		t.Errorf("Expected amount 26.00 (2 * (10.00 + 3.00)), got %v", order.Amount.Value())
+	}
+}
+
+func TestCreateOrderUseCase_Execute_ModifierErrors(t *testing.T) {
+	catalog := NewMockProductCatalogGateway()
+	catalog.AddModifier("product-1", "queijo-extra", "3.00")
+
+	tests := []struct {
+		name     string
+		modifier dtos.CreateOrderItemModifierDTO
+		check    func(err error) bool
+	}{
+		{"modifier not offered", dtos.CreateOrderItemModifierDTO{ModifierID: "bacon-extra"}, func(err error) bool {
+			_, ok := err.(*exceptions.ProductModifierNotFoundException)
+			return ok
+		}},
+		{"client price different from catalog", dtos.CreateOrderItemModifierDTO{ModifierID: "queijo-extra", PriceDelta: "1.00"}, func(err error) bool {
+			_, ok := err.(*exceptions.ProductPriceMismatchException)
+			return ok
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, mockOrderGateway := newPricingTestUseCase(catalog)
+
+			_, err := uc.Execute(context.Background(), dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{
+				{ProductID: "product-1", Quantity: 1, Modifiers: []dtos.CreateOrderItemModifierDTO{tt.modifier}},
+			}})
+
+			if !tt.check(err) {
+				t.Errorf("Unexpected error %T (%v)", err, err)
+			}
+			if len(mockOrderGateway.orders) != 0 {
+				t.Error("Expected no order to be created")
+			}
+		})
+	}
+}
+
+func TestCreateOrderUseCase_Execute_Combo(t *testing.T) {
+	catalog := NewMockProductCatalogGateway()
+	catalog.AddCombo("combo-1", "30.00",
+		entities.ProductComboItem{ProductID: "product-1", Quantity: 1},
+		entities.ProductComboItem{ProductID: "product-2", Quantity: 2},
+	)
+	uc, _ := newPricingTestUseCase(catalog)
+
+	order, err := uc.Execute(context.Background(), dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{
+		{ProductID: "combo-1", Quantity: 2},
+	}})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if order.Amount.Value().String() != "60.00" {
+		t.Errorf("Expected amount 60.00 (2 combos at 30.00), got %v", order.Amount.Value())
+	}
+
+	combo := order.Items[0]
+	if len(order.Items) != 1 || len(combo.Components) != 2 {
+		t.Fatalf("Expected one combo item with 2 components, got %+v", order.Items)
+	}
+	component := combo.Components[1]
+	if component.ProductID.Value() != "product-2" || component.Quantity.Value() != 4 || component.OrderID != order.ID {
+		t.Errorf("Expected 4 of product-2 in the order, got %+v", component)
+	}
+	if component.UnitPrice.Value().String() != "25.00" || component.Product.Name != "Product product-2" {
+		t.Errorf("Expected component priced and described by the catalog, got %+v", component)
+	}
+}
+
+func TestCreateOrderUseCase_Execute_ComboWithUnavailableComponent(t *testing.T) {
+	catalog := NewMockProductCatalogGateway()
+	catalog.AddProduct("product-2", "25.00", false)
+	catalog.AddCombo("combo-1", "30.00",
+		entities.ProductComboItem{ProductID: "product-1", Quantity: 1},
+		entities.ProductComboItem{ProductID: "product-2", Quantity: 1},
+	)
+	uc, mockOrderGateway := newPricingTestUseCase(catalog)
+
+	_, err := uc.Execute(context.Background(), dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{
+		{ProductID: "combo-1", Quantity: 1},
+	}})
+
+	if _, ok := err.(*exceptions.ProductUnavailableException); !ok {
+		t.Errorf("Expected ProductUnavailableException, got %T (%v)", err, err)
+	}
+	if len(mockOrderGateway.orders) != 0 {
+		t.Error("Expected no order to be created")
+	}
+}
+
+func TestCreateOrderUseCase_Execute_CustomerName(t *testing.T) {
+	uc, _ := newPricingTestUseCase(NewMockProductCatalogGateway())
+	items := []dtos.CreateOrderItemDTO{{ProductID: "product-1", Quantity: 1}}
+
+	order, err := uc.Execute(context.Background(), dtos.CreateOrderDTO{CustomerName: " Maria ", Items: items})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if order.CustomerName != "Maria" {
+		t.Errorf("Expected customer name Maria, got %q", order.CustomerName)
+	}
+
+	_, err = uc.Execute(context.Background(), dtos.CreateOrderDTO{CustomerName: strings.Repeat("a", entities.MAX_CUSTOMER_NAME_LENGTH+1), Items: items})
+	if _, ok := err.(*exceptions.InvalidOrderDataException); !ok {
+		t.Errorf("Expected InvalidOrderDataException for a long name, got %T", err)
+	}
+}
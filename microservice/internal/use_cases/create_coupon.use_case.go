@@ -0,0 +1,64 @@
+package use_cases
+
+import (
+	"context"
+	"time"
+
+	"microservice/internal/adapters/dtos"
+	"microservice/internal/domain/entities"
+	"microservice/internal/domain/exceptions"
+	"microservice/internal/domain/value_objects"
+	"microservice/internal/interfaces"
+)
+
+type CreateCouponUseCase struct {
+	couponGateway interfaces.ICouponGateway
+}
+
+func NewCreateCouponUseCase(couponGateway interfaces.ICouponGateway) *CreateCouponUseCase {
+	return &CreateCouponUseCase{
+		couponGateway: couponGateway,
+	}
+}
+
+func (uc *CreateCouponUseCase) Execute(ctx context.Context, dto dtos.CreateCouponDTO) (entities.Coupon, error) {
+	currency := dto.Currency
+	if currency == "" {
+		currency = value_objects.DEFAULT_CURRENCY
+	}
+
+	fixedAmount, err := value_objects.NewMoney(0, currency)
+	if err != nil {
+		return entities.Coupon{}, &exceptions.InvalidCouponDataException{Message: err.Error()}
+	}
+	if dto.Amount != "" {
+		fixedAmount, err = value_objects.ParseMoney(dto.Amount, currency)
+		if err != nil {
+			return entities.Coupon{}, &exceptions.InvalidCouponDataException{Message: err.Error()}
+		}
+	}
+
+	coupon, err := entities.NewCoupon(entities.Coupon{
+		Code:           dto.Code,
+		Type:           dto.Type,
+		Percentage:     dto.Percentage,
+		FixedAmount:    fixedAmount,
+		ProductID:      dto.ProductID,
+		BuyQuantity:    dto.BuyQuantity,
+		FreeQuantity:   dto.FreeQuantity,
+		FirstOrderOnly: dto.FirstOrderOnly,
+		ValidFrom:      dto.ValidFrom,
+		ValidUntil:     dto.ValidUntil,
+		MaxUses:        dto.MaxUses,
+		CreatedAt:      time.Now(),
+	})
+	if err != nil {
+		return entities.Coupon{}, err
+	}
+
+	if err := uc.couponGateway.Create(ctx, *coupon); err != nil {
+		return entities.Coupon{}, err
+	}
+
+	return *coupon, nil
+}
@@ -0,0 +1,59 @@
+package use_cases
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"microservice/internal/adapters/dtos"
+	"microservice/internal/domain/entities"
+)
+
+func TestCreateCouponUseCase_Execute_Success(t *testing.T) {
+	couponGateway := NewMockCouponGateway()
+
+	coupon, err := NewCreateCouponUseCase(couponGateway).Execute(context.Background(), dtos.CreateCouponDTO{
+		Code:    "menos5",
+		Type:    entities.COUPON_TYPE_FIXED_AMOUNT,
+		Amount:  "5.00",
+		MaxUses: 100,
+	})
+
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if coupon.Code != "MENOS5" || coupon.FixedAmount.String() != "5.00" || coupon.FixedAmount.Currency() != "BRL" {
+		t.Errorf("Execute() = %+v, want MENOS5 5.00 BRL", coupon)
+	}
+	if coupon.CreatedAt.IsZero() {
+		t.Error("Execute() CreatedAt should be set")
+	}
+	if _, err := couponGateway.FindByCode(context.Background(), "MENOS5"); err != nil {
+		t.Errorf("Execute() did not save the coupon: %v", err)
+	}
+}
+
+func TestCreateCouponUseCase_Execute_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		dto      dtos.CreateCouponDTO
+		expected string
+	}{
+		{"invalid amount", dtos.CreateCouponDTO{Code: "MENOS5", Type: entities.COUPON_TYPE_FIXED_AMOUNT, Amount: "abc"}, "*exceptions.InvalidCouponDataException"},
+		{"unsupported currency", dtos.CreateCouponDTO{Code: "MENOS5", Type: entities.COUPON_TYPE_FIXED_AMOUNT, Amount: "5.00", Currency: "XYZ"}, "*exceptions.InvalidCouponDataException"},
+		{"invalid percentage", dtos.CreateCouponDTO{Code: "PROMO", Type: entities.COUPON_TYPE_PERCENTAGE, Percentage: 150}, "*exceptions.InvalidCouponDataException"},
+		{"duplicated code", dtos.CreateCouponDTO{Code: "promo10", Type: entities.COUPON_TYPE_PERCENTAGE, Percentage: 10}, "*exceptions.CouponAlreadyExistsException"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			couponGateway := NewMockCouponGateway()
+			couponGateway.AddCoupon(entities.Coupon{Code: "PROMO10", Type: entities.COUPON_TYPE_PERCENTAGE, Percentage: 10})
+
+			_, err := NewCreateCouponUseCase(couponGateway).Execute(context.Background(), tt.dto)
+			if got := fmt.Sprintf("%T", err); got != tt.expected {
+				t.Errorf("Execute() error = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package use_cases
 
 import (
+	"context"
+
 	"microservice/internal/domain/entities"
 	"microservice/internal/domain/exceptions"
 	"microservice/internal/interfaces"
@@ -16,16 +18,21 @@ func NewDeleteOrderUseCase(orderGateway interfaces.IOrderGateway) *DeleteOrderUs
 	}
 }
 
-func (uc *DeleteOrderUseCase) Execute(id string) error {
+func (uc *DeleteOrderUseCase) Execute(ctx context.Context, id string) error {
 	err := entities.ValidateID(id)
 	if err != nil {
 		return err
 	}
 
-	_, err = uc.orderGateway.FindByID(id)
+	_, err = uc.orderGateway.FindByID(ctx, id)
 	if err != nil {
 		return &exceptions.OrderNotFoundException{}
 	}
 
-	return uc.orderGateway.Delete(id)
+	event, err := newOrderDeletedEvent(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return uc.orderGateway.Delete(ctx, id, event)
 }
@@ -1,6 +1,7 @@
 package use_cases
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -69,7 +70,7 @@ func TestDeleteOrderUseCase_Execute_InvalidID(t *testing.T) {
 	mockGateway := NewMockOrderGateway()
 	uc := NewDeleteOrderUseCase(mockGateway)
 
-	err := uc.Execute("invalid-id")
+	err := uc.Execute(context.Background(), "invalid-id")
 	if err == nil {
 		t.Error("Expected error for invalid ID")
 	}
@@ -83,7 +84,7 @@ func TestDeleteOrderUseCase_Execute_EmptyID(t *testing.T) {
 	mockGateway := NewMockOrderGateway()
 	uc := NewDeleteOrderUseCase(mockGateway)
 
-	err := uc.Execute("")
+	err := uc.Execute(context.Background(), "")
 	if err == nil {
 		t.Error("Expected error for empty ID")
 	}
@@ -107,16 +108,16 @@ func TestDeleteOrderUseCase_Execute_Success(t *testing.T) {
 	validID := "550e8400-e29b-41d4-a716-446655440000"
 	customerID := "customer-123"
 	status, _ := entities.NewOrderStatus("pending", "Pending")
-	order, _ := entities.NewOrderWithItems(validID, &customerID, 25.0, *status, []entities.OrderItem{}, time.Now(), nil)
+	order, _ := entities.NewOrderWithItems(validID, &customerID, brl("25.00"), *status, []entities.OrderItem{}, time.Now(), nil)
 	mockGateway.AddOrder(order)
 
-	err := uc.Execute(validID)
+	err := uc.Execute(context.Background(), validID)
 	if err != nil {
 		t.Errorf("Expected no error for successful delete, got %v", err)
 	}
 
 	// Verify order was deleted
-	_, err = mockGateway.FindByID(validID)
+	_, err = mockGateway.FindByID(context.Background(), validID)
 	if err == nil {
 		t.Error("Expected order to be deleted")
 	}
@@ -128,7 +129,7 @@ func TestDeleteOrderUseCase_Execute_OrderNotFound(t *testing.T) {
 
 	validID := "550e8400-e29b-41d4-a716-446655440000"
 	
-	err := uc.Execute(validID)
+	err := uc.Execute(context.Background(), validID)
 	if err == nil {
 		t.Error("Expected error when order not found")
 	}
@@ -145,7 +146,7 @@ func TestDeleteOrderUseCase_Execute_GatewayFindError(t *testing.T) {
 
 	validID := "550e8400-e29b-41d4-a716-446655440000"
 	
-	err := uc.Execute(validID)
+	err := uc.Execute(context.Background(), validID)
 	if err == nil {
 		t.Error("Expected error when gateway fails")
 	}
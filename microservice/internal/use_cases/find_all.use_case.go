@@ -1,11 +1,28 @@
 package use_cases
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
 	"microservice/internal/adapters/dtos"
 	"microservice/internal/domain/entities"
+	"microservice/internal/domain/exceptions"
 	"microservice/internal/interfaces"
 )
 
+const (
+	DEFAULT_ORDER_PAGE_LIMIT = 20
+	MAX_ORDER_PAGE_LIMIT     = 100
+)
+
+type OrderPage struct {
+	Orders     []entities.Order
+	NextCursor *string
+	Total      int64
+}
+
 type FindAllOrdersUseCase struct {
 	orderGateway interfaces.IOrderGateway
 }
@@ -16,6 +33,93 @@ func NewFindAllOrdersUseCase(orderGateway interfaces.IOrderGateway) *FindAllOrde
 	}
 }
 
-func (uc *FindAllOrdersUseCase) Execute(filter dtos.OrderFilterDTO) ([]entities.Order, error) {
-	return uc.orderGateway.FindAll(filter)
+func (uc *FindAllOrdersUseCase) Execute(ctx context.Context, filter dtos.OrderFilterDTO) (OrderPage, error) {
+	if err := normalizeOrderFilter(&filter); err != nil {
+		return OrderPage{}, err
+	}
+
+	total, err := uc.orderGateway.Count(ctx, filter)
+	if err != nil {
+		return OrderPage{}, err
+	}
+
+	// Busca um registro a mais para saber se existe próxima página
+	limit := filter.Limit
+	filter.Limit = limit + 1
+
+	orders, err := uc.orderGateway.FindAll(ctx, filter)
+	if err != nil {
+		return OrderPage{}, err
+	}
+
+	page := OrderPage{Orders: orders, Total: total}
+	if len(orders) > limit {
+		page.Orders = orders[:limit]
+		last := page.Orders[limit-1]
+		cursor, err := encodeOrderCursor(dtos.OrderCursorDTO{CreatedAt: last.CreatedAt, ID: last.ID})
+		if err != nil {
+			return OrderPage{}, err
+		}
+		page.NextCursor = &cursor
+	}
+
+	return page, nil
+}
+
+func normalizeOrderFilter(filter *dtos.OrderFilterDTO) error {
+	if filter.Limit == 0 {
+		filter.Limit = DEFAULT_ORDER_PAGE_LIMIT
+	}
+	if filter.Limit < 0 || filter.Limit > MAX_ORDER_PAGE_LIMIT {
+		return &exceptions.InvalidOrderFilterException{
+			Message: fmt.Sprintf("Limit must be between 1 and %d", MAX_ORDER_PAGE_LIMIT),
+		}
+	}
+
+	switch filter.Sort {
+	case "":
+		filter.Sort = dtos.ORDER_SORT_CREATED_AT_DESC
+	case dtos.ORDER_SORT_CREATED_AT_ASC, dtos.ORDER_SORT_CREATED_AT_DESC:
+	default:
+		return &exceptions.InvalidOrderFilterException{
+			Message: fmt.Sprintf("Sort must be %s or %s", dtos.ORDER_SORT_CREATED_AT_ASC, dtos.ORDER_SORT_CREATED_AT_DESC),
+		}
+	}
+
+	if filter.CreatedAtFrom != nil && filter.CreatedAtTo != nil && filter.CreatedAtFrom.After(*filter.CreatedAtTo) {
+		return &exceptions.InvalidOrderFilterException{Message: "created_at_from must be before created_at_to"}
+	}
+
+	filter.After = nil
+	if filter.Cursor != nil {
+		cursor, err := decodeOrderCursor(*filter.Cursor)
+		if err != nil {
+			return err
+		}
+		filter.After = &cursor
+	}
+
+	return nil
+}
+
+func encodeOrderCursor(cursor dtos.OrderCursorDTO) (string, error) {
+	body, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode order cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(body), nil
+}
+
+func decodeOrderCursor(value string) (dtos.OrderCursorDTO, error) {
+	var cursor dtos.OrderCursorDTO
+
+	body, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, &exceptions.InvalidOrderFilterException{Message: "Invalid cursor"}
+	}
+	if err := json.Unmarshal(body, &cursor); err != nil || cursor.ID == "" || cursor.CreatedAt.IsZero() {
+		return cursor, &exceptions.InvalidOrderFilterException{Message: "Invalid cursor"}
+	}
+
+	return cursor, nil
 }
@@ -1,11 +1,14 @@
 package use_cases
 
 import (
+	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	"microservice/internal/adapters/dtos"
 	"microservice/internal/domain/entities"
+	"microservice/internal/domain/exceptions"
 )
 
 func TestFindAllOrdersUseCase_FilterDTOStructure(t *testing.T) {
@@ -124,21 +127,21 @@ func TestFindAllOrdersUseCase_Execute_Success(t *testing.T) {
 	// Add test orders
 	customerID := "customer-123"
 	status, _ := entities.NewOrderStatus("pending", "Pending")
-	order1, _ := entities.NewOrderWithItems("order-1", &customerID, 25.0, *status, []entities.OrderItem{}, time.Now(), nil)
-	order2, _ := entities.NewOrderWithItems("order-2", &customerID, 35.0, *status, []entities.OrderItem{}, time.Now(), nil)
+	order1, _ := entities.NewOrderWithItems("order-1", &customerID, brl("25.00"), *status, []entities.OrderItem{}, time.Now(), nil)
+	order2, _ := entities.NewOrderWithItems("order-2", &customerID, brl("35.00"), *status, []entities.OrderItem{}, time.Now(), nil)
 	
 	mockGateway.AddOrder(order1)
 	mockGateway.AddOrder(order2)
 
 	filter := dtos.OrderFilterDTO{}
-	orders, err := uc.Execute(filter)
+	page, err := uc.Execute(context.Background(), filter)
 
 	if err != nil {
 		t.Errorf("Expected no error for successful find all, got %v", err)
 	}
 
-	if len(orders) != 2 {
-		t.Errorf("Expected 2 orders, got %d", len(orders))
+	if len(page.Orders) != 2 {
+		t.Errorf("Expected 2 orders, got %d", len(page.Orders))
 	}
 }
 
@@ -147,14 +150,14 @@ func TestFindAllOrdersUseCase_Execute_EmptyResult(t *testing.T) {
 	uc := NewFindAllOrdersUseCase(mockGateway)
 
 	filter := dtos.OrderFilterDTO{}
-	orders, err := uc.Execute(filter)
+	page, err := uc.Execute(context.Background(), filter)
 
 	if err != nil {
 		t.Errorf("Expected no error for empty result, got %v", err)
 	}
 
-	if len(orders) != 0 {
-		t.Errorf("Expected 0 orders, got %d", len(orders))
+	if len(page.Orders) != 0 {
+		t.Errorf("Expected 0 orders, got %d", len(page.Orders))
 	}
 }
 
@@ -166,7 +169,7 @@ func TestFindAllOrdersUseCase_Execute_WithFilter(t *testing.T) {
 	customerID := "customer-123"
 	statusID := "pending"
 	status, _ := entities.NewOrderStatus(statusID, "Pending")
-	order, _ := entities.NewOrderWithItems("order-1", &customerID, 25.0, *status, []entities.OrderItem{}, time.Now(), nil)
+	order, _ := entities.NewOrderWithItems("order-1", &customerID, brl("25.00"), *status, []entities.OrderItem{}, time.Now(), nil)
 	
 	mockGateway.AddOrder(order)
 
@@ -174,13 +177,122 @@ func TestFindAllOrdersUseCase_Execute_WithFilter(t *testing.T) {
 		CustomerID: &customerID,
 		StatusID:   &statusID,
 	}
-	orders, err := uc.Execute(filter)
+	page, err := uc.Execute(context.Background(), filter)
 
 	if err != nil {
 		t.Errorf("Expected no error for filtered find all, got %v", err)
 	}
 
-	if len(orders) != 1 {
-		t.Errorf("Expected 1 order, got %d", len(orders))
+	if len(page.Orders) != 1 {
+		t.Errorf("Expected 1 order, got %d", len(page.Orders))
 	}
-}
\ No newline at end of file
+}
+func addPaginationOrders(mockGateway *MockOrderGateway, count int) time.Time {
+	status, _ := entities.NewOrderStatus("status-1", entities.ORDER_STATUS_RECEIVED)
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < count; i++ {
+		id := fmt.Sprintf("order-%02d", i)
+		order, _ := entities.NewOrderWithItems(id, nil, brl("10.00"), *status, []entities.OrderItem{}, base.Add(time.Duration(i)*time.Minute), nil)
+		mockGateway.AddOrder(order)
+	}
+	return base
+}
+
+func TestFindAllOrdersUseCase_Execute_PaginatesWithCursor(t *testing.T) {
+	mockGateway := NewMockOrderGateway()
+	uc := NewFindAllOrdersUseCase(mockGateway)
+	addPaginationOrders(mockGateway, 5)
+
+	first, err := uc.Execute(context.Background(), dtos.OrderFilterDTO{Limit: 2})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(first.Orders) != 2 || first.Orders[0].ID != "order-04" || first.Orders[1].ID != "order-03" {
+		t.Fatalf("Unexpected first page: %+v", first.Orders)
+	}
+	if first.Total != 5 {
+		t.Errorf("Expected total 5, got %d", first.Total)
+	}
+	if first.NextCursor == nil {
+		t.Fatal("Expected next cursor on first page")
+	}
+
+	second, err := uc.Execute(context.Background(), dtos.OrderFilterDTO{Limit: 2, Cursor: first.NextCursor})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(second.Orders) != 2 || second.Orders[0].ID != "order-02" || second.Orders[1].ID != "order-01" {
+		t.Fatalf("Unexpected second page: %+v", second.Orders)
+	}
+
+	last, err := uc.Execute(context.Background(), dtos.OrderFilterDTO{Limit: 2, Cursor: second.NextCursor})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(last.Orders) != 1 || last.Orders[0].ID != "order-00" {
+		t.Fatalf("Unexpected last page: %+v", last.Orders)
+	}
+	if last.NextCursor != nil {
+		t.Error("Expected no next cursor on last page")
+	}
+}
+
+func TestFindAllOrdersUseCase_Execute_AscendingSort(t *testing.T) {
+	mockGateway := NewMockOrderGateway()
+	uc := NewFindAllOrdersUseCase(mockGateway)
+	addPaginationOrders(mockGateway, 3)
+
+	page, err := uc.Execute(context.Background(), dtos.OrderFilterDTO{Limit: 2, Sort: dtos.ORDER_SORT_CREATED_AT_ASC})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if page.Orders[0].ID != "order-00" || page.Orders[1].ID != "order-01" {
+		t.Errorf("Unexpected ascending page: %+v", page.Orders)
+	}
+}
+
+func TestFindAllOrdersUseCase_Execute_InvalidFilter(t *testing.T) {
+	invalidCursor := "not-a-cursor"
+	from := time.Now()
+	to := from.Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		filter dtos.OrderFilterDTO
+	}{
+		{"limit above maximum", dtos.OrderFilterDTO{Limit: MAX_ORDER_PAGE_LIMIT + 1}},
+		{"negative limit", dtos.OrderFilterDTO{Limit: -1}},
+		{"unknown sort", dtos.OrderFilterDTO{Sort: "amount"}},
+		{"malformed cursor", dtos.OrderFilterDTO{Cursor: &invalidCursor}},
+		{"inverted date range", dtos.OrderFilterDTO{CreatedAtFrom: &from, CreatedAtTo: &to}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewFindAllOrdersUseCase(NewMockOrderGateway())
+
+			_, err := uc.Execute(context.Background(), tt.filter)
+
+			if _, ok := err.(*exceptions.InvalidOrderFilterException); !ok {
+				t.Errorf("Expected InvalidOrderFilterException, got %v", err)
+			}
+		})
+	}
+}
+
+func TestFindAllOrdersUseCase_Execute_DefaultLimit(t *testing.T) {
+	mockGateway := NewMockOrderGateway()
+	uc := NewFindAllOrdersUseCase(mockGateway)
+	addPaginationOrders(mockGateway, DEFAULT_ORDER_PAGE_LIMIT+1)
+
+	page, err := uc.Execute(context.Background(), dtos.OrderFilterDTO{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(page.Orders) != DEFAULT_ORDER_PAGE_LIMIT {
+		t.Errorf("Expected %d orders, got %d", DEFAULT_ORDER_PAGE_LIMIT, len(page.Orders))
+	}
+	if page.NextCursor == nil {
+		t.Error("Expected next cursor when more orders exist")
+	}
+}
@@ -1,6 +1,8 @@
 package use_cases
 
 import (
+	"context"
+
 	"microservice/internal/domain/entities"
 	"microservice/internal/interfaces"
 )
@@ -15,6 +17,6 @@ func NewFindAllOrderStatusUseCase(orderStatusGateway interfaces.IOrderStatusGate
 	}
 }
 
-func (uc *FindAllOrderStatusUseCase) Execute() ([]entities.OrderStatus, error) {
-	return uc.orderStatusGateway.FindAll()
+func (uc *FindAllOrderStatusUseCase) Execute(ctx context.Context) ([]entities.OrderStatus, error) {
+	return uc.orderStatusGateway.FindAll(ctx)
 }
@@ -1,6 +1,7 @@
 package use_cases
 
 import (
+	"context"
 	"testing"
 
 	"microservice/internal/adapters/dtos"
@@ -117,7 +118,7 @@ func TestFindAllOrderStatusUseCase_Execute_Success(t *testing.T) {
 	mockGateway.AddStatus(status1)
 	mockGateway.AddStatus(status2)
 
-	statuses, err := uc.Execute()
+	statuses, err := uc.Execute(context.Background())
 
 	if err != nil {
 		t.Errorf("Expected no error for successful find all, got %v", err)
@@ -132,7 +133,7 @@ func TestFindAllOrderStatusUseCase_Execute_EmptyResult(t *testing.T) {
 	mockGateway := NewMockOrderStatusGateway()
 	uc := NewFindAllOrderStatusUseCase(mockGateway)
 
-	statuses, err := uc.Execute()
+	statuses, err := uc.Execute(context.Background())
 
 	if err != nil {
 		t.Errorf("Expected no error for empty result, got %v", err)
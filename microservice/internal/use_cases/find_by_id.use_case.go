@@ -1,6 +1,8 @@
 package use_cases
 
 import (
+	"context"
+
 	"microservice/internal/domain/entities"
 	"microservice/internal/domain/exceptions"
 	"microservice/internal/interfaces"
@@ -16,13 +18,13 @@ func NewFindOrderByIDUseCase(orderGateway interfaces.IOrderGateway) *FindOrderBy
 	}
 }
 
-func (uc *FindOrderByIDUseCase) Execute(id string) (entities.Order, error) {
+func (uc *FindOrderByIDUseCase) Execute(ctx context.Context, id string) (entities.Order, error) {
 	err := entities.ValidateID(id)
 	if err != nil {
 		return entities.Order{}, err
 	}
 
-	order, err := uc.orderGateway.FindByID(id)
+	order, err := uc.orderGateway.FindByID(ctx, id)
 	if err != nil {
 		return entities.Order{}, &exceptions.OrderNotFoundException{}
 	}
@@ -1,6 +1,7 @@
 package use_cases
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -75,7 +76,7 @@ func TestFindOrderByIDUseCase_Execute_InvalidID(t *testing.T) {
 	mockGateway := NewMockOrderGateway()
 	uc := NewFindOrderByIDUseCase(mockGateway)
 
-	_, err := uc.Execute("invalid-id")
+	_, err := uc.Execute(context.Background(), "invalid-id")
 	if err == nil {
 		t.Error("Expected error for invalid ID")
 	}
@@ -89,7 +90,7 @@ func TestFindOrderByIDUseCase_Execute_EmptyID(t *testing.T) {
 	mockGateway := NewMockOrderGateway()
 	uc := NewFindOrderByIDUseCase(mockGateway)
 
-	_, err := uc.Execute("")
+	_, err := uc.Execute(context.Background(), "")
 	if err == nil {
 		t.Error("Expected error for empty ID")
 	}
@@ -107,7 +108,7 @@ func TestFindOrderByIDUseCase_Execute_ReturnsEmptyOrderOnError(t *testing.T) {
 	mockGateway := NewMockOrderGateway()
 	uc := NewFindOrderByIDUseCase(mockGateway)
 
-	order, err := uc.Execute("invalid-id")
+	order, err := uc.Execute(context.Background(), "invalid-id")
 	if err == nil {
 		t.Error("Expected error for invalid ID")
 	}
@@ -127,10 +128,10 @@ func TestFindOrderByIDUseCase_Execute_Success(t *testing.T) {
 	validID := "550e8400-e29b-41d4-a716-446655440000"
 	customerID := "customer-123"
 	status, _ := entities.NewOrderStatus("pending", "Pending")
-	expectedOrder, _ := entities.NewOrderWithItems(validID, &customerID, 25.0, *status, []entities.OrderItem{}, time.Now(), nil)
+	expectedOrder, _ := entities.NewOrderWithItems(validID, &customerID, brl("25.00"), *status, []entities.OrderItem{}, time.Now(), nil)
 	mockGateway.AddOrder(expectedOrder)
 
-	order, err := uc.Execute(validID)
+	order, err := uc.Execute(context.Background(), validID)
 	if err != nil {
 		t.Errorf("Expected no error for successful find, got %v", err)
 	}
@@ -150,7 +151,7 @@ func TestFindOrderByIDUseCase_Execute_OrderNotFound(t *testing.T) {
 
 	validID := "550e8400-e29b-41d4-a716-446655440000"
 	
-	order, err := uc.Execute(validID)
+	order, err := uc.Execute(context.Background(), validID)
 	if err == nil {
 		t.Error("Expected error when order not found")
 	}
@@ -171,7 +172,7 @@ func TestFindOrderByIDUseCase_Execute_GatewayError(t *testing.T) {
 
 	validID := "550e8400-e29b-41d4-a716-446655440000"
 	
-	order, err := uc.Execute(validID)
+	order, err := uc.Execute(context.Background(), validID)
 	if err == nil {
 		t.Error("Expected error when gateway fails")
 	}
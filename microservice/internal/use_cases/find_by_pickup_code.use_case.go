@@ -0,0 +1,40 @@
+package use_cases
+
+import (
+	"context"
+	"time"
+
+	"microservice/internal/domain/entities"
+	"microservice/internal/domain/exceptions"
+	"microservice/internal/domain/value_objects"
+	"microservice/internal/interfaces"
+)
+
+type FindOrderByPickupCodeUseCase struct {
+	orderGateway   interfaces.IOrderGateway
+	pickupLocation *time.Location
+	now            func() time.Time
+}
+
+func NewFindOrderByPickupCodeUseCase(orderGateway interfaces.IOrderGateway, pickupLocation *time.Location) *FindOrderByPickupCodeUseCase {
+	return &FindOrderByPickupCodeUseCase{
+		orderGateway:   orderGateway,
+		pickupLocation: pickupLocation,
+		now:            time.Now,
+	}
+}
+
+// Só pedidos de hoje; códigos de dias anteriores foram reutilizados
+func (uc *FindOrderByPickupCodeUseCase) Execute(ctx context.Context, code string) (entities.Order, error) {
+	pickupCode, err := value_objects.ParsePickupCode(code)
+	if err != nil {
+		return entities.Order{}, err
+	}
+
+	order, err := uc.orderGateway.FindByPickupCode(ctx, entities.PickupDay(uc.now(), uc.pickupLocation), pickupCode)
+	if err != nil {
+		return entities.Order{}, &exceptions.OrderNotFoundException{}
+	}
+
+	return *order, nil
+}
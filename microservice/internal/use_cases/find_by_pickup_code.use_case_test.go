@@ -0,0 +1,116 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"microservice/internal/adapters/dtos"
+	"microservice/internal/domain/entities"
+	"microservice/internal/domain/exceptions"
+)
+
+func TestCreateOrderUseCase_Execute_AssignsDailyPickupCodes(t *testing.T) {
+	uc, _ := newPricingTestUseCase(NewMockProductCatalogGateway())
+	items := []dtos.CreateOrderItemDTO{{ProductID: "product-1", Quantity: 1}}
+
+	for _, want := range []string{"A001", "A002"} {
+		order, err := uc.Execute(context.Background(), dtos.CreateOrderDTO{Items: items})
+		if err != nil {
+			t.Fatalf("Execute() unexpected error: %v", err)
+		}
+		if order.PickupCode.Value() != want {
+			t.Errorf("Execute() PickupCode = %s, want %s", order.PickupCode.Value(), want)
+		}
+		if order.PickupDate != entities.PickupDay(order.CreatedAt, time.UTC) {
+			t.Errorf("Execute() PickupDate = %s, want %s", order.PickupDate, entities.PickupDay(order.CreatedAt, time.UTC))
+		}
+	}
+}
+
+func TestFindOrderByPickupCodeUseCase_Execute_Success(t *testing.T) {
+	createUC, orderGateway := newPricingTestUseCase(NewMockProductCatalogGateway())
+	created, err := createUC.Execute(context.Background(), dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{{ProductID: "product-1", Quantity: 1}}})
+	if err != nil {
+		t.Fatalf("Create unexpected error: %v", err)
+	}
+
+	uc := NewFindOrderByPickupCodeUseCase(orderGateway, time.UTC)
+	uc.now = func() time.Time { return created.CreatedAt }
+
+	// O código é aceito em minúsculas e com espaços
+	order, err := uc.Execute(context.Background(), " a001 ")
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if order.ID != created.ID {
+		t.Errorf("Execute() ID = %s, want %s", order.ID, created.ID)
+	}
+}
+
+func TestFindOrderByPickupCodeUseCase_Execute_Errors(t *testing.T) {
+	createUC, orderGateway := newPricingTestUseCase(NewMockProductCatalogGateway())
+	created, err := createUC.Execute(context.Background(), dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{{ProductID: "product-1", Quantity: 1}}})
+	if err != nil {
+		t.Fatalf("Create unexpected error: %v", err)
+	}
+
+	t.Run("invalid code", func(t *testing.T) {
+		_, err := NewFindOrderByPickupCodeUseCase(orderGateway, time.UTC).Execute(context.Background(), "1A")
+		if _, ok := err.(*exceptions.InvalidOrderDataException); !ok {
+			t.Errorf("Execute() error = %T, want *exceptions.InvalidOrderDataException", err)
+		}
+	})
+
+	t.Run("unknown code", func(t *testing.T) {
+		uc := NewFindOrderByPickupCodeUseCase(orderGateway, time.UTC)
+		uc.now = func() time.Time { return created.CreatedAt }
+
+		_, err := uc.Execute(context.Background(), "Z999")
+		if _, ok := err.(*exceptions.OrderNotFoundException); !ok {
+			t.Errorf("Execute() error = %T, want *exceptions.OrderNotFoundException", err)
+		}
+	})
+
+	t.Run("code from another day", func(t *testing.T) {
+		uc := NewFindOrderByPickupCodeUseCase(orderGateway, time.UTC)
+		uc.now = func() time.Time { return created.CreatedAt.AddDate(0, 0, 1) }
+
+		_, err := uc.Execute(context.Background(), created.PickupCode.Value())
+		if _, ok := err.(*exceptions.OrderNotFoundException); !ok {
+			t.Errorf("Execute() error = %T, want *exceptions.OrderNotFoundException", err)
+		}
+	})
+}
+
+func TestFindOrderByPickupCodeUseCase_Execute_NearMidnight(t *testing.T) {
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatalf("LoadLocation() unexpected error: %v", err)
+	}
+
+	createUC, orderGateway := newPricingTestUseCase(NewMockProductCatalogGateway())
+	createUC.pickupLocation = saoPaulo
+	created, err := createUC.Execute(context.Background(), dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{{ProductID: "product-1", Quantity: 1}}})
+	if err != nil {
+		t.Fatalf("Create unexpected error: %v", err)
+	}
+	if created.PickupDate != entities.PickupDay(created.CreatedAt, saoPaulo) {
+		t.Fatalf("Execute() PickupDate = %s, want %s", created.PickupDate, entities.PickupDay(created.CreatedAt, saoPaulo))
+	}
+
+	// 23:30 do mesmo dia no restaurante, que em UTC já é o dia seguinte
+	year, month, day := created.CreatedAt.In(saoPaulo).Date()
+	lateNight := time.Date(year, month, day, 23, 30, 0, 0, saoPaulo).UTC()
+
+	uc := NewFindOrderByPickupCodeUseCase(orderGateway, saoPaulo)
+	uc.now = func() time.Time { return lateNight }
+
+	order, err := uc.Execute(context.Background(), created.PickupCode.Value())
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if order.ID != created.ID {
+		t.Errorf("Execute() ID = %s, want %s", order.ID, created.ID)
+	}
+}
@@ -0,0 +1,37 @@
+package use_cases
+
+import (
+	"context"
+
+	"microservice/internal/domain/entities"
+	"microservice/internal/domain/exceptions"
+	"microservice/internal/interfaces"
+)
+
+type FindGuestOrderUseCase struct {
+	orderGateway interfaces.IOrderGateway
+}
+
+func NewFindGuestOrderUseCase(orderGateway interfaces.IOrderGateway) *FindGuestOrderUseCase {
+	return &FindGuestOrderUseCase{
+		orderGateway: orderGateway,
+	}
+}
+
+// Token errado dá o mesmo erro que pedido inexistente, para não revelar IDs
+func (uc *FindGuestOrderUseCase) Execute(ctx context.Context, id string, guestToken string) (entities.Order, error) {
+	err := entities.ValidateID(id)
+	if err != nil {
+		return entities.Order{}, err
+	}
+
+	order, err := uc.orderGateway.FindByID(ctx, id)
+	if err != nil {
+		return entities.Order{}, &exceptions.OrderNotFoundException{}
+	}
+	if !order.HasGuestToken(guestToken) {
+		return entities.Order{}, &exceptions.OrderNotFoundException{}
+	}
+
+	return *order, nil
+}
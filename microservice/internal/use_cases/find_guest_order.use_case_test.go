@@ -0,0 +1,74 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+
+	"microservice/internal/adapters/dtos"
+	"microservice/internal/domain/exceptions"
+)
+
+func TestCreateOrderUseCase_Execute_IssuesGuestToken(t *testing.T) {
+	uc, _ := newPricingTestUseCase(NewMockProductCatalogGateway())
+	items := []dtos.CreateOrderItemDTO{{ProductID: "product-1", Quantity: 1}}
+
+	guestOrder, err := uc.Execute(context.Background(), dtos.CreateOrderDTO{Items: items})
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if guestOrder.GuestToken == "" || !guestOrder.HasGuestToken(guestOrder.GuestToken) {
+		t.Error("Execute() did not issue a guest token for an order without customer")
+	}
+
+	customerID := "customer-123"
+	customerOrder, err := uc.Execute(context.Background(), dtos.CreateOrderDTO{CustomerID: &customerID, Items: items})
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if customerOrder.GuestToken != "" || customerOrder.GuestTokenHash != nil {
+		t.Error("Execute() issued a guest token for an order with customer")
+	}
+}
+
+func TestFindGuestOrderUseCase_Execute(t *testing.T) {
+	createUC, orderGateway := newPricingTestUseCase(NewMockProductCatalogGateway())
+	created, err := createUC.Execute(context.Background(), dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{{ProductID: "product-1", Quantity: 1}}})
+	if err != nil {
+		t.Fatalf("Create unexpected error: %v", err)
+	}
+
+	uc := NewFindGuestOrderUseCase(orderGateway)
+
+	order, err := uc.Execute(context.Background(), created.ID, created.GuestToken)
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if order.ID != created.ID {
+		t.Errorf("Execute() ID = %s, want %s", order.ID, created.ID)
+	}
+
+	tests := []struct {
+		name  string
+		id    string
+		token string
+	}{
+		{"wrong token", created.ID, "wrong-token"},
+		{"empty token", created.ID, ""},
+		{"unknown order", "550e8400-e29b-41d4-a716-446655440099", created.GuestToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.Execute(context.Background(), tt.id, tt.token)
+			if _, ok := err.(*exceptions.OrderNotFoundException); !ok {
+				t.Errorf("Execute() error = %T, want *exceptions.OrderNotFoundException", err)
+			}
+		})
+	}
+
+	t.Run("invalid id", func(t *testing.T) {
+		_, err := uc.Execute(context.Background(), "invalid-id", created.GuestToken)
+		if _, ok := err.(*exceptions.InvalidOrderDataException); !ok {
+			t.Errorf("Execute() error = %T, want *exceptions.InvalidOrderDataException", err)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package use_cases
+
+import (
+	"context"
+	"time"
+
+	"microservice/internal/domain/entities"
+	"microservice/internal/interfaces"
+)
+
+// Pedidos mais antigos que isso saem do painel mesmo sem terem sido entregues
+const DEFAULT_ORDER_BOARD_MAX_AGE = 2 * time.Hour
+
+type FindOrderBoardUseCase struct {
+	orderGateway       interfaces.IOrderGateway
+	orderStatusGateway interfaces.IOrderStatusGateway
+	now                func() time.Time
+}
+
+func NewFindOrderBoardUseCase(orderGateway interfaces.IOrderGateway, orderStatusGateway interfaces.IOrderStatusGateway) *FindOrderBoardUseCase {
+	return &FindOrderBoardUseCase{
+		orderGateway:       orderGateway,
+		orderStatusGateway: orderStatusGateway,
+		now:                time.Now,
+	}
+}
+
+// maxAge zero usa DEFAULT_ORDER_BOARD_MAX_AGE
+func (uc *FindOrderBoardUseCase) Execute(ctx context.Context, maxAge time.Duration) (entities.OrderBoard, error) {
+	if maxAge <= 0 {
+		maxAge = DEFAULT_ORDER_BOARD_MAX_AGE
+	}
+
+	statuses, err := uc.orderStatusGateway.FindAll(ctx)
+	if err != nil {
+		return entities.OrderBoard{}, err
+	}
+
+	var boardStatuses []entities.OrderStatus
+	for _, status := range statuses {
+		for _, name := range entities.ORDER_BOARD_STATUSES {
+			if status.Name.Value() == name {
+				boardStatuses = append(boardStatuses, status)
+			}
+		}
+	}
+
+	now := uc.now()
+	if len(boardStatuses) == 0 {
+		return entities.NewOrderBoard(nil, nil, now), nil
+	}
+
+	entries, err := uc.orderGateway.FindBoard(ctx, boardStatuses, now.Add(-maxAge))
+	if err != nil {
+		return entities.OrderBoard{}, err
+	}
+
+	return entities.NewOrderBoard(boardStatuses, entries, now), nil
+}
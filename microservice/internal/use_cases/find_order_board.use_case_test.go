@@ -0,0 +1,117 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"microservice/internal/domain/entities"
+)
+
+func newBoardTestGateways(t *testing.T) (*MockOrderGateway, *MockOrderStatusGateway, map[string]*entities.OrderStatus) {
+	t.Helper()
+	statusGateway := NewMockOrderStatusGateway()
+	statuses := make(map[string]*entities.OrderStatus)
+	for id, name := range map[string]string{
+		"status-1": entities.ORDER_STATUS_RECEIVED,
+		"status-2": entities.ORDER_STATUS_CONFIRMED,
+		"status-3": entities.ORDER_STATUS_PREPARING,
+		"status-4": entities.ORDER_STATUS_READY,
+		"status-5": entities.ORDER_STATUS_DELIVERED,
+		"status-6": entities.ORDER_STATUS_CANCELLED,
+	} {
+		status, _ := entities.NewOrderStatus(id, name)
+		statusGateway.AddStatus(status)
+		statuses[name] = status
+	}
+	return NewMockOrderGateway(), statusGateway, statuses
+}
+
+func addBoardTestOrder(orderGateway *MockOrderGateway, id string, status *entities.OrderStatus, createdAt time.Time) {
+	order, _ := entities.NewOrder(id, nil)
+	order.Status = *status
+	order.CreatedAt = createdAt
+	orderGateway.AddOrder(order)
+}
+
+func TestFindOrderBoardUseCase_Execute(t *testing.T) {
+	orderGateway, statusGateway, statuses := newBoardTestGateways(t)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	addBoardTestOrder(orderGateway, "received-new", statuses[entities.ORDER_STATUS_RECEIVED], now.Add(-5*time.Minute))
+	addBoardTestOrder(orderGateway, "received-old", statuses[entities.ORDER_STATUS_RECEIVED], now.Add(-25*time.Minute))
+	addBoardTestOrder(orderGateway, "ready", statuses[entities.ORDER_STATUS_READY], now.Add(-15*time.Minute))
+	addBoardTestOrder(orderGateway, "delivered", statuses[entities.ORDER_STATUS_DELIVERED], now.Add(-10*time.Minute))
+	addBoardTestOrder(orderGateway, "cancelled", statuses[entities.ORDER_STATUS_CANCELLED], now.Add(-10*time.Minute))
+	addBoardTestOrder(orderGateway, "stale", statuses[entities.ORDER_STATUS_PREPARING], now.Add(-45*time.Minute))
+
+	uc := NewFindOrderBoardUseCase(orderGateway, statusGateway)
+	uc.now = func() time.Time { return now }
+
+	board, err := uc.Execute(context.Background(), 30*time.Minute)
+
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	want := map[string][]string{
+		entities.ORDER_STATUS_READY:     {"ready"},
+		entities.ORDER_STATUS_PREPARING: {},
+		entities.ORDER_STATUS_CONFIRMED: {},
+		entities.ORDER_STATUS_RECEIVED:  {"received-old", "received-new"},
+	}
+	if len(board.Groups) != len(entities.ORDER_BOARD_STATUSES) {
+		t.Fatalf("Execute() groups = %d, want %d", len(board.Groups), len(entities.ORDER_BOARD_STATUSES))
+	}
+	for i, group := range board.Groups {
+		name := group.Status.Name.Value()
+		if name != entities.ORDER_BOARD_STATUSES[i] {
+			t.Errorf("Execute() group %d = %s, want %s", i, name, entities.ORDER_BOARD_STATUSES[i])
+		}
+		var ids []string
+		for _, entry := range group.Orders {
+			ids = append(ids, entry.OrderID)
+		}
+		if len(ids) != len(want[name]) {
+			t.Errorf("Execute() %s orders = %v, want %v", name, ids, want[name])
+			continue
+		}
+		for j := range ids {
+			if ids[j] != want[name][j] {
+				t.Errorf("Execute() %s orders = %v, want %v", name, ids, want[name])
+			}
+		}
+	}
+	if !board.GeneratedAt.Equal(now) {
+		t.Errorf("Execute() GeneratedAt = %v, want %v", board.GeneratedAt, now)
+	}
+}
+
+func TestFindOrderBoardUseCase_Execute_DefaultMaxAge(t *testing.T) {
+	orderGateway, statusGateway, statuses := newBoardTestGateways(t)
+	now := time.Now()
+	addBoardTestOrder(orderGateway, "recent", statuses[entities.ORDER_STATUS_RECEIVED], now.Add(-DEFAULT_ORDER_BOARD_MAX_AGE+time.Minute))
+	addBoardTestOrder(orderGateway, "stale", statuses[entities.ORDER_STATUS_RECEIVED], now.Add(-DEFAULT_ORDER_BOARD_MAX_AGE-time.Minute))
+
+	uc := NewFindOrderBoardUseCase(orderGateway, statusGateway)
+	uc.now = func() time.Time { return now }
+
+	board, err := uc.Execute(context.Background(), 0)
+
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	received := board.Groups[len(board.Groups)-1]
+	if len(received.Orders) != 1 || received.Orders[0].OrderID != "recent" {
+		t.Errorf("Execute() received orders = %+v, want only recent", received.Orders)
+	}
+}
+
+func TestFindOrderBoardUseCase_Execute_NoBoardStatuses(t *testing.T) {
+	board, err := NewFindOrderBoardUseCase(NewMockOrderGateway(), NewMockOrderStatusGateway()).Execute(context.Background(), time.Hour)
+
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if len(board.Groups) != 0 {
+		t.Errorf("Execute() groups = %+v, want none", board.Groups)
+	}
+}
@@ -0,0 +1,32 @@
+package use_cases
+
+import (
+	"context"
+
+	"microservice/internal/domain/entities"
+	"microservice/internal/domain/exceptions"
+	"microservice/internal/interfaces"
+)
+
+type FindOrderStatusHistoryUseCase struct {
+	orderGateway interfaces.IOrderGateway
+}
+
+func NewFindOrderStatusHistoryUseCase(orderGateway interfaces.IOrderGateway) *FindOrderStatusHistoryUseCase {
+	return &FindOrderStatusHistoryUseCase{
+		orderGateway: orderGateway,
+	}
+}
+
+func (uc *FindOrderStatusHistoryUseCase) Execute(ctx context.Context, orderID string) ([]entities.OrderStatusChange, error) {
+	err := entities.ValidateID(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.orderGateway.FindByID(ctx, orderID); err != nil {
+		return nil, &exceptions.OrderNotFoundException{}
+	}
+
+	return uc.orderGateway.FindStatusHistory(ctx, orderID)
+}
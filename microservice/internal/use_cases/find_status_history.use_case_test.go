@@ -0,0 +1,77 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"microservice/internal/adapters/dtos"
+	"microservice/internal/adapters/gateways"
+	"microservice/internal/domain/entities"
+	"microservice/internal/domain/exceptions"
+)
+
+func TestFindOrderStatusHistoryUseCase_Execute_InvalidID(t *testing.T) {
+	uc := NewFindOrderStatusHistoryUseCase(NewMockOrderGateway())
+
+	history, err := uc.Execute(context.Background(), "invalid-id")
+
+	assert.Nil(t, history)
+	assert.IsType(t, &exceptions.InvalidOrderDataException{}, err)
+}
+
+func TestFindOrderStatusHistoryUseCase_Execute_OrderNotFound(t *testing.T) {
+	uc := NewFindOrderStatusHistoryUseCase(NewMockOrderGateway())
+
+	history, err := uc.Execute(context.Background(), "550e8400-e29b-41d4-a716-446655440000")
+
+	assert.Nil(t, history)
+	assert.IsType(t, &exceptions.OrderNotFoundException{}, err)
+}
+
+func TestFindOrderStatusHistoryUseCase_Execute_RecordsEveryTransition(t *testing.T) {
+	orderDS := newTestOrderDataSource()
+	statusDS := newTestOrderStatusDataSource()
+	statusDS.addStatus("status-2", "Confirmado")
+	statusDS.addStatus("status-3", "Em preparação")
+	orderGateway := gateways.NewOrderGateway(orderDS)
+	statusGateway := gateways.NewOrderStatusGateway(statusDS)
+
+	customerID := "customer-1"
+	created, err := NewCreateOrderUseCase(orderGateway, statusGateway, NewMockProductCatalogGateway(), time.UTC).Execute(context.Background(), dtos.CreateOrderDTO{CustomerID: &customerID, Items: []dtos.CreateOrderItemDTO{
+		{ProductID: "product-1", Quantity: 1, Price: "10.00"},
+	}})
+	assert.NoError(t, err)
+
+	_, err = NewUpdateOrderUseCase(orderGateway, statusGateway).Execute(context.Background(), dtos.UpdateOrderDTO{
+		ID:       created.ID,
+		StatusID: "status-2",
+	})
+	assert.NoError(t, err)
+
+	_, err = NewUpdateOrderStatusUseCase(orderGateway, statusGateway).Execute(context.Background(), UpdateOrderStatusDTO{
+		OrderID: created.ID,
+		Status:  "Em preparação",
+		Source:  entities.STATUS_CHANGE_SOURCE_KITCHEN,
+	})
+	assert.NoError(t, err)
+
+	history, err := NewFindOrderStatusHistoryUseCase(orderGateway).Execute(context.Background(), created.ID)
+	assert.NoError(t, err)
+	assert.Len(t, history, 3)
+
+	assert.Nil(t, history[0].PreviousStatus)
+	assert.Equal(t, "Recebido", history[0].NewStatus.Name.Value())
+	assert.Equal(t, entities.STATUS_CHANGE_SOURCE_REST, history[0].Source)
+	assert.Equal(t, &customerID, history[0].Actor)
+
+	assert.Equal(t, "Recebido", history[1].PreviousStatus.Name.Value())
+	assert.Equal(t, "Confirmado", history[1].NewStatus.Name.Value())
+	assert.Equal(t, entities.STATUS_CHANGE_SOURCE_REST, history[1].Source)
+
+	assert.Equal(t, "Confirmado", history[2].PreviousStatus.Name.Value())
+	assert.Equal(t, "Em preparação", history[2].NewStatus.Name.Value())
+	assert.Equal(t, entities.STATUS_CHANGE_SOURCE_KITCHEN, history[2].Source)
+}
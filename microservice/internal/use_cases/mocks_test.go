@@ -1,21 +1,42 @@
 package use_cases
 
 import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"microservice/internal/adapters/brokers"
 	"microservice/internal/adapters/dtos"
 	"microservice/internal/domain/entities"
 	"microservice/internal/domain/exceptions"
+	"microservice/internal/domain/value_objects"
 )
 
 type MockOrderGateway struct {
-	orders             map[string]*entities.Order
-	shouldFailFindByID bool
-	shouldFailUpdate   bool
-	shouldFailCreate   bool
+	orders                map[string]*entities.Order
+	shouldFailFindByID    bool
+	shouldFailUpdate      bool
+	shouldFailCreate      bool
+	shouldFailApplyCoupon bool
+	shouldFailClaim       bool
+	history               []entities.OrderStatusChange
+
+	// Pedidos removidos e quando foram removidos
+	deleted    map[string]*entities.Order
+	deletedAt  map[string]time.Time
+	purgeCalls int
+
+	pickupSequences map[string]int
 }
 
 func NewMockOrderGateway() *MockOrderGateway {
 	return &MockOrderGateway{
-		orders: make(map[string]*entities.Order),
+		orders:    make(map[string]*entities.Order),
+		deleted:   make(map[string]*entities.Order),
+		deletedAt: make(map[string]time.Time),
+
+		pickupSequences: make(map[string]int),
 	}
 }
 
@@ -35,15 +56,15 @@ func (m *MockOrderGateway) SetShouldFailCreate(fail bool) {
 	m.shouldFailCreate = fail
 }
 
-func (m *MockOrderGateway) Create(order entities.Order) error {
+func (m *MockOrderGateway) Create(ctx context.Context, order entities.Order, events ...brokers.OrderEvent) error {
 	if m.shouldFailCreate {
 		return &exceptions.InvalidOrderDataException{Message: "Create failed"}
 	}
-	m.orders[order.ID] = &order
+	m.storeOrder(order)
 	return nil
 }
 
-func (m *MockOrderGateway) FindByID(id string) (*entities.Order, error) {
+func (m *MockOrderGateway) FindByID(ctx context.Context, id string) (*entities.Order, error) {
 	if m.shouldFailFindByID {
 		return nil, &exceptions.OrderNotFoundException{}
 	}
@@ -55,28 +76,192 @@ func (m *MockOrderGateway) FindByID(id string) (*entities.Order, error) {
 	return order, nil
 }
 
-func (m *MockOrderGateway) FindAll(filter dtos.OrderFilterDTO) ([]entities.Order, error) {
+func (m *MockOrderGateway) FindAll(ctx context.Context, filter dtos.OrderFilterDTO) ([]entities.Order, error) {
 	orders := make([]entities.Order, 0, len(m.orders))
 	for _, order := range m.orders {
 		orders = append(orders, *order)
 	}
+
+	ascending := filter.Sort == dtos.ORDER_SORT_CREATED_AT_ASC
+	less := func(a, b entities.Order) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID < b.ID
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		if ascending {
+			return less(orders[i], orders[j])
+		}
+		return less(orders[j], orders[i])
+	})
+
+	if filter.After != nil {
+		cursor := entities.Order{ID: filter.After.ID, CreatedAt: filter.After.CreatedAt}
+		remaining := make([]entities.Order, 0, len(orders))
+		for _, order := range orders {
+			if (ascending && less(cursor, order)) || (!ascending && less(order, cursor)) {
+				remaining = append(remaining, order)
+			}
+		}
+		orders = remaining
+	}
+	if filter.Limit > 0 && len(orders) > filter.Limit {
+		orders = orders[:filter.Limit]
+	}
+
 	return orders, nil
 }
 
-func (m *MockOrderGateway) Update(order entities.Order) error {
+func (m *MockOrderGateway) Count(ctx context.Context, filter dtos.OrderFilterDTO) (int64, error) {
+	var total int64
+	for _, order := range m.orders {
+		if filter.CustomerID != nil && (order.CustomerID == nil || *order.CustomerID != *filter.CustomerID) {
+			continue
+		}
+		if slices.Contains(filter.ExcludedStatuses, order.Status.Name.Value()) {
+			continue
+		}
+		total++
+	}
+	return total, nil
+}
+
+func (m *MockOrderGateway) Update(ctx context.Context, order entities.Order, events ...brokers.OrderEvent) error {
 	if m.shouldFailUpdate {
 		return &exceptions.InvalidOrderDataException{Message: "Update failed"}
 	}
 
-	m.orders[order.ID] = &order
+	m.storeOrder(order)
+	return nil
+}
+
+func (m *MockOrderGateway) UpdateFromMessage(ctx context.Context, order entities.Order, message dtos.ProcessedMessageDTO, events ...brokers.OrderEvent) error {
+	return m.Update(ctx, order, events...)
+}
+
+func (m *MockOrderGateway) ApplyCoupon(ctx context.Context, order entities.Order, events ...brokers.OrderEvent) error {
+	if m.shouldFailApplyCoupon {
+		return &exceptions.CouponUsageLimitReachedException{}
+	}
+
+	m.storeOrder(order)
 	return nil
 }
 
-func (m *MockOrderGateway) Delete(id string) error {
+func (m *MockOrderGateway) Delete(ctx context.Context, id string, events ...brokers.OrderEvent) error {
+	if order, ok := m.orders[id]; ok {
+		m.deleted[id] = order
+		m.deletedAt[id] = time.Now()
+	}
 	delete(m.orders, id)
 	return nil
 }
 
+func (m *MockOrderGateway) FindByPickupCode(ctx context.Context, day string, code value_objects.PickupCode) (*entities.Order, error) {
+	for _, order := range m.orders {
+		if order.PickupDate == day && order.PickupCode == code {
+			return order, nil
+		}
+	}
+	return nil, &exceptions.OrderNotFoundException{}
+}
+
+func (m *MockOrderGateway) FindBoard(ctx context.Context, statuses []entities.OrderStatus, createdFrom time.Time) ([]entities.OrderBoardEntry, error) {
+	var entries []entities.OrderBoardEntry
+	for _, order := range m.orders {
+		if order.CreatedAt.Before(createdFrom) {
+			continue
+		}
+		for _, status := range statuses {
+			if order.Status.ID == status.ID {
+				entries = append(entries, entities.OrderBoardEntry{
+					OrderID:      order.ID,
+					PickupCode:   order.PickupCode,
+					CustomerName: order.CustomerName,
+					Status:       order.Status,
+					CreatedAt:    order.CreatedAt,
+				})
+			}
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+	return entries, nil
+}
+
+func (m *MockOrderGateway) NextPickupCode(ctx context.Context, day string) (value_objects.PickupCode, error) {
+	m.pickupSequences[day]++
+	return value_objects.NewPickupCode(m.pickupSequences[day])
+}
+
+// Claim fails like a concurrent claim that got there first when shouldFailClaim is set
+func (m *MockOrderGateway) Claim(ctx context.Context, orderID string, customerID string, guestTokenHash string, claimedAt time.Time) error {
+	order, ok := m.orders[orderID]
+	if !ok || m.shouldFailClaim {
+		return &exceptions.OrderAlreadyClaimedException{}
+	}
+	order.CustomerID = &customerID
+	order.GuestTokenHash = nil
+	order.UpdatedAt = &claimedAt
+	return nil
+}
+
+func (m *MockOrderGateway) ReissueGuestToken(ctx context.Context, orderID string, guestTokenHash string) error {
+	order, ok := m.orders[orderID]
+	if !ok || order.CustomerID != nil || m.shouldFailClaim {
+		return &exceptions.OrderAlreadyClaimedException{}
+	}
+	order.GuestTokenHash = &guestTokenHash
+	return nil
+}
+
+func (m *MockOrderGateway) Restore(ctx context.Context, id string, events ...brokers.OrderEvent) error {
+	order, ok := m.deleted[id]
+	if !ok {
+		return &exceptions.OrderNotFoundException{Message: "Deleted order not found"}
+	}
+	delete(m.deleted, id)
+	delete(m.deletedAt, id)
+	m.orders[id] = order
+	return nil
+}
+
+func (m *MockOrderGateway) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	m.purgeCalls++
+
+	var purged int64
+	for id, deletedAt := range m.deletedAt {
+		if purged == int64(limit) {
+			break
+		}
+		if deletedAt.Before(deletedBefore) {
+			delete(m.deleted, id)
+			delete(m.deletedAt, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func (m *MockOrderGateway) FindStatusHistory(ctx context.Context, orderID string) ([]entities.OrderStatusChange, error) {
+	history := make([]entities.OrderStatusChange, 0)
+	for _, change := range m.history {
+		if change.OrderID == orderID {
+			history = append(history, change)
+		}
+	}
+	return history, nil
+}
+
+// storeOrder keeps the order as if it had been reloaded, moving its pending status changes to the history.
+func (m *MockOrderGateway) storeOrder(order entities.Order) {
+	m.history = append(m.history, order.StatusChanges...)
+	order.StatusChanges = nil
+	m.orders[order.ID] = &order
+}
+
 type MockOrderStatusGateway struct {
 	statuses             map[string]*entities.OrderStatus
 	statusesByName       map[string]*entities.OrderStatus
@@ -104,7 +289,7 @@ func (m *MockOrderStatusGateway) SetShouldFailFindByName(fail bool) {
 	m.shouldFailFindByName = fail
 }
 
-func (m *MockOrderStatusGateway) FindAll() ([]entities.OrderStatus, error) {
+func (m *MockOrderStatusGateway) FindAll(ctx context.Context) ([]entities.OrderStatus, error) {
 	statuses := make([]entities.OrderStatus, 0, len(m.statuses))
 	for _, status := range m.statuses {
 		statuses = append(statuses, *status)
@@ -112,7 +297,7 @@ func (m *MockOrderStatusGateway) FindAll() ([]entities.OrderStatus, error) {
 	return statuses, nil
 }
 
-func (m *MockOrderStatusGateway) FindByID(id string) (*entities.OrderStatus, error) {
+func (m *MockOrderStatusGateway) FindByID(ctx context.Context, id string) (*entities.OrderStatus, error) {
 	if m.shouldFailFindByID {
 		return nil, &exceptions.OrderStatusNotFoundException{}
 	}
@@ -124,7 +309,7 @@ func (m *MockOrderStatusGateway) FindByID(id string) (*entities.OrderStatus, err
 	return status, nil
 }
 
-func (m *MockOrderStatusGateway) FindByName(name string) (*entities.OrderStatus, error) {
+func (m *MockOrderStatusGateway) FindByName(ctx context.Context, name string) (*entities.OrderStatus, error) {
 	if m.shouldFailFindByName {
 		return nil, &exceptions.OrderStatusNotFoundException{}
 	}
@@ -135,3 +320,89 @@ func (m *MockOrderStatusGateway) FindByName(name string) (*entities.OrderStatus,
 	}
 	return status, nil
 }
+
+type MockProductCatalogGateway struct {
+	products           map[string]*entities.Product
+	shouldFailFindByID bool
+}
+
+// NewMockProductCatalogGateway starts with product-1 (10.00) and product-2 (25.00) available
+func NewMockProductCatalogGateway() *MockProductCatalogGateway {
+	m := &MockProductCatalogGateway{
+		products: make(map[string]*entities.Product),
+	}
+	m.AddProduct("product-1", "10.00", true)
+	m.AddProduct("product-2", "25.00", true)
+	return m
+}
+
+func (m *MockProductCatalogGateway) AddProduct(id string, price string, available bool) {
+	product, _ := entities.NewProduct(id, "Product "+id, brl(price), available)
+	product.Category = "Lanche"
+	m.products[id] = product
+}
+
+func (m *MockProductCatalogGateway) AddModifier(productID string, modifierID string, priceDelta string) {
+	product := m.products[productID]
+	product.Modifiers = append(product.Modifiers, entities.ProductModifier{
+		ID:         modifierID,
+		Name:       "Modifier " + modifierID,
+		PriceDelta: brl(priceDelta),
+	})
+}
+
+func (m *MockProductCatalogGateway) AddCombo(id string, price string, comboItems ...entities.ProductComboItem) {
+	m.AddProduct(id, price, true)
+	m.products[id].ComboItems = comboItems
+}
+
+func (m *MockProductCatalogGateway) SetShouldFailFindByID(fail bool) {
+	m.shouldFailFindByID = fail
+}
+
+func (m *MockProductCatalogGateway) FindByID(ctx context.Context, productID string) (*entities.Product, error) {
+	if m.shouldFailFindByID {
+		return nil, &exceptions.ProductCatalogUnavailableException{}
+	}
+
+	product, exists := m.products[productID]
+	if !exists {
+		return nil, &exceptions.ProductNotFoundException{}
+	}
+	return product, nil
+}
+
+func brl(value string) value_objects.Money {
+	money, _ := value_objects.ParseMoney(value, value_objects.DEFAULT_CURRENCY)
+	return money
+}
+
+type MockCouponGateway struct {
+	coupons map[string]*entities.Coupon
+}
+
+func NewMockCouponGateway() *MockCouponGateway {
+	return &MockCouponGateway{
+		coupons: make(map[string]*entities.Coupon),
+	}
+}
+
+func (m *MockCouponGateway) AddCoupon(coupon entities.Coupon) {
+	m.coupons[coupon.Code] = &coupon
+}
+
+func (m *MockCouponGateway) Create(ctx context.Context, coupon entities.Coupon) error {
+	if _, exists := m.coupons[coupon.Code]; exists {
+		return &exceptions.CouponAlreadyExistsException{}
+	}
+	m.AddCoupon(coupon)
+	return nil
+}
+
+func (m *MockCouponGateway) FindByCode(ctx context.Context, code string) (*entities.Coupon, error) {
+	coupon, exists := m.coupons[entities.NormalizeCouponCode(code)]
+	if !exists {
+		return nil, &exceptions.CouponNotFoundException{}
+	}
+	return coupon, nil
+}
@@ -0,0 +1,149 @@
+package use_cases
+
+import (
+	"context"
+	"time"
+
+	"microservice/internal/adapters/brokers"
+	"microservice/internal/domain/entities"
+)
+
+func newOrderItemsPayload(order entities.Order) []brokers.OrderItemPayload {
+	return newItemsPayload(order.Items)
+}
+
+func newItemsPayload(orderItems []entities.OrderItem) []brokers.OrderItemPayload {
+	items := make([]brokers.OrderItemPayload, len(orderItems))
+	for i, item := range orderItems {
+		items[i] = brokers.OrderItemPayload{
+			ID:              item.ID,
+			ProductID:       item.ProductID.Value(),
+			ProductName:     item.Product.Name,
+			ProductCategory: item.Product.Category,
+			ProductNotes:    item.Product.Notes,
+			Quantity:        item.Quantity.Value(),
+			UnitPrice:       item.UnitPrice.Value().String(),
+			Notes:           item.Notes,
+			Modifiers:       newOrderItemModifiersPayload(item.Modifiers),
+			Components:      newItemsPayload(item.Components),
+		}
+	}
+	return items
+}
+
+func newOrderItemModifiersPayload(modifiers []entities.OrderItemModifier) []brokers.OrderItemModifierPayload {
+	if len(modifiers) == 0 {
+		return nil
+	}
+
+	payload := make([]brokers.OrderItemModifierPayload, len(modifiers))
+	for i, modifier := range modifiers {
+		payload[i] = brokers.OrderItemModifierPayload{
+			ID:         modifier.ModifierID,
+			Name:       modifier.Name,
+			PriceDelta: modifier.PriceDelta.String(),
+		}
+	}
+	return payload
+}
+
+func newOrderCreatedEvent(ctx context.Context, order entities.Order) (brokers.OrderEvent, error) {
+	return brokers.NewOrderEvent(ctx, brokers.ORDER_CREATED_EVENT, order.ID, brokers.OrderCreatedPayload{
+		OrderID:    order.ID,
+		CustomerID: order.CustomerID,
+		PickupCode: order.PickupCode.Value(),
+		Amount:     order.Amount.Value().String(),
+		Currency:   order.Amount.Value().Currency(),
+		Status:     order.Status.Name.Value(),
+		Items:      newOrderItemsPayload(order),
+		CreatedAt:  order.CreatedAt,
+	})
+}
+
+func newOrderStatusChangedEvent(ctx context.Context, order entities.Order, previousStatus entities.OrderStatus) (brokers.OrderEvent, error) {
+	// Usa o momento da mudança recém registrada; UpdatedAt pode ser o da leitura
+	changedAt := time.Now()
+	if len(order.StatusChanges) > 0 {
+		changedAt = order.StatusChanges[len(order.StatusChanges)-1].ChangedAt
+	}
+
+	return brokers.NewOrderEvent(ctx, brokers.ORDER_STATUS_CHANGED_EVENT, order.ID, brokers.OrderStatusChangedPayload{
+		OrderID:        order.ID,
+		PreviousStatus: previousStatus.Name.Value(),
+		Status:         order.Status.Name.Value(),
+		ChangedAt:      changedAt,
+	})
+}
+
+func newOrderDeletedEvent(ctx context.Context, orderID string) (brokers.OrderEvent, error) {
+	return brokers.NewOrderEvent(ctx, brokers.ORDER_DELETED_EVENT, orderID, brokers.OrderDeletedPayload{
+		OrderID:   orderID,
+		DeletedAt: time.Now(),
+	})
+}
+
+func newOrderRestoredEvent(ctx context.Context, orderID string) (brokers.OrderEvent, error) {
+	return brokers.NewOrderEvent(ctx, brokers.ORDER_RESTORED_EVENT, orderID, brokers.OrderRestoredPayload{
+		OrderID:    orderID,
+		RestoredAt: time.Now(),
+	})
+}
+
+func newOrderCouponAppliedEvent(ctx context.Context, order entities.Order) (brokers.OrderEvent, error) {
+	appliedAt := time.Now()
+	if order.UpdatedAt != nil {
+		appliedAt = *order.UpdatedAt
+	}
+
+	var couponCode string
+	if order.CouponCode != nil {
+		couponCode = *order.CouponCode
+	}
+
+	return brokers.NewOrderEvent(ctx, brokers.ORDER_COUPON_APPLIED_EVENT, order.ID, brokers.OrderCouponAppliedPayload{
+		OrderID:    order.ID,
+		CouponCode: couponCode,
+		Subtotal:   order.Subtotal.String(),
+		Discount:   order.Discount.String(),
+		Amount:     order.Amount.Value().String(),
+		Currency:   order.Amount.Value().Currency(),
+		AppliedAt:  appliedAt,
+	})
+}
+
+func newKitchenOrderRequestedEvent(ctx context.Context, order entities.Order) (brokers.OrderEvent, error) {
+	return brokers.NewOrderEvent(ctx, brokers.KITCHEN_ORDER_REQUESTED_EVENT, order.ID, brokers.KitchenOrderRequestPayload{
+		OrderID:     order.ID,
+		CustomerID:  order.CustomerID,
+		PickupCode:  order.PickupCode.Value(),
+		Currency:    order.Amount.Value().Currency(),
+		Items:       newOrderItemsPayload(order),
+		RequestedAt: time.Now(),
+	})
+}
+
+// Informa se o pagamento deve ser estornado e se a cozinha já tinha recebido o pedido
+func newOrderCancelledEvent(ctx context.Context, order entities.Order, previousStatus entities.OrderStatus) (brokers.OrderEvent, error) {
+	cancelledAt := time.Now()
+	if order.CancelledAt != nil {
+		cancelledAt = *order.CancelledAt
+	}
+
+	var reason string
+	if order.CancellationReason != nil {
+		reason = *order.CancellationReason
+	}
+
+	previous := previousStatus.Name.Value()
+	return brokers.NewOrderEvent(ctx, brokers.ORDER_CANCELLED_EVENT, order.ID, brokers.OrderCancelledPayload{
+		OrderID:         order.ID,
+		CustomerID:      order.CustomerID,
+		PreviousStatus:  previous,
+		Reason:          reason,
+		Amount:          order.Amount.Value().String(),
+		Currency:        order.Amount.Value().Currency(),
+		RefundRequired:  previous != entities.ORDER_STATUS_RECEIVED,
+		KitchenNotified: previous == entities.ORDER_STATUS_CONFIRMED || previous == entities.ORDER_STATUS_PREPARING,
+		CancelledAt:     cancelledAt,
+	})
+}
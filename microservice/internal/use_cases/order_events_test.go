@@ -0,0 +1,401 @@
+package use_cases
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"microservice/internal/adapters/brokers"
+	"microservice/internal/adapters/daos"
+	"microservice/internal/adapters/dtos"
+	"microservice/internal/adapters/gateways"
+	"microservice/internal/domain/entities"
+	"microservice/utils/correlation"
+)
+
+func decodeOutboxEvent(t *testing.T, message daos.OutboxMessageDAO) brokers.OrderEvent {
+	var event brokers.OrderEvent
+	assert.NoError(t, json.Unmarshal(message.Payload, &event))
+	return event
+}
+
+func TestCreateOrderUseCase_WritesOrderCreatedEventToOutbox(t *testing.T) {
+	orderDS := newTestOrderDataSource()
+	orderGateway := gateways.NewOrderGateway(orderDS)
+	statusGateway := gateways.NewOrderStatusGateway(newTestOrderStatusDataSource())
+
+	uc := NewCreateOrderUseCase(orderGateway, statusGateway, NewMockProductCatalogGateway(), time.UTC)
+
+	order, err := uc.Execute(context.Background(), dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{
+		{ProductID: "product-1", Quantity: 2, Price: "10.00"},
+	}})
+	assert.NoError(t, err)
+
+	assert.Len(t, orderDS.outbox, 1)
+	assert.Equal(t, brokers.ORDER_CREATED_EVENT, orderDS.outbox[0].EventType)
+	assert.Equal(t, order.ID, orderDS.outbox[0].OrderID)
+
+	event := decodeOutboxEvent(t, orderDS.outbox[0])
+	assert.Equal(t, brokers.ORDER_EVENT_VERSION, event.Version)
+
+	var payload brokers.OrderCreatedPayload
+	assert.NoError(t, json.Unmarshal(event.Payload, &payload))
+	assert.Equal(t, "20.00", payload.Amount)
+	assert.Equal(t, "BRL", payload.Currency)
+	assert.Equal(t, "10.00", payload.Items[0].UnitPrice)
+	assert.Equal(t, "Product product-1", payload.Items[0].ProductName)
+	assert.Equal(t, "Lanche", payload.Items[0].ProductCategory)
+	assert.Equal(t, "Recebido", payload.Status)
+	assert.Equal(t, "A001", payload.PickupCode)
+	assert.Len(t, payload.Items, 1)
+}
+
+func TestCreateOrderUseCase_OutboxEventCarriesRequestID(t *testing.T) {
+	orderDS := newTestOrderDataSource()
+	orderGateway := gateways.NewOrderGateway(orderDS)
+	statusGateway := gateways.NewOrderStatusGateway(newTestOrderStatusDataSource())
+	ctx := correlation.WithID(context.Background(), "req-123")
+
+	_, err := NewCreateOrderUseCase(orderGateway, statusGateway, NewMockProductCatalogGateway(), time.UTC).Execute(ctx, dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{
+		{ProductID: "product-1", Quantity: 1, Price: "10.00"},
+	}})
+	assert.NoError(t, err)
+
+	assert.Len(t, orderDS.outbox, 1)
+	assert.Equal(t, "req-123", decodeOutboxEvent(t, orderDS.outbox[0]).CorrelationID)
+}
+
+func TestUpdateOrderUseCase_WritesStatusChangedEventToOutbox(t *testing.T) {
+	orderDS := newTestOrderDataSource()
+	statusDS := newTestOrderStatusDataSource()
+	statusDS.statuses["status-2"] = daos.OrderStatusDAO{ID: "status-2", Name: "Confirmado"}
+	orderGateway := gateways.NewOrderGateway(orderDS)
+	statusGateway := gateways.NewOrderStatusGateway(statusDS)
+
+	created, err := NewCreateOrderUseCase(orderGateway, statusGateway, NewMockProductCatalogGateway(), time.UTC).Execute(context.Background(), dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{
+		{ProductID: "product-1", Quantity: 1, Price: "10.00"},
+	}})
+	assert.NoError(t, err)
+
+	_, err = NewUpdateOrderUseCase(orderGateway, statusGateway).Execute(context.Background(), dtos.UpdateOrderDTO{
+		ID:       created.ID,
+		StatusID: "status-2",
+	})
+	assert.NoError(t, err)
+
+	assert.Len(t, orderDS.outbox, 2)
+	assert.Equal(t, brokers.ORDER_STATUS_CHANGED_EVENT, orderDS.outbox[1].EventType)
+
+	var payload brokers.OrderStatusChangedPayload
+	assert.NoError(t, json.Unmarshal(decodeOutboxEvent(t, orderDS.outbox[1]).Payload, &payload))
+	assert.Equal(t, "Recebido", payload.PreviousStatus)
+	assert.Equal(t, "Confirmado", payload.Status)
+}
+
+func TestOrderStatusChangedEvent_UsesRecordedChangeTime(t *testing.T) {
+	received, _ := entities.NewOrderStatus("status-1", entities.ORDER_STATUS_RECEIVED)
+	confirmed, _ := entities.NewOrderStatus("status-2", entities.ORDER_STATUS_CONFIRMED)
+	order, _ := entities.NewOrder("550e8400-e29b-41d4-a716-446655440000", nil)
+	order.Status = *received
+
+	previousStatus := order.Status
+	assert.NoError(t, order.ChangeStatus(*confirmed, entities.STATUS_CHANGE_SOURCE_KITCHEN, nil))
+	// O evento não depende do UpdatedAt carregado do banco
+	stale := time.Now().Add(-time.Hour)
+	order.UpdatedAt = &stale
+
+	event, err := newOrderStatusChangedEvent(context.Background(), *order, previousStatus)
+	assert.NoError(t, err)
+
+	var payload brokers.OrderStatusChangedPayload
+	assert.NoError(t, json.Unmarshal(event.Payload, &payload))
+	assert.True(t, payload.ChangedAt.Equal(order.StatusChanges[0].ChangedAt), "ChangedAt = %v, want %v", payload.ChangedAt, order.StatusChanges[0].ChangedAt)
+}
+
+func TestUpdateOrderUseCase_SameStatusWritesNoEvent(t *testing.T) {
+	orderDS := newTestOrderDataSource()
+	orderGateway := gateways.NewOrderGateway(orderDS)
+	statusGateway := gateways.NewOrderStatusGateway(newTestOrderStatusDataSource())
+
+	created, _ := NewCreateOrderUseCase(orderGateway, statusGateway, NewMockProductCatalogGateway(), time.UTC).Execute(context.Background(), dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{
+		{ProductID: "product-1", Quantity: 1, Price: "10.00"},
+	}})
+
+	_, err := NewUpdateOrderUseCase(orderGateway, statusGateway).Execute(context.Background(), dtos.UpdateOrderDTO{
+		ID:       created.ID,
+		StatusID: INITIAL_ORDER_STATUS_ID,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, orderDS.outbox, 1)
+}
+
+func TestDeleteOrderUseCase_WritesOrderDeletedEventToOutbox(t *testing.T) {
+	orderDS := newTestOrderDataSource()
+	orderGateway := gateways.NewOrderGateway(orderDS)
+	statusGateway := gateways.NewOrderStatusGateway(newTestOrderStatusDataSource())
+
+	created, _ := NewCreateOrderUseCase(orderGateway, statusGateway, NewMockProductCatalogGateway(), time.UTC).Execute(context.Background(), dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{
+		{ProductID: "product-1", Quantity: 1, Price: "10.00"},
+	}})
+
+	err := NewDeleteOrderUseCase(orderGateway).Execute(context.Background(), created.ID)
+	assert.NoError(t, err)
+
+	assert.Len(t, orderDS.outbox, 2)
+	assert.Equal(t, brokers.ORDER_DELETED_EVENT, orderDS.outbox[1].EventType)
+	assert.Equal(t, created.ID, orderDS.outbox[1].OrderID)
+}
+
+func TestProcessPaymentConfirmationUseCase_ConfirmedPaymentWritesKitchenRequestToOutbox(t *testing.T) {
+	orderDS := newTestOrderDataSource()
+	statusDS := newTestOrderStatusDataSource()
+	statusDS.addStatus("status-2", "Confirmado")
+	orderGateway := gateways.NewOrderGateway(orderDS)
+	statusGateway := gateways.NewOrderStatusGateway(statusDS)
+
+	created, err := NewCreateOrderUseCase(orderGateway, statusGateway, NewMockProductCatalogGateway(), time.UTC).Execute(context.Background(), dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{
+		{ProductID: "product-1", Quantity: 2, Price: "10.00"},
+	}})
+	assert.NoError(t, err)
+
+	uc := NewProcessPaymentConfirmationUseCase(orderGateway, statusGateway)
+	result, err := uc.Execute(context.Background(), PaymentConfirmationDTO{
+		OrderID:   created.ID,
+		PaymentID: "payment-1",
+		Status:    "confirmed",
+		Amount:    brl("20.00"),
+	})
+	assert.NoError(t, err)
+	assert.True(t, result.ShouldNotifyKitchen)
+
+	assert.Len(t, orderDS.outbox, 3)
+	assert.Equal(t, brokers.ORDER_STATUS_CHANGED_EVENT, orderDS.outbox[1].EventType)
+	assert.Equal(t, brokers.KITCHEN_ORDER_REQUESTED_EVENT, orderDS.outbox[2].EventType)
+
+	var payload brokers.KitchenOrderRequestPayload
+	assert.NoError(t, json.Unmarshal(decodeOutboxEvent(t, orderDS.outbox[2]).Payload, &payload))
+	assert.Equal(t, created.ID, payload.OrderID)
+	assert.Equal(t, created.PickupCode.Value(), payload.PickupCode)
+	assert.Len(t, payload.Items, 1)
+	assert.Equal(t, 2, payload.Items[0].Quantity)
+}
+
+func TestProcessPaymentConfirmationUseCase_CancelledPaymentWritesCancelledEventToOutbox(t *testing.T) {
+	orderDS := newTestOrderDataSource()
+	statusDS := newTestOrderStatusDataSource()
+	statusDS.addStatus("status-9", "Cancelado")
+	orderGateway := gateways.NewOrderGateway(orderDS)
+	statusGateway := gateways.NewOrderStatusGateway(statusDS)
+
+	created, err := NewCreateOrderUseCase(orderGateway, statusGateway, NewMockProductCatalogGateway(), time.UTC).Execute(context.Background(), dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{
+		{ProductID: "product-1", Quantity: 1, Price: "10.00"},
+	}})
+	assert.NoError(t, err)
+
+	result, err := NewProcessPaymentConfirmationUseCase(orderGateway, statusGateway).Execute(context.Background(), PaymentConfirmationDTO{
+		OrderID:   created.ID,
+		PaymentID: "payment-1",
+		Status:    "cancelled",
+		Amount:    brl("10.00"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, PAYMENT_CANCELLED_REASON, *result.Order.CancellationReason)
+	assert.NotNil(t, result.Order.CancelledAt)
+
+	assert.Len(t, orderDS.outbox, 3)
+	assert.Equal(t, brokers.ORDER_STATUS_CHANGED_EVENT, orderDS.outbox[1].EventType)
+	assert.Equal(t, brokers.ORDER_CANCELLED_EVENT, orderDS.outbox[2].EventType)
+
+	stored := orderDS.orders[created.ID]
+	assert.Equal(t, PAYMENT_CANCELLED_REASON, *stored.CancellationReason)
+	assert.Equal(t, "payment-1", *stored.StatusChanges[len(stored.StatusChanges)-1].Actor)
+}
+
+func TestOrderCreatedEvent_IncludesComboComponents(t *testing.T) {
+	orderDS := newTestOrderDataSource()
+	orderGateway := gateways.NewOrderGateway(orderDS)
+	statusGateway := gateways.NewOrderStatusGateway(newTestOrderStatusDataSource())
+
+	catalog := NewMockProductCatalogGateway()
+	catalog.AddCombo("combo-1", "30.00", entities.ProductComboItem{ProductID: "product-1", Quantity: 1})
+
+	_, err := NewCreateOrderUseCase(orderGateway, statusGateway, catalog, time.UTC).Execute(context.Background(), dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{
+		{ProductID: "combo-1", Quantity: 1},
+	}})
+	assert.NoError(t, err)
+
+	var payload brokers.OrderCreatedPayload
+	assert.NoError(t, json.Unmarshal(decodeOutboxEvent(t, orderDS.outbox[0]).Payload, &payload))
+	assert.Equal(t, "30.00", payload.Amount)
+	assert.Len(t, payload.Items, 1)
+	assert.Len(t, payload.Items[0].Components, 1)
+	assert.Equal(t, "product-1", payload.Items[0].Components[0].ProductID)
+	assert.Equal(t, 1, payload.Items[0].Components[0].Quantity)
+}
+
+func TestKitchenOrderRequest_IncludesItemNotesAndModifiers(t *testing.T) {
+	orderDS := newTestOrderDataSource()
+	statusDS := newTestOrderStatusDataSource()
+	statusDS.addStatus("status-2", "Confirmado")
+	orderGateway := gateways.NewOrderGateway(orderDS)
+	statusGateway := gateways.NewOrderStatusGateway(statusDS)
+
+	catalog := NewMockProductCatalogGateway()
+	catalog.AddModifier("product-1", "sem-cebola", "0")
+
+	created, err := NewCreateOrderUseCase(orderGateway, statusGateway, catalog, time.UTC).Execute(context.Background(), dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{
+		{ProductID: "product-1", Quantity: 1, Notes: "cortar ao meio", Modifiers: []dtos.CreateOrderItemModifierDTO{{ModifierID: "sem-cebola"}}},
+	}})
+	assert.NoError(t, err)
+
+	_, err = NewProcessPaymentConfirmationUseCase(orderGateway, statusGateway).Execute(context.Background(), PaymentConfirmationDTO{
+		OrderID:   created.ID,
+		PaymentID: "payment-1",
+		Status:    "confirmed",
+		Amount:    brl("10.00"),
+	})
+	assert.NoError(t, err)
+
+	var payload brokers.KitchenOrderRequestPayload
+	assert.NoError(t, json.Unmarshal(decodeOutboxEvent(t, orderDS.outbox[2]).Payload, &payload))
+	assert.Equal(t, "cortar ao meio", payload.Items[0].Notes)
+	assert.Equal(t, []brokers.OrderItemModifierPayload{
+		{ID: "sem-cebola", Name: "Modifier sem-cebola", PriceDelta: "0.00"},
+	}, payload.Items[0].Modifiers)
+}
+
+func TestProcessPaymentConfirmationUseCase_FailedPaymentDoesNotRequestKitchen(t *testing.T) {
+	orderDS := newTestOrderDataSource()
+	statusDS := newTestOrderStatusDataSource()
+	statusDS.addStatus("status-failed", "Falhou")
+	orderGateway := gateways.NewOrderGateway(orderDS)
+	statusGateway := gateways.NewOrderStatusGateway(statusDS)
+
+	created, _ := NewCreateOrderUseCase(orderGateway, statusGateway, NewMockProductCatalogGateway(), time.UTC).Execute(context.Background(), dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{
+		{ProductID: "product-1", Quantity: 1, Price: "10.00"},
+	}})
+
+	uc := NewProcessPaymentConfirmationUseCase(orderGateway, statusGateway)
+	_, err := uc.Execute(context.Background(), PaymentConfirmationDTO{
+		OrderID:   created.ID,
+		PaymentID: "payment-1",
+		Status:    "failed",
+		Amount:    brl("10.00"),
+	})
+	assert.NoError(t, err)
+
+	assert.Len(t, orderDS.outbox, 2)
+	assert.Equal(t, brokers.ORDER_STATUS_CHANGED_EVENT, orderDS.outbox[1].EventType)
+}
+
+func TestApplyCouponUseCase_WritesCouponAppliedEventToOutbox(t *testing.T) {
+	orderDS := newTestOrderDataSource()
+	orderGateway := gateways.NewOrderGateway(orderDS)
+	statusGateway := gateways.NewOrderStatusGateway(newTestOrderStatusDataSource())
+
+	created, err := NewCreateOrderUseCase(orderGateway, statusGateway, NewMockProductCatalogGateway(), time.UTC).Execute(context.Background(), dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{
+		{ProductID: "product-1", Quantity: 2},
+	}})
+	assert.NoError(t, err)
+	orderDS.outbox = nil
+
+	couponGateway := NewMockCouponGateway()
+	couponGateway.AddCoupon(entities.Coupon{Code: "PROMO10", Type: entities.COUPON_TYPE_PERCENTAGE, Percentage: 10})
+
+	_, err = NewApplyCouponUseCase(orderGateway, couponGateway).Execute(context.Background(), dtos.ApplyCouponDTO{OrderID: created.ID, Code: "PROMO10"})
+	assert.NoError(t, err)
+
+	assert.Len(t, orderDS.outbox, 1)
+	assert.Equal(t, brokers.ORDER_COUPON_APPLIED_EVENT, orderDS.outbox[0].EventType)
+
+	var payload brokers.OrderCouponAppliedPayload
+	assert.NoError(t, json.Unmarshal(decodeOutboxEvent(t, orderDS.outbox[0]).Payload, &payload))
+	assert.Equal(t, created.ID, payload.OrderID)
+	assert.Equal(t, "PROMO10", payload.CouponCode)
+	assert.Equal(t, "20.00", payload.Subtotal)
+	assert.Equal(t, "2.00", payload.Discount)
+	assert.Equal(t, "18.00", payload.Amount)
+	assert.Equal(t, "BRL", payload.Currency)
+
+	stored := orderDS.orders[created.ID]
+	assert.Equal(t, int64(1800), stored.Amount)
+	assert.Equal(t, int64(200), stored.Discount)
+}
+
+func TestCancelOrderUseCase_WritesCancelledEventToOutbox(t *testing.T) {
+	orderDS := newTestOrderDataSource()
+	statusDS := newTestOrderStatusDataSource()
+	statusDS.addStatus("status-2", "Confirmado")
+	statusDS.addStatus("status-9", "Cancelado")
+	orderGateway := gateways.NewOrderGateway(orderDS)
+	statusGateway := gateways.NewOrderStatusGateway(statusDS)
+
+	created, err := NewCreateOrderUseCase(orderGateway, statusGateway, NewMockProductCatalogGateway(), time.UTC).Execute(context.Background(), dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{
+		{ProductID: "product-1", Quantity: 1, Price: "10.00"},
+	}})
+	assert.NoError(t, err)
+	_, err = NewUpdateOrderUseCase(orderGateway, statusGateway).Execute(context.Background(), dtos.UpdateOrderDTO{ID: created.ID, StatusID: "status-2"})
+	assert.NoError(t, err)
+
+	_, err = NewCancelOrderUseCase(orderGateway, statusGateway).Execute(context.Background(), dtos.CancelOrderDTO{
+		OrderID: created.ID,
+		Reason:  "Cliente desistiu",
+	})
+	assert.NoError(t, err)
+
+	assert.Len(t, orderDS.outbox, 4)
+	assert.Equal(t, brokers.ORDER_STATUS_CHANGED_EVENT, orderDS.outbox[2].EventType)
+	assert.Equal(t, brokers.ORDER_CANCELLED_EVENT, orderDS.outbox[3].EventType)
+
+	var payload brokers.OrderCancelledPayload
+	assert.NoError(t, json.Unmarshal(decodeOutboxEvent(t, orderDS.outbox[3]).Payload, &payload))
+	assert.Equal(t, created.ID, payload.OrderID)
+	assert.Equal(t, "Confirmado", payload.PreviousStatus)
+	assert.Equal(t, "Cliente desistiu", payload.Reason)
+	assert.Equal(t, "10.00", payload.Amount)
+	assert.True(t, payload.RefundRequired)
+	assert.True(t, payload.KitchenNotified)
+	assert.False(t, payload.CancelledAt.IsZero())
+}
+
+func TestOrderCancelledEvent_UnpaidOrderNeedsNoRefund(t *testing.T) {
+	received, _ := entities.NewOrderStatus("status-1", entities.ORDER_STATUS_RECEIVED)
+	cancelled, _ := entities.NewOrderStatus("status-9", entities.ORDER_STATUS_CANCELLED)
+	order, _ := entities.NewOrder("550e8400-e29b-41d4-a716-446655440000", nil)
+	order.Status = *received
+	assert.NoError(t, order.Cancel(*cancelled, "Pedido duplicado", entities.STATUS_CHANGE_SOURCE_REST, nil, time.Now()))
+
+	event, err := newOrderCancelledEvent(context.Background(), *order, *received)
+	assert.NoError(t, err)
+
+	var payload brokers.OrderCancelledPayload
+	assert.NoError(t, json.Unmarshal(event.Payload, &payload))
+	assert.Equal(t, "Recebido", payload.PreviousStatus)
+	assert.False(t, payload.RefundRequired)
+	assert.False(t, payload.KitchenNotified)
+}
+
+func TestRestoreOrderUseCase_WritesRestoredEventToOutbox(t *testing.T) {
+	orderDS := newTestOrderDataSource()
+	orderGateway := gateways.NewOrderGateway(orderDS)
+	statusGateway := gateways.NewOrderStatusGateway(newTestOrderStatusDataSource())
+
+	created, err := NewCreateOrderUseCase(orderGateway, statusGateway, NewMockProductCatalogGateway(), time.UTC).Execute(context.Background(), dtos.CreateOrderDTO{Items: []dtos.CreateOrderItemDTO{
+		{ProductID: "product-1", Quantity: 1, Price: "10.00"},
+	}})
+	assert.NoError(t, err)
+	assert.NoError(t, NewDeleteOrderUseCase(orderGateway).Execute(context.Background(), created.ID))
+
+	_, err = NewRestoreOrderUseCase(orderGateway).Execute(context.Background(), created.ID)
+	assert.NoError(t, err)
+
+	assert.Len(t, orderDS.outbox, 3)
+	assert.Equal(t, brokers.ORDER_RESTORED_EVENT, orderDS.outbox[2].EventType)
+
+	var payload brokers.OrderRestoredPayload
+	assert.NoError(t, json.Unmarshal(decodeOutboxEvent(t, orderDS.outbox[2]).Payload, &payload))
+	assert.Equal(t, created.ID, payload.OrderID)
+}
@@ -1,12 +1,15 @@
 package use_cases
 
 import (
+	"context"
 	"fmt"
 	"time"
 
+	"microservice/internal/adapters/brokers"
 	"microservice/internal/adapters/dtos"
 	"microservice/internal/domain/entities"
 	"microservice/internal/domain/exceptions"
+	"microservice/internal/domain/value_objects"
 	"microservice/internal/interfaces"
 )
 
@@ -25,11 +28,21 @@ func NewProcessPaymentConfirmationUseCase(
 	}
 }
 
+// Motivo registrado nos pedidos cancelados pelo serviço de pagamentos
+const PAYMENT_CANCELLED_REASON = "Payment cancelled"
+
+// Status do pedido correspondente a cada status de pagamento
+var paymentStatusToOrderStatus = map[string]string{
+	"confirmed": entities.ORDER_STATUS_CONFIRMED,
+	"failed":    entities.ORDER_STATUS_FAILED,
+	"cancelled": entities.ORDER_STATUS_CANCELLED,
+}
+
 type PaymentConfirmationDTO struct {
 	OrderID       string
 	PaymentID     string
 	Status        string // "confirmed", "failed", "cancelled"
-	Amount        float64
+	Amount        value_objects.Money
 	PaymentMethod string
 	ProcessedAt   time.Time
 }
@@ -41,14 +54,14 @@ type PaymentConfirmationResult struct {
 	Message             string
 }
 
-func (uc *ProcessPaymentConfirmationUseCase) Execute(dto PaymentConfirmationDTO) (*PaymentConfirmationResult, error) {
+func (uc *ProcessPaymentConfirmationUseCase) Execute(ctx context.Context, dto PaymentConfirmationDTO) (*PaymentConfirmationResult, error) {
 	// 1. Validar dados de entrada
 	if err := uc.validateInput(dto); err != nil {
 		return nil, err
 	}
 
 	// 2. Buscar o pedido
-	order, err := uc.orderGateway.FindByID(dto.OrderID)
+	order, err := uc.orderGateway.FindByID(ctx, dto.OrderID)
 	if err != nil {
 		return nil, &exceptions.OrderNotFoundException{}
 	}
@@ -65,9 +78,11 @@ func (uc *ProcessPaymentConfirmationUseCase) Execute(dto PaymentConfirmationDTO)
 	// 4. Processar baseado no status do pagamento
 	switch dto.Status {
 	case "confirmed":
-		return uc.processConfirmedPayment(order, dto)
-	case "failed", "cancelled":
-		return uc.processFailedPayment(order, dto)
+		return uc.processConfirmedPayment(ctx, order, dto)
+	case "failed":
+		return uc.processFailedPayment(ctx, order, dto)
+	case "cancelled":
+		return uc.processCancelledPayment(ctx, order, dto)
 	default:
 		return &PaymentConfirmationResult{
 			Order:         *order,
@@ -77,9 +92,13 @@ func (uc *ProcessPaymentConfirmationUseCase) Execute(dto PaymentConfirmationDTO)
 	}
 }
 
-func (uc *ProcessPaymentConfirmationUseCase) processConfirmedPayment(order *entities.Order, dto PaymentConfirmationDTO) (*PaymentConfirmationResult, error) {
-	// Buscar status "paid"
-	paidStatus, err := uc.findOrCreateStatus("paid", "Paid")
+func (uc *ProcessPaymentConfirmationUseCase) processConfirmedPayment(ctx context.Context, order *entities.Order, dto PaymentConfirmationDTO) (*PaymentConfirmationResult, error) {
+	if err := checkPaidAmount(order, dto); err != nil {
+		return nil, err
+	}
+
+	// Buscar status "Confirmado"
+	confirmedStatus, err := uc.findStatus(ctx, entities.ORDER_STATUS_CONFIRMED)
 	if err != nil {
 		return nil, err
 	}
@@ -87,10 +106,10 @@ func (uc *ProcessPaymentConfirmationUseCase) processConfirmedPayment(order *enti
 	// Atualizar pedido
 	updateDTO := dtos.UpdateOrderDTO{
 		ID:       order.ID,
-		StatusID: paidStatus.ID,
+		StatusID: confirmedStatus.ID,
 	}
 
-	updatedOrder, err := uc.updateOrder(updateDTO)
+	updatedOrder, err := uc.updateOrder(ctx, updateDTO, &dto.PaymentID, true)
 	if err != nil {
 		return nil, err
 	}
@@ -103,9 +122,8 @@ func (uc *ProcessPaymentConfirmationUseCase) processConfirmedPayment(order *enti
 	}, nil
 }
 
-func (uc *ProcessPaymentConfirmationUseCase) processFailedPayment(order *entities.Order, dto PaymentConfirmationDTO) (*PaymentConfirmationResult, error) {
-	// Buscar status "failed"
-	failedStatus, err := uc.findOrCreateStatus("failed", "Failed")
+func (uc *ProcessPaymentConfirmationUseCase) processFailedPayment(ctx context.Context, order *entities.Order, dto PaymentConfirmationDTO) (*PaymentConfirmationResult, error) {
+	failedStatus, err := uc.findStatus(ctx, entities.ORDER_STATUS_FAILED)
 	if err != nil {
 		return nil, err
 	}
@@ -116,7 +134,7 @@ func (uc *ProcessPaymentConfirmationUseCase) processFailedPayment(order *entitie
 		StatusID: failedStatus.ID,
 	}
 
-	updatedOrder, err := uc.updateOrder(updateDTO)
+	updatedOrder, err := uc.updateOrder(ctx, updateDTO, &dto.PaymentID, false)
 	if err != nil {
 		return nil, err
 	}
@@ -125,65 +143,126 @@ func (uc *ProcessPaymentConfirmationUseCase) processFailedPayment(order *entitie
 		Order:               updatedOrder,
 		StatusChanged:       true,
 		ShouldNotifyKitchen: false,
-		Message:             fmt.Sprintf("Order %s marked as failed", order.ID),
+		Message:             fmt.Sprintf("Order %s marked as %s", order.ID, dto.Status),
+	}, nil
+}
+
+// Passa por Order.Cancel, para registrar o motivo e avisar a cozinha como nos cancelamentos pela API
+func (uc *ProcessPaymentConfirmationUseCase) processCancelledPayment(ctx context.Context, order *entities.Order, dto PaymentConfirmationDTO) (*PaymentConfirmationResult, error) {
+	cancelledStatus, err := uc.findStatus(ctx, entities.ORDER_STATUS_CANCELLED)
+	if err != nil {
+		return nil, err
+	}
+
+	previousStatus := order.Status
+	if err := order.Cancel(*cancelledStatus, PAYMENT_CANCELLED_REASON, entities.STATUS_CHANGE_SOURCE_PAYMENT, &dto.PaymentID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	statusChanged, err := newOrderStatusChangedEvent(ctx, *order, previousStatus)
+	if err != nil {
+		return nil, err
+	}
+	orderCancelled, err := newOrderCancelledEvent(ctx, *order, previousStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.orderGateway.Update(ctx, *order, statusChanged, orderCancelled); err != nil {
+		return nil, err
+	}
+
+	return &PaymentConfirmationResult{
+		Order:               *order,
+		StatusChanged:       true,
+		ShouldNotifyKitchen: false,
+		Message:             fmt.Sprintf("Order %s marked as cancelled", order.ID),
 	}, nil
 }
 
 func (uc *ProcessPaymentConfirmationUseCase) validateInput(dto PaymentConfirmationDTO) error {
 	if dto.OrderID == "" {
-		return fmt.Errorf("order ID is required")
+		return &exceptions.InvalidPaymentConfirmationException{Message: "order ID is required"}
 	}
 	if dto.PaymentID == "" {
-		return fmt.Errorf("payment ID is required")
+		return &exceptions.InvalidPaymentConfirmationException{Message: "payment ID is required"}
 	}
 	if dto.Status == "" {
-		return fmt.Errorf("payment status is required")
+		return &exceptions.InvalidPaymentConfirmationException{Message: "payment status is required"}
 	}
-	if dto.Amount <= 0 {
-		return fmt.Errorf("payment amount must be positive")
+	if !dto.Amount.IsPositive() {
+		return &exceptions.InvalidPaymentConfirmationException{Message: "payment amount must be positive"}
 	}
 	return nil
 }
 
-func (uc *ProcessPaymentConfirmationUseCase) canUpdateOrderStatus(order *entities.Order, newStatus string) bool {
-	currentStatus := order.Status.Name.Value()
+// checkPaidAmount só aceita o pagamento do total exato do pedido; a mensagem
+// rejeitada vai para a dead-letter
+func checkPaidAmount(order *entities.Order, dto PaymentConfirmationDTO) error {
+	total := order.Amount.Value()
+	if dto.Amount != total {
+		return &exceptions.InvalidPaymentConfirmationException{
+			Message: fmt.Sprintf("payment amount %s %s does not match order total %s %s", dto.Amount, dto.Amount.Currency(), total, total.Currency()),
+		}
+	}
+	return nil
+}
 
-	switch currentStatus {
-	case "pending", "created":
-		return newStatus == "confirmed" || newStatus == "failed" || newStatus == "cancelled"
-	case "paid":
-		return false // Pedido já pago não pode ser alterado
-	case "failed", "cancelled":
-		return false // Pedidos falhados/cancelados não podem ser alterados
-	default:
+func (uc *ProcessPaymentConfirmationUseCase) canUpdateOrderStatus(order *entities.Order, paymentStatus string) bool {
+	newStatus, exists := paymentStatusToOrderStatus[paymentStatus]
+	if !exists {
 		return false
 	}
+
+	return entities.CanTransitionOrderStatus(order.Status.Name.Value(), newStatus)
 }
 
-func (uc *ProcessPaymentConfirmationUseCase) findOrCreateStatus(statusID, statusName string) (*entities.OrderStatus, error) {
-	status, err := uc.orderStatusGateway.FindByID(statusID)
+func (uc *ProcessPaymentConfirmationUseCase) findStatus(ctx context.Context, statusName string) (*entities.OrderStatus, error) {
+	status, err := uc.orderStatusGateway.FindByName(ctx, statusName)
 	if err != nil {
-		return entities.NewOrderStatus(statusID, statusName)
+		return nil, &exceptions.OrderStatusNotFoundException{}
 	}
 	return status, nil
 }
 
-func (uc *ProcessPaymentConfirmationUseCase) updateOrder(dto dtos.UpdateOrderDTO) (entities.Order, error) {
-	order, err := uc.orderGateway.FindByID(dto.ID)
+// updateOrder grava o novo status, tendo o pagamento como autor no histórico, e,
+// quando notifyKitchen é verdadeiro, o pedido para a cozinha no mesmo outbox.
+func (uc *ProcessPaymentConfirmationUseCase) updateOrder(ctx context.Context, dto dtos.UpdateOrderDTO, paymentID *string, notifyKitchen bool) (entities.Order, error) {
+	order, err := uc.orderGateway.FindByID(ctx, dto.ID)
 	if err != nil {
 		return entities.Order{}, &exceptions.OrderNotFoundException{}
 	}
 
-	status, err := uc.orderStatusGateway.FindByID(dto.StatusID)
+	status, err := uc.orderStatusGateway.FindByID(ctx, dto.StatusID)
 	if err != nil {
 		return entities.Order{}, &exceptions.OrderStatusNotFoundException{}
 	}
 
-	order.Status = *status
+	previousStatus := order.Status
+	if err := order.ChangeStatus(*status, entities.STATUS_CHANGE_SOURCE_PAYMENT, paymentID); err != nil {
+		return entities.Order{}, err
+	}
 	now := time.Now()
 	order.UpdatedAt = &now
 
-	err = uc.orderGateway.Update(*order)
+	var events []brokers.OrderEvent
+	if previousStatus.ID != order.Status.ID {
+		event, err := newOrderStatusChangedEvent(ctx, *order, previousStatus)
+		if err != nil {
+			return entities.Order{}, err
+		}
+		events = append(events, event)
+	}
+
+	if notifyKitchen {
+		event, err := newKitchenOrderRequestedEvent(ctx, *order)
+		if err != nil {
+			return entities.Order{}, err
+		}
+		events = append(events, event)
+	}
+
+	err = uc.orderGateway.Update(ctx, *order, events...)
 	if err != nil {
 		return entities.Order{}, err
 	}
@@ -1,6 +1,7 @@
 package use_cases
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -47,7 +48,7 @@ func TestProcessPaymentConfirmationUseCase_ValidateInput(t *testing.T) {
 				OrderID:   "order-1",
 				PaymentID: "payment-1",
 				Status:    "confirmed",
-				Amount:    25.0,
+				Amount:    brl("25.00"),
 			},
 			expectError: false,
 		},
@@ -56,7 +57,7 @@ func TestProcessPaymentConfirmationUseCase_ValidateInput(t *testing.T) {
 			dto: PaymentConfirmationDTO{
 				PaymentID: "payment-1",
 				Status:    "confirmed",
-				Amount:    25.0,
+				Amount:    brl("25.00"),
 			},
 			expectError: true,
 			errorMsg:    "order ID is required",
@@ -66,7 +67,7 @@ func TestProcessPaymentConfirmationUseCase_ValidateInput(t *testing.T) {
 			dto: PaymentConfirmationDTO{
 				OrderID: "order-1",
 				Status:  "confirmed",
-				Amount:  25.0,
+				Amount:  brl("25.00"),
 			},
 			expectError: true,
 			errorMsg:    "payment ID is required",
@@ -76,7 +77,7 @@ func TestProcessPaymentConfirmationUseCase_ValidateInput(t *testing.T) {
 			dto: PaymentConfirmationDTO{
 				OrderID:   "order-1",
 				PaymentID: "payment-1",
-				Amount:    25.0,
+				Amount:    brl("25.00"),
 			},
 			expectError: true,
 			errorMsg:    "payment status is required",
@@ -87,7 +88,7 @@ func TestProcessPaymentConfirmationUseCase_ValidateInput(t *testing.T) {
 				OrderID:   "order-1",
 				PaymentID: "payment-1",
 				Status:    "confirmed",
-				Amount:    0,
+				Amount:    brl("0.00"),
 			},
 			expectError: true,
 			errorMsg:    "payment amount must be positive",
@@ -116,8 +117,8 @@ func TestProcessPaymentConfirmationUseCase_ValidateInput(t *testing.T) {
 func TestProcessPaymentConfirmationUseCase_CanUpdateOrderStatus_Simple(t *testing.T) {
 	uc := &ProcessPaymentConfirmationUseCase{}
 
-	amount, _ := value_objects.NewAmount(25.0)
-	status, _ := entities.NewOrderStatus("status-id", "pending")
+	amount, _ := value_objects.NewAmount(brl("25.00"))
+	status, _ := entities.NewOrderStatus("status-id", "Recebido")
 
 	order := &entities.Order{
 		ID:     "order-1",
@@ -127,12 +128,12 @@ func TestProcessPaymentConfirmationUseCase_CanUpdateOrderStatus_Simple(t *testin
 
 	result := uc.canUpdateOrderStatus(order, "confirmed")
 	if !result {
-		t.Error("Expected pending order to be updatable to confirmed")
+		t.Error("Expected received order to be updatable to confirmed")
 	}
 
 	result = uc.canUpdateOrderStatus(order, "failed")
 	if !result {
-		t.Error("Expected pending order to be updatable to failed")
+		t.Error("Expected received order to be updatable to failed")
 	}
 }
 
@@ -141,7 +142,7 @@ func TestPaymentConfirmationDTO_Structure(t *testing.T) {
 		OrderID:       "order-1",
 		PaymentID:     "payment-1",
 		Status:        "confirmed",
-		Amount:        25.0,
+		Amount:        brl("25.00"),
 		PaymentMethod: "credit_card",
 	}
 
@@ -157,8 +158,8 @@ func TestPaymentConfirmationDTO_Structure(t *testing.T) {
 		t.Errorf("Expected Status 'confirmed', got '%s'", dto.Status)
 	}
 
-	if dto.Amount != 25.0 {
-		t.Errorf("Expected Amount 25.0, got %f", dto.Amount)
+	if dto.Amount != brl("25.00") {
+		t.Errorf("Expected Amount 25.00, got %s", dto.Amount)
 	}
 
 	if dto.PaymentMethod != "credit_card" {
@@ -167,7 +168,7 @@ func TestPaymentConfirmationDTO_Structure(t *testing.T) {
 }
 
 func TestPaymentConfirmationResult_Structure(t *testing.T) {
-	amount, _ := value_objects.NewAmount(25.0)
+	amount, _ := value_objects.NewAmount(brl("25.00"))
 	status, _ := entities.NewOrderStatus("status-1", "pending")
 
 	order := entities.Order{
@@ -206,7 +207,7 @@ func TestProcessPaymentConfirmationUseCase_Execute_ValidInput_ValidationOnly(t *
 		OrderID:   "550e8400-e29b-41d4-a716-446655440000",
 		PaymentID: "payment-1",
 		Status:    "confirmed",
-		Amount:    25.0,
+		Amount:    brl("25.00"),
 	}
 
 	err := uc.validateInput(dto)
@@ -223,22 +224,21 @@ func TestProcessPaymentConfirmationUseCase_canUpdateOrderStatus_AllCases(t *test
 		newStatus     string
 		expected      bool
 	}{
-		{"pending", "confirmed", true},
-		{"pending", "failed", true},
-		{"pending", "cancelled", true},
-		{"created", "confirmed", true},
-		{"created", "failed", true},
-		{"created", "cancelled", true},
-		{"paid", "confirmed", false},
-		{"paid", "failed", false},
-		{"failed", "confirmed", false},
-		{"cancelled", "confirmed", false},
+		{"Recebido", "confirmed", true},
+		{"Recebido", "failed", true},
+		{"Recebido", "cancelled", true},
+		{"Recebido", "refunded", false},
+		{"Confirmado", "confirmed", false},
+		{"Confirmado", "failed", false},
+		{"Em preparação", "confirmed", false},
+		{"Falhou", "confirmed", false},
+		{"Cancelado", "confirmed", false},
 		{"unknown", "confirmed", false},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.currentStatus+"_to_"+tc.newStatus, func(t *testing.T) {
-			amount, _ := value_objects.NewAmount(25.0)
+			amount, _ := value_objects.NewAmount(brl("25.00"))
 			status, _ := entities.NewOrderStatus("status-id", tc.currentStatus)
 
 			order := &entities.Order{
@@ -255,16 +255,16 @@ func TestProcessPaymentConfirmationUseCase_canUpdateOrderStatus_AllCases(t *test
 	}
 }
 
-func TestProcessPaymentConfirmationUseCase_findOrCreateStatus_MethodExists(t *testing.T) {
+func TestProcessPaymentConfirmationUseCase_findStatus_MethodExists(t *testing.T) {
 	uc := &ProcessPaymentConfirmationUseCase{}
 
-	_ = uc.findOrCreateStatus
+	_ = uc.findStatus
 }
 
 func TestProcessPaymentConfirmationUseCase_processConfirmedPayment_MethodExists(t *testing.T) {
 	uc := &ProcessPaymentConfirmationUseCase{}
 
-	amount, _ := value_objects.NewAmount(99.99)
+	amount, _ := value_objects.NewAmount(brl("99.99"))
 	status, _ := entities.NewOrderStatus("pending", "Pending")
 	order := &entities.Order{
 		ID:     "order-123",
@@ -276,7 +276,7 @@ func TestProcessPaymentConfirmationUseCase_processConfirmedPayment_MethodExists(
 		OrderID:   "order-123",
 		PaymentID: "payment-456",
 		Status:    "confirmed",
-		Amount:    99.99,
+		Amount:    brl("99.99"),
 	}
 
 	_ = uc.processConfirmedPayment
@@ -287,7 +287,7 @@ func TestProcessPaymentConfirmationUseCase_processConfirmedPayment_MethodExists(
 func TestProcessPaymentConfirmationUseCase_processFailedPayment_MethodExists(t *testing.T) {
 	uc := &ProcessPaymentConfirmationUseCase{}
 
-	amount, _ := value_objects.NewAmount(99.99)
+	amount, _ := value_objects.NewAmount(brl("99.99"))
 	status, _ := entities.NewOrderStatus("pending", "Pending")
 	order := &entities.Order{
 		ID:     "order-123",
@@ -299,7 +299,7 @@ func TestProcessPaymentConfirmationUseCase_processFailedPayment_MethodExists(t *
 		OrderID:   "order-123",
 		PaymentID: "payment-456",
 		Status:    "failed",
-		Amount:    99.99,
+		Amount:    brl("99.99"),
 	}
 
 	_ = uc.processFailedPayment
@@ -331,10 +331,10 @@ func TestProcessPaymentConfirmationUseCase_Execute_OrderNotFound(t *testing.T) {
 		OrderID:   "non-existent-order",
 		PaymentID: "payment-1",
 		Status:    "confirmed",
-		Amount:    25.0,
+		Amount:    brl("25.00"),
 	}
 
-	result, err := uc.Execute(dto)
+	result, err := uc.Execute(context.Background(), dto)
 
 	if result != nil {
 		t.Error("Expected result to be nil when order not found")
@@ -359,10 +359,10 @@ func TestProcessPaymentConfirmationUseCase_Execute_InvalidInput(t *testing.T) {
 		OrderID:   "", // Invalid: empty order ID
 		PaymentID: "payment-1",
 		Status:    "confirmed",
-		Amount:    25.0,
+		Amount:    brl("25.00"),
 	}
 
-	result, err := uc.Execute(dto)
+	result, err := uc.Execute(context.Background(), dto)
 
 	if result != nil {
 		t.Error("Expected result to be nil for invalid input")
@@ -382,10 +382,10 @@ func TestProcessPaymentConfirmationUseCase_processConfirmedPayment_Success(t *te
 	mockStatusGateway := NewMockOrderStatusGateway()
 
 	customerID := "customer-1"
-	pendingStatus, _ := entities.NewOrderStatus("pending", "pending")
-	order, _ := entities.NewOrderWithItems("order-1", &customerID, 25.0, *pendingStatus, []entities.OrderItem{}, time.Now(), nil)
+	pendingStatus, _ := entities.NewOrderStatus("received", "Recebido")
+	order, _ := entities.NewOrderWithItems("order-1", &customerID, brl("25.00"), *pendingStatus, []entities.OrderItem{}, time.Now(), nil)
 
-	paidStatus, _ := entities.NewOrderStatus("paid", "Paid")
+	paidStatus, _ := entities.NewOrderStatus("paid", "Confirmado")
 
 	mockOrderGateway.AddOrder(order)
 	mockStatusGateway.AddStatus(paidStatus)
@@ -396,10 +396,10 @@ func TestProcessPaymentConfirmationUseCase_processConfirmedPayment_Success(t *te
 		OrderID:   "order-1",
 		PaymentID: "payment-1",
 		Status:    "confirmed",
-		Amount:    25.0,
+		Amount:    brl("25.00"),
 	}
 
-	result, err := uc.processConfirmedPayment(order, dto)
+	result, err := uc.processConfirmedPayment(context.Background(), order, dto)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -413,11 +413,11 @@ func TestProcessPaymentConfirmationUseCase_processConfirmedPayment_StatusNotFoun
 	mockOrderGateway := NewMockOrderGateway()
 	mockStatusGateway := NewMockOrderStatusGateway()
 
-	mockStatusGateway.SetShouldFailFindByID(true)
+	mockStatusGateway.SetShouldFailFindByName(true)
 
 	customerID := "customer-1"
-	pendingStatus, _ := entities.NewOrderStatus("pending", "pending")
-	order, _ := entities.NewOrderWithItems("order-1", &customerID, 25.0, *pendingStatus, []entities.OrderItem{}, time.Now(), nil)
+	pendingStatus, _ := entities.NewOrderStatus("received", "Recebido")
+	order, _ := entities.NewOrderWithItems("order-1", &customerID, brl("25.00"), *pendingStatus, []entities.OrderItem{}, time.Now(), nil)
 
 	uc := NewProcessPaymentConfirmationUseCase(mockOrderGateway, mockStatusGateway)
 
@@ -425,10 +425,10 @@ func TestProcessPaymentConfirmationUseCase_processConfirmedPayment_StatusNotFoun
 		OrderID:   "order-1",
 		PaymentID: "payment-1",
 		Status:    "confirmed",
-		Amount:    25.0,
+		Amount:    brl("25.00"),
 	}
 
-	result, err := uc.processConfirmedPayment(order, dto)
+	result, err := uc.processConfirmedPayment(context.Background(), order, dto)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -439,10 +439,10 @@ func TestProcessPaymentConfirmationUseCase_processFailedPayment_Success(t *testi
 	mockStatusGateway := NewMockOrderStatusGateway()
 
 	customerID := "customer-1"
-	pendingStatus, _ := entities.NewOrderStatus("pending", "pending")
-	order, _ := entities.NewOrderWithItems("order-1", &customerID, 25.0, *pendingStatus, []entities.OrderItem{}, time.Now(), nil)
+	pendingStatus, _ := entities.NewOrderStatus("received", "Recebido")
+	order, _ := entities.NewOrderWithItems("order-1", &customerID, brl("25.00"), *pendingStatus, []entities.OrderItem{}, time.Now(), nil)
 
-	failedStatus, _ := entities.NewOrderStatus("failed", "Failed")
+	failedStatus, _ := entities.NewOrderStatus("failed", "Falhou")
 
 	mockOrderGateway.AddOrder(order)
 	mockStatusGateway.AddStatus(failedStatus)
@@ -453,10 +453,10 @@ func TestProcessPaymentConfirmationUseCase_processFailedPayment_Success(t *testi
 		OrderID:   "order-1",
 		PaymentID: "payment-1",
 		Status:    "failed",
-		Amount:    25.0,
+		Amount:    brl("25.00"),
 	}
 
-	result, err := uc.processFailedPayment(order, dto)
+	result, err := uc.processFailedPayment(context.Background(), order, dto)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -470,11 +470,11 @@ func TestProcessPaymentConfirmationUseCase_processFailedPayment_StatusNotFound(t
 	mockOrderGateway := NewMockOrderGateway()
 	mockStatusGateway := NewMockOrderStatusGateway()
 
-	mockStatusGateway.SetShouldFailFindByID(true)
+	mockStatusGateway.SetShouldFailFindByName(true)
 
 	customerID := "customer-1"
-	pendingStatus, _ := entities.NewOrderStatus("pending", "pending")
-	order, _ := entities.NewOrderWithItems("order-1", &customerID, 25.0, *pendingStatus, []entities.OrderItem{}, time.Now(), nil)
+	pendingStatus, _ := entities.NewOrderStatus("received", "Recebido")
+	order, _ := entities.NewOrderWithItems("order-1", &customerID, brl("25.00"), *pendingStatus, []entities.OrderItem{}, time.Now(), nil)
 
 	uc := NewProcessPaymentConfirmationUseCase(mockOrderGateway, mockStatusGateway)
 
@@ -482,47 +482,42 @@ func TestProcessPaymentConfirmationUseCase_processFailedPayment_StatusNotFound(t
 		OrderID:   "order-1",
 		PaymentID: "payment-1",
 		Status:    "failed",
-		Amount:    25.0,
+		Amount:    brl("25.00"),
 	}
 
-	result, err := uc.processFailedPayment(order, dto)
+	result, err := uc.processFailedPayment(context.Background(), order, dto)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
 }
 
-func TestProcessPaymentConfirmationUseCase_findOrCreateStatus_StatusExists(t *testing.T) {
+func TestProcessPaymentConfirmationUseCase_findStatus_StatusExists(t *testing.T) {
 	mockOrderGateway := NewMockOrderGateway()
 	mockStatusGateway := NewMockOrderStatusGateway()
 
-	existingStatus, _ := entities.NewOrderStatus("paid", "Paid")
+	existingStatus, _ := entities.NewOrderStatus("paid", "Confirmado")
 	mockStatusGateway.AddStatus(existingStatus)
 
 	uc := NewProcessPaymentConfirmationUseCase(mockOrderGateway, mockStatusGateway)
 
-	status, err := uc.findOrCreateStatus("paid", "Paid")
+	status, err := uc.findStatus(context.Background(), "Confirmado")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, status)
 	assert.Equal(t, "paid", status.ID)
-	assert.Equal(t, "Paid", status.Name.Value())
+	assert.Equal(t, "Confirmado", status.Name.Value())
 }
 
-func TestProcessPaymentConfirmationUseCase_findOrCreateStatus_StatusNotExists(t *testing.T) {
+func TestProcessPaymentConfirmationUseCase_findStatus_StatusNotExists(t *testing.T) {
 	mockOrderGateway := NewMockOrderGateway()
 	mockStatusGateway := NewMockOrderStatusGateway()
 
-	// Configurar mock para não encontrar o status
-	mockStatusGateway.SetShouldFailFindByID(true)
-
 	uc := NewProcessPaymentConfirmationUseCase(mockOrderGateway, mockStatusGateway)
 
-	status, err := uc.findOrCreateStatus("new-status", "New Status")
+	status, err := uc.findStatus(context.Background(), "Confirmado")
 
-	assert.NoError(t, err)
-	assert.NotNil(t, status)
-	assert.Equal(t, "new-status", status.ID)
-	assert.Equal(t, "New Status", status.Name.Value())
+	assert.Nil(t, status)
+	assert.IsType(t, &exceptions.OrderStatusNotFoundException{}, err)
 }
 
 func TestProcessPaymentConfirmationUseCase_updateOrder_Success(t *testing.T) {
@@ -530,10 +525,10 @@ func TestProcessPaymentConfirmationUseCase_updateOrder_Success(t *testing.T) {
 	mockStatusGateway := NewMockOrderStatusGateway()
 
 	customerID := "customer-1"
-	pendingStatus, _ := entities.NewOrderStatus("pending", "pending")
-	order, _ := entities.NewOrderWithItems("order-1", &customerID, 25.0, *pendingStatus, []entities.OrderItem{}, time.Now(), nil)
+	pendingStatus, _ := entities.NewOrderStatus("received", "Recebido")
+	order, _ := entities.NewOrderWithItems("order-1", &customerID, brl("25.00"), *pendingStatus, []entities.OrderItem{}, time.Now(), nil)
 
-	paidStatus, _ := entities.NewOrderStatus("paid", "Paid")
+	paidStatus, _ := entities.NewOrderStatus("paid", "Confirmado")
 
 	mockOrderGateway.AddOrder(order)
 	mockStatusGateway.AddStatus(paidStatus)
@@ -545,7 +540,7 @@ func TestProcessPaymentConfirmationUseCase_updateOrder_Success(t *testing.T) {
 		StatusID: "paid",
 	}
 
-	updatedOrder, err := uc.updateOrder(dto)
+	updatedOrder, err := uc.updateOrder(context.Background(), dto, nil, false)
 
 	assert.NoError(t, err)
 	assert.Equal(t, "paid", updatedOrder.Status.ID)
@@ -565,7 +560,7 @@ func TestProcessPaymentConfirmationUseCase_updateOrder_OrderNotFound(t *testing.
 		StatusID: "status-1",
 	}
 
-	_, err := uc.updateOrder(dto)
+	_, err := uc.updateOrder(context.Background(), dto, nil, false)
 
 	assert.Error(t, err)
 	assert.IsType(t, &exceptions.OrderNotFoundException{}, err)
@@ -576,8 +571,8 @@ func TestProcessPaymentConfirmationUseCase_updateOrder_StatusNotFound(t *testing
 	mockStatusGateway := NewMockOrderStatusGateway()
 
 	customerID := "customer-1"
-	pendingStatus, _ := entities.NewOrderStatus("pending", "pending")
-	order, _ := entities.NewOrderWithItems("order-1", &customerID, 25.0, *pendingStatus, []entities.OrderItem{}, time.Now(), nil)
+	pendingStatus, _ := entities.NewOrderStatus("received", "Recebido")
+	order, _ := entities.NewOrderWithItems("order-1", &customerID, brl("25.00"), *pendingStatus, []entities.OrderItem{}, time.Now(), nil)
 
 	mockOrderGateway.AddOrder(order)
 	mockStatusGateway.SetShouldFailFindByID(true)
@@ -589,7 +584,7 @@ func TestProcessPaymentConfirmationUseCase_updateOrder_StatusNotFound(t *testing
 		StatusID: "non-existent-status",
 	}
 
-	_, err := uc.updateOrder(dto)
+	_, err := uc.updateOrder(context.Background(), dto, nil, false)
 
 	assert.Error(t, err)
 	assert.IsType(t, &exceptions.OrderStatusNotFoundException{}, err)
@@ -600,10 +595,10 @@ func TestProcessPaymentConfirmationUseCase_Execute_Success_Confirmed(t *testing.
 	mockStatusGateway := NewMockOrderStatusGateway()
 
 	customerID := "customer-1"
-	pendingStatus, _ := entities.NewOrderStatus("pending", "pending")
-	order, _ := entities.NewOrderWithItems("order-1", &customerID, 25.0, *pendingStatus, []entities.OrderItem{}, time.Now(), nil)
+	pendingStatus, _ := entities.NewOrderStatus("received", "Recebido")
+	order, _ := entities.NewOrderWithItems("order-1", &customerID, brl("25.00"), *pendingStatus, []entities.OrderItem{}, time.Now(), nil)
 
-	paidStatus, _ := entities.NewOrderStatus("paid", "Paid")
+	paidStatus, _ := entities.NewOrderStatus("paid", "Confirmado")
 
 	mockOrderGateway.AddOrder(order)
 	mockStatusGateway.AddStatus(paidStatus)
@@ -614,10 +609,10 @@ func TestProcessPaymentConfirmationUseCase_Execute_Success_Confirmed(t *testing.
 		OrderID:   "order-1",
 		PaymentID: "payment-1",
 		Status:    "confirmed",
-		Amount:    25.0,
+		Amount:    brl("25.00"),
 	}
 
-	result, err := uc.Execute(dto)
+	result, err := uc.Execute(context.Background(), dto)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -632,10 +627,10 @@ func TestProcessPaymentConfirmationUseCase_Execute_Success_Failed(t *testing.T)
 	mockStatusGateway := NewMockOrderStatusGateway()
 
 	customerID := "customer-1"
-	pendingStatus, _ := entities.NewOrderStatus("pending", "pending")
-	order, _ := entities.NewOrderWithItems("order-1", &customerID, 25.0, *pendingStatus, []entities.OrderItem{}, time.Now(), nil)
+	pendingStatus, _ := entities.NewOrderStatus("received", "Recebido")
+	order, _ := entities.NewOrderWithItems("order-1", &customerID, brl("25.00"), *pendingStatus, []entities.OrderItem{}, time.Now(), nil)
 
-	failedStatus, _ := entities.NewOrderStatus("failed", "Failed")
+	failedStatus, _ := entities.NewOrderStatus("failed", "Falhou")
 
 	mockOrderGateway.AddOrder(order)
 	mockStatusGateway.AddStatus(failedStatus)
@@ -646,10 +641,10 @@ func TestProcessPaymentConfirmationUseCase_Execute_Success_Failed(t *testing.T)
 		OrderID:   "order-1",
 		PaymentID: "payment-1",
 		Status:    "failed",
-		Amount:    25.0,
+		Amount:    brl("25.00"),
 	}
 
-	result, err := uc.Execute(dto)
+	result, err := uc.Execute(context.Background(), dto)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -664,13 +659,13 @@ func TestProcessPaymentConfirmationUseCase_Execute_Success_Cancelled(t *testing.
 	mockStatusGateway := NewMockOrderStatusGateway()
 
 	customerID := "customer-1"
-	pendingStatus, _ := entities.NewOrderStatus("pending", "pending")
-	order, _ := entities.NewOrderWithItems("order-1", &customerID, 25.0, *pendingStatus, []entities.OrderItem{}, time.Now(), nil)
+	pendingStatus, _ := entities.NewOrderStatus("received", "Recebido")
+	order, _ := entities.NewOrderWithItems("order-1", &customerID, brl("25.00"), *pendingStatus, []entities.OrderItem{}, time.Now(), nil)
 
-	failedStatus, _ := entities.NewOrderStatus("failed", "Failed")
+	cancelledStatus, _ := entities.NewOrderStatus("cancelled", "Cancelado")
 
 	mockOrderGateway.AddOrder(order)
-	mockStatusGateway.AddStatus(failedStatus)
+	mockStatusGateway.AddStatus(cancelledStatus)
 
 	uc := NewProcessPaymentConfirmationUseCase(mockOrderGateway, mockStatusGateway)
 
@@ -678,17 +673,17 @@ func TestProcessPaymentConfirmationUseCase_Execute_Success_Cancelled(t *testing.
 		OrderID:   "order-1",
 		PaymentID: "payment-1",
 		Status:    "cancelled",
-		Amount:    25.0,
+		Amount:    brl("25.00"),
 	}
 
-	result, err := uc.Execute(dto)
+	result, err := uc.Execute(context.Background(), dto)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.True(t, result.StatusChanged)
 	assert.False(t, result.ShouldNotifyKitchen)
-	assert.Equal(t, "failed", result.Order.Status.ID)
-	assert.Contains(t, result.Message, "marked as failed")
+	assert.Equal(t, "cancelled", result.Order.Status.ID)
+	assert.Contains(t, result.Message, "marked as cancelled")
 }
 
 func TestProcessPaymentConfirmationUseCase_Execute_UnknownStatus(t *testing.T) {
@@ -696,8 +691,8 @@ func TestProcessPaymentConfirmationUseCase_Execute_UnknownStatus(t *testing.T) {
 	mockStatusGateway := NewMockOrderStatusGateway()
 
 	customerID := "customer-1"
-	pendingStatus, _ := entities.NewOrderStatus("pending", "pending")
-	order, _ := entities.NewOrderWithItems("order-1", &customerID, 25.0, *pendingStatus, []entities.OrderItem{}, time.Now(), nil)
+	pendingStatus, _ := entities.NewOrderStatus("received", "Recebido")
+	order, _ := entities.NewOrderWithItems("order-1", &customerID, brl("25.00"), *pendingStatus, []entities.OrderItem{}, time.Now(), nil)
 
 	mockOrderGateway.AddOrder(order)
 
@@ -707,16 +702,16 @@ func TestProcessPaymentConfirmationUseCase_Execute_UnknownStatus(t *testing.T) {
 		OrderID:   "order-1",
 		PaymentID: "payment-1",
 		Status:    "unknown-status",
-		Amount:    25.0,
+		Amount:    brl("25.00"),
 	}
 
-	result, err := uc.Execute(dto)
+	result, err := uc.Execute(context.Background(), dto)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.False(t, result.StatusChanged)
 	assert.False(t, result.ShouldNotifyKitchen)
-	assert.Equal(t, "pending", result.Order.Status.ID)
+	assert.Equal(t, "received", result.Order.Status.ID)
 	assert.Contains(t, result.Message, "cannot be updated")
 }
 
@@ -725,8 +720,8 @@ func TestProcessPaymentConfirmationUseCase_Execute_CannotUpdateStatus(t *testing
 	mockStatusGateway := NewMockOrderStatusGateway()
 
 	customerID := "customer-1"
-	paidStatus, _ := entities.NewOrderStatus("paid", "paid")
-	order, _ := entities.NewOrderWithItems("order-1", &customerID, 25.0, *paidStatus, []entities.OrderItem{}, time.Now(), nil)
+	paidStatus, _ := entities.NewOrderStatus("paid", "Confirmado")
+	order, _ := entities.NewOrderWithItems("order-1", &customerID, brl("25.00"), *paidStatus, []entities.OrderItem{}, time.Now(), nil)
 
 	mockOrderGateway.AddOrder(order)
 
@@ -736,10 +731,10 @@ func TestProcessPaymentConfirmationUseCase_Execute_CannotUpdateStatus(t *testing
 		OrderID:   "order-1",
 		PaymentID: "payment-1",
 		Status:    "confirmed",
-		Amount:    25.0,
+		Amount:    brl("25.00"),
 	}
 
-	result, err := uc.Execute(dto)
+	result, err := uc.Execute(context.Background(), dto)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -748,3 +743,35 @@ func TestProcessPaymentConfirmationUseCase_Execute_CannotUpdateStatus(t *testing
 	assert.Equal(t, "paid", result.Order.Status.ID)
 	assert.Contains(t, result.Message, "cannot be updated")
 }
+
+func TestProcessPaymentConfirmationUseCase_Execute_Underpaid(t *testing.T) {
+	mockOrderGateway := NewMockOrderGateway()
+	mockStatusGateway := NewMockOrderStatusGateway()
+
+	customerID := "customer-1"
+	pendingStatus, _ := entities.NewOrderStatus("received", "Recebido")
+	order, _ := entities.NewOrderWithItems("order-1", &customerID, brl("25.00"), *pendingStatus, []entities.OrderItem{}, time.Now(), nil)
+
+	paidStatus, _ := entities.NewOrderStatus("paid", "Confirmado")
+
+	mockOrderGateway.AddOrder(order)
+	mockStatusGateway.AddStatus(paidStatus)
+
+	uc := NewProcessPaymentConfirmationUseCase(mockOrderGateway, mockStatusGateway)
+
+	usd, _ := value_objects.NewMoney(2500, "USD")
+	for _, amount := range []value_objects.Money{brl("0.01"), brl("24.99"), brl("25.01"), usd} {
+		result, err := uc.Execute(context.Background(), PaymentConfirmationDTO{
+			OrderID:   "order-1",
+			PaymentID: "payment-1",
+			Status:    "confirmed",
+			Amount:    amount,
+		})
+
+		assert.Nil(t, result)
+		assert.IsType(t, &exceptions.InvalidPaymentConfirmationException{}, err, "amount %v", amount)
+	}
+
+	stored, _ := mockOrderGateway.FindByID(context.Background(), "order-1")
+	assert.Equal(t, "received", stored.Status.ID)
+}
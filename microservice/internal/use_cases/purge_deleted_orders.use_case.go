@@ -0,0 +1,45 @@
+package use_cases
+
+import (
+	"context"
+	"time"
+
+	"microservice/internal/interfaces"
+)
+
+const DEFAULT_PURGE_BATCH_SIZE = 500
+
+type PurgeDeletedOrdersUseCase struct {
+	orderGateway interfaces.IOrderGateway
+	retention    time.Duration
+	batchSize    int
+}
+
+func NewPurgeDeletedOrdersUseCase(orderGateway interfaces.IOrderGateway, retention time.Duration, batchSize int) *PurgeDeletedOrdersUseCase {
+	if batchSize <= 0 {
+		batchSize = DEFAULT_PURGE_BATCH_SIZE
+	}
+
+	return &PurgeDeletedOrdersUseCase{
+		orderGateway: orderGateway,
+		retention:    retention,
+		batchSize:    batchSize,
+	}
+}
+
+// Remove em lotes e retorna quantos pedidos foram removidos
+func (uc *PurgeDeletedOrdersUseCase) Execute(ctx context.Context, now time.Time) (int64, error) {
+	deletedBefore := now.Add(-uc.retention)
+
+	var total int64
+	for {
+		purged, err := uc.orderGateway.PurgeDeleted(ctx, deletedBefore, uc.batchSize)
+		total += purged
+		if err != nil {
+			return total, err
+		}
+		if purged < int64(uc.batchSize) {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package use_cases
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestPurgeDeletedOrdersUseCase_Execute(t *testing.T) {
+	now := time.Now()
+	orderGateway := NewMockOrderGateway()
+	for i := 0; i < 5; i++ {
+		id := fmt.Sprintf("expired-%d", i)
+		orderGateway.deleted[id] = newCouponTestOrder(t, id, nil)
+		orderGateway.deletedAt[id] = now.Add(-31 * 24 * time.Hour)
+	}
+	orderGateway.deleted["recent"] = newCouponTestOrder(t, "recent", nil)
+	orderGateway.deletedAt["recent"] = now.Add(-time.Hour)
+
+	purged, err := NewPurgeDeletedOrdersUseCase(orderGateway, 30*24*time.Hour, 2).Execute(context.Background(), now)
+
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if purged != 5 {
+		t.Errorf("Execute() purged = %v, want 5", purged)
+	}
+	if orderGateway.purgeCalls != 3 {
+		t.Errorf("Execute() batches = %v, want 3", orderGateway.purgeCalls)
+	}
+	if _, ok := orderGateway.deleted["recent"]; !ok {
+		t.Error("Execute() should keep orders still within the retention period")
+	}
+}
+
+func TestPurgeDeletedOrdersUseCase_DefaultBatchSize(t *testing.T) {
+	uc := NewPurgeDeletedOrdersUseCase(NewMockOrderGateway(), time.Hour, 0)
+	if uc.batchSize != DEFAULT_PURGE_BATCH_SIZE {
+		t.Errorf("batchSize = %v, want %v", uc.batchSize, DEFAULT_PURGE_BATCH_SIZE)
+	}
+}
+
+func TestPurgeDeletedOrdersUseCase_Execute_Error(t *testing.T) {
+	_, err := NewPurgeDeletedOrdersUseCase(&mockOrderGateway{}, time.Hour, 10).Execute(context.Background(), time.Now())
+	if err == nil {
+		t.Error("Execute() expected error, got nil")
+	}
+}
@@ -0,0 +1,44 @@
+package use_cases
+
+import (
+	"context"
+
+	"microservice/internal/domain/entities"
+	"microservice/internal/domain/exceptions"
+	"microservice/internal/interfaces"
+)
+
+type ReissueGuestTokenUseCase struct {
+	orderGateway interfaces.IOrderGateway
+}
+
+func NewReissueGuestTokenUseCase(orderGateway interfaces.IOrderGateway) *ReissueGuestTokenUseCase {
+	return &ReissueGuestTokenUseCase{
+		orderGateway: orderGateway,
+	}
+}
+
+// O token anterior deixa de valer; pedidos com cliente voltam sem token
+func (uc *ReissueGuestTokenUseCase) Execute(ctx context.Context, id string) (entities.Order, error) {
+	err := entities.ValidateID(id)
+	if err != nil {
+		return entities.Order{}, err
+	}
+
+	order, err := uc.orderGateway.FindByID(ctx, id)
+	if err != nil {
+		return entities.Order{}, &exceptions.OrderNotFoundException{}
+	}
+	if order.CustomerID != nil {
+		return *order, nil
+	}
+
+	if err := order.IssueGuestToken(); err != nil {
+		return entities.Order{}, err
+	}
+	if err := uc.orderGateway.ReissueGuestToken(ctx, order.ID, *order.GuestTokenHash); err != nil {
+		return entities.Order{}, err
+	}
+
+	return *order, nil
+}
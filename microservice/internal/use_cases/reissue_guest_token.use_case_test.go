@@ -0,0 +1,59 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+
+	"microservice/internal/adapters/dtos"
+	"microservice/internal/domain/exceptions"
+)
+
+func TestReissueGuestTokenUseCase_Execute_Success(t *testing.T) {
+	created, orderGateway := createGuestOrder(t)
+
+	order, err := NewReissueGuestTokenUseCase(orderGateway).Execute(context.Background(), created.ID)
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if order.GuestToken == "" || order.GuestToken == created.GuestToken {
+		t.Fatalf("Execute() GuestToken = %q, want a new token", order.GuestToken)
+	}
+
+	findUC := NewFindGuestOrderUseCase(orderGateway)
+	if _, err := findUC.Execute(context.Background(), created.ID, order.GuestToken); err != nil {
+		t.Errorf("FindGuestOrder rejected the reissued token: %v", err)
+	}
+	if _, err := findUC.Execute(context.Background(), created.ID, created.GuestToken); err == nil {
+		t.Error("FindGuestOrder accepted the token that was replaced")
+	}
+}
+
+func TestReissueGuestTokenUseCase_Execute_ClaimedOrder(t *testing.T) {
+	created, orderGateway := createGuestOrder(t)
+	claim := dtos.ClaimOrderDTO{OrderID: created.ID, GuestToken: created.GuestToken, CustomerID: "customer-123"}
+	if _, err := NewClaimOrderUseCase(orderGateway).Execute(context.Background(), claim); err != nil {
+		t.Fatalf("Claim unexpected error: %v", err)
+	}
+
+	order, err := NewReissueGuestTokenUseCase(orderGateway).Execute(context.Background(), created.ID)
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if order.GuestToken != "" || order.GuestTokenHash != nil {
+		t.Errorf("Execute() issued a token for a claimed order")
+	}
+}
+
+func TestReissueGuestTokenUseCase_Execute_Errors(t *testing.T) {
+	_, orderGateway := createGuestOrder(t)
+	uc := NewReissueGuestTokenUseCase(orderGateway)
+
+	if _, err := uc.Execute(context.Background(), "invalid-id"); err == nil {
+		t.Error("Execute() accepted an invalid order ID")
+	}
+
+	_, err := uc.Execute(context.Background(), "550e8400-e29b-41d4-a716-446655440099")
+	if _, ok := err.(*exceptions.OrderNotFoundException); !ok {
+		t.Errorf("Execute() error = %T, want *exceptions.OrderNotFoundException", err)
+	}
+}
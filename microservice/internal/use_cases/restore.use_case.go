@@ -0,0 +1,41 @@
+package use_cases
+
+import (
+	"context"
+
+	"microservice/internal/domain/entities"
+	"microservice/internal/interfaces"
+)
+
+type RestoreOrderUseCase struct {
+	orderGateway interfaces.IOrderGateway
+}
+
+func NewRestoreOrderUseCase(orderGateway interfaces.IOrderGateway) *RestoreOrderUseCase {
+	return &RestoreOrderUseCase{
+		orderGateway: orderGateway,
+	}
+}
+
+func (uc *RestoreOrderUseCase) Execute(ctx context.Context, id string) (entities.Order, error) {
+	err := entities.ValidateID(id)
+	if err != nil {
+		return entities.Order{}, err
+	}
+
+	event, err := newOrderRestoredEvent(ctx, id)
+	if err != nil {
+		return entities.Order{}, err
+	}
+
+	if err := uc.orderGateway.Restore(ctx, id, event); err != nil {
+		return entities.Order{}, err
+	}
+
+	order, err := uc.orderGateway.FindByID(ctx, id)
+	if err != nil {
+		return entities.Order{}, err
+	}
+
+	return *order, nil
+}
@@ -0,0 +1,49 @@
+package use_cases
+
+import (
+	"context"
+	"testing"
+
+	"microservice/internal/domain/exceptions"
+)
+
+func TestRestoreOrderUseCase_Execute_Success(t *testing.T) {
+	orderGateway := NewMockOrderGateway()
+	order := newCouponTestOrder(t, "550e8400-e29b-41d4-a716-446655440000", nil)
+	orderGateway.AddOrder(order)
+	if err := NewDeleteOrderUseCase(orderGateway).Execute(context.Background(), order.ID); err != nil {
+		t.Fatalf("Delete unexpected error: %v", err)
+	}
+
+	result, err := NewRestoreOrderUseCase(orderGateway).Execute(context.Background(), order.ID)
+
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if result.ID != order.ID {
+		t.Errorf("Execute() ID = %v, want %v", result.ID, order.ID)
+	}
+	if _, err := orderGateway.FindByID(context.Background(), order.ID); err != nil {
+		t.Errorf("restored order should be found, got %v", err)
+	}
+}
+
+func TestRestoreOrderUseCase_Execute_Errors(t *testing.T) {
+	t.Run("invalid id", func(t *testing.T) {
+		_, err := NewRestoreOrderUseCase(NewMockOrderGateway()).Execute(context.Background(), "invalid")
+		if _, ok := err.(*exceptions.InvalidOrderDataException); !ok {
+			t.Errorf("Execute() error = %T, want *exceptions.InvalidOrderDataException", err)
+		}
+	})
+
+	t.Run("order not deleted", func(t *testing.T) {
+		orderGateway := NewMockOrderGateway()
+		order := newCouponTestOrder(t, "550e8400-e29b-41d4-a716-446655440000", nil)
+		orderGateway.AddOrder(order)
+
+		_, err := NewRestoreOrderUseCase(orderGateway).Execute(context.Background(), order.ID)
+		if _, ok := err.(*exceptions.OrderNotFoundException); !ok {
+			t.Errorf("Execute() error = %T, want *exceptions.OrderNotFoundException", err)
+		}
+	})
+}
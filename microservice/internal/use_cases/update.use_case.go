@@ -1,8 +1,10 @@
 package use_cases
 
 import (
+	"context"
 	"time"
 
+	"microservice/internal/adapters/brokers"
 	"microservice/internal/adapters/dtos"
 	"microservice/internal/domain/entities"
 	"microservice/internal/domain/exceptions"
@@ -21,27 +23,39 @@ func NewUpdateOrderUseCase(orderGateway interfaces.IOrderGateway, orderStatusGat
 	}
 }
 
-func (uc *UpdateOrderUseCase) Execute(dto dtos.UpdateOrderDTO) (entities.Order, error) {
+func (uc *UpdateOrderUseCase) Execute(ctx context.Context, dto dtos.UpdateOrderDTO) (entities.Order, error) {
 	err := entities.ValidateID(dto.ID)
 	if err != nil {
 		return entities.Order{}, err
 	}
 
-	order, err := uc.orderGateway.FindByID(dto.ID)
+	order, err := uc.orderGateway.FindByID(ctx, dto.ID)
 	if err != nil {
 		return entities.Order{}, &exceptions.OrderNotFoundException{}
 	}
 
-	status, err := uc.orderStatusGateway.FindByID(dto.StatusID)
+	status, err := uc.orderStatusGateway.FindByID(ctx, dto.StatusID)
 	if err != nil {
 		return entities.Order{}, &exceptions.OrderStatusNotFoundException{}
 	}
 
-	order.Status = *status
+	previousStatus := order.Status
+	if err := order.ChangeStatus(*status, entities.STATUS_CHANGE_SOURCE_REST, dto.Actor); err != nil {
+		return entities.Order{}, err
+	}
 	now := time.Now()
 	order.UpdatedAt = &now
 
-	err = uc.orderGateway.Update(*order)
+	var events []brokers.OrderEvent
+	if previousStatus.ID != status.ID {
+		event, err := newOrderStatusChangedEvent(ctx, *order, previousStatus)
+		if err != nil {
+			return entities.Order{}, err
+		}
+		events = append(events, event)
+	}
+
+	err = uc.orderGateway.Update(ctx, *order, events...)
 	if err != nil {
 		return entities.Order{}, err
 	}
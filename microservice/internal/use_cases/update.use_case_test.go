@@ -1,6 +1,7 @@
 package use_cases
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -51,13 +52,13 @@ func TestUpdateOrderUseCase_OrderCanUpdateStatus(t *testing.T) {
 	customerID := "customer-123"
 	status1, _ := entities.NewOrderStatus("status-1", "Pending")
 	status2, _ := entities.NewOrderStatus("status-2", "Confirmed")
-	item, _ := entities.NewOrderItem("item-1", "product-1", "order-1", 2, 10.0)
+	item, _ := entities.NewOrderItem("item-1", "product-1", "order-1", 2, brl("10.00"), "", nil)
 	now := time.Now()
 
 	order, _ := entities.NewOrderWithItems(
 		"550e8400-e29b-41d4-a716-446655440000",
 		&customerID,
-		20.0,
+		brl("20.00"),
 		*status1,
 		[]entities.OrderItem{*item},
 		now,
@@ -79,13 +80,13 @@ func TestUpdateOrderUseCase_OrderCanUpdateStatus(t *testing.T) {
 func TestUpdateOrderUseCase_PreservesOrderData(t *testing.T) {
 	customerID := "customer-123"
 	status, _ := entities.NewOrderStatus("status-1", "Pending")
-	item, _ := entities.NewOrderItem("item-1", "product-1", "order-1", 2, 10.0)
+	item, _ := entities.NewOrderItem("item-1", "product-1", "order-1", 2, brl("10.00"), "", nil)
 	createdAt := time.Now().Add(-24 * time.Hour)
 
 	order, _ := entities.NewOrderWithItems(
 		"550e8400-e29b-41d4-a716-446655440000",
 		&customerID,
-		20.0,
+		brl("20.00"),
 		*status,
 		[]entities.OrderItem{*item},
 		createdAt,
@@ -98,8 +99,8 @@ func TestUpdateOrderUseCase_PreservesOrderData(t *testing.T) {
 	if *order.CustomerID != customerID {
 		t.Errorf("Order.CustomerID = %v, want %v", *order.CustomerID, customerID)
 	}
-	if order.Amount.Value() != 20.0 {
-		t.Errorf("Order.Amount = %v, want 20.0", order.Amount.Value())
+	if order.Amount.Value().String() != "20.00" {
+		t.Errorf("Order.Amount = %v, want 20.00", order.Amount.Value())
 	}
 	if len(order.Items) != 1 {
 		t.Errorf("Order.Items length = %v, want 1", len(order.Items))
@@ -129,7 +130,7 @@ func TestUpdateOrderUseCase_Execute_InvalidID(t *testing.T) {
 		StatusID: "status-1",
 	}
 
-	_, err := uc.Execute(dto)
+	_, err := uc.Execute(context.Background(), dto)
 	if err == nil {
 		t.Error("Expected error for invalid ID")
 	}
@@ -149,7 +150,7 @@ func TestUpdateOrderUseCase_Execute_EmptyID(t *testing.T) {
 		StatusID: "status-1",
 	}
 
-	_, err := uc.Execute(dto)
+	_, err := uc.Execute(context.Background(), dto)
 	if err == nil {
 		t.Error("Expected error for empty ID")
 	}
@@ -173,7 +174,7 @@ func TestUpdateOrderUseCase_Execute_ReturnsEmptyOrderOnError(t *testing.T) {
 		StatusID: "status-1",
 	}
 
-	order, err := uc.Execute(dto)
+	order, err := uc.Execute(context.Background(), dto)
 	if err == nil {
 		t.Error("Expected error for invalid ID")
 	}
@@ -208,10 +209,10 @@ func TestUpdateOrderUseCase_Execute_Success(t *testing.T) {
 	// Create and add test order and status
 	validID := "550e8400-e29b-41d4-a716-446655440000"
 	customerID := "customer-123"
-	oldStatus, _ := entities.NewOrderStatus("pending", "Pending")
-	newStatus, _ := entities.NewOrderStatus("paid", "Paid")
+	oldStatus, _ := entities.NewOrderStatus("pending", "Recebido")
+	newStatus, _ := entities.NewOrderStatus("paid", "Confirmado")
 	
-	order, _ := entities.NewOrderWithItems(validID, &customerID, 25.0, *oldStatus, []entities.OrderItem{}, time.Now(), nil)
+	order, _ := entities.NewOrderWithItems(validID, &customerID, brl("25.00"), *oldStatus, []entities.OrderItem{}, time.Now(), nil)
 	mockOrderGateway.AddOrder(order)
 	mockStatusGateway.AddStatus(newStatus)
 
@@ -220,7 +221,7 @@ func TestUpdateOrderUseCase_Execute_Success(t *testing.T) {
 		StatusID: "paid",
 	}
 
-	updatedOrder, err := uc.Execute(dto)
+	updatedOrder, err := uc.Execute(context.Background(), dto)
 	if err != nil {
 		t.Errorf("Expected no error for successful update, got %v", err)
 	}
@@ -244,7 +245,7 @@ func TestUpdateOrderUseCase_Execute_OrderNotFound(t *testing.T) {
 		StatusID: "paid",
 	}
 
-	order, err := uc.Execute(dto)
+	order, err := uc.Execute(context.Background(), dto)
 	if err == nil {
 		t.Error("Expected error when order not found")
 	}
@@ -267,7 +268,7 @@ func TestUpdateOrderUseCase_Execute_StatusNotFound(t *testing.T) {
 	validID := "550e8400-e29b-41d4-a716-446655440000"
 	customerID := "customer-123"
 	status, _ := entities.NewOrderStatus("pending", "Pending")
-	order, _ := entities.NewOrderWithItems(validID, &customerID, 25.0, *status, []entities.OrderItem{}, time.Now(), nil)
+	order, _ := entities.NewOrderWithItems(validID, &customerID, brl("25.00"), *status, []entities.OrderItem{}, time.Now(), nil)
 	mockOrderGateway.AddOrder(order)
 
 	dto := dtos.UpdateOrderDTO{
@@ -275,7 +276,7 @@ func TestUpdateOrderUseCase_Execute_StatusNotFound(t *testing.T) {
 		StatusID: "non-existent-status",
 	}
 
-	updatedOrder, err := uc.Execute(dto)
+	updatedOrder, err := uc.Execute(context.Background(), dto)
 	if err == nil {
 		t.Error("Expected error when status not found")
 	}
@@ -297,10 +298,10 @@ func TestUpdateOrderUseCase_Execute_UpdateError(t *testing.T) {
 	// Add order and status, but make update fail
 	validID := "550e8400-e29b-41d4-a716-446655440000"
 	customerID := "customer-123"
-	oldStatus, _ := entities.NewOrderStatus("pending", "Pending")
-	newStatus, _ := entities.NewOrderStatus("paid", "Paid")
+	oldStatus, _ := entities.NewOrderStatus("pending", "Recebido")
+	newStatus, _ := entities.NewOrderStatus("paid", "Confirmado")
 	
-	order, _ := entities.NewOrderWithItems(validID, &customerID, 25.0, *oldStatus, []entities.OrderItem{}, time.Now(), nil)
+	order, _ := entities.NewOrderWithItems(validID, &customerID, brl("25.00"), *oldStatus, []entities.OrderItem{}, time.Now(), nil)
 	mockOrderGateway.AddOrder(order)
 	mockStatusGateway.AddStatus(newStatus)
 	mockOrderGateway.SetShouldFailUpdate(true)
@@ -310,7 +311,7 @@ func TestUpdateOrderUseCase_Execute_UpdateError(t *testing.T) {
 		StatusID: "paid",
 	}
 
-	updatedOrder, err := uc.Execute(dto)
+	updatedOrder, err := uc.Execute(context.Background(), dto)
 	if err == nil {
 		t.Error("Expected error when update fails")
 	}
@@ -320,6 +321,35 @@ func TestUpdateOrderUseCase_Execute_UpdateError(t *testing.T) {
 	}
 }
 
+func TestUpdateOrderUseCase_Execute_InvalidStatusTransition(t *testing.T) {
+	mockOrderGateway := NewMockOrderGateway()
+	mockStatusGateway := NewMockOrderStatusGateway()
+	uc := NewUpdateOrderUseCase(mockOrderGateway, mockStatusGateway)
+
+	validID := "550e8400-e29b-41d4-a716-446655440000"
+	customerID := "customer-123"
+	oldStatus, _ := entities.NewOrderStatus("delivered", "Entregue")
+	newStatus, _ := entities.NewOrderStatus("preparing", "Em preparação")
+
+	order, _ := entities.NewOrderWithItems(validID, &customerID, brl("25.00"), *oldStatus, []entities.OrderItem{}, time.Now(), nil)
+	mockOrderGateway.AddOrder(order)
+	mockStatusGateway.AddStatus(newStatus)
+
+	_, err := uc.Execute(context.Background(), dtos.UpdateOrderDTO{
+		ID:       validID,
+		StatusID: "preparing",
+	})
+
+	if _, ok := err.(*exceptions.InvalidStatusTransitionException); !ok {
+		t.Errorf("Expected InvalidStatusTransitionException, got %T", err)
+	}
+
+	stored, _ := mockOrderGateway.FindByID(context.Background(), validID)
+	if stored.Status.ID != "delivered" {
+		t.Errorf("Expected stored status to remain 'delivered', got %s", stored.Status.ID)
+	}
+}
+
 func TestUpdateOrderUseCase_Execute_ValidatesID(t *testing.T) {
 	// Test ID validation
 	validID := "550e8400-e29b-41d4-a716-446655440000"
@@ -1,11 +1,14 @@
 package use_cases
 
 import (
+	"context"
 	"fmt"
-	"log"
 
+	"microservice/internal/adapters/brokers"
+	"microservice/internal/adapters/dtos"
 	"microservice/internal/domain/entities"
 	"microservice/internal/interfaces"
+	"microservice/utils/correlation"
 )
 
 type UpdateOrderStatusUseCase struct {
@@ -14,8 +17,12 @@ type UpdateOrderStatusUseCase struct {
 }
 
 type UpdateOrderStatusDTO struct {
-	OrderID string `json:"order_id"`
-	Status  string `json:"status"`
+	OrderID string  `json:"order_id"`
+	Status  string  `json:"status"`
+	Source  string  `json:"source"`
+	Actor   *string `json:"actor,omitempty"`
+	// Mensagem do broker que originou a mudança, gravada na mesma transação do pedido
+	ProcessedMessage *dtos.ProcessedMessageDTO `json:"-"`
 }
 
 type UpdateOrderStatusResult struct {
@@ -30,11 +37,11 @@ func NewUpdateOrderStatusUseCase(orderGateway interfaces.IOrderGateway, orderSta
 	}
 }
 
-func (uc *UpdateOrderStatusUseCase) Execute(dto UpdateOrderStatusDTO) (*UpdateOrderStatusResult, error) {
-	log.Printf("Updating order %s status to: %s", dto.OrderID, dto.Status)
+func (uc *UpdateOrderStatusUseCase) Execute(ctx context.Context, dto UpdateOrderStatusDTO) (*UpdateOrderStatusResult, error) {
+	correlation.Logf(ctx, "Updating order %s status to: %s", dto.OrderID, dto.Status)
 
 	// Buscar o pedido
-	order, err := uc.orderGateway.FindByID(dto.OrderID)
+	order, err := uc.orderGateway.FindByID(ctx, dto.OrderID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find order %s: %w", dto.OrderID, err)
 	}
@@ -43,16 +50,32 @@ func (uc *UpdateOrderStatusUseCase) Execute(dto UpdateOrderStatusDTO) (*UpdateOr
 	orderStatusName := uc.mapKitchenStatusToOrderStatus(dto.Status)
 
 	// Buscar o status pelo nome
-	newStatus, err := uc.orderStatusGateway.FindByName(orderStatusName)
+	newStatus, err := uc.orderStatusGateway.FindByName(ctx, orderStatusName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find order status '%s': %w", orderStatusName, err)
 	}
 
-	// Atualizar o status do pedido
-	order.Status = *newStatus
+	// Atualizar o status do pedido respeitando as transições permitidas
+	previousStatus := order.Status
+	if err := order.ChangeStatus(*newStatus, dto.Source, dto.Actor); err != nil {
+		return nil, err
+	}
+
+	var events []brokers.OrderEvent
+	if previousStatus.ID != newStatus.ID {
+		event, err := newOrderStatusChangedEvent(ctx, *order, previousStatus)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
 
-	// Salvar as alterações
-	err = uc.orderGateway.Update(*order)
+	// Salvar as alterações junto com os eventos (outbox)
+	if dto.ProcessedMessage != nil {
+		err = uc.orderGateway.UpdateFromMessage(ctx, *order, *dto.ProcessedMessage, events...)
+	} else {
+		err = uc.orderGateway.Update(ctx, *order, events...)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to update order %s: %w", dto.OrderID, err)
 	}
@@ -62,15 +85,15 @@ func (uc *UpdateOrderStatusUseCase) Execute(dto UpdateOrderStatusDTO) (*UpdateOr
 		Message: fmt.Sprintf("Order %s status updated to %s", dto.OrderID, orderStatusName),
 	}
 
-	log.Printf("Order %s status successfully updated to: %s", dto.OrderID, orderStatusName)
+	correlation.Logf(ctx, "Order %s status successfully updated to: %s", dto.OrderID, orderStatusName)
 	return result, nil
 }
 
 func (uc *UpdateOrderStatusUseCase) mapKitchenStatusToOrderStatus(kitchenStatus string) string {
 	statusMap := map[string]string{
-		"Em preparação": "Em preparação",
-		"Pronto":        "Pronto",
-		"Finalizado":    "Finalizado",
+		"Em preparação": entities.ORDER_STATUS_PREPARING,
+		"Pronto":        entities.ORDER_STATUS_READY,
+		"Finalizado":    entities.ORDER_STATUS_DELIVERED,
 	}
 
 	if orderStatus, exists := statusMap[kitchenStatus]; exists {
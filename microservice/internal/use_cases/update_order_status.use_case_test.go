@@ -1,11 +1,16 @@
 package use_cases
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"microservice/internal/adapters/brokers"
 	"microservice/internal/adapters/dtos"
 	"microservice/internal/domain/entities"
+	"microservice/internal/domain/exceptions"
+	"microservice/internal/domain/value_objects"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -15,58 +20,105 @@ type mockOrderGateway struct {
 	findByIDFunc func(id string) (*entities.Order, error)
 	updateFunc   func(order entities.Order) error
 	findAllFunc  func(filter dtos.OrderFilterDTO) ([]entities.Order, error)
+
+	processedMessages []dtos.ProcessedMessageDTO
 }
 
-func (m *mockOrderGateway) FindByID(id string) (*entities.Order, error) {
+func (m *mockOrderGateway) FindByID(ctx context.Context, id string) (*entities.Order, error) {
 	if m.findByIDFunc != nil {
 		return m.findByIDFunc(id)
 	}
 	return nil, errors.New("not implemented")
 }
 
-func (m *mockOrderGateway) Update(order entities.Order) error {
+func (m *mockOrderGateway) Update(ctx context.Context, order entities.Order, events ...brokers.OrderEvent) error {
 	if m.updateFunc != nil {
 		return m.updateFunc(order)
 	}
 	return nil
 }
 
-func (m *mockOrderGateway) Create(order entities.Order) error {
+func (m *mockOrderGateway) UpdateFromMessage(ctx context.Context, order entities.Order, message dtos.ProcessedMessageDTO, events ...brokers.OrderEvent) error {
+	m.processedMessages = append(m.processedMessages, message)
+	return m.Update(ctx, order, events...)
+}
+
+func (m *mockOrderGateway) ApplyCoupon(ctx context.Context, order entities.Order, events ...brokers.OrderEvent) error {
 	return errors.New("not implemented")
 }
 
-func (m *mockOrderGateway) FindAll(filter dtos.OrderFilterDTO) ([]entities.Order, error) {
+func (m *mockOrderGateway) Create(ctx context.Context, order entities.Order, events ...brokers.OrderEvent) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockOrderGateway) FindAll(ctx context.Context, filter dtos.OrderFilterDTO) ([]entities.Order, error) {
 	if m.findAllFunc != nil {
 		return m.findAllFunc(filter)
 	}
 	return nil, errors.New("not implemented")
 }
 
-func (m *mockOrderGateway) Delete(id string) error {
+func (m *mockOrderGateway) Count(ctx context.Context, filter dtos.OrderFilterDTO) (int64, error) {
+	return 0, errors.New("not implemented")
+}
+
+func (m *mockOrderGateway) Delete(ctx context.Context, id string, events ...brokers.OrderEvent) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockOrderGateway) FindByPickupCode(ctx context.Context, day string, code value_objects.PickupCode) (*entities.Order, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockOrderGateway) FindBoard(ctx context.Context, statuses []entities.OrderStatus, createdFrom time.Time) ([]entities.OrderBoardEntry, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockOrderGateway) NextPickupCode(ctx context.Context, day string) (value_objects.PickupCode, error) {
+	return value_objects.PickupCode{}, errors.New("not implemented")
+}
+
+func (m *mockOrderGateway) Restore(ctx context.Context, id string, events ...brokers.OrderEvent) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockOrderGateway) Claim(ctx context.Context, orderID string, customerID string, guestTokenHash string, claimedAt time.Time) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockOrderGateway) ReissueGuestToken(ctx context.Context, orderID string, guestTokenHash string) error {
 	return errors.New("not implemented")
 }
 
+func (m *mockOrderGateway) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	return 0, errors.New("not implemented")
+}
+
+func (m *mockOrderGateway) FindStatusHistory(ctx context.Context, orderID string) ([]entities.OrderStatusChange, error) {
+	return nil, errors.New("not implemented")
+}
+
 type mockOrderStatusGateway struct {
 	findByNameFunc func(name string) (*entities.OrderStatus, error)
 	findByIDFunc   func(id string) (*entities.OrderStatus, error)
 	findAllFunc    func() ([]entities.OrderStatus, error)
 }
 
-func (m *mockOrderStatusGateway) FindByName(name string) (*entities.OrderStatus, error) {
+func (m *mockOrderStatusGateway) FindByName(ctx context.Context, name string) (*entities.OrderStatus, error) {
 	if m.findByNameFunc != nil {
 		return m.findByNameFunc(name)
 	}
 	return nil, errors.New("not implemented")
 }
 
-func (m *mockOrderStatusGateway) FindByID(id string) (*entities.OrderStatus, error) {
+func (m *mockOrderStatusGateway) FindByID(ctx context.Context, id string) (*entities.OrderStatus, error) {
 	if m.findByIDFunc != nil {
 		return m.findByIDFunc(id)
 	}
 	return nil, errors.New("not implemented")
 }
 
-func (m *mockOrderStatusGateway) FindAll() ([]entities.OrderStatus, error) {
+func (m *mockOrderStatusGateway) FindAll(ctx context.Context) ([]entities.OrderStatus, error) {
 	if m.findAllFunc != nil {
 		return m.findAllFunc()
 	}
@@ -86,7 +138,7 @@ func TestNewUpdateOrderStatusUseCase(t *testing.T) {
 func TestUpdateOrderStatusUseCase_Execute_Success(t *testing.T) {
 	customerID := "customer-123"
 	order, _ := entities.NewOrder("order-123", &customerID)
-	oldStatus, _ := entities.NewOrderStatus("status-1", "Recebido")
+	oldStatus, _ := entities.NewOrderStatus("status-1", "Confirmado")
 	newStatus, _ := entities.NewOrderStatus("status-2", "Em preparação")
 	order.Status = *oldStatus
 
@@ -115,10 +167,11 @@ func TestUpdateOrderStatusUseCase_Execute_Success(t *testing.T) {
 	
 	dto := UpdateOrderStatusDTO{
 		OrderID: "order-123",
+		Source:  entities.STATUS_CHANGE_SOURCE_KITCHEN,
 		Status:  "Em preparação",
 	}
 
-	result, err := useCase.Execute(dto)
+	result, err := useCase.Execute(context.Background(), dto)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -127,6 +180,35 @@ func TestUpdateOrderStatusUseCase_Execute_Success(t *testing.T) {
 	assert.Contains(t, result.Message, "Order order-123 status updated to Em preparação")
 }
 
+func TestUpdateOrderStatusUseCase_Execute_RecordsProcessedMessage(t *testing.T) {
+	order, _ := entities.NewOrder("order-123", nil)
+	confirmed, _ := entities.NewOrderStatus("status-2", entities.ORDER_STATUS_CONFIRMED)
+	preparing, _ := entities.NewOrderStatus("status-3", entities.ORDER_STATUS_PREPARING)
+	order.Status = *confirmed
+
+	orderGateway := &mockOrderGateway{
+		findByIDFunc: func(id string) (*entities.Order, error) {
+			return order, nil
+		},
+	}
+	statusGateway := &mockOrderStatusGateway{
+		findByNameFunc: func(name string) (*entities.OrderStatus, error) {
+			return preparing, nil
+		},
+	}
+
+	useCase := NewUpdateOrderStatusUseCase(orderGateway, statusGateway)
+	_, err := useCase.Execute(context.Background(), UpdateOrderStatusDTO{
+		OrderID:          "order-123",
+		Status:           entities.ORDER_STATUS_PREPARING,
+		Source:           entities.STATUS_CHANGE_SOURCE_KITCHEN,
+		ProcessedMessage: &dtos.ProcessedMessageDTO{Consumer: "order_updates", MessageID: "message-1"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dtos.ProcessedMessageDTO{{Consumer: "order_updates", MessageID: "message-1"}}, orderGateway.processedMessages)
+}
+
 func TestUpdateOrderStatusUseCase_Execute_OrderNotFound(t *testing.T) {
 	orderGateway := &mockOrderGateway{
 		findByIDFunc: func(id string) (*entities.Order, error) {
@@ -140,10 +222,11 @@ func TestUpdateOrderStatusUseCase_Execute_OrderNotFound(t *testing.T) {
 	
 	dto := UpdateOrderStatusDTO{
 		OrderID: "non-existent-order",
+		Source:  entities.STATUS_CHANGE_SOURCE_KITCHEN,
 		Status:  "Em preparação",
 	}
 
-	result, err := useCase.Execute(dto)
+	result, err := useCase.Execute(context.Background(), dto)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -172,10 +255,11 @@ func TestUpdateOrderStatusUseCase_Execute_StatusNotFound(t *testing.T) {
 	
 	dto := UpdateOrderStatusDTO{
 		OrderID: "order-123",
+		Source:  entities.STATUS_CHANGE_SOURCE_KITCHEN,
 		Status:  "Status Inexistente",
 	}
 
-	result, err := useCase.Execute(dto)
+	result, err := useCase.Execute(context.Background(), dto)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -185,7 +269,7 @@ func TestUpdateOrderStatusUseCase_Execute_StatusNotFound(t *testing.T) {
 func TestUpdateOrderStatusUseCase_Execute_UpdateError(t *testing.T) {
 	customerID := "customer-123"
 	order, _ := entities.NewOrder("order-123", &customerID)
-	oldStatus, _ := entities.NewOrderStatus("status-1", "Recebido")
+	oldStatus, _ := entities.NewOrderStatus("status-1", "Confirmado")
 	newStatus, _ := entities.NewOrderStatus("status-2", "Em preparação")
 	order.Status = *oldStatus
 
@@ -208,10 +292,11 @@ func TestUpdateOrderStatusUseCase_Execute_UpdateError(t *testing.T) {
 	
 	dto := UpdateOrderStatusDTO{
 		OrderID: "order-123",
+		Source:  entities.STATUS_CHANGE_SOURCE_KITCHEN,
 		Status:  "Em preparação",
 	}
 
-	result, err := useCase.Execute(dto)
+	result, err := useCase.Execute(context.Background(), dto)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -240,7 +325,7 @@ func TestUpdateOrderStatusUseCase_MapKitchenStatusToOrderStatus(t *testing.T) {
 		{
 			name:           "Finalizado mapping",
 			kitchenStatus:  "Finalizado",
-			expectedStatus: "Finalizado",
+			expectedStatus: "Entregue",
 		},
 		{
 			name:           "Unknown status returns same",
@@ -260,6 +345,7 @@ func TestUpdateOrderStatusUseCase_MapKitchenStatusToOrderStatus(t *testing.T) {
 func TestUpdateOrderStatusDTO_Structure(t *testing.T) {
 	dto := UpdateOrderStatusDTO{
 		OrderID: "order-123",
+		Source:  entities.STATUS_CHANGE_SOURCE_KITCHEN,
 		Status:  "Em preparação",
 	}
 
@@ -284,21 +370,21 @@ func TestUpdateOrderStatusResult_Structure(t *testing.T) {
 
 func TestUpdateOrderStatusUseCase_Execute_AllKitchenStatuses(t *testing.T) {
 	testCases := []struct {
-		name          string
-		kitchenStatus string
+		name           string
+		currentStatus  string
+		kitchenStatus  string
 		expectedStatus string
 	}{
-		{"Em preparação", "Em preparação", "Em preparação"},
-		{"Pronto", "Pronto", "Pronto"},
-		{"Finalizado", "Finalizado", "Finalizado"},
-		{"Cancelado", "Cancelado", "Cancelado"},
+		{"Em preparação", "Confirmado", "Em preparação", "Em preparação"},
+		{"Pronto", "Em preparação", "Pronto", "Pronto"},
+		{"Finalizado", "Pronto", "Finalizado", "Entregue"},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			customerID := "customer-123"
 			order, _ := entities.NewOrder("order-123", &customerID)
-			oldStatus, _ := entities.NewOrderStatus("status-1", "Recebido")
+			oldStatus, _ := entities.NewOrderStatus("status-1", tc.currentStatus)
 			newStatus, _ := entities.NewOrderStatus("status-2", tc.expectedStatus)
 			order.Status = *oldStatus
 
@@ -324,14 +410,81 @@ func TestUpdateOrderStatusUseCase_Execute_AllKitchenStatuses(t *testing.T) {
 			
 			dto := UpdateOrderStatusDTO{
 				OrderID: "order-123",
+				Source:  entities.STATUS_CHANGE_SOURCE_KITCHEN,
 				Status:  tc.kitchenStatus,
 			}
 
-			result, err := useCase.Execute(dto)
+			result, err := useCase.Execute(context.Background(), dto)
 
 			assert.NoError(t, err)
 			assert.NotNil(t, result)
 			assert.Equal(t, tc.expectedStatus, result.Order.Status.Name.Value())
 		})
 	}
-}
\ No newline at end of file
+}
+func TestUpdateOrderStatusUseCase_Execute_RejectsCancellation(t *testing.T) {
+	customerID := "customer-123"
+	order, _ := entities.NewOrder("order-123", &customerID)
+	oldStatus, _ := entities.NewOrderStatus("status-1", "Confirmado")
+	cancelled, _ := entities.NewOrderStatus("status-2", "Cancelado")
+	order.Status = *oldStatus
+
+	updated := false
+	orderGateway := &mockOrderGateway{
+		findByIDFunc: func(id string) (*entities.Order, error) {
+			return order, nil
+		},
+		updateFunc: func(o entities.Order) error {
+			updated = true
+			return nil
+		},
+	}
+	statusGateway := &mockOrderStatusGateway{
+		findByNameFunc: func(name string) (*entities.OrderStatus, error) {
+			return cancelled, nil
+		},
+	}
+
+	_, err := NewUpdateOrderStatusUseCase(orderGateway, statusGateway).Execute(context.Background(), UpdateOrderStatusDTO{
+		OrderID: "order-123",
+		Source:  entities.STATUS_CHANGE_SOURCE_KITCHEN,
+		Status:  "Cancelado",
+	})
+
+	var transitionErr *exceptions.InvalidStatusTransitionException
+	assert.ErrorAs(t, err, &transitionErr)
+	assert.False(t, updated)
+}
+
+func TestUpdateOrderStatusUseCase_Execute_InvalidTransition(t *testing.T) {
+	customerID := "customer-123"
+	order, _ := entities.NewOrder("order-123", &customerID)
+	oldStatus, _ := entities.NewOrderStatus("status-1", "Recebido")
+	newStatus, _ := entities.NewOrderStatus("status-2", "Pronto")
+	order.Status = *oldStatus
+
+	updated := false
+	orderGateway := &mockOrderGateway{
+		findByIDFunc: func(id string) (*entities.Order, error) {
+			return order, nil
+		},
+		updateFunc: func(o entities.Order) error {
+			updated = true
+			return nil
+		},
+	}
+
+	statusGateway := &mockOrderStatusGateway{
+		findByNameFunc: func(name string) (*entities.OrderStatus, error) {
+			return newStatus, nil
+		},
+	}
+
+	useCase := NewUpdateOrderStatusUseCase(orderGateway, statusGateway)
+
+	result, err := useCase.Execute(context.Background(), UpdateOrderStatusDTO{OrderID: "order-123", Status: "Pronto", Source: entities.STATUS_CHANGE_SOURCE_KITCHEN})
+
+	assert.Nil(t, result)
+	assert.IsType(t, &exceptions.InvalidStatusTransitionException{}, err)
+	assert.False(t, updated)
+}
@@ -3,30 +3,43 @@ package use_cases
 import (
 	"context"
 	"errors"
+	"slices"
+	"sort"
 	"testing"
+	"time"
 
-	"microservice/internal/adapters/brokers"
 	"microservice/internal/adapters/daos"
 	"microservice/internal/adapters/dtos"
 	"microservice/internal/adapters/gateways"
+	"microservice/internal/domain/exceptions"
 )
 
 type testOrderDataSource struct {
-	orders map[string]daos.OrderDAO
+	orders  map[string]daos.OrderDAO
+	outbox  []daos.OutboxMessageDAO
+	history []daos.OrderStatusHistoryDAO
+	deleted map[string]daos.OrderDAO
+
+	pickupSequences map[string]int
 }
 
 func newTestOrderDataSource() *testOrderDataSource {
 	return &testOrderDataSource{
-		orders: make(map[string]daos.OrderDAO),
+		orders:  make(map[string]daos.OrderDAO),
+		deleted: make(map[string]daos.OrderDAO),
+
+		pickupSequences: make(map[string]int),
 	}
 }
 
-func (ds *testOrderDataSource) Create(order daos.OrderDAO) error {
+func (ds *testOrderDataSource) Create(ctx context.Context, order daos.OrderDAO, outbox ...daos.OutboxMessageDAO) error {
 	ds.orders[order.ID] = order
+	ds.outbox = append(ds.outbox, outbox...)
+	ds.history = append(ds.history, order.StatusChanges...)
 	return nil
 }
 
-func (ds *testOrderDataSource) FindAll(filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error) {
+func (ds *testOrderDataSource) FindAll(ctx context.Context, filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error) {
 	result := make([]daos.OrderDAO, 0, len(ds.orders))
 	for _, order := range ds.orders {
 		result = append(result, order)
@@ -34,7 +47,11 @@ func (ds *testOrderDataSource) FindAll(filter dtos.OrderFilterDTO) ([]daos.Order
 	return result, nil
 }
 
-func (ds *testOrderDataSource) FindByID(id string) (daos.OrderDAO, error) {
+func (ds *testOrderDataSource) Count(ctx context.Context, filter dtos.OrderFilterDTO) (int64, error) {
+	return int64(len(ds.orders)), nil
+}
+
+func (ds *testOrderDataSource) FindByID(ctx context.Context, id string) (daos.OrderDAO, error) {
 	order, ok := ds.orders[id]
 	if !ok {
 		return daos.OrderDAO{}, errors.New("not found")
@@ -42,16 +59,114 @@ func (ds *testOrderDataSource) FindByID(id string) (daos.OrderDAO, error) {
 	return order, nil
 }
 
-func (ds *testOrderDataSource) Update(order daos.OrderDAO) error {
+func (ds *testOrderDataSource) Update(ctx context.Context, order daos.OrderDAO, outbox ...daos.OutboxMessageDAO) error {
+	ds.orders[order.ID] = order
+	ds.outbox = append(ds.outbox, outbox...)
+	ds.history = append(ds.history, order.StatusChanges...)
+	return nil
+}
+
+func (ds *testOrderDataSource) UpdateFromMessage(ctx context.Context, order daos.OrderDAO, message daos.ProcessedMessageDAO, outbox ...daos.OutboxMessageDAO) error {
+	return ds.Update(ctx, order, outbox...)
+}
+
+func (ds *testOrderDataSource) ApplyCoupon(ctx context.Context, order daos.OrderDAO, outbox ...daos.OutboxMessageDAO) error {
 	ds.orders[order.ID] = order
+	ds.outbox = append(ds.outbox, outbox...)
 	return nil
 }
 
-func (ds *testOrderDataSource) Delete(id string) error {
+func (ds *testOrderDataSource) Delete(ctx context.Context, id string, outbox ...daos.OutboxMessageDAO) error {
+	if order, ok := ds.orders[id]; ok {
+		ds.deleted[id] = order
+	}
 	delete(ds.orders, id)
+	ds.outbox = append(ds.outbox, outbox...)
+	return nil
+}
+
+func (ds *testOrderDataSource) FindByPickupCode(ctx context.Context, day string, code string) (daos.OrderDAO, error) {
+	for _, order := range ds.orders {
+		if order.PickupDate != nil && *order.PickupDate == day && order.PickupCode != nil && *order.PickupCode == code {
+			return order, nil
+		}
+	}
+	return daos.OrderDAO{}, errors.New("not found")
+}
+
+func (ds *testOrderDataSource) FindBoard(ctx context.Context, statusIDs []string, createdFrom time.Time) ([]daos.OrderBoardEntryDAO, error) {
+	var entries []daos.OrderBoardEntryDAO
+	for _, order := range ds.orders {
+		if !slices.Contains(statusIDs, order.Status.ID) || order.CreatedAt.Before(createdFrom) {
+			continue
+		}
+		entries = append(entries, daos.OrderBoardEntryDAO{
+			ID:           order.ID,
+			PickupCode:   order.PickupCode,
+			CustomerName: order.CustomerName,
+			StatusID:     order.Status.ID,
+			StatusName:   order.Status.Name,
+			CreatedAt:    order.CreatedAt,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+	return entries, nil
+}
+
+func (ds *testOrderDataSource) NextPickupSequence(ctx context.Context, day string) (int, error) {
+	ds.pickupSequences[day]++
+	return ds.pickupSequences[day], nil
+}
+
+func (ds *testOrderDataSource) Claim(ctx context.Context, orderID string, customerID string, guestTokenHash string, claimedAt time.Time) error {
+	order, ok := ds.orders[orderID]
+	if !ok || order.CustomerID != nil || order.GuestTokenHash == nil || *order.GuestTokenHash != guestTokenHash {
+		return &exceptions.OrderAlreadyClaimedException{}
+	}
+	order.CustomerID = &customerID
+	order.GuestTokenHash = nil
+	order.UpdatedAt = &claimedAt
+	ds.orders[orderID] = order
+	return nil
+}
+
+func (ds *testOrderDataSource) ReissueGuestToken(ctx context.Context, orderID string, guestTokenHash string) error {
+	order, ok := ds.orders[orderID]
+	if !ok || order.CustomerID != nil {
+		return &exceptions.OrderAlreadyClaimedException{}
+	}
+	order.GuestTokenHash = &guestTokenHash
+	ds.orders[orderID] = order
+	return nil
+}
+
+func (ds *testOrderDataSource) Restore(ctx context.Context, id string, outbox ...daos.OutboxMessageDAO) error {
+	order, ok := ds.deleted[id]
+	if !ok {
+		return &exceptions.OrderNotFoundException{Message: "Deleted order not found"}
+	}
+	delete(ds.deleted, id)
+	ds.orders[id] = order
+	ds.outbox = append(ds.outbox, outbox...)
 	return nil
 }
 
+func (ds *testOrderDataSource) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	return 0, nil
+}
+
+func (ds *testOrderDataSource) FindStatusHistory(ctx context.Context, orderID string) ([]daos.OrderStatusHistoryDAO, error) {
+	history := make([]daos.OrderStatusHistoryDAO, 0)
+	for _, change := range ds.history {
+		if change.OrderID == orderID {
+			history = append(history, change)
+		}
+	}
+	return history, nil
+}
+
 type testOrderStatusDataSource struct {
 	statuses       map[string]daos.OrderStatusDAO
 	statusesByName map[string]daos.OrderStatusDAO
@@ -71,7 +186,13 @@ func newTestOrderStatusDataSource() *testOrderStatusDataSource {
 	return ds
 }
 
-func (ds *testOrderStatusDataSource) FindByID(id string) (daos.OrderStatusDAO, error) {
+func (ds *testOrderStatusDataSource) addStatus(id string, name string) {
+	status := daos.OrderStatusDAO{ID: id, Name: name}
+	ds.statuses[id] = status
+	ds.statusesByName[name] = status
+}
+
+func (ds *testOrderStatusDataSource) FindByID(ctx context.Context, id string) (daos.OrderStatusDAO, error) {
 	status, ok := ds.statuses[id]
 	if !ok {
 		return daos.OrderStatusDAO{}, errors.New("not found")
@@ -79,7 +200,7 @@ func (ds *testOrderStatusDataSource) FindByID(id string) (daos.OrderStatusDAO, e
 	return status, nil
 }
 
-func (ds *testOrderStatusDataSource) FindByName(name string) (daos.OrderStatusDAO, error) {
+func (ds *testOrderStatusDataSource) FindByName(ctx context.Context, name string) (daos.OrderStatusDAO, error) {
 	status, ok := ds.statusesByName[name]
 	if !ok {
 		return daos.OrderStatusDAO{}, errors.New("not found")
@@ -87,7 +208,7 @@ func (ds *testOrderStatusDataSource) FindByName(name string) (daos.OrderStatusDA
 	return status, nil
 }
 
-func (ds *testOrderStatusDataSource) FindAll() ([]daos.OrderStatusDAO, error) {
+func (ds *testOrderStatusDataSource) FindAll(ctx context.Context) ([]daos.OrderStatusDAO, error) {
 	result := make([]daos.OrderStatusDAO, 0, len(ds.statuses))
 	for _, status := range ds.statuses {
 		result = append(result, status)
@@ -95,34 +216,43 @@ func (ds *testOrderStatusDataSource) FindAll() ([]daos.OrderStatusDAO, error) {
 	return result, nil
 }
 
-// Mock do MessageBroker para testes
-type testMessageBroker struct{}
+type testProductCatalogDataSource struct {
+	products map[string]daos.ProductDAO
+}
 
-func (m *testMessageBroker) ConsumeOrderUpdates(ctx context.Context, handler brokers.OrderUpdateHandler) error {
-	return nil
+func newTestProductCatalogDataSource() *testProductCatalogDataSource {
+	return &testProductCatalogDataSource{
+		products: map[string]daos.ProductDAO{
+			"product-1": {ID: "product-1", Name: "X-Burger", Price: 1000, Currency: "BRL", Available: true},
+			"product-2": {ID: "product-2", Name: "Batata Frita", Price: 2500, Currency: "BRL", Available: true},
+		},
+	}
 }
 
-func (m *testMessageBroker) Close() error {
-	return nil
+func (ds *testProductCatalogDataSource) FindByID(ctx context.Context, productID string) (*daos.ProductDAO, error) {
+	product, ok := ds.products[productID]
+	if !ok {
+		return nil, nil
+	}
+	return &product, nil
 }
 
 func TestCreateOrderUseCase_Execute_Integration(t *testing.T) {
 	orderDS := newTestOrderDataSource()
 	statusDS := newTestOrderStatusDataSource()
-	broker := &testMessageBroker{}
 
 	orderGateway := gateways.NewOrderGateway(orderDS)
 	statusGateway := gateways.NewOrderStatusGateway(statusDS)
 
-	uc := NewCreateOrderUseCase(orderGateway, statusGateway, broker)
+	uc := NewCreateOrderUseCase(orderGateway, statusGateway, gateways.NewProductCatalogGateway(newTestProductCatalogDataSource()), time.UTC)
 
 	customerID := "customer-123"
 	items := []dtos.CreateOrderItemDTO{
-		{ProductID: "product-1", Quantity: 2, Price: 10.0},
-		{ProductID: "product-2", Quantity: 1, Price: 25.0},
+		{ProductID: "product-1", Quantity: 2, Price: "10.00"},
+		{ProductID: "product-2", Quantity: 1, Price: "25.00"},
 	}
 
-	result, err := uc.Execute(&customerID, items)
+	result, err := uc.Execute(context.Background(), dtos.CreateOrderDTO{CustomerID: &customerID, Items: items})
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -140,27 +270,26 @@ func TestCreateOrderUseCase_Execute_Integration(t *testing.T) {
 		t.Errorf("Expected 2 items, got %d", len(result.Items))
 	}
 
-	expectedAmount := 45.0
-	if result.Amount.Value() != expectedAmount {
-		t.Errorf("Expected amount %.2f, got %.2f", expectedAmount, result.Amount.Value())
+	expectedAmount := "45.00"
+	if result.Amount.Value().String() != expectedAmount {
+		t.Errorf("Expected amount %s, got %s", expectedAmount, result.Amount.Value())
 	}
 }
 
 func TestCreateOrderUseCase_Execute_WithoutCustomerID_Integration(t *testing.T) {
 	orderDS := newTestOrderDataSource()
 	statusDS := newTestOrderStatusDataSource()
-	broker := &testMessageBroker{}
 
 	orderGateway := gateways.NewOrderGateway(orderDS)
 	statusGateway := gateways.NewOrderStatusGateway(statusDS)
 
-	uc := NewCreateOrderUseCase(orderGateway, statusGateway, broker)
+	uc := NewCreateOrderUseCase(orderGateway, statusGateway, gateways.NewProductCatalogGateway(newTestProductCatalogDataSource()), time.UTC)
 
 	items := []dtos.CreateOrderItemDTO{
-		{ProductID: "product-1", Quantity: 1, Price: 15.0},
+		{ProductID: "product-1", Quantity: 1, Price: "10.00"},
 	}
 
-	result, err := uc.Execute(nil, items)
+	result, err := uc.Execute(context.Background(), dtos.CreateOrderDTO{Items: items})
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -178,19 +307,18 @@ func TestCreateOrderUseCase_Execute_WithoutCustomerID_Integration(t *testing.T)
 func TestCreateOrderUseCase_Execute_StatusNotFound_Integration(t *testing.T) {
 	orderDS := newTestOrderDataSource()
 	statusDS := &testOrderStatusDataSource{statuses: make(map[string]daos.OrderStatusDAO)} // Empty statuses
-	broker := &testMessageBroker{}
 
 	orderGateway := gateways.NewOrderGateway(orderDS)
 	statusGateway := gateways.NewOrderStatusGateway(statusDS)
 
-	uc := NewCreateOrderUseCase(orderGateway, statusGateway, broker)
+	uc := NewCreateOrderUseCase(orderGateway, statusGateway, gateways.NewProductCatalogGateway(newTestProductCatalogDataSource()), time.UTC)
 
 	customerID := "customer-123"
 	items := []dtos.CreateOrderItemDTO{
-		{ProductID: "product-1", Quantity: 1, Price: 15.0},
+		{ProductID: "product-1", Quantity: 1, Price: "10.00"},
 	}
 
-	_, err := uc.Execute(&customerID, items)
+	_, err := uc.Execute(context.Background(), dtos.CreateOrderDTO{CustomerID: &customerID, Items: items})
 
 	if err == nil {
 		t.Error("Expected error when status not found")
@@ -203,7 +331,7 @@ func TestFindAllOrdersUseCase_Execute_Integration(t *testing.T) {
 	orderDS.orders["550e8400-e29b-41d4-a716-446655440000"] = daos.OrderDAO{
 		ID:         "550e8400-e29b-41d4-a716-446655440000",
 		CustomerID: stringPtr("customer-1"),
-		Amount:     25.0,
+		Amount:     2500,
 		Status: daos.OrderStatusDAO{
 			ID:   "status-1",
 			Name: "Pending",
@@ -214,7 +342,7 @@ func TestFindAllOrdersUseCase_Execute_Integration(t *testing.T) {
 				OrderID:   "550e8400-e29b-41d4-a716-446655440000",
 				ProductID: "product-1",
 				Quantity:  1,
-				UnitPrice: 25.0,
+				UnitPrice: 2500,
 			},
 		},
 	}
@@ -223,14 +351,17 @@ func TestFindAllOrdersUseCase_Execute_Integration(t *testing.T) {
 
 	uc := NewFindAllOrdersUseCase(orderGateway)
 
-	result, err := uc.Execute(dtos.OrderFilterDTO{})
+	result, err := uc.Execute(context.Background(), dtos.OrderFilterDTO{})
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
 
-	if len(result) != 1 {
-		t.Errorf("Expected 1 order, got %d", len(result))
+	if len(result.Orders) != 1 {
+		t.Errorf("Expected 1 order, got %d", len(result.Orders))
+	}
+	if result.Total != 1 {
+		t.Errorf("Expected total 1, got %d", result.Total)
 	}
 }
 
@@ -241,7 +372,7 @@ func TestFindAllOrderStatusUseCase_Execute_Integration(t *testing.T) {
 
 	uc := NewFindAllOrderStatusUseCase(statusGateway)
 
-	result, err := uc.Execute()
+	result, err := uc.Execute(context.Background())
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -255,42 +386,85 @@ func TestFindAllOrderStatusUseCase_Execute_Integration(t *testing.T) {
 // Error data source for testing error scenarios
 type errorOrderDataSource struct{}
 
-func (ds *errorOrderDataSource) Create(order daos.OrderDAO) error {
+func (ds *errorOrderDataSource) Create(ctx context.Context, order daos.OrderDAO, outbox ...daos.OutboxMessageDAO) error {
 	return errors.New("database error")
 }
 
-func (ds *errorOrderDataSource) FindAll(filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error) {
+func (ds *errorOrderDataSource) FindAll(ctx context.Context, filter dtos.OrderFilterDTO) ([]daos.OrderDAO, error) {
 	return nil, errors.New("database error")
 }
 
-func (ds *errorOrderDataSource) FindByID(id string) (daos.OrderDAO, error) {
+func (ds *errorOrderDataSource) Count(ctx context.Context, filter dtos.OrderFilterDTO) (int64, error) {
+	return 0, errors.New("database error")
+}
+
+func (ds *errorOrderDataSource) FindByID(ctx context.Context, id string) (daos.OrderDAO, error) {
 	return daos.OrderDAO{}, errors.New("database error")
 }
 
-func (ds *errorOrderDataSource) Update(order daos.OrderDAO) error {
+func (ds *errorOrderDataSource) Update(ctx context.Context, order daos.OrderDAO, outbox ...daos.OutboxMessageDAO) error {
+	return errors.New("database error")
+}
+
+func (ds *errorOrderDataSource) UpdateFromMessage(ctx context.Context, order daos.OrderDAO, message daos.ProcessedMessageDAO, outbox ...daos.OutboxMessageDAO) error {
+	return errors.New("database error")
+}
+
+func (ds *errorOrderDataSource) ApplyCoupon(ctx context.Context, order daos.OrderDAO, outbox ...daos.OutboxMessageDAO) error {
 	return errors.New("database error")
 }
 
-func (ds *errorOrderDataSource) Delete(id string) error {
+func (ds *errorOrderDataSource) Delete(ctx context.Context, id string, outbox ...daos.OutboxMessageDAO) error {
 	return errors.New("database error")
 }
 
+func (ds *errorOrderDataSource) FindByPickupCode(ctx context.Context, day string, code string) (daos.OrderDAO, error) {
+	return daos.OrderDAO{}, errors.New("database error")
+}
+
+func (ds *errorOrderDataSource) FindBoard(ctx context.Context, statusIDs []string, createdFrom time.Time) ([]daos.OrderBoardEntryDAO, error) {
+	return nil, errors.New("database error")
+}
+
+func (ds *errorOrderDataSource) NextPickupSequence(ctx context.Context, day string) (int, error) {
+	return 0, errors.New("database error")
+}
+
+func (ds *errorOrderDataSource) Restore(ctx context.Context, id string, outbox ...daos.OutboxMessageDAO) error {
+	return errors.New("database error")
+}
+
+func (ds *errorOrderDataSource) Claim(ctx context.Context, orderID string, customerID string, guestTokenHash string, claimedAt time.Time) error {
+	return errors.New("database error")
+}
+
+func (ds *errorOrderDataSource) ReissueGuestToken(ctx context.Context, orderID string, guestTokenHash string) error {
+	return errors.New("database error")
+}
+
+func (ds *errorOrderDataSource) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	return 0, errors.New("database error")
+}
+
+func (ds *errorOrderDataSource) FindStatusHistory(ctx context.Context, orderID string) ([]daos.OrderStatusHistoryDAO, error) {
+	return nil, errors.New("database error")
+}
+
 func TestCreateOrderUseCase_Execute_DatabaseError_Integration(t *testing.T) {
 	orderDS := &errorOrderDataSource{}
 	statusDS := newTestOrderStatusDataSource()
-	broker := &testMessageBroker{}
 
 	orderGateway := gateways.NewOrderGateway(orderDS)
 	statusGateway := gateways.NewOrderStatusGateway(statusDS)
 
-	uc := NewCreateOrderUseCase(orderGateway, statusGateway, broker)
+	uc := NewCreateOrderUseCase(orderGateway, statusGateway, gateways.NewProductCatalogGateway(newTestProductCatalogDataSource()), time.UTC)
 
 	customerID := "customer-123"
 	items := []dtos.CreateOrderItemDTO{
-		{ProductID: "product-1", Quantity: 1, Price: 15.0},
+		{ProductID: "product-1", Quantity: 1, Price: "10.00"},
 	}
 
-	_, err := uc.Execute(&customerID, items)
+	_, err := uc.Execute(context.Background(), dtos.CreateOrderDTO{CustomerID: &customerID, Items: items})
 
 	if err == nil {
 		t.Error("Expected error from database")
@@ -304,7 +478,7 @@ func TestFindAllOrdersUseCase_Execute_DatabaseError_Integration(t *testing.T) {
 
 	uc := NewFindAllOrdersUseCase(orderGateway)
 
-	_, err := uc.Execute(dtos.OrderFilterDTO{})
+	_, err := uc.Execute(context.Background(), dtos.OrderFilterDTO{})
 
 	if err == nil {
 		t.Error("Expected error from database")
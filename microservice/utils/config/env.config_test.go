@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 func TestLoadConfig_Singleton(t *testing.T) {
@@ -196,6 +197,49 @@ func TestConfig_MessageBroker_RabbitMQ(t *testing.T) {
 	if config.MessageBroker.RabbitMQ.OrdersQueue != "orders.updates" {
 		t.Errorf("Expected RabbitMQ OrdersQueue 'orders.updates', got %s", config.MessageBroker.RabbitMQ.OrdersQueue)
 	}
+
+	if config.MessageBroker.RabbitMQ.PaymentsQueue != "payment.confirmation" {
+		t.Errorf("Expected default RabbitMQ PaymentsQueue 'payment.confirmation', got %s", config.MessageBroker.RabbitMQ.PaymentsQueue)
+	}
+
+	if config.MessageBroker.RabbitMQ.KitchenQueue != "kitchen-order.create" {
+		t.Errorf("Expected default RabbitMQ KitchenQueue 'kitchen-order.create', got %s", config.MessageBroker.RabbitMQ.KitchenQueue)
+	}
+}
+
+func TestConfig_MessageBroker_SQS_TerraformQueueNames(t *testing.T) {
+	setupTestEnv()
+	defer cleanupTestEnv()
+
+	os.Setenv("AWS_SQS_PAYMENTS_QUEUE", "https://sqs.us-east-2.amazonaws.com/123456789012/payments")
+	os.Setenv("AWS_SQS_KITCHEN_ORDERS_QUEUE", "https://sqs.us-east-2.amazonaws.com/123456789012/kitchen-orders")
+	defer os.Unsetenv("AWS_SQS_PAYMENTS_QUEUE")
+	defer os.Unsetenv("AWS_SQS_KITCHEN_ORDERS_QUEUE")
+	os.Setenv("AWS_SQS_ORDER_EVENTS_QUEUE", "https://sqs.us-east-2.amazonaws.com/123456789012/order-events")
+	defer os.Unsetenv("AWS_SQS_ORDER_EVENTS_QUEUE")
+	// Sem o nome antigo, vale o injetado pelo Terraform
+	os.Unsetenv("SQS_ORDERS_QUEUE_URL")
+	os.Setenv("AWS_SQS_ORDERS_QUEUE", "https://sqs.us-east-2.amazonaws.com/123456789012/orders")
+	defer os.Unsetenv("AWS_SQS_ORDERS_QUEUE")
+
+	config := &Config{}
+	config.Load()
+
+	if config.MessageBroker.SQS.OrdersQueueURL != "https://sqs.us-east-2.amazonaws.com/123456789012/orders" {
+		t.Errorf("Expected SQS OrdersQueueURL from AWS_SQS_ORDERS_QUEUE, got %s", config.MessageBroker.SQS.OrdersQueueURL)
+	}
+
+	if config.MessageBroker.SQS.PaymentsQueueURL != "https://sqs.us-east-2.amazonaws.com/123456789012/payments" {
+		t.Errorf("Expected SQS PaymentsQueueURL from AWS_SQS_PAYMENTS_QUEUE, got %s", config.MessageBroker.SQS.PaymentsQueueURL)
+	}
+
+	if config.MessageBroker.SQS.KitchenQueueURL != "https://sqs.us-east-2.amazonaws.com/123456789012/kitchen-orders" {
+		t.Errorf("Expected SQS KitchenQueueURL from AWS_SQS_KITCHEN_ORDERS_QUEUE, got %s", config.MessageBroker.SQS.KitchenQueueURL)
+	}
+
+	if config.MessageBroker.SQS.OrderEventsQueueURL != "https://sqs.us-east-2.amazonaws.com/123456789012/order-events" {
+		t.Errorf("Expected SQS OrderEventsQueueURL from AWS_SQS_ORDER_EVENTS_QUEUE, got %s", config.MessageBroker.SQS.OrderEventsQueueURL)
+	}
 }
 
 func TestConfig_MessageBroker_Defaults(t *testing.T) {
@@ -234,6 +278,172 @@ func TestConfig_MessageBroker_Defaults(t *testing.T) {
 	}
 }
 
+func TestConfig_ShutdownTimeout(t *testing.T) {
+	setupTestEnv()
+	defer cleanupTestEnv()
+
+	os.Unsetenv("SHUTDOWN_TIMEOUT")
+	config := &Config{}
+	config.Load()
+
+	if config.ShutdownTimeout != 25*time.Second {
+		t.Errorf("Expected default ShutdownTimeout 25s, got %s", config.ShutdownTimeout)
+	}
+
+	os.Setenv("SHUTDOWN_TIMEOUT", "10s")
+	defer os.Unsetenv("SHUTDOWN_TIMEOUT")
+	config = &Config{}
+	config.Load()
+
+	if config.ShutdownTimeout != 10*time.Second {
+		t.Errorf("Expected ShutdownTimeout 10s, got %s", config.ShutdownTimeout)
+	}
+}
+
+func TestConfig_RestaurantLocation(t *testing.T) {
+	setupTestEnv()
+	defer cleanupTestEnv()
+
+	os.Unsetenv("RESTAURANT_TIMEZONE")
+	config := &Config{}
+	config.Load()
+
+	if config.RestaurantLocation.String() != "America/Sao_Paulo" {
+		t.Errorf("Expected default RestaurantLocation America/Sao_Paulo, got %s", config.RestaurantLocation)
+	}
+
+	os.Setenv("RESTAURANT_TIMEZONE", "Europe/Lisbon")
+	defer os.Unsetenv("RESTAURANT_TIMEZONE")
+	config = &Config{}
+	config.Load()
+
+	if config.RestaurantLocation.String() != "Europe/Lisbon" {
+		t.Errorf("Expected RestaurantLocation Europe/Lisbon, got %s", config.RestaurantLocation)
+	}
+}
+
+func TestConfig_Outbox(t *testing.T) {
+	setupTestEnv()
+	defer cleanupTestEnv()
+
+	config := &Config{}
+	config.Load()
+
+	if config.Outbox.MaxAttempts != 10 {
+		t.Errorf("Expected default Outbox.MaxAttempts 10, got %d", config.Outbox.MaxAttempts)
+	}
+	if config.Outbox.LeaseDuration != time.Minute {
+		t.Errorf("Expected default Outbox.LeaseDuration 1m, got %s", config.Outbox.LeaseDuration)
+	}
+
+	os.Setenv("OUTBOX_MAX_ATTEMPTS", "3")
+	os.Setenv("OUTBOX_LEASE_DURATION", "30s")
+	defer func() {
+		os.Unsetenv("OUTBOX_MAX_ATTEMPTS")
+		os.Unsetenv("OUTBOX_LEASE_DURATION")
+	}()
+	config = &Config{}
+	config.Load()
+
+	if config.Outbox.MaxAttempts != 3 {
+		t.Errorf("Expected Outbox.MaxAttempts 3, got %d", config.Outbox.MaxAttempts)
+	}
+	if config.Outbox.LeaseDuration != 30*time.Second {
+		t.Errorf("Expected Outbox.LeaseDuration 30s, got %s", config.Outbox.LeaseDuration)
+	}
+}
+
+func TestConfig_Retention(t *testing.T) {
+	setupTestEnv()
+	defer cleanupTestEnv()
+
+	config := &Config{}
+	config.Load()
+
+	if config.Retention.DeletedOrders != 90*24*time.Hour {
+		t.Errorf("Expected default Retention.DeletedOrders 2160h, got %s", config.Retention.DeletedOrders)
+	}
+	if config.Retention.PurgeInterval != time.Hour {
+		t.Errorf("Expected default Retention.PurgeInterval 1h, got %s", config.Retention.PurgeInterval)
+	}
+
+	os.Setenv("DELETED_ORDERS_RETENTION", "720h")
+	os.Setenv("DELETED_ORDERS_PURGE_INTERVAL", "15m")
+	defer func() {
+		os.Unsetenv("DELETED_ORDERS_RETENTION")
+		os.Unsetenv("DELETED_ORDERS_PURGE_INTERVAL")
+	}()
+	config = &Config{}
+	config.Load()
+
+	if config.Retention.DeletedOrders != 720*time.Hour {
+		t.Errorf("Expected Retention.DeletedOrders 720h, got %s", config.Retention.DeletedOrders)
+	}
+	if config.Retention.PurgeInterval != 15*time.Minute {
+		t.Errorf("Expected Retention.PurgeInterval 15m, got %s", config.Retention.PurgeInterval)
+	}
+}
+
+func TestConfig_ProductCatalog(t *testing.T) {
+	setupTestEnv()
+	defer cleanupTestEnv()
+
+	config := &Config{}
+	config.Load()
+
+	if config.ProductCatalog.Type != "http" {
+		t.Errorf("Expected default ProductCatalog.Type 'http', got %s", config.ProductCatalog.Type)
+	}
+	if config.ProductCatalog.BaseURL != "http://localhost:8081" {
+		t.Errorf("Expected default ProductCatalog.BaseURL 'http://localhost:8081', got %s", config.ProductCatalog.BaseURL)
+	}
+	if config.ProductCatalog.Timeout != 3*time.Second {
+		t.Errorf("Expected default ProductCatalog.Timeout 3s, got %s", config.ProductCatalog.Timeout)
+	}
+
+	os.Setenv("PRODUCT_CATALOG_TYPE", "memory")
+	os.Setenv("PRODUCT_CATALOG_URL", "http://products:8080")
+	os.Setenv("PRODUCT_CATALOG_TIMEOUT", "500ms")
+	defer func() {
+		os.Unsetenv("PRODUCT_CATALOG_TYPE")
+		os.Unsetenv("PRODUCT_CATALOG_URL")
+		os.Unsetenv("PRODUCT_CATALOG_TIMEOUT")
+	}()
+	config = &Config{}
+	config.Load()
+
+	if config.ProductCatalog.Type != "memory" {
+		t.Errorf("Expected ProductCatalog.Type 'memory', got %s", config.ProductCatalog.Type)
+	}
+	if config.ProductCatalog.BaseURL != "http://products:8080" {
+		t.Errorf("Expected ProductCatalog.BaseURL 'http://products:8080', got %s", config.ProductCatalog.BaseURL)
+	}
+	if config.ProductCatalog.Timeout != 500*time.Millisecond {
+		t.Errorf("Expected ProductCatalog.Timeout 500ms, got %s", config.ProductCatalog.Timeout)
+	}
+}
+
+func TestConfig_Database_QueryTimeout(t *testing.T) {
+	setupTestEnv()
+	defer cleanupTestEnv()
+
+	config := &Config{}
+	config.Load()
+
+	if config.Database.QueryTimeout != 5*time.Second {
+		t.Errorf("Expected default Database.QueryTimeout 5s, got %s", config.Database.QueryTimeout)
+	}
+
+	os.Setenv("DB_QUERY_TIMEOUT", "750ms")
+	defer os.Unsetenv("DB_QUERY_TIMEOUT")
+	config = &Config{}
+	config.Load()
+
+	if config.Database.QueryTimeout != 750*time.Millisecond {
+		t.Errorf("Expected Database.QueryTimeout 750ms, got %s", config.Database.QueryTimeout)
+	}
+}
+
 func TestConfig_API_Configuration(t *testing.T) {
 	setupTestEnv()
 	defer cleanupTestEnv()
@@ -399,3 +609,83 @@ func cleanupTestEnv() {
 		os.Unsetenv(envVar)
 	}
 }
+
+func TestConfig_Auth(t *testing.T) {
+	setupTestEnv()
+	defer cleanupTestEnv()
+
+	config := &Config{}
+	config.Load()
+
+	if config.Auth.Mode != "cognito" {
+		t.Errorf("Expected default Auth.Mode 'cognito', got %s", config.Auth.Mode)
+	}
+	if config.Auth.JWKSCacheTTL != time.Hour {
+		t.Errorf("Expected default Auth.JWKSCacheTTL 1h, got %s", config.Auth.JWKSCacheTTL)
+	}
+	if config.Auth.StaticIssuer != "orders-local" {
+		t.Errorf("Expected default Auth.StaticIssuer 'orders-local', got %s", config.Auth.StaticIssuer)
+	}
+
+	os.Setenv("AWS_REGION", "sa-east-1")
+	os.Setenv("AWS_COGNITO_USER_POOL_ID", "sa-east-1_abc")
+	os.Setenv("AWS_COGNITO_USER_POOL_CLIENT_ID", "web-client, totem-client")
+	os.Setenv("AUTH_SERVICE_CLIENT_IDS", "kitchen-service,")
+	os.Setenv("AUTH_JWKS_CACHE_TTL", "30m")
+	defer func() {
+		os.Unsetenv("AWS_REGION")
+		os.Unsetenv("AWS_COGNITO_USER_POOL_ID")
+		os.Unsetenv("AWS_COGNITO_USER_POOL_CLIENT_ID")
+		os.Unsetenv("AUTH_SERVICE_CLIENT_IDS")
+		os.Unsetenv("AUTH_JWKS_CACHE_TTL")
+	}()
+	config = &Config{}
+	config.Load()
+
+	if config.Auth.Region != "sa-east-1" {
+		t.Errorf("Expected Auth.Region 'sa-east-1', got %s", config.Auth.Region)
+	}
+	if config.Auth.UserPoolID != "sa-east-1_abc" {
+		t.Errorf("Expected Auth.UserPoolID 'sa-east-1_abc', got %s", config.Auth.UserPoolID)
+	}
+	if len(config.Auth.ClientIDs) != 2 || config.Auth.ClientIDs[0] != "web-client" || config.Auth.ClientIDs[1] != "totem-client" {
+		t.Errorf("Expected Auth.ClientIDs [web-client totem-client], got %v", config.Auth.ClientIDs)
+	}
+	if len(config.Auth.ServiceClientIDs) != 1 || config.Auth.ServiceClientIDs[0] != "kitchen-service" {
+		t.Errorf("Expected Auth.ServiceClientIDs [kitchen-service], got %v", config.Auth.ServiceClientIDs)
+	}
+	if config.Auth.JWKSCacheTTL != 30*time.Minute {
+		t.Errorf("Expected Auth.JWKSCacheTTL 30m, got %s", config.Auth.JWKSCacheTTL)
+	}
+}
+
+func TestConfig_Idempotency(t *testing.T) {
+	setupTestEnv()
+	defer cleanupTestEnv()
+
+	config := &Config{}
+	config.Load()
+
+	if config.Idempotency.KeyTTL != 24*time.Hour {
+		t.Errorf("Expected default Idempotency.KeyTTL 24h, got %s", config.Idempotency.KeyTTL)
+	}
+	if config.Idempotency.LockTimeout != time.Minute {
+		t.Errorf("Expected default Idempotency.LockTimeout 1m, got %s", config.Idempotency.LockTimeout)
+	}
+
+	os.Setenv("IDEMPOTENCY_KEY_TTL", "2h")
+	os.Setenv("IDEMPOTENCY_LOCK_TIMEOUT", "30s")
+	defer func() {
+		os.Unsetenv("IDEMPOTENCY_KEY_TTL")
+		os.Unsetenv("IDEMPOTENCY_LOCK_TIMEOUT")
+	}()
+	config = &Config{}
+	config.Load()
+
+	if config.Idempotency.KeyTTL != 2*time.Hour {
+		t.Errorf("Expected Idempotency.KeyTTL 2h, got %s", config.Idempotency.KeyTTL)
+	}
+	if config.Idempotency.LockTimeout != 30*time.Second {
+		t.Errorf("Expected Idempotency.LockTimeout 30s, got %s", config.Idempotency.LockTimeout)
+	}
+}
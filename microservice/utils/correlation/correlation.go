@@ -0,0 +1,64 @@
+package correlation
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+
+	"microservice/utils/identity"
+)
+
+const (
+	// Cabeçalho HTTP que identifica a requisição entre os serviços
+	REQUEST_ID_HEADER = "X-Request-ID"
+	// Cabeçalho/atributo das mensagens publicadas e consumidas
+	REQUEST_ID_ATTRIBUTE = "request_id"
+)
+
+// IDs recebidos de fora vão para os logs, então só aceitamos um formato seguro
+var validID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+type contextKey struct{}
+
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// "" quando não há
+func ID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+func IsValidID(id string) bool {
+	return validID.MatchString(id)
+}
+
+// Aceita o ID recebido quando bem formado; senão gera um novo
+func Resolve(received string) string {
+	if IsValidID(received) {
+		return received
+	}
+	return identity.NewUUIDV4()
+}
+
+func Ensure(ctx context.Context, received string) (context.Context, string) {
+	id := Resolve(received)
+	return WithID(ctx, id), id
+}
+
+func LogPrefix(id string) string {
+	return fmt.Sprintf("[%s=%s] ", REQUEST_ID_ATTRIBUTE, id)
+}
+
+// Como log.Printf, com o prefixo do request ID quando houver
+func Logf(ctx context.Context, format string, args ...any) {
+	if id := ID(ctx); id != "" {
+		format = LogPrefix(id) + format
+	}
+	log.Printf(format, args...)
+}
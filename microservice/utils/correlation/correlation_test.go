@@ -0,0 +1,72 @@
+package correlation
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"microservice/utils/identity"
+)
+
+func TestWithID(t *testing.T) {
+	ctx := WithID(context.Background(), "req-1")
+
+	if got := ID(ctx); got != "req-1" {
+		t.Errorf("ID() = %q, want req-1", got)
+	}
+	if got := ID(context.Background()); got != "" {
+		t.Errorf("ID() without request ID = %q, want empty", got)
+	}
+}
+
+func TestEnsure(t *testing.T) {
+	tests := []struct {
+		name     string
+		received string
+		wantKept bool
+	}{
+		{"keeps a well formed ID", "totem-42.a1:b2", true},
+		{"generates when missing", "", false},
+		{"generates when unsafe to log", "abc\nforged line", false},
+		{"generates when too long", strings.Repeat("a", 129), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, id := Ensure(context.Background(), tt.received)
+
+			if ID(ctx) != id {
+				t.Errorf("Ensure() context ID = %q, want %q", ID(ctx), id)
+			}
+			if tt.wantKept && id != tt.received {
+				t.Errorf("Ensure() = %q, want %q", id, tt.received)
+			}
+			if !tt.wantKept && !identity.IsValidUUID(id) {
+				t.Errorf("Ensure() = %q, want a new UUID", id)
+			}
+		})
+	}
+}
+
+func TestLogf(t *testing.T) {
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+
+	Logf(WithID(context.Background(), "req-7"), "Order %s updated", "order-1")
+	Logf(context.Background(), "Order %s updated", "order-2")
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Logf() wrote %d lines, want 2", len(lines))
+	}
+	if !strings.HasSuffix(lines[0], "[request_id=req-7] Order order-1 updated") {
+		t.Errorf("Logf() = %q, want the request ID prefix", lines[0])
+	}
+	if strings.Contains(lines[1], "request_id") {
+		t.Errorf("Logf() = %q, want no prefix without request ID", lines[1])
+	}
+}
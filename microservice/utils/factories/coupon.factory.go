@@ -0,0 +1,24 @@
+package factories
+
+import (
+	"microservice/infra/db/postgres/data_source"
+	"microservice/internal/interfaces"
+)
+
+var newCouponDataSource func() interfaces.ICouponDataSource = func() interfaces.ICouponDataSource {
+	return data_source.NewGormCouponDataSource()
+}
+
+func NewCouponDataSource() interfaces.ICouponDataSource {
+	return newCouponDataSource()
+}
+
+func SetNewCouponDataSource(fn func() interfaces.ICouponDataSource) {
+	if fn == nil {
+		newCouponDataSource = func() interfaces.ICouponDataSource {
+			return data_source.NewGormCouponDataSource()
+		}
+		return
+	}
+	newCouponDataSource = fn
+}
@@ -0,0 +1,20 @@
+package factories
+
+import (
+	"testing"
+
+	"microservice/infra/db/postgres/data_source"
+	"microservice/internal/interfaces"
+)
+
+func TestSetNewCouponDataSource(t *testing.T) {
+	stub := &data_source.GormCouponDataSource{}
+	SetNewCouponDataSource(func() interfaces.ICouponDataSource {
+		return stub
+	})
+	defer SetNewCouponDataSource(nil)
+
+	if NewCouponDataSource() != stub {
+		t.Error("Expected NewCouponDataSource to return the configured data source")
+	}
+}
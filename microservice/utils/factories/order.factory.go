@@ -15,6 +15,10 @@ var newOrderStatusDataSource func() interfaces.IOrderStatusDataSource = func() i
 	return data_source.NewGormOrderStatusDataSource()
 }
 
+var newIdempotencyDataSource func() interfaces.IIdempotencyDataSource = func() interfaces.IIdempotencyDataSource {
+	return data_source.NewGormIdempotencyDataSource()
+}
+
 func NewOrderDataSource() interfaces.IOrderDataSource {
 	return newOrderDataSource()
 }
@@ -23,6 +27,10 @@ func NewOrderStatusDataSource() interfaces.IOrderStatusDataSource {
 	return newOrderStatusDataSource()
 }
 
+func NewIdempotencyDataSource() interfaces.IIdempotencyDataSource {
+	return newIdempotencyDataSource()
+}
+
 func NewUpdateOrderStatusUseCase() *use_cases.UpdateOrderStatusUseCase {
 	orderDataSource := NewOrderDataSource()
 	orderStatusDataSource := NewOrderStatusDataSource()
@@ -50,3 +58,13 @@ func SetNewOrderStatusDataSource(fn func() interfaces.IOrderStatusDataSource) {
 	}
 	newOrderStatusDataSource = fn
 }
+
+func SetNewIdempotencyDataSource(fn func() interfaces.IIdempotencyDataSource) {
+	if fn == nil {
+		newIdempotencyDataSource = func() interfaces.IIdempotencyDataSource {
+			return data_source.NewGormIdempotencyDataSource()
+		}
+		return
+	}
+	newIdempotencyDataSource = fn
+}
@@ -0,0 +1,24 @@
+package factories
+
+import (
+	"microservice/infra/catalog"
+	"microservice/internal/interfaces"
+)
+
+var newProductCatalogDataSource func() interfaces.IProductCatalogDataSource = func() interfaces.IProductCatalogDataSource {
+	return catalog.GetDataSource()
+}
+
+func NewProductCatalogDataSource() interfaces.IProductCatalogDataSource {
+	return newProductCatalogDataSource()
+}
+
+func SetNewProductCatalogDataSource(fn func() interfaces.IProductCatalogDataSource) {
+	if fn == nil {
+		newProductCatalogDataSource = func() interfaces.IProductCatalogDataSource {
+			return catalog.GetDataSource()
+		}
+		return
+	}
+	newProductCatalogDataSource = fn
+}
@@ -0,0 +1,20 @@
+package factories
+
+import (
+	"testing"
+
+	"microservice/infra/catalog"
+	"microservice/internal/interfaces"
+)
+
+func TestSetNewProductCatalogDataSource(t *testing.T) {
+	stub := catalog.NewInMemoryProductCatalogDataSource()
+	SetNewProductCatalogDataSource(func() interfaces.IProductCatalogDataSource {
+		return stub
+	})
+	defer SetNewProductCatalogDataSource(nil)
+
+	if NewProductCatalogDataSource() != stub {
+		t.Error("Expected NewProductCatalogDataSource to return the configured data source")
+	}
+}